@@ -107,6 +107,63 @@ func (a *Array) Decode(target interface{}) *Array {
 	return a
 }
 
+// ToSlice returns array elements converted to Go type T.
+//
+// Every element is converted in the same way as by ValueOf. If some
+// elements can't be converted, a single failure is reported, listing
+// index, expected Go type, and actual JSON type of every such element,
+// and nil is returned.
+//
+// ToSlice is a bridge from fluent assertions to ordinary Go logic.
+//
+// Example:
+//
+//	array := NewArray(t, []interface{}{1, 2, 3})
+//
+//	ids := ToSlice[int](array)
+//	sort.Ints(ids)
+func ToSlice[T any](a *Array) []T {
+	var zero T
+
+	targetType := reflect.TypeOf(&zero).Elem()
+
+	opChain := a.chain.enter("ToSlice[%s]()", targetType)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return nil
+	}
+
+	result := make([]T, 0, len(a.value))
+
+	var errs []error
+
+	for index, element := range a.value {
+		converted, ok := convertValue[T](element)
+		if !ok {
+			errs = append(errs, fmt.Errorf(
+				"element at index %d: actual value is JSON %s",
+				index, jsonTypeName(element)))
+			continue
+		}
+		result = append(result, converted)
+	}
+
+	if len(errs) != 0 {
+		opChain.fail(AssertionFailure{
+			Type:   AssertType,
+			Actual: &AssertionValue{a.value},
+			Errors: append([]error{
+				fmt.Errorf("expected: every element can be converted to %s",
+					targetType),
+			}, errs...),
+		})
+		return nil
+	}
+
+	return result
+}
+
 // Alias is similar to Value.Alias.
 func (a *Array) Alias(name string) *Array {
 	opChain := a.chain.enter("Alias(%q)", name)
@@ -133,6 +190,38 @@ func (a *Array) Schema(schema interface{}) *Array {
 	return a
 }
 
+// EverySchema succeeds if every array element matches given JSON Schema.
+//
+// Schema is interpreted in the same way as by Value.Schema. Unlike Schema,
+// which validates the whole array at once, EverySchema validates every
+// element separately, and on failure reports indices of mismatching
+// elements together with validation errors for each of them.
+//
+// Empty array always succeeds.
+//
+// Example:
+//
+//	schema := `{
+//		"type": "object",
+//		"properties": {
+//			"id": {"type": "integer"}
+//		},
+//		"required": ["id"]
+//	}`
+//
+//	array := NewArray(t, []interface{}{
+//		map[string]interface{}{"id": 1},
+//		map[string]interface{}{"id": 2},
+//	})
+//	array.EverySchema(schema)
+func (a *Array) EverySchema(schema interface{}) *Array {
+	opChain := a.chain.enter("EverySchema()")
+	defer opChain.leave()
+
+	jsonEverySchema(opChain, a.value, schema)
+	return a
+}
+
 // Length returns a new Number instance with array length.
 //
 // Example:
@@ -514,6 +603,50 @@ func (a *Array) Transform(fn func(index int, value interface{}) interface{}) *Ar
 	return newArray(opChain, transformedArray)
 }
 
+// Sample returns a new array with n elements of the original array, chosen
+// randomly using given seed. Elements keep their original order.
+//
+// Sample allows to check a property over a subset of a huge array, keeping
+// test runtime bounded. The same seed always selects the same elements, so
+// a failure can be reproduced. If n is greater than or equal to array length,
+// the whole array is returned.
+//
+// Note that indices passed to Every and similar methods of the returned
+// array are indices in the sample, not in the original array.
+//
+// Example:
+//
+//	array := NewArray(t, items)
+//	array.Sample(100, 42).Every(func(index int, value *httpexpect.Value) {
+//		value.Object().ContainsKey("id")
+//	})
+func (a *Array) Sample(n int, seed int64) *Array {
+	opChain := a.chain.enter("Sample(%d, %d)", n, seed)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	if n < 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected negative sample size %d", n),
+			},
+		})
+		return newArray(opChain, nil)
+	}
+
+	sampledArray := []interface{}{}
+
+	for _, index := range sampleIndices(len(a.value), n, seed) {
+		sampledArray = append(sampledArray, a.value[index])
+	}
+
+	return newArray(opChain, sampledArray)
+}
+
 // Find accepts a function that returns a boolean, runs it over the array
 // elements, and returns the first element on which it returned true.
 //
@@ -765,6 +898,8 @@ func (a *Array) Empty() *Array {
 //
 // value should be a slice of any type.
 //
+// Optional CompareOptions can be given to customize comparison.
+//
 // Example:
 //
 //	array := NewArray(t, []interface{}{"foo", 123})
@@ -775,7 +910,7 @@ func (a *Array) Empty() *Array {
 //
 //	array := NewArray(t, []interface{}{123, 456})
 //	array.IsEqual([]int{}{123, 456})
-func (a *Array) IsEqual(value interface{}) *Array {
+func (a *Array) IsEqual(value interface{}, opts ...CompareOptions) *Array {
 	opChain := a.chain.enter("IsEqual()")
 	defer opChain.leave()
 
@@ -788,7 +923,12 @@ func (a *Array) IsEqual(value interface{}) *Array {
 		return a
 	}
 
-	if !reflect.DeepEqual(expected, a.value) {
+	compareOpts, ok := getCompareOptions(opChain, opts)
+	if !ok {
+		return a
+	}
+
+	if !compareValues(compareOpts, a.value, expected) {
 		opChain.fail(AssertionFailure{
 			Type:     AssertEqual,
 			Actual:   &AssertionValue{a.value},
@@ -807,11 +947,13 @@ func (a *Array) IsEqual(value interface{}) *Array {
 //
 // value should be a slice of any type.
 //
+// Optional CompareOptions can be given to customize comparison.
+//
 // Example:
 //
 //	array := NewArray(t, []interface{}{"foo", 123})
 //	array.NotEqual([]interface{}{123, "foo"})
-func (a *Array) NotEqual(value interface{}) *Array {
+func (a *Array) NotEqual(value interface{}, opts ...CompareOptions) *Array {
 	opChain := a.chain.enter("NotEqual()")
 	defer opChain.leave()
 
@@ -824,7 +966,12 @@ func (a *Array) NotEqual(value interface{}) *Array {
 		return a
 	}
 
-	if reflect.DeepEqual(expected, a.value) {
+	compareOpts, ok := getCompareOptions(opChain, opts)
+	if !ok {
+		return a
+	}
+
+	if compareValues(compareOpts, a.value, expected) {
 		opChain.fail(AssertionFailure{
 			Type:     AssertNotEqual,
 			Actual:   &AssertionValue{a.value},
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestArray_FailedChain(t *testing.T) {
@@ -13,11 +14,14 @@ func TestArray_FailedChain(t *testing.T) {
 
 		value.Path("$").chain.assert(t, failure)
 		value.Schema("")
+		value.EverySchema("")
 		value.Alias("foo")
 
 		var target interface{}
 		value.Decode(&target)
 
+		assert.Nil(t, ToSlice[int](value))
+
 		value.Length().chain.assert(t, failure)
 		value.Value(0).chain.assert(t, failure)
 		value.First().chain.assert(t, failure)
@@ -57,6 +61,7 @@ func TestArray_FailedChain(t *testing.T) {
 		value.Transform(func(index int, value interface{}) interface{} {
 			return nil
 		})
+		value.Sample(1, 0).chain.assert(t, failure)
 		value.Find(func(index int, value *Value) bool {
 			value.String().NotEmpty()
 			return true
@@ -221,6 +226,57 @@ func TestArray_Decode(t *testing.T) {
 	})
 }
 
+func TestArray_ToSlice(t *testing.T) {
+	type S struct {
+		A int `json:"a"`
+	}
+
+	t.Run("success", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		assert.Equal(t, []int{1, 2, 3},
+			ToSlice[int](NewArray(reporter, []interface{}{1, 2, 3})))
+
+		assert.Equal(t, []string{"a", "b"},
+			ToSlice[string](NewArray(reporter, []interface{}{"a", "b"})))
+
+		assert.Equal(t, []S{{A: 1}, {A: 2}},
+			ToSlice[S](NewArray(reporter, []interface{}{
+				map[string]interface{}{"a": 1},
+				map[string]interface{}{"a": 2},
+			})))
+
+		assert.Equal(t, []*int{nil},
+			ToSlice[*int](NewArray(reporter, []interface{}{nil})))
+
+		assert.Equal(t, []int{},
+			ToSlice[int](NewArray(reporter, []interface{}{})))
+
+		assert.False(t, reporter.reported)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		array := NewArrayC(Config{AssertionHandler: handler},
+			[]interface{}{1, "two", 3, 4.5})
+
+		assert.Nil(t, ToSlice[int](array))
+		array.chain.assert(t, failure)
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertType, handler.failure.Type)
+		assert.Equal(t, []string{"Array()", "ToSlice[int]()"}, handler.ctx.Path)
+		require.Equal(t, 3, len(handler.failure.Errors))
+		assert.Equal(t, "expected: every element can be converted to int",
+			handler.failure.Errors[0].Error())
+		assert.Equal(t, "element at index 1: actual value is JSON string",
+			handler.failure.Errors[1].Error())
+		assert.Equal(t, "element at index 3: actual value is JSON number",
+			handler.failure.Errors[2].Error())
+	})
+}
+
 func TestArray_Alias(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -294,6 +350,104 @@ func TestArray_Schema(t *testing.T) {
 	}
 }
 
+func TestArray_EverySchema(t *testing.T) {
+	schema := `{
+		"type": "object",
+		"properties": {
+			"id": {"type": "integer"}
+		},
+		"required": ["id"]
+	}`
+
+	t.Run("all match", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		NewArray(reporter, []interface{}{
+			map[string]interface{}{"id": 1},
+			map[string]interface{}{"id": 2},
+		}).EverySchema(schema).
+			chain.assert(t, success)
+	})
+
+	t.Run("empty array", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		NewArray(reporter, []interface{}{}).EverySchema(schema).
+			chain.assert(t, success)
+	})
+
+	t.Run("go schema", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		NewArray(reporter, []interface{}{"foo", "bar"}).
+			EverySchema(map[string]interface{}{"type": "string"}).
+			chain.assert(t, success)
+
+		NewArray(reporter, []interface{}{"foo", 123}).
+			EverySchema(map[string]interface{}{"type": "string"}).
+			chain.assert(t, failure)
+	})
+
+	t.Run("one element fails", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		array := NewArrayC(Config{AssertionHandler: handler}, []interface{}{
+			map[string]interface{}{"id": 1},
+			map[string]interface{}{"id": "two"},
+			map[string]interface{}{"id": 3},
+		})
+
+		array.EverySchema(schema)
+		array.chain.assert(t, failure)
+
+		assert.Equal(t, 1, handler.failureCalled)
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertMatchSchema, handler.failure.Type)
+		require.Equal(t, 3, len(handler.failure.Errors))
+		assert.Equal(t, "elements at indices [1] do not match",
+			handler.failure.Errors[1].Error())
+		assert.Contains(t, handler.failure.Errors[2].Error(),
+			"element at index 1: /id:")
+
+		schemaErrs := handler.failure.SchemaErrors()
+		require.Equal(t, 1, len(schemaErrs))
+		assert.Equal(t, "/id", schemaErrs[0].InstancePath)
+	})
+
+	t.Run("several elements fail", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		array := NewArrayC(Config{AssertionHandler: handler}, []interface{}{
+			map[string]interface{}{},
+			map[string]interface{}{"id": 2},
+			map[string]interface{}{"id": "three"},
+		})
+
+		array.EverySchema(schema)
+		array.chain.assert(t, failure)
+
+		assert.Equal(t, 1, handler.failureCalled)
+		require.NotNil(t, handler.failure)
+		require.Equal(t, 4, len(handler.failure.Errors))
+		assert.Equal(t, "elements at indices [0 2] do not match",
+			handler.failure.Errors[1].Error())
+		assert.Contains(t, handler.failure.Errors[2].Error(), "element at index 0:")
+		assert.Contains(t, handler.failure.Errors[3].Error(), "element at index 2:")
+	})
+
+	t.Run("invalid schema", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		array := NewArrayC(Config{AssertionHandler: handler}, []interface{}{1, 2})
+
+		array.EverySchema(`{"type": "bad"}`)
+		array.chain.assert(t, failure)
+
+		assert.Equal(t, 1, handler.failureCalled)
+		assert.Equal(t, AssertValid, handler.failure.Type)
+	})
+}
+
 func TestArray_Getters(t *testing.T) {
 	t.Run("empty", func(t *testing.T) {
 		reporter := newMockReporter(t)
@@ -1850,6 +2004,87 @@ func TestArray_Filter(t *testing.T) {
 	})
 }
 
+func TestArray_Sample(t *testing.T) {
+	elements := make([]interface{}, 1000)
+	for i := range elements {
+		elements[i] = float64(i)
+	}
+
+	t.Run("subset", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		array := NewArray(reporter, elements)
+
+		sample := array.Sample(10, 42)
+		sample.Length().IsEqual(10)
+		sample.IsOrdered()
+		sample.Every(func(_ int, value *Value) {
+			value.Number().InRange(0, 999)
+		})
+
+		assert.Equal(t, sample.Raw(), array.Sample(10, 42).Raw())
+		assert.NotEqual(t, sample.Raw(), array.Sample(10, 43).Raw())
+		assert.Equal(t, 1000, len(array.Raw()))
+
+		array.chain.assert(t, success)
+		sample.chain.assert(t, success)
+	})
+
+	t.Run("unique elements", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		array := NewArray(reporter, elements)
+
+		sample := array.Sample(500, 1)
+		assert.Equal(t, 500, len(sample.Raw()))
+
+		seen := map[interface{}]bool{}
+		for _, v := range sample.Raw() {
+			assert.False(t, seen[v])
+			seen[v] = true
+		}
+	})
+
+	t.Run("whole array", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		array := NewArray(reporter, []interface{}{"foo", "bar"})
+
+		assert.Equal(t, []interface{}{"foo", "bar"}, array.Sample(2, 42).Raw())
+		assert.Equal(t, []interface{}{"foo", "bar"}, array.Sample(5, 42).Raw())
+		assert.Equal(t, []interface{}{}, array.Sample(0, 42).Raw())
+
+		array.chain.assert(t, success)
+	})
+
+	t.Run("empty array", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		array := NewArray(reporter, []interface{}{})
+
+		sample := array.Sample(10, 42)
+		assert.Equal(t, []interface{}{}, sample.Raw())
+		sample.chain.assert(t, success)
+	})
+
+	t.Run("failed element", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		array := NewArray(reporter, elements)
+
+		sample := array.Sample(10, 42)
+		sample.Every(func(_ int, value *Value) {
+			value.Number().Lt(0)
+		})
+
+		sample.chain.assert(t, failure)
+	})
+
+	t.Run("invalid argument", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		array := NewArray(reporter, elements)
+
+		sample := array.Sample(-1, 42)
+		array.chain.assert(t, failure)
+		sample.chain.assert(t, failure)
+	})
+}
+
 func TestArray_Find(t *testing.T) {
 	t.Run("elements of same type", func(t *testing.T) {
 		reporter := newMockReporter(t)
@@ -0,0 +1,114 @@
+package httpexpect
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ArtifactPath returns path of the file with given name inside artifacts
+// directory of the current test, and creates the directory if needed.
+//
+// Artifacts directory of the test is a subdirectory of Config.ArtifactsDir
+// named after Config.TestName. Name is sanitized to be a valid file name.
+//
+// Reports failure and returns empty string if Config.ArtifactsDir is not
+// set, or if directory can't be created.
+//
+// Example:
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		TestName:     t.Name(),
+//		ArtifactsDir: "test-artifacts",
+//		Reporter:     httpexpect.NewAssertReporter(t),
+//	})
+//
+//	body := e.GET("/export").Expect().Body().Raw()
+//
+//	path := e.ArtifactPath("export.csv")
+//	_ = os.WriteFile(path, []byte(body), 0o644)
+func (e *Expect) ArtifactPath(name string) string {
+	opChain := e.chain.enter("ArtifactPath(%q)", name)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return ""
+	}
+
+	dir := artifactsDir(e.config)
+
+	if dir == "" {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("Config.ArtifactsDir is not set"),
+			},
+		})
+		return ""
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("failed to create artifacts directory"),
+				err,
+			},
+		})
+		return ""
+	}
+
+	return filepath.Join(dir, attachmentFileName(name))
+}
+
+// artifactsDir returns artifacts directory of the test, which is a
+// subdirectory of Config.ArtifactsDir named after Config.TestName.
+// Returns empty string if Config.ArtifactsDir is not set.
+func artifactsDir(config Config) string {
+	if config.ArtifactsDir == "" {
+		return ""
+	}
+
+	if config.TestName == "" {
+		return config.ArtifactsDir
+	}
+
+	return filepath.Join(config.ArtifactsDir, attachmentFileName(config.TestName))
+}
+
+var (
+	artifactMu    sync.Mutex
+	artifactPaths = map[string]int{}
+)
+
+// writeArtifact writes file with given name into dir, creating dir if needed,
+// and returns path of the written file.
+//
+// The first file with given name written by this process replaces file from
+// previous runs; subsequent files get numeric suffix, e.g. "dump-2.txt".
+func writeArtifact(dir, name string, content []byte) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, attachmentFileName(name))
+
+	artifactMu.Lock()
+	artifactPaths[path]++
+	n := artifactPaths[path]
+	artifactMu.Unlock()
+
+	if n > 1 {
+		ext := filepath.Ext(path)
+		path = strings.TrimSuffix(path, ext) + "-" + strconv.Itoa(n) + ext
+	}
+
+	if err := os.WriteFile(path, content, 0o644); err != nil { //nolint:gosec
+		return "", err
+	}
+
+	return path, nil
+}
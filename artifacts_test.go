@@ -0,0 +1,187 @@
+package httpexpect
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArtifacts_Dir(t *testing.T) {
+	cases := []struct {
+		name     string
+		config   Config
+		expected string
+	}{
+		{
+			name:     "not set",
+			config:   Config{TestName: "TestFoo"},
+			expected: "",
+		},
+		{
+			name:     "no test name",
+			config:   Config{ArtifactsDir: "out"},
+			expected: "out",
+		},
+		{
+			name:     "test name",
+			config:   Config{ArtifactsDir: "out", TestName: "TestFoo"},
+			expected: filepath.Join("out", "TestFoo"),
+		},
+		{
+			name:     "subtest name",
+			config:   Config{ArtifactsDir: "out", TestName: "TestFoo/bar baz"},
+			expected: filepath.Join("out", "TestFoo_bar_baz"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, artifactsDir(tc.config))
+		})
+	}
+}
+
+func TestArtifacts_Write(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "TestWrite")
+
+	path1, err := writeArtifact(dir, "dump.txt", []byte("one"))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "dump.txt"), path1)
+
+	path2, err := writeArtifact(dir, "dump.txt", []byte("two"))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "dump-2.txt"), path2)
+
+	content, err := os.ReadFile(path1)
+	require.NoError(t, err)
+	assert.Equal(t, "one", string(content))
+
+	content, err = os.ReadFile(path2)
+	require.NoError(t, err)
+	assert.Equal(t, "two", string(content))
+}
+
+func TestArtifacts_Path(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		root := t.TempDir()
+
+		e := WithConfig(Config{
+			TestName:     "TestFoo/bar",
+			ArtifactsDir: root,
+			Reporter:     newMockReporter(t),
+		})
+
+		path := e.ArtifactPath("../body.json")
+
+		e.chain.assert(t, success)
+		assert.Equal(t, filepath.Join(root, "TestFoo_bar", "body.json"), path)
+
+		info, err := os.Stat(filepath.Dir(path))
+		require.NoError(t, err)
+		assert.True(t, info.IsDir())
+	})
+
+	t.Run("not set", func(t *testing.T) {
+		e := WithConfig(Config{
+			TestName: "TestFoo",
+			Reporter: newMockReporter(t),
+		})
+
+		path := e.ArtifactPath("body.json")
+
+		e.chain.assert(t, failure)
+		assert.Equal(t, "", path)
+	})
+
+	t.Run("can't create", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "file")
+		require.NoError(t, os.WriteFile(file, nil, 0o644))
+
+		e := WithConfig(Config{
+			TestName:     "TestFoo",
+			ArtifactsDir: file,
+			Reporter:     newMockReporter(t),
+		})
+
+		path := e.ArtifactPath("body.json")
+
+		e.chain.assert(t, failure)
+		assert.Equal(t, "", path)
+	})
+}
+
+func TestArtifacts_Handlers(t *testing.T) {
+	client := ClientFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       io.NopCloser(bytes.NewBufferString("not found")),
+			Request:    req,
+		}, nil
+	})
+
+	t.Run("shared directory", func(t *testing.T) {
+		root := t.TempDir()
+
+		e := WithConfig(Config{
+			TestName:     "TestUser/get",
+			ArtifactsDir: root,
+			Client:       client,
+			AssertionHandler: &HTMLReportHandler{
+				Handler: &AttachmentHandler{
+					Handler: &mockAssertionHandler{},
+				},
+			},
+		})
+
+		e.GET("/user").Expect().Status(http.StatusOK)
+		e.GET("/user").Expect().Status(http.StatusCreated)
+
+		dir := filepath.Join(root, "TestUser_get")
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+
+		var names []string
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+
+		assert.ElementsMatch(t,
+			[]string{"http-dump.txt", "http-dump-2.txt", "report.html"}, names)
+
+		report, err := os.ReadFile(filepath.Join(dir, "report.html"))
+		require.NoError(t, err)
+		assert.Contains(t, string(report), "GET /user HTTP/1.1")
+
+		dump, err := os.ReadFile(filepath.Join(dir, "http-dump.txt"))
+		require.NoError(t, err)
+		assert.Contains(t, string(dump), "not found")
+
+		_, err = os.Stat(filepath.Join(root, "index.html"))
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("not set", func(t *testing.T) {
+		logger := newMockLogger(t)
+
+		e := WithConfig(Config{
+			TestName: "TestUser/get",
+			Client:   client,
+			AssertionHandler: &HTMLReportHandler{
+				Handler:     &mockAssertionHandler{},
+				ErrorLogger: logger,
+			},
+		})
+
+		e.GET("/user").Expect().Status(http.StatusOK)
+
+		assert.True(t, logger.logged)
+		assert.Contains(t, logger.lastMessage, "Config.ArtifactsDir")
+	})
+}
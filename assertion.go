@@ -1,5 +1,7 @@
 package httpexpect
 
+import "net/http"
+
 // AssertionType defines type of performed assertion.
 type AssertionType uint
 
@@ -100,6 +102,13 @@ const (
 	// This severity is used for assertions issued inside predicate functions,
 	// e.g. in Array.Filter and Object.Filter.
 	SeverityLog
+
+	// This assertion failure should be reported as a warning, but should not
+	// cause test failure.
+	// Typically handler will print it using a separate logger.
+	// This severity is used for assertions made via Expect.Warn, Request.Warn,
+	// and Response.Warn, e.g. when rolling out new checks gradually.
+	SeverityWarning
 )
 
 // AssertionContext provides context where the assetion happened.
@@ -108,8 +117,13 @@ type AssertionContext struct {
 	// Usually comes from testing.T
 	TestName string
 
+	// Directory for debugging output of the running test
+	// Comes from Config.ArtifactsDir and Config.TestName, may be empty
+	ArtifactsDir string
+
 	// Name of request being sent
-	// Comes from Request.WithName()
+	// Comes from Request.WithName(), prefixed with names of enclosing
+	// scenario and step, if any, e.g. "user lifecycle / create / create user"
 	RequestName string
 
 	// Chain of nested assertion names
@@ -138,6 +152,26 @@ type AssertionContext struct {
 	// Whether reporter is known to output to testing.TB
 	// For example, true when reporter is testing.T or testify-based reporter.
 	TestingTB bool
+
+	// Redactor for sensitive data in failure messages
+	// Comes from Config.Redactor, may be nil
+	Redactor *Redactor
+
+	// Source of random values used by randomized helpers
+	// Comes from Config.RandSource, may be nil
+	RandSource *RandSource
+}
+
+// contextHTTPRequest returns http.Request associated with assertion context,
+// taken either from Request or from Response, or nil if there is none.
+func contextHTTPRequest(ctx *AssertionContext) *http.Request {
+	if ctx.Request != nil && ctx.Request.httpReq != nil {
+		return ctx.Request.httpReq
+	}
+	if ctx.Response != nil && ctx.Response.httpResp != nil {
+		return ctx.Response.httpResp.Request
+	}
+	return nil
 }
 
 // AssertionFailure provides detailed information about failed assertion.
@@ -195,6 +229,10 @@ type AssertionFailure struct {
 
 	// Stacktrace of the failure
 	Stacktrace []StacktraceEntry
+
+	// Name of header which value is checked, if any
+	// Used by Redactor to hide values of sensitive headers
+	header string
 }
 
 // AssertionValue holds expected or actual value
@@ -216,8 +254,45 @@ type AssertionList []interface{}
 // You can log every performed assertion, or report only failures. You can implement
 // custom formatting, for example, provide a JSON output for ulterior processing.
 //
+// Handler receives structured data rather than formatted strings: AssertionContext
+// describes where the assertion was made (test and request names, assertion path,
+// request and response), and AssertionFailure describes what went wrong (assertion
+// type, severity, actual and expected values, stacktrace). This makes it possible
+// to build custom reporting, metrics, or flaky test detection on top of it.
+//
+// Handler is invoked synchronously from the goroutine that made the assertion.
+// If assertions are made from multiple goroutines, handler should be safe for
+// concurrent use. Handler should not modify context and failure, and should
+// not retain them after returning.
+//
 // Usually you don't need to implement AssertionHandler; instead you can implement
 // Reporter, which is much simpler, and use it with DefaultAssertionHandler.
+// To add custom handling while keeping default reporting, combine handlers
+// using MultiAssertionHandler.
+//
+// Example:
+//
+//	type metricsHandler struct {
+//		failures map[AssertionType]int
+//	}
+//
+//	func (h *metricsHandler) Success(ctx *AssertionContext) {}
+//
+//	func (h *metricsHandler) Failure(
+//		ctx *AssertionContext, failure *AssertionFailure,
+//	) {
+//		h.failures[failure.Type]++
+//	}
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		AssertionHandler: httpexpect.MultiAssertionHandler{
+//			&httpexpect.DefaultAssertionHandler{
+//				Formatter: &httpexpect.DefaultFormatter{},
+//				Reporter:  httpexpect.NewAssertReporter(t),
+//			},
+//			&metricsHandler{failures: map[AssertionType]int{}},
+//		},
+//	})
 type AssertionHandler interface {
 	// Invoked every time when an assertion succeeded.
 	// May ignore failure, or log it, e.g. using t.Logf().
@@ -227,6 +302,7 @@ type AssertionHandler interface {
 	// Handling depends on Failure.Severity field:
 	//  - for SeverityError, reports failure to testing suite, e.g. using t.Errorf()
 	//  - for SeverityLog, ignores failure, or logs it, e.g. using t.Logf()
+	//  - for SeverityWarning, prints warning without failing test, e.g. using t.Logf()
 	Failure(*AssertionContext, *AssertionFailure)
 }
 
@@ -235,13 +311,17 @@ type AssertionHandler interface {
 //   - Formatter is used to format success and failure messages
 //   - Reporter is used to report formatted fatal failure messages
 //   - Logger is used to print formatted success and non-fatal failure messages
+//   - WarningLogger is used to print formatted warning messages
 //
-// Formatter and Reporter are required. Logger is optional.
+// Formatter and Reporter are required. Logger and WarningLogger are optional.
+// If WarningLogger is nil, warnings are printed to Logger, and if Logger is
+// nil too, warnings are ignored.
 // By default httpexpect creates DefaultAssertionHandler without Logger.
 type DefaultAssertionHandler struct {
-	Formatter Formatter
-	Reporter  Reporter
-	Logger    Logger
+	Formatter     Formatter
+	Reporter      Reporter
+	Logger        Logger
+	WarningLogger Logger
 }
 
 // Success implements AssertionHandler.Success.
@@ -285,5 +365,51 @@ func (h *DefaultAssertionHandler) Failure(
 		msg := h.Formatter.FormatFailure(ctx, failure)
 
 		h.Logger.Logf("%s", msg)
+
+	case SeverityWarning:
+		logger := h.WarningLogger
+		if logger == nil {
+			logger = h.Logger
+		}
+		if logger == nil {
+			return
+		}
+
+		msg := h.Formatter.FormatFailure(ctx, failure)
+
+		logger.Logf("[WARNING] %s", msg)
+	}
+}
+
+// MultiAssertionHandler is AssertionHandler that forwards every event to
+// each of the given handlers, in order.
+//
+// It can be used to combine DefaultAssertionHandler, which reports failures
+// to testing framework, with custom handlers, e.g. for metrics or logging.
+//
+// Example:
+//
+//	handler := MultiAssertionHandler{
+//		&DefaultAssertionHandler{
+//			Formatter: &DefaultFormatter{},
+//			Reporter:  NewAssertReporter(t),
+//		},
+//		myMetricsHandler,
+//	}
+type MultiAssertionHandler []AssertionHandler
+
+// Success implements AssertionHandler.Success.
+func (m MultiAssertionHandler) Success(ctx *AssertionContext) {
+	for _, h := range m {
+		h.Success(ctx)
+	}
+}
+
+// Failure implements AssertionHandler.Failure.
+func (m MultiAssertionHandler) Failure(
+	ctx *AssertionContext, failure *AssertionFailure,
+) {
+	for _, h := range m {
+		h.Failure(ctx, failure)
 	}
 }
@@ -141,6 +141,68 @@ func TestAssertion_Handler(t *testing.T) {
 	})
 }
 
+func TestAssertion_HandlerWarnings(t *testing.T) {
+	failure := &AssertionFailure{
+		Type:     AssertValid,
+		Severity: SeverityWarning,
+	}
+
+	t.Run("warning logger", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		logger := newMockLogger(t)
+		warningLogger := newMockLogger(t)
+
+		handler := &DefaultAssertionHandler{
+			Formatter:     newMockFormatter(t),
+			Reporter:      reporter,
+			Logger:        logger,
+			WarningLogger: warningLogger,
+		}
+
+		handler.Failure(&AssertionContext{TestName: t.Name()}, failure)
+
+		assert.True(t, warningLogger.logged)
+		assert.Contains(t, warningLogger.lastMessage, "[WARNING]")
+		assert.Contains(t, warningLogger.lastMessage, t.Name())
+
+		assert.False(t, logger.logged)
+		assert.False(t, reporter.reported)
+	})
+
+	t.Run("fallback to logger", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		logger := newMockLogger(t)
+
+		handler := &DefaultAssertionHandler{
+			Formatter: newMockFormatter(t),
+			Reporter:  reporter,
+			Logger:    logger,
+		}
+
+		handler.Failure(&AssertionContext{TestName: t.Name()}, failure)
+
+		assert.True(t, logger.logged)
+		assert.Contains(t, logger.lastMessage, "[WARNING]")
+
+		assert.False(t, reporter.reported)
+	})
+
+	t.Run("no loggers", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		formatter := newMockFormatter(t)
+
+		handler := &DefaultAssertionHandler{
+			Formatter: formatter,
+			Reporter:  reporter,
+		}
+
+		handler.Failure(&AssertionContext{TestName: t.Name()}, failure)
+
+		assert.Equal(t, 0, formatter.formattedFailure)
+		assert.False(t, reporter.reported)
+	})
+}
+
 func TestAssertion_HandlerPanics(t *testing.T) {
 	t.Run("success, nil Formatter", func(t *testing.T) {
 		handler := &DefaultAssertionHandler{
@@ -195,6 +257,81 @@ func TestAssertion_HandlerPanics(t *testing.T) {
 	})
 }
 
+func TestAssertion_MultiHandler(t *testing.T) {
+	handler1 := &mockAssertionHandler{}
+	handler2 := &mockAssertionHandler{}
+
+	handler := MultiAssertionHandler{handler1, handler2}
+
+	ctx := &AssertionContext{
+		TestName: t.Name(),
+	}
+
+	failure := &AssertionFailure{
+		Type:     AssertValid,
+		Severity: SeverityError,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		handler.Success(ctx)
+
+		for _, h := range []*mockAssertionHandler{handler1, handler2} {
+			assert.Equal(t, 1, h.successCalled)
+			assert.Equal(t, 0, h.failureCalled)
+			assert.Same(t, ctx, h.ctx)
+			assert.Nil(t, h.failure)
+		}
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		handler.Failure(ctx, failure)
+
+		for _, h := range []*mockAssertionHandler{handler1, handler2} {
+			assert.Equal(t, 1, h.successCalled)
+			assert.Equal(t, 1, h.failureCalled)
+			assert.Same(t, ctx, h.ctx)
+			assert.Same(t, failure, h.failure)
+		}
+	})
+
+	t.Run("order", func(t *testing.T) {
+		var calls []int
+
+		handler := MultiAssertionHandler{
+			&mockAssertionHandler{assertionCb: func() { calls = append(calls, 1) }},
+			&mockAssertionHandler{assertionCb: func() { calls = append(calls, 2) }},
+		}
+
+		handler.Success(ctx)
+		handler.Failure(ctx, failure)
+
+		assert.Equal(t, []int{1, 2, 1, 2}, calls)
+	})
+
+	t.Run("config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		custom := &mockAssertionHandler{}
+
+		e := WithConfig(Config{
+			AssertionHandler: MultiAssertionHandler{
+				&DefaultAssertionHandler{
+					Formatter: &DefaultFormatter{},
+					Reporter:  reporter,
+				},
+				custom,
+			},
+		})
+
+		e.Value(1).IsEqual(2)
+
+		assert.True(t, reporter.reported)
+		assert.Equal(t, 1, custom.failureCalled)
+		assert.Equal(t, AssertEqual, custom.failure.Type)
+		assert.Equal(t,
+			[]string{"Value()", "IsEqual()"}, custom.ctx.Path)
+	})
+}
+
 func TestAssertion_ValidateTraits(t *testing.T) {
 	cases := []struct {
 		name              string
@@ -0,0 +1,391 @@
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AttachmentWriter stores failure attachments in CI test reports.
+//
+// Implementations are JUnitAttachmentWriter and AllureAttachmentWriter.
+type AttachmentWriter interface {
+	// WriteAttachment stores attachment with given name and contents
+	// for the test with given name.
+	WriteAttachment(testName, name string, content []byte) error
+}
+
+// AttachmentHandler is an AssertionHandler that attaches full HTTP request
+// and response dump to test report when assertion fails.
+//
+// AttachmentHandler wraps another AssertionHandler (typically
+// DefaultAssertionHandler), which is invoked for every assertion as usual.
+// In addition, for every failure with SeverityError, AttachmentHandler dumps
+// request and response of the failed assertion, including headers and bodies,
+// and passes the dump to AttachmentWriter.
+//
+// Sensitive headers are redacted before writing, see RedactHeaders. If
+// Config.Redactor is set, it is applied to the dump as well.
+//
+// Example:
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		BaseURL: "http://example.com",
+//		AssertionHandler: &httpexpect.AttachmentHandler{
+//			Handler: &httpexpect.DefaultAssertionHandler{
+//				Formatter: &httpexpect.DefaultFormatter{},
+//				Reporter:  httpexpect.NewAssertReporter(t),
+//			},
+//			Writer: &httpexpect.JUnitAttachmentWriter{
+//				Dir:    "test-attachments",
+//				Logger: t,
+//			},
+//		},
+//	})
+type AttachmentHandler struct {
+	// Wrapped handler.
+	// Should not be nil.
+	Handler AssertionHandler
+
+	// Writer for attachments.
+	// If nil, attachments are written to artifacts directory of the test
+	// (see Config.ArtifactsDir), or are not written if it's not set.
+	Writer AttachmentWriter
+
+	// Names of headers which values are replaced with "<redacted>".
+	// If nil, DefaultRedactHeaders is used.
+	RedactHeaders []string
+
+	// If not nil, invoked for request and response bodies before writing
+	// them, and may be used to redact sensitive data.
+	RedactBody func(body []byte) []byte
+
+	// If not nil, invoked when attachment can't be written.
+	ErrorLogger Logger
+}
+
+// DefaultRedactHeaders defines headers redacted by AttachmentHandler by default.
+var DefaultRedactHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+}
+
+// Success implements AssertionHandler.Success.
+func (h *AttachmentHandler) Success(ctx *AssertionContext) {
+	if h.Handler == nil {
+		panic("AttachmentHandler.Handler is nil")
+	}
+
+	h.Handler.Success(ctx)
+}
+
+// Failure implements AssertionHandler.Failure.
+func (h *AttachmentHandler) Failure(
+	ctx *AssertionContext, failure *AssertionFailure,
+) {
+	if h.Handler == nil {
+		panic("AttachmentHandler.Handler is nil")
+	}
+
+	if failure.Severity == SeverityError {
+		if err := h.writeDump(ctx); err != nil && h.ErrorLogger != nil {
+			h.ErrorLogger.Logf("failed to write attachment: %s", err)
+		}
+	}
+
+	h.Handler.Failure(ctx, failure)
+}
+
+func (h *AttachmentHandler) writeDump(ctx *AssertionContext) error {
+	if h.Writer == nil && ctx.ArtifactsDir == "" {
+		return nil
+	}
+
+	dump := h.dump(ctx)
+	if len(dump) == 0 {
+		return nil
+	}
+
+	if h.Writer != nil {
+		return h.Writer.WriteAttachment(ctx.TestName, "http-dump.txt", dump)
+	}
+
+	_, err := writeArtifact(ctx.ArtifactsDir, "http-dump.txt", dump)
+	return err
+}
+
+func (h *AttachmentHandler) dump(ctx *AssertionContext) []byte {
+	var b bytes.Buffer
+
+	if req := h.dumpRequest(ctx); len(req) != 0 {
+		b.WriteString("=== request ===\n")
+		b.Write(req)
+	}
+
+	if resp := h.dumpResponse(ctx); len(resp) != 0 {
+		if b.Len() != 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString("=== response ===\n")
+		b.Write(resp)
+	}
+
+	return b.Bytes()
+}
+
+func (h *AttachmentHandler) dumpRequest(ctx *AssertionContext) []byte {
+	var b bytes.Buffer
+
+	if ctx.Request != nil && ctx.Request.httpReq != nil {
+		httpReq := ctx.Request.httpReq
+
+		reqCopy := *httpReq
+		reqCopy.URL = ctx.Redactor.redactURL(httpReq.URL)
+		reqCopy.Header = h.redactHeader(ctx.Redactor.RedactHeader(httpReq.Header))
+		reqCopy.Body = nil
+
+		if head, err := httputil.DumpRequest(&reqCopy, false); err == nil {
+			b.Write(normalizeNewlines(head))
+		}
+
+		if bw, ok := httpReq.Body.(*bodyWrapper); ok {
+			if body, err := bw.GetBody(); err == nil {
+				h.writeBody(&b, ctx.Redactor, body)
+			}
+		} else if httpReq.GetBody != nil {
+			if body, err := httpReq.GetBody(); err == nil {
+				h.writeBody(&b, ctx.Redactor, body)
+			}
+		}
+	}
+
+	return b.Bytes()
+}
+
+func (h *AttachmentHandler) dumpResponse(ctx *AssertionContext) []byte {
+	var b bytes.Buffer
+
+	if ctx.Response != nil && ctx.Response.httpResp != nil {
+		httpResp := ctx.Response.httpResp
+
+		respCopy := *httpResp
+		respCopy.Header = h.redactHeader(ctx.Redactor.RedactHeader(httpResp.Header))
+		respCopy.Body = nil
+
+		if head, err := httputil.DumpResponse(&respCopy, false); err == nil {
+			b.Write(normalizeNewlines(head))
+		}
+
+		switch ctx.Response.contentState {
+		case contentRetreived:
+			h.writeBody(&b, ctx.Redactor, io.NopCloser(bytes.NewReader(ctx.Response.content)))
+
+		case contentPending:
+			if bw, ok := httpResp.Body.(*bodyWrapper); ok {
+				if body, err := bw.GetBody(); err == nil {
+					h.writeBody(&b, ctx.Redactor, body)
+				}
+			}
+
+		case contentFailed, contentHijacked:
+			break
+		}
+	}
+
+	return b.Bytes()
+}
+
+func (h *AttachmentHandler) writeBody(
+	b *bytes.Buffer, redactor *Redactor, body io.ReadCloser,
+) {
+	defer body.Close()
+
+	content, err := io.ReadAll(body)
+	if err != nil || len(content) == 0 {
+		return
+	}
+
+	content = redactor.RedactBody(content)
+
+	if h.RedactBody != nil {
+		content = h.RedactBody(content)
+	}
+
+	b.Write(content)
+	if !bytes.HasSuffix(content, []byte("\n")) {
+		b.WriteString("\n")
+	}
+}
+
+func (h *AttachmentHandler) redactHeader(header http.Header) http.Header {
+	names := h.RedactHeaders
+	if names == nil {
+		names = DefaultRedactHeaders
+	}
+
+	result := header.Clone()
+
+	for _, name := range names {
+		if values := result.Values(name); len(values) != 0 {
+			redacted := make([]string, len(values))
+			for i := range redacted {
+				redacted[i] = "<redacted>"
+			}
+			result[http.CanonicalHeaderKey(name)] = redacted
+		}
+	}
+
+	return result
+}
+
+func normalizeNewlines(b []byte) []byte {
+	return bytes.ReplaceAll(b, []byte("\r\n"), []byte("\n"))
+}
+
+// JUnitAttachmentWriter writes attachments for JUnit XML reports.
+//
+// Attachment is written into a file inside Dir, and a line in the form
+// "[[ATTACHMENT|/path/to/file]]" is printed to Logger. When test output is
+// converted to JUnit XML (e.g. using go-junit-report), the line ends up in
+// test system-out, and CI servers that support this convention (e.g. Jenkins
+// JUnit Attachments plugin) show the file together with failed test.
+type JUnitAttachmentWriter struct {
+	// Directory for attachment files.
+	// Created if doesn't exist.
+	Dir string
+
+	// Logger used to print attachment markers.
+	// Typically testing.T.
+	Logger Logger
+
+	mu      sync.Mutex
+	counter int
+}
+
+// WriteAttachment implements AttachmentWriter.WriteAttachment.
+func (w *JUnitAttachmentWriter) WriteAttachment(
+	testName, name string, content []byte,
+) error {
+	w.mu.Lock()
+	w.counter++
+	counter := w.counter
+	w.mu.Unlock()
+
+	dir, err := filepath.Abs(filepath.Join(w.Dir, attachmentFileName(testName)))
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("%d-%s", counter, attachmentFileName(name)))
+
+	if err := os.WriteFile(path, content, 0o644); err != nil { //nolint:gosec
+		return err
+	}
+
+	if w.Logger != nil {
+		w.Logger.Logf("[[ATTACHMENT|%s]]", path)
+	}
+
+	return nil
+}
+
+// AllureAttachmentWriter writes attachments for Allure reports.
+//
+// For every attachment, it writes attachment file and a test result file
+// with "failed" status referencing the attachment into Dir, which should
+// be the "allure-results" directory passed to Allure.
+type AllureAttachmentWriter struct {
+	// Directory with Allure results.
+	// Created if doesn't exist.
+	Dir string
+}
+
+type allureResult struct {
+	UUID        string             `json:"uuid"`
+	Name        string             `json:"name"`
+	FullName    string             `json:"fullName"`
+	Status      string             `json:"status"`
+	Stage       string             `json:"stage"`
+	Start       int64              `json:"start"`
+	Stop        int64              `json:"stop"`
+	Attachments []allureAttachment `json:"attachments"`
+}
+
+type allureAttachment struct {
+	Name   string `json:"name"`
+	Source string `json:"source"`
+	Type   string `json:"type"`
+}
+
+// WriteAttachment implements AttachmentWriter.WriteAttachment.
+func (w *AllureAttachmentWriter) WriteAttachment(
+	testName, name string, content []byte,
+) error {
+	if err := os.MkdirAll(w.Dir, 0o755); err != nil {
+		return err
+	}
+
+	uuid := newUUID()
+
+	source := uuid + "-attachment" + filepath.Ext(name)
+
+	if err := os.WriteFile(
+		filepath.Join(w.Dir, source), content, 0o644); err != nil { //nolint:gosec
+		return err
+	}
+
+	now := time.Now().UnixNano() / int64(time.Millisecond)
+
+	result := allureResult{
+		UUID:     uuid,
+		Name:     testName,
+		FullName: testName,
+		Status:   "failed",
+		Stage:    "finished",
+		Start:    now,
+		Stop:     now,
+		Attachments: []allureAttachment{
+			{
+				Name:   name,
+				Source: source,
+				Type:   "text/plain",
+			},
+		},
+	}
+
+	b, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(
+		filepath.Join(w.Dir, uuid+"-result.json"), b, 0o644) //nolint:gosec
+}
+
+var attachmentFileNameRe = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+func attachmentFileName(name string) string {
+	name = attachmentFileNameRe.ReplaceAllString(name, "_")
+	name = strings.Trim(name, "_.")
+
+	if name == "" {
+		return "unnamed"
+	}
+
+	return name
+}
@@ -0,0 +1,237 @@
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockAttachmentWriter struct {
+	testName string
+	name     string
+	content  []byte
+	calls    int
+	err      error
+}
+
+func (w *mockAttachmentWriter) WriteAttachment(
+	testName, name string, content []byte,
+) error {
+	w.testName = testName
+	w.name = name
+	w.content = content
+	w.calls++
+	return w.err
+}
+
+func TestAttachmentHandler_Failure(t *testing.T) {
+	client := ClientFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusBadRequest,
+			Header: http.Header{
+				"Set-Cookie": {"session=secret"},
+				"X-Foo":      {"bar"},
+			},
+			Body: io.NopCloser(bytes.NewBufferString(`{"error":"password is wrong"}`)),
+		}, nil
+	})
+
+	newExpect := func(handler *AttachmentHandler) *Expect {
+		return WithConfig(Config{
+			TestName:         "TestLogin",
+			Client:           client,
+			AssertionHandler: handler,
+		})
+	}
+
+	t.Run("dump", func(t *testing.T) {
+		writer := &mockAttachmentWriter{}
+		wrapped := &mockAssertionHandler{}
+
+		e := newExpect(&AttachmentHandler{
+			Handler: wrapped,
+			Writer:  writer,
+		})
+
+		e.POST("/login").
+			WithHeader("Authorization", "Bearer token").
+			WithText("user=foo").
+			Expect().
+			Status(http.StatusOK)
+
+		assert.Equal(t, 1, wrapped.failureCalled)
+		assert.Equal(t, 1, writer.calls)
+		assert.Equal(t, "TestLogin", writer.testName)
+		assert.Equal(t, "http-dump.txt", writer.name)
+
+		dump := string(writer.content)
+
+		assert.Contains(t, dump, "=== request ===\nPOST /login HTTP/1.1\n")
+		assert.Contains(t, dump, "Authorization: <redacted>\n")
+		assert.Contains(t, dump, "user=foo\n")
+		assert.Contains(t, dump, "=== response ===\nHTTP/0.0 400 Bad Request\n")
+		assert.Contains(t, dump, "Set-Cookie: <redacted>\n")
+		assert.Contains(t, dump, "X-Foo: bar\n")
+		assert.Contains(t, dump, `{"error":"password is wrong"}`)
+
+		assert.NotContains(t, dump, "Bearer token")
+		assert.NotContains(t, dump, "session=secret")
+	})
+
+	t.Run("redact options", func(t *testing.T) {
+		writer := &mockAttachmentWriter{}
+
+		e := newExpect(&AttachmentHandler{
+			Handler:       &mockAssertionHandler{},
+			Writer:        writer,
+			RedactHeaders: []string{"x-foo"},
+			RedactBody: func(body []byte) []byte {
+				return bytes.ReplaceAll(body, []byte("password"), []byte("***"))
+			},
+		})
+
+		e.POST("/login").
+			WithHeader("Authorization", "Bearer token").
+			Expect().
+			Status(http.StatusOK)
+
+		dump := string(writer.content)
+
+		assert.Contains(t, dump, "Authorization: Bearer token\n")
+		assert.Contains(t, dump, "X-Foo: <redacted>\n")
+		assert.Contains(t, dump, `{"error":"*** is wrong"}`)
+	})
+
+	t.Run("writer error", func(t *testing.T) {
+		writer := &mockAttachmentWriter{
+			err: errors.New("test error"),
+		}
+		logger := newMockLogger(t)
+
+		e := newExpect(&AttachmentHandler{
+			Handler:     &mockAssertionHandler{},
+			Writer:      writer,
+			ErrorLogger: logger,
+		})
+
+		e.GET("/").Expect().Status(http.StatusOK)
+
+		assert.True(t, logger.logged)
+		assert.Contains(t, logger.lastMessage, "test error")
+	})
+}
+
+func TestAttachmentHandler_Severity(t *testing.T) {
+	writer := &mockAttachmentWriter{}
+	wrapped := &mockAssertionHandler{}
+
+	handler := &AttachmentHandler{
+		Handler: wrapped,
+		Writer:  writer,
+	}
+
+	ctx := &AssertionContext{
+		Response: &Response{
+			httpResp: &http.Response{StatusCode: http.StatusOK},
+		},
+	}
+
+	handler.Success(ctx)
+	assert.Equal(t, 1, wrapped.successCalled)
+	assert.Equal(t, 0, writer.calls)
+
+	handler.Failure(ctx, &AssertionFailure{Severity: SeverityLog})
+	assert.Equal(t, 1, wrapped.failureCalled)
+	assert.Equal(t, 0, writer.calls)
+
+	handler.Failure(ctx, &AssertionFailure{Severity: SeverityError})
+	assert.Equal(t, 2, wrapped.failureCalled)
+	assert.Equal(t, 1, writer.calls)
+
+	handler.Failure(&AssertionContext{}, &AssertionFailure{Severity: SeverityError})
+	assert.Equal(t, 3, wrapped.failureCalled)
+	assert.Equal(t, 1, writer.calls)
+}
+
+func TestAttachmentHandler_NilHandler(t *testing.T) {
+	handler := &AttachmentHandler{}
+
+	assert.Panics(t, func() {
+		handler.Success(&AssertionContext{})
+	})
+	assert.Panics(t, func() {
+		handler.Failure(&AssertionContext{}, &AssertionFailure{})
+	})
+}
+
+func TestJUnitAttachmentWriter(t *testing.T) {
+	dir := t.TempDir()
+	logger := newMockLogger(t)
+
+	writer := &JUnitAttachmentWriter{
+		Dir:    dir,
+		Logger: logger,
+	}
+
+	err := writer.WriteAttachment("TestFoo/sub test", "dump.txt", []byte("hello"))
+	require.NoError(t, err)
+
+	path := filepath.Join(dir, "TestFoo_sub_test", "1-dump.txt")
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(content))
+
+	assert.True(t, logger.logged)
+	assert.True(t, strings.HasPrefix(logger.lastMessage, "[[ATTACHMENT|"))
+	assert.True(t, strings.HasSuffix(logger.lastMessage, "1-dump.txt]]"))
+}
+
+func TestAllureAttachmentWriter(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "allure-results")
+
+	writer := &AllureAttachmentWriter{
+		Dir: dir,
+	}
+
+	err := writer.WriteAttachment("TestFoo", "dump.txt", []byte("hello"))
+	require.NoError(t, err)
+
+	results, err := filepath.Glob(filepath.Join(dir, "*-result.json"))
+	require.NoError(t, err)
+	require.Equal(t, 1, len(results))
+
+	data, err := os.ReadFile(results[0])
+	require.NoError(t, err)
+
+	var result allureResult
+	require.NoError(t, json.Unmarshal(data, &result))
+
+	assert.Equal(t, "TestFoo", result.Name)
+	assert.Equal(t, "failed", result.Status)
+	assert.Regexp(t,
+		`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`,
+		result.UUID)
+	require.Equal(t, 1, len(result.Attachments))
+	assert.Equal(t, "dump.txt", result.Attachments[0].Name)
+
+	content, err := os.ReadFile(filepath.Join(dir, result.Attachments[0].Source))
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(content))
+}
+
+func TestAttachmentFileName(t *testing.T) {
+	assert.Equal(t, "TestFoo_bar", attachmentFileName("TestFoo/bar"))
+	assert.Equal(t, "a.txt", attachmentFileName("a.txt"))
+	assert.Equal(t, "unnamed", attachmentFileName("../"))
+	assert.Equal(t, "unnamed", attachmentFileName(""))
+}
@@ -0,0 +1,349 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const defaultBatchConcurrency = 8
+
+// Batch sends multiple independent requests concurrently.
+//
+// It's useful to speed up suites dominated by independent checks, like
+// a series of GET requests to different endpoints. Batch is created using
+// Expect.Batch.
+type Batch struct {
+	noCopy      noCopy
+	config      Config
+	chain       *chain
+	requests    []*Request
+	concurrency int
+}
+
+// BatchResult holds responses of requests sent by Batch.Expect,
+// in the same order as requests were passed to Expect.Batch.
+type BatchResult struct {
+	noCopy    noCopy
+	config    Config
+	chain     *chain
+	requests  []*Request
+	responses []*Response
+}
+
+// Batch returns a new Batch that will send given requests.
+//
+// Requests should be built, but Expect should not be called on them;
+// they are sent by Batch.Expect instead. By default, up to 8 requests
+// are sent in parallel; use Batch.Concurrency to change this.
+//
+// Example:
+//
+//	result := e.Batch(
+//		e.GET("/users"),
+//		e.GET("/orders"),
+//		e.GET("/products"),
+//	).Expect()
+//
+//	result.Each(func(i int, resp *httpexpect.Response) {
+//		resp.Status(http.StatusOK)
+//	})
+func (e *Expect) Batch(requests ...*Request) *Batch {
+	opChain := e.chain.enter("Batch()")
+	defer opChain.leave()
+
+	for _, req := range requests {
+		if req == nil {
+			opChain.fail(AssertionFailure{
+				Type: AssertUsage,
+				Errors: []error{
+					errors.New("unexpected nil request"),
+				},
+			})
+			break
+		}
+	}
+
+	return &Batch{
+		config:      e.config,
+		chain:       opChain.clone(),
+		requests:    requests,
+		concurrency: defaultBatchConcurrency,
+	}
+}
+
+// Concurrency sets maximum number of requests sent in parallel.
+//
+// Default is 8. Use 1 to send requests sequentially.
+//
+// Example:
+//
+//	e.Batch(requests...).Concurrency(20).Expect()
+func (b *Batch) Concurrency(c int) *Batch {
+	opChain := b.chain.enter("Concurrency(%d)", c)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return b
+	}
+
+	if c <= 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected non-positive concurrency: %d", c),
+			},
+		})
+		return b
+	}
+
+	b.concurrency = c
+
+	return b
+}
+
+// Expect sends all requests, waits until they are completed, and returns
+// BatchResult with responses.
+//
+// Every request is handled like by Request.Expect: failures, like failed
+// transport or default response assertions, are reported via chain of
+// corresponding request. Note that matchers and default response assertions
+// are invoked from multiple goroutines, so they should be safe for
+// concurrent use.
+//
+// Example:
+//
+//	result := e.Batch(e.GET("/a"), e.GET("/b")).Expect()
+//
+//	result.Response(0).Status(http.StatusOK)
+//	result.Response(1).Status(http.StatusNotFound)
+func (b *Batch) Expect() *BatchResult {
+	opChain := b.chain.enter("Expect()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newBatchResult(opChain, b.config, nil, nil)
+	}
+
+	// prepare requests in caller goroutine, so that goroutine
+	// checks enabled by Config.Parallel pass
+	chains := make([]*chain, len(b.requests))
+	prepared := make([]bool, len(b.requests))
+
+	for i, req := range b.requests {
+		chains[i] = req.chain.enter("Expect()")
+		prepared[i] = req.prepare(chains[i])
+	}
+
+	responses := make([]*Response, len(b.requests))
+
+	indexes := make(chan int, len(b.requests))
+	for i := range b.requests {
+		indexes <- i
+	}
+	close(indexes)
+
+	concurrency := b.concurrency
+	if concurrency > len(b.requests) {
+		concurrency = len(b.requests)
+	}
+
+	var wg sync.WaitGroup
+
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+				responses[i] = b.send(i, chains[i], prepared[i])
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return newBatchResult(opChain, b.config, b.requests, responses)
+}
+
+func (b *Batch) send(i int, reqChain *chain, prepared bool) *Response {
+	defer reqChain.leave()
+
+	req := b.requests[i]
+
+	var resp *Response
+	if prepared {
+		resp = req.complete(reqChain)
+	}
+
+	if resp == nil {
+		resp = newResponse(responseOpts{
+			config:   req.config,
+			chain:    reqChain,
+			failures: req.failures,
+		})
+	}
+
+	return resp
+}
+
+func newBatchResult(
+	parent *chain, config Config, requests []*Request, responses []*Response,
+) *BatchResult {
+	return &BatchResult{
+		config:    config,
+		chain:     parent.clone(),
+		requests:  requests,
+		responses: responses,
+	}
+}
+
+// Len returns number of responses.
+func (br *BatchResult) Len() int {
+	return len(br.responses)
+}
+
+// Responses returns all responses, in order of requests.
+func (br *BatchResult) Responses() []*Response {
+	return append([]*Response(nil), br.responses...)
+}
+
+// Response returns response for request with given index.
+//
+// If index is out of range, failure is reported and empty (but non-nil)
+// instance is returned.
+//
+// Example:
+//
+//	result := e.Batch(e.GET("/a"), e.GET("/b")).Expect()
+//	result.Response(1).Status(http.StatusOK)
+func (br *BatchResult) Response(index int) *Response {
+	opChain := br.chain.enter("Response(%d)", index)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newResponse(responseOpts{config: br.config, chain: opChain})
+	}
+
+	if index < 0 || index >= len(br.responses) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertInRange,
+			Actual:   &AssertionValue{index},
+			Expected: &AssertionValue{AssertionRange{0, len(br.responses) - 1}},
+			Errors: []error{
+				errors.New("expected: valid response index"),
+			},
+		})
+		return newResponse(responseOpts{config: br.config, chain: opChain})
+	}
+
+	return br.responses[index]
+}
+
+// Each invokes given function for every response, sequentially,
+// in order of requests.
+//
+// Example:
+//
+//	result.Each(func(i int, resp *httpexpect.Response) {
+//		resp.Status(http.StatusOK).JSON().Object().ContainsKey("id")
+//	})
+func (br *BatchResult) Each(fn func(index int, resp *Response)) *BatchResult {
+	opChain := br.chain.enter("Each()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return br
+	}
+
+	if fn == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil function argument"),
+			},
+		})
+		return br
+	}
+
+	for i, resp := range br.responses {
+		fn(i, resp)
+	}
+
+	return br
+}
+
+// Passed returns true if there were no failures, neither when sending
+// requests, nor in assertions made on responses so far.
+func (br *BatchResult) Passed() bool {
+	for _, resp := range br.responses {
+		if _, err := resp.Result(); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Summary returns human-readable summary of failures of all requests,
+// including failures of assertions made on responses so far.
+//
+// Summary contains total number of requests and number of failed requests,
+// and a line per every failed request with its index, method, URL, and
+// failures. Returns empty string if batch is empty.
+//
+// Example:
+//
+//	result := e.Batch(requests...).Expect()
+//	result.Each(checkResponse)
+//
+//	if !result.Passed() {
+//		t.Log(result.Summary())
+//	}
+func (br *BatchResult) Summary() string {
+	if len(br.responses) == 0 {
+		return ""
+	}
+
+	var (
+		lines  []string
+		failed int
+	)
+
+	for i, resp := range br.responses {
+		_, err := resp.Result()
+		if err == nil {
+			continue
+		}
+
+		failed++
+
+		lines = append(lines,
+			fmt.Sprintf("#%d %s: %s", i, br.requestName(i), err.Error()))
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%d requests, %d failed\n", len(br.responses), failed)
+
+	for _, line := range lines {
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+func (br *BatchResult) requestName(index int) string {
+	if index >= len(br.requests) {
+		return ""
+	}
+
+	req := br.requests[index]
+	if req.httpReq == nil {
+		return ""
+	}
+
+	return req.httpReq.Method + " " + req.httpReq.URL.String()
+}
@@ -0,0 +1,189 @@
+package httpexpect
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatch_Expect(t *testing.T) {
+	var (
+		inflight    int64
+		maxInflight int64
+	)
+
+	client := ClientFunc(func(req *http.Request) (*http.Response, error) {
+		n := atomic.AddInt64(&inflight, 1)
+		defer atomic.AddInt64(&inflight, -1)
+
+		for {
+			m := atomic.LoadInt64(&maxInflight)
+			if n <= m || atomic.CompareAndSwapInt64(&maxInflight, m, n) {
+				break
+			}
+		}
+
+		// later requests complete earlier
+		if strings.HasSuffix(req.URL.Path, "/0") {
+			time.Sleep(20 * time.Millisecond)
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"X-Path": {req.URL.Path}},
+			Body:       http.NoBody,
+			Request:    req,
+		}, nil
+	})
+
+	e := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Client:   client,
+		Reporter: newMockReporter(t),
+		Parallel: true,
+	})
+
+	var requests []*Request
+	for i := 0; i < 6; i++ {
+		requests = append(requests, e.GET("/items/{id}", i))
+	}
+
+	result := e.Batch(requests...).Concurrency(3).Expect()
+	result.chain.assert(t, success)
+
+	assert.Equal(t, 6, result.Len())
+	assert.Equal(t, 6, len(result.Responses()))
+	assert.LessOrEqual(t, atomic.LoadInt64(&maxInflight), int64(3))
+	assert.Greater(t, atomic.LoadInt64(&maxInflight), int64(1))
+
+	var indexes []int
+
+	result.Each(func(i int, resp *Response) {
+		indexes = append(indexes, i)
+
+		resp.Status(http.StatusOK)
+		resp.Header("X-Path").IsEqual("/items/" + strconv.Itoa(i))
+		resp.chain.assert(t, success)
+	})
+
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5}, indexes)
+
+	result.Response(2).Header("X-Path").IsEqual("/items/2")
+
+	assert.True(t, result.Passed())
+	assert.Equal(t, "6 requests, 0 failed\n", result.Summary())
+}
+
+func TestBatch_Failures(t *testing.T) {
+	client := ClientFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path == "/fail" {
+			return nil, errors.New("connection refused")
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       http.NoBody,
+			Request:    req,
+		}, nil
+	})
+
+	e := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Client:   client,
+		Reporter: newMockReporter(t),
+	})
+
+	failed := e.GET("/fail")
+
+	result := e.Batch(e.GET("/ok"), failed, e.GET("/status")).Expect()
+	result.chain.assert(t, success)
+
+	failed.chain.assert(t, failure)
+	result.Response(0).chain.assert(t, success)
+	result.Response(1).chain.assert(t, failure)
+
+	assert.False(t, result.Passed())
+
+	result.Response(2).Status(http.StatusNotFound)
+
+	summary := result.Summary()
+
+	assert.True(t, strings.HasPrefix(summary, "3 requests, 2 failed\n"))
+	assert.Contains(t, summary,
+		"#1 GET http://example.com/fail: assertion failed: ")
+	assert.Contains(t, summary, "connection refused")
+	assert.Contains(t, summary,
+		"#2 GET http://example.com/status: assertion failed: ")
+}
+
+func TestBatch_Usage(t *testing.T) {
+	newExpect := func(t *testing.T) *Expect {
+		return WithConfig(Config{
+			Client:   &mockClient{},
+			Reporter: newMockReporter(t),
+		})
+	}
+
+	t.Run("nil request", func(t *testing.T) {
+		e := newExpect(t)
+
+		result := e.Batch(e.GET("/a"), nil).Expect()
+		result.chain.assert(t, failure)
+
+		assert.Equal(t, 0, result.Len())
+		assert.Equal(t, "", result.Summary())
+	})
+
+	t.Run("zero concurrency", func(t *testing.T) {
+		e := newExpect(t)
+
+		batch := e.Batch(e.GET("/a")).Concurrency(0)
+		batch.chain.assert(t, failure)
+	})
+
+	t.Run("empty batch", func(t *testing.T) {
+		e := newExpect(t)
+
+		result := e.Batch().Expect()
+		result.chain.assert(t, success)
+
+		assert.Equal(t, 0, result.Len())
+		assert.True(t, result.Passed())
+	})
+
+	t.Run("index out of range", func(t *testing.T) {
+		e := newExpect(t)
+
+		result := e.Batch(e.GET("/a")).Expect()
+
+		result.Response(1).chain.assert(t, failure)
+		result.Response(-1).chain.assert(t, failure)
+	})
+
+	t.Run("nil function", func(t *testing.T) {
+		e := newExpect(t)
+
+		result := e.Batch(e.GET("/a")).Expect()
+
+		result.Each(nil)
+		result.chain.assert(t, failure)
+	})
+
+	t.Run("request after expect", func(t *testing.T) {
+		e := newExpect(t)
+
+		req := e.GET("/a")
+		req.Expect().chain.assert(t, success)
+
+		result := e.Batch(req).Expect()
+		result.Response(0).chain.assert(t, failure)
+
+		assert.False(t, result.Passed())
+	})
+}
@@ -0,0 +1,167 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+)
+
+// BenchmarkReporter is a struct that implements the Reporter interface
+// for benchmarks. It stops benchmark timer and calls b.Fatalf() when
+// an assertion fails.
+//
+// Timer is stopped before failure message is formatted, so reporting
+// doesn't affect measurements. Successful assertions are not reported
+// at all and don't cause any formatting.
+type BenchmarkReporter struct {
+	backend *testing.B
+}
+
+// NewBenchmarkReporter returns a new BenchmarkReporter object.
+func NewBenchmarkReporter(b *testing.B) *BenchmarkReporter {
+	return &BenchmarkReporter{b}
+}
+
+// Errorf implements Reporter.Errorf.
+func (r *BenchmarkReporter) Errorf(message string, args ...interface{}) {
+	r.backend.StopTimer()
+	r.backend.Fatalf("%s", fmt.Sprintf(message, args...))
+}
+
+// ForBenchmark returns a new Expect instance configured for benchmarks.
+//
+// ForBenchmark is similar to Default, but it doesn't print requests and
+// responses, because printing in a benchmark loop would dominate timings.
+// It uses:
+//   - baseURL for Config.BaseURL
+//   - b.Name() for Config.TestName
+//   - NewBenchmarkReporter(b) for Config.Reporter
+//
+// Use Request.Benchmark to send request in the benchmark loop.
+//
+// Example:
+//
+//	func BenchmarkUsers(b *testing.B) {
+//		e := httpexpect.ForBenchmark(b, server.URL)
+//
+//		e.GET("/users").Benchmark(b, func(resp *httpexpect.Response) {
+//			resp.Status(http.StatusOK)
+//		})
+//	}
+func ForBenchmark(b *testing.B, baseURL string) *Expect {
+	return WithConfig(Config{
+		TestName: b.Name(),
+		BaseURL:  baseURL,
+		Reporter: NewBenchmarkReporter(b),
+	})
+}
+
+// Benchmark sends request b.N times and invokes check for every response.
+//
+// Request is built and encoded only once, before benchmark timer is reset,
+// so building request, including JSON encoding and value providers, is
+// excluded from timing. Every iteration sends a copy of the same request.
+// Sending request, reading response, and assertions made by check are
+// included into timing. Response body is drained and closed after check
+// returns, so connections can be reused.
+//
+// Benchmark stops on the first failure, either when request can't be sent,
+// or when an assertion made by check fails. Printers, retries, and response
+// matchers are not applied.
+//
+// check may be nil, in which case only requests are sent.
+//
+// Example:
+//
+//	func BenchmarkCreateUser(b *testing.B) {
+//		e := httpexpect.ForBenchmark(b, server.URL)
+//
+//		e.POST("/users").
+//			WithJSON(user).
+//			Benchmark(b, func(resp *httpexpect.Response) {
+//				resp.Status(http.StatusCreated).
+//					JSON().Object().ContainsKey("id")
+//			})
+//	}
+func (r *Request) Benchmark(b *testing.B, check func(resp *Response)) {
+	opChain := r.chain.enter("Benchmark()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return
+	}
+
+	if b == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil benchmark"),
+			},
+		})
+		return
+	}
+
+	if r.wsUpgrade {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected Benchmark() call for websocket request"),
+			},
+		})
+		return
+	}
+
+	if !r.prepare(opChain) {
+		return
+	}
+
+	body, ok := r.encodeReusable(opChain)
+	if !ok {
+		return
+	}
+
+	b.ResetTimer()
+	defer b.StopTimer()
+
+	for i := 0; i < b.N; i++ {
+		httpReq := r.cloneReusable(body)
+
+		start := time.Now()
+
+		httpResp, err := r.config.Client.Do(httpReq)
+		if err != nil {
+			b.StopTimer()
+			opChain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					errors.New("failed to send http request"),
+					err,
+				},
+			})
+			return
+		}
+
+		resp := newResponse(responseOpts{
+			config:   r.config,
+			chain:    opChain,
+			httpResp: httpResp,
+			failures: r.failures,
+			rtt:      []time.Duration{time.Since(start)},
+		})
+
+		if check != nil {
+			check(resp)
+		}
+
+		if resp.httpResp != nil && resp.httpResp.Body != nil {
+			_, _ = io.Copy(io.Discard, resp.httpResp.Body)
+			_ = resp.httpResp.Body.Close()
+		}
+
+		if resp.chain.treeFailed() {
+			return
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package httpexpect
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setBenchtime(t *testing.T, value string) {
+	f := flag.Lookup("test.benchtime")
+	if f == nil {
+		return
+	}
+
+	old := f.Value.String()
+	_ = f.Value.Set(value)
+
+	t.Cleanup(func() {
+		_ = f.Value.Set(old)
+	})
+}
+
+func TestBenchmark_Request(t *testing.T) {
+	setBenchtime(t, "100x")
+
+	var (
+		count  int64
+		bodies int64
+	)
+
+	client := ClientFunc(func(req *http.Request) (*http.Response, error) {
+		atomic.AddInt64(&count, 1)
+
+		b, _ := io.ReadAll(req.Body)
+		if string(b) == `{"a":1}` {
+			atomic.AddInt64(&bodies, 1)
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       newMockBody(`{"ok": true}`),
+			Request:    req,
+		}, nil
+	})
+
+	t.Run("success", func(t *testing.T) {
+		atomic.StoreInt64(&count, 0)
+		atomic.StoreInt64(&bodies, 0)
+
+		checks := 0
+
+		result := testing.Benchmark(func(b *testing.B) {
+			e := WithConfig(Config{
+				BaseURL:  "http://example.com",
+				Client:   client,
+				Reporter: NewBenchmarkReporter(b),
+			})
+
+			e.POST("/test").
+				WithJSON(map[string]interface{}{"a": 1}).
+				Benchmark(b, func(resp *Response) {
+					checks++
+					resp.Status(http.StatusOK).JSON().Object().HasValue("ok", true)
+				})
+		})
+
+		assert.NotZero(t, result.N)
+		assert.Equal(t, int64(checks), atomic.LoadInt64(&count))
+		assert.Equal(t, atomic.LoadInt64(&count), atomic.LoadInt64(&bodies))
+	})
+
+	t.Run("nil check", func(t *testing.T) {
+		result := testing.Benchmark(func(b *testing.B) {
+			ForBenchmark(b, "http://example.com").
+				GET("/test").
+				WithClient(client).
+				Benchmark(b, nil)
+		})
+
+		assert.NotZero(t, result.N)
+	})
+
+	t.Run("check failure", func(t *testing.T) {
+		checks := 0
+
+		result := testing.Benchmark(func(b *testing.B) {
+			checks = 0
+
+			e := WithConfig(Config{
+				BaseURL:  "http://example.com",
+				Client:   client,
+				Reporter: newMockReporter(t),
+			})
+
+			e.GET("/test").
+				Benchmark(b, func(resp *Response) {
+					checks++
+					resp.Status(http.StatusNotFound)
+				})
+		})
+
+		assert.NotZero(t, result.N)
+		assert.Equal(t, 1, checks)
+	})
+
+	t.Run("client failure", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		testing.Benchmark(func(b *testing.B) {
+			e := WithConfig(Config{
+				BaseURL: "http://example.com",
+				Client: ClientFunc(func(req *http.Request) (*http.Response, error) {
+					return nil, errors.New("error")
+				}),
+				Reporter: reporter,
+			})
+
+			e.GET("/test").Benchmark(b, nil)
+		})
+
+		assert.True(t, reporter.reported)
+	})
+
+	t.Run("reporter", func(t *testing.T) {
+		result := testing.Benchmark(func(b *testing.B) {
+			e := WithConfig(Config{
+				BaseURL:  "http://example.com",
+				Client:   client,
+				Reporter: NewBenchmarkReporter(b),
+			})
+
+			e.GET("/test").
+				Benchmark(b, func(resp *Response) {
+					resp.Status(http.StatusNotFound)
+				})
+		})
+
+		// benchmark failed
+		assert.Zero(t, result.N)
+	})
+}
+
+func TestBenchmark_Usage(t *testing.T) {
+	t.Run("nil benchmark", func(t *testing.T) {
+		req := NewRequestC(Config{
+			Client:   &mockClient{},
+			Reporter: newMockReporter(t),
+		}, "GET", "/test")
+
+		req.Benchmark(nil, nil)
+		req.chain.assert(t, failure)
+	})
+
+	t.Run("websocket", func(t *testing.T) {
+		testing.Benchmark(func(b *testing.B) {
+			req := NewRequestC(Config{
+				Client:   &mockClient{},
+				Reporter: newMockReporter(t),
+			}, "GET", "/test").WithWebsocketUpgrade()
+
+			req.Benchmark(b, nil)
+			req.chain.assert(t, failure)
+		})
+	})
+
+	t.Run("after expect", func(t *testing.T) {
+		testing.Benchmark(func(b *testing.B) {
+			req := NewRequestC(Config{
+				Client:   &mockClient{},
+				Reporter: newMockReporter(t),
+			}, "GET", "/test")
+
+			req.Expect()
+			req.chain.assert(t, success)
+
+			req.Benchmark(b, nil)
+			req.chain.assert(t, failure)
+		})
+	})
+}
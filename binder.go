@@ -2,6 +2,7 @@ package httpexpect
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"fmt"
 	"io"
@@ -20,11 +21,24 @@ import (
 // Binder emulates network communication by invoking given http.Handler
 // directly. It passes httptest.ResponseRecorder as http.ResponseWriter
 // to the handler, and then constructs http.Response from recorded data.
+//
+// Connection metadata seen by the handler, like RemoteAddr, TLS, and
+// request context, can be controlled either for all requests using Binder
+// fields, or for individual requests using Request.WithRemoteAddr,
+// Request.WithTLSState, and Request.WithContextValue.
 type Binder struct {
 	// HTTP handler invoked for every request.
 	Handler http.Handler
 	// TLS connection state used for https:// requests.
+	// Not used if request already has TLS state.
 	TLS *tls.ConnectionState
+	// Remote address, e.g. "10.0.0.1:1234", used for requests that
+	// don't have one. If empty, RemoteAddr is left empty.
+	RemoteAddr string
+	// If non-nil, invoked for every request to modify its context,
+	// similar to http.Server.ConnContext. Returned context is passed
+	// to the handler and must be non-nil.
+	ConnContext func(ctx context.Context, req *http.Request) context.Context
 }
 
 // NewBinder returns a new Binder given a http.Handler.
@@ -54,14 +68,22 @@ func (binder Binder) RoundTrip(origReq *http.Request) (*http.Response, error) {
 		req.Body = http.NoBody
 	}
 
-	if req.URL != nil && req.URL.Scheme == "https" && binder.TLS != nil {
+	if req.URL != nil && req.URL.Scheme == "https" && req.TLS == nil {
 		req.TLS = binder.TLS
 	}
 
+	if req.RemoteAddr == "" {
+		req.RemoteAddr = binder.RemoteAddr
+	}
+
 	if req.RequestURI == "" {
 		req.RequestURI = req.URL.RequestURI()
 	}
 
+	if binder.ConnContext != nil {
+		req = *req.WithContext(binder.ConnContext(req.Context(), &req))
+	}
+
 	recorder := httptest.NewRecorder()
 
 	binder.Handler.ServeHTTP(recorder, &req)
@@ -94,7 +116,11 @@ type FastBinder struct {
 	// FastHTTP handler invoked for every request.
 	Handler fasthttp.RequestHandler
 	// TLS connection state used for https:// requests.
+	// Not used if request already has TLS state.
 	TLS *tls.ConnectionState
+	// Remote address, e.g. "10.0.0.1:1234", used for requests that
+	// don't have one.
+	RemoteAddr string
 	// If non-nil, fasthttp.RequestCtx.Logger() will print messages to it.
 	Logger Logger
 }
@@ -115,7 +141,9 @@ func (binder FastBinder) RoundTrip(stdreq *http.Request) (*http.Response, error)
 	fastreq := std2fast(stdreq)
 
 	var conn net.Conn
-	if stdreq.URL != nil && stdreq.URL.Scheme == "https" && binder.TLS != nil {
+	if stdreq.TLS != nil {
+		conn = connTLS{state: stdreq.TLS}
+	} else if stdreq.URL != nil && stdreq.URL.Scheme == "https" && binder.TLS != nil {
 		conn = connTLS{state: binder.TLS}
 	} else {
 		conn = connNonTLS{}
@@ -127,8 +155,13 @@ func (binder FastBinder) RoundTrip(stdreq *http.Request) (*http.Response, error)
 
 	fastreq.CopyTo(&ctx.Request)
 
-	if stdreq.RemoteAddr != "" {
-		var parts = strings.SplitN(stdreq.RemoteAddr, ":", 2)
+	remoteAddr := stdreq.RemoteAddr
+	if remoteAddr == "" {
+		remoteAddr = binder.RemoteAddr
+	}
+
+	if remoteAddr != "" {
+		var parts = strings.SplitN(remoteAddr, ":", 2)
 		host := parts[0]
 		port := 0
 		if len(parts) > 1 {
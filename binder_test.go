@@ -2,6 +2,7 @@ package httpexpect
 
 import (
 	"bufio"
+	"context"
 	"crypto/tls"
 	"io"
 	"net/http"
@@ -149,6 +150,95 @@ func TestBinder_TLS(t *testing.T) {
 	})
 }
 
+func TestBinder_RemoteAddr(t *testing.T) {
+	var remoteAddr string
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		remoteAddr = r.RemoteAddr
+	})
+
+	client := &http.Client{
+		Transport: Binder{
+			Handler:    handler,
+			RemoteAddr: "10.0.0.1:1234",
+		},
+	}
+
+	t.Run("default", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "http://example.com/path", nil)
+		_, err := client.Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, "10.0.0.1:1234", remoteAddr)
+	})
+
+	t.Run("request", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "http://example.com/path", nil)
+		req.RemoteAddr = "10.0.0.2:5678"
+		_, err := client.Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, "10.0.0.2:5678", remoteAddr)
+	})
+}
+
+func TestBinder_ConnContext(t *testing.T) {
+	type ctxKey struct{}
+
+	var value interface{}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		value = r.Context().Value(ctxKey{})
+	})
+
+	client := &http.Client{
+		Transport: Binder{
+			Handler: handler,
+			ConnContext: func(ctx context.Context, req *http.Request) context.Context {
+				return context.WithValue(ctx, ctxKey{}, req.URL.Path)
+			},
+		},
+	}
+
+	req, _ := http.NewRequest("GET", "http://example.com/path", nil)
+	_, err := client.Do(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "/path", value)
+}
+
+func TestBinder_RequestMetadata(t *testing.T) {
+	type ctxKey struct{}
+
+	var (
+		remoteAddr string
+		tlsState   *tls.ConnectionState
+		value      interface{}
+	)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		remoteAddr = r.RemoteAddr
+		tlsState = r.TLS
+		value = r.Context().Value(ctxKey{})
+	})
+
+	state := &tls.ConnectionState{ServerName: "example.com"}
+
+	e := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: newMockReporter(t),
+	})
+
+	e.GET("/path").
+		WithHandler(handler).
+		WithRemoteAddr("10.0.0.3:9999").
+		WithTLSState(state).
+		WithContextValue(ctxKey{}, "value").
+		Expect().
+		Status(http.StatusOK)
+
+	assert.Equal(t, "10.0.0.3:9999", remoteAddr)
+	assert.Same(t, state, tlsState)
+	assert.Equal(t, "value", value)
+}
+
 func TestBinder_Chunked(t *testing.T) {
 	handler := &mockHandler{
 		t:       t,
@@ -286,6 +376,43 @@ func TestFastBinder_RemoteAddr(t *testing.T) {
 	assert.Equal(t, `ok`, string(b))
 }
 
+func TestFastBinder_Metadata(t *testing.T) {
+	var (
+		remoteAddr string
+		isTLS      bool
+	)
+
+	handler := func(ctx *fasthttp.RequestCtx) {
+		remoteAddr = ctx.RemoteAddr().String()
+		isTLS = ctx.IsTLS()
+	}
+
+	client := &http.Client{
+		Transport: FastBinder{
+			Handler:    handler,
+			RemoteAddr: "10.0.0.1:1234",
+		},
+	}
+
+	t.Run("default", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "http://example.com/path", nil)
+		_, err := client.Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, "10.0.0.1:1234", remoteAddr)
+		assert.False(t, isTLS)
+	})
+
+	t.Run("request", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "http://example.com/path", nil)
+		req.RemoteAddr = "10.0.0.2:5678"
+		req.TLS = &tls.ConnectionState{}
+		_, err := client.Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, "10.0.0.2:5678", remoteAddr)
+		assert.True(t, isTLS)
+	})
+}
+
 func TestFastBinder_Protocol(t *testing.T) {
 	test := func(setProto func(req *http.Request)) {
 		handler := func(ctx *fasthttp.RequestCtx) {
@@ -0,0 +1,288 @@
+package httpexpect
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"mime"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// BodyDecoder decodes response body of specific media type into Go value.
+//
+// It is used by Response.Decode. Decoders are registered in CodecRegistry
+// by media type.
+//
+// BodyDecoderFunc can be used to adapt a function.
+type BodyDecoder interface {
+	Decode(data []byte, target interface{}) error
+}
+
+// BodyDecoderFunc is an adapter that allows a function to be used
+// as the BodyDecoder.
+//
+// Example:
+//
+//	decoder := httpexpect.BodyDecoderFunc(func(data []byte, target interface{}) error {
+//		return proto.Unmarshal(data, target.(proto.Message))
+//	})
+type BodyDecoderFunc func(data []byte, target interface{}) error
+
+// Decode implements BodyDecoder.Decode.
+func (f BodyDecoderFunc) Decode(data []byte, target interface{}) error {
+	return f(data, target)
+}
+
+// CodecRegistry maps media types to BodyDecoders used by Response.Decode.
+//
+// JSON and XML are supported out of the box:
+//   - "application/json" and any "+json" type, like "application/problem+json",
+//     are decoded using Config.JSONDecoder
+//   - "application/xml", "text/xml", and any "+xml" type are decoded
+//     using encoding/xml
+//
+// Other formats, like protobuf or Avro, are supported by registering
+// decoders for their media types. Registered decoders take precedence
+// over builtin ones.
+//
+// Registry is set via Config.Codecs and can be overridden for a single
+// request using Request.WithCodec.
+//
+// Example:
+//
+//	codecs := httpexpect.NewCodecRegistry().
+//		Register("application/x-protobuf", httpexpect.BodyDecoderFunc(
+//			func(data []byte, target interface{}) error {
+//				return proto.Unmarshal(data, target.(proto.Message))
+//			}))
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		Reporter: httpexpect.NewAssertReporter(t),
+//		Codecs:   codecs,
+//	})
+type CodecRegistry struct {
+	mu       sync.RWMutex
+	decoders map[string]BodyDecoder
+}
+
+// NewCodecRegistry returns a new CodecRegistry with builtin decoders only.
+func NewCodecRegistry() *CodecRegistry {
+	return &CodecRegistry{
+		decoders: make(map[string]BodyDecoder),
+	}
+}
+
+// Register sets decoder for given media type, e.g. "application/x-protobuf".
+//
+// Media type is matched case-insensitively and without parameters.
+// Suffix form, like "+avro", matches all media types with this suffix,
+// unless there is a decoder for exact media type.
+//
+// Panics if media type is empty or decoder is nil.
+func (cr *CodecRegistry) Register(mediaType string, decoder BodyDecoder) *CodecRegistry {
+	if mediaType == "" {
+		panic("CodecRegistry.Register: empty media type")
+	}
+
+	if decoder == nil {
+		panic("CodecRegistry.Register: nil decoder")
+	}
+
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+
+	cr.decoders[strings.ToLower(mediaType)] = decoder
+
+	return cr
+}
+
+// Clone returns a copy of registry, which can be modified independently,
+// e.g. to override decoders in a single test.
+func (cr *CodecRegistry) Clone() *CodecRegistry {
+	clone := NewCodecRegistry()
+
+	if cr == nil {
+		return clone
+	}
+
+	cr.mu.RLock()
+	defer cr.mu.RUnlock()
+
+	for k, v := range cr.decoders {
+		clone.decoders[k] = v
+	}
+
+	return clone
+}
+
+// lookup returns decoder for given media type (without parameters).
+func (cr *CodecRegistry) lookup(
+	mediaType string, jsonDecoder JSONDecoder,
+) (BodyDecoder, bool) {
+	mediaType = strings.ToLower(mediaType)
+
+	var suffix string
+	if i := strings.LastIndex(mediaType, "+"); i >= 0 {
+		suffix = mediaType[i:]
+	}
+
+	if cr != nil {
+		cr.mu.RLock()
+		defer cr.mu.RUnlock()
+
+		if d, ok := cr.decoders[mediaType]; ok {
+			return d, true
+		}
+		if d, ok := cr.decoders[suffix]; ok && suffix != "" {
+			return d, true
+		}
+	}
+
+	switch {
+	case isJSONMediaType(mediaType):
+		return BodyDecoderFunc(jsonDecoderOrDefault(jsonDecoder).Unmarshal), true
+
+	case isXMLMediaType(mediaType):
+		return BodyDecoderFunc(xml.Unmarshal), true
+	}
+
+	return nil, false
+}
+
+// Decode decodes response body into target, using decoder chosen by
+// response Content-Type. See CodecRegistry for supported media types.
+//
+// target should be a non-nil pointer to a value of type expected by
+// decoder, e.g. pointer to struct for JSON and XML.
+//
+// Example:
+//
+//	var user User
+//	resp := NewResponse(t, response)
+//	resp.Decode(&user)
+func (r *Response) Decode(target interface{}) *Response {
+	opChain := r.chain.enter("Decode()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if target == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil target argument"),
+			},
+		})
+		return r
+	}
+
+	if v := reflect.ValueOf(target); v.Kind() != reflect.Ptr || v.IsNil() {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected %T target argument, expected non-nil pointer",
+					target),
+			},
+		})
+		return r
+	}
+
+	contentType := r.httpResp.Header.Get("Content-Type")
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{contentType},
+			Errors: []error{
+				errors.New(`expected: valid "Content-Type" header`),
+			},
+		})
+		return r
+	}
+
+	decoder, ok := r.config.Codecs.lookup(mediaType, r.config.JSONDecoder)
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{mediaType},
+			Errors: []error{
+				errors.New(`expected: "Content-Type" header with registered decoder`),
+				fmt.Errorf("no decoder for media type %q, see Config.Codecs", mediaType),
+			},
+		})
+		return r
+	}
+
+	content, ok := r.getContent(opChain, "Decode()")
+	if !ok {
+		return r
+	}
+
+	if err := decoder.Decode(content, target); err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{string(content)},
+			Errors: []error{
+				fmt.Errorf("failed to decode %s body", mediaType),
+				err,
+			},
+		})
+		return r
+	}
+
+	return r
+}
+
+// WithCodec registers decoder for given media type for response to this
+// request, overriding Config.Codecs. See CodecRegistry.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "/users/1")
+//	req.WithCodec("application/x-protobuf", protoDecoder)
+//	req.Expect().Decode(&user)
+func (r *Request) WithCodec(mediaType string, decoder BodyDecoder) *Request {
+	opChain := r.chain.enter("WithCodec()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithCodec()") {
+		return r
+	}
+
+	if mediaType == "" {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected empty media type"),
+			},
+		})
+		return r
+	}
+
+	if decoder == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil argument"),
+			},
+		})
+		return r
+	}
+
+	// clone registry to keep Config.Codecs shared by other requests intact
+	r.config.Codecs = r.config.Codecs.Clone().Register(mediaType, decoder)
+
+	return r
+}
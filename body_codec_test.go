@@ -0,0 +1,205 @@
+package httpexpect
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type codecTestUser struct {
+	Name string `json:"name" xml:"name"`
+	Age  int    `json:"age" xml:"age"`
+}
+
+func TestBodyCodec_Decode(t *testing.T) {
+	customDecoder := BodyDecoderFunc(func(data []byte, target interface{}) error {
+		parts := strings.Split(string(data), ",")
+		if len(parts) != 2 {
+			return errors.New("bad format")
+		}
+		target.(*codecTestUser).Name = parts[0]
+		target.(*codecTestUser).Age = len(parts[1])
+		return nil
+	})
+
+	cases := []struct {
+		name        string
+		contentType string
+		body        string
+		codecs      *CodecRegistry
+		result      chainResult
+		expected    codecTestUser
+	}{
+		{
+			name:        "json",
+			contentType: "application/json; charset=utf-8",
+			body:        `{"name":"john","age":30}`,
+			result:      success,
+			expected:    codecTestUser{"john", 30},
+		},
+		{
+			name:        "json suffix",
+			contentType: "application/vnd.api+json",
+			body:        `{"name":"john","age":30}`,
+			result:      success,
+			expected:    codecTestUser{"john", 30},
+		},
+		{
+			name:        "xml",
+			contentType: "application/xml",
+			body:        `<user><name>john</name><age>30</age></user>`,
+			result:      success,
+			expected:    codecTestUser{"john", 30},
+		},
+		{
+			name:        "xml suffix",
+			contentType: "application/atom+xml",
+			body:        `<user><name>john</name><age>30</age></user>`,
+			result:      success,
+			expected:    codecTestUser{"john", 30},
+		},
+		{
+			name:        "registered",
+			contentType: "application/X-Custom",
+			body:        "john,xxx",
+			codecs:      NewCodecRegistry().Register("application/x-custom", customDecoder),
+			result:      success,
+			expected:    codecTestUser{"john", 3},
+		},
+		{
+			name:        "registered suffix",
+			contentType: "application/vnd.user+custom",
+			body:        "john,xxx",
+			codecs:      NewCodecRegistry().Register("+custom", customDecoder),
+			result:      success,
+			expected:    codecTestUser{"john", 3},
+		},
+		{
+			name:        "registered overrides builtin",
+			contentType: "application/json",
+			body:        "john,xxx",
+			codecs:      NewCodecRegistry().Register("application/json", customDecoder),
+			result:      success,
+			expected:    codecTestUser{"john", 3},
+		},
+		{
+			name:        "decoding error",
+			contentType: "application/json",
+			body:        `{"name":`,
+			result:      failure,
+		},
+		{
+			name:        "unknown media type",
+			contentType: "application/x-protobuf",
+			body:        "\x0a\x04john",
+			result:      failure,
+		},
+		{
+			name:        "missing content type",
+			contentType: "",
+			body:        `{"name":"john"}`,
+			result:      failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := NewResponseC(Config{
+				Reporter: newMockReporter(t),
+				Codecs:   tc.codecs,
+			}, &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": {tc.contentType}},
+				Body:       newMockBody(tc.body),
+			})
+
+			var user codecTestUser
+
+			resp.Decode(&user)
+			resp.chain.assert(t, tc.result)
+
+			assert.Equal(t, tc.expected, user)
+		})
+	}
+
+	t.Run("invalid target", func(t *testing.T) {
+		for _, target := range []interface{}{nil, codecTestUser{}, (*codecTestUser)(nil)} {
+			resp := NewResponse(newMockReporter(t), &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body:       newMockBody(`{}`),
+			})
+
+			resp.Decode(target)
+			resp.chain.assert(t, failure)
+		}
+	})
+}
+
+func TestBodyCodec_Registry(t *testing.T) {
+	decoder := BodyDecoderFunc(func([]byte, interface{}) error { return nil })
+
+	t.Run("clone", func(t *testing.T) {
+		reg := NewCodecRegistry().Register("application/x-a", decoder)
+		clone := reg.Clone().Register("application/x-b", decoder)
+
+		_, ok := reg.lookup("application/x-b", nil)
+		assert.False(t, ok)
+
+		_, ok = clone.lookup("application/x-a", nil)
+		assert.True(t, ok)
+
+		var nilReg *CodecRegistry
+		assert.NotNil(t, nilReg.Clone())
+	})
+
+	t.Run("panics", func(t *testing.T) {
+		assert.Panics(t, func() {
+			NewCodecRegistry().Register("", decoder)
+		})
+		assert.Panics(t, func() {
+			NewCodecRegistry().Register("application/x-a", nil)
+		})
+	})
+
+	t.Run("request override", func(t *testing.T) {
+		codecs := NewCodecRegistry()
+
+		client := ClientFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": {"application/x-custom"}},
+				Body:       newMockBody("john"),
+			}, nil
+		})
+
+		e := WithConfig(Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+			Codecs:   codecs,
+		})
+
+		var user codecTestUser
+
+		e.GET("/").
+			WithCodec("application/x-custom", BodyDecoderFunc(
+				func(data []byte, target interface{}) error {
+					target.(*codecTestUser).Name = string(data)
+					return nil
+				})).
+			Expect().
+			Decode(&user).
+			chain.assert(t, success)
+
+		assert.Equal(t, "john", user.Name)
+
+		// config registry is not modified
+		_, ok := codecs.lookup("application/x-custom", nil)
+		assert.False(t, ok)
+
+		e.GET("/").Expect().Decode(&user).chain.assert(t, failure)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"io"
+	"os"
 	"runtime"
 	"sync"
 )
@@ -25,10 +26,21 @@ import (
 // If Rewind, GetBody, or Close is invoked before the body is fully read first time,
 // bodyWrapper automatically performs full read.
 //
+// Peek reads only as much as requested and doesn't advance reading position, so
+// that subsequent Read returns peeked content again.
+//
 // At any moment, the user can call DisableRewinds. In this case, Rewind and GetBody
 // functionality is disabled, memory cache is cleared, and bodyWrapper switches to
 // reading original body (if it's not fully read yet).
 //
+// If EnableSpooling is called, content that exceeds given threshold is stored in
+// a temporary file instead of memory. The file is removed when the cache is cleared
+// or when bodyWrapper is garbage collected.
+//
+// If SetLimit is called, reading more than given number of bytes from original
+// body fails with errBodyTooLarge, so that neither Read nor GetBody, Rewind, and
+// Close can buffer more than the limit.
+//
 // bodyWrapper automatically creates finalizer that will close original body if the
 // user never reads it fully or calls Closes.
 type bodyWrapper struct {
@@ -42,13 +54,21 @@ type bodyWrapper struct {
 	// If set, called after HTTP response is fully read into memory.
 	httpCancelFunc context.CancelFunc
 
-	// Reader for HTTP response body stored in memory.
+	// Reader for HTTP response body stored in memory or spool file.
 	// Rewind() resets this reader to start from the beginning.
-	memReader *bytes.Reader
+	// Until HTTP response is fully read, holds content stored by Peek()
+	// and not yet returned from Read().
+	memReader *io.SectionReader
 
 	// HTTP response body stored in memory.
 	memBytes []byte
 
+	// HTTP response body stored in temporary file, used instead of memBytes
+	// when body size exceeds spoolThreshold.
+	spool          *bodySpool
+	spoolSize      int64
+	spoolThreshold int64
+
 	// Cached read and close errors.
 	readErr  error
 	closeErr error
@@ -68,6 +88,7 @@ func newBodyWrapper(reader io.ReadCloser, cancelFunc context.CancelFunc) *bodyWr
 	bw := &bodyWrapper{
 		httpReader:     reader,
 		httpCancelFunc: cancelFunc,
+		memReader:      emptySectionReader(),
 	}
 
 	// Finalizer will close body if closeAndCancel was never called.
@@ -83,7 +104,10 @@ func (bw *bodyWrapper) Read(p []byte) (int, error) {
 
 	bw.isReadBefore = true
 
-	if bw.isRewindDisabled && !bw.isFullyRead {
+	if !bw.isFullyRead && sectionRemaining(bw.memReader) != 0 {
+		// Read from memory what was stored by Peek.
+		return bw.peekReadNext(p)
+	} else if bw.isRewindDisabled && !bw.isFullyRead {
 		// Regular read from original HTTP response.
 		return bw.httpReader.Read(p)
 	} else if !bw.isFullyRead {
@@ -120,11 +144,11 @@ func (bw *bodyWrapper) Close() error {
 	}
 
 	// Reset memory reader.
-	bw.memReader = bytes.NewReader(nil)
+	bw.memReader = emptySectionReader()
 
 	// Free memory when rewind is disabled.
 	if bw.isRewindDisabled {
-		bw.memBytes = nil
+		bw.freeStore()
 	}
 
 	return err
@@ -152,7 +176,7 @@ func (bw *bodyWrapper) Rewind() {
 	}
 
 	// Reset memory reader.
-	bw.memReader = bytes.NewReader(bw.memBytes)
+	bw.memReader = bw.storeReader(0)
 }
 
 // Create new reader to retrieve body contents.
@@ -182,7 +206,115 @@ func (bw *bodyWrapper) GetBody() (io.ReadCloser, error) {
 	}
 
 	// Return fresh reader for memory chunk.
-	return io.NopCloser(bytes.NewReader(bw.memBytes)), nil
+	return io.NopCloser(bw.storeReader(0)), nil
+}
+
+// Get whole body contents.
+// If HTTP response is not fully read yet, reads it first.
+// Returned slice shares memory with bodyWrapper and should not be modified.
+func (bw *bodyWrapper) Bytes() ([]byte, error) {
+	bw.mu.Lock()
+	defer bw.mu.Unlock()
+
+	bw.isReadBefore = true
+
+	// Preserve original reader error.
+	if bw.readErr != nil {
+		return nil, bw.readErr
+	}
+
+	// Bytes() requires rewinds to be enabled.
+	if bw.isRewindDisabled {
+		return nil, errors.New("rewinds are disabled, cannot get bytes")
+	}
+
+	// If HTTP response is not fully read yet, do it now.
+	if !bw.isFullyRead {
+		if err := bw.httpReadFull(); err != nil {
+			return nil, err
+		}
+	}
+
+	if bw.spool != nil {
+		b := make([]byte, bw.spoolSize)
+		if _, err := bw.spool.ReadAt(b, 0); err != nil && err != io.EOF {
+			return nil, err
+		}
+		return b, nil
+	}
+
+	if bw.memBytes == nil {
+		return []byte{}, nil
+	}
+
+	return bw.memBytes, nil
+}
+
+// Get first n bytes of body contents, or whole body if it's shorter.
+// If HTTP response is not fully read yet, reads only as much as needed.
+// Doesn't advance reading position.
+func (bw *bodyWrapper) Peek(n int64) ([]byte, error) {
+	bw.mu.Lock()
+	defer bw.mu.Unlock()
+
+	// Peek() requires rewinds to be enabled.
+	if bw.isRewindDisabled {
+		return nil, errors.New("rewinds are disabled, cannot peek body")
+	}
+
+	if !bw.isFullyRead {
+		// Position of next Read.
+		offset := bw.storeSize() - sectionRemaining(bw.memReader)
+
+		if size := bw.storeSize(); size < n {
+			_, err := io.CopyN(storeWriter{bw}, bw.httpReader, n-size)
+
+			if err != nil {
+				if err != io.EOF {
+					bw.readErr = err
+				}
+				_ = bw.closeAndCancel()
+
+				bw.isFullyRead = true
+			}
+		}
+
+		// Next Read will return stored content starting from the same position.
+		bw.memReader = bw.storeReader(offset)
+	}
+
+	if bw.readErr != nil {
+		return nil, bw.readErr
+	}
+
+	if size := bw.storeSize(); size < n {
+		n = size
+	}
+
+	b := make([]byte, n)
+	if _, err := bw.storeReader(0).ReadAt(b, 0); err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// Store contents exceeding threshold in temporary file instead of memory.
+// Should be called before reading.
+func (bw *bodyWrapper) EnableSpooling(threshold int64) {
+	bw.mu.Lock()
+	defer bw.mu.Unlock()
+
+	bw.spoolThreshold = threshold
+}
+
+// Fail reading original body if it is larger than limit.
+// Should be called before reading.
+func (bw *bodyWrapper) SetLimit(limit int64) {
+	bw.mu.Lock()
+	defer bw.mu.Unlock()
+
+	bw.httpReader = &limitedReader{reader: bw.httpReader, remaining: limit}
 }
 
 // Disables storing body contents in memory and clears the cache.
@@ -190,13 +322,11 @@ func (bw *bodyWrapper) DisableRewinds() {
 	bw.mu.Lock()
 	defer bw.mu.Unlock()
 
-	// Free memory if reading from original HTTP response, or reading from memory
-	// and memory reader has nothing left to read.
+	// Free memory if memory reader has nothing left to read.
 	// Otherwise, i.e. when we're reading from memory, and there is more to read,
-	// memReadNext() will free memory later when it hits EOF.
-	if !bw.isFullyRead || bw.memReader.Len() == 0 {
-		bw.memReader = bytes.NewReader(nil)
-		bw.memBytes = nil
+	// memReadNext() or peekReadNext() will free memory later when it hits EOF.
+	if sectionRemaining(bw.memReader) == 0 {
+		bw.freeStore()
 	}
 
 	bw.isRewindDisabled = true
@@ -209,8 +339,7 @@ func (bw *bodyWrapper) memReadNext(p []byte) (int, error) {
 		// Free memory after we hit EOF when reading from memory,
 		// if rewinds were disabled while we were reading from it.
 		if bw.isRewindDisabled {
-			bw.memReader = bytes.NewReader(nil)
-			bw.memBytes = nil
+			bw.freeStore()
 		}
 		if bw.readErr != nil {
 			err = bw.readErr
@@ -220,11 +349,30 @@ func (bw *bodyWrapper) memReadNext(p []byte) (int, error) {
 	return n, err
 }
 
+func (bw *bodyWrapper) peekReadNext(p []byte) (int, error) {
+	n, err := bw.memReader.Read(p)
+
+	// There is more to read from original HTTP response.
+	if err == io.EOF {
+		err = nil
+	}
+
+	// Free memory after we read everything stored by Peek,
+	// if rewinds were disabled while we were reading from it.
+	if bw.isRewindDisabled && sectionRemaining(bw.memReader) == 0 {
+		bw.freeStore()
+	}
+
+	return n, err
+}
+
 func (bw *bodyWrapper) httpReadNext(p []byte) (int, error) {
 	n, err := bw.httpReader.Read(p)
 
 	if n > 0 {
-		bw.memBytes = append(bw.memBytes, p[:n]...)
+		if storeErr := bw.store(p[:n]); storeErr != nil {
+			err = storeErr
+		}
 	}
 
 	if err != nil {
@@ -237,19 +385,29 @@ func (bw *bodyWrapper) httpReadNext(p []byte) (int, error) {
 
 		// Switch to reading from memory.
 		bw.isFullyRead = true
-		bw.memReader = bytes.NewReader(nil)
+		bw.memReader = emptySectionReader()
 	}
 
 	return n, err
 }
 
 func (bw *bodyWrapper) httpReadFull() error {
-	b, err := io.ReadAll(bw.httpReader)
+	// Continue from position of next Read, which may be before the end
+	// of stored content if Peek was used.
+	offset := bw.storeSize() - sectionRemaining(bw.memReader)
+
+	var err error
+	if bw.spoolThreshold > 0 {
+		_, err = io.Copy(storeWriter{bw}, bw.httpReader)
+	} else {
+		var b []byte
+		b, err = io.ReadAll(bw.httpReader)
+		bw.memBytes = append(bw.memBytes, b...)
+	}
 
 	// Switch to reading from memory.
 	bw.isFullyRead = true
-	bw.memBytes = append(bw.memBytes, b...)
-	bw.memReader = bytes.NewReader(bw.memBytes[len(bw.memBytes)-len(b):])
+	bw.memReader = bw.storeReader(offset)
 
 	if err != nil {
 		bw.readErr = err
@@ -290,3 +448,149 @@ func (bw *bodyWrapper) closeAndCancel() error {
 
 	return bw.closeErr
 }
+
+// Append contents to memory or spool file.
+// Switches to spool file when memory contents exceed spoolThreshold.
+func (bw *bodyWrapper) store(p []byte) error {
+	if bw.spool != nil {
+		n, err := bw.spool.file.WriteAt(p, bw.spoolSize)
+		bw.spoolSize += int64(n)
+		return err
+	}
+
+	bw.memBytes = append(bw.memBytes, p...)
+
+	if bw.spoolThreshold > 0 && int64(len(bw.memBytes)) > bw.spoolThreshold {
+		spool, err := newBodySpool()
+		if err != nil {
+			// Keep contents in memory.
+			return nil
+		}
+
+		n, err := spool.file.WriteAt(bw.memBytes, 0)
+		if err != nil {
+			spool.remove()
+			return nil
+		}
+
+		bw.spool = spool
+		bw.spoolSize = int64(n)
+		bw.memBytes = nil
+	}
+
+	return nil
+}
+
+func (bw *bodyWrapper) storeSize() int64 {
+	if bw.spool != nil {
+		return bw.spoolSize
+	}
+	return int64(len(bw.memBytes))
+}
+
+// Create reader for stored contents, starting from given offset.
+func (bw *bodyWrapper) storeReader(offset int64) *io.SectionReader {
+	if bw.spool != nil {
+		return io.NewSectionReader(bw.spool, offset, bw.spoolSize-offset)
+	}
+	return io.NewSectionReader(
+		bytes.NewReader(bw.memBytes), offset, int64(len(bw.memBytes))-offset)
+}
+
+// Free memory and remove spool file.
+func (bw *bodyWrapper) freeStore() {
+	bw.memReader = emptySectionReader()
+	bw.memBytes = nil
+
+	if bw.spool != nil {
+		bw.spool.remove()
+		bw.spool = nil
+		bw.spoolSize = 0
+	}
+}
+
+func sectionRemaining(r *io.SectionReader) int64 {
+	pos, _ := r.Seek(0, io.SeekCurrent)
+	return r.Size() - pos
+}
+
+func emptySectionReader() *io.SectionReader {
+	return io.NewSectionReader(bytes.NewReader(nil), 0, 0)
+}
+
+type storeWriter struct {
+	bw *bodyWrapper
+}
+
+func (w storeWriter) Write(p []byte) (int, error) {
+	if err := w.bw.store(p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+var errBodyTooLarge = errors.New("body is too large")
+
+// Like io.LimitReader, but reports error instead of EOF when limit
+// is exceeded, and forwards Close to underlying reader.
+type limitedReader struct {
+	reader    io.ReadCloser
+	remaining int64
+}
+
+func (lr *limitedReader) Read(p []byte) (int, error) {
+	if lr.remaining <= 0 {
+		// check whether there is anything after the limit
+		var b [1]byte
+		n, err := lr.reader.Read(b[:])
+		if n > 0 {
+			return 0, errBodyTooLarge
+		}
+		return 0, err
+	}
+
+	if int64(len(p)) > lr.remaining {
+		p = p[:lr.remaining]
+	}
+
+	n, err := lr.reader.Read(p)
+	lr.remaining -= int64(n)
+
+	return n, err
+}
+
+func (lr *limitedReader) Close() error {
+	return lr.reader.Close()
+}
+
+// Temporary file holding spooled body.
+type bodySpool struct {
+	file *os.File
+}
+
+func newBodySpool() (*bodySpool, error) {
+	file, err := os.CreateTemp("", "httpexpect-body-*")
+	if err != nil {
+		return nil, err
+	}
+
+	spool := &bodySpool{file: file}
+
+	// Finalizer will remove file if remove was never called.
+	runtime.SetFinalizer(spool, (*bodySpool).remove)
+
+	return spool, nil
+}
+
+// Readers reference bodySpool instead of file, so that it is not
+// finalized while they are in use.
+func (s *bodySpool) ReadAt(p []byte, off int64) (int, error) {
+	return s.file.ReadAt(p, off)
+}
+
+func (s *bodySpool) remove() {
+	runtime.SetFinalizer(s, nil)
+
+	_ = s.file.Close()
+	_ = os.Remove(s.file.Name())
+}
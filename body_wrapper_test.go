@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"io"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -1456,3 +1457,292 @@ func TestBodyWrapper_DisableRewindsErrors(t *testing.T) {
 		assert.Equal(t, 2, body.readCount)
 	})
 }
+
+func TestBodyWrapper_Bytes(t *testing.T) {
+	t.Run("before read", func(t *testing.T) {
+		body := newMockBody("test_body")
+		wrp := newBodyWrapper(body, nil)
+
+		b, err := wrp.Bytes()
+		assert.NoError(t, err)
+		assert.Equal(t, "test_body", string(b))
+		assert.Equal(t, 1, body.closeCount)
+
+		// shares memory with wrapper
+		assert.Same(t, &wrp.memBytes[0], &b[0])
+
+		// can still read
+		b, err = io.ReadAll(wrp)
+		assert.NoError(t, err)
+		assert.Equal(t, "test_body", string(b))
+	})
+
+	t.Run("empty body", func(t *testing.T) {
+		wrp := newBodyWrapper(newMockBody(""), nil)
+
+		b, err := wrp.Bytes()
+		assert.NoError(t, err)
+		assert.NotNil(t, b)
+		assert.Equal(t, 0, len(b))
+	})
+
+	t.Run("read error", func(t *testing.T) {
+		body := newMockBody("test_body")
+		body.readErr = errors.New("read_error")
+
+		wrp := newBodyWrapper(body, nil)
+
+		_, err := wrp.Bytes()
+		assert.Error(t, err)
+	})
+
+	t.Run("rewinds disabled", func(t *testing.T) {
+		wrp := newBodyWrapper(newMockBody("test_body"), nil)
+		wrp.DisableRewinds()
+
+		_, err := wrp.Bytes()
+		assert.Error(t, err)
+	})
+}
+
+func TestBodyWrapper_Peek(t *testing.T) {
+	t.Run("before read", func(t *testing.T) {
+		body := newMockBody("test_body")
+		wrp := newBodyWrapper(body, nil)
+
+		b, err := wrp.Peek(4)
+		assert.NoError(t, err)
+		assert.Equal(t, "test", string(b))
+
+		// body is not fully read
+		assert.Equal(t, 0, body.closeCount)
+
+		b, err = wrp.Peek(6)
+		assert.NoError(t, err)
+		assert.Equal(t, "test_b", string(b))
+
+		// can still read from the beginning
+		b, err = io.ReadAll(wrp)
+		assert.NoError(t, err)
+		assert.Equal(t, "test_body", string(b))
+		assert.Equal(t, 1, body.closeCount)
+	})
+
+	t.Run("after read", func(t *testing.T) {
+		wrp := newBodyWrapper(newMockBody("test_body"), nil)
+
+		b := make([]byte, 2)
+		n, err := wrp.Read(b)
+		assert.NoError(t, err)
+		assert.Equal(t, "te", string(b[:n]))
+
+		b, err = wrp.Peek(4)
+		assert.NoError(t, err)
+		assert.Equal(t, "test", string(b))
+
+		// reading continues from the same position
+		b, err = io.ReadAll(wrp)
+		assert.NoError(t, err)
+		assert.Equal(t, "st_body", string(b))
+
+		wrp.Rewind()
+
+		b, err = io.ReadAll(wrp)
+		assert.NoError(t, err)
+		assert.Equal(t, "test_body", string(b))
+	})
+
+	t.Run("whole body", func(t *testing.T) {
+		body := newMockBody("test_body")
+		wrp := newBodyWrapper(body, nil)
+
+		b, err := wrp.Peek(100)
+		assert.NoError(t, err)
+		assert.Equal(t, "test_body", string(b))
+		assert.Equal(t, 1, body.closeCount)
+
+		b, err = io.ReadAll(wrp)
+		assert.NoError(t, err)
+		assert.Equal(t, "test_body", string(b))
+	})
+
+	t.Run("then disable rewinds", func(t *testing.T) {
+		wrp := newBodyWrapper(newMockBody("test_body"), nil)
+
+		b, err := wrp.Peek(4)
+		assert.NoError(t, err)
+		assert.Equal(t, "test", string(b))
+
+		wrp.DisableRewinds()
+
+		b, err = io.ReadAll(wrp)
+		assert.NoError(t, err)
+		assert.Equal(t, "test_body", string(b))
+
+		assert.Nil(t, wrp.memBytes)
+	})
+
+	t.Run("read error", func(t *testing.T) {
+		body := newMockBody("test_body")
+		body.readErr = errors.New("read_error")
+
+		wrp := newBodyWrapper(body, nil)
+
+		_, err := wrp.Peek(4)
+		assert.Error(t, err)
+	})
+
+	t.Run("rewinds disabled", func(t *testing.T) {
+		wrp := newBodyWrapper(newMockBody("test_body"), nil)
+		wrp.DisableRewinds()
+
+		_, err := wrp.Peek(4)
+		assert.Error(t, err)
+	})
+}
+
+func TestBodyWrapper_Limit(t *testing.T) {
+	t.Run("below limit", func(t *testing.T) {
+		wrp := newBodyWrapper(newMockBody("test_body"), nil)
+		wrp.SetLimit(9)
+
+		b, err := io.ReadAll(wrp)
+		assert.NoError(t, err)
+		assert.Equal(t, "test_body", string(b))
+
+		b, err = wrp.Bytes()
+		assert.NoError(t, err)
+		assert.Equal(t, "test_body", string(b))
+	})
+
+	t.Run("read", func(t *testing.T) {
+		body := newMockBody("test_body")
+		wrp := newBodyWrapper(body, nil)
+		wrp.SetLimit(4)
+
+		b, err := io.ReadAll(wrp)
+		assert.True(t, errors.Is(err, errBodyTooLarge))
+		assert.Equal(t, "test", string(b))
+		assert.Equal(t, 1, body.closeCount)
+	})
+
+	t.Run("get body", func(t *testing.T) {
+		body := newMockBody("test_body")
+		wrp := newBodyWrapper(body, nil)
+		wrp.SetLimit(4)
+
+		_, err := wrp.GetBody()
+		assert.True(t, errors.Is(err, errBodyTooLarge))
+		assert.Equal(t, 4, len(wrp.memBytes))
+		assert.Equal(t, 1, body.closeCount)
+
+		_, err = wrp.Bytes()
+		assert.True(t, errors.Is(err, errBodyTooLarge))
+	})
+
+	t.Run("rewind", func(t *testing.T) {
+		wrp := newBodyWrapper(newMockBody("test_body"), nil)
+		wrp.SetLimit(4)
+
+		b := make([]byte, 2)
+		_, err := wrp.Read(b)
+		assert.NoError(t, err)
+
+		wrp.Rewind()
+		assert.Equal(t, 4, len(wrp.memBytes))
+
+		_, err = wrp.Bytes()
+		assert.True(t, errors.Is(err, errBodyTooLarge))
+	})
+
+	t.Run("rewinds disabled", func(t *testing.T) {
+		wrp := newBodyWrapper(newMockBody("test_body"), nil)
+		wrp.SetLimit(4)
+		wrp.DisableRewinds()
+
+		b, err := io.ReadAll(wrp)
+		assert.True(t, errors.Is(err, errBodyTooLarge))
+		assert.Equal(t, "test", string(b))
+	})
+}
+
+func TestBodyWrapper_Spooling(t *testing.T) {
+	bodyText := "0123456789abcdef"
+
+	t.Run("below threshold", func(t *testing.T) {
+		wrp := newBodyWrapper(newMockBody(bodyText), nil)
+		wrp.EnableSpooling(int64(len(bodyText)))
+
+		b, err := io.ReadAll(wrp)
+		assert.NoError(t, err)
+		assert.Equal(t, bodyText, string(b))
+
+		assert.Nil(t, wrp.spool)
+		assert.Equal(t, bodyText, string(wrp.memBytes))
+	})
+
+	t.Run("read", func(t *testing.T) {
+		wrp := newBodyWrapper(newMockBody(bodyText), nil)
+		wrp.EnableSpooling(4)
+
+		p := make([]byte, 3)
+		var b []byte
+		for {
+			n, err := wrp.Read(p)
+			b = append(b, p[:n]...)
+			if err == io.EOF {
+				break
+			}
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, bodyText, string(b))
+
+		assert.NotNil(t, wrp.spool)
+		assert.Nil(t, wrp.memBytes)
+		assert.Equal(t, int64(len(bodyText)), wrp.spoolSize)
+
+		wrp.Rewind()
+
+		b, err := io.ReadAll(wrp)
+		assert.NoError(t, err)
+		assert.Equal(t, bodyText, string(b))
+	})
+
+	t.Run("close, get body, bytes", func(t *testing.T) {
+		wrp := newBodyWrapper(newMockBody(bodyText), nil)
+		wrp.EnableSpooling(4)
+
+		assert.NoError(t, wrp.Close())
+		assert.NotNil(t, wrp.spool)
+
+		reader, err := wrp.GetBody()
+		assert.NoError(t, err)
+
+		b, err := io.ReadAll(reader)
+		assert.NoError(t, err)
+		assert.Equal(t, bodyText, string(b))
+
+		b, err = wrp.Bytes()
+		assert.NoError(t, err)
+		assert.Equal(t, bodyText, string(b))
+	})
+
+	t.Run("disable rewinds", func(t *testing.T) {
+		wrp := newBodyWrapper(newMockBody(bodyText), nil)
+		wrp.EnableSpooling(4)
+
+		assert.NoError(t, wrp.Close())
+
+		name := wrp.spool.file.Name()
+
+		_, err := os.Stat(name)
+		assert.NoError(t, err)
+
+		wrp.DisableRewinds()
+
+		assert.Nil(t, wrp.spool)
+
+		_, err = os.Stat(name)
+		assert.True(t, os.IsNotExist(err))
+	})
+}
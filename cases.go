@@ -0,0 +1,184 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// TestCase describes a single case of table-driven test run by
+// Expect.RunCases.
+//
+// Only Path is required. Expectations with zero values are not checked.
+type TestCase struct {
+	// Name of the subtest.
+	// If empty, "METHOD path" is used, e.g. "GET /users/{id}".
+	Name string
+
+	// HTTP method.
+	// If empty, "GET" is used.
+	Method string
+
+	// Request path and optional path arguments, the same as passed
+	// to Expect.Request.
+	Path     string
+	PathArgs []interface{}
+
+	// Request mutators, invoked in order after constructing request.
+	// Can be used to set headers, query parameters, body, etc.
+	Request []func(req *Request)
+
+	// Expected status code.
+	// If zero, status is not checked.
+	Status int
+
+	// Expected subset of JSON object body (see Object.ContainsSubset).
+	// If nil, body is not checked.
+	Body interface{}
+
+	// Expected JSON Schema of body (see Value.Schema).
+	// If nil, schema is not checked.
+	Schema interface{}
+
+	// Additional checks of response.
+	// If nil, no additional checks are made.
+	Check func(resp *Response)
+}
+
+// RunCases runs every test case as a subtest of t.
+//
+// For every case, RunCases builds a request, applies request mutators,
+// sends request, and checks status, body, and schema of the response.
+// Builders and matchers attached to Expect are applied as usual.
+//
+// Cases are run sequentially, in the order in which they are given.
+// Failures of a case are reported to its subtest. If Config.AssertionHandler
+// is DefaultAssertionHandler (which is the case when Config.Reporter is
+// used), it is replaced with a copy that reports to the subtest using
+// AssertReporter. Other assertion handlers are used as is.
+//
+// Example:
+//
+//	e := httpexpect.Default(t, "http://example.com")
+//
+//	e.RunCases(t, []httpexpect.TestCase{
+//		{
+//			Name:     "get user",
+//			Path:     "/users/{id}",
+//			PathArgs: []interface{}{1},
+//			Status:   http.StatusOK,
+//			Body:     map[string]interface{}{"id": 1},
+//		},
+//		{
+//			Name:   "create user",
+//			Method: "POST",
+//			Path:   "/users",
+//			Request: []func(req *httpexpect.Request){
+//				func(req *httpexpect.Request) {
+//					req.WithJSON(map[string]interface{}{"name": "john"})
+//				},
+//			},
+//			Status: http.StatusCreated,
+//		},
+//		{
+//			Name:     "unknown user",
+//			Path:     "/users/{id}",
+//			PathArgs: []interface{}{999},
+//			Status:   http.StatusNotFound,
+//		},
+//	})
+func (e *Expect) RunCases(t *testing.T, cases []TestCase) {
+	opChain := e.chain.enter("RunCases()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return
+	}
+
+	if t == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil testing.T"),
+			},
+		})
+		return
+	}
+
+	for i, tc := range cases {
+		for _, fn := range tc.Request {
+			if fn == nil {
+				opChain.fail(AssertionFailure{
+					Type: AssertUsage,
+					Errors: []error{
+						fmt.Errorf("unexpected nil request mutator in case %d", i),
+					},
+				})
+				return
+			}
+		}
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(testCaseName(tc), func(t *testing.T) {
+			e.runCase(opChain, t, tc)
+		})
+	}
+}
+
+func (e *Expect) runCase(opChain *chain, t *testing.T, tc TestCase) {
+	caseChain := opChain.clone()
+
+	if h, ok := e.config.AssertionHandler.(*DefaultAssertionHandler); ok {
+		handler := *h
+		handler.Reporter = NewAssertReporter(t)
+		caseChain.setHandler(&handler)
+	}
+
+	caseExpect := e.withChain(caseChain)
+
+	method := tc.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	req := caseExpect.Request(method, tc.Path, tc.PathArgs...)
+
+	for _, fn := range tc.Request {
+		fn(req)
+	}
+
+	resp := req.Expect()
+
+	if tc.Status != 0 {
+		resp.Status(tc.Status)
+	}
+
+	if tc.Body != nil {
+		resp.JSON().Object().ContainsSubset(tc.Body)
+	}
+
+	if tc.Schema != nil {
+		resp.JSON().Schema(tc.Schema)
+	}
+
+	if tc.Check != nil {
+		tc.Check(resp)
+	}
+}
+
+func testCaseName(tc TestCase) string {
+	if tc.Name != "" {
+		return tc.Name
+	}
+
+	method := tc.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	return method + " " + tc.Path
+}
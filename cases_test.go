@@ -0,0 +1,151 @@
+package httpexpect
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCases_Run(t *testing.T) {
+	client := ClientFunc(func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case "/users/1":
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body: io.NopCloser(bytes.NewBufferString(
+					`{"id":1,"name":"john","admin":false}`)),
+			}, nil
+		case "/users":
+			assert.Equal(t, "secret", req.Header.Get("X-Token"))
+			return &http.Response{
+				StatusCode: http.StatusCreated,
+				Body:       http.NoBody,
+			}, nil
+		default:
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Body:       http.NoBody,
+			}, nil
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		e := WithConfig(Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		})
+
+		checked := false
+
+		e.RunCases(t, []TestCase{
+			{
+				Name:     "get user",
+				Path:     "/users/{id}",
+				PathArgs: []interface{}{1},
+				Status:   http.StatusOK,
+				Body:     map[string]interface{}{"name": "john"},
+				Schema:   `{"type": "object", "required": ["id"]}`,
+				Check: func(resp *Response) {
+					checked = true
+				},
+			},
+			{
+				Method: "POST",
+				Path:   "/users",
+				Request: []func(req *Request){
+					func(req *Request) {
+						req.WithHeader("X-Token", "secret")
+					},
+				},
+				Status: http.StatusCreated,
+			},
+			{
+				Path:   "/missing",
+				Status: http.StatusNotFound,
+			},
+		})
+
+		assert.True(t, checked)
+		e.chain.assert(t, success)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		e := WithConfig(Config{
+			Client:           client,
+			AssertionHandler: handler,
+		})
+
+		var ran []string
+
+		e.RunCases(t, []TestCase{
+			{
+				Name:   "wrong status",
+				Path:   "/missing",
+				Status: http.StatusOK,
+			},
+			{
+				Name: "wrong body",
+				Path: "/users/1",
+				Body: map[string]interface{}{"name": "bob"},
+			},
+			{
+				Name: "check",
+				Path: "/users/1",
+				Check: func(resp *Response) {
+					ran = append(ran, "check")
+				},
+			},
+		})
+
+		assert.Equal(t, 2, handler.failureCalled)
+		assert.Equal(t, []string{"check"}, ran)
+	})
+
+	t.Run("names", func(t *testing.T) {
+		assert.Equal(t, "foo",
+			testCaseName(TestCase{Name: "foo", Path: "/bar"}))
+		assert.Equal(t, "GET /bar",
+			testCaseName(TestCase{Path: "/bar"}))
+		assert.Equal(t, "PUT /bar/{id}",
+			testCaseName(TestCase{Method: "PUT", Path: "/bar/{id}"}))
+	})
+}
+
+func TestCases_Usage(t *testing.T) {
+	t.Run("nil testing.T", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		e := WithConfig(Config{
+			Client:   &mockClient{},
+			Reporter: reporter,
+		})
+
+		e.RunCases(nil, []TestCase{{Path: "/"}})
+
+		assert.True(t, reporter.reported)
+	})
+
+	t.Run("nil mutator", func(t *testing.T) {
+		client := &mockClient{}
+		reporter := newMockReporter(t)
+
+		e := WithConfig(Config{
+			Client:   client,
+			Reporter: reporter,
+		})
+
+		e.RunCases(t, []TestCase{
+			{Path: "/a"},
+			{Path: "/b", Request: []func(req *Request){nil}},
+		})
+
+		assert.True(t, reporter.reported)
+		assert.Nil(t, client.req)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -79,6 +80,10 @@ type chain struct {
 	handler  AssertionHandler
 	severity AssertionSeverity
 	failure  *AssertionFailure
+	clock    Clock
+
+	// Name of header which value is checked by this chain.
+	header string
 }
 
 // If enabled, chain will panic if used incorrectly or gets illformed AssertionFailure.
@@ -118,6 +123,7 @@ func newChainWithConfig(name string, config Config) *chain {
 	}
 
 	c.context.TestName = config.TestName
+	c.context.ArtifactsDir = artifactsDir(config)
 
 	if name != "" {
 		c.context.Path = []string{name}
@@ -135,6 +141,12 @@ func newChainWithConfig(name string, config Config) *chain {
 
 	c.context.TestingTB = isTestingTB(c.handler)
 
+	c.context.Redactor = config.Redactor
+
+	c.context.RandSource = config.RandSource
+
+	c.clock = config.Clock
+
 	return c
 }
 
@@ -182,6 +194,21 @@ func (c *chain) env() *Environment {
 	return c.context.Environment
 }
 
+// Get current time.
+// Root chain constructor gets clock from config, or uses time.Now.
+// Child chains inherit clock from parent.
+func (c *chain) now() time.Time {
+	c.mu.Lock()
+	clock := c.clock
+	c.mu.Unlock()
+
+	if clock == nil {
+		return time.Now()
+	}
+
+	return clock.Now()
+}
+
 // Make this chain to be root.
 // Chain's parent field is cleared.
 // Failures wont be propagated to the upper chains anymore.
@@ -196,6 +223,21 @@ func (c *chain) setRoot() {
 	c.parent = nil
 }
 
+// Mark chain as having failed children.
+// Used when children were made root using setRoot(), but their failures
+// should still fail this chain and its parents when it's leaved.
+// Nothing is reported by this chain itself.
+func (c *chain) setFailedChildren() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if chainValidation && c.state == stateLeaved {
+		panic("can't use chain after leave")
+	}
+
+	c.flags |= flagFailedChildren
+}
+
 // Set severity of reported failures.
 // Chain always overrides failure severity with configured one.
 func (c *chain) setSeverity(severity AssertionSeverity) {
@@ -238,6 +280,42 @@ func (c *chain) setRequestName(name string) {
 	c.context.RequestName = name
 }
 
+// Get request name from AssertionContext.
+func (c *chain) requestName() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.context.RequestName
+}
+
+// Join names of nested operations into hierarchical request name.
+func joinRequestName(parent, name string) string {
+	switch {
+	case parent == "":
+		return name
+	case name == "":
+		return parent
+	default:
+		return parent + " / " + name
+	}
+}
+
+// Clear request name, request and response from AssertionContext.
+// Used when a new request is derived from a response, e.g. when
+// following a link, so that the new request gets its own context.
+func (c *chain) clearRequest() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if chainValidation && c.state == stateLeaved {
+		panic("can't use chain after leave")
+	}
+
+	c.context.RequestName = ""
+	c.context.Request = nil
+	c.context.Response = nil
+}
+
 // Store request pointer in AssertionContext.
 // Child chains inherit context from parent.
 func (c *chain) setRequest(req *Request) {
@@ -272,6 +350,21 @@ func (c *chain) setResponse(resp *Response) {
 	c.context.Response = resp
 }
 
+// Store name of header which value is checked.
+// Child chains inherit header from parent.
+// Reported failures are tagged with header name, so that Redactor can
+// hide value of sensitive header.
+func (c *chain) setHeader(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if chainValidation && c.state == stateLeaved {
+		panic("can't use chain after leave")
+	}
+
+	c.header = name
+}
+
 // Set assertion handler
 // Chain always overrides assertion handler with given one.
 func (c *chain) setHandler(handler AssertionHandler) {
@@ -309,6 +402,8 @@ func (c *chain) clone() *chain {
 		context:  contextCopy,
 		handler:  c.handler,
 		severity: c.severity,
+		clock:    c.clock,
+		header:   c.header,
 		// failure is not inherited because it should be reported only once
 		// by the chain where it happened
 		failure: nil,
@@ -323,9 +418,11 @@ func (c *chain) enter(name string, args ...interface{}) *chain {
 
 	chainCopy.state = stateEntered
 	if name != "" {
+		// append to the copies made by clone(), never to the parent's slices,
+		// so that concurrent enter() calls on the same parent don't share memory
 		chainCopy.context.Path = append(chainCopy.context.Path, fmt.Sprintf(name, args...))
 		chainCopy.context.AliasedPath =
-			append(c.context.AliasedPath, fmt.Sprintf(name, args...))
+			append(chainCopy.context.AliasedPath, fmt.Sprintf(name, args...))
 	}
 
 	return chainCopy
@@ -405,6 +502,13 @@ func (c *chain) leave() {
 	if flags&(flagFailed) != 0 && failure != nil {
 		handler.Failure(&context, failure)
 
+		if context.Request != nil {
+			context.Request.telemetry.recordFailure(&context, failure)
+			context.Request.failures.record(&context, failure)
+		} else if context.Response != nil {
+			context.Response.failures.record(&context, failure)
+		}
+
 		if chainValidation {
 			if err := validateAssertion(failure); err != nil {
 				panic(err)
@@ -452,6 +556,8 @@ func (c *chain) fail(failure AssertionFailure) {
 
 	failure.Stacktrace = stacktrace()
 
+	failure.header = c.header
+
 	c.failure = &failure
 }
 
@@ -509,6 +615,14 @@ func (c *chain) clear() {
 
 // Whether handler outputs to testing.TB
 func isTestingTB(in AssertionHandler) bool {
+	if m, ok := in.(MultiAssertionHandler); ok {
+		for _, h := range m {
+			if isTestingTB(h) {
+				return true
+			}
+		}
+		return false
+	}
 	h, ok := in.(*DefaultAssertionHandler)
 	if !ok {
 		return false
@@ -2,7 +2,9 @@ package httpexpect
 
 import (
 	"errors"
+	"fmt"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -323,6 +325,21 @@ func TestChain_Panics(t *testing.T) {
 		})
 	})
 
+	t.Run("set request after clear", func(t *testing.T) {
+		chain := newChainWithDefaults("test", newMockReporter(t))
+
+		opChain := chain.enter("foo")
+		opChain.setRequest(&Request{})
+		opChain.setResponse(&Response{})
+
+		opChain.clearRequest()
+
+		assert.NotPanics(t, func() {
+			opChain.setRequest(&Request{})
+			opChain.setResponse(&Response{})
+		})
+	})
+
 	t.Run("leave without enter", func(t *testing.T) {
 		chain := newChainWithDefaults("test", newMockReporter(t))
 
@@ -461,6 +478,9 @@ func TestChain_Panics(t *testing.T) {
 			func(chain *chain) {
 				chain.setResponse(&Response{})
 			},
+			func(chain *chain) {
+				chain.clearRequest()
+			},
 		}
 
 		for _, setter := range setterFuncs {
@@ -875,6 +895,68 @@ func TestChain_TestingTB(t *testing.T) {
 
 			chain = newChainWithDefaults(tc.name, tc.args.reporter)
 			assert.Equal(t, tc.want, chain.context.TestingTB)
+
+			chain = newChainWithConfig(tc.name, Config{
+				AssertionHandler: MultiAssertionHandler{
+					&mockAssertionHandler{},
+					tc.args.handler,
+				},
+			}.withDefaults())
+			assert.Equal(t, tc.want, chain.context.TestingTB)
 		})
 	}
 }
+
+func TestChain_Parallel(t *testing.T) {
+	rootChain := newChainWithDefaults("root", newMockReporter(t))
+
+	middleChain := rootChain.enter("foo")
+	parentChain := middleChain.enter("bar")
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			c := parentChain.enter("child%d", i)
+			defer c.leave()
+
+			c.mu.Lock()
+			defer c.mu.Unlock()
+
+			assert.Equal(t, fmt.Sprintf("root.foo.bar.child%d", i),
+				strings.Join(c.context.AliasedPath, "."))
+		}(i)
+	}
+
+	wg.Wait()
+
+	parentChain.leave()
+	middleChain.leave()
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			c1 := rootChain.enter("foo%d", i)
+			c1.setAlias(fmt.Sprint("alias", i))
+
+			c2 := c1.enter("bar")
+			if i%2 == 0 {
+				c2.fail(testFailure())
+			}
+			c2.leave()
+
+			c1.leave()
+		}(i)
+	}
+
+	wg.Wait()
+
+	rootChain.assertFlags(t, flagFailed|flagFailedChildren)
+}
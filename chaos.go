@@ -0,0 +1,230 @@
+package httpexpect
+
+import (
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// ChaosFault defines a fault injected by ChaosTransport into a request.
+type ChaosFault string
+
+const (
+	// Request is passed to underlying transport unchanged.
+	ChaosPass ChaosFault = "pass"
+
+	// Request is delayed by ChaosConfig.Latency (plus jitter) before
+	// passing it to underlying transport.
+	ChaosDelay ChaosFault = "delay"
+
+	// Request is not sent, and connection reset error is returned.
+	ChaosReset ChaosFault = "reset"
+
+	// Request is sent, but response body is cut in the middle and
+	// reading it returns io.ErrUnexpectedEOF.
+	ChaosTruncate ChaosFault = "truncate"
+)
+
+// ChaosConfig defines faults injected by ChaosTransport.
+type ChaosConfig struct {
+	// Seed for random generator used to choose faults and jitter.
+	// Two transports with the same config inject the same faults
+	// into the same sequence of requests.
+	Seed int64
+
+	// Deterministic sequence of faults.
+	// If non-empty, i-th request gets i-th fault from the sequence.
+	// When sequence is exhausted, faults are chosen randomly using
+	// rates defined below.
+	Sequence []ChaosFault
+
+	// Probabilities, from 0 to 1, of injecting corresponding fault
+	// into a request. Checked in the order of declaration; at most
+	// one fault is injected into a request.
+	ResetRate    float64
+	TruncateRate float64
+	DelayRate    float64
+
+	// Delay used by ChaosDelay fault.
+	Latency time.Duration
+
+	// Maximum random duration added to Latency.
+	LatencyJitter time.Duration
+}
+
+// ChaosTransport implements http.RoundTripper that wraps another
+// RoundTripper and injects faults: latency, connection resets, and
+// truncated bodies.
+//
+// It can be used to check how retry and timeout settings of the client
+// (see Request.WithRetryPolicy and Request.WithTimeout) and the service
+// itself behave under failures.
+//
+// Injected faults are deterministic: they are chosen either from
+// configured sequence, or using random generator initialized with
+// configured seed.
+//
+// ChaosTransport is safe for concurrent use.
+type ChaosTransport struct {
+	transport http.RoundTripper
+	config    ChaosConfig
+
+	mu     sync.Mutex
+	rand   *rand.Rand
+	faults []ChaosFault
+}
+
+// NewChaosTransport returns a new ChaosTransport given underlying
+// RoundTripper and config.
+//
+// If transport is nil, http.DefaultTransport is used.
+//
+// Example:
+//
+//	client := &http.Client{
+//		Transport: NewChaosTransport(NewBinder(handler), ChaosConfig{
+//			Sequence: []ChaosFault{ChaosReset, ChaosReset, ChaosPass},
+//		}),
+//	}
+func NewChaosTransport(
+	transport http.RoundTripper, config ChaosConfig,
+) *ChaosTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &ChaosTransport{
+		transport: transport,
+		config:    config,
+		rand:      rand.New(rand.NewSource(config.Seed)), //nolint:gosec
+	}
+}
+
+// Faults returns faults injected so far, one per request,
+// in the order of requests.
+func (ct *ChaosTransport) Faults() []ChaosFault {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	return append([]ChaosFault(nil), ct.faults...)
+}
+
+// RoundTrip implements http.RoundTripper.RoundTrip.
+func (ct *ChaosTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	fault, delay := ct.next()
+
+	switch fault {
+	case ChaosReset:
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		return nil, &net.OpError{
+			Op:  "read",
+			Net: "tcp",
+			Err: syscall.ECONNRESET,
+		}
+
+	case ChaosDelay:
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			if req.Body != nil {
+				_ = req.Body.Close()
+			}
+			return nil, req.Context().Err()
+		}
+
+	case ChaosPass, ChaosTruncate:
+	}
+
+	resp, err := ct.transport.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if fault == ChaosTruncate && resp.Body != nil {
+		resp.Body = newChaosTruncatedBody(resp.Body)
+	}
+
+	return resp, nil
+}
+
+func (ct *ChaosTransport) next() (ChaosFault, time.Duration) {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	var fault ChaosFault
+
+	if n := len(ct.faults); n < len(ct.config.Sequence) {
+		fault = ct.config.Sequence[n]
+	} else {
+		switch {
+		case ct.config.ResetRate > 0 && ct.rand.Float64() < ct.config.ResetRate:
+			fault = ChaosReset
+		case ct.config.TruncateRate > 0 && ct.rand.Float64() < ct.config.TruncateRate:
+			fault = ChaosTruncate
+		case ct.config.DelayRate > 0 && ct.rand.Float64() < ct.config.DelayRate:
+			fault = ChaosDelay
+		default:
+			fault = ChaosPass
+		}
+	}
+
+	var delay time.Duration
+
+	if fault == ChaosDelay {
+		delay = ct.config.Latency
+		if ct.config.LatencyJitter > 0 {
+			delay += time.Duration(ct.rand.Int63n(int64(ct.config.LatencyJitter) + 1))
+		}
+	}
+
+	ct.faults = append(ct.faults, fault)
+
+	return fault, delay
+}
+
+// chaosTruncatedBody reads whole underlying body, returns first half
+// of it, and then fails with io.ErrUnexpectedEOF.
+type chaosTruncatedBody struct {
+	body io.ReadCloser
+	data []byte
+	read bool
+}
+
+func newChaosTruncatedBody(body io.ReadCloser) *chaosTruncatedBody {
+	return &chaosTruncatedBody{body: body}
+}
+
+func (b *chaosTruncatedBody) Read(p []byte) (int, error) {
+	if !b.read {
+		b.read = true
+
+		data, err := io.ReadAll(b.body)
+		if err != nil {
+			return 0, err
+		}
+
+		b.data = data[:len(data)/2]
+	}
+
+	if len(b.data) == 0 {
+		return 0, io.ErrUnexpectedEOF
+	}
+
+	n := copy(p, b.data)
+	b.data = b.data[n:]
+
+	return n, nil
+}
+
+func (b *chaosTruncatedBody) Close() error {
+	return b.body.Close()
+}
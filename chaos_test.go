@@ -0,0 +1,167 @@
+package httpexpect
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChaos_Sequence(t *testing.T) {
+	var count int32
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&count, 1)
+		_, _ = w.Write([]byte("0123456789"))
+	})
+
+	transport := NewChaosTransport(NewBinder(handler), ChaosConfig{
+		Sequence: []ChaosFault{ChaosReset, ChaosTruncate, ChaosDelay, ChaosPass},
+		Latency:  time.Millisecond,
+	})
+
+	client := &http.Client{Transport: transport}
+
+	t.Run("reset", func(t *testing.T) {
+		_, err := client.Get("http://example.com")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, syscall.ECONNRESET))
+		assert.Equal(t, int32(0), atomic.LoadInt32(&count))
+	})
+
+	t.Run("truncate", func(t *testing.T) {
+		resp, err := client.Get("http://example.com")
+		require.NoError(t, err)
+
+		b, err := io.ReadAll(resp.Body)
+		assert.Equal(t, io.ErrUnexpectedEOF, err)
+		assert.Equal(t, "01234", string(b))
+		assert.NoError(t, resp.Body.Close())
+	})
+
+	t.Run("delay", func(t *testing.T) {
+		resp, err := client.Get("http://example.com")
+		require.NoError(t, err)
+
+		b, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, "0123456789", string(b))
+	})
+
+	t.Run("pass", func(t *testing.T) {
+		resp, err := client.Get("http://example.com")
+		require.NoError(t, err)
+
+		b, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, "0123456789", string(b))
+	})
+
+	t.Run("exhausted", func(t *testing.T) {
+		_, err := client.Get("http://example.com")
+		require.NoError(t, err)
+	})
+
+	assert.Equal(t, []ChaosFault{
+		ChaosReset, ChaosTruncate, ChaosDelay, ChaosPass, ChaosPass,
+	}, transport.Faults())
+}
+
+func TestChaos_Seed(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	config := ChaosConfig{
+		Seed:         42,
+		ResetRate:    0.3,
+		TruncateRate: 0.3,
+		DelayRate:    0.3,
+	}
+
+	run := func() []ChaosFault {
+		transport := NewChaosTransport(NewBinder(handler), config)
+		client := &http.Client{Transport: transport}
+
+		for i := 0; i < 50; i++ {
+			resp, err := client.Get("http://example.com")
+			if err == nil {
+				_, _ = io.Copy(io.Discard, resp.Body)
+				_ = resp.Body.Close()
+			}
+		}
+
+		return transport.Faults()
+	}
+
+	faults1 := run()
+	faults2 := run()
+
+	assert.Equal(t, faults1, faults2)
+
+	kinds := map[ChaosFault]bool{}
+	for _, f := range faults1 {
+		kinds[f] = true
+	}
+
+	assert.Equal(t, map[ChaosFault]bool{
+		ChaosPass:     true,
+		ChaosDelay:    true,
+		ChaosReset:    true,
+		ChaosTruncate: true,
+	}, kinds)
+}
+
+func TestChaos_Retries(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	})
+
+	transport := NewChaosTransport(NewBinder(handler), ChaosConfig{
+		Sequence: []ChaosFault{ChaosReset, ChaosReset},
+	})
+
+	e := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: newMockReporter(t),
+		Client:   &http.Client{Transport: transport},
+	})
+
+	resp := e.GET("/").
+		WithRetryPolicy(RetryAllErrors).
+		WithMaxRetries(2).
+		WithRetryDelay(0, 0).
+		Expect()
+
+	resp.chain.assert(t, success)
+	resp.Body().IsEqual("ok")
+
+	assert.Equal(t, []ChaosFault{ChaosReset, ChaosReset, ChaosPass}, transport.Faults())
+}
+
+func TestChaos_Timeout(t *testing.T) {
+	server := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	transport := NewChaosTransport(nil, ChaosConfig{
+		Sequence: []ChaosFault{ChaosDelay},
+		Latency:  time.Hour,
+	})
+
+	client := &http.Client{
+		Transport: transport,
+		Timeout:   10 * time.Millisecond,
+	}
+
+	_, err := client.Get(server.URL)
+	require.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "deadline") ||
+		strings.Contains(err.Error(), "Timeout"))
+}
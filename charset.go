@@ -0,0 +1,110 @@
+package httpexpect
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"strings"
+
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+// Charset returns a new String instance with charset of response body.
+//
+// Charset is taken from Content-Type header. If header doesn't specify
+// charset, it is detected from byte order mark (BOM) at the beginning of
+// the body. If charset can't be determined, Charset returns empty string.
+// Returned charset is lower-cased, e.g. "iso-8859-1" or "utf-16le".
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.Charset().IsEqual("iso-8859-1")
+func (r *Response) Charset() *String {
+	opChain := r.chain.enter("Charset()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	content, ok := r.getContent(opChain, "Charset()")
+	if !ok {
+		return newString(opChain, "")
+	}
+
+	charset, _ := r.detectCharset(content)
+
+	return newString(opChain, charset)
+}
+
+var charsetBOMs = []struct {
+	charset string
+	bom     []byte
+}{
+	{"utf-8", []byte{0xEF, 0xBB, 0xBF}},
+	{"utf-16be", []byte{0xFE, 0xFF}},
+	{"utf-16le", []byte{0xFF, 0xFE}},
+}
+
+// detectCharset returns charset of content and length of BOM, if any.
+func (r *Response) detectCharset(content []byte) (string, int) {
+	var charset string
+
+	_, params, err := mime.ParseMediaType(r.httpResp.Header.Get("Content-Type"))
+	if err == nil {
+		charset = strings.ToLower(params["charset"])
+	}
+
+	for _, b := range charsetBOMs {
+		if bytes.HasPrefix(content, b.bom) {
+			if charset == "" {
+				charset = b.charset
+			}
+			// BOM is stripped only if it matches charset
+			if charset == b.charset ||
+				(b.charset == "utf-8" && charset == "utf8") {
+				return charset, len(b.bom)
+			}
+			break
+		}
+	}
+
+	return charset, 0
+}
+
+// decodeContent transcodes content to UTF-8 according to detected charset.
+// Content in UTF-8 or in unknown charset is returned as is, with BOM removed.
+func (r *Response) decodeContent(opChain *chain, content []byte) ([]byte, bool) {
+	charset, bomLen := r.detectCharset(content)
+
+	content = content[bomLen:]
+
+	if charset == "" || charset == "utf-8" || charset == "utf8" {
+		return content, true
+	}
+
+	enc, err := htmlindex.Get(charset)
+	if err != nil {
+		// unknown charset, keep content as is
+		return content, true
+	}
+
+	if name, _ := htmlindex.Name(enc); name == "utf-8" {
+		return content, true
+	}
+
+	decoded, err := enc.NewDecoder().Bytes(content)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				fmt.Errorf("failed to decode response body from charset %q", charset),
+				err,
+			},
+		})
+		return nil, false
+	}
+
+	return decoded, true
+}
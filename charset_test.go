@@ -0,0 +1,120 @@
+package httpexpect
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestCharset_Response(t *testing.T) {
+	cases := []struct {
+		name        string
+		contentType string
+		body        []byte
+		opts        []ContentOpts
+		charset     string
+		text        string
+		textResult  chainResult
+	}{
+		{
+			name:        "utf-8",
+			contentType: "text/plain; charset=utf-8",
+			body:        []byte("café"),
+			charset:     "utf-8",
+			text:        "café",
+			textResult:  success,
+		},
+		{
+			name:        "no charset",
+			contentType: "text/plain",
+			body:        []byte("café"),
+			charset:     "",
+			text:        "café",
+			textResult:  success,
+		},
+		{
+			name:        "latin-1",
+			contentType: "text/plain; charset=ISO-8859-1",
+			body:        []byte{'c', 'a', 'f', 0xE9},
+			opts:        []ContentOpts{{Charset: "iso-8859-1"}},
+			charset:     "iso-8859-1",
+			text:        "café",
+			textResult:  success,
+		},
+		{
+			name:        "latin-1 not expected",
+			contentType: "text/plain; charset=ISO-8859-1",
+			body:        []byte{'c', 'a', 'f', 0xE9},
+			charset:     "iso-8859-1",
+			textResult:  failure,
+		},
+		{
+			name:        "windows-1251",
+			contentType: "text/plain; charset=windows-1251",
+			body:        []byte{0xEF, 0xF0, 0xE8, 0xE2, 0xE5, 0xF2},
+			opts:        []ContentOpts{{Charset: "windows-1251"}},
+			charset:     "windows-1251",
+			text:        "привет",
+			textResult:  success,
+		},
+		{
+			name:        "utf-8 bom",
+			contentType: "text/plain",
+			body:        []byte("\xEF\xBB\xBFhello"),
+			charset:     "utf-8",
+			text:        "hello",
+			textResult:  success,
+		},
+		{
+			name:        "utf-16le bom",
+			contentType: "text/plain",
+			body:        []byte{0xFF, 0xFE, 'h', 0, 'i', 0},
+			charset:     "utf-16le",
+			text:        "hi",
+			textResult:  success,
+		},
+		{
+			name:        "utf-16be bom",
+			contentType: "text/plain",
+			body:        []byte{0xFE, 0xFF, 0, 'h', 0, 'i'},
+			charset:     "utf-16be",
+			text:        "hi",
+			textResult:  success,
+		},
+		{
+			name:        "unknown charset",
+			contentType: "text/plain; charset=test-charset",
+			body:        []byte("hello"),
+			opts:        []ContentOpts{{Charset: "test-charset"}},
+			charset:     "test-charset",
+			text:        "hello",
+			textResult:  success,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			newResp := func() *Response {
+				return NewResponse(newMockReporter(t), &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": {tc.contentType}},
+					Body:       io.NopCloser(bytes.NewReader(tc.body)),
+				})
+			}
+
+			resp := newResp()
+			resp.Charset().IsEqual(tc.charset)
+			resp.chain.assert(t, success)
+
+			resp = newResp()
+			text := resp.Text(tc.opts...)
+			text.chain.assert(t, tc.textResult)
+
+			if tc.textResult {
+				text.IsEqual(tc.text)
+				text.chain.assert(t, success)
+			}
+		})
+	}
+}
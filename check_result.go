@@ -0,0 +1,122 @@
+package httpexpect
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CheckResult describes outcome of assertions made on response,
+// as returned by Response.Result.
+type CheckResult struct {
+	// Response status code.
+	// Zero if response was not received.
+	StatusCode int
+
+	// Response round-trip time.
+	// Zero if response was not received.
+	RoundTripTime time.Duration
+
+	// Failed assertions with SeverityError, in order of occurrence.
+	Failures []CheckFailure
+
+	// Failed assertions with SeverityWarning, in order of occurrence.
+	Warnings []CheckFailure
+}
+
+// Passed returns true if there are no failures.
+// Warnings are not taken into account.
+func (cr *CheckResult) Passed() bool {
+	return len(cr.Failures) == 0
+}
+
+// CheckFailure describes a single failed assertion in CheckResult.
+type CheckFailure struct {
+	// Assertion path, same as AssertionContext.Path.
+	Path []string
+
+	// Assertion failure.
+	Failure AssertionFailure
+}
+
+// String returns assertion path and errors in a single line.
+func (cf CheckFailure) String() string {
+	var b strings.Builder
+
+	b.WriteString(strings.Join(cf.Path, "."))
+
+	for _, err := range cf.Failure.Errors {
+		if err == nil {
+			continue
+		}
+		if b.Len() != 0 {
+			b.WriteString(": ")
+		}
+		b.WriteString(err.Error())
+	}
+
+	return b.String()
+}
+
+// CheckError is returned by Response.Result if there are failed assertions.
+type CheckError struct {
+	// Failed assertions with SeverityError.
+	Failures []CheckFailure
+}
+
+// Error implements error.Error.
+func (ce *CheckError) Error() string {
+	switch len(ce.Failures) {
+	case 0:
+		return "assertion failed"
+
+	case 1:
+		return "assertion failed: " + ce.Failures[0].String()
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%d assertions failed:", len(ce.Failures))
+
+	for _, failure := range ce.Failures {
+		b.WriteString("\n" + defaultIndent + failure.String())
+	}
+
+	return b.String()
+}
+
+// failureLog accumulates failures reported for request and its response.
+// Shared between Request, Response, and their chains.
+//
+// Failures with SeverityLog are not recorded: they are produced on purpose,
+// e.g. by predicates of Array.Filter, and don't fail the test.
+type failureLog struct {
+	mu       sync.Mutex
+	failures []CheckFailure
+}
+
+func (fl *failureLog) record(ctx *AssertionContext, failure *AssertionFailure) {
+	if fl == nil || failure.Severity == SeverityLog {
+		return
+	}
+
+	fl.mu.Lock()
+	defer fl.mu.Unlock()
+
+	fl.failures = append(fl.failures, CheckFailure{
+		Path:    append([]string(nil), ctx.Path...),
+		Failure: *failure,
+	})
+}
+
+func (fl *failureLog) get() []CheckFailure {
+	if fl == nil {
+		return nil
+	}
+
+	fl.mu.Lock()
+	defer fl.mu.Unlock()
+
+	return append([]CheckFailure(nil), fl.failures...)
+}
@@ -0,0 +1,183 @@
+package httpexpect
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckResult_Response(t *testing.T) {
+	newExpect := func(status int) *Expect {
+		client := ClientFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: status,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body:       newMockBody(`{"status": "ok"}`),
+				Request:    req,
+			}, nil
+		})
+
+		return WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Client:   client,
+			Reporter: NewDetachedReporter(),
+		})
+	}
+
+	t.Run("passed", func(t *testing.T) {
+		resp := newExpect(http.StatusOK).GET("/health").Expect()
+		resp.Status(http.StatusOK).JSON().Object().HasValue("status", "ok")
+
+		result, err := resp.Result()
+		assert.NoError(t, err)
+		require.NotNil(t, result)
+
+		assert.True(t, result.Passed())
+		assert.Equal(t, http.StatusOK, result.StatusCode)
+		assert.Empty(t, result.Failures)
+		assert.Empty(t, result.Warnings)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		resp := newExpect(http.StatusServiceUnavailable).GET("/health").Expect()
+		resp.Status(http.StatusOK)
+
+		result, err := resp.Result()
+		require.Error(t, err)
+		require.NotNil(t, result)
+
+		assert.False(t, result.Passed())
+		assert.Equal(t, http.StatusServiceUnavailable, result.StatusCode)
+		require.Equal(t, 1, len(result.Failures))
+
+		assert.Equal(t,
+			[]string{`Request("GET")`, `Expect()`, `Status()`},
+			result.Failures[0].Path)
+		assert.Equal(t, AssertEqual, result.Failures[0].Failure.Type)
+
+		var checkErr *CheckError
+		require.True(t, errors.As(err, &checkErr))
+		assert.Equal(t, result.Failures, checkErr.Failures)
+		assert.Contains(t, err.Error(), "assertion failed: Request(\"GET\").Expect().Status()")
+	})
+
+	t.Run("warnings", func(t *testing.T) {
+		resp := newExpect(http.StatusOK).GET("/health").Expect()
+		resp.Warn().Header("X-Request-ID").NotEmpty()
+
+		result, err := resp.Result()
+		assert.NoError(t, err)
+
+		assert.True(t, result.Passed())
+		require.Equal(t, 1, len(result.Warnings))
+		assert.Equal(t, SeverityWarning, result.Warnings[0].Failure.Severity)
+	})
+
+	t.Run("filter and find", func(t *testing.T) {
+		e := WithConfig(Config{
+			BaseURL: "http://example.com",
+			Client: ClientFunc(func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": {"application/json"}},
+					Body:       newMockBody(`[1, "two", 3, "four"]`),
+					Request:    req,
+				}, nil
+			}),
+			Reporter: NewDetachedReporter(),
+		})
+
+		resp := e.GET("/items").Expect()
+
+		// failed predicates are logged, but they are not failures
+		arr := resp.JSON().Array()
+		arr.Filter(func(_ int, value *Value) bool {
+			value.Number()
+			return true
+		}).Length().IsEqual(2)
+		arr.Find(func(_ int, value *Value) bool {
+			return value.String().Raw() == "four"
+		}).IsEqual("four")
+
+		result, err := resp.Result()
+		assert.NoError(t, err)
+
+		assert.True(t, result.Passed())
+		assert.Empty(t, result.Failures)
+		assert.Empty(t, result.Warnings)
+	})
+
+	t.Run("request failed", func(t *testing.T) {
+		e := WithConfig(Config{
+			BaseURL: "http://example.com",
+			Client: ClientFunc(func(req *http.Request) (*http.Response, error) {
+				return nil, errors.New("connection refused")
+			}),
+			Reporter: NewDetachedReporter(),
+		})
+
+		resp := e.GET("/health").Expect()
+		resp.Status(http.StatusOK)
+
+		result, err := resp.Result()
+		require.Error(t, err)
+
+		assert.Equal(t, 0, result.StatusCode)
+		require.Equal(t, 1, len(result.Failures))
+		assert.Equal(t, AssertOperation, result.Failures[0].Failure.Type)
+	})
+
+	t.Run("standalone response", func(t *testing.T) {
+		resp := NewResponse(NewDetachedReporter(), &http.Response{
+			StatusCode: http.StatusNotFound,
+		}, time.Second)
+
+		resp.Status(http.StatusOK)
+
+		result, err := resp.Result()
+		require.Error(t, err)
+
+		assert.Equal(t, http.StatusNotFound, result.StatusCode)
+		assert.Equal(t, time.Second, result.RoundTripTime)
+		require.Equal(t, 1, len(result.Failures))
+		assert.Equal(t, []string{`Response()`, `Status()`}, result.Failures[0].Path)
+	})
+}
+
+func TestCheckResult_Error(t *testing.T) {
+	failure1 := CheckFailure{
+		Path: []string{"Expect()", "Status()"},
+		Failure: AssertionFailure{
+			Errors: []error{
+				errors.New("expected: http status is equal to 200"),
+				nil,
+			},
+		},
+	}
+
+	failure2 := CheckFailure{
+		Path: []string{"Expect()", "JSON()"},
+		Failure: AssertionFailure{
+			Errors: []error{
+				errors.New("expected: json body"),
+			},
+		},
+	}
+
+	assert.Equal(t, "assertion failed",
+		(&CheckError{}).Error())
+
+	assert.Equal(t,
+		"assertion failed: Expect().Status(): expected: http status is equal to 200",
+		(&CheckError{Failures: []CheckFailure{failure1}}).Error())
+
+	assert.Equal(t,
+		"2 assertions failed:\n"+
+			"  Expect().Status(): expected: http status is equal to 200\n"+
+			"  Expect().JSON(): expected: json body",
+		(&CheckError{Failures: []CheckFailure{failure1, failure2}}).Error())
+}
@@ -0,0 +1,109 @@
+package httpexpect
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock provides current time and timers.
+//
+// Clock is used by time-based assertions, retry backoff, and scenario
+// retries. Durations, like round-trip time, are measured using time.Now
+// regardless of Clock. Default implementation uses time.Now and time.After.
+// FakeClock can be used to make tests deterministic.
+//
+// Example:
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		Clock: httpexpect.NewFakeClock(
+//			time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)),
+//	})
+type Clock interface {
+	// Now returns current time.
+	Now() time.Time
+
+	// After waits for the duration to elapse and then sends the current
+	// time on the returned channel.
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func clockOrDefault(clock Clock) Clock {
+	if clock == nil {
+		return systemClock{}
+	}
+	return clock
+}
+
+// FakeClock implements Clock with manually controlled time.
+//
+// Time doesn't flow by itself: it changes only when Set, Advance, or
+// After is called. After doesn't block, but instead advances clock by
+// given duration and returns a channel that is already fired. This way
+// retry backoff and other delays happen instantly, while their effect
+// is still observable via Now.
+//
+// FakeClock is safe for concurrent use.
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFakeClock returns a new FakeClock set to given time.
+//
+// Example:
+//
+//	clock := NewFakeClock(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+//	clock.Advance(time.Hour)
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns current fake time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// After advances fake time by d and returns a channel with new time.
+// Negative or zero duration doesn't change time.
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if d > 0 {
+		c.now = c.now.Add(d)
+	}
+
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+
+	return ch
+}
+
+// Set sets fake time to given value.
+func (c *FakeClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = now
+}
+
+// Advance moves fake time forward by d.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
@@ -0,0 +1,84 @@
+package httpexpect
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClock_Fake(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	clock := NewFakeClock(start)
+	assert.Equal(t, start, clock.Now())
+
+	clock.Advance(time.Minute)
+	assert.Equal(t, start.Add(time.Minute), clock.Now())
+
+	tm := <-clock.After(time.Second)
+	assert.Equal(t, start.Add(time.Minute+time.Second), tm)
+	assert.Equal(t, start.Add(time.Minute+time.Second), clock.Now())
+
+	<-clock.After(-time.Second)
+	assert.Equal(t, start.Add(time.Minute+time.Second), clock.Now())
+
+	clock.Set(start)
+	assert.Equal(t, start, clock.Now())
+}
+
+func TestClock_System(t *testing.T) {
+	clock := clockOrDefault(nil)
+
+	before := time.Now()
+	now := clock.Now()
+	assert.False(t, now.Before(before))
+
+	select {
+	case <-clock.After(time.Millisecond):
+	case <-time.After(time.Minute):
+		t.Fatal("timer not fired")
+	}
+
+	fake := NewFakeClock(time.Unix(0, 0))
+	assert.Same(t, fake, clockOrDefault(fake))
+}
+
+func TestClock_Retries(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+
+	callCount := 0
+
+	client := &mockClient{
+		resp: http.Response{
+			StatusCode: http.StatusServiceUnavailable,
+		},
+		cb: func(req *http.Request) {
+			callCount++
+		},
+	}
+
+	config := Config{
+		Client:   client,
+		Reporter: newMockReporter(t),
+		Clock:    clock,
+	}
+
+	resp := NewRequestC(config, http.MethodGet, "/url").
+		WithRetryPolicy(RetryTimeoutAndServerErrors).
+		WithMaxRetries(3).
+		WithRetryDelay(time.Second, 10*time.Second).
+		Expect()
+
+	resp.chain.assert(t, success)
+
+	assert.Equal(t, 4, callCount)
+
+	// 1s + 2s + 4s of backoff passed instantly
+	assert.Equal(t, 7*time.Second, clock.Now().Sub(start))
+
+	// round-trip time is measured in wall time and doesn't include backoff
+	assert.True(t, resp.RoundTripTime().Raw() < time.Second)
+}
@@ -0,0 +1,362 @@
+package httpexpect
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CompareOptions controls deep comparison performed by IsEqual and NotEqual
+// of Value, Object, and Array.
+//
+// Zero CompareOptions gives the same semantics as comparison without
+// options: values are equal if their canonical forms are deeply equal.
+//
+// Paths use the same syntax as Redactor.JSONPaths and are relative to the
+// compared value:
+//   - "$.a.b" - field "b" of object "a" of root object
+//   - "$.a[*].b", "$.a.*.b" - field "b" of every element of "a"
+//   - "$.a[0].b" - field "b" of first element of array "a"
+//   - "$..b" - field "b" at any depth
+//
+// Example:
+//
+//	resp.JSON().Object().IsEqual(expected, CompareOptions{
+//		IgnorePaths:     []string{"$.id", "$..created_at"},
+//		NumberTolerance: 0.001,
+//		IgnoreCase:      true,
+//		UnorderedArrays: true,
+//		Comparators: map[string]func(actual, expected interface{}) bool{
+//			"$.token": func(actual, expected interface{}) bool {
+//				return actual != ""
+//			},
+//		},
+//	})
+type CompareOptions struct {
+	// Paths of fields and elements that are not compared.
+	// If an ignored field is missing in one of the values, it's not
+	// reported as a difference.
+	IgnorePaths []string
+
+	// Maximum allowed absolute difference between two numbers.
+	// Zero means that numbers should be exactly equal.
+	NumberTolerance float64
+
+	// If true, strings are compared case-insensitively.
+	IgnoreCase bool
+
+	// If true, arrays are compared ignoring element order.
+	// Element paths then refer to indexes in the actual array.
+	UnorderedArrays bool
+
+	// Custom comparators for given paths.
+	// Comparator receives actual and expected values in canonical form
+	// and replaces default comparison of the whole subtree.
+	// If multiple paths match, the lexicographically first one is used.
+	Comparators map[string]func(actual, expected interface{}) bool
+}
+
+type compareComparator struct {
+	path []jsonPathSegment
+	fn   func(actual, expected interface{}) bool
+}
+
+type compiledCompareOptions struct {
+	CompareOptions
+	ignore      [][]jsonPathSegment
+	comparators []compareComparator
+}
+
+// getCompareOptions validates variadic options argument of IsEqual and
+// NotEqual and returns compiled options, or nil if there are no options.
+func getCompareOptions(
+	opChain *chain, opts []CompareOptions,
+) (*compiledCompareOptions, bool) {
+	if len(opts) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple opts arguments"),
+			},
+		})
+		return nil, false
+	}
+
+	if len(opts) == 0 {
+		return nil, true
+	}
+
+	compiled, err := compileCompareOptions(opts[0])
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				err,
+			},
+		})
+		return nil, false
+	}
+
+	return compiled, true
+}
+
+func compileCompareOptions(opts CompareOptions) (*compiledCompareOptions, error) {
+	if opts.NumberTolerance < 0 || math.IsNaN(opts.NumberTolerance) {
+		return nil, errors.New("unexpected negative or NaN NumberTolerance")
+	}
+
+	compiled := &compiledCompareOptions{
+		CompareOptions: opts,
+	}
+
+	for _, path := range opts.IgnorePaths {
+		segments, err := parseJSONPath(path)
+		if err != nil {
+			return nil, err
+		}
+		compiled.ignore = append(compiled.ignore, segments)
+	}
+
+	paths := make([]string, 0, len(opts.Comparators))
+	for path := range opts.Comparators {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		if opts.Comparators[path] == nil {
+			return nil, errors.New("unexpected nil comparator for " + path)
+		}
+		segments, err := parseJSONPath(path)
+		if err != nil {
+			return nil, err
+		}
+		compiled.comparators = append(compiled.comparators, compareComparator{
+			path: segments,
+			fn:   opts.Comparators[path],
+		})
+	}
+
+	return compiled, nil
+}
+
+// compareValues reports whether two values in canonical form are equal.
+// If opts is nil, values are compared using plain deep equality.
+func compareValues(opts *compiledCompareOptions, actual, expected interface{}) bool {
+	if opts == nil {
+		return reflect.DeepEqual(actual, expected)
+	}
+	return opts.compare(nil, actual, expected)
+}
+
+func (opts *compiledCompareOptions) compare(
+	path []string, actual, expected interface{},
+) bool {
+	if opts.isIgnored(path) {
+		return true
+	}
+
+	for _, c := range opts.comparators {
+		if matchJSONPath(c.path, path) {
+			return c.fn(actual, expected)
+		}
+	}
+
+	switch exp := expected.(type) {
+	case nil:
+		return actual == nil
+
+	case bool:
+		act, ok := actual.(bool)
+		return ok && act == exp
+
+	case float64:
+		act, ok := actual.(float64)
+		if !ok {
+			return false
+		}
+		if act == exp {
+			return true
+		}
+		return math.Abs(act-exp) <= opts.NumberTolerance
+
+	case string:
+		act, ok := actual.(string)
+		if !ok {
+			return false
+		}
+		if opts.IgnoreCase {
+			return strings.EqualFold(act, exp)
+		}
+		return act == exp
+
+	case map[string]interface{}:
+		act, ok := actual.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		return opts.compareMaps(path, act, exp)
+
+	case []interface{}:
+		act, ok := actual.([]interface{})
+		if !ok {
+			return false
+		}
+		if opts.UnorderedArrays {
+			return opts.compareUnordered(path, act, exp)
+		}
+		return opts.compareOrdered(path, act, exp)
+
+	default:
+		return false
+	}
+}
+
+func (opts *compiledCompareOptions) compareMaps(
+	path []string, actual, expected map[string]interface{},
+) bool {
+	for key, expVal := range expected {
+		childPath := appendPath(path, key)
+		actVal, ok := actual[key]
+		if !ok {
+			if !opts.isIgnored(childPath) {
+				return false
+			}
+			continue
+		}
+		if !opts.compare(childPath, actVal, expVal) {
+			return false
+		}
+	}
+
+	for key := range actual {
+		if _, ok := expected[key]; !ok {
+			if !opts.isIgnored(appendPath(path, key)) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func (opts *compiledCompareOptions) compareOrdered(
+	path []string, actual, expected []interface{},
+) bool {
+	if len(actual) != len(expected) {
+		return false
+	}
+
+	for i := range expected {
+		if !opts.compare(appendPath(path, strconv.Itoa(i)), actual[i], expected[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// compareUnordered reports whether every expected element can be paired
+// with a distinct actual element. Since ignored paths and tolerances make
+// several elements match each other, pairs are found using maximum
+// bipartite matching rather than greedily.
+func (opts *compiledCompareOptions) compareUnordered(
+	path []string, actual, expected []interface{},
+) bool {
+	if len(actual) != len(expected) {
+		return false
+	}
+
+	// matches[e] lists indexes of actual elements matching expected[e]
+	matches := make([][]int, len(expected))
+
+	for e, expVal := range expected {
+		for a, actVal := range actual {
+			if opts.compare(appendPath(path, strconv.Itoa(a)), actVal, expVal) {
+				matches[e] = append(matches[e], a)
+			}
+		}
+		if len(matches[e]) == 0 {
+			return false
+		}
+	}
+
+	// pairedWith[a] is index of expected element paired with actual[a], or -1
+	pairedWith := make([]int, len(actual))
+	for a := range pairedWith {
+		pairedWith[a] = -1
+	}
+
+	for e := range expected {
+		visited := make([]bool, len(actual))
+		if !augmentMatching(e, matches, pairedWith, visited) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// augmentMatching tries to pair expected element e with an actual element,
+// re-pairing previously paired elements if needed.
+func augmentMatching(e int, matches [][]int, pairedWith []int, visited []bool) bool {
+	for _, a := range matches[e] {
+		if visited[a] {
+			continue
+		}
+		visited[a] = true
+
+		if pairedWith[a] < 0 || augmentMatching(pairedWith[a], matches, pairedWith, visited) {
+			pairedWith[a] = e
+			return true
+		}
+	}
+
+	return false
+}
+
+func (opts *compiledCompareOptions) isIgnored(path []string) bool {
+	if len(path) == 0 {
+		return false
+	}
+	for _, pattern := range opts.ignore {
+		if matchJSONPath(pattern, path) {
+			return true
+		}
+	}
+	return false
+}
+
+func appendPath(path []string, elem string) []string {
+	result := make([]string, len(path)+1)
+	copy(result, path)
+	result[len(path)] = elem
+	return result
+}
+
+// matchJSONPath reports whether path, a list of object keys and array
+// indexes, matches parsed JSON path pattern.
+func matchJSONPath(pattern []jsonPathSegment, path []string) bool {
+	if len(pattern) == 0 {
+		return len(path) == 0
+	}
+
+	seg := pattern[0]
+
+	if seg.recursive {
+		for i := range path {
+			if (seg.any || seg.name == path[i]) &&
+				matchJSONPath(pattern[1:], path[i+1:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return len(path) != 0 &&
+		(seg.any || seg.name == path[0]) &&
+		matchJSONPath(pattern[1:], path[1:])
+}
@@ -0,0 +1,276 @@
+package httpexpect
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareOptions_Compare(t *testing.T) {
+	cases := []struct {
+		name     string
+		opts     CompareOptions
+		actual   interface{}
+		expected interface{}
+		result   bool
+	}{
+		{
+			name:     "zero options, equal",
+			actual:   map[string]interface{}{"a": []interface{}{1.0, "x"}},
+			expected: map[string]interface{}{"a": []interface{}{1.0, "x"}},
+			result:   true,
+		},
+		{
+			name:     "zero options, not equal",
+			actual:   map[string]interface{}{"a": []interface{}{1.0, "x"}},
+			expected: map[string]interface{}{"a": []interface{}{"x", 1.0}},
+			result:   false,
+		},
+		{
+			name:     "tolerance, within",
+			opts:     CompareOptions{NumberTolerance: 0.1},
+			actual:   []interface{}{1.05},
+			expected: []interface{}{1.0},
+			result:   true,
+		},
+		{
+			name:     "tolerance, outside",
+			opts:     CompareOptions{NumberTolerance: 0.1},
+			actual:   []interface{}{1.2},
+			expected: []interface{}{1.0},
+			result:   false,
+		},
+		{
+			name:     "ignore case",
+			opts:     CompareOptions{IgnoreCase: true},
+			actual:   map[string]interface{}{"a": "FOO"},
+			expected: map[string]interface{}{"a": "foo"},
+			result:   true,
+		},
+		{
+			name:     "case sensitive",
+			actual:   map[string]interface{}{"a": "FOO"},
+			expected: map[string]interface{}{"a": "foo"},
+			result:   false,
+		},
+		{
+			name:     "unordered arrays",
+			opts:     CompareOptions{UnorderedArrays: true},
+			actual:   []interface{}{"b", []interface{}{2.0, 1.0}, "a"},
+			expected: []interface{}{"a", "b", []interface{}{1.0, 2.0}},
+			result:   true,
+		},
+		{
+			name:     "unordered arrays, duplicates",
+			opts:     CompareOptions{UnorderedArrays: true},
+			actual:   []interface{}{"a", "a", "b"},
+			expected: []interface{}{"a", "b", "b"},
+			result:   false,
+		},
+		{
+			name: "unordered arrays, tolerance",
+			opts: CompareOptions{
+				UnorderedArrays: true,
+				NumberTolerance: 0.5,
+			},
+			// greedy matching would pair 1.0 with 1.4 and leave 1.8 unpaired
+			actual:   []interface{}{1.4, 0.8},
+			expected: []interface{}{1.0, 1.8},
+			result:   true,
+		},
+		{
+			name: "ignore paths",
+			opts: CompareOptions{
+				IgnorePaths: []string{"$.id", "$.items[*].ts", "$..secret"},
+			},
+			actual: map[string]interface{}{
+				"id": 1.0,
+				"items": []interface{}{
+					map[string]interface{}{"v": "a", "ts": 1.0},
+				},
+				"nested": map[string]interface{}{"secret": "x"},
+			},
+			expected: map[string]interface{}{
+				"id": 2.0,
+				"items": []interface{}{
+					map[string]interface{}{"v": "a", "ts": 2.0},
+				},
+				"nested": map[string]interface{}{},
+			},
+			result: true,
+		},
+		{
+			name: "ignore paths, other field differs",
+			opts: CompareOptions{
+				IgnorePaths: []string{"$.id"},
+			},
+			actual:   map[string]interface{}{"id": 1.0, "name": "a"},
+			expected: map[string]interface{}{"id": 2.0, "name": "b"},
+			result:   false,
+		},
+		{
+			name: "comparator",
+			opts: CompareOptions{
+				Comparators: map[string]func(actual, expected interface{}) bool{
+					"$.token": func(actual, expected interface{}) bool {
+						return actual != ""
+					},
+				},
+			},
+			actual:   map[string]interface{}{"token": "abc", "name": "a"},
+			expected: map[string]interface{}{"token": "", "name": "a"},
+			result:   true,
+		},
+		{
+			name: "comparator, rejects",
+			opts: CompareOptions{
+				Comparators: map[string]func(actual, expected interface{}) bool{
+					"$[*]": func(actual, expected interface{}) bool {
+						return false
+					},
+				},
+			},
+			actual:   []interface{}{1.0},
+			expected: []interface{}{1.0},
+			result:   false,
+		},
+		{
+			name:     "type mismatch",
+			opts:     CompareOptions{NumberTolerance: 1},
+			actual:   map[string]interface{}{"a": "1"},
+			expected: map[string]interface{}{"a": 1.0},
+			result:   false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts, err := compileCompareOptions(tc.opts)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.result, compareValues(opts, tc.actual, tc.expected))
+		})
+	}
+}
+
+func TestCompareOptions_Invalid(t *testing.T) {
+	cases := []struct {
+		name string
+		opts CompareOptions
+	}{
+		{
+			name: "bad ignore path",
+			opts: CompareOptions{IgnorePaths: []string{"a.b"}},
+		},
+		{
+			name: "bad comparator path",
+			opts: CompareOptions{
+				Comparators: map[string]func(actual, expected interface{}) bool{
+					"$.a[": func(actual, expected interface{}) bool {
+						return true
+					},
+				},
+			},
+		},
+		{
+			name: "nil comparator",
+			opts: CompareOptions{
+				Comparators: map[string]func(actual, expected interface{}) bool{
+					"$.a": nil,
+				},
+			},
+		},
+		{
+			name: "negative tolerance",
+			opts: CompareOptions{NumberTolerance: -1},
+		},
+		{
+			name: "NaN tolerance",
+			opts: CompareOptions{NumberTolerance: math.NaN()},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			NewValue(reporter, 1).IsEqual(1, tc.opts).
+				chain.assert(t, failure)
+		})
+	}
+}
+
+func TestCompareOptions_IsEqual(t *testing.T) {
+	opts := CompareOptions{
+		IgnorePaths:     []string{"$..id"},
+		IgnoreCase:      true,
+		UnorderedArrays: true,
+	}
+
+	t.Run("value", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		NewValue(reporter, "FOO").IsEqual("foo", opts).
+			chain.assert(t, success)
+
+		NewValue(reporter, "FOO").NotEqual("foo", opts).
+			chain.assert(t, failure)
+
+		NewValue(reporter, "FOO").IsEqual("foo").
+			chain.assert(t, failure)
+	})
+
+	t.Run("object", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		actual := map[string]interface{}{
+			"id":   123,
+			"tags": []interface{}{"B", "a"},
+		}
+		expected := map[string]interface{}{
+			"tags": []string{"A", "b"},
+		}
+
+		NewObject(reporter, actual).IsEqual(expected, opts).
+			chain.assert(t, success)
+
+		NewObject(reporter, actual).NotEqual(expected, opts).
+			chain.assert(t, failure)
+
+		NewObject(reporter, actual).IsEqual(expected).
+			chain.assert(t, failure)
+	})
+
+	t.Run("array", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		actual := []interface{}{
+			map[string]interface{}{"id": 1, "name": "Bob"},
+			map[string]interface{}{"id": 2, "name": "Alice"},
+		}
+		expected := []interface{}{
+			map[string]interface{}{"name": "alice"},
+			map[string]interface{}{"name": "bob"},
+		}
+
+		NewArray(reporter, actual).IsEqual(expected, opts).
+			chain.assert(t, success)
+
+		NewArray(reporter, actual).NotEqual(expected, opts).
+			chain.assert(t, failure)
+
+		NewArray(reporter, actual).IsEqual(expected).
+			chain.assert(t, failure)
+	})
+
+	t.Run("multiple options", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		NewValue(reporter, 1).IsEqual(1, opts, opts).
+			chain.assert(t, failure)
+
+		NewArray(reporter, []interface{}{}).NotEqual([]interface{}{1}, opts, opts).
+			chain.assert(t, failure)
+	})
+}
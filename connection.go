@@ -0,0 +1,208 @@
+package httpexpect
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"net/textproto"
+	"sync"
+	"time"
+)
+
+// ConnectionStats contains statistics of connections used by requests
+// sent via Expect instance.
+//
+// Statistics are collected using net/http/httptrace, so they're available
+// only for clients that use http.Transport (e.g. http.Client with default
+// transport). Requests sent via Binder or FastBinder are not counted.
+type ConnectionStats struct {
+	// Number of connections obtained by requests, including retries.
+	Total int
+
+	// Number of newly established connections.
+	New int
+
+	// Number of connections reused from previous requests.
+	Reused int
+
+	// Number of reused connections that were taken from idle pool.
+	Idle int
+
+	// Maximum time a reused connection spent in idle pool.
+	MaxIdleTime time.Duration
+}
+
+// connStats accumulates ConnectionStats.
+// Shared between Expect instance, its copies, and its requests.
+type connStats struct {
+	mu    sync.Mutex
+	stats ConnectionStats
+}
+
+func (cs *connStats) record(info httptrace.GotConnInfo) {
+	if cs == nil {
+		return
+	}
+
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	cs.stats.Total++
+
+	if info.Reused {
+		cs.stats.Reused++
+	} else {
+		cs.stats.New++
+	}
+
+	if info.WasIdle {
+		cs.stats.Idle++
+
+		if info.IdleTime > cs.stats.MaxIdleTime {
+			cs.stats.MaxIdleTime = info.IdleTime
+		}
+	}
+}
+
+func (cs *connStats) get() ConnectionStats {
+	if cs == nil {
+		return ConnectionStats{}
+	}
+
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	return cs.stats
+}
+
+func (cs *connStats) reset() {
+	if cs == nil {
+		return
+	}
+
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	cs.stats = ConnectionStats{}
+}
+
+// connTracer records info about connection obtained for request,
+// headers of 103 Early Hints responses received before response,
+// and timestamps of request phases.
+type connTracer struct {
+	mu    sync.Mutex
+	info  *httptrace.GotConnInfo
+	hints []http.Header
+
+	stats *connStats
+
+	start time.Time
+
+	dnsStart     time.Time
+	dnsDone      time.Time
+	connectStart time.Time
+	connectDone  time.Time
+	tlsStart     time.Time
+	tlsDone      time.Time
+	firstByte    time.Time
+}
+
+func newConnTracer(stats *connStats) *connTracer {
+	return &connTracer{
+		stats: stats,
+		start: time.Now(),
+	}
+}
+
+func (ct *connTracer) trace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			ct.mu.Lock()
+			ct.info = &info
+			ct.mu.Unlock()
+
+			ct.stats.record(info)
+		},
+		Got1xxResponse: func(code int, header textproto.MIMEHeader) error {
+			if code == http.StatusEarlyHints {
+				ct.mu.Lock()
+				ct.hints = append(ct.hints, http.Header(header).Clone())
+				ct.mu.Unlock()
+			}
+			return nil
+		},
+		DNSStart: func(httptrace.DNSStartInfo) {
+			ct.mark(&ct.dnsStart)
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			ct.mark(&ct.dnsDone)
+		},
+		ConnectStart: func(string, string) {
+			ct.mark(&ct.connectStart)
+		},
+		ConnectDone: func(string, string, error) {
+			ct.mark(&ct.connectDone)
+		},
+		TLSHandshakeStart: func() {
+			ct.mark(&ct.tlsStart)
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			ct.mark(&ct.tlsDone)
+		},
+		GotFirstResponseByte: func() {
+			ct.mark(&ct.firstByte)
+		},
+	}
+}
+
+// mark stores current time into given timestamp, unless it's already set.
+// With happy eyeballs, connect may be started multiple times; we record
+// the first start and the first completion.
+// Timestamps are used only to compute durations, so they're taken from
+// time.Now and not from Config.Clock, which may be fake.
+func (ct *connTracer) mark(ts *time.Time) {
+	now := time.Now()
+
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	if ts.IsZero() {
+		*ts = now
+	}
+}
+
+// timing returns durations of recorded request phases.
+// Phases that didn't happen (e.g. DNS lookup for reused connection)
+// have zero duration.
+func (ct *connTracer) timing() RequestTiming {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	phase := func(start, end time.Time) time.Duration {
+		if start.IsZero() || end.IsZero() {
+			return 0
+		}
+		return end.Sub(start)
+	}
+
+	return RequestTiming{
+		DNSLookup:       phase(ct.dnsStart, ct.dnsDone),
+		Connect:         phase(ct.connectStart, ct.connectDone),
+		TLSHandshake:    phase(ct.tlsStart, ct.tlsDone),
+		TimeToFirstByte: phase(ct.start, ct.firstByte),
+	}
+}
+
+func (ct *connTracer) gotConn() *httptrace.GotConnInfo {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	return ct.info
+}
+
+func (ct *connTracer) earlyHints() []http.Header {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	return ct.hints
+}
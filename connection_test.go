@@ -0,0 +1,122 @@
+package httpexpect
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConnection_Stats(t *testing.T) {
+	stats := &connStats{}
+
+	stats.record(httptrace.GotConnInfo{})
+	stats.record(httptrace.GotConnInfo{Reused: true})
+	stats.record(httptrace.GotConnInfo{
+		Reused: true, WasIdle: true, IdleTime: time.Second,
+	})
+	stats.record(httptrace.GotConnInfo{
+		Reused: true, WasIdle: true, IdleTime: time.Millisecond,
+	})
+
+	assert.Equal(t, ConnectionStats{
+		Total:       4,
+		New:         1,
+		Reused:      3,
+		Idle:        2,
+		MaxIdleTime: time.Second,
+	}, stats.get())
+
+	stats.reset()
+	assert.Equal(t, ConnectionStats{}, stats.get())
+
+	var nilStats *connStats
+	nilStats.record(httptrace.GotConnInfo{})
+	nilStats.reset()
+	assert.Equal(t, ConnectionStats{}, nilStats.get())
+}
+
+func TestConnection_Reused(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("ok"))
+		}))
+	defer server.Close()
+
+	e := WithConfig(Config{
+		BaseURL:  server.URL,
+		Reporter: newMockReporter(t),
+		Client: &http.Client{
+			Transport: &http.Transport{},
+		},
+	})
+
+	resp := e.GET("/").Expect()
+	resp.Body().IsEqual("ok")
+	resp.ConnectionReused().IsFalse()
+	resp.chain.assert(t, success)
+
+	resp = e.Builder(func(*Request) {}).GET("/").Expect()
+	resp.Body().IsEqual("ok")
+	resp.ConnectionReused().IsTrue()
+	resp.chain.assert(t, success)
+
+	stats := e.ConnectionStats()
+	assert.Equal(t, 2, stats.Total)
+	assert.Equal(t, 1, stats.New)
+	assert.Equal(t, 1, stats.Reused)
+	assert.Equal(t, 1, stats.Idle)
+
+	e.ResetConnectionStats()
+	assert.Equal(t, ConnectionStats{}, e.ConnectionStats())
+}
+
+func TestConnection_NotAvailable(t *testing.T) {
+	t.Run("binder", func(t *testing.T) {
+		e := WithConfig(Config{
+			Reporter: newMockReporter(t),
+			Client: &http.Client{
+				Transport: NewBinder(http.HandlerFunc(
+					func(w http.ResponseWriter, r *http.Request) {})),
+			},
+		})
+
+		resp := e.GET("/").Expect()
+		resp.chain.assert(t, success)
+
+		resp.ConnectionReused()
+		resp.chain.assert(t, failure)
+		resp.chain.clear()
+
+		resp.ResolvedAddr()
+		resp.chain.assert(t, failure)
+
+		assert.Equal(t, ConnectionStats{}, e.ConnectionStats())
+	})
+
+	t.Run("manual response", func(t *testing.T) {
+		resp := NewResponse(newMockReporter(t), &http.Response{})
+
+		resp.ConnectionReused()
+		resp.chain.assert(t, failure)
+		resp.chain.clear()
+
+		resp.ResolvedAddr()
+		resp.chain.assert(t, failure)
+	})
+
+	t.Run("failed chain", func(t *testing.T) {
+		chain := newMockChain(t, flagFailed)
+
+		resp := newResponse(responseOpts{
+			config: newMockConfig(newMockReporter(t)),
+			chain:  chain,
+		})
+
+		resp.ConnectionReused().chain.assert(t, failure)
+		resp.ResolvedAddr().chain.assert(t, failure)
+	})
+}
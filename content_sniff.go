@@ -0,0 +1,160 @@
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// ContentTypeMatchesBody succeeds if response body is consistent with
+// declared Content-Type.
+//
+// It catches misconfigured handlers, for example, those that send HTML
+// error pages with "application/json" type. Body is checked as follows:
+//   - for JSON types ("application/json" and "+json" suffix), body should
+//     be valid JSON
+//   - for XML types ("application/xml", "text/xml", and "+xml" suffix),
+//     body should be well-formed XML
+//   - for other types, body is sniffed using http.DetectContentType, and
+//     detected type should match declared one, unless the body was not
+//     recognized ("text/plain" or "application/octet-stream")
+//
+// Empty body always matches. If body is not empty, Content-Type should be
+// present and valid. If Content-Encoding is set (e.g. "gzip"), body can't
+// be inspected and the check is skipped.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.ContentTypeMatchesBody()
+func (r *Response) ContentTypeMatchesBody() *Response {
+	opChain := r.chain.enter("ContentTypeMatchesBody()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if enc := r.httpResp.Header.Get("Content-Encoding"); enc != "" &&
+		!strings.EqualFold(enc, "identity") {
+		return r
+	}
+
+	content, ok := r.getContent(opChain, "ContentTypeMatchesBody()")
+	if !ok {
+		return r
+	}
+
+	if len(content) == 0 {
+		return r
+	}
+
+	detectedType, _, _ := mime.ParseMediaType(http.DetectContentType(content))
+
+	contentType := r.httpResp.Header.Get("Content-Type")
+
+	if contentType == "" {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{r.httpResp.Header},
+			Errors: append([]error{
+				errors.New(`expected: response with body has "Content-Type" header`),
+				fmt.Errorf("detected media type: %s", detectedType),
+			}, r.bodyExcerpt()...),
+		})
+		return r
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{contentType},
+			Errors: []error{
+				errors.New(`invalid "Content-Type" response header`),
+				err,
+			},
+		})
+		return r
+	}
+
+	var mismatch error
+
+	switch {
+	case isJSONMediaType(mediaType):
+		if !json.Valid(content) {
+			mismatch = errors.New("body is not valid JSON")
+		}
+
+	case isXMLMediaType(mediaType):
+		if err := checkXML(content); err != nil {
+			mismatch = fmt.Errorf("body is not well-formed XML: %w", err)
+		}
+
+	default:
+		if detectedType != mediaType &&
+			detectedType != "text/plain" &&
+			detectedType != "application/octet-stream" {
+			mismatch = errors.New("body looks like a different media type")
+		}
+	}
+
+	if mismatch != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{contentType},
+			Errors: append([]error{
+				errors.New(`expected: response body matches "Content-Type" header`),
+				mismatch,
+				fmt.Errorf("declared media type: %s", mediaType),
+				fmt.Errorf("detected media type: %s", detectedType),
+			}, r.bodyExcerpt()...),
+		})
+		return r
+	}
+
+	return r
+}
+
+func isJSONMediaType(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+func isXMLMediaType(mediaType string) bool {
+	return mediaType == "application/xml" || mediaType == "text/xml" ||
+		strings.HasSuffix(mediaType, "+xml")
+}
+
+// checkXML returns error if content is not well-formed XML document
+// with at least one element.
+func checkXML(content []byte) error {
+	dec := xml.NewDecoder(bytes.NewReader(content))
+	dec.Strict = true
+
+	hasElement := false
+
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if _, ok := tok.(xml.StartElement); ok {
+			hasElement = true
+		}
+	}
+
+	if !hasElement {
+		return errors.New("no root element")
+	}
+
+	return nil
+}
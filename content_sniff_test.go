@@ -0,0 +1,165 @@
+package httpexpect
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponse_ContentTypeMatchesBody(t *testing.T) {
+	htmlPage := "<!DOCTYPE html><html><body><h1>502 Bad Gateway</h1></body></html>"
+
+	cases := []struct {
+		name   string
+		header http.Header
+		body   string
+		result chainResult
+	}{
+		{
+			name:   "json",
+			header: http.Header{"Content-Type": {"application/json; charset=utf-8"}},
+			body:   `{"id": 1}`,
+			result: success,
+		},
+		{
+			name:   "json suffix",
+			header: http.Header{"Content-Type": {"application/problem+json"}},
+			body:   `{"title": "error"}`,
+			result: success,
+		},
+		{
+			name:   "html as json",
+			header: http.Header{"Content-Type": {"application/json"}},
+			body:   htmlPage,
+			result: failure,
+		},
+		{
+			name:   "truncated json",
+			header: http.Header{"Content-Type": {"application/json"}},
+			body:   `{"id": 1`,
+			result: failure,
+		},
+		{
+			name:   "xml",
+			header: http.Header{"Content-Type": {"application/xml"}},
+			body:   `<?xml version="1.0"?><user><id>1</id></user>`,
+			result: success,
+		},
+		{
+			name:   "xml suffix",
+			header: http.Header{"Content-Type": {"application/atom+xml"}},
+			body:   `<feed></feed>`,
+			result: success,
+		},
+		{
+			name:   "json as xml",
+			header: http.Header{"Content-Type": {"text/xml"}},
+			body:   `{"id": 1}`,
+			result: failure,
+		},
+		{
+			name:   "malformed xml",
+			header: http.Header{"Content-Type": {"application/xml"}},
+			body:   `<user><id>1</user>`,
+			result: failure,
+		},
+		{
+			name:   "html",
+			header: http.Header{"Content-Type": {"text/html; charset=utf-8"}},
+			body:   htmlPage,
+			result: success,
+		},
+		{
+			name:   "plain text",
+			header: http.Header{"Content-Type": {"text/plain"}},
+			body:   "hello",
+			result: success,
+		},
+		{
+			name:   "unrecognized body",
+			header: http.Header{"Content-Type": {"text/csv"}},
+			body:   "a,b\n1,2\n",
+			result: success,
+		},
+		{
+			name:   "html as image",
+			header: http.Header{"Content-Type": {"image/png"}},
+			body:   htmlPage,
+			result: failure,
+		},
+		{
+			name:   "png",
+			header: http.Header{"Content-Type": {"image/png"}},
+			body:   "\x89PNG\x0D\x0A\x1A\x0A\x00\x00\x00\x0DIHDR",
+			result: success,
+		},
+		{
+			name:   "empty body",
+			header: http.Header{"Content-Type": {"application/json"}},
+			body:   "",
+			result: success,
+		},
+		{
+			name:   "missing content type",
+			header: http.Header{},
+			body:   `{"id": 1}`,
+			result: failure,
+		},
+		{
+			name:   "invalid content type",
+			header: http.Header{"Content-Type": {"application/json; ="}},
+			body:   `{"id": 1}`,
+			result: failure,
+		},
+		{
+			name: "encoded body",
+			header: http.Header{
+				"Content-Type":     {"application/json"},
+				"Content-Encoding": {"gzip"},
+			},
+			body:   "\x1f\x8b\x08\x00",
+			result: success,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := NewResponse(newMockReporter(t), &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     tc.header,
+				Body:       newMockBody(tc.body),
+			})
+
+			resp.ContentTypeMatchesBody()
+			resp.chain.assert(t, tc.result)
+		})
+	}
+
+	t.Run("failure message", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		resp := NewResponseC(Config{AssertionHandler: handler}, &http.Response{
+			StatusCode: http.StatusBadGateway,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       newMockBody(htmlPage),
+		})
+
+		resp.ContentTypeMatchesBody()
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertValid, handler.failure.Type)
+		assert.Equal(t, "application/json", handler.failure.Actual.Value)
+
+		var messages []string
+		for _, err := range handler.failure.Errors {
+			messages = append(messages, err.Error())
+		}
+
+		assert.Contains(t, messages, "body is not valid JSON")
+		assert.Contains(t, messages, "declared media type: application/json")
+		assert.Contains(t, messages, "detected media type: text/html")
+		assert.Contains(t, messages, "response body:\n"+htmlPage)
+	})
+}
@@ -2,7 +2,12 @@ package httpexpect
 
 import (
 	"errors"
+	"fmt"
+	"net"
 	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
 	"time"
 )
 
@@ -165,6 +170,66 @@ func (c *Cookie) Expires() *DateTime {
 	return newDateTime(opChain, c.value.Expires)
 }
 
+// DecodeWith decodes cookie value using given codec and returns a new
+// Object instance with decoded payload.
+//
+// This is useful for signed or encrypted cookies, which are opaque
+// otherwise. See SecureCookieCodec and JWTCookieCodec for builtin
+// codecs, or implement CookieCodec for custom formats.
+//
+// If codec fails, or payload is not an object, failure is reported.
+//
+// Example:
+//
+//	resp.Cookie("session").
+//		DecodeWith(SecureCookieCodec{HashKey: hashKey}).
+//		HasValue("user", "alice")
+func (c *Cookie) DecodeWith(codec CookieCodec) *Object {
+	opChain := c.chain.enter("DecodeWith()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	if codec == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil codec argument"),
+			},
+		})
+		return newObject(opChain, nil)
+	}
+
+	payload, err := codec.Decode(c.value.Name, c.value.Value)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{c.value.Value},
+			Errors: []error{
+				errors.New("expected: cookie value can be decoded with given codec"),
+				err,
+			},
+		})
+		return newObject(opChain, nil)
+	}
+
+	data, ok := payload.(map[string]interface{})
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{payload},
+			Errors: []error{
+				errors.New("expected: decoded cookie payload is object"),
+			},
+		})
+		return newObject(opChain, nil)
+	}
+
+	return newObject(opChain, data)
+}
+
 // ContainsMaxAge succeeds if cookie has Max-Age field.
 //
 // In particular, if Max-Age is present and is zero (which means delete
@@ -280,3 +345,510 @@ func (c *Cookie) MaxAge() *Duration {
 		return newDuration(opChain, &age)
 	}
 }
+
+// HasSameSite succeeds if cookie SameSite attribute is equal to given mode.
+//
+// http.SameSiteDefaultMode means that SameSite attribute is present
+// without value or absent.
+//
+// Example:
+//
+//	cookie := NewCookie(t, &http.Cookie{...})
+//	cookie.HasSameSite(http.SameSiteStrictMode)
+func (c *Cookie) HasSameSite(mode http.SameSite) *Cookie {
+	opChain := c.chain.enter("HasSameSite(%s)", sameSiteText(mode))
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	if normalizeSameSite(c.value.SameSite) != normalizeSameSite(mode) {
+		opChain.fail(AssertionFailure{
+			Type: AssertEqual,
+			Actual: &AssertionValue{
+				sameSiteText(normalizeSameSite(c.value.SameSite)),
+			},
+			Expected: &AssertionValue{sameSiteText(mode)},
+			Errors: []error{
+				errors.New("expected: cookie has given SameSite attribute"),
+			},
+		})
+	}
+
+	return c
+}
+
+// IsSecure succeeds if cookie has Secure attribute.
+//
+// Example:
+//
+//	cookie := NewCookie(t, &http.Cookie{...})
+//	cookie.IsSecure()
+func (c *Cookie) IsSecure() *Cookie {
+	opChain := c.chain.enter("IsSecure()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	if !c.value.Secure {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{c.value},
+			Errors: []error{
+				errors.New("expected: cookie has Secure attribute"),
+			},
+		})
+	}
+
+	return c
+}
+
+// NotSecure succeeds if cookie does not have Secure attribute.
+//
+// Example:
+//
+//	cookie := NewCookie(t, &http.Cookie{...})
+//	cookie.NotSecure()
+func (c *Cookie) NotSecure() *Cookie {
+	opChain := c.chain.enter("NotSecure()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	if c.value.Secure {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotValid,
+			Actual: &AssertionValue{c.value},
+			Errors: []error{
+				errors.New("expected: cookie does not have Secure attribute"),
+			},
+		})
+	}
+
+	return c
+}
+
+// IsHTTPOnly succeeds if cookie has HttpOnly attribute.
+//
+// Example:
+//
+//	cookie := NewCookie(t, &http.Cookie{...})
+//	cookie.IsHTTPOnly()
+func (c *Cookie) IsHTTPOnly() *Cookie {
+	opChain := c.chain.enter("IsHTTPOnly()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	if !c.value.HttpOnly {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{c.value},
+			Errors: []error{
+				errors.New("expected: cookie has HttpOnly attribute"),
+			},
+		})
+	}
+
+	return c
+}
+
+// NotHTTPOnly succeeds if cookie does not have HttpOnly attribute.
+//
+// Example:
+//
+//	cookie := NewCookie(t, &http.Cookie{...})
+//	cookie.NotHTTPOnly()
+func (c *Cookie) NotHTTPOnly() *Cookie {
+	opChain := c.chain.enter("NotHTTPOnly()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	if c.value.HttpOnly {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotValid,
+			Actual: &AssertionValue{c.value},
+			Errors: []error{
+				errors.New("expected: cookie does not have HttpOnly attribute"),
+			},
+		})
+	}
+
+	return c
+}
+
+// IsPartitioned succeeds if cookie has Partitioned attribute (CHIPS).
+//
+// Example:
+//
+//	cookie := NewCookie(t, &http.Cookie{...})
+//	cookie.IsPartitioned()
+func (c *Cookie) IsPartitioned() *Cookie {
+	opChain := c.chain.enter("IsPartitioned()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	if !cookiePartitioned(c.value) {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{c.value},
+			Errors: []error{
+				errors.New("expected: cookie has Partitioned attribute"),
+			},
+		})
+	}
+
+	return c
+}
+
+// NotPartitioned succeeds if cookie does not have Partitioned attribute.
+//
+// Example:
+//
+//	cookie := NewCookie(t, &http.Cookie{...})
+//	cookie.NotPartitioned()
+func (c *Cookie) NotPartitioned() *Cookie {
+	opChain := c.chain.enter("NotPartitioned()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	if cookiePartitioned(c.value) {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotValid,
+			Actual: &AssertionValue{c.value},
+			Errors: []error{
+				errors.New("expected: cookie does not have Partitioned attribute"),
+			},
+		})
+	}
+
+	return c
+}
+
+// HasCoherentExpiry succeeds if cookie Max-Age and Expires attributes
+// don't contradict each other.
+//
+// If only one of the attributes is present, or none, method succeeds.
+// If both are present, Expires should be equal to current time plus
+// Max-Age, with given tolerance. If Max-Age is zero or negative, which
+// means delete cookie now, Expires should be in the past.
+//
+// Current time is taken from Config.Clock.
+//
+// Example:
+//
+//	cookie := NewCookie(t, &http.Cookie{...})
+//	cookie.HasCoherentExpiry(time.Minute)
+func (c *Cookie) HasCoherentExpiry(tolerance time.Duration) *Cookie {
+	opChain := c.chain.enter("HasCoherentExpiry()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	if c.value.MaxAge == 0 || c.value.Expires.IsZero() {
+		return c
+	}
+
+	now := opChain.now()
+
+	if c.value.MaxAge < 0 {
+		if c.value.Expires.After(now) {
+			opChain.fail(AssertionFailure{
+				Type:     AssertLt,
+				Actual:   &AssertionValue{c.value.Expires},
+				Expected: &AssertionValue{now},
+				Errors: []error{
+					errors.New("expected: cookie with non-positive Max-Age" +
+						" has Expires in the past"),
+				},
+			})
+		}
+		return c
+	}
+
+	expected := now.Add(time.Duration(c.value.MaxAge) * time.Second)
+
+	min, max := expected.Add(-tolerance), expected.Add(tolerance)
+
+	if c.value.Expires.Before(min) || c.value.Expires.After(max) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertInRange,
+			Actual:   &AssertionValue{c.value.Expires},
+			Expected: &AssertionValue{AssertionRange{min, max}},
+			Errors: []error{
+				errors.New("expected: cookie Expires matches Max-Age"),
+			},
+		})
+	}
+
+	return c
+}
+
+// IsDeletion succeeds if cookie instructs client to delete the cookie.
+//
+// Cookie is considered a deletion if its value is empty and it either
+// has Max-Age zero or negative, or has Expires in the past. Current time
+// is taken from Config.Clock.
+//
+// Example:
+//
+//	resp := e.POST("/logout").Expect()
+//	resp.Cookie("session").IsDeletion()
+func (c *Cookie) IsDeletion() *Cookie {
+	opChain := c.chain.enter("IsDeletion()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	if c.value.Value != "" {
+		opChain.fail(AssertionFailure{
+			Type:   AssertEmpty,
+			Actual: &AssertionValue{c.value.Value},
+			Errors: []error{
+				errors.New("expected: deletion cookie has empty value"),
+			},
+		})
+		return c
+	}
+
+	if !cookieExpired(c.value, opChain.now()) {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{c.value},
+			Errors: []error{
+				errors.New("expected: deletion cookie has non-positive Max-Age" +
+					" or Expires in the past"),
+			},
+		})
+	}
+
+	return c
+}
+
+// NotDeletion succeeds if cookie does not instruct client to delete
+// the cookie.
+//
+// See IsDeletion for details.
+//
+// Example:
+//
+//	resp := e.POST("/login").Expect()
+//	resp.Cookie("session").NotDeletion()
+func (c *Cookie) NotDeletion() *Cookie {
+	opChain := c.chain.enter("NotDeletion()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	if c.value.Value == "" && cookieExpired(c.value, opChain.now()) {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotValid,
+			Actual: &AssertionValue{c.value},
+			Errors: []error{
+				errors.New("expected: cookie is not a deletion"),
+			},
+		})
+	}
+
+	return c
+}
+
+// IsScopedTo succeeds if cookie would be sent by browser with request
+// to given URL, according to its Domain, Path, and Secure attributes.
+//
+// Matching follows RFC 6265, sections 5.1.3 and 5.1.4. If cookie has
+// no Domain attribute (host-only cookie), only path and scheme are
+// checked, because the origin host is unknown.
+//
+// Example:
+//
+//	cookie := NewCookie(t, &http.Cookie{...})
+//	cookie.IsScopedTo("https://api.example.com/v1/users")
+func (c *Cookie) IsScopedTo(rawURL string) *Cookie {
+	opChain := c.chain.enter("IsScopedTo(%q)", rawURL)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected invalid URL argument"),
+				err,
+			},
+		})
+		return c
+	}
+
+	if !cookieMatchesURL(c.value, u) {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{c.value},
+			Errors: []error{
+				errors.New("expected: cookie is sent to given URL"),
+			},
+		})
+	}
+
+	return c
+}
+
+// NotScopedTo succeeds if cookie would not be sent by browser with
+// request to given URL.
+//
+// See IsScopedTo for details.
+//
+// Example:
+//
+//	cookie := NewCookie(t, &http.Cookie{...})
+//	cookie.NotScopedTo("https://other.com/")
+func (c *Cookie) NotScopedTo(rawURL string) *Cookie {
+	opChain := c.chain.enter("NotScopedTo(%q)", rawURL)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected invalid URL argument"),
+				err,
+			},
+		})
+		return c
+	}
+
+	if cookieMatchesURL(c.value, u) {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotValid,
+			Actual: &AssertionValue{c.value},
+			Errors: []error{
+				errors.New("expected: cookie is not sent to given URL"),
+			},
+		})
+	}
+
+	return c
+}
+
+// cookieExpired reports whether cookie is already expired at given
+// time, i.e. has Max-Age zero or negative or Expires in the past.
+// Note that http.Cookie represents "Max-Age=0" as negative MaxAge.
+func cookieExpired(cookie *http.Cookie, now time.Time) bool {
+	if cookie.MaxAge < 0 {
+		return true
+	}
+	return !cookie.Expires.IsZero() && !cookie.Expires.After(now)
+}
+
+func normalizeSameSite(mode http.SameSite) http.SameSite {
+	if mode == 0 {
+		return http.SameSiteDefaultMode
+	}
+	return mode
+}
+
+func sameSiteText(mode http.SameSite) string {
+	switch mode {
+	case http.SameSiteDefaultMode:
+		return "Default"
+	case http.SameSiteLaxMode:
+		return "Lax"
+	case http.SameSiteStrictMode:
+		return "Strict"
+	case http.SameSiteNoneMode:
+		return "None"
+	}
+	return fmt.Sprintf("SameSite(%d)", int(mode))
+}
+
+// cookiePartitioned reports whether cookie has Partitioned attribute.
+// http.Cookie.Partitioned field is not available in older Go versions,
+// so we also look into unparsed attributes.
+func cookiePartitioned(cookie *http.Cookie) bool {
+	field := reflect.ValueOf(cookie).Elem().FieldByName("Partitioned")
+	if field.IsValid() && field.Kind() == reflect.Bool && field.Bool() {
+		return true
+	}
+
+	attrs := append([]string(nil), cookie.Unparsed...)
+	if cookie.Raw != "" {
+		attrs = append(attrs, strings.Split(cookie.Raw, ";")...)
+	}
+
+	for _, attr := range attrs {
+		if strings.EqualFold(strings.TrimSpace(attr), "Partitioned") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func cookieMatchesURL(cookie *http.Cookie, u *url.URL) bool {
+	if cookie.Secure && u.Scheme != "https" && u.Scheme != "wss" {
+		return false
+	}
+
+	if domain := strings.TrimPrefix(strings.ToLower(cookie.Domain), "."); domain != "" {
+		host := strings.ToLower(u.Hostname())
+
+		if host != domain {
+			if !strings.HasSuffix(host, "."+domain) || net.ParseIP(host) != nil {
+				return false
+			}
+		}
+	}
+
+	cookiePath := cookie.Path
+	if cookiePath == "" || cookiePath[0] != '/' {
+		cookiePath = "/"
+	}
+
+	reqPath := u.EscapedPath()
+	if reqPath == "" {
+		reqPath = "/"
+	}
+
+	if reqPath == cookiePath {
+		return true
+	}
+
+	if strings.HasPrefix(reqPath, cookiePath) {
+		if strings.HasSuffix(cookiePath, "/") || reqPath[len(cookiePath)] == '/' {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,198 @@
+package httpexpect
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"strconv"
+	"strings"
+)
+
+// CookieCodec decodes signed or encrypted cookie value into a payload.
+//
+// Decode receives cookie name and raw cookie value and returns decoded
+// payload. Payload is typically map[string]interface{}, which allows
+// to inspect it using Object.
+//
+// Builtin implementations are SecureCookieCodec and JWTCookieCodec.
+// CookieCodecFunc can be used to adapt a function.
+type CookieCodec interface {
+	Decode(name, value string) (interface{}, error)
+}
+
+// CookieCodecFunc is an adapter that allows a function to be used
+// as the CookieCodec
+//
+// Example:
+//
+//	codec := CookieCodecFunc(func(name, value string) (interface{}, error) {
+//		return myframework.DecodeSession(value)
+//	})
+type CookieCodecFunc func(name, value string) (interface{}, error)
+
+// Decode implements CookieCodec.Decode.
+func (f CookieCodecFunc) Decode(name, value string) (interface{}, error) {
+	return f(name, value)
+}
+
+// SecureCookieCodec decodes cookies produced by gorilla/securecookie.
+//
+// HashKey is used to verify HMAC-SHA256 signature of the cookie and
+// is required. BlockKey, if set, is used to decrypt AES-CTR encrypted
+// cookies; it should be 16, 24, or 32 bytes long.
+//
+// Only cookies serialized with securecookie.JSONEncoder are supported;
+// default gob serialization can't be decoded without knowing the
+// original Go type. Timestamp embedded into cookie is not checked.
+//
+// Example:
+//
+//	resp.Cookie("session").
+//		DecodeWith(SecureCookieCodec{HashKey: hashKey, BlockKey: blockKey}).
+//		HasValue("user", "alice")
+type SecureCookieCodec struct {
+	HashKey  []byte
+	BlockKey []byte
+}
+
+// Decode implements CookieCodec.Decode.
+func (c SecureCookieCodec) Decode(name, value string) (interface{}, error) {
+	if len(c.HashKey) == 0 {
+		return nil, errors.New("securecookie: hash key is not set")
+	}
+
+	b, err := base64.URLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("securecookie: invalid base64 encoding: %w", err)
+	}
+
+	// b is "date|value|mac"
+	parts := bytes.SplitN(b, []byte("|"), 3)
+	if len(parts) != 3 {
+		return nil, errors.New("securecookie: invalid format")
+	}
+
+	signed := append([]byte(name+"|"), b[:len(b)-len(parts[2])-1]...)
+
+	mac := hmac.New(sha256.New, c.HashKey)
+	mac.Write(signed)
+	if !hmac.Equal(mac.Sum(nil), parts[2]) {
+		return nil, errors.New("securecookie: signature mismatch")
+	}
+
+	if _, err := strconv.ParseInt(string(parts[0]), 10, 64); err != nil {
+		return nil, errors.New("securecookie: invalid timestamp")
+	}
+
+	payload, err := base64.URLEncoding.DecodeString(string(parts[1]))
+	if err != nil {
+		return nil, fmt.Errorf("securecookie: invalid base64 encoding: %w", err)
+	}
+
+	if len(c.BlockKey) != 0 {
+		block, err := aes.NewCipher(c.BlockKey)
+		if err != nil {
+			return nil, fmt.Errorf("securecookie: invalid block key: %w", err)
+		}
+
+		size := block.BlockSize()
+		if len(payload) <= size {
+			return nil, errors.New("securecookie: encrypted value is too short")
+		}
+
+		iv, data := payload[:size], payload[size:]
+		cipher.NewCTR(block, iv).XORKeyStream(data, data)
+
+		payload = data
+	}
+
+	var result interface{}
+	if err := json.Unmarshal(payload, &result); err != nil {
+		return nil, fmt.Errorf("securecookie: invalid JSON payload: %w", err)
+	}
+
+	return result, nil
+}
+
+// JWTCookieCodec decodes cookies holding JSON Web Token (RFC 7519) and
+// returns token claims.
+//
+// If Key is set, token signature is verified using HMAC algorithm
+// from token header (HS256, HS384, or HS512), and tokens with other
+// algorithms are rejected. If Key is not set, signature is not verified.
+//
+// Registered claims, like "exp", are not validated; they can be checked
+// using Object assertions.
+//
+// Example:
+//
+//	resp.Cookie("token").
+//		DecodeWith(JWTCookieCodec{Key: secret}).
+//		HasValue("sub", "alice")
+type JWTCookieCodec struct {
+	Key []byte
+}
+
+// Decode implements CookieCodec.Decode.
+func (c JWTCookieCodec) Decode(name, value string) (interface{}, error) {
+	parts := strings.Split(value, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("jwt: token should have three parts")
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := jwtDecodePart(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("jwt: invalid header: %w", err)
+	}
+
+	if len(c.Key) != 0 {
+		var hashFunc func() hash.Hash
+
+		switch header.Alg {
+		case "HS256":
+			hashFunc = sha256.New
+		case "HS384":
+			hashFunc = sha512.New384
+		case "HS512":
+			hashFunc = sha512.New
+		default:
+			return nil, fmt.Errorf("jwt: unsupported algorithm %q", header.Alg)
+		}
+
+		signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+		if err != nil {
+			return nil, fmt.Errorf("jwt: invalid signature encoding: %w", err)
+		}
+
+		mac := hmac.New(hashFunc, c.Key)
+		mac.Write([]byte(parts[0] + "." + parts[1]))
+		if !hmac.Equal(mac.Sum(nil), signature) {
+			return nil, errors.New("jwt: signature mismatch")
+		}
+	}
+
+	var claims interface{}
+	if err := jwtDecodePart(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("jwt: invalid claims: %w", err)
+	}
+
+	return claims, nil
+}
+
+func jwtDecodePart(part string, target interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, target)
+}
@@ -0,0 +1,252 @@
+package httpexpect
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// encodeSecureCookie mimics securecookie.Encode with JSONEncoder.
+func encodeSecureCookie(
+	t *testing.T, name string, value interface{}, hashKey, blockKey []byte,
+) string {
+	payload, err := json.Marshal(value)
+	require.NoError(t, err)
+
+	if blockKey != nil {
+		block, err := aes.NewCipher(blockKey)
+		require.NoError(t, err)
+
+		iv := make([]byte, block.BlockSize())
+		for i := range iv {
+			iv[i] = byte(i)
+		}
+
+		data := append([]byte{}, payload...)
+		cipher.NewCTR(block, iv).XORKeyStream(data, data)
+
+		payload = append(iv, data...)
+	}
+
+	b := []byte(fmt.Sprintf("%s|%d|%s|",
+		name, 1700000000, base64.URLEncoding.EncodeToString(payload)))
+
+	mac := hmac.New(sha256.New, hashKey)
+	mac.Write(b[:len(b)-1])
+
+	b = append(b, mac.Sum(nil)...)[len(name)+1:]
+
+	return base64.URLEncoding.EncodeToString(b)
+}
+
+func encodeJWT(
+	t *testing.T, alg string, hashFunc func() hash.Hash, key []byte, claims interface{},
+) string {
+	header, err := json.Marshal(map[string]string{"alg": alg, "typ": "JWT"})
+	require.NoError(t, err)
+
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." +
+		base64.RawURLEncoding.EncodeToString(payload)
+
+	var signature []byte
+	if hashFunc != nil {
+		mac := hmac.New(hashFunc, key)
+		mac.Write([]byte(signed))
+		signature = mac.Sum(nil)
+	}
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestCookieCodec_Func(t *testing.T) {
+	codec := CookieCodecFunc(func(name, value string) (interface{}, error) {
+		return map[string]interface{}{name: value}, nil
+	})
+
+	payload, err := codec.Decode("a", "b")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": "b"}, payload)
+}
+
+func TestCookieCodec_SecureCookie(t *testing.T) {
+	hashKey := []byte("0123456789abcdef0123456789abcdef")
+	blockKey := []byte("fedcba9876543210")
+
+	data := map[string]interface{}{
+		"user": "alice",
+		"id":   42.0,
+	}
+
+	t.Run("signed", func(t *testing.T) {
+		value := encodeSecureCookie(t, "session", data, hashKey, nil)
+
+		payload, err := SecureCookieCodec{HashKey: hashKey}.Decode("session", value)
+		assert.NoError(t, err)
+		assert.Equal(t, data, payload)
+	})
+
+	t.Run("encrypted", func(t *testing.T) {
+		value := encodeSecureCookie(t, "session", data, hashKey, blockKey)
+
+		payload, err := SecureCookieCodec{
+			HashKey:  hashKey,
+			BlockKey: blockKey,
+		}.Decode("session", value)
+		assert.NoError(t, err)
+		assert.Equal(t, data, payload)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		signed := encodeSecureCookie(t, "session", data, hashKey, nil)
+		encrypted := encodeSecureCookie(t, "session", data, hashKey, blockKey)
+
+		cases := []struct {
+			name   string
+			codec  SecureCookieCodec
+			cookie string
+			value  string
+		}{
+			{
+				name:   "no hash key",
+				codec:  SecureCookieCodec{},
+				cookie: "session",
+				value:  signed,
+			},
+			{
+				name:   "wrong hash key",
+				codec:  SecureCookieCodec{HashKey: []byte("bad")},
+				cookie: "session",
+				value:  signed,
+			},
+			{
+				name:   "wrong cookie name",
+				codec:  SecureCookieCodec{HashKey: hashKey},
+				cookie: "other",
+				value:  signed,
+			},
+			{
+				name:   "invalid base64",
+				codec:  SecureCookieCodec{HashKey: hashKey},
+				cookie: "session",
+				value:  "!!!",
+			},
+			{
+				name:   "invalid format",
+				codec:  SecureCookieCodec{HashKey: hashKey},
+				cookie: "session",
+				value:  base64.URLEncoding.EncodeToString([]byte("abc")),
+			},
+			{
+				name:   "encrypted without block key",
+				codec:  SecureCookieCodec{HashKey: hashKey},
+				cookie: "session",
+				value:  encrypted,
+			},
+			{
+				name: "invalid block key",
+				codec: SecureCookieCodec{
+					HashKey:  hashKey,
+					BlockKey: []byte("short"),
+				},
+				cookie: "session",
+				value:  encrypted,
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := tc.codec.Decode(tc.cookie, tc.value)
+				assert.Error(t, err)
+			})
+		}
+	})
+}
+
+func TestCookieCodec_JWT(t *testing.T) {
+	key := []byte("secret")
+
+	claims := map[string]interface{}{
+		"sub": "alice",
+		"exp": 1700000000.0,
+	}
+
+	t.Run("algorithms", func(t *testing.T) {
+		cases := []struct {
+			alg      string
+			hashFunc func() hash.Hash
+		}{
+			{"HS256", sha256.New},
+			{"HS384", sha512.New384},
+			{"HS512", sha512.New},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.alg, func(t *testing.T) {
+				token := encodeJWT(t, tc.alg, tc.hashFunc, key, claims)
+
+				payload, err := JWTCookieCodec{Key: key}.Decode("token", token)
+				assert.NoError(t, err)
+				assert.Equal(t, claims, payload)
+			})
+		}
+	})
+
+	t.Run("unverified", func(t *testing.T) {
+		token := encodeJWT(t, "RS256", nil, nil, claims)
+
+		payload, err := JWTCookieCodec{}.Decode("token", token)
+		assert.NoError(t, err)
+		assert.Equal(t, claims, payload)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		cases := []struct {
+			name  string
+			key   []byte
+			token string
+		}{
+			{
+				name:  "wrong key",
+				key:   []byte("other"),
+				token: encodeJWT(t, "HS256", sha256.New, key, claims),
+			},
+			{
+				name:  "unsupported algorithm",
+				key:   key,
+				token: encodeJWT(t, "none", nil, nil, claims),
+			},
+			{
+				name:  "wrong number of parts",
+				token: "a.b",
+			},
+			{
+				name:  "invalid header",
+				token: "!!!.e30.",
+			},
+			{
+				name:  "invalid claims",
+				token: base64.RawURLEncoding.EncodeToString([]byte(`{}`)) + ".!!!.",
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := JWTCookieCodec{Key: tc.key}.Decode("token", tc.token)
+				assert.Error(t, err)
+			})
+		}
+	})
+}
@@ -0,0 +1,366 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// CookieList provides methods to inspect a list of http.Cookie values,
+// e.g. all cookies set by Set-Cookie headers of a response.
+//
+// Unlike Response.Cookie, which looks up a single cookie by name, CookieList
+// keeps every Set-Cookie occurrence, including repeated ones for the same
+// name, so that multiplicity can be checked precisely.
+type CookieList struct {
+	noCopy noCopy
+	chain  *chain
+	value  []*http.Cookie
+}
+
+// NewCookieList returns a new CookieList instance.
+//
+// If reporter is nil, the function panics.
+// If value is nil, failure is reported.
+//
+// Example:
+//
+//	list := NewCookieList(t, []*http.Cookie{...})
+//
+//	list.Names().ContainsOnly("session", "csrf")
+//	list.IsUnique()
+func NewCookieList(reporter Reporter, value []*http.Cookie) *CookieList {
+	return newCookieList(newChainWithDefaults("CookieList()", reporter), value)
+}
+
+// NewCookieListC returns a new CookieList instance with config.
+//
+// Requirements for config are same as for WithConfig function.
+// If value is nil, failure is reported.
+//
+// See NewCookieList for usage example.
+func NewCookieListC(config Config, value []*http.Cookie) *CookieList {
+	return newCookieList(
+		newChainWithConfig("CookieList()", config.withDefaults()), value)
+}
+
+func newCookieList(parent *chain, val []*http.Cookie) *CookieList {
+	l := &CookieList{chain: parent.clone(), value: nil}
+
+	opChain := l.chain.enter("")
+	defer opChain.leave()
+
+	if val == nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{val},
+			Errors: []error{
+				errors.New("expected: non-nil cookie list"),
+			},
+		})
+	} else {
+		for _, c := range val {
+			if c == nil {
+				opChain.fail(AssertionFailure{
+					Type:   AssertNotNil,
+					Actual: &AssertionValue{val},
+					Errors: []error{
+						errors.New("expected: cookie list without nil elements"),
+					},
+				})
+				return l
+			}
+		}
+		l.value = append([]*http.Cookie{}, val...)
+	}
+
+	return l
+}
+
+// Raw returns underlying cookies attached to CookieList.
+// This is the value originally passed to NewCookieList.
+//
+// Example:
+//
+//	list := NewCookieList(t, cookies)
+//	assert.Equal(t, cookies, list.Raw())
+func (l *CookieList) Raw() []*http.Cookie {
+	return l.value
+}
+
+// Alias is similar to Value.Alias.
+func (l *CookieList) Alias(name string) *CookieList {
+	opChain := l.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	l.chain.setAlias(name)
+	return l
+}
+
+// Length returns a new Number instance with number of cookies in list.
+//
+// Repeated cookies with the same name are counted separately.
+//
+// Example:
+//
+//	list := NewCookieList(t, []*http.Cookie{...})
+//	list.Length().IsEqual(2)
+func (l *CookieList) Length() *Number {
+	opChain := l.chain.enter("Length()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, 0)
+	}
+
+	return newNumber(opChain, float64(len(l.value)))
+}
+
+// Names returns a new Array instance with cookie names, in the order
+// in which cookies appear in list.
+//
+// If a cookie is repeated, its name appears in array multiple times.
+//
+// Example:
+//
+//	list := NewCookieList(t, []*http.Cookie{...})
+//	list.Names().ContainsOnly("session", "csrf")
+func (l *CookieList) Names() *Array {
+	opChain := l.chain.enter("Names()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	names := []interface{}{}
+	for _, c := range l.value {
+		names = append(names, c.Name)
+	}
+
+	return newArray(opChain, names)
+}
+
+// Get returns a new Cookie instance with cookie with given name.
+//
+// If there are several cookies with this name, the last one is returned,
+// because it's the one that client will end up storing.
+// If there are no such cookies, failure is reported.
+//
+// Example:
+//
+//	list := NewCookieList(t, []*http.Cookie{...})
+//	list.Get("session").Value().NotEmpty()
+func (l *CookieList) Get(name string) *Cookie {
+	opChain := l.chain.enter("Get(%q)", name)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newCookie(opChain, nil)
+	}
+
+	for i := len(l.value) - 1; i >= 0; i-- {
+		if l.value[i].Name == name {
+			return newCookie(opChain, l.value[i])
+		}
+	}
+
+	opChain.fail(AssertionFailure{
+		Type:     AssertContainsElement,
+		Actual:   &AssertionValue{l.names()},
+		Expected: &AssertionValue{name},
+		Errors: []error{
+			errors.New("expected: cookie list contains cookie with given name"),
+		},
+	})
+
+	return newCookie(opChain, nil)
+}
+
+// IsEmpty succeeds if list has no cookies.
+//
+// Example:
+//
+//	list := NewCookieList(t, []*http.Cookie{})
+//	list.IsEmpty()
+func (l *CookieList) IsEmpty() *CookieList {
+	opChain := l.chain.enter("IsEmpty()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return l
+	}
+
+	if len(l.value) != 0 {
+		opChain.fail(AssertionFailure{
+			Type:   AssertEmpty,
+			Actual: &AssertionValue{l.names()},
+			Errors: []error{
+				errors.New("expected: empty cookie list"),
+			},
+		})
+	}
+
+	return l
+}
+
+// NotEmpty succeeds if list has at least one cookie.
+//
+// Example:
+//
+//	list := NewCookieList(t, []*http.Cookie{...})
+//	list.NotEmpty()
+func (l *CookieList) NotEmpty() *CookieList {
+	opChain := l.chain.enter("NotEmpty()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return l
+	}
+
+	if len(l.value) == 0 {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotEmpty,
+			Actual: &AssertionValue{l.names()},
+			Errors: []error{
+				errors.New("expected: non-empty cookie list"),
+			},
+		})
+	}
+
+	return l
+}
+
+// HasCount succeeds if list has exactly n cookies with given name.
+//
+// Zero n can be used to check that there are no cookies with given name.
+//
+// Example:
+//
+//	list := NewCookieList(t, []*http.Cookie{...})
+//	list.HasCount("session", 1)
+func (l *CookieList) HasCount(name string, n int) *CookieList {
+	opChain := l.chain.enter("HasCount(%q, %d)", name, n)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return l
+	}
+
+	if n < 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected negative count argument"),
+			},
+		})
+		return l
+	}
+
+	count := 0
+	for _, c := range l.value {
+		if c.Name == name {
+			count++
+		}
+	}
+
+	if count != n {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{count},
+			Expected: &AssertionValue{n},
+			Errors: []error{
+				fmt.Errorf("expected: cookie list has %d cookie(s) with name %q",
+					n, name),
+			},
+		})
+	}
+
+	return l
+}
+
+// IsUnique succeeds if every cookie name appears in list only once.
+//
+// Repeated Set-Cookie headers for the same name usually indicate a bug:
+// only the last one takes effect, and the others are silently ignored.
+//
+// Example:
+//
+//	list := NewCookieList(t, []*http.Cookie{...})
+//	list.IsUnique()
+func (l *CookieList) IsUnique() *CookieList {
+	opChain := l.chain.enter("IsUnique()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return l
+	}
+
+	if dups := l.duplicates(); len(dups) != 0 {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{l.names()},
+			Errors: []error{
+				errors.New("expected: cookie list has no repeated cookie names"),
+				fmt.Errorf("repeated cookie names: %q", dups),
+			},
+		})
+	}
+
+	return l
+}
+
+// NotUnique succeeds if at least one cookie name appears in list
+// more than once.
+//
+// Example:
+//
+//	list := NewCookieList(t, []*http.Cookie{...})
+//	list.NotUnique()
+func (l *CookieList) NotUnique() *CookieList {
+	opChain := l.chain.enter("NotUnique()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return l
+	}
+
+	if dups := l.duplicates(); len(dups) == 0 {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotValid,
+			Actual: &AssertionValue{l.names()},
+			Errors: []error{
+				errors.New("expected: cookie list has repeated cookie names"),
+			},
+		})
+	}
+
+	return l
+}
+
+func (l *CookieList) names() []string {
+	names := []string{}
+	for _, c := range l.value {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+// duplicates returns names that appear more than once, in order of
+// their first appearance.
+func (l *CookieList) duplicates() []string {
+	counts := map[string]int{}
+	for _, c := range l.value {
+		counts[c.Name]++
+	}
+
+	dups := []string{}
+	for _, c := range l.value {
+		if counts[c.Name] > 1 {
+			dups = append(dups, c.Name)
+			counts[c.Name] = 0
+		}
+	}
+
+	return dups
+}
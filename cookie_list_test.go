@@ -0,0 +1,251 @@
+package httpexpect
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCookieList_FailedChain(t *testing.T) {
+	check := func(value *CookieList, isNil bool) {
+		value.chain.assert(t, failure)
+
+		if isNil {
+			assert.Nil(t, value.Raw())
+		} else {
+			assert.NotNil(t, value.Raw())
+		}
+
+		value.Alias("foo")
+
+		value.Length().chain.assert(t, failure)
+		value.Names().chain.assert(t, failure)
+		value.Get("foo").chain.assert(t, failure)
+
+		value.IsEmpty()
+		value.NotEmpty()
+		value.HasCount("foo", 1)
+		value.IsUnique()
+		value.NotUnique()
+	}
+
+	t.Run("failed chain", func(t *testing.T) {
+		chain := newMockChain(t, flagFailed)
+		value := newCookieList(chain, []*http.Cookie{})
+
+		check(value, false)
+	})
+
+	t.Run("nil value", func(t *testing.T) {
+		chain := newMockChain(t)
+		value := newCookieList(chain, nil)
+
+		check(value, true)
+	})
+
+	t.Run("nil element", func(t *testing.T) {
+		chain := newMockChain(t)
+		value := newCookieList(chain, []*http.Cookie{{Name: "a"}, nil})
+
+		check(value, true)
+	})
+
+	t.Run("failed chain, nil value", func(t *testing.T) {
+		chain := newMockChain(t, flagFailed)
+		value := newCookieList(chain, nil)
+
+		check(value, true)
+	})
+}
+
+func TestCookieList_Constructors(t *testing.T) {
+	cookies := []*http.Cookie{
+		{Name: "a", Value: "1"},
+		{Name: "b", Value: "2"},
+	}
+
+	t.Run("reporter", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewCookieList(reporter, cookies)
+		value.Length().IsEqual(2)
+		value.chain.assert(t, success)
+	})
+
+	t.Run("config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewCookieListC(Config{
+			Reporter: reporter,
+		}, cookies)
+		value.Length().IsEqual(2)
+		value.chain.assert(t, success)
+	})
+
+	t.Run("chain", func(t *testing.T) {
+		chain := newMockChain(t)
+		value := newCookieList(chain, cookies)
+		assert.NotSame(t, value.chain, &chain)
+		assert.Equal(t, value.chain.context.Path, chain.context.Path)
+	})
+}
+
+func TestCookieList_Getters(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	cookies := []*http.Cookie{
+		{Name: "a", Value: "1"},
+		{Name: "b", Value: "2"},
+		{Name: "a", Value: "3"},
+	}
+
+	list := NewCookieList(reporter, cookies)
+
+	assert.Equal(t, cookies, list.Raw())
+
+	list.Length().IsEqual(3)
+	list.chain.assert(t, success)
+
+	assert.Equal(t, []interface{}{"a", "b", "a"}, list.Names().Raw())
+	list.chain.assert(t, success)
+
+	list.Get("a").Value().IsEqual("3")
+	list.chain.assert(t, success)
+
+	list.Get("b").Value().IsEqual("2")
+	list.chain.assert(t, success)
+
+	cookie := list.Get("c")
+	cookie.chain.assert(t, failure)
+	assert.Nil(t, cookie.Raw())
+	list.chain.assert(t, failure)
+}
+
+func TestCookieList_IsEmpty(t *testing.T) {
+	cases := []struct {
+		name      string
+		value     []*http.Cookie
+		wantEmpty chainResult
+	}{
+		{
+			name:      "empty",
+			value:     []*http.Cookie{},
+			wantEmpty: success,
+		},
+		{
+			name:      "not empty",
+			value:     []*http.Cookie{{Name: "a"}},
+			wantEmpty: failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			NewCookieList(reporter, tc.value).IsEmpty().
+				chain.assert(t, tc.wantEmpty)
+
+			NewCookieList(reporter, tc.value).NotEmpty().
+				chain.assert(t, !tc.wantEmpty)
+		})
+	}
+}
+
+func TestCookieList_HasCount(t *testing.T) {
+	cookies := []*http.Cookie{
+		{Name: "a"},
+		{Name: "b"},
+		{Name: "a"},
+	}
+
+	cases := []struct {
+		name   string
+		cookie string
+		count  int
+		result chainResult
+	}{
+		{
+			name:   "repeated",
+			cookie: "a",
+			count:  2,
+			result: success,
+		},
+		{
+			name:   "single",
+			cookie: "b",
+			count:  1,
+			result: success,
+		},
+		{
+			name:   "absent",
+			cookie: "c",
+			count:  0,
+			result: success,
+		},
+		{
+			name:   "mismatch",
+			cookie: "a",
+			count:  1,
+			result: failure,
+		},
+		{
+			name:   "negative",
+			cookie: "a",
+			count:  -1,
+			result: failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			NewCookieList(reporter, cookies).HasCount(tc.cookie, tc.count).
+				chain.assert(t, tc.result)
+		})
+	}
+}
+
+func TestCookieList_IsUnique(t *testing.T) {
+	cases := []struct {
+		name       string
+		value      []*http.Cookie
+		wantUnique chainResult
+	}{
+		{
+			name:       "empty",
+			value:      []*http.Cookie{},
+			wantUnique: success,
+		},
+		{
+			name:       "unique",
+			value:      []*http.Cookie{{Name: "a"}, {Name: "b"}},
+			wantUnique: success,
+		},
+		{
+			name:       "repeated",
+			value:      []*http.Cookie{{Name: "a"}, {Name: "b"}, {Name: "a"}},
+			wantUnique: failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			NewCookieList(reporter, tc.value).IsUnique().
+				chain.assert(t, tc.wantUnique)
+
+			NewCookieList(reporter, tc.value).NotUnique().
+				chain.assert(t, !tc.wantUnique)
+		})
+	}
+
+	t.Run("duplicates", func(t *testing.T) {
+		list := NewCookieList(newMockReporter(t), []*http.Cookie{
+			{Name: "b"}, {Name: "a"}, {Name: "b"}, {Name: "a"}, {Name: "b"},
+		})
+
+		assert.Equal(t, []string{"b", "a"}, list.duplicates())
+	})
+}
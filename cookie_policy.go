@@ -0,0 +1,238 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+)
+
+// CookiePolicy defines attributes required for cookies set by responses.
+//
+// Policy is a list of rules. Every rule applies to cookies which names
+// match its pattern. If several rules match a cookie, all of them are
+// applied.
+//
+// Policy is usually defined once and shared between tests of many
+// services, and checked using Response.CookiesComply.
+//
+// Example:
+//
+//	var cookiePolicy = httpexpect.CookiePolicy{
+//		Rules: []httpexpect.CookieRule{
+//			{
+//				Name:     "*",
+//				Secure:   true,
+//				SameSite: http.SameSiteLaxMode,
+//			},
+//			{
+//				Name:        "session*",
+//				HTTPOnly:    true,
+//				SameSite:    http.SameSiteStrictMode,
+//				MaxLifetime: 24 * time.Hour,
+//			},
+//		},
+//	}
+type CookiePolicy struct {
+	// Rules applied to cookies.
+	Rules []CookieRule
+
+	// If true, cookies which names don't match any rule are reported.
+	DenyUnknown bool
+}
+
+// CookieRule defines attributes required for cookies which names match
+// given pattern. Zero fields are not checked.
+type CookieRule struct {
+	// Pattern matched against cookie name, in the syntax of path.Match,
+	// e.g. "session" or "__Host-*". Empty pattern matches any cookie.
+	Name string
+
+	// Cookie should have Secure attribute.
+	Secure bool
+
+	// Cookie should have HttpOnly attribute.
+	HTTPOnly bool
+
+	// Cookie should have Partitioned attribute.
+	Partitioned bool
+
+	// Cookie should have given SameSite attribute.
+	// Use http.SameSiteDefaultMode to require absent attribute.
+	// Zero value means that SameSite is not checked.
+	SameSite http.SameSite
+
+	// Cookie should have given Path attribute.
+	Path string
+
+	// Cookie should have given Domain attribute.
+	// Leading dot is ignored. Use "-" to require absent attribute
+	// (host-only cookie).
+	Domain string
+
+	// Maximum lifetime of cookie, defined by Max-Age or Expires attribute.
+	// If set, session cookies (without Max-Age and Expires) are accepted,
+	// and deletion cookies are not checked.
+	MaxLifetime time.Duration
+
+	// Cookie should not be a session cookie, i.e. should have Max-Age
+	// or Expires attribute.
+	Persistent bool
+}
+
+func (rule *CookieRule) matches(name string) (bool, error) {
+	if rule.Name == "" {
+		return true, nil
+	}
+	return path.Match(rule.Name, name)
+}
+
+func (rule *CookieRule) check(cookie *http.Cookie, now time.Time) []error {
+	var errs []error
+
+	violation := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("cookie %q: %s",
+			cookie.Name, fmt.Sprintf(format, args...)))
+	}
+
+	if rule.Secure && !cookie.Secure {
+		violation("missing Secure attribute")
+	}
+
+	if rule.HTTPOnly && !cookie.HttpOnly {
+		violation("missing HttpOnly attribute")
+	}
+
+	if rule.Partitioned && !cookiePartitioned(cookie) {
+		violation("missing Partitioned attribute")
+	}
+
+	if rule.SameSite != 0 &&
+		normalizeSameSite(cookie.SameSite) != normalizeSameSite(rule.SameSite) {
+		violation("SameSite is %s, expected %s",
+			sameSiteText(normalizeSameSite(cookie.SameSite)), sameSiteText(rule.SameSite))
+	}
+
+	if rule.Path != "" && cookie.Path != rule.Path {
+		violation("Path is %q, expected %q", cookie.Path, rule.Path)
+	}
+
+	if rule.Domain != "" {
+		actual := strings.TrimPrefix(strings.ToLower(cookie.Domain), ".")
+
+		if rule.Domain == "-" {
+			if actual != "" {
+				violation("Domain is %q, expected host-only cookie", cookie.Domain)
+			}
+		} else if expected := strings.TrimPrefix(
+			strings.ToLower(rule.Domain), "."); actual != expected {
+			violation("Domain is %q, expected %q", cookie.Domain, rule.Domain)
+		}
+	}
+
+	isSession := cookie.MaxAge == 0 && cookie.Expires.IsZero()
+
+	if rule.Persistent && isSession {
+		violation("missing Max-Age or Expires attribute")
+	}
+
+	if rule.MaxLifetime > 0 && !isSession && !cookieExpired(cookie, now) {
+		var lifetime time.Duration
+		if cookie.MaxAge > 0 {
+			lifetime = time.Duration(cookie.MaxAge) * time.Second
+		} else {
+			lifetime = cookie.Expires.Sub(now)
+		}
+
+		if lifetime > rule.MaxLifetime {
+			violation("lifetime is %s, expected at most %s",
+				lifetime, rule.MaxLifetime)
+		}
+	}
+
+	return errs
+}
+
+// CookiesComply succeeds if all cookies set by this response comply with
+// given policy.
+//
+// Every cookie is checked against every rule of the policy which pattern
+// matches cookie name. All violations are reported in a single failure.
+// Current time, used to check cookie lifetime, is taken from Config.Clock.
+//
+// Example:
+//
+//	resp := e.POST("/login").Expect()
+//	resp.CookiesComply(httpexpect.CookiePolicy{
+//		Rules: []httpexpect.CookieRule{
+//			{Name: "*", Secure: true, HTTPOnly: true},
+//		},
+//	})
+func (r *Response) CookiesComply(policy CookiePolicy) *Response {
+	opChain := r.chain.enter("CookiesComply()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	for _, rule := range policy.Rules {
+		if _, err := rule.matches(""); err != nil {
+			opChain.fail(AssertionFailure{
+				Type: AssertUsage,
+				Errors: []error{
+					fmt.Errorf("invalid cookie name pattern %q", rule.Name),
+					err,
+				},
+			})
+			return r
+		}
+	}
+
+	now := opChain.now()
+
+	var (
+		violations []error
+		violators  []string
+	)
+
+	for _, cookie := range r.cookies {
+		matched := false
+		var errs []error
+
+		for i := range policy.Rules {
+			rule := &policy.Rules[i]
+
+			if ok, _ := rule.matches(cookie.Name); !ok {
+				continue
+			}
+
+			matched = true
+			errs = append(errs, rule.check(cookie, now)...)
+		}
+
+		if !matched && policy.DenyUnknown {
+			errs = append(errs,
+				fmt.Errorf("cookie %q: not allowed by policy", cookie.Name))
+		}
+
+		if len(errs) != 0 {
+			violations = append(violations, errs...)
+			violators = append(violators, cookie.String())
+		}
+	}
+
+	if len(violations) != 0 {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{violators},
+			Errors: append([]error{
+				errors.New("expected: response cookies comply with cookie policy"),
+			}, violations...),
+		})
+	}
+
+	return r
+}
@@ -0,0 +1,205 @@
+package httpexpect
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCookiePolicy_Comply(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name      string
+		cookies   []string
+		policy    CookiePolicy
+		result    chainResult
+		numErrors int
+	}{
+		{
+			name:    "no rules",
+			cookies: []string{"session=abc"},
+			result:  success,
+		},
+		{
+			name:    "no cookies",
+			cookies: nil,
+			policy: CookiePolicy{
+				Rules:       []CookieRule{{Secure: true}},
+				DenyUnknown: true,
+			},
+			result: success,
+		},
+		{
+			name: "all attributes",
+			cookies: []string{
+				"session=abc; Path=/; Domain=.example.com; Max-Age=3600;" +
+					" Secure; HttpOnly; SameSite=Strict; Partitioned",
+			},
+			policy: CookiePolicy{
+				Rules: []CookieRule{
+					{
+						Name:        "session",
+						Secure:      true,
+						HTTPOnly:    true,
+						Partitioned: true,
+						SameSite:    http.SameSiteStrictMode,
+						Path:        "/",
+						Domain:      "example.com",
+						MaxLifetime: time.Hour,
+						Persistent:  true,
+					},
+				},
+			},
+			result: success,
+		},
+		{
+			name:    "missing attributes",
+			cookies: []string{"session=abc; SameSite=Lax"},
+			policy: CookiePolicy{
+				Rules: []CookieRule{
+					{
+						Name:        "session",
+						Secure:      true,
+						HTTPOnly:    true,
+						Partitioned: true,
+						SameSite:    http.SameSiteStrictMode,
+						Path:        "/",
+						Domain:      "example.com",
+						Persistent:  true,
+					},
+				},
+			},
+			result:    failure,
+			numErrors: 7,
+		},
+		{
+			name:    "pattern",
+			cookies: []string{"__Host-id=1; Secure", "theme=dark"},
+			policy: CookiePolicy{
+				Rules: []CookieRule{
+					{Name: "__Host-*", Secure: true, Domain: "-"},
+				},
+			},
+			result: success,
+		},
+		{
+			name:    "multiple rules",
+			cookies: []string{"session=abc; Secure"},
+			policy: CookiePolicy{
+				Rules: []CookieRule{
+					{Secure: true},
+					{Name: "sess*", HTTPOnly: true},
+				},
+			},
+			result:    failure,
+			numErrors: 1,
+		},
+		{
+			name:    "multiple cookies",
+			cookies: []string{"a=1", "b=2; Secure", "c=3"},
+			policy: CookiePolicy{
+				Rules: []CookieRule{{Name: "*", Secure: true}},
+			},
+			result:    failure,
+			numErrors: 2,
+		},
+		{
+			name:    "host-only violated",
+			cookies: []string{"__Host-id=1; Secure; Domain=example.com"},
+			policy: CookiePolicy{
+				Rules: []CookieRule{{Name: "__Host-*", Domain: "-"}},
+			},
+			result:    failure,
+			numErrors: 1,
+		},
+		{
+			name:    "lifetime max-age",
+			cookies: []string{"session=abc; Max-Age=7200"},
+			policy: CookiePolicy{
+				Rules: []CookieRule{{MaxLifetime: time.Hour}},
+			},
+			result:    failure,
+			numErrors: 1,
+		},
+		{
+			name:    "lifetime expires",
+			cookies: []string{"session=abc; Expires=Mon, 01 Jan 2024 00:30:00 GMT"},
+			policy: CookiePolicy{
+				Rules: []CookieRule{{MaxLifetime: time.Hour}},
+			},
+			result: success,
+		},
+		{
+			name:    "lifetime expires too long",
+			cookies: []string{"session=abc; Expires=Tue, 02 Jan 2024 00:00:00 GMT"},
+			policy: CookiePolicy{
+				Rules: []CookieRule{{MaxLifetime: time.Hour}},
+			},
+			result:    failure,
+			numErrors: 1,
+		},
+		{
+			name:    "lifetime session cookie",
+			cookies: []string{"session=abc"},
+			policy: CookiePolicy{
+				Rules: []CookieRule{{MaxLifetime: time.Hour}},
+			},
+			result: success,
+		},
+		{
+			name:    "lifetime deletion",
+			cookies: []string{"session=; Max-Age=0"},
+			policy: CookiePolicy{
+				Rules: []CookieRule{{MaxLifetime: time.Hour}},
+			},
+			result: success,
+		},
+		{
+			name:    "deny unknown",
+			cookies: []string{"session=abc; Secure", "tracking=1"},
+			policy: CookiePolicy{
+				Rules:       []CookieRule{{Name: "session", Secure: true}},
+				DenyUnknown: true,
+			},
+			result:    failure,
+			numErrors: 1,
+		},
+		{
+			name:    "invalid pattern",
+			cookies: []string{"session=abc"},
+			policy: CookiePolicy{
+				Rules: []CookieRule{{Name: "[", Secure: true}},
+			},
+			result: failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := &mockAssertionHandler{}
+
+			httpResp := &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Set-Cookie": tc.cookies},
+				Body:       newMockBody(""),
+			}
+
+			resp := NewResponseC(Config{
+				AssertionHandler: handler,
+				Clock:            NewFakeClock(now),
+			}, httpResp)
+
+			resp.CookiesComply(tc.policy)
+			resp.chain.assert(t, tc.result)
+
+			if tc.numErrors != 0 {
+				require.NotNil(t, handler.failure)
+				assert.Equal(t, tc.numErrors+1, len(handler.failure.Errors))
+			}
+		})
+	}
+}
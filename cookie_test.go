@@ -1,6 +1,7 @@
 package httpexpect
 
 import (
+	"crypto/sha256"
 	"net/http"
 	"testing"
 	"time"
@@ -27,9 +28,23 @@ func TestCookie_FailedChain(t *testing.T) {
 		value.Path().chain.assert(t, failure)
 		value.Expires().chain.assert(t, failure)
 		value.MaxAge().chain.assert(t, failure)
+		value.DecodeWith(JWTCookieCodec{}).chain.assert(t, failure)
 
 		value.ContainsMaxAge()
 		value.NotContainsMaxAge()
+
+		value.HasSameSite(http.SameSiteLaxMode)
+		value.IsSecure()
+		value.NotSecure()
+		value.IsHTTPOnly()
+		value.NotHTTPOnly()
+		value.IsPartitioned()
+		value.NotPartitioned()
+		value.HasCoherentExpiry(0)
+		value.IsDeletion()
+		value.NotDeletion()
+		value.IsScopedTo("http://example.com")
+		value.NotScopedTo("http://example.com")
 	}
 
 	t.Run("failed chain", func(t *testing.T) {
@@ -208,3 +223,371 @@ func TestCookie_MaxAge(t *testing.T) {
 		})
 	}
 }
+
+func TestCookie_DecodeWith(t *testing.T) {
+	key := []byte("secret")
+
+	token := encodeJWT(t, "HS256", sha256.New, key, map[string]interface{}{
+		"sub": "alice",
+	})
+
+	t.Run("success", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		cookie := NewCookie(reporter, &http.Cookie{Name: "token", Value: token})
+
+		obj := cookie.DecodeWith(JWTCookieCodec{Key: key})
+		obj.HasValue("sub", "alice")
+		obj.chain.assert(t, success)
+		cookie.chain.assert(t, success)
+	})
+
+	t.Run("decode error", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		cookie := NewCookie(reporter, &http.Cookie{Name: "token", Value: token})
+
+		obj := cookie.DecodeWith(JWTCookieCodec{Key: []byte("other")})
+		obj.chain.assert(t, failure)
+		assert.Nil(t, obj.Raw())
+	})
+
+	t.Run("non-object payload", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		codec := CookieCodecFunc(func(name, value string) (interface{}, error) {
+			return value, nil
+		})
+
+		NewCookie(reporter, &http.Cookie{Name: "a", Value: "b"}).
+			DecodeWith(codec).chain.assert(t, failure)
+	})
+
+	t.Run("nil codec", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		NewCookie(reporter, &http.Cookie{Name: "a", Value: "b"}).
+			DecodeWith(nil).chain.assert(t, failure)
+	})
+}
+
+func TestCookie_Attributes(t *testing.T) {
+	t.Run("same site", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		cookie := NewCookie(reporter, &http.Cookie{SameSite: http.SameSiteStrictMode})
+
+		cookie.HasSameSite(http.SameSiteStrictMode).chain.assert(t, success)
+		cookie.chain.clear()
+
+		cookie.HasSameSite(http.SameSiteLaxMode).chain.assert(t, failure)
+		cookie.chain.clear()
+
+		NewCookie(reporter, &http.Cookie{}).
+			HasSameSite(http.SameSiteDefaultMode).chain.assert(t, success)
+	})
+
+	t.Run("flags", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		cases := []struct {
+			name     string
+			cookie   *http.Cookie
+			check    func(*Cookie) *Cookie
+			notCheck func(*Cookie) *Cookie
+		}{
+			{
+				name:     "secure",
+				cookie:   &http.Cookie{Secure: true},
+				check:    (*Cookie).IsSecure,
+				notCheck: (*Cookie).NotSecure,
+			},
+			{
+				name:     "http only",
+				cookie:   &http.Cookie{HttpOnly: true},
+				check:    (*Cookie).IsHTTPOnly,
+				notCheck: (*Cookie).NotHTTPOnly,
+			},
+			{
+				name: "partitioned",
+				cookie: &http.Cookie{
+					Raw: "id=1; Path=/; Secure; Partitioned",
+				},
+				check:    (*Cookie).IsPartitioned,
+				notCheck: (*Cookie).NotPartitioned,
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				tc.check(NewCookie(reporter, tc.cookie)).chain.assert(t, success)
+				tc.notCheck(NewCookie(reporter, tc.cookie)).chain.assert(t, failure)
+
+				tc.check(NewCookie(reporter, &http.Cookie{})).chain.assert(t, failure)
+				tc.notCheck(NewCookie(reporter, &http.Cookie{})).chain.assert(t, success)
+			})
+		}
+	})
+
+	t.Run("partitioned from response", func(t *testing.T) {
+		resp := NewResponse(newMockReporter(t), &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Set-Cookie": {
+					"a=1; Secure; Partitioned",
+					"b=2; Secure",
+				},
+			},
+		})
+
+		resp.Cookie("a").IsPartitioned().chain.assert(t, success)
+		resp.Cookie("b").NotPartitioned().chain.assert(t, success)
+	})
+}
+
+func TestCookie_HasCoherentExpiry(t *testing.T) {
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	config := Config{
+		Reporter: newMockReporter(t),
+		Clock:    NewFakeClock(now),
+	}
+
+	cases := []struct {
+		name   string
+		cookie *http.Cookie
+		result chainResult
+	}{
+		{
+			name:   "no attributes",
+			cookie: &http.Cookie{},
+			result: success,
+		},
+		{
+			name:   "only max age",
+			cookie: &http.Cookie{MaxAge: 60},
+			result: success,
+		},
+		{
+			name:   "only expires",
+			cookie: &http.Cookie{Expires: now.Add(-time.Hour)},
+			result: success,
+		},
+		{
+			name:   "coherent",
+			cookie: &http.Cookie{MaxAge: 3600, Expires: now.Add(time.Hour)},
+			result: success,
+		},
+		{
+			name:   "coherent within tolerance",
+			cookie: &http.Cookie{MaxAge: 3600, Expires: now.Add(59 * time.Minute)},
+			result: success,
+		},
+		{
+			name:   "incoherent",
+			cookie: &http.Cookie{MaxAge: 60, Expires: now.Add(24 * time.Hour)},
+			result: failure,
+		},
+		{
+			name:   "deletion coherent",
+			cookie: &http.Cookie{MaxAge: -1, Expires: time.Unix(0, 0)},
+			result: success,
+		},
+		{
+			name:   "deletion incoherent",
+			cookie: &http.Cookie{MaxAge: -1, Expires: now.Add(time.Hour)},
+			result: failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			NewCookieC(config, tc.cookie).HasCoherentExpiry(2*time.Minute).
+				chain.assert(t, tc.result)
+		})
+	}
+}
+
+func TestCookie_IsDeletion(t *testing.T) {
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	config := Config{
+		Reporter: newMockReporter(t),
+		Clock:    NewFakeClock(now),
+	}
+
+	cases := []struct {
+		name         string
+		cookie       *http.Cookie
+		wantDeletion chainResult
+	}{
+		{
+			name:         "max age zero",
+			cookie:       &http.Cookie{Name: "session", MaxAge: -1},
+			wantDeletion: success,
+		},
+		{
+			name:         "expires in the past",
+			cookie:       &http.Cookie{Name: "session", Expires: time.Unix(0, 0)},
+			wantDeletion: success,
+		},
+		{
+			name:         "expires now",
+			cookie:       &http.Cookie{Name: "session", Expires: now},
+			wantDeletion: success,
+		},
+		{
+			name: "max age zero with value",
+			cookie: &http.Cookie{
+				Name: "session", Value: "deleted", MaxAge: -1,
+			},
+			wantDeletion: failure,
+		},
+		{
+			name:         "expires in the future",
+			cookie:       &http.Cookie{Name: "session", Expires: now.Add(time.Hour)},
+			wantDeletion: failure,
+		},
+		{
+			name:         "positive max age",
+			cookie:       &http.Cookie{Name: "session", MaxAge: 60},
+			wantDeletion: failure,
+		},
+		{
+			name:         "session cookie",
+			cookie:       &http.Cookie{Name: "session"},
+			wantDeletion: failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			NewCookieC(config, tc.cookie).IsDeletion().
+				chain.assert(t, tc.wantDeletion)
+
+			NewCookieC(config, tc.cookie).NotDeletion().
+				chain.assert(t, !tc.wantDeletion)
+		})
+	}
+
+	t.Run("from response", func(t *testing.T) {
+		resp := NewResponse(newMockReporter(t), &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Set-Cookie": {
+					"a=; Max-Age=0",
+					"b=; Expires=Thu, 01 Jan 1970 00:00:00 GMT",
+					"c=1; Max-Age=3600",
+				},
+			},
+		})
+
+		resp.Cookie("a").IsDeletion().chain.assert(t, success)
+		resp.Cookie("b").IsDeletion().chain.assert(t, success)
+		resp.Cookie("c").NotDeletion().chain.assert(t, success)
+	})
+}
+
+func TestCookie_IsScopedTo(t *testing.T) {
+	cases := []struct {
+		name   string
+		cookie *http.Cookie
+		url    string
+		result chainResult
+	}{
+		{
+			name:   "exact domain",
+			cookie: &http.Cookie{Domain: "example.com"},
+			url:    "http://example.com/",
+			result: success,
+		},
+		{
+			name:   "subdomain",
+			cookie: &http.Cookie{Domain: ".example.com"},
+			url:    "http://api.example.com/",
+			result: success,
+		},
+		{
+			name:   "other domain",
+			cookie: &http.Cookie{Domain: "example.com"},
+			url:    "http://example.org/",
+			result: failure,
+		},
+		{
+			name:   "domain suffix without dot",
+			cookie: &http.Cookie{Domain: "example.com"},
+			url:    "http://badexample.com/",
+			result: failure,
+		},
+		{
+			name:   "host-only",
+			cookie: &http.Cookie{},
+			url:    "http://anything.com/path",
+			result: success,
+		},
+		{
+			name:   "path prefix",
+			cookie: &http.Cookie{Path: "/api"},
+			url:    "http://example.com/api/users",
+			result: success,
+		},
+		{
+			name:   "path exact",
+			cookie: &http.Cookie{Path: "/api"},
+			url:    "http://example.com/api",
+			result: success,
+		},
+		{
+			name:   "path prefix without slash",
+			cookie: &http.Cookie{Path: "/api"},
+			url:    "http://example.com/apiv2",
+			result: failure,
+		},
+		{
+			name:   "path with trailing slash",
+			cookie: &http.Cookie{Path: "/api/"},
+			url:    "http://example.com/api/users",
+			result: success,
+		},
+		{
+			name:   "other path",
+			cookie: &http.Cookie{Path: "/api"},
+			url:    "http://example.com/",
+			result: failure,
+		},
+		{
+			name:   "secure over https",
+			cookie: &http.Cookie{Secure: true},
+			url:    "https://example.com/",
+			result: success,
+		},
+		{
+			name:   "secure over http",
+			cookie: &http.Cookie{Secure: true},
+			url:    "http://example.com/",
+			result: failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			NewCookie(reporter, tc.cookie).IsScopedTo(tc.url).
+				chain.assert(t, tc.result)
+
+			NewCookie(reporter, tc.cookie).NotScopedTo(tc.url).
+				chain.assert(t, !tc.result)
+		})
+	}
+
+	t.Run("invalid url", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		NewCookie(reporter, &http.Cookie{}).IsScopedTo("http://[::1").
+			chain.assert(t, failure)
+
+		NewCookie(reporter, &http.Cookie{}).NotScopedTo("http://[::1").
+			chain.assert(t, failure)
+	})
+}
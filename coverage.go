@@ -0,0 +1,561 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// EndpointCoverage describes which parts of endpoint responses were asserted,
+// as recorded by CoverageTracker.
+type EndpointCoverage struct {
+	// Request method, e.g. "GET".
+	Method string
+
+	// URL path, e.g. "/users/123".
+	Path string
+
+	// Status codes of responses on which any assertion was made,
+	// in ascending order.
+	Statuses []int
+
+	// Status codes of responses for which status was asserted
+	// (using Response.Status, StatusRange, or StatusList), in ascending order.
+	AssertedStatuses []int
+
+	// JSON paths of response body fields that were asserted,
+	// e.g. "$.items[*].id", in ascending order.
+	Fields []string
+}
+
+// CoverageTracker is AssertionHandler that records which endpoints, status
+// codes, and response fields were asserted, and prints a coverage report.
+//
+// Like FailureCollector, CoverageTracker doesn't report failures by itself.
+// It is opt-in and should be combined with another handler using
+// MultiAssertionHandler. The same tracker may be shared across many tests,
+// and then its report can be printed at the end of the run.
+//
+// Both successful and failed assertions are recorded. Endpoint is identified
+// by request method and URL path. Response fields are recorded when they are
+// accessed using Object.Value, Array.Value, Value.Path, and similar methods
+// on the result of Response.JSON or Response.JSONP; array indexes are
+// replaced with "[*]".
+//
+// CoverageTracker can also compare recorded coverage with OpenAPI spec
+// to find operations and status codes that were not tested; see Diff.
+//
+// CoverageTracker is safe for concurrent use.
+//
+// Example:
+//
+//	var tracker = httpexpect.NewCoverageTracker()
+//
+//	func TestMain(m *testing.M) {
+//		code := m.Run()
+//		tracker.Print(os.Stdout)
+//		os.Exit(code)
+//	}
+//
+//	func TestUsers(t *testing.T) {
+//		e := httpexpect.WithConfig(httpexpect.Config{
+//			BaseURL: "http://example.com",
+//			AssertionHandler: httpexpect.MultiAssertionHandler{
+//				&httpexpect.DefaultAssertionHandler{
+//					Formatter: &httpexpect.DefaultFormatter{},
+//					Reporter:  httpexpect.NewAssertReporter(t),
+//				},
+//				tracker,
+//			},
+//		})
+//
+//		e.GET("/users").Expect().Status(http.StatusOK)
+//	}
+type CoverageTracker struct {
+	mu        sync.Mutex
+	endpoints map[string]*endpointCoverage
+}
+
+type endpointCoverage struct {
+	method   string
+	path     string
+	statuses map[int]bool
+	asserted map[int]bool
+	fields   map[string]bool
+}
+
+// NewCoverageTracker returns a new empty CoverageTracker.
+func NewCoverageTracker() *CoverageTracker {
+	return &CoverageTracker{
+		endpoints: map[string]*endpointCoverage{},
+	}
+}
+
+// Success implements AssertionHandler.Success.
+func (ct *CoverageTracker) Success(ctx *AssertionContext) {
+	ct.record(ctx)
+}
+
+// Failure implements AssertionHandler.Failure.
+func (ct *CoverageTracker) Failure(
+	ctx *AssertionContext, failure *AssertionFailure,
+) {
+	ct.record(ctx)
+}
+
+func (ct *CoverageTracker) record(ctx *AssertionContext) {
+	httpReq := contextHTTPRequest(ctx)
+	if httpReq == nil || httpReq.URL == nil {
+		return
+	}
+
+	var httpResp *http.Response
+	if ctx.Response != nil {
+		httpResp = ctx.Response.httpResp
+	}
+
+	field, hasField := coverageField(ctx.Path)
+
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	key := httpReq.Method + " " + httpReq.URL.Path
+
+	ep := ct.endpoints[key]
+	if ep == nil {
+		ep = &endpointCoverage{
+			method:   httpReq.Method,
+			path:     httpReq.URL.Path,
+			statuses: map[int]bool{},
+			asserted: map[int]bool{},
+			fields:   map[string]bool{},
+		}
+		ct.endpoints[key] = ep
+	}
+
+	if httpResp != nil {
+		ep.statuses[httpResp.StatusCode] = true
+
+		if coverageStatusAsserted(ctx.Path) {
+			ep.asserted[httpResp.StatusCode] = true
+		}
+	}
+
+	if hasField {
+		ep.fields[field] = true
+	}
+}
+
+// Endpoints returns recorded endpoints, sorted by path and method.
+func (ct *CoverageTracker) Endpoints() []EndpointCoverage {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	endpoints := make([]EndpointCoverage, 0, len(ct.endpoints))
+
+	for _, ep := range ct.endpoints {
+		endpoints = append(endpoints, EndpointCoverage{
+			Method:           ep.method,
+			Path:             ep.path,
+			Statuses:         sortedInts(ep.statuses),
+			AssertedStatuses: sortedInts(ep.asserted),
+			Fields:           sortedStrings(ep.fields),
+		})
+	}
+
+	sort.Slice(endpoints, func(i, j int) bool {
+		if endpoints[i].Path != endpoints[j].Path {
+			return endpoints[i].Path < endpoints[j].Path
+		}
+		return endpoints[i].Method < endpoints[j].Method
+	})
+
+	return endpoints
+}
+
+// Reset removes all recorded coverage.
+func (ct *CoverageTracker) Reset() {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	ct.endpoints = map[string]*endpointCoverage{}
+}
+
+// Summary returns human-readable coverage report.
+// If nothing was recorded, returns empty string.
+func (ct *CoverageTracker) Summary() string {
+	endpoints := ct.Endpoints()
+	if len(endpoints) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%d %s covered\n",
+		len(endpoints), pluralize(len(endpoints), "endpoint", "endpoints"))
+
+	for _, ep := range endpoints {
+		fmt.Fprintf(&b, "\n%s %s:\n", ep.Method, ep.Path)
+
+		if len(ep.Statuses) != 0 {
+			fmt.Fprintf(&b, "%sstatuses: %s\n", defaultIndent, joinInts(ep.Statuses))
+		}
+
+		if len(ep.AssertedStatuses) != 0 {
+			fmt.Fprintf(&b, "%sasserted statuses: %s\n",
+				defaultIndent, joinInts(ep.AssertedStatuses))
+		}
+
+		if len(ep.Fields) != 0 {
+			fmt.Fprintf(&b, "%sfields: %s\n",
+				defaultIndent, strings.Join(ep.Fields, ", "))
+		}
+	}
+
+	return b.String()
+}
+
+// Print writes coverage report to w.
+// If nothing was recorded, nothing is written.
+func (ct *CoverageTracker) Print(w io.Writer) {
+	if summary := ct.Summary(); summary != "" {
+		_, _ = io.WriteString(w, summary)
+	}
+}
+
+// CoverageDiff describes difference between recorded coverage and
+// OpenAPI spec, as returned by CoverageTracker.Diff.
+type CoverageDiff struct {
+	// Operations from spec that were never requested,
+	// e.g. "DELETE /users/{id}".
+	UncoveredOperations []string
+
+	// Response status codes from spec that were never asserted,
+	// e.g. "GET /users/{id} 404".
+	UncoveredStatuses []string
+
+	// Requested endpoints that are not present in spec,
+	// e.g. "GET /internal/debug".
+	UnknownEndpoints []string
+}
+
+// Empty returns true if recorded coverage fully matches spec.
+func (cd *CoverageDiff) Empty() bool {
+	return len(cd.UncoveredOperations) == 0 &&
+		len(cd.UncoveredStatuses) == 0 &&
+		len(cd.UnknownEndpoints) == 0
+}
+
+// String returns human-readable diff report.
+// If diff is empty, returns empty string.
+func (cd *CoverageDiff) String() string {
+	var b strings.Builder
+
+	sections := []struct {
+		title string
+		items []string
+	}{
+		{"uncovered operations", cd.UncoveredOperations},
+		{"uncovered statuses", cd.UncoveredStatuses},
+		{"unknown endpoints", cd.UnknownEndpoints},
+	}
+
+	for _, section := range sections {
+		if len(section.items) == 0 {
+			continue
+		}
+
+		if b.Len() != 0 {
+			b.WriteString("\n")
+		}
+
+		fmt.Fprintf(&b, "%s:\n", section.title)
+
+		for _, item := range section.items {
+			fmt.Fprintf(&b, "%s%s\n", defaultIndent, item)
+		}
+	}
+
+	return b.String()
+}
+
+// Diff compares recorded coverage with OpenAPI spec in JSON format.
+//
+// Operations are taken from "paths" object of the spec. Templated path
+// segments, like "{id}", match any segment of requested URL path.
+// Status codes like "404" match only given code, and status ranges like
+// "4XX" match any code from the range; "default" responses are ignored.
+//
+// Status code is considered covered only if it was asserted, not just
+// received.
+//
+// Example:
+//
+//	spec, _ := os.ReadFile("openapi.json")
+//
+//	diff, err := tracker.Diff(spec)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Print(diff)
+func (ct *CoverageTracker) Diff(spec []byte) (*CoverageDiff, error) {
+	operations, err := parseOpenAPIOperations(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints := ct.Endpoints()
+
+	diff := &CoverageDiff{}
+	matched := make([]bool, len(endpoints))
+
+	for _, op := range operations {
+		var asserted []int
+		requested := false
+
+		for n, ep := range endpoints {
+			if ep.Method != op.method || !matchOpenAPIPath(op.path, ep.Path) {
+				continue
+			}
+			matched[n] = true
+			requested = true
+			asserted = append(asserted, ep.AssertedStatuses...)
+		}
+
+		name := op.method + " " + op.path
+
+		if !requested {
+			diff.UncoveredOperations = append(diff.UncoveredOperations, name)
+			continue
+		}
+
+		for _, status := range op.statuses {
+			if !matchOpenAPIStatus(status, asserted) {
+				diff.UncoveredStatuses = append(diff.UncoveredStatuses,
+					name+" "+status)
+			}
+		}
+	}
+
+	for n, ep := range endpoints {
+		if !matched[n] {
+			diff.UnknownEndpoints = append(diff.UnknownEndpoints,
+				ep.Method+" "+ep.Path)
+		}
+	}
+
+	return diff, nil
+}
+
+type openAPIOperation struct {
+	method   string
+	path     string
+	statuses []string
+}
+
+var openAPIMethods = []string{
+	"get", "put", "post", "delete", "options", "head", "patch", "trace",
+}
+
+func parseOpenAPIOperations(spec []byte) ([]openAPIOperation, error) {
+	var doc struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+
+	if err := json.Unmarshal(spec, &doc); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI spec: %w", err)
+	}
+
+	paths := make([]string, 0, len(doc.Paths))
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var operations []openAPIOperation
+
+	for _, path := range paths {
+		item := doc.Paths[path]
+
+		for _, method := range openAPIMethods {
+			raw, ok := item[method]
+			if !ok {
+				continue
+			}
+
+			var op struct {
+				Responses map[string]json.RawMessage `json:"responses"`
+			}
+
+			if err := json.Unmarshal(raw, &op); err != nil {
+				return nil, fmt.Errorf(
+					"invalid OpenAPI operation %s %s: %w",
+					strings.ToUpper(method), path, err)
+			}
+
+			var statuses []string
+			for status := range op.Responses {
+				if status != "default" {
+					statuses = append(statuses, status)
+				}
+			}
+			sort.Strings(statuses)
+
+			operations = append(operations, openAPIOperation{
+				method:   strings.ToUpper(method),
+				path:     path,
+				statuses: statuses,
+			})
+		}
+	}
+
+	return operations, nil
+}
+
+func matchOpenAPIPath(template, path string) bool {
+	templateSegments := strings.Split(strings.Trim(template, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+
+	if len(templateSegments) != len(pathSegments) {
+		return false
+	}
+
+	for n, segment := range templateSegments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			if pathSegments[n] == "" {
+				return false
+			}
+			continue
+		}
+		if segment != pathSegments[n] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func matchOpenAPIStatus(status string, codes []int) bool {
+	for _, code := range codes {
+		s := strconv.Itoa(code)
+
+		if strings.EqualFold(status, s) {
+			return true
+		}
+
+		if len(status) == 3 && strings.EqualFold(status[1:], "XX") &&
+			len(s) == 3 && status[0] == s[0] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// coverageStatusAsserted reports whether assertion path contains
+// status check of response.
+func coverageStatusAsserted(path []string) bool {
+	for _, segment := range path {
+		switch segment {
+		case "Status()", "StatusRange()", "StatusList()":
+			return true
+		}
+	}
+	return false
+}
+
+// coverageField builds JSON path of response field from assertion path.
+// Returns false if assertion doesn't refer to a field of response body.
+func coverageField(path []string) (string, bool) {
+	start := -1
+	for n, segment := range path {
+		if segment == "JSON()" || segment == "JSONP()" {
+			start = n + 1
+			break
+		}
+	}
+	if start < 0 {
+		return "", false
+	}
+
+	var b strings.Builder
+	b.WriteString("$")
+
+	found := false
+
+	for _, segment := range path[start:] {
+		name, arg, ok := splitCoverageSegment(segment)
+		if !ok {
+			continue
+		}
+
+		switch name {
+		case "Value", "Field", "HasValue", "NotHasValue":
+			if key, err := strconv.Unquote(arg); err == nil {
+				b.WriteString("." + key)
+				found = true
+			} else if _, err := strconv.Atoi(arg); err == nil {
+				b.WriteString("[*]")
+				found = true
+			}
+
+		case "Path":
+			if p, err := strconv.Unquote(arg); err == nil {
+				b.WriteString(strings.TrimPrefix(p, "$"))
+				found = true
+			}
+		}
+	}
+
+	if !found {
+		return "", false
+	}
+
+	return b.String(), true
+}
+
+// splitCoverageSegment splits `Name(arg)` into name and arg.
+func splitCoverageSegment(segment string) (string, string, bool) {
+	open := strings.IndexByte(segment, '(')
+	if open < 0 || !strings.HasSuffix(segment, ")") {
+		return "", "", false
+	}
+	return segment[:open], segment[open+1 : len(segment)-1], true
+}
+
+func sortedInts(set map[int]bool) []int {
+	if len(set) == 0 {
+		return nil
+	}
+	list := make([]int, 0, len(set))
+	for v := range set {
+		list = append(list, v)
+	}
+	sort.Ints(list)
+	return list
+}
+
+func sortedStrings(set map[string]bool) []string {
+	if len(set) == 0 {
+		return nil
+	}
+	list := make([]string, 0, len(set))
+	for v := range set {
+		list = append(list, v)
+	}
+	sort.Strings(list)
+	return list
+}
+
+func joinInts(list []int) string {
+	s := make([]string, len(list))
+	for n, v := range list {
+		s[n] = strconv.Itoa(v)
+	}
+	return strings.Join(s, ", ")
+}
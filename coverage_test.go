@@ -0,0 +1,238 @@
+package httpexpect
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCoverageExpect(t *testing.T, tracker *CoverageTracker) *Expect {
+	client := ClientFunc(func(req *http.Request) (*http.Response, error) {
+		status := http.StatusOK
+		body := `{"id": 1, "name": "john", "items": [{"id": 2}, {"id": 3}]}`
+		if strings.HasSuffix(req.URL.Path, "/missing") {
+			status = http.StatusNotFound
+			body = `{"error": "not found"}`
+		}
+		return &http.Response{
+			StatusCode: status,
+			Header: http.Header{
+				"Content-Type": {"application/json"},
+			},
+			Body:    io.NopCloser(bytes.NewBufferString(body)),
+			Request: req,
+		}, nil
+	})
+
+	return WithConfig(Config{
+		BaseURL: "http://example.com",
+		Client:  client,
+		AssertionHandler: MultiAssertionHandler{
+			&DefaultAssertionHandler{
+				Formatter: &DefaultFormatter{},
+				Reporter:  newMockReporter(t),
+			},
+			tracker,
+		},
+	})
+}
+
+func TestCoverageTracker_Endpoints(t *testing.T) {
+	tracker := NewCoverageTracker()
+
+	e := newCoverageExpect(t, tracker)
+
+	obj := e.GET("/users/1").Expect().Status(http.StatusOK).JSON().Object()
+	obj.Value("name").String().IsEqual("john")
+	obj.Value("items").Array().Value(0).Object().HasValue("id", 2)
+	obj.ContainsKey("id")
+
+	e.GET("/users/1").Expect().JSON().Path("$.id").IsEqual(1)
+
+	e.GET("/users/missing").Expect().Status(http.StatusOK) // fails
+
+	e.POST("/users").Expect()
+
+	endpoints := tracker.Endpoints()
+	require.Equal(t, 3, len(endpoints))
+
+	assert.Equal(t, EndpointCoverage{
+		Method: "POST",
+		Path:   "/users",
+	}, endpoints[0])
+
+	assert.Equal(t, EndpointCoverage{
+		Method:           "GET",
+		Path:             "/users/1",
+		Statuses:         []int{200},
+		AssertedStatuses: []int{200},
+		Fields:           []string{"$.id", "$.items", "$.items[*]", "$.items[*].id", "$.name"},
+	}, endpoints[1])
+
+	assert.Equal(t, EndpointCoverage{
+		Method:           "GET",
+		Path:             "/users/missing",
+		Statuses:         []int{404},
+		AssertedStatuses: []int{404},
+	}, endpoints[2])
+
+	tracker.Reset()
+	assert.Empty(t, tracker.Endpoints())
+}
+
+func TestCoverageTracker_Field(t *testing.T) {
+	cases := []struct {
+		path  []string
+		field string
+	}{
+		{[]string{`Expect()`, `Status()`}, ""},
+		{[]string{`Expect()`, `JSON()`, `Object()`}, ""},
+		{[]string{`JSON()`, `Object()`, `Value("a")`, `IsEqual()`}, "$.a"},
+		{[]string{`JSON()`, `Object()`, `Value("a")`, `Object()`, `Field("b")`}, "$.a.b"},
+		{[]string{`JSON()`, `Array()`, `Value(3)`, `Object()`, `HasValue("c")`}, "$[*].c"},
+		{[]string{`JSONP()`, `Object()`, `NotHasValue("d")`}, "$.d"},
+		{[]string{`JSON()`, `Path("$.a.b")`, `Object()`, `Value("c")`}, "$.a.b.c"},
+		{[]string{`Header("a")`, `Value("a")`}, ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(strings.Join(tc.path, "."), func(t *testing.T) {
+			field, ok := coverageField(tc.path)
+			assert.Equal(t, tc.field != "", ok)
+			assert.Equal(t, tc.field, field)
+		})
+	}
+}
+
+func TestCoverageTracker_Summary(t *testing.T) {
+	tracker := NewCoverageTracker()
+
+	assert.Equal(t, "", tracker.Summary())
+
+	var buf bytes.Buffer
+	tracker.Print(&buf)
+	assert.Equal(t, "", buf.String())
+
+	e := newCoverageExpect(t, tracker)
+
+	e.GET("/users/1").Expect().Status(http.StatusOK).
+		JSON().Object().Value("name").IsEqual("john")
+	e.DELETE("/users/1").Expect()
+
+	assert.Equal(t,
+		"2 endpoints covered\n"+
+			"\n"+
+			"DELETE /users/1:\n"+
+			"\n"+
+			"GET /users/1:\n"+
+			"  statuses: 200\n"+
+			"  asserted statuses: 200\n"+
+			"  fields: $.name\n",
+		tracker.Summary())
+
+	tracker.Print(&buf)
+	assert.Equal(t, tracker.Summary(), buf.String())
+}
+
+func TestCoverageTracker_Diff(t *testing.T) {
+	spec := `{
+		"openapi": "3.0.0",
+		"paths": {
+			"/users": {
+				"get": {"responses": {"200": {}}},
+				"post": {"responses": {"201": {}, "default": {}}}
+			},
+			"/users/{id}": {
+				"parameters": [],
+				"get": {"responses": {"200": {}, "4XX": {}}},
+				"delete": {"responses": {"204": {}}}
+			}
+		}
+	}`
+
+	tracker := NewCoverageTracker()
+
+	e := newCoverageExpect(t, tracker)
+
+	e.GET("/users/1").Expect().Status(http.StatusOK)
+	e.GET("/users/2").Expect()
+	e.GET("/users").Expect()
+	e.GET("/debug").Expect()
+
+	diff, err := tracker.Diff([]byte(spec))
+	require.NoError(t, err)
+
+	assert.False(t, diff.Empty())
+	assert.Equal(t, &CoverageDiff{
+		UncoveredOperations: []string{"POST /users", "DELETE /users/{id}"},
+		UncoveredStatuses:   []string{"GET /users 200", "GET /users/{id} 4XX"},
+		UnknownEndpoints:    []string{"GET /debug"},
+	}, diff)
+
+	assert.Equal(t,
+		"uncovered operations:\n"+
+			"  POST /users\n"+
+			"  DELETE /users/{id}\n"+
+			"\n"+
+			"uncovered statuses:\n"+
+			"  GET /users 200\n"+
+			"  GET /users/{id} 4XX\n"+
+			"\n"+
+			"unknown endpoints:\n"+
+			"  GET /debug\n",
+		diff.String())
+
+	e.GET("/users/missing").Expect().Status(http.StatusNotFound)
+
+	diff, err = tracker.Diff([]byte(spec))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"GET /users 200"}, diff.UncoveredStatuses)
+
+	t.Run("empty", func(t *testing.T) {
+		diff := &CoverageDiff{}
+		assert.True(t, diff.Empty())
+		assert.Equal(t, "", diff.String())
+	})
+
+	t.Run("invalid spec", func(t *testing.T) {
+		_, err := tracker.Diff([]byte(`{"paths": [`))
+		assert.Error(t, err)
+
+		_, err = tracker.Diff([]byte(`{"paths": {"/a": {"get": 1}}}`))
+		assert.Error(t, err)
+	})
+}
+
+func TestCoverageTracker_Concurrency(t *testing.T) {
+	tracker := NewCoverageTracker()
+
+	httpReq, err := http.NewRequest("GET", "http://example.com/users", nil)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				tracker.Success(&AssertionContext{
+					Path:    []string{"JSON()", `Value("a")`},
+					Request: &Request{httpReq: httpReq},
+				})
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	endpoints := tracker.Endpoints()
+	require.Equal(t, 1, len(endpoints))
+	assert.Equal(t, []string{"$.a"}, endpoints[0].Fields)
+}
@@ -0,0 +1,306 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ErrCredentialsNotFound is returned by CredentialsProvider when it has no
+// credentials with requested name.
+var ErrCredentialsNotFound = errors.New("credentials not found")
+
+// Credentials defines authorization data applied to request by
+// Request.WithCredentials.
+//
+// If Token is set, it is sent as bearer token in "Authorization" header.
+// Otherwise, Username and Password are sent using HTTP Basic Authentication.
+type Credentials struct {
+	// User name for HTTP Basic Authentication.
+	// May be empty.
+	Username string `json:"username"`
+
+	// Password for HTTP Basic Authentication.
+	// May be empty.
+	Password string `json:"password"`
+
+	// Bearer token.
+	// May be empty.
+	Token string `json:"token"`
+}
+
+// CredentialsProvider looks up credentials by name, e.g. "service-a".
+//
+// Providers keep secrets out of test code and allow to rotate them without
+// edits. Provider is invoked every time credentials are applied to request,
+// so it always returns actual secrets.
+//
+// If there are no credentials with given name, provider should return
+// ErrCredentialsNotFound.
+//
+// Builtin providers are EnvCredentials and FileCredentials. Other sources,
+// like Vault, can be plugged in using CredentialsProviderFunc. Providers
+// can be combined using CredentialsChain.
+type CredentialsProvider interface {
+	Credentials(name string) (Credentials, error)
+}
+
+// CredentialsProviderFunc is an adapter that allows a function to be used
+// as the CredentialsProvider.
+//
+// Example:
+//
+//	vault := httpexpect.CredentialsProviderFunc(
+//		func(name string) (httpexpect.Credentials, error) {
+//			secret, err := vaultClient.Logical().Read("secret/" + name)
+//			if err != nil {
+//				return httpexpect.Credentials{}, err
+//			}
+//			if secret == nil {
+//				return httpexpect.Credentials{}, httpexpect.ErrCredentialsNotFound
+//			}
+//			return httpexpect.Credentials{
+//				Token: secret.Data["token"].(string),
+//			}, nil
+//		})
+type CredentialsProviderFunc func(name string) (Credentials, error)
+
+// Credentials implements CredentialsProvider.Credentials.
+func (f CredentialsProviderFunc) Credentials(name string) (Credentials, error) {
+	return f(name)
+}
+
+// EnvCredentials returns provider that reads credentials from environment
+// variables.
+//
+// Variable names are formed from "HTTPEXPECT_", upper-cased credentials
+// name, and a suffix. Dashes in name are replaced with underscores.
+// Supported variables (for credentials "service-a"):
+//
+//	HTTPEXPECT_SERVICE_A_USERNAME  - Credentials.Username
+//	HTTPEXPECT_SERVICE_A_PASSWORD  - Credentials.Password
+//	HTTPEXPECT_SERVICE_A_TOKEN     - Credentials.Token
+//
+// Returns ErrCredentialsNotFound if none of the variables is set.
+//
+// Example:
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		Reporter:    httpexpect.NewAssertReporter(t),
+//		Credentials: httpexpect.EnvCredentials(),
+//	})
+func EnvCredentials() CredentialsProvider {
+	return CredentialsProviderFunc(func(name string) (Credentials, error) {
+		prefix := "HTTPEXPECT_" +
+			strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+
+		var (
+			creds Credentials
+			found bool
+		)
+
+		lookup := func(suffix string) string {
+			value, ok := os.LookupEnv(prefix + suffix)
+			if ok {
+				found = true
+			}
+			return value
+		}
+
+		creds.Username = lookup("USERNAME")
+		creds.Password = lookup("PASSWORD")
+		creds.Token = lookup("TOKEN")
+
+		if !found {
+			return Credentials{}, ErrCredentialsNotFound
+		}
+
+		return creds, nil
+	})
+}
+
+// FileCredentials returns provider that reads credentials from a JSON file.
+//
+// The file should contain an object that maps names to credentials:
+//
+//	{
+//	  "service-a": {"username": "john", "password": "secret"},
+//	  "service-b": {"token": "secret-token"}
+//	}
+//
+// The file is read every time credentials are requested, so secrets can
+// be rotated while tests are running.
+//
+// Example:
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		Reporter:    httpexpect.NewAssertReporter(t),
+//		Credentials: httpexpect.FileCredentials("/run/secrets/credentials.json"),
+//	})
+func FileCredentials(path string) CredentialsProvider {
+	return CredentialsProviderFunc(func(name string) (Credentials, error) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return Credentials{}, err
+		}
+
+		var all map[string]Credentials
+
+		if err := json.Unmarshal(data, &all); err != nil {
+			return Credentials{},
+				fmt.Errorf("can't parse credentials file %q: %w", path, err)
+		}
+
+		creds, ok := all[name]
+		if !ok {
+			return Credentials{}, ErrCredentialsNotFound
+		}
+
+		return creds, nil
+	})
+}
+
+// CredentialsChain returns provider that tries given providers in order,
+// and returns credentials from the first one that has them.
+//
+// Providers that return ErrCredentialsNotFound are skipped; any other
+// error is returned immediately.
+//
+// Example:
+//
+//	provider := httpexpect.CredentialsChain(
+//		httpexpect.EnvCredentials(),
+//		httpexpect.FileCredentials("credentials.json"),
+//	)
+func CredentialsChain(providers ...CredentialsProvider) CredentialsProvider {
+	return CredentialsProviderFunc(func(name string) (Credentials, error) {
+		for _, provider := range providers {
+			if provider == nil {
+				continue
+			}
+
+			creds, err := provider.Credentials(name)
+			if errors.Is(err, ErrCredentialsNotFound) {
+				continue
+			}
+
+			return creds, err
+		}
+
+		return Credentials{}, ErrCredentialsNotFound
+	})
+}
+
+// WithCredentials looks up credentials with given name using
+// Config.Credentials and applies them to request.
+//
+// If credentials have Token, "Authorization" header is set to bearer
+// token. Otherwise, HTTP Basic Authentication is used. If Config.Credentials
+// is nil, EnvCredentials is used.
+//
+// If credentials are not found, or have neither token nor user name,
+// failure is reported.
+//
+// Example:
+//
+//	// HTTPEXPECT_SERVICE_A_TOKEN=secret
+//	req := NewRequestC(config, "GET", "http://example.com/path")
+//	req.WithCredentials("service-a")
+//	// "Authorization" header is now "Bearer secret"
+func (r *Request) WithCredentials(name string) *Request {
+	opChain := r.chain.enter("WithCredentials(%q)", name)
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithCredentials()") {
+		return r
+	}
+
+	provider := r.config.Credentials
+	if provider == nil {
+		provider = EnvCredentials()
+	}
+
+	creds, err := provider.Credentials(name)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				fmt.Errorf("failed to get credentials %q", name),
+				err,
+			},
+		})
+		return r
+	}
+
+	switch {
+	case creds.Token != "":
+		r.httpReq.Header.Set("Authorization", "Bearer "+creds.Token)
+
+	case creds.Username != "":
+		r.httpReq.SetBasicAuth(creds.Username, creds.Password)
+
+	default:
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				fmt.Errorf("credentials %q have neither token nor user name", name),
+			},
+		})
+	}
+
+	return r
+}
+
+// WithBasicAuthFromEnv is like WithBasicAuth, but reads user name and
+// password from given environment variables.
+//
+// If any of the variables is not set, failure is reported.
+//
+// Example:
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	req.WithBasicAuthFromEnv("API_USER", "API_PASSWORD")
+func (r *Request) WithBasicAuthFromEnv(usernameVar, passwordVar string) *Request {
+	opChain := r.chain.enter("WithBasicAuthFromEnv()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithBasicAuthFromEnv()") {
+		return r
+	}
+
+	var values [2]string
+
+	for i, name := range []string{usernameVar, passwordVar} {
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			opChain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					fmt.Errorf("environment variable %q is not set", name),
+				},
+			})
+			return r
+		}
+		values[i] = value
+	}
+
+	r.httpReq.SetBasicAuth(values[0], values[1])
+
+	return r
+}
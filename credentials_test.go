@@ -0,0 +1,209 @@
+package httpexpect
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCredentials_Env(t *testing.T) {
+	t.Setenv("HTTPEXPECT_SERVICE_A_USERNAME", "john")
+	t.Setenv("HTTPEXPECT_SERVICE_A_PASSWORD", "secret")
+	t.Setenv("HTTPEXPECT_SERVICE_B_TOKEN", "token")
+
+	provider := EnvCredentials()
+
+	creds, err := provider.Credentials("service-a")
+	require.NoError(t, err)
+	assert.Equal(t, Credentials{Username: "john", Password: "secret"}, creds)
+
+	creds, err = provider.Credentials("service-b")
+	require.NoError(t, err)
+	assert.Equal(t, Credentials{Token: "token"}, creds)
+
+	_, err = provider.Credentials("unknown")
+	assert.True(t, errors.Is(err, ErrCredentialsNotFound))
+}
+
+func TestCredentials_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.json")
+
+	provider := FileCredentials(path)
+
+	_, err := provider.Credentials("service-a")
+	assert.Error(t, err)
+
+	require.NoError(t, os.WriteFile(path,
+		[]byte(`{"service-a": {"username": "john", "password": "secret"}}`), 0600))
+
+	creds, err := provider.Credentials("service-a")
+	require.NoError(t, err)
+	assert.Equal(t, Credentials{Username: "john", Password: "secret"}, creds)
+
+	_, err = provider.Credentials("service-b")
+	assert.True(t, errors.Is(err, ErrCredentialsNotFound))
+
+	// rotated secret is picked up without recreating provider
+	require.NoError(t, os.WriteFile(path,
+		[]byte(`{"service-a": {"token": "new-token"}}`), 0600))
+
+	creds, err = provider.Credentials("service-a")
+	require.NoError(t, err)
+	assert.Equal(t, Credentials{Token: "new-token"}, creds)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{bad`), 0600))
+
+	_, err = provider.Credentials("service-a")
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, ErrCredentialsNotFound))
+}
+
+func TestCredentials_Chain(t *testing.T) {
+	failing := CredentialsProviderFunc(func(name string) (Credentials, error) {
+		return Credentials{}, errors.New("vault is sealed")
+	})
+
+	found := CredentialsProviderFunc(func(name string) (Credentials, error) {
+		if name == "service-a" {
+			return Credentials{Token: "token"}, nil
+		}
+		return Credentials{}, ErrCredentialsNotFound
+	})
+
+	notFound := CredentialsProviderFunc(func(name string) (Credentials, error) {
+		return Credentials{}, ErrCredentialsNotFound
+	})
+
+	creds, err := CredentialsChain(notFound, nil, found, failing).
+		Credentials("service-a")
+	require.NoError(t, err)
+	assert.Equal(t, Credentials{Token: "token"}, creds)
+
+	_, err = CredentialsChain(notFound, found).Credentials("service-b")
+	assert.True(t, errors.Is(err, ErrCredentialsNotFound))
+
+	_, err = CredentialsChain(notFound, failing, found).Credentials("service-a")
+	assert.EqualError(t, err, "vault is sealed")
+
+	_, err = CredentialsChain().Credentials("service-a")
+	assert.True(t, errors.Is(err, ErrCredentialsNotFound))
+}
+
+func TestCredentials_Request(t *testing.T) {
+	provider := CredentialsProviderFunc(func(name string) (Credentials, error) {
+		switch name {
+		case "basic":
+			return Credentials{Username: "john", Password: "secret"}, nil
+		case "token":
+			return Credentials{Username: "john", Token: "token"}, nil
+		case "empty":
+			return Credentials{}, nil
+		case "broken":
+			return Credentials{}, errors.New("broken")
+		}
+		return Credentials{}, ErrCredentialsNotFound
+	})
+
+	cases := []struct {
+		name           string
+		credentials    string
+		result         chainResult
+		expectedHeader string
+	}{
+		{
+			name:           "basic auth",
+			credentials:    "basic",
+			result:         success,
+			expectedHeader: "Basic am9objpzZWNyZXQ=",
+		},
+		{
+			name:           "token",
+			credentials:    "token",
+			result:         success,
+			expectedHeader: "Bearer token",
+		},
+		{
+			name:        "empty credentials",
+			credentials: "empty",
+			result:      failure,
+		},
+		{
+			name:        "provider error",
+			credentials: "broken",
+			result:      failure,
+		},
+		{
+			name:        "not found",
+			credentials: "unknown",
+			result:      failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &mockClient{}
+
+			e := WithConfig(Config{
+				Client:      client,
+				Reporter:    newMockReporter(t),
+				Credentials: provider,
+			})
+
+			req := e.GET("/path").WithCredentials(tc.credentials)
+			req.chain.assert(t, tc.result)
+
+			if tc.result == success {
+				req.Expect()
+				require.NotNil(t, client.req)
+				assert.Equal(t, tc.expectedHeader, client.req.Header.Get("Authorization"))
+			}
+		})
+	}
+
+	t.Run("env by default", func(t *testing.T) {
+		t.Setenv("HTTPEXPECT_SERVICE_A_TOKEN", "env-token")
+
+		client := &mockClient{}
+
+		e := WithConfig(Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		})
+
+		e.GET("/path").WithCredentials("service-a").Expect().
+			chain.assert(t, success)
+
+		require.NotNil(t, client.req)
+		assert.Equal(t, "Bearer env-token", client.req.Header.Get("Authorization"))
+	})
+}
+
+func TestCredentials_BasicAuthFromEnv(t *testing.T) {
+	t.Setenv("TEST_API_USER", "john")
+	t.Setenv("TEST_API_PASSWORD", "secret")
+
+	client := &mockClient{}
+
+	e := WithConfig(Config{
+		Client:   client,
+		Reporter: newMockReporter(t),
+	})
+
+	e.GET("/path").WithBasicAuthFromEnv("TEST_API_USER", "TEST_API_PASSWORD").
+		Expect().
+		chain.assert(t, success)
+
+	require.NotNil(t, client.req)
+
+	username, password, ok := client.req.BasicAuth()
+	assert.True(t, ok)
+	assert.Equal(t, "john", username)
+	assert.Equal(t, "secret", password)
+
+	e.GET("/path").WithBasicAuthFromEnv("TEST_API_USER", "TEST_API_UNKNOWN").
+		chain.assert(t, failure)
+}
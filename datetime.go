@@ -2,6 +2,7 @@ package httpexpect
 
 import (
 	"errors"
+	"fmt"
 	"time"
 )
 
@@ -669,3 +670,335 @@ func (dt *DateTime) AsLocal() *DateTime {
 
 	return newDateTime(opChain, dt.value.Local())
 }
+
+// InZone returns a new DateTime instance in given time zone.
+//
+// name is interpreted as by time.LoadLocation: "UTC", "Local", or a name
+// from IANA Time Zone database, like "America/New_York".
+//
+// Example:
+//
+//	dt := NewDateTime(t, time.Date(2022, 12, 30, 15, 4, 5, 0, time.UTC))
+//	dt.InZone("Asia/Tokyo").Hour().IsEqual(0)
+func (dt *DateTime) InZone(name string) *DateTime {
+	opChain := dt.chain.enter("InZone(%q)", name)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newDateTime(opChain, time.Unix(0, 0))
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected invalid time zone %q", name),
+				err,
+			},
+		})
+		return newDateTime(opChain, time.Unix(0, 0))
+	}
+
+	return newDateTime(opChain, dt.value.In(loc))
+}
+
+// Truncate returns a new DateTime instance with datetime rounded down
+// to a multiple of unit.
+//
+// Like time.Time.Truncate, it operates on absolute time since zero time,
+// hence units larger than an hour may give unexpected results in time
+// zones with non-whole-hour offsets.
+//
+// Example:
+//
+//	dt := NewDateTime(t, time.Date(2022, 12, 30, 15, 4, 5, 0, time.UTC))
+//	dt.Truncate(time.Hour).IsEqual(time.Date(2022, 12, 30, 15, 0, 0, 0, time.UTC))
+func (dt *DateTime) Truncate(unit time.Duration) *DateTime {
+	opChain := dt.chain.enter("Truncate(%v)", unit)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newDateTime(opChain, time.Unix(0, 0))
+	}
+
+	if unit <= 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected non-positive unit: %v", unit),
+			},
+		})
+		return newDateTime(opChain, time.Unix(0, 0))
+	}
+
+	return newDateTime(opChain, dt.value.Truncate(unit))
+}
+
+// HasYear succeeds if datetime year is equal to given value.
+//
+// Example:
+//
+//	dt := NewDateTime(t, time.Date(2022, 12, 30, 15, 4, 5, 0, time.UTC))
+//	dt.HasYear(2022)
+func (dt *DateTime) HasYear(year int) *DateTime {
+	opChain := dt.chain.enter("HasYear(%d)", year)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return dt
+	}
+
+	if dt.value.Year() != year {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{dt.value.Year()},
+			Expected: &AssertionValue{year},
+			Errors: []error{
+				errors.New("expected: datetime has given year"),
+			},
+		})
+	}
+
+	return dt
+}
+
+// HasMonth succeeds if datetime month is equal to given value.
+//
+// Example:
+//
+//	dt := NewDateTime(t, time.Date(2022, 12, 30, 15, 4, 5, 0, time.UTC))
+//	dt.HasMonth(time.December)
+func (dt *DateTime) HasMonth(month time.Month) *DateTime {
+	opChain := dt.chain.enter("HasMonth(%v)", month)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return dt
+	}
+
+	if dt.value.Month() != month {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{dt.value.Month()},
+			Expected: &AssertionValue{month},
+			Errors: []error{
+				errors.New("expected: datetime has given month"),
+			},
+		})
+	}
+
+	return dt
+}
+
+// HasDay succeeds if datetime day of the month is equal to given value.
+//
+// Example:
+//
+//	dt := NewDateTime(t, time.Date(2022, 12, 30, 15, 4, 5, 0, time.UTC))
+//	dt.HasDay(30)
+func (dt *DateTime) HasDay(day int) *DateTime {
+	opChain := dt.chain.enter("HasDay(%d)", day)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return dt
+	}
+
+	if dt.value.Day() != day {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{dt.value.Day()},
+			Expected: &AssertionValue{day},
+			Errors: []error{
+				errors.New("expected: datetime has given day"),
+			},
+		})
+	}
+
+	return dt
+}
+
+// IsWeekday succeeds if datetime falls on Monday through Friday.
+//
+// Example:
+//
+//	dt := NewDateTime(t, time.Date(2022, 12, 30, 15, 4, 5, 0, time.UTC))
+//	dt.IsWeekday()
+func (dt *DateTime) IsWeekday() *DateTime {
+	opChain := dt.chain.enter("IsWeekday()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return dt
+	}
+
+	if isWeekend(dt.value) {
+		opChain.fail(AssertionFailure{
+			Type:   AssertBelongs,
+			Actual: &AssertionValue{dt.value.Weekday()},
+			Expected: &AssertionValue{AssertionList{
+				time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday,
+			}},
+			Errors: []error{
+				errors.New("expected: datetime is a weekday"),
+			},
+		})
+	}
+
+	return dt
+}
+
+// IsWeekend succeeds if datetime falls on Saturday or Sunday.
+//
+// Example:
+//
+//	dt := NewDateTime(t, time.Date(2022, 12, 31, 15, 4, 5, 0, time.UTC))
+//	dt.IsWeekend()
+func (dt *DateTime) IsWeekend() *DateTime {
+	opChain := dt.chain.enter("IsWeekend()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return dt
+	}
+
+	if !isWeekend(dt.value) {
+		opChain.fail(AssertionFailure{
+			Type:   AssertBelongs,
+			Actual: &AssertionValue{dt.value.Weekday()},
+			Expected: &AssertionValue{AssertionList{
+				time.Saturday, time.Sunday,
+			}},
+			Errors: []error{
+				errors.New("expected: datetime is a weekend day"),
+			},
+		})
+	}
+
+	return dt
+}
+
+func isWeekend(tm time.Time) bool {
+	return tm.Weekday() == time.Saturday || tm.Weekday() == time.Sunday
+}
+
+// IsEqualIgnoringSubsecond succeeds if DateTime is equal to given value
+// after both are truncated to whole seconds.
+//
+// It is useful when timestamps pass through formats with second precision,
+// like HTTP-date or Unix time.
+//
+// Example:
+//
+//	dt := NewDateTime(t, time.Unix(10, 500))
+//	dt.IsEqualIgnoringSubsecond(time.Unix(10, 999))
+func (dt *DateTime) IsEqualIgnoringSubsecond(value time.Time) *DateTime {
+	opChain := dt.chain.enter("IsEqualIgnoringSubsecond()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return dt
+	}
+
+	if !dt.value.Truncate(time.Second).Equal(value.Truncate(time.Second)) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{dt.value},
+			Expected: &AssertionValue{value},
+			Errors: []error{
+				errors.New("expected: time points are equal ignoring subsecond part"),
+			},
+		})
+	}
+
+	return dt
+}
+
+// IsWithinLast succeeds if DateTime is within given duration before
+// current time, i.e. within range [now - d; now].
+//
+// Current time is taken from Config.Clock, or from time.Now if clock
+// is not set.
+//
+// Example:
+//
+//	dt := NewDateTime(t, time.Now().Add(-time.Minute))
+//	dt.IsWithinLast(5 * time.Minute)
+func (dt *DateTime) IsWithinLast(d time.Duration) *DateTime {
+	opChain := dt.chain.enter("IsWithinLast(%v)", d)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return dt
+	}
+
+	if d < 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected negative duration: %v", d),
+			},
+		})
+		return dt
+	}
+
+	now := opChain.now()
+
+	if dt.value.Before(now.Add(-d)) || dt.value.After(now) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertInRange,
+			Actual:   &AssertionValue{dt.value},
+			Expected: &AssertionValue{AssertionRange{now.Add(-d), now}},
+			Errors: []error{
+				fmt.Errorf("expected: time point is within last %v", d),
+			},
+		})
+	}
+
+	return dt
+}
+
+// IsWithinNext succeeds if DateTime is within given duration after
+// current time, i.e. within range [now; now + d].
+//
+// Current time is taken from Config.Clock, or from time.Now if clock
+// is not set.
+//
+// Example:
+//
+//	dt := NewDateTime(t, time.Now().Add(time.Hour))
+//	dt.IsWithinNext(2 * time.Hour)
+func (dt *DateTime) IsWithinNext(d time.Duration) *DateTime {
+	opChain := dt.chain.enter("IsWithinNext(%v)", d)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return dt
+	}
+
+	if d < 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected negative duration: %v", d),
+			},
+		})
+		return dt
+	}
+
+	now := opChain.now()
+
+	if dt.value.Before(now) || dt.value.After(now.Add(d)) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertInRange,
+			Actual:   &AssertionValue{dt.value},
+			Expected: &AssertionValue{AssertionRange{now, now.Add(d)}},
+			Errors: []error{
+				fmt.Errorf("expected: time point is within next %v", d),
+			},
+		})
+	}
+
+	return dt
+}
@@ -1,6 +1,7 @@
 package httpexpect
 
 import (
+	"net/http"
 	"testing"
 	"time"
 
@@ -40,6 +41,17 @@ func TestDateTime_FailedChain(t *testing.T) {
 
 	value.AsUTC().chain.assert(t, failure)
 	value.AsLocal().chain.assert(t, failure)
+	value.InZone("UTC").chain.assert(t, failure)
+	value.Truncate(time.Hour).chain.assert(t, failure)
+
+	value.HasYear(1970)
+	value.HasMonth(time.January)
+	value.HasDay(1)
+	value.IsWeekday()
+	value.IsWeekend()
+	value.IsEqualIgnoringSubsecond(tm)
+	value.IsWithinLast(time.Hour)
+	value.IsWithinNext(time.Hour)
 }
 
 func TestDateTime_Constructors(t *testing.T) {
@@ -366,3 +378,290 @@ func TestDateTime_InList(t *testing.T) {
 		})
 	}
 }
+
+func TestDateTime_InZone(t *testing.T) {
+	tm := time.Date(2022, 12, 30, 15, 4, 5, 0, time.UTC)
+
+	t.Run("utc", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		dt := NewDateTime(reporter, tm.In(time.FixedZone("X", 3600))).InZone("UTC")
+		dt.chain.assert(t, success)
+
+		dt.Zone().IsEqual("UTC")
+		dt.Hour().IsEqual(15)
+		dt.chain.assert(t, success)
+	})
+
+	t.Run("fixed offset", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		dt := NewDateTime(reporter, tm).InZone("Etc/GMT-9")
+		if dt.chain.failed() {
+			t.Skip("time zone database is not available")
+		}
+
+		dt.Hour().IsEqual(0)
+		dt.HasDay(31)
+		dt.IsEqual(tm)
+		dt.chain.assert(t, success)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		NewDateTime(reporter, tm).InZone("Invalid/Zone").
+			chain.assert(t, failure)
+	})
+}
+
+func TestDateTime_Truncate(t *testing.T) {
+	tm := time.Date(2022, 12, 30, 15, 4, 5, 123, time.UTC)
+
+	cases := []struct {
+		name   string
+		unit   time.Duration
+		want   time.Time
+		result chainResult
+	}{
+		{
+			name:   "second",
+			unit:   time.Second,
+			want:   time.Date(2022, 12, 30, 15, 4, 5, 0, time.UTC),
+			result: success,
+		},
+		{
+			name:   "hour",
+			unit:   time.Hour,
+			want:   time.Date(2022, 12, 30, 15, 0, 0, 0, time.UTC),
+			result: success,
+		},
+		{
+			name:   "day",
+			unit:   24 * time.Hour,
+			want:   time.Date(2022, 12, 30, 0, 0, 0, 0, time.UTC),
+			result: success,
+		},
+		{
+			name:   "zero",
+			unit:   0,
+			result: failure,
+		},
+		{
+			name:   "negative",
+			unit:   -time.Second,
+			result: failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			dt := NewDateTime(reporter, tm).Truncate(tc.unit)
+			dt.chain.assert(t, tc.result)
+
+			if tc.result == success {
+				assert.True(t, tc.want.Equal(dt.Raw()))
+			}
+		})
+	}
+}
+
+func TestDateTime_Calendar(t *testing.T) {
+	friday := time.Date(2022, 12, 30, 15, 4, 5, 0, time.UTC)
+	saturday := friday.Add(24 * time.Hour)
+	sunday := saturday.Add(24 * time.Hour)
+	monday := sunday.Add(24 * time.Hour)
+
+	t.Run("date", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		NewDateTime(reporter, friday).HasYear(2022).chain.assert(t, success)
+		NewDateTime(reporter, friday).HasYear(2023).chain.assert(t, failure)
+
+		NewDateTime(reporter, friday).HasMonth(time.December).
+			chain.assert(t, success)
+		NewDateTime(reporter, friday).HasMonth(time.January).
+			chain.assert(t, failure)
+
+		NewDateTime(reporter, friday).HasDay(30).chain.assert(t, success)
+		NewDateTime(reporter, friday).HasDay(31).chain.assert(t, failure)
+	})
+
+	t.Run("weekday", func(t *testing.T) {
+		cases := []struct {
+			time    time.Time
+			weekday bool
+		}{
+			{friday, true},
+			{saturday, false},
+			{sunday, false},
+			{monday, true},
+		}
+
+		for _, tc := range cases {
+			reporter := newMockReporter(t)
+
+			NewDateTime(reporter, tc.time).IsWeekday().
+				chain.assert(t, chainResult(tc.weekday))
+
+			NewDateTime(reporter, tc.time).IsWeekend().
+				chain.assert(t, chainResult(!tc.weekday))
+		}
+	})
+}
+
+func TestDateTime_IsEqualIgnoringSubsecond(t *testing.T) {
+	cases := []struct {
+		name   string
+		time   time.Time
+		value  time.Time
+		result chainResult
+	}{
+		{
+			name:   "equal",
+			time:   time.Unix(10, 0),
+			value:  time.Unix(10, 0),
+			result: success,
+		},
+		{
+			name:   "different subsecond",
+			time:   time.Unix(10, 500),
+			value:  time.Unix(10, 999999999),
+			result: success,
+		},
+		{
+			name:   "different zone",
+			time:   time.Unix(10, 1).UTC(),
+			value:  time.Unix(10, 2).In(time.FixedZone("X", 3600)),
+			result: success,
+		},
+		{
+			name:   "different second",
+			time:   time.Unix(10, 999999999),
+			value:  time.Unix(11, 0),
+			result: failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			NewDateTime(reporter, tc.time).IsEqualIgnoringSubsecond(tc.value).
+				chain.assert(t, tc.result)
+		})
+	}
+}
+
+func TestDateTime_IsWithin(t *testing.T) {
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	config := Config{
+		Reporter: newMockReporter(t),
+		Clock:    NewFakeClock(now),
+	}
+
+	cases := []struct {
+		name     string
+		time     time.Time
+		duration time.Duration
+		wantLast chainResult
+		wantNext chainResult
+	}{
+		{
+			name:     "now",
+			time:     now,
+			duration: time.Minute,
+			wantLast: success,
+			wantNext: success,
+		},
+		{
+			name:     "in the past",
+			time:     now.Add(-time.Minute),
+			duration: 5 * time.Minute,
+			wantLast: success,
+			wantNext: failure,
+		},
+		{
+			name:     "in the future",
+			time:     now.Add(time.Minute),
+			duration: 5 * time.Minute,
+			wantLast: failure,
+			wantNext: success,
+		},
+		{
+			name:     "on the boundary",
+			time:     now.Add(-5 * time.Minute),
+			duration: 5 * time.Minute,
+			wantLast: success,
+			wantNext: failure,
+		},
+		{
+			name:     "too old",
+			time:     now.Add(-time.Hour),
+			duration: 5 * time.Minute,
+			wantLast: failure,
+			wantNext: failure,
+		},
+		{
+			name:     "too far",
+			time:     now.Add(time.Hour),
+			duration: 5 * time.Minute,
+			wantLast: failure,
+			wantNext: failure,
+		},
+		{
+			name:     "negative duration",
+			time:     now,
+			duration: -time.Minute,
+			wantLast: failure,
+			wantNext: failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			NewDateTimeC(config, tc.time).IsWithinLast(tc.duration).
+				chain.assert(t, tc.wantLast)
+
+			NewDateTimeC(config, tc.time).IsWithinNext(tc.duration).
+				chain.assert(t, tc.wantNext)
+		})
+	}
+
+	t.Run("default clock", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		NewDateTime(reporter, time.Now().Add(-time.Second)).IsWithinLast(time.Hour).
+			chain.assert(t, success)
+
+		NewDateTime(reporter, time.Now().Add(time.Hour)).IsWithinNext(2*time.Hour).
+			chain.assert(t, success)
+	})
+
+	t.Run("clock from expect", func(t *testing.T) {
+		e := WithConfig(Config{
+			Reporter: newMockReporter(t),
+			Client: ClientFunc(func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header: http.Header{
+						"Last-Modified": {"Thu, 01 Jun 2023 11:58:00 GMT"},
+					},
+					Body: http.NoBody,
+				}, nil
+			}),
+			Clock: config.Clock,
+		})
+
+		dt := e.GET("/").Expect().HeaderDateTime("Last-Modified")
+
+		dt.IsWithinLast(5 * time.Minute)
+		dt.chain.assert(t, success)
+
+		dt.IsWithinLast(time.Minute)
+		dt.chain.assert(t, failure)
+	})
+}
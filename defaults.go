@@ -0,0 +1,84 @@
+package httpexpect
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// MergePolicy defines how Config.DefaultHeaders and Config.DefaultQuery
+// are merged with headers and query parameters set for individual request.
+//
+// Default merge policy is ReplaceDefaults.
+type MergePolicy int
+
+const (
+	// indicates that Config.DefaultsMergePolicy was not set
+	defaultMergePolicy MergePolicy = iota
+
+	// ReplaceDefaults skips default value if request has its own value
+	// with the same key. E.g. if Config.DefaultHeaders has "Accept" and
+	// request sets "Accept" via Request.WithHeader, only request value
+	// is sent.
+	ReplaceDefaults
+
+	// AppendDefaults always adds default values. If request has its own
+	// value with the same key, both values are sent, request value first.
+	AppendDefaults
+)
+
+// applyDefaults merges Config.DefaultHeaders and Config.DefaultQuery
+// into request according to Config.DefaultsMergePolicy.
+//
+// Defaults are applied when request is sent, so the result doesn't
+// depend on the order of WithXXX calls.
+func (r *Request) applyDefaults() {
+	policy := r.config.DefaultsMergePolicy
+	if policy == defaultMergePolicy {
+		policy = ReplaceDefaults
+	}
+
+	if len(r.config.DefaultHeaders) != 0 {
+		if r.httpReq.Header == nil {
+			r.httpReq.Header = make(http.Header)
+		}
+
+		mergeDefaults(r.httpReq.Header,
+			canonicalHeader(r.config.DefaultHeaders), policy)
+	}
+
+	if len(r.config.DefaultQuery) != 0 {
+		if r.query == nil {
+			r.query = make(url.Values)
+
+			// keep query string from URL, if any
+			for k, v := range r.httpReq.URL.Query() {
+				r.query[k] = v
+			}
+		}
+
+		mergeDefaults(r.query, r.config.DefaultQuery, policy)
+	}
+}
+
+func mergeDefaults(
+	values map[string][]string, defaults map[string][]string, policy MergePolicy,
+) {
+	for k, v := range defaults {
+		if _, ok := values[k]; ok && policy == ReplaceDefaults {
+			continue
+		}
+
+		values[k] = append(values[k], v...)
+	}
+}
+
+func canonicalHeader(header http.Header) http.Header {
+	ret := make(http.Header, len(header))
+
+	for k, v := range header {
+		k = http.CanonicalHeaderKey(k)
+		ret[k] = append(ret[k], v...)
+	}
+
+	return ret
+}
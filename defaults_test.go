@@ -0,0 +1,165 @@
+package httpexpect
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaults_Headers(t *testing.T) {
+	defaults := http.Header{
+		"accept":  {"application/json"},
+		"X-Token": {"default"},
+	}
+
+	cases := []struct {
+		name   string
+		policy MergePolicy
+		result http.Header
+	}{
+		{
+			name:   "default policy",
+			policy: defaultMergePolicy,
+			result: http.Header{
+				"Accept":  {"application/json"},
+				"X-Token": {"request"},
+			},
+		},
+		{
+			name:   "replace",
+			policy: ReplaceDefaults,
+			result: http.Header{
+				"Accept":  {"application/json"},
+				"X-Token": {"request"},
+			},
+		},
+		{
+			name:   "append",
+			policy: AppendDefaults,
+			result: http.Header{
+				"Accept":  {"application/json"},
+				"X-Token": {"request", "default"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &mockClient{}
+
+			e := WithConfig(Config{
+				BaseURL:             "http://example.com",
+				Client:              client,
+				Reporter:            newMockReporter(t),
+				DefaultHeaders:      defaults,
+				DefaultsMergePolicy: tc.policy,
+			})
+
+			e.GET("/path").
+				WithHeader("x-token", "request").
+				Expect().
+				chain.assert(t, success)
+
+			assert.Equal(t, tc.result, client.req.Header)
+		})
+	}
+
+	t.Run("defaults not modified", func(t *testing.T) {
+		assert.Equal(t, http.Header{
+			"accept":  {"application/json"},
+			"X-Token": {"default"},
+		}, defaults)
+	})
+}
+
+func TestDefaults_Query(t *testing.T) {
+	defaults := url.Values{
+		"page":  {"1"},
+		"limit": {"10"},
+	}
+
+	cases := []struct {
+		name   string
+		policy MergePolicy
+		query  string
+	}{
+		{
+			name:   "replace",
+			policy: ReplaceDefaults,
+			query:  "limit=50&page=1",
+		},
+		{
+			name:   "append",
+			policy: AppendDefaults,
+			query:  "limit=50&limit=10&page=1",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &mockClient{}
+
+			e := WithConfig(Config{
+				BaseURL:             "http://example.com",
+				Client:              client,
+				Reporter:            newMockReporter(t),
+				DefaultQuery:        defaults,
+				DefaultsMergePolicy: tc.policy,
+			})
+
+			e.GET("/path").
+				WithQuery("limit", 50).
+				Expect().
+				chain.assert(t, success)
+
+			assert.Equal(t, tc.query, client.req.URL.RawQuery)
+		})
+	}
+
+	t.Run("base url query", func(t *testing.T) {
+		client := &mockClient{}
+
+		e := WithConfig(Config{
+			BaseURL:      "http://example.com?key=secret",
+			Client:       client,
+			Reporter:     newMockReporter(t),
+			DefaultQuery: defaults,
+		})
+
+		e.GET("/path").Expect().chain.assert(t, success)
+
+		assert.Equal(t, "key=secret&limit=10&page=1", client.req.URL.RawQuery)
+	})
+}
+
+func TestDefaults_Profile(t *testing.T) {
+	client := &mockClient{}
+
+	e := WithConfig(Config{
+		Client:   client,
+		Reporter: newMockReporter(t),
+		DefaultHeaders: http.Header{
+			"X-Env":   {"default"},
+			"X-Token": {"default"},
+		},
+		Profiles: map[string]Profile{
+			"dev": {
+				BaseURL: "http://dev.example.com",
+				Headers: map[string]string{"x-env": "dev"},
+			},
+		},
+	})
+
+	e.WithProfile("dev").
+		GET("/path").
+		WithHeader("X-Token", "request").
+		Expect().
+		chain.assert(t, success)
+
+	assert.Equal(t, http.Header{
+		"X-Env":   {"dev"},
+		"X-Token": {"request"},
+	}, client.req.Header)
+}
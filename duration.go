@@ -2,6 +2,8 @@ package httpexpect
 
 import (
 	"errors"
+	"fmt"
+	"math"
 	"time"
 )
 
@@ -551,3 +553,103 @@ func (d *Duration) NotInList(values ...time.Duration) *Duration {
 
 	return d
 }
+
+// Plus returns a new Duration instance with given value added to duration.
+//
+// Example:
+//
+//	d := NewDuration(t, time.Minute)
+//	d.Plus(time.Second).IsEqual(61 * time.Second)
+func (d *Duration) Plus(value time.Duration) *Duration {
+	opChain := d.chain.enter("Plus(%v)", value)
+	defer opChain.leave()
+
+	return d.addDuration(opChain, value)
+}
+
+// Minus returns a new Duration instance with given value subtracted
+// from duration.
+//
+// Example:
+//
+//	d := NewDuration(t, time.Minute)
+//	d.Minus(time.Second).IsEqual(59 * time.Second)
+func (d *Duration) Minus(value time.Duration) *Duration {
+	opChain := d.chain.enter("Minus(%v)", value)
+	defer opChain.leave()
+
+	if value == math.MinInt64 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected out of range argument: %v", value),
+			},
+		})
+		return newDuration(opChain, nil)
+	}
+
+	return d.addDuration(opChain, -value)
+}
+
+func (d *Duration) addDuration(opChain *chain, value time.Duration) *Duration {
+	if opChain.failed() {
+		return newDuration(opChain, nil)
+	}
+
+	if d.value == nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{d.value},
+			Errors: []error{
+				errors.New("expected: duration is present"),
+			},
+		})
+		return newDuration(opChain, nil)
+	}
+
+	result := *d.value + value
+
+	if (value > 0 && result < *d.value) || (value < 0 && result > *d.value) {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{d.value},
+			Errors: []error{
+				fmt.Errorf("expected: duration stays in range after adding %v", value),
+			},
+		})
+		return newDuration(opChain, nil)
+	}
+
+	return newDuration(opChain, &result)
+}
+
+// AsSeconds returns a new Number instance with duration in seconds.
+//
+// Fractional part is preserved, so that duration can be compared with
+// numeric fields like "expires_in" using Number methods.
+//
+// Example:
+//
+//	d := NewDuration(t, 1500*time.Millisecond)
+//	d.AsSeconds().IsEqual(1.5)
+func (d *Duration) AsSeconds() *Number {
+	opChain := d.chain.enter("AsSeconds()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, 0)
+	}
+
+	if d.value == nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{d.value},
+			Errors: []error{
+				errors.New("expected: duration is present"),
+			},
+		})
+		return newNumber(opChain, 0)
+	}
+
+	return newNumber(opChain, d.value.Seconds())
+}
@@ -1,6 +1,7 @@
 package httpexpect
 
 import (
+	"math"
 	"testing"
 	"time"
 
@@ -25,6 +26,9 @@ func TestDuration_FailedChain(t *testing.T) {
 	value.Ge(tm)
 	value.Lt(tm)
 	value.Le(tm)
+	value.Plus(tm).chain.assert(t, failure)
+	value.Minus(tm).chain.assert(t, failure)
+	value.AsSeconds().chain.assert(t, failure)
 }
 
 func TestDuration_Constructors(t *testing.T) {
@@ -312,3 +316,65 @@ func TestDuration_InList(t *testing.T) {
 		})
 	}
 }
+
+func TestDuration_Arithmetic(t *testing.T) {
+	t.Run("plus", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewDuration(reporter, time.Minute)
+		result := value.Plus(time.Second)
+		result.IsEqual(61 * time.Second)
+
+		assert.Equal(t, time.Minute, value.Raw())
+		value.chain.assert(t, success)
+		result.chain.assert(t, success)
+	})
+
+	t.Run("minus", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewDuration(reporter, time.Minute)
+		result := value.Minus(2 * time.Minute)
+		result.IsEqual(-time.Minute)
+
+		value.chain.assert(t, success)
+		result.chain.assert(t, success)
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewDuration(reporter, math.MaxInt64-1)
+		value.Plus(2).chain.assert(t, failure)
+		value.chain.assert(t, failure)
+
+		value = NewDuration(reporter, math.MinInt64+1)
+		value.Minus(2).chain.assert(t, failure)
+		value.chain.assert(t, failure)
+
+		value = NewDuration(reporter, 0)
+		value.Minus(math.MinInt64).chain.assert(t, failure)
+		value.chain.assert(t, failure)
+	})
+
+	t.Run("unset", func(t *testing.T) {
+		value := newDuration(newMockChain(t), nil)
+		value.Plus(time.Second).chain.assert(t, failure)
+	})
+}
+
+func TestDuration_AsSeconds(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewDuration(reporter, 1500*time.Millisecond)
+	value.AsSeconds().IsEqual(1.5)
+	value.chain.assert(t, success)
+
+	value = NewDuration(reporter, time.Hour)
+	value.AsSeconds().IsEqual(3600).IsInt()
+	value.chain.assert(t, success)
+
+	unset := newDuration(newMockChain(t), nil)
+	unset.AsSeconds().chain.assert(t, failure)
+	unset.chain.assert(t, failure)
+}
@@ -1,6 +1,7 @@
 package e2e
 
 import (
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -103,3 +104,46 @@ func TestE2EPrinter_Multiple(t *testing.T) {
 	assert.Equal(t, "test_request", string(p2.reqBody))
 	assert.Equal(t, "test_response", string(p2.respBody))
 }
+
+type mockLogger struct {
+	messages []string
+}
+
+func (l *mockLogger) Logf(message string, args ...interface{}) {
+	l.messages = append(l.messages, fmt.Sprintf(message, args...))
+}
+
+func TestE2EPrinter_Log(t *testing.T) {
+	handler := createPrinterHandler()
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	logger := &mockLogger{}
+	p := &mockPrinter{}
+
+	e := httpexpect.WithConfig(httpexpect.Config{
+		BaseURL:  server.URL,
+		Reporter: httpexpect.NewAssertReporter(t),
+		Printers: []httpexpect.Printer{
+			&httpexpect.LogPrinter{
+				Logger: logger,
+			},
+			p,
+		},
+	})
+
+	e.POST("/test").
+		WithText("test_request").
+		Expect().
+		Text().
+		IsEqual("test_response")
+
+	assert.Equal(t, 1, len(logger.messages))
+	assert.Contains(t, logger.messages[0], "test_request")
+	assert.Contains(t, logger.messages[0], "200 OK")
+	assert.Contains(t, logger.messages[0], "test_response")
+
+	assert.Equal(t, "test_request", string(p.reqBody))
+	assert.Equal(t, "test_response", string(p.respBody))
+}
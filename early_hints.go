@@ -0,0 +1,146 @@
+package httpexpect
+
+import (
+	"errors"
+	"strings"
+)
+
+// EarlyHints returns a new Array instance with links from Link headers
+// of 103 Early Hints interim responses received before the response.
+//
+// Every element is an object with "url" key holding link target, and
+// a key for every link parameter, e.g. "rel" and "as". Parameter names
+// are lower-cased. Links from all interim responses are included, in the
+// order in which they were received.
+//
+// Interim responses are collected using net/http/httptrace, so, like
+// ConnectionReused, EarlyHints reports failure if response was received
+// not via http.Transport. If no interim responses were received, EarlyHints
+// returns empty array.
+//
+// Note that http.Client doesn't support HTTP/2 server push, so resources
+// pushed by server are not available. Early hints are the recommended
+// replacement for server push.
+//
+// Example:
+//
+//	resp := e.GET("/").Expect()
+//
+//	resp.EarlyHints().ContainsAny(map[string]interface{}{
+//		"url": "/style.css",
+//		"rel": "preload",
+//		"as":  "style",
+//	})
+func (r *Response) EarlyHints() *Array {
+	opChain := r.chain.enter("EarlyHints()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	if r.connInfo == nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{r.connInfo},
+			Errors: []error{
+				errors.New("expected: early hints info is available"),
+				errors.New("early hints are collected only for clients" +
+					" that use http.Transport"),
+			},
+		})
+		return newArray(opChain, nil)
+	}
+
+	links := []interface{}{}
+
+	for _, header := range r.earlyHints {
+		for _, link := range parseLinks(header.Values("Link")) {
+			links = append(links, link)
+		}
+	}
+
+	return newArray(opChain, links)
+}
+
+// parseLinks parses values of Link header (RFC 8288).
+// Malformed links are skipped.
+func parseLinks(values []string) []map[string]interface{} {
+	var links []map[string]interface{}
+
+	for _, value := range values {
+		for _, part := range splitLinkHeader(value) {
+			if link := parseLink(part); link != nil {
+				links = append(links, link)
+			}
+		}
+	}
+
+	return links
+}
+
+// splitLinkHeader splits header value by commas that are not inside
+// angle brackets or quoted strings.
+func splitLinkHeader(value string) []string {
+	var (
+		parts   []string
+		start   int
+		inURL   bool
+		inQuote bool
+	)
+
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; {
+		case inQuote && c == '\\':
+			i++
+		case c == '"' && !inURL:
+			inQuote = !inQuote
+		case c == '<' && !inQuote:
+			inURL = true
+		case c == '>' && !inQuote:
+			inURL = false
+		case c == ',' && !inURL && !inQuote:
+			parts = append(parts, value[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(parts, value[start:])
+}
+
+func parseLink(s string) map[string]interface{} {
+	s = strings.TrimSpace(s)
+
+	if !strings.HasPrefix(s, "<") {
+		return nil
+	}
+
+	end := strings.IndexByte(s, '>')
+	if end < 0 {
+		return nil
+	}
+
+	link := map[string]interface{}{
+		"url": strings.TrimSpace(s[1:end]),
+	}
+
+	for _, param := range strings.Split(s[end+1:], ";") {
+		param = strings.TrimSpace(param)
+		if param == "" {
+			continue
+		}
+
+		name, value, _ := strings.Cut(param, "=")
+
+		name = strings.ToLower(strings.TrimSpace(name))
+		value = strings.TrimSpace(value)
+
+		if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+			value = strings.ReplaceAll(value[1:len(value)-1], `\"`, `"`)
+		}
+
+		link[name] = value
+	}
+
+	return link
+}
@@ -0,0 +1,135 @@
+package httpexpect
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEarlyHints_Response(t *testing.T) {
+	server := newRawResponseServer(t, map[string]string{
+		"/hints": "HTTP/1.1 103 Early Hints\r\n" +
+			"Link: </style.css>; rel=preload; as=style\r\n" +
+			"\r\n" +
+			"HTTP/1.1 103 Early Hints\r\n" +
+			"Link: </script.js>; rel=preload; as=script, </font.woff2>;" +
+			" rel=preload; as=font; crossorigin\r\n" +
+			"\r\n" +
+			"HTTP/1.1 200 OK\r\n" +
+			"Content-Length: 0\r\n" +
+			"\r\n",
+		"/plain": "HTTP/1.1 200 OK\r\n" +
+			"Content-Length: 0\r\n" +
+			"\r\n",
+	})
+
+	newExpect := func(t *testing.T) *Expect {
+		return WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: newMockReporter(t),
+			Client: &http.Client{
+				Transport: &http.Transport{},
+			},
+		})
+	}
+
+	t.Run("hints", func(t *testing.T) {
+		resp := newExpect(t).GET("/hints").Expect()
+
+		resp.Status(http.StatusOK)
+		resp.EarlyHints().IsEqual([]interface{}{
+			map[string]interface{}{
+				"url": "/style.css",
+				"rel": "preload",
+				"as":  "style",
+			},
+			map[string]interface{}{
+				"url": "/script.js",
+				"rel": "preload",
+				"as":  "script",
+			},
+			map[string]interface{}{
+				"url":         "/font.woff2",
+				"rel":         "preload",
+				"as":          "font",
+				"crossorigin": "",
+			},
+		})
+
+		resp.chain.assert(t, success)
+	})
+
+	t.Run("no hints", func(t *testing.T) {
+		resp := newExpect(t).GET("/plain").Expect()
+
+		resp.EarlyHints().IsEmpty()
+		resp.chain.assert(t, success)
+	})
+
+	t.Run("not available", func(t *testing.T) {
+		e := WithConfig(Config{
+			Reporter: newMockReporter(t),
+			Client: &http.Client{
+				Transport: NewBinder(http.HandlerFunc(
+					func(w http.ResponseWriter, r *http.Request) {})),
+			},
+		})
+
+		resp := e.GET("/").Expect()
+		resp.chain.assert(t, success)
+
+		resp.EarlyHints()
+		resp.chain.assert(t, failure)
+	})
+}
+
+func TestEarlyHints_ParseLinks(t *testing.T) {
+	cases := []struct {
+		name   string
+		values []string
+		links  []map[string]interface{}
+	}{
+		{
+			name:   "empty",
+			values: nil,
+			links:  nil,
+		},
+		{
+			name:   "url only",
+			values: []string{"</a>"},
+			links: []map[string]interface{}{
+				{"url": "/a"},
+			},
+		},
+		{
+			name:   "multiple values",
+			values: []string{"</a>; rel=preload", "</b>; rel=preconnect"},
+			links: []map[string]interface{}{
+				{"url": "/a", "rel": "preload"},
+				{"url": "/b", "rel": "preconnect"},
+			},
+		},
+		{
+			name:   "comma in url and quotes",
+			values: []string{`</a,b>; title="x, \"y\"", </c>; REL=Preload`},
+			links: []map[string]interface{}{
+				{"url": "/a,b", "title": `x, "y"`},
+				{"url": "/c", "rel": "Preload"},
+			},
+		},
+		{
+			name:   "malformed",
+			values: []string{"/a; rel=preload, </b>, <missing-end"},
+			links: []map[string]interface{}{
+				{"url": "/b"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.links, parseLinks(tc.values))
+		})
+	}
+}
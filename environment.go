@@ -1,8 +1,13 @@
 package httpexpect
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"os"
+	"reflect"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -17,9 +22,15 @@ import (
 //	env.Put("key", "value")
 //	value := env.GetString("key")
 type Environment struct {
-	mu    sync.RWMutex
-	chain *chain
-	data  map[string]interface{}
+	chain  *chain
+	store  *envStore
+	prefix string
+}
+
+// Storage shared between Environment and its scopes.
+type envStore struct {
+	mu   sync.RWMutex
+	data map[string]interface{}
 }
 
 // NewEnvironment returns a new Environment.
@@ -47,7 +58,9 @@ func NewEnvironmentC(config Config) *Environment {
 func newEnvironment(parent *chain) *Environment {
 	return &Environment{
 		chain: parent.clone(),
-		data:  make(map[string]interface{}),
+		store: &envStore{
+			data: make(map[string]interface{}),
+		},
 	}
 }
 
@@ -62,10 +75,10 @@ func (e *Environment) Put(key string, value interface{}) {
 	opChain := e.chain.enter("Put(%q)", key)
 	defer opChain.leave()
 
-	e.mu.Lock()
-	defer e.mu.Unlock()
+	e.store.mu.Lock()
+	defer e.store.mu.Unlock()
 
-	e.data[key] = value
+	e.store.data[e.prefix+key] = value
 }
 
 // Delete removes the value with key from the environment.
@@ -79,10 +92,10 @@ func (e *Environment) Delete(key string) {
 	opChain := e.chain.enter("Delete(%q)", key)
 	defer opChain.leave()
 
-	e.mu.Lock()
-	defer e.mu.Unlock()
+	e.store.mu.Lock()
+	defer e.store.mu.Unlock()
 
-	delete(e.data, key)
+	delete(e.store.data, e.prefix+key)
 }
 
 // Clear will delete all key value pairs from the environment
@@ -97,10 +110,17 @@ func (e *Environment) Clear() {
 	opChain := e.chain.enter("Clear()")
 	defer opChain.leave()
 
-	e.mu.Lock()
-	defer e.mu.Unlock()
+	e.store.mu.Lock()
+	defer e.store.mu.Unlock()
 
-	e.data = make(map[string]interface{})
+	if e.prefix == "" {
+		e.store.data = make(map[string]interface{})
+		return
+	}
+
+	for key := range e.scopeData() {
+		delete(e.store.data, e.prefix+key)
+	}
 }
 
 // Has returns true if value exists in the environment.
@@ -114,10 +134,10 @@ func (e *Environment) Has(key string) bool {
 	opChain := e.chain.enter("Has(%q)", key)
 	defer opChain.leave()
 
-	e.mu.RLock()
-	defer e.mu.RUnlock()
+	e.store.mu.RLock()
+	defer e.store.mu.RUnlock()
 
-	_, ok := e.data[key]
+	_, ok := e.store.data[e.prefix+key]
 	return ok
 }
 
@@ -133,10 +153,10 @@ func (e *Environment) Get(key string) interface{} {
 	opChain := e.chain.enter("Get(%q)", key)
 	defer opChain.leave()
 
-	e.mu.RLock()
-	defer e.mu.RUnlock()
+	e.store.mu.RLock()
+	defer e.store.mu.RUnlock()
 
-	value, _ := envValue(opChain, e.data, key)
+	value, _ := e.getValue(opChain, key)
 
 	return value
 }
@@ -152,10 +172,10 @@ func (e *Environment) GetBool(key string) bool {
 	opChain := e.chain.enter("GetBool(%q)", key)
 	defer opChain.leave()
 
-	e.mu.RLock()
-	defer e.mu.RUnlock()
+	e.store.mu.RLock()
+	defer e.store.mu.RUnlock()
 
-	value, ok := envValue(opChain, e.data, key)
+	value, ok := e.getValue(opChain, key)
 	if !ok {
 		return false
 	}
@@ -187,10 +207,10 @@ func (e *Environment) GetInt(key string) int {
 	opChain := e.chain.enter("GetInt(%q)", key)
 	defer opChain.leave()
 
-	e.mu.RLock()
-	defer e.mu.RUnlock()
+	e.store.mu.RLock()
+	defer e.store.mu.RUnlock()
 
-	value, ok := envValue(opChain, e.data, key)
+	value, ok := e.getValue(opChain, key)
 	if !ok {
 		return 0
 	}
@@ -275,10 +295,10 @@ func (e *Environment) GetFloat(key string) float64 {
 	opChain := e.chain.enter("GetFloat(%q)", key)
 	defer opChain.leave()
 
-	e.mu.RLock()
-	defer e.mu.RUnlock()
+	e.store.mu.RLock()
+	defer e.store.mu.RUnlock()
 
-	value, ok := envValue(opChain, e.data, key)
+	value, ok := e.getValue(opChain, key)
 	if !ok {
 		return 0
 	}
@@ -318,10 +338,10 @@ func (e *Environment) GetString(key string) string {
 	opChain := e.chain.enter("GetString(%q)", key)
 	defer opChain.leave()
 
-	e.mu.RLock()
-	defer e.mu.RUnlock()
+	e.store.mu.RLock()
+	defer e.store.mu.RUnlock()
 
-	value, ok := envValue(opChain, e.data, key)
+	value, ok := e.getValue(opChain, key)
 	if !ok {
 		return ""
 	}
@@ -352,10 +372,10 @@ func (e *Environment) GetBytes(key string) []byte {
 	opChain := e.chain.enter("GetBytes(%q)", key)
 	defer opChain.leave()
 
-	e.mu.RLock()
-	defer e.mu.RUnlock()
+	e.store.mu.RLock()
+	defer e.store.mu.RUnlock()
 
-	value, ok := envValue(opChain, e.data, key)
+	value, ok := e.getValue(opChain, key)
 	if !ok {
 		return nil
 	}
@@ -387,10 +407,10 @@ func (e *Environment) GetDuration(key string) time.Duration {
 	opChain := e.chain.enter("GetDuration(%q)", key)
 	defer opChain.leave()
 
-	e.mu.RLock()
-	defer e.mu.RUnlock()
+	e.store.mu.RLock()
+	defer e.store.mu.RUnlock()
 
-	value, ok := envValue(opChain, e.data, key)
+	value, ok := e.getValue(opChain, key)
 	if !ok {
 		return time.Duration(0)
 	}
@@ -422,10 +442,10 @@ func (e *Environment) GetTime(key string) time.Time {
 	opChain := e.chain.enter("GetTime(%q)", key)
 	defer opChain.leave()
 
-	e.mu.RLock()
-	defer e.mu.RUnlock()
+	e.store.mu.RLock()
+	defer e.store.mu.RUnlock()
 
-	value, ok := envValue(opChain, e.data, key)
+	value, ok := e.getValue(opChain, key)
 	if !ok {
 		return time.Unix(0, 0)
 	}
@@ -458,12 +478,12 @@ func (e *Environment) List() []string {
 	opChain := e.chain.enter("List()")
 	defer opChain.leave()
 
-	e.mu.RLock()
-	defer e.mu.RUnlock()
+	e.store.mu.RLock()
+	defer e.store.mu.RUnlock()
 
 	keys := []string{}
 
-	for key := range e.data {
+	for key := range e.scopeData() {
 		keys = append(keys, key)
 	}
 
@@ -490,8 +510,8 @@ func (e *Environment) Glob(pattern string) []string {
 	opChain := e.chain.enter("Glob(%q)", pattern)
 	defer opChain.leave()
 
-	e.mu.RLock()
-	defer e.mu.RUnlock()
+	e.store.mu.RLock()
+	defer e.store.mu.RUnlock()
 
 	glb, err := glob.Compile(pattern)
 	if err != nil {
@@ -505,7 +525,7 @@ func (e *Environment) Glob(pattern string) []string {
 	}
 
 	keys := []string{}
-	for key := range e.data {
+	for key := range e.scopeData() {
 		if glb.Match(key) {
 			keys = append(keys, key)
 		}
@@ -517,13 +537,310 @@ func (e *Environment) Glob(pattern string) []string {
 	return keys
 }
 
-func envValue(chain *chain, env map[string]interface{}, key string) (interface{}, bool) {
-	v, ok := env[key]
+// Scope returns a new Environment that shares storage with the current one,
+// but uses separate namespace for keys.
+//
+// Keys stored in a scope are prefixed with scope name and a dot. For example,
+// key "token" put into scope "auth" can be accessed as "auth.token" from the
+// parent environment. Scopes can be nested.
+//
+// Scopes are useful to isolate data of sub-tests that share environment.
+//
+// Example:
+//
+//	env := NewEnvironment(t)
+//
+//	t.Run("user1", func(t *testing.T) {
+//		scope := env.Scope("user1")
+//		scope.Put("token", "abc")
+//	})
+//
+//	token := env.GetString("user1.token")
+func (e *Environment) Scope(name string) *Environment {
+	opChain := e.chain.enter("Scope(%q)", name)
+	defer opChain.leave()
+
+	if name == "" {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected empty scope name"),
+			},
+		})
+	}
+
+	return &Environment{
+		chain:  opChain.clone(),
+		store:  e.store,
+		prefix: e.prefix + name + ".",
+	}
+}
+
+// EnvironmentSnapshot holds a copy of environment values.
+// It is returned by Environment.Snapshot and used by Environment.Restore.
+type EnvironmentSnapshot struct {
+	data map[string]interface{}
+}
+
+// Snapshot returns a copy of all values stored in the environment (or in
+// the environment scope, if Scope was used).
+//
+// Values are copied shallowly, i.e. if value is a pointer, map, or slice,
+// it will be shared between environment and snapshot.
+//
+// Example:
+//
+//	snapshot := env.Snapshot()
+//	defer env.Restore(snapshot)
+//
+//	env.Put("key", "temporary value")
+func (e *Environment) Snapshot() *EnvironmentSnapshot {
+	opChain := e.chain.enter("Snapshot()")
+	defer opChain.leave()
+
+	e.store.mu.RLock()
+	defer e.store.mu.RUnlock()
 
+	return &EnvironmentSnapshot{
+		data: e.scopeData(),
+	}
+}
+
+// Restore replaces all values stored in the environment (or in the
+// environment scope, if Scope was used) with values from snapshot.
+//
+// Values that were added after the snapshot was taken are removed.
+// If snapshot is nil, reports failure.
+//
+// Example:
+//
+//	snapshot := env.Snapshot()
+//	defer env.Restore(snapshot)
+func (e *Environment) Restore(snapshot *EnvironmentSnapshot) {
+	opChain := e.chain.enter("Restore()")
+	defer opChain.leave()
+
+	if snapshot == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil argument"),
+			},
+		})
+		return
+	}
+
+	e.store.mu.Lock()
+	defer e.store.mu.Unlock()
+
+	for key := range e.scopeData() {
+		delete(e.store.data, e.prefix+key)
+	}
+
+	for key, value := range snapshot.data {
+		e.store.data[e.prefix+key] = value
+	}
+}
+
+// SaveFile writes all values stored in the environment (or in the
+// environment scope, if Scope was used) into a JSON file.
+//
+// Values of basic types (bool, integers, floats, string, []byte,
+// time.Duration, time.Time) are saved together with their type and will
+// be restored with the same type by LoadFile. Values of other types are
+// saved as plain JSON and will be restored as generic JSON values
+// (map[string]interface{}, []interface{}, float64, etc).
+//
+// If file can't be written or value can't be encoded, reports failure.
+//
+// This allows to share environment between test runs or processes, e.g.
+// save authentication token in one suite and use it in another.
+//
+// Example:
+//
+//	env.Put("token", token)
+//	env.SaveFile("env.json")
+func (e *Environment) SaveFile(path string) {
+	opChain := e.chain.enter("SaveFile(%q)", path)
+	defer opChain.leave()
+
+	e.store.mu.RLock()
+	defer e.store.mu.RUnlock()
+
+	entries := make(map[string]envFileEntry)
+
+	for key, value := range e.scopeData() {
+		entry, err := newEnvFileEntry(value)
+		if err != nil {
+			opChain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					fmt.Errorf("failed to encode environment value %q", key),
+					err,
+				},
+			})
+			return
+		}
+		entries[key] = entry
+	}
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err == nil {
+		err = os.WriteFile(path, data, 0o644) //nolint:gosec
+	}
+
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("failed to save environment"),
+				err,
+			},
+		})
+	}
+}
+
+// LoadFile reads values from a JSON file written by SaveFile and puts them
+// into the environment (or into the environment scope, if Scope was used).
+//
+// Existing values with the same keys are overwritten, other values are
+// preserved.
+//
+// If file can't be read or parsed, reports failure.
+//
+// Example:
+//
+//	env.LoadFile("env.json")
+//	token := env.GetString("token")
+func (e *Environment) LoadFile(path string) {
+	opChain := e.chain.enter("LoadFile(%q)", path)
+	defer opChain.leave()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("failed to load environment"),
+				err,
+			},
+		})
+		return
+	}
+
+	var entries map[string]envFileEntry
+
+	if err := json.Unmarshal(data, &entries); err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("failed to parse environment file"),
+				err,
+			},
+		})
+		return
+	}
+
+	values := make(map[string]interface{}, len(entries))
+
+	for key, entry := range entries {
+		value, err := entry.decode()
+		if err != nil {
+			opChain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					fmt.Errorf("failed to decode environment value %q", key),
+					err,
+				},
+			})
+			return
+		}
+		values[key] = value
+	}
+
+	e.store.mu.Lock()
+	defer e.store.mu.Unlock()
+
+	for key, value := range values {
+		e.store.data[e.prefix+key] = value
+	}
+}
+
+// Types preserved by SaveFile and LoadFile.
+var envFileTypes = map[string]reflect.Type{
+	"bool":     reflect.TypeOf(false),
+	"int":      reflect.TypeOf(int(0)),
+	"int8":     reflect.TypeOf(int8(0)),
+	"int16":    reflect.TypeOf(int16(0)),
+	"int32":    reflect.TypeOf(int32(0)),
+	"int64":    reflect.TypeOf(int64(0)),
+	"uint":     reflect.TypeOf(uint(0)),
+	"uint8":    reflect.TypeOf(uint8(0)),
+	"uint16":   reflect.TypeOf(uint16(0)),
+	"uint32":   reflect.TypeOf(uint32(0)),
+	"uint64":   reflect.TypeOf(uint64(0)),
+	"float32":  reflect.TypeOf(float32(0)),
+	"float64":  reflect.TypeOf(float64(0)),
+	"string":   reflect.TypeOf(""),
+	"bytes":    reflect.TypeOf([]byte(nil)),
+	"duration": reflect.TypeOf(time.Duration(0)),
+	"time":     reflect.TypeOf(time.Time{}),
+}
+
+type envFileEntry struct {
+	Type  string          `json:"type,omitempty"`
+	Value json.RawMessage `json:"value"`
+}
+
+func newEnvFileEntry(value interface{}) (envFileEntry, error) {
+	var entry envFileEntry
+
+	if value != nil {
+		for name, typ := range envFileTypes {
+			if reflect.TypeOf(value) == typ {
+				entry.Type = name
+				break
+			}
+		}
+	}
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		return entry, err
+	}
+
+	entry.Value = b
+
+	return entry, nil
+}
+
+func (entry envFileEntry) decode() (interface{}, error) {
+	if entry.Type == "" {
+		var value interface{}
+		err := json.Unmarshal(entry.Value, &value)
+		return value, err
+	}
+
+	typ, ok := envFileTypes[entry.Type]
 	if !ok {
-		chain.fail(AssertionFailure{
+		return nil, fmt.Errorf("unknown value type %q", entry.Type)
+	}
+
+	ptr := reflect.New(typ)
+	if err := json.Unmarshal(entry.Value, ptr.Interface()); err != nil {
+		return nil, err
+	}
+
+	return ptr.Elem().Interface(), nil
+}
+
+func (e *Environment) getValue(opChain *chain, key string) (interface{}, bool) {
+	v, ok := e.store.data[e.prefix+key]
+
+	if !ok {
+		opChain.fail(AssertionFailure{
 			Type:     AssertContainsKey,
-			Actual:   &AssertionValue{env},
+			Actual:   &AssertionValue{e.scopeData()},
 			Expected: &AssertionValue{key},
 			Errors: []error{
 				errors.New("expected: environment contains key"),
@@ -534,3 +851,17 @@ func envValue(chain *chain, env map[string]interface{}, key string) (interface{}
 
 	return v, true
 }
+
+// Returns copy of values belonging to environment scope, with scope prefix
+// stripped from keys. Should be called with mutex locked.
+func (e *Environment) scopeData() map[string]interface{} {
+	data := make(map[string]interface{})
+
+	for key, value := range e.store.data {
+		if strings.HasPrefix(key, e.prefix) {
+			data[strings.TrimPrefix(key, e.prefix)] = value
+		}
+	}
+
+	return data
+}
@@ -3,6 +3,8 @@ package httpexpect
 import (
 	"fmt"
 	"math"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -110,7 +112,7 @@ func TestEnvironment_Clear(t *testing.T) {
 		assert.False(t, env.Has(key))
 	}
 
-	assert.Zero(t, len(env.data))
+	assert.Zero(t, len(env.store.data))
 	env.chain.assert(t, success)
 }
 
@@ -573,3 +575,219 @@ func TestEnvironment_Glob(t *testing.T) {
 		env.chain.assert(t, failure)
 	})
 }
+
+func TestEnvironment_Scope(t *testing.T) {
+	t.Run("basic", func(t *testing.T) {
+		env := newEnvironment(newMockChain(t))
+
+		env.Put("key", 1)
+
+		scope := env.Scope("foo")
+		scope.chain.assert(t, success)
+
+		assert.False(t, scope.Has("key"))
+
+		scope.Put("key", 2)
+		assert.Equal(t, 2, scope.GetInt("key"))
+		assert.Equal(t, 2, env.GetInt("foo.key"))
+		assert.Equal(t, 1, env.GetInt("key"))
+
+		assert.Equal(t, []string{"key"}, scope.List())
+		assert.Equal(t, []string{"foo.key", "key"}, env.List())
+		assert.Equal(t, []string{"key"}, scope.Glob("*"))
+
+		env.chain.assert(t, success)
+		scope.chain.assert(t, success)
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		env := newEnvironment(newMockChain(t))
+
+		scope := env.Scope("foo").Scope("bar")
+		scope.Put("key", "value")
+
+		assert.Equal(t, "value", env.GetString("foo.bar.key"))
+		assert.Equal(t, "value", env.Scope("foo").GetString("bar.key"))
+
+		env.chain.assert(t, success)
+	})
+
+	t.Run("clear", func(t *testing.T) {
+		env := newEnvironment(newMockChain(t))
+
+		env.Put("key", 1)
+		env.Scope("foo").Put("key", 2)
+		env.Scope("bar").Put("key", 3)
+
+		env.Scope("foo").Clear()
+		assert.Equal(t, []string{"bar.key", "key"}, env.List())
+
+		env.Clear()
+		assert.Equal(t, []string{}, env.List())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		env := newEnvironment(newMockChain(t))
+
+		env.Put("key", 1)
+
+		scope := env.Scope("foo")
+		scope.Get("key")
+		scope.chain.assert(t, failure)
+
+		env.chain.assert(t, success)
+	})
+
+	t.Run("empty name", func(t *testing.T) {
+		env := newEnvironment(newMockChain(t))
+
+		scope := env.Scope("")
+		scope.chain.assert(t, failure)
+	})
+}
+
+func TestEnvironment_Snapshot(t *testing.T) {
+	t.Run("restore", func(t *testing.T) {
+		env := newEnvironment(newMockChain(t))
+
+		env.Put("k1", 1)
+		env.Put("k2", 2)
+
+		snapshot := env.Snapshot()
+
+		env.Put("k1", 10)
+		env.Delete("k2")
+		env.Put("k3", 3)
+
+		env.Restore(snapshot)
+		env.chain.assert(t, success)
+
+		assert.Equal(t, []string{"k1", "k2"}, env.List())
+		assert.Equal(t, 1, env.GetInt("k1"))
+		assert.Equal(t, 2, env.GetInt("k2"))
+	})
+
+	t.Run("scope", func(t *testing.T) {
+		env := newEnvironment(newMockChain(t))
+
+		scope := env.Scope("foo")
+		scope.Put("key", 1)
+
+		snapshot := scope.Snapshot()
+
+		scope.Put("key", 2)
+		env.Put("other", 3)
+
+		scope.Restore(snapshot)
+		env.chain.assert(t, success)
+
+		assert.Equal(t, []string{"foo.key", "other"}, env.List())
+		assert.Equal(t, 1, env.GetInt("foo.key"))
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		env := newEnvironment(newMockChain(t))
+
+		env.Restore(nil)
+		env.chain.assert(t, failure)
+	})
+}
+
+func TestEnvironment_File(t *testing.T) {
+	t.Run("save and load", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "env.json")
+
+		tm := time.Unix(9999999, 123).UTC()
+
+		env1 := newEnvironment(newMockChain(t))
+
+		env1.Put("bool", true)
+		env1.Put("int", 123)
+		env1.Put("int8", int8(-5))
+		env1.Put("uint64", uint64(math.MaxUint64))
+		env1.Put("float", 1.5)
+		env1.Put("string", "str")
+		env1.Put("bytes", []byte("bytes"))
+		env1.Put("duration", time.Second)
+		env1.Put("time", tm)
+		env1.Put("map", map[string]interface{}{"foo": 1})
+		env1.Put("nil", nil)
+
+		env1.SaveFile(path)
+		env1.chain.assert(t, success)
+
+		env2 := newEnvironment(newMockChain(t))
+
+		env2.Put("existing", "value")
+
+		env2.LoadFile(path)
+		env2.chain.assert(t, success)
+
+		assert.Equal(t, true, env2.Get("bool"))
+		assert.Equal(t, 123, env2.Get("int"))
+		assert.Equal(t, int8(-5), env2.Get("int8"))
+		assert.Equal(t, uint64(math.MaxUint64), env2.Get("uint64"))
+		assert.Equal(t, 1.5, env2.Get("float"))
+		assert.Equal(t, "str", env2.Get("string"))
+		assert.Equal(t, []byte("bytes"), env2.Get("bytes"))
+		assert.Equal(t, time.Second, env2.GetDuration("duration"))
+		assert.True(t, tm.Equal(env2.GetTime("time")))
+		assert.Equal(t, map[string]interface{}{"foo": 1.0}, env2.Get("map"))
+		assert.Nil(t, env2.Get("nil"))
+		assert.Equal(t, "value", env2.Get("existing"))
+
+		env2.chain.assert(t, success)
+	})
+
+	t.Run("scope", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "env.json")
+
+		env1 := newEnvironment(newMockChain(t))
+
+		env1.Put("key", 1)
+		env1.Scope("foo").Put("key", 2)
+
+		env1.Scope("foo").SaveFile(path)
+		env1.chain.assert(t, success)
+
+		env2 := newEnvironment(newMockChain(t))
+
+		env2.Scope("bar").LoadFile(path)
+		env2.chain.assert(t, success)
+
+		assert.Equal(t, []string{"bar.key"}, env2.List())
+		assert.Equal(t, 2, env2.GetInt("bar.key"))
+	})
+
+	t.Run("unencodable value", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "env.json")
+
+		env := newEnvironment(newMockChain(t))
+
+		env.Put("key", func() {})
+
+		env.SaveFile(path)
+		env.chain.assert(t, failure)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "env.json")
+
+		env := newEnvironment(newMockChain(t))
+
+		env.LoadFile(path)
+		env.chain.assert(t, failure)
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "env.json")
+
+		err := os.WriteFile(path, []byte(`{"key":{"type":"bad","value":1}}`), 0o600)
+		assert.NoError(t, err)
+
+		env := newEnvironment(newMockChain(t))
+
+		env.LoadFile(path)
+		env.chain.assert(t, failure)
+	})
+}
@@ -24,6 +24,11 @@
 //
 // Note that http handler can be usually obtained from http framework you're using.
 // E.g., echo framework provides either http.Handler or fasthttp.RequestHandler.
+// Similarly, grpc-gateway runtime.ServeMux is an http.Handler, so gRPC services
+// exposed via grpc-gateway can be tested using Binder too.
+//
+// Request.WithHandler and Request.WithFastHandler may be used to set up
+// Binder or FastBinder for individual requests.
 //
 // You can also provide your own implementation of RequestFactory (creates http.Request),
 // or Client (gets http.Request and returns http.Response).
@@ -75,12 +80,24 @@
 //
 // Custom AssertionHandler can handle all assertions (e.g. dump them in JSON format)
 // and is free to use or not to use Formatter and Reporter in its sole discretion.
+//
+// # Concurrency
+//
+// Expect and Environment instances are safe for concurrent use and can be shared
+// between parallel tests.
+//
+// Request is a builder and should be used from a single goroutine: all WithXXX
+// calls and the Expect call should happen on the goroutine that created the
+// request. Set Config.Parallel to detect violations of this rule at runtime.
+// The same applies to Response and other matchers created from a request.
 package httpexpect
 
 import (
 	"context"
 	"io"
 	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/gorilla/websocket"
 )
@@ -93,6 +110,8 @@ type Expect struct {
 	chain    *chain
 	builders []func(*Request)
 	matchers []func(*Response)
+
+	connStats *connStats
 }
 
 // Config contains various settings.
@@ -104,6 +123,15 @@ type Config struct {
 	// Normally you set this value to t.Name().
 	TestName string
 
+	// ArtifactsDir defines directory for debugging output of tests.
+	// May be empty.
+	//
+	// If non-empty, every test gets its own subdirectory named after
+	// TestName, so all output of a failing test lands in one place. It's
+	// used by HTMLReportHandler (if its Dir is empty), AttachmentHandler
+	// (if its Writer is nil), and Expect.ArtifactPath.
+	ArtifactsDir string
+
 	// BaseURL is a URL to prepended to all requests.
 	// May be empty.
 	//
@@ -143,6 +171,59 @@ type Config struct {
 	// custom implementation.
 	WebsocketDialer WebsocketDialer
 
+	// HostOverrides maps host names to addresses that should be used instead
+	// of resolving them, similar to curl --resolve.
+	// May be nil.
+	//
+	// Keys are either "host:port" or "host"; the former take precedence.
+	// Values are either "ip:port" or "ip"; in the latter case, original
+	// port is kept. URL, Host header, and TLS server name are not changed,
+	// so tests can target specific instances behind shared host names.
+	//
+	// Overrides require Client to be *http.Client with nil Transport or
+	// *http.Transport. Client is copied, and original one is not modified.
+	// Overrides are not applied to WebsocketDialer.
+	//
+	// Example:
+	//
+	//	HostOverrides: map[string]string{
+	//		"api.example.com:443": "10.0.0.5:8443",
+	//	}
+	HostOverrides map[string]string
+
+	// HostTransports defines transports used for requests to specific hosts.
+	// May be nil.
+	//
+	// Keys are either "host:port" or "host"; the former take precedence.
+	// Key "host" matches only default port of URL scheme, i.e. 80 for
+	// "http" and 443 for "https", and "host:443" matches both
+	// "https://host/" and "https://host:443/".
+	//
+	// Requests to other hosts are sent using transport of Client. Since
+	// every transport has its own connection pool, this also gives each
+	// host a separate pool.
+	//
+	// Like HostOverrides, requires Client to be *http.Client.
+	HostTransports map[string]http.RoundTripper
+
+	// Resolver is used to resolve host names instead of system resolver.
+	// May be nil.
+	//
+	// Resolved addresses are tried in order until connection succeeds.
+	// HostOverrides take precedence over Resolver. Address that actually
+	// served a request is available via Response.ResolvedAddr.
+	//
+	// Like HostOverrides, requires Client to be *http.Client with nil
+	// Transport or *http.Transport, and isn't applied to WebsocketDialer.
+	//
+	// Example:
+	//
+	//	Resolver: httpexpect.ResolverFunc(
+	//		func(ctx context.Context, host string) ([]string, error) {
+	//			return []string{"10.0.0.5"}, nil
+	//		}),
+	Resolver Resolver
+
 	// Context is passed to all requests. It is typically used for request cancellation,
 	// either explicit or after a time-out.
 	// May be nil.
@@ -179,6 +260,17 @@ type Config struct {
 	// relatively big task.
 	Formatter Formatter
 
+	// WarningLogger is used to print formatted warning messages.
+	// May be nil.
+	//
+	// Config.WarningLogger is used by DefaultAssertionHandler, which is
+	// automatically constructed when AssertionHandler is nil.
+	//
+	// Warnings are failures of assertions made via Expect.Warn, Request.Warn,
+	// or Response.Warn. They are printed, but don't cause test failure.
+	// If WarningLogger is nil, warnings are ignored.
+	WarningLogger Logger
+
 	// AssertionHandler handles successful and failed assertions.
 	// May be nil.
 	//
@@ -195,6 +287,10 @@ type Config struct {
 	// of successful assertions and non-fatal failures, you can manually construct
 	// DefaultAssertionHandler and set its Logger field to a non-nil value.
 	//
+	// To observe assertions without replacing default reporting, e.g. to
+	// collect metrics, use MultiAssertionHandler with DefaultAssertionHandler
+	// and your own handler.
+	//
 	// Usually you don't need custom AssertionHandler and it's enough just to
 	// set Reporter. Use AssertionHandler for more precise control of reports.
 	AssertionHandler AssertionHandler
@@ -212,6 +308,201 @@ type Config struct {
 	// with their format, but want to send logs somewhere else than *testing.T.
 	Printers []Printer
 
+	// Redactor hides sensitive data in printer output and failure messages.
+	// May be nil.
+	//
+	// If set, requests and responses are redacted before passing them to
+	// Printers, and request and response dumps in failure messages are
+	// redacted too. See Redactor for details.
+	Redactor *Redactor
+
+	// Telemetry receives instrumentation events for requests, e.g. to
+	// export them as OpenTelemetry spans and metrics.
+	// May be nil.
+	//
+	// If set, it is notified about every request and every retry attempt,
+	// and about assertion failures related to requests. OpenTelemetry
+	// implementation is provided by github.com/gavv/httpexpect/v2/otelexpect.
+	// See Telemetry for details.
+	Telemetry Telemetry
+
+	// RetryBudget limits retries across all requests.
+	// May be nil.
+	//
+	// If set, total number of retries and time spent on retries is limited,
+	// and requests to endpoints that keep failing are stopped by a circuit
+	// breaker. The same RetryBudget can be shared by multiple Expect
+	// instances to apply limits to the whole suite. See RetryBudget for
+	// details.
+	RetryBudget *RetryBudget
+
+	// DefaultResponseAssertions are invoked for every response.
+	// May be nil.
+	//
+	// They are invoked from Request.Expect, after retrieving a new response
+	// and before matchers attached via Expect.Matcher and Request.WithMatcher.
+	// Use them to enforce platform-wide invariants, e.g. that there are no 5xx
+	// responses or that every response has X-Request-ID header.
+	//
+	// Individual requests can opt out using Request.WithoutDefaultAssertions.
+	DefaultResponseAssertions []func(*Response)
+
+	// DefaultHeaders are added to every request.
+	// May be nil.
+	//
+	// Defaults are merged with headers set for individual request, e.g. via
+	// Request.WithHeader, according to DefaultsMergePolicy. Unlike headers
+	// added via Expect.Builder, result doesn't depend on the order of calls.
+	DefaultHeaders http.Header
+
+	// DefaultQuery defines query parameters added to every request.
+	// May be nil.
+	//
+	// Defaults are merged with query parameters set for individual request,
+	// e.g. via Request.WithQuery, according to DefaultsMergePolicy.
+	DefaultQuery url.Values
+
+	// DefaultsMergePolicy defines what happens when request has its own
+	// value for a key from DefaultHeaders or DefaultQuery.
+	// Default is ReplaceDefaults.
+	//
+	// With ReplaceDefaults, request value wins and default value is not sent.
+	// With AppendDefaults, both values are sent.
+	DefaultsMergePolicy MergePolicy
+
+	// RequestIDHeader enables automatic request IDs.
+	// May be empty.
+	//
+	// If non-empty, every request gets a header with this name and a unique
+	// ID, unless the header was already set, e.g. via Request.WithHeader.
+	// This applies to WebSocket handshakes too. The same header is shown in
+	// failure reports and checked by Response.EchoesRequestID, which makes
+	// it easy to find server logs related to a failed assertion.
+	//
+	// Typical value is "X-Request-ID".
+	RequestIDHeader string
+
+	// RequestIDGenerator generates IDs for RequestIDHeader.
+	// May be nil.
+	//
+	// If nil, random version 4 UUIDs are generated. Use it to match the
+	// format expected by your services, e.g. to add a prefix.
+	RequestIDGenerator func() string
+
+	// Clock provides current time and timers.
+	// May be nil.
+	//
+	// It is used by time-based assertions like DateTime.IsWithinLast, and
+	// to wait between retries, polls, and scenario step attempts.
+	//
+	// Durations, like round-trip time, timeouts, and transfer throughput,
+	// are always measured using monotonic wall time (time.Now), so they
+	// remain correct when Clock is fake.
+	//
+	// If nil, set to a clock that uses time.Now and time.After. Use
+	// NewFakeClock to make suites deterministic.
+	Clock Clock
+
+	// JSONEncoder encodes request and WebSocket message bodies.
+	// May be nil.
+	//
+	// It is used by Request.WithJSON, Websocket.WriteJSON, and
+	// Websocket.CloseWithJSON. If nil, encoding/json is used.
+	JSONEncoder JSONEncoder
+
+	// JSONDecoder decodes response and WebSocket message bodies.
+	// May be nil.
+	//
+	// It is used by Response.JSON, Response.JSONP, and WebsocketMessage.JSON.
+	// Use it when encoding/json behavior, e.g. around big numbers or
+	// duplicate keys, doesn't match your backend. If nil, encoding/json
+	// is used.
+	JSONDecoder JSONDecoder
+
+	// Codecs defines decoders used by Response.Decode for media types
+	// other than JSON and XML, e.g. protobuf or Avro.
+	// May be nil.
+	//
+	// If nil, only builtin JSON and XML decoders are used.
+	// See CodecRegistry.
+	Codecs *CodecRegistry
+
+	// StrictJSON enables additional checks of JSON response bodies.
+	// Default is false.
+	//
+	// If true, Response.JSON and Response.JSONP report failure when an
+	// object in the body has duplicate keys, with paths of duplicates.
+	// Standard decoding silently keeps the last value, which may hide
+	// server bugs.
+	//
+	// Use Expect.Warn or Response.Warn to report duplicates as warnings
+	// instead of errors.
+	StrictJSON bool
+
+	// StrictTransfer enables checks of HTTP transfer semantics for every
+	// response. Default is false.
+	//
+	// If true, every response is checked using Response.HasStrictTransfer,
+	// e.g. that 204 response has no body and 201 response has Location
+	// header. Individual requests can override it using
+	// Request.WithStrictTransfer.
+	StrictTransfer bool
+
+	// HeaderCasing defines how names of response headers are treated.
+	// Default is HeaderCasingAsIs.
+	//
+	// Use HeaderCasingCanonical to normalize names, so that tests behave
+	// identically for HTTP/1.1 and HTTP/2 and for handlers invoked directly,
+	// or HeaderCasingStrict to report non-canonical names as failures.
+	HeaderCasing HeaderCasing
+
+	// MaxBodyBytes limits size of response body read by Response.
+	// Default is zero, which means no limit.
+	//
+	// If body is larger, methods like Response.Body and Response.JSON report
+	// failure and the rest of body is not read. Since body is read after
+	// transparent decompression, this also protects from decompression bombs.
+	//
+	// The limit also applies when body is read by Printers and Redactor,
+	// which see an error instead of the part of body after the limit.
+	MaxBodyBytes int64
+
+	// StatusBodyExcerpt defines maximum size, in bytes, of response body
+	// excerpt included into failure message when Response.Status,
+	// Response.StatusRange, or Response.StatusList fails.
+	//
+	// Default is zero, which means 1024 bytes. Negative value disables
+	// excerpt. Only the excerpt is read from the body, so the body can
+	// still be retrieved later, e.g. using Response.Reader. JSON bodies
+	// are pretty-printed if they fit into the limit, and Config.Redactor
+	// is applied to the excerpt.
+	StatusBodyExcerpt int
+
+	// MaxJSONDepth limits nesting depth of objects and arrays in JSON
+	// response bodies. Default is zero, which means no limit.
+	//
+	// If limit is exceeded, Response.JSON and Response.JSONP report failure
+	// without decoding the body.
+	MaxJSONDepth int
+
+	// DecodeTimeout limits time spent by Response.JSON and Response.JSONP
+	// decoding the body. Default is zero, which means no limit.
+	//
+	// If decoding takes longer, failure is reported. Decoding itself is not
+	// interrupted and finishes in background. Timeout is measured in wall
+	// time and is not affected by Clock.
+	DecodeTimeout time.Duration
+
+	// BodySpoolThreshold defines size of response body after which its copy
+	// is stored in a temporary file instead of memory.
+	// Default is zero, which means that body is always kept in memory.
+	//
+	// Response keeps a copy of body to allow reading it multiple times, e.g.
+	// by printers, retries, and assertions. For very large bodies this copy
+	// may be moved to disk. Note that Response.Body, Response.JSON, and other
+	// accessors still load the whole body into memory when called.
+	BodySpoolThreshold int64
+
 	// Environment provides a container for arbitrary data shared between tests.
 	// May be nil.
 	//
@@ -222,6 +513,58 @@ type Config struct {
 	// If Environment is nil, a new empty environment is automatically created
 	// when Expect instance is constructed.
 	Environment *Environment
+
+	// Faker generates random test data.
+	// May be nil.
+	//
+	// Faker is used to expand placeholders like "{{faker.email}}" in request
+	// bodies (see Request.WithJSONTemplate). Tests can also access it via
+	// Expect.Faker().
+	//
+	// If Faker is nil, a new Faker that takes values from RandSource is
+	// automatically created. Use NewFaker with fixed seed, or set RandSource,
+	// to make generated data reproducible.
+	Faker *Faker
+
+	// RandSource provides random numbers for randomized helpers: default
+	// Faker and multipart boundaries. Request IDs are taken from it only
+	// if RequestIDGenerator is set to RandSource.RequestIDGenerator().
+	// May be nil.
+	//
+	// If RandSource is nil, a new RandSource with random seed is automatically
+	// created. If any random value was used, the seed is printed in failure
+	// messages; use NewRandSource with this seed to reproduce the failure.
+	// For automatically created RandSource, the seed is printed only if
+	// values were generated by Faker.
+	RandSource *RandSource
+
+	// Profiles defines named environments, like "dev", "staging", or "prod".
+	// May be nil.
+	//
+	// Profile is selected using Expect.WithProfile. If profile is not found
+	// in this map, it is looked up in environment variables.
+	//
+	// You can use LoadProfiles to read profiles from a JSON file.
+	Profiles map[string]Profile
+
+	// Credentials provides secrets for Request.WithCredentials.
+	// May be nil.
+	//
+	// If Credentials is nil, EnvCredentials is used.
+	Credentials CredentialsProvider
+
+	// Parallel enables runtime checks for concurrent usage.
+	// Default is false.
+	//
+	// Expect, Environment, and assertion chains are always safe for concurrent
+	// use, so the same Expect instance can be shared between parallel tests.
+	// However, a single Request is a builder that should be configured and
+	// sent from one goroutine.
+	//
+	// If Parallel is true, every Request remembers the goroutine that created
+	// it and reports failure when it is modified or sent from another goroutine.
+	// This helps to find races in suites that use t.Parallel().
+	Parallel bool
 }
 
 func (config Config) withDefaults() Config {
@@ -235,10 +578,40 @@ func (config Config) withDefaults() Config {
 		}
 	}
 
+	if len(config.HostOverrides) != 0 || len(config.HostTransports) != 0 ||
+		config.Resolver != nil {
+		client, err := newHostClient(config.Client,
+			config.HostOverrides, config.HostTransports, config.Resolver)
+		if err != nil {
+			panic(err)
+		}
+		config.Client = client
+	}
+
 	if config.WebsocketDialer == nil {
 		config.WebsocketDialer = &websocket.Dialer{}
 	}
 
+	if config.RandSource == nil {
+		config.RandSource = newRandomRandSource()
+	}
+
+	if config.Faker == nil {
+		config.Faker = newSourceFaker(config.RandSource)
+	}
+
+	if config.Clock == nil {
+		config.Clock = systemClock{}
+	}
+
+	if config.JSONEncoder == nil {
+		config.JSONEncoder = defaultJSONEncoder{}
+	}
+
+	if config.JSONDecoder == nil {
+		config.JSONDecoder = defaultJSONDecoder{}
+	}
+
 	if config.AssertionHandler == nil {
 		if config.Formatter == nil {
 			config.Formatter = &DefaultFormatter{}
@@ -249,8 +622,9 @@ func (config Config) withDefaults() Config {
 		}
 
 		config.AssertionHandler = &DefaultAssertionHandler{
-			Formatter: config.Formatter,
-			Reporter:  config.Reporter,
+			Formatter:     config.Formatter,
+			Reporter:      config.Reporter,
+			WarningLogger: config.WarningLogger,
 		}
 	}
 
@@ -270,14 +644,61 @@ func (config *Config) validate() {
 		panic("Config.AssertionHandler is nil")
 	}
 
-	if handler, ok := config.AssertionHandler.(*DefaultAssertionHandler); ok {
-		if handler.Formatter == nil {
+	if err := validateHostOverrides(config.HostOverrides); err != nil {
+		panic(err)
+	}
+
+	if err := validateHostTransports(config.HostTransports); err != nil {
+		panic(err)
+	}
+
+	if config.HeaderCasing < HeaderCasingAsIs || config.HeaderCasing > HeaderCasingStrict {
+		panic("Config.HeaderCasing is invalid")
+	}
+
+	if config.MaxBodyBytes < 0 {
+		panic("Config.MaxBodyBytes is negative")
+	}
+
+	if config.MaxJSONDepth < 0 {
+		panic("Config.MaxJSONDepth is negative")
+	}
+
+	if config.DecodeTimeout < 0 {
+		panic("Config.DecodeTimeout is negative")
+	}
+
+	if config.BodySpoolThreshold < 0 {
+		panic("Config.BodySpoolThreshold is negative")
+	}
+
+	if config.Redactor != nil {
+		if err := config.Redactor.compile(); err != nil {
+			panic(err)
+		}
+	}
+
+	validateAssertionHandler(config.AssertionHandler)
+}
+
+func validateAssertionHandler(handler AssertionHandler) {
+	switch h := handler.(type) {
+	case *DefaultAssertionHandler:
+		if h.Formatter == nil {
 			panic("DefaultAssertionHandler.Formatter is nil")
 		}
 
-		if handler.Reporter == nil {
+		if h.Reporter == nil {
 			panic("DefaultAssertionHandler.Reporter is nil")
 		}
+
+	case MultiAssertionHandler:
+		for _, child := range h {
+			if child == nil {
+				panic("MultiAssertionHandler element is nil")
+			}
+			validateAssertionHandler(child)
+		}
 	}
 }
 
@@ -381,6 +802,37 @@ func (f WebsocketDialerFunc) Dial(
 	return f(url, reqH)
 }
 
+// Resolver is used to resolve host names to IP addresses.
+// net.Resolver implements this interface.
+//
+// Example:
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		Resolver: &net.Resolver{
+//			PreferGo: true,
+//		},
+//	})
+type Resolver interface {
+	// LookupHost returns addresses of given host.
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// ResolverFunc is an adapter that allows a function to be used as the Resolver
+//
+// Example:
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		Resolver: httpextect.ResolverFunc(
+//			func(ctx context.Context, host string) ([]string, error) {
+//				// resolver code here
+//			}),
+//	})
+type ResolverFunc func(ctx context.Context, host string) ([]string, error)
+
+func (f ResolverFunc) LookupHost(ctx context.Context, host string) ([]string, error) {
+	return f(ctx, host)
+}
+
 // Reporter is used to report failures.
 // *testing.T, FatalReporter, AssertReporter, RequireReporter, PanicReporter implement it.
 type Reporter interface {
@@ -467,6 +919,7 @@ func New(t LoggerReporter, baseURL string) *Expect {
 //   - baseURL for Config.BaseURL
 //   - t.Name() for Config.TestName
 //   - NewAssertReporter(t) for Config.Reporter
+//   - t for Config.WarningLogger
 //   - NewCompactPrinter(t) for Config.Printers
 //
 // Example:
@@ -480,9 +933,10 @@ func New(t LoggerReporter, baseURL string) *Expect {
 //	}
 func Default(t TestingTB, baseURL string) *Expect {
 	return WithConfig(Config{
-		TestName: t.Name(),
-		BaseURL:  baseURL,
-		Reporter: NewAssertReporter(t),
+		TestName:      t.Name(),
+		BaseURL:       baseURL,
+		Reporter:      NewAssertReporter(t),
+		WarningLogger: t,
 		Printers: []Printer{
 			NewCompactPrinter(t),
 		},
@@ -521,8 +975,9 @@ func WithConfig(config Config) *Expect {
 	config.validate()
 
 	return &Expect{
-		chain:  newChainWithConfig("", config),
-		config: config,
+		chain:     newChainWithConfig("", config),
+		config:    config,
+		connStats: &connStats{},
 	}
 }
 
@@ -539,12 +994,43 @@ func (e *Expect) Env() *Environment {
 	return e.chain.env()
 }
 
+// Faker returns Faker associated with Expect instance.
+// Tests can use it to generate random test data.
+//
+// Example:
+//
+//	e := httpexpect.Default(t, "http://example.com")
+//
+//	email := e.Faker().Email()
+//
+//	e.POST("/users").WithJSON(map[string]interface{}{"email": email}).
+//		Expect().
+//		Status(http.StatusCreated)
+func (e *Expect) Faker() *Faker {
+	return e.config.Faker
+}
+
 func (e *Expect) clone() *Expect {
 	return &Expect{
 		config:   e.config,
 		chain:    e.chain.clone(),
 		builders: append(([]func(*Request))(nil), e.builders...),
 		matchers: append(([]func(*Response))(nil), e.matchers...),
+
+		connStats: e.connStats,
+	}
+}
+
+// withChain returns a copy of Expect instance that reports to given chain.
+// Builders and matchers are shared with the original instance.
+func (e *Expect) withChain(chain *chain) *Expect {
+	return &Expect{
+		config:   e.config,
+		chain:    chain,
+		builders: e.builders,
+		matchers: e.matchers,
+
+		connStats: e.connStats,
 	}
 }
 
@@ -600,6 +1086,57 @@ func (e *Expect) Matcher(matcher func(*Response)) *Expect {
 	return ret
 }
 
+// Warn returns a copy of Expect instance that reports failures as warnings.
+//
+// Failures of assertions made on requests and responses created by returned
+// instance have SeverityWarning. They are printed using Config.WarningLogger,
+// but don't cause test failure.
+//
+// This is useful for rolling out new contract checks gradually: first enable
+// them as warnings, and when all services pass them, make them errors.
+//
+// Example:
+//
+//	e := httpexpect.Default(t, "http://example.com")
+//
+//	e.Warn().GET("/users").
+//		Expect().
+//		Header("X-Request-ID").NotEmpty()
+func (e *Expect) Warn() *Expect {
+	opChain := e.chain.enter("Warn()")
+	defer opChain.leave()
+
+	ret := e.clone()
+	ret.chain.setSeverity(SeverityWarning)
+
+	return ret
+}
+
+// ConnectionStats returns statistics of connections used by requests sent
+// via this Expect instance and its copies (see Builder, Matcher, Warn).
+//
+// Together with Response.ConnectionReused, it can be used to verify that
+// the service or gateway keeps connections alive and that client pool is
+// configured properly. See ConnectionStats for details.
+//
+// Example:
+//
+//	for i := 0; i < 10; i++ {
+//		e.GET("/ping").Expect().Status(http.StatusOK)
+//	}
+//
+//	stats := e.ConnectionStats()
+//	assert.Equal(t, 1, stats.New)
+//	assert.Equal(t, 9, stats.Reused)
+func (e *Expect) ConnectionStats() ConnectionStats {
+	return e.connStats.get()
+}
+
+// ResetConnectionStats resets statistics returned by ConnectionStats.
+func (e *Expect) ResetConnectionStats() {
+	e.connStats.reset()
+}
+
 // Request returns a new Request instance.
 // Arguments are similar to NewRequest.
 // After creating request, all builders attached to Expect instance are invoked.
@@ -608,7 +1145,15 @@ func (e *Expect) Request(method, path string, pathargs ...interface{}) *Request
 	opChain := e.chain.enter("Request(%q)", method)
 	defer opChain.leave()
 
+	return e.newRequest(opChain, method, path, pathargs...)
+}
+
+func (e *Expect) newRequest(
+	opChain *chain, method, path string, pathargs ...interface{},
+) *Request {
 	req := newRequest(opChain, e.config, method, path, pathargs...)
+	req.connStats = e.connStats
+	req.origin = e
 
 	for _, builder := range e.builders {
 		builder(req)
@@ -2,8 +2,10 @@ package httpexpect
 
 import (
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"sync"
 	"testing"
 
 	"github.com/gorilla/websocket"
@@ -700,6 +702,36 @@ func TestExpect_Config(t *testing.T) {
 			badConfig.AssertionHandler = nil
 			badConfig.validate()
 		})
+
+		assert.Panics(t, func() {
+			badConfig := config
+			badConfig.Redactor = &Redactor{JSONPaths: []string{"bad"}}
+			badConfig.validate()
+		})
+
+		assert.Panics(t, func() {
+			badConfig := config
+			badConfig.MaxBodyBytes = -1
+			badConfig.validate()
+		})
+
+		assert.Panics(t, func() {
+			badConfig := config
+			badConfig.MaxJSONDepth = -1
+			badConfig.validate()
+		})
+
+		assert.Panics(t, func() {
+			badConfig := config
+			badConfig.DecodeTimeout = -1
+			badConfig.validate()
+		})
+
+		assert.Panics(t, func() {
+			badConfig := config
+			badConfig.BodySpoolThreshold = -1
+			badConfig.validate()
+		})
 	})
 
 	t.Run("validate handler", func(t *testing.T) {
@@ -735,6 +767,35 @@ func TestExpect_Config(t *testing.T) {
 			}
 			badConfig.validate()
 		})
+
+		assert.NotPanics(t, func() {
+			badConfig := config
+			badConfig.AssertionHandler = MultiAssertionHandler{
+				&DefaultAssertionHandler{
+					Formatter: &DefaultFormatter{},
+					Reporter:  newMockReporter(t),
+				},
+				&mockAssertionHandler{},
+			}
+			badConfig.validate()
+		})
+
+		assert.Panics(t, func() {
+			badConfig := config
+			badConfig.AssertionHandler = MultiAssertionHandler{
+				&DefaultAssertionHandler{
+					Formatter: &DefaultFormatter{},
+					Reporter:  nil,
+				},
+			}
+			badConfig.validate()
+		})
+
+		assert.Panics(t, func() {
+			badConfig := config
+			badConfig.AssertionHandler = MultiAssertionHandler{nil}
+			badConfig.validate()
+		})
 	})
 }
 
@@ -843,3 +904,102 @@ func TestExpect_Adapters(t *testing.T) {
 		assert.Contains(t, message, "test logger called")
 	})
 }
+
+func TestExpect_Parallel(t *testing.T) {
+	client := ClientFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       http.NoBody,
+		}, nil
+	})
+
+	e := WithConfig(Config{
+		Client:   client,
+		Reporter: NewAssertReporter(t),
+		Parallel: true,
+	})
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			key := fmt.Sprint("key", i)
+
+			e.Env().Put(key, i)
+			e.Env().GetInt(key)
+
+			e.GET("/path").
+				WithHeader("X-Index", key).
+				Expect().
+				Status(http.StatusOK).
+				Alias(key).
+				Body().
+				IsEmpty()
+		}(i)
+	}
+
+	wg.Wait()
+
+	e.chain.assert(t, success)
+	assert.Equal(t, 10, len(e.Env().List()))
+}
+
+func TestExpect_Warn(t *testing.T) {
+	client := ClientFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Header:     http.Header{},
+			Body:       http.NoBody,
+		}, nil
+	})
+
+	reporter := newMockReporter(t)
+	warningLogger := newMockLogger(t)
+
+	e := WithConfig(Config{
+		Client:        client,
+		Reporter:      reporter,
+		WarningLogger: warningLogger,
+	})
+
+	resp := e.Warn().GET("/path").Expect()
+	resp.Status(http.StatusOK)
+
+	assert.False(t, reporter.reported)
+	assert.True(t, warningLogger.logged)
+	assert.Contains(t, warningLogger.lastMessage, "[WARNING]")
+
+	warningLogger.logged = false
+
+	e.GET("/path").Expect().Status(http.StatusOK)
+
+	assert.True(t, reporter.reported)
+	assert.False(t, warningLogger.logged)
+}
+
+func TestExpect_Faker(t *testing.T) {
+	t.Run("custom", func(t *testing.T) {
+		faker := NewFaker(1)
+
+		e := WithConfig(Config{
+			Reporter: newMockReporter(t),
+			Faker:    faker,
+		})
+
+		assert.Same(t, faker, e.Faker())
+	})
+
+	t.Run("default", func(t *testing.T) {
+		e := WithConfig(Config{
+			Reporter: newMockReporter(t),
+		})
+
+		assert.NotNil(t, e.Faker())
+		assert.Same(t, e.Faker(), e.Builder(func(*Request) {}).Faker())
+	})
+}
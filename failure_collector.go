@@ -0,0 +1,233 @@
+package httpexpect
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// FailureGroup describes a group of identical assertion failures collected
+// by FailureCollector.
+//
+// Failures are identical if they have the same assertion type, assertion
+// path, and endpoint (request method and URL path).
+type FailureGroup struct {
+	// Type of failed assertion.
+	Type AssertionType
+
+	// Assertion path, same as AssertionContext.Path.
+	Path []string
+
+	// Request method and URL path, e.g. "GET /users".
+	// Empty if failure is not related to any request.
+	Endpoint string
+
+	// Number of failures in group.
+	Count int
+
+	// Names of tests in which failures occurred, in order of first failure.
+	Tests []string
+
+	// Errors of first failure in group.
+	Errors []error
+}
+
+// FailureCollector is AssertionHandler that groups identical assertion failures
+// and prints a summary.
+//
+// FailureCollector doesn't report failures by itself. It is opt-in and should
+// be combined with another handler (usually DefaultAssertionHandler) using
+// MultiAssertionHandler. The same collector may be shared across many tests,
+// and then its summary can be printed at the end of the run.
+//
+// This helps to triage cases when a single regression breaks hundreds of
+// assertions: instead of reading every failure, one can see that, say, all
+// of them are status checks of the same endpoint.
+//
+// Only failures with SeverityError are collected.
+//
+// FailureCollector is safe for concurrent use.
+//
+// Example:
+//
+//	var collector = httpexpect.NewFailureCollector()
+//
+//	func TestMain(m *testing.M) {
+//		code := m.Run()
+//		collector.Print(os.Stdout)
+//		os.Exit(code)
+//	}
+//
+//	func TestUsers(t *testing.T) {
+//		e := httpexpect.WithConfig(httpexpect.Config{
+//			BaseURL: "http://example.com",
+//			AssertionHandler: httpexpect.MultiAssertionHandler{
+//				&httpexpect.DefaultAssertionHandler{
+//					Formatter: &httpexpect.DefaultFormatter{},
+//					Reporter:  httpexpect.NewAssertReporter(t),
+//				},
+//				collector,
+//			},
+//		})
+//
+//		e.GET("/users").Expect().Status(http.StatusOK)
+//	}
+type FailureCollector struct {
+	mu     sync.Mutex
+	groups map[string]*FailureGroup
+	order  []string
+}
+
+// NewFailureCollector returns a new empty FailureCollector.
+func NewFailureCollector() *FailureCollector {
+	return &FailureCollector{
+		groups: map[string]*FailureGroup{},
+	}
+}
+
+// Success implements AssertionHandler.Success.
+// Successful assertions are ignored.
+func (fc *FailureCollector) Success(ctx *AssertionContext) {
+}
+
+// Failure implements AssertionHandler.Failure.
+func (fc *FailureCollector) Failure(
+	ctx *AssertionContext, failure *AssertionFailure,
+) {
+	if failure.Severity != SeverityError {
+		return
+	}
+
+	endpoint := ""
+	if httpReq := contextHTTPRequest(ctx); httpReq != nil {
+		endpoint = httpReq.Method
+		if httpReq.URL != nil {
+			endpoint += " " + httpReq.URL.Path
+		}
+	}
+
+	key := fmt.Sprintf("%s\x00%s\x00%s",
+		failure.Type, strings.Join(ctx.Path, "\x01"), endpoint)
+
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	group := fc.groups[key]
+	if group == nil {
+		group = &FailureGroup{
+			Type:     failure.Type,
+			Path:     append([]string(nil), ctx.Path...),
+			Endpoint: endpoint,
+			Errors:   append([]error(nil), failure.Errors...),
+		}
+		fc.groups[key] = group
+		fc.order = append(fc.order, key)
+	}
+
+	group.Count++
+
+	if ctx.TestName != "" && !stringInSlice(ctx.TestName, group.Tests) {
+		group.Tests = append(group.Tests, ctx.TestName)
+	}
+}
+
+// Groups returns collected failure groups.
+//
+// Groups are sorted by number of failures, from largest to smallest;
+// groups with equal number of failures are ordered by first occurrence.
+func (fc *FailureCollector) Groups() []FailureGroup {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	groups := make([]FailureGroup, 0, len(fc.order))
+
+	for _, key := range fc.order {
+		group := *fc.groups[key]
+		group.Path = append([]string(nil), group.Path...)
+		group.Tests = append([]string(nil), group.Tests...)
+		group.Errors = append([]error(nil), group.Errors...)
+		groups = append(groups, group)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Count > groups[j].Count
+	})
+
+	return groups
+}
+
+// Reset removes all collected failures.
+func (fc *FailureCollector) Reset() {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	fc.groups = map[string]*FailureGroup{}
+	fc.order = nil
+}
+
+// Summary returns human-readable summary of collected failures.
+// If there are no failures, returns empty string.
+func (fc *FailureCollector) Summary() string {
+	groups := fc.Groups()
+	if len(groups) == 0 {
+		return ""
+	}
+
+	total := 0
+	for _, group := range groups {
+		total += group.Count
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%d %s in %d %s\n",
+		total, pluralize(total, "assertion failure", "assertion failures"),
+		len(groups), pluralize(len(groups), "group", "groups"))
+
+	for _, group := range groups {
+		fmt.Fprintf(&b, "\n%d %s in %d %s:\n",
+			group.Count, pluralize(group.Count, "failure", "failures"),
+			len(group.Tests), pluralize(len(group.Tests), "test", "tests"))
+
+		fmt.Fprintf(&b, "%stype: %s\n", defaultIndent, group.Type)
+
+		if group.Endpoint != "" {
+			fmt.Fprintf(&b, "%sendpoint: %s\n", defaultIndent, group.Endpoint)
+		}
+
+		if len(group.Path) != 0 {
+			fmt.Fprintf(&b, "%sassertion: %s\n",
+				defaultIndent, strings.Join(group.Path, "."))
+		}
+
+		if len(group.Errors) != 0 {
+			fmt.Fprintf(&b, "%serror: %s\n", defaultIndent, group.Errors[0])
+		}
+
+		if len(group.Tests) != 0 {
+			fmt.Fprintf(&b, "%stests: %s\n",
+				defaultIndent, strings.Join(group.Tests, ", "))
+		}
+	}
+
+	return b.String()
+}
+
+// Print writes summary of collected failures to w.
+// If there are no failures, nothing is written.
+func (fc *FailureCollector) Print(w io.Writer) {
+	if summary := fc.Summary(); summary != "" {
+		_, _ = io.WriteString(w, summary)
+	}
+}
+
+func stringInSlice(s string, list []string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,184 @@
+package httpexpect
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailureCollector_Groups(t *testing.T) {
+	collector := NewFailureCollector()
+
+	newContext := func(testName, method, url string, path ...string) *AssertionContext {
+		ctx := &AssertionContext{
+			TestName: testName,
+			Path:     path,
+		}
+		if method != "" {
+			httpReq, err := http.NewRequest(method, url, nil)
+			require.NoError(t, err)
+			ctx.Request = &Request{httpReq: httpReq}
+		}
+		return ctx
+	}
+
+	statusFailure := &AssertionFailure{
+		Type:     AssertEqual,
+		Severity: SeverityError,
+		Errors:   []error{errors.New("expected: http status is equal to 200")},
+	}
+
+	// same group: query and host don't matter
+	collector.Failure(
+		newContext("Test1", "GET", "http://a.com/users?page=1", "Expect()", "Status()"),
+		statusFailure)
+	collector.Failure(
+		newContext("Test2", "GET", "http://b.com/users?page=2", "Expect()", "Status()"),
+		statusFailure)
+	collector.Failure(
+		newContext("Test2", "GET", "http://b.com/users", "Expect()", "Status()"),
+		statusFailure)
+
+	// different endpoint
+	collector.Failure(
+		newContext("Test1", "POST", "http://a.com/users", "Expect()", "Status()"),
+		statusFailure)
+
+	// different type
+	collector.Failure(
+		newContext("Test3", "GET", "http://a.com/users", "Expect()", "Status()"),
+		&AssertionFailure{
+			Type:     AssertNotEqual,
+			Severity: SeverityError,
+		})
+
+	// no request
+	collector.Failure(
+		newContext("Test3", "", "", "Value()", "IsEqual()"),
+		statusFailure)
+	collector.Failure(
+		newContext("Test3", "", "", "Value()", "IsEqual()"),
+		statusFailure)
+
+	// ignored
+	collector.Success(newContext("Test1", "GET", "http://a.com/users"))
+	collector.Failure(
+		newContext("Test1", "GET", "http://a.com/users", "Expect()", "Status()"),
+		&AssertionFailure{
+			Type:     AssertEqual,
+			Severity: SeverityWarning,
+		})
+
+	groups := collector.Groups()
+	require.Equal(t, 4, len(groups))
+
+	assert.Equal(t, FailureGroup{
+		Type:     AssertEqual,
+		Path:     []string{"Expect()", "Status()"},
+		Endpoint: "GET /users",
+		Count:    3,
+		Tests:    []string{"Test1", "Test2"},
+		Errors:   statusFailure.Errors,
+	}, groups[0])
+
+	assert.Equal(t, AssertEqual, groups[1].Type)
+	assert.Equal(t, "", groups[1].Endpoint)
+	assert.Equal(t, 2, groups[1].Count)
+	assert.Equal(t, []string{"Test3"}, groups[1].Tests)
+
+	assert.Equal(t, "POST /users", groups[2].Endpoint)
+	assert.Equal(t, 1, groups[2].Count)
+
+	assert.Equal(t, AssertNotEqual, groups[3].Type)
+	assert.Equal(t, "GET /users", groups[3].Endpoint)
+	assert.Equal(t, 1, groups[3].Count)
+
+	collector.Reset()
+	assert.Empty(t, collector.Groups())
+}
+
+func TestFailureCollector_Summary(t *testing.T) {
+	collector := NewFailureCollector()
+
+	assert.Equal(t, "", collector.Summary())
+
+	var buf bytes.Buffer
+	collector.Print(&buf)
+	assert.Equal(t, "", buf.String())
+
+	client := ClientFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusInternalServerError,
+			Body:       http.NoBody,
+			Request:    req,
+		}, nil
+	})
+
+	for _, testName := range []string{"TestA", "TestB"} {
+		e := WithConfig(Config{
+			TestName: testName,
+			BaseURL:  "http://example.com",
+			Client:   client,
+			AssertionHandler: MultiAssertionHandler{
+				&DefaultAssertionHandler{
+					Formatter: &DefaultFormatter{},
+					Reporter:  newMockReporter(t),
+				},
+				collector,
+			},
+		})
+
+		e.GET("/users").Expect().Status(http.StatusOK)
+		e.GET("/users").Expect().Status(http.StatusOK)
+	}
+
+	assert.Equal(t,
+		"4 assertion failures in 1 group\n"+
+			"\n"+
+			"4 failures in 2 tests:\n"+
+			"  type: AssertEqual\n"+
+			"  endpoint: GET /users\n"+
+			"  assertion: Request(\"GET\").Expect().Status()\n"+
+			"  error: unexpected http status value\n"+
+			"  tests: TestA, TestB\n",
+		collector.Summary())
+
+	collector.Print(&buf)
+	assert.Equal(t, collector.Summary(), buf.String())
+}
+
+func TestFailureCollector_Concurrency(t *testing.T) {
+	collector := NewFailureCollector()
+
+	failure := &AssertionFailure{
+		Type:     AssertValid,
+		Severity: SeverityError,
+	}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				collector.Failure(&AssertionContext{
+					TestName: "Test",
+					Path:     []string{"Value()"},
+				}, failure)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	groups := collector.Groups()
+	require.Equal(t, 1, len(groups))
+	assert.Equal(t, 1000, groups[0].Count)
+	assert.Equal(t, []string{"Test"}, groups[0].Tests)
+}
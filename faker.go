@@ -0,0 +1,262 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Faker generates random test data, like names, emails, and UUIDs.
+//
+// Faker is deterministic: two instances created with the same seed produce
+// the same sequence of values. This allows to reproduce failures of tests
+// that use random data.
+//
+// Faker is safe for concurrent use.
+//
+// Faker is also used to expand placeholders like "{{faker.email}}" in
+// request bodies, see Request.WithJSONTemplate.
+type Faker struct {
+	mu   sync.Mutex
+	seed int64
+	rand *rand.Rand
+
+	// If set, used instead of rand.
+	source *RandSource
+}
+
+// NewFaker returns a new Faker with given seed.
+//
+// Example:
+//
+//	faker := NewFaker(42)
+//	email := faker.Email()
+func NewFaker(seed int64) *Faker {
+	return &Faker{
+		seed: seed,
+		rand: rand.New(rand.NewSource(seed)), //nolint:gosec
+	}
+}
+
+// newSourceFaker returns Faker that takes values from given RandSource.
+func newSourceFaker(source *RandSource) *Faker {
+	return &Faker{
+		seed:   source.Seed(),
+		source: source,
+	}
+}
+
+var (
+	fakerFirstNames = []string{
+		"Alice", "Bob", "Carol", "Dave", "Eve", "Frank", "Grace", "Heidi",
+		"Ivan", "Judy", "Mallory", "Niaj", "Olivia", "Peggy", "Rupert",
+		"Sybil", "Trent", "Uma", "Victor", "Walter",
+	}
+	fakerLastNames = []string{
+		"Anderson", "Brown", "Clark", "Davis", "Evans", "Garcia", "Harris",
+		"Jackson", "Johnson", "King", "Lewis", "Martin", "Miller", "Moore",
+		"Robinson", "Smith", "Taylor", "Thomas", "Walker", "Wilson",
+	}
+	fakerWords = []string{
+		"alpha", "bravo", "charlie", "delta", "echo", "foxtrot", "golf",
+		"hotel", "india", "juliet", "kilo", "lima", "mike", "november",
+		"oscar", "papa", "quebec", "romeo", "sierra", "tango",
+	}
+	fakerDomains = []string{
+		"example.com", "example.net", "example.org",
+	}
+)
+
+// Seed returns seed used to create Faker.
+// Log it to be able to reproduce a failed test.
+//
+// For default Faker created by Expect, it's the seed of Config.RandSource.
+func (f *Faker) Seed() int64 {
+	return f.seed
+}
+
+// Int returns random integer in range [min; max].
+//
+// If max is less than min, the function panics.
+func (f *Faker) Int(min, max int) int {
+	if max < min {
+		panic("max is less than min")
+	}
+
+	return min + f.intn(max-min+1)
+}
+
+// FirstName returns random first name, e.g. "Alice".
+func (f *Faker) FirstName() string {
+	return f.pick(fakerFirstNames)
+}
+
+// LastName returns random last name, e.g. "Smith".
+func (f *Faker) LastName() string {
+	return f.pick(fakerLastNames)
+}
+
+// Name returns random full name, e.g. "Alice Smith".
+func (f *Faker) Name() string {
+	return f.FirstName() + " " + f.LastName()
+}
+
+// Word returns random lower-case word, e.g. "delta".
+func (f *Faker) Word() string {
+	return f.pick(fakerWords)
+}
+
+// Username returns random user name, e.g. "alice.smith42".
+func (f *Faker) Username() string {
+	return fmt.Sprintf("%s.%s%d",
+		strings.ToLower(f.FirstName()), strings.ToLower(f.LastName()), f.Int(0, 999))
+}
+
+// Email returns random email address, e.g. "alice.smith42@example.com".
+func (f *Faker) Email() string {
+	return f.Username() + "@" + f.pick(fakerDomains)
+}
+
+// UUID returns random version 4 UUID, e.g. "1b4e28ba-2fa1-41d2-883f-0016d3cca427".
+func (f *Faker) UUID() string {
+	var b [16]byte
+	f.read(b[:])
+
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // variant 10
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+func (f *Faker) pick(list []string) string {
+	return list[f.intn(len(list))]
+}
+
+func (f *Faker) intn(n int) int {
+	if f.source != nil {
+		return f.source.intn(n)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.rand.Intn(n)
+}
+
+func (f *Faker) read(b []byte) {
+	if f.source != nil {
+		f.source.read(b)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, _ = f.rand.Read(b)
+}
+
+// Generate value for placeholder kind, e.g. "email" for "{{faker.email}}".
+func (f *Faker) generate(kind string) (interface{}, bool) {
+	switch kind {
+	case "first_name":
+		return f.FirstName(), true
+	case "last_name":
+		return f.LastName(), true
+	case "name":
+		return f.Name(), true
+	case "word":
+		return f.Word(), true
+	case "username":
+		return f.Username(), true
+	case "email":
+		return f.Email(), true
+	case "uuid":
+		return f.UUID(), true
+	case "int":
+		return f.Int(0, 1000000), true
+	}
+	return nil, false
+}
+
+var fakerPlaceholderRe = regexp.MustCompile(`\{\{\s*faker\.([a-z_]+)\s*\}\}`)
+
+// Replace placeholders like "{{faker.email}}" in JSON document.
+// The same placeholder is replaced with the same value within a document.
+// Returns generated values, keyed by placeholder name, e.g. "faker.email".
+//
+// If placeholder is the whole JSON string, the string is replaced with
+// JSON value, so that "{{faker.int}}" becomes a number. Otherwise,
+// value is formatted into the string.
+func (f *Faker) expandJSON(data []byte) ([]byte, map[string]interface{}, error) {
+	matches := fakerPlaceholderRe.FindAllSubmatchIndex(data, -1)
+	if len(matches) == 0 {
+		return data, nil, nil
+	}
+
+	values := map[string]interface{}{}
+
+	var (
+		result []byte
+		last   int
+	)
+
+	for _, m := range matches {
+		start, end := m[0], m[1]
+
+		kind := string(data[m[2]:m[3]])
+		key := "faker." + kind
+
+		value, ok := values[key]
+		if !ok {
+			value, ok = f.generate(kind)
+			if !ok {
+				return nil, nil,
+					fmt.Errorf("unknown faker placeholder %q", string(data[start:end]))
+			}
+			values[key] = value
+		}
+
+		var b []byte
+		if isWholeJSONString(data, start, end) {
+			b, _ = json.Marshal(value)
+			start--
+			end++
+		} else {
+			// placeholder is inside JSON string, so we escape value
+			// and strip quotes added by json.Marshal
+			b, _ = json.Marshal(fmt.Sprint(value))
+			b = b[1 : len(b)-1]
+		}
+
+		result = append(result, data[last:start]...)
+		result = append(result, b...)
+		last = end
+	}
+
+	result = append(result, data[last:]...)
+
+	return result, values, nil
+}
+
+// Check if data[start:end] is surrounded by quotes of JSON string,
+// i.e. is the whole string.
+func isWholeJSONString(data []byte, start, end int) bool {
+	if start == 0 || end == len(data) {
+		return false
+	}
+
+	if data[start-1] != '"' || data[end] != '"' {
+		return false
+	}
+
+	// opening quote should not be escaped
+	backslashes := 0
+	for i := start - 2; i >= 0 && data[i] == '\\'; i-- {
+		backslashes++
+	}
+
+	return backslashes%2 == 0
+}
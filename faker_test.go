@@ -0,0 +1,154 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFaker_Determinism(t *testing.T) {
+	generate := func(f *Faker) []string {
+		return []string{
+			f.FirstName(),
+			f.LastName(),
+			f.Name(),
+			f.Word(),
+			f.Username(),
+			f.Email(),
+			f.UUID(),
+		}
+	}
+
+	f1 := NewFaker(123)
+	f2 := NewFaker(123)
+	f3 := NewFaker(456)
+
+	assert.Equal(t, int64(123), f1.Seed())
+
+	v1 := generate(f1)
+	v2 := generate(f2)
+	v3 := generate(f3)
+
+	assert.Equal(t, v1, v2)
+	assert.NotEqual(t, v1, v3)
+}
+
+func TestFaker_Values(t *testing.T) {
+	f := NewFaker(1)
+
+	t.Run("Int", func(t *testing.T) {
+		for i := 0; i < 100; i++ {
+			n := f.Int(-5, 5)
+			assert.GreaterOrEqual(t, n, -5)
+			assert.LessOrEqual(t, n, 5)
+		}
+
+		assert.Equal(t, 7, f.Int(7, 7))
+
+		assert.Panics(t, func() {
+			f.Int(2, 1)
+		})
+	})
+
+	t.Run("Name", func(t *testing.T) {
+		assert.Len(t, strings.Split(f.Name(), " "), 2)
+	})
+
+	t.Run("Email", func(t *testing.T) {
+		assert.Regexp(t, `^[a-z]+\.[a-z]+\d+@example\.(com|net|org)$`, f.Email())
+	})
+
+	t.Run("UUID", func(t *testing.T) {
+		re := regexp.MustCompile(
+			`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+		for i := 0; i < 100; i++ {
+			assert.Regexp(t, re, f.UUID())
+		}
+	})
+}
+
+func TestFaker_Parallel(t *testing.T) {
+	f := NewFaker(1)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 100; j++ {
+				_ = f.Email()
+				_ = f.UUID()
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestFaker_ExpandJSON(t *testing.T) {
+	t.Run("no placeholders", func(t *testing.T) {
+		f := NewFaker(1)
+
+		data := []byte(`{"foo":"bar"}`)
+
+		result, values, err := f.expandJSON(data)
+		require.NoError(t, err)
+
+		assert.Equal(t, data, result)
+		assert.Nil(t, values)
+	})
+
+	t.Run("placeholders", func(t *testing.T) {
+		f := NewFaker(1)
+
+		data := []byte(
+			`{"a":"{{faker.email}}","b":"{{ faker.email }}","c":"id-{{faker.uuid}}"}`)
+
+		result, values, err := f.expandJSON(data)
+		require.NoError(t, err)
+
+		var object map[string]string
+		require.NoError(t, json.Unmarshal(result, &object))
+
+		assert.Equal(t, values["faker.email"], object["a"])
+		assert.Equal(t, values["faker.email"], object["b"])
+		assert.Equal(t, "id-"+values["faker.uuid"].(string), object["c"])
+		assert.Len(t, values, 2)
+	})
+
+	t.Run("numbers", func(t *testing.T) {
+		f := NewFaker(1)
+
+		data := []byte(`{"a":"{{faker.int}}","b":"n{{faker.int}}","c":"\"{{faker.int}}\""}`)
+
+		result, values, err := f.expandJSON(data)
+		require.NoError(t, err)
+
+		n, ok := values["faker.int"].(int)
+		require.True(t, ok)
+
+		var object map[string]interface{}
+		require.NoError(t, json.Unmarshal(result, &object))
+
+		assert.Equal(t, float64(n), object["a"])
+		assert.Equal(t, fmt.Sprintf("n%d", n), object["b"])
+		assert.Equal(t, fmt.Sprintf("\"%d\"", n), object["c"])
+	})
+
+	t.Run("unknown placeholder", func(t *testing.T) {
+		f := NewFaker(1)
+
+		_, _, err := f.expandJSON([]byte(`{"a":"{{faker.unknown}}"}`))
+		assert.Error(t, err)
+	})
+}
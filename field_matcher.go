@@ -0,0 +1,95 @@
+package httpexpect
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// FieldMatcher checks a single object field in Object.Fields.
+//
+// MatchField receives field key, field value in canonical form, and flag
+// telling whether field is present in object. It should report failure
+// using opChain if the field doesn't match.
+//
+// Builtin matchers are Nullable and Absent.
+type FieldMatcher interface {
+	MatchField(opChain *Chain, key string, value interface{}, present bool)
+}
+
+// Nullable returns FieldMatcher that succeeds if field is present and is
+// either null or can be converted to Go type T (see ValueOf).
+//
+// Example:
+//
+//	object.Fields(map[string]interface{}{
+//		"deleted_at": Nullable[string](),
+//		"parent_id":  Nullable[int](),
+//	})
+func Nullable[T any]() FieldMatcher {
+	return nullableMatcher[T]{}
+}
+
+type nullableMatcher[T any] struct{}
+
+func (nullableMatcher[T]) MatchField(
+	opChain *Chain, key string, value interface{}, present bool,
+) {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+
+	if !present {
+		opChain.Fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				fmt.Errorf("expected: field %q is present and is null or %s",
+					key, typ),
+				fmt.Errorf("field %q is absent", key),
+			},
+		})
+		return
+	}
+
+	if value == nil {
+		return
+	}
+
+	if _, ok := convertValue[T](value); !ok {
+		opChain.Fail(AssertionFailure{
+			Type:   AssertType,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				fmt.Errorf("expected: field %q is null or %s", key, typ),
+				fmt.Errorf("actual value is JSON %s", jsonTypeName(value)),
+			},
+		})
+	}
+}
+
+// Absent returns FieldMatcher that succeeds if field is missing.
+//
+// Note that a field which is present and null is not absent.
+//
+// Example:
+//
+//	object.Fields(map[string]interface{}{
+//		"password": Absent(),
+//	})
+func Absent() FieldMatcher {
+	return absentMatcher{}
+}
+
+type absentMatcher struct{}
+
+func (absentMatcher) MatchField(
+	opChain *Chain, key string, value interface{}, present bool,
+) {
+	if present {
+		opChain.Fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				fmt.Errorf("expected: field %q is absent", key),
+			},
+		})
+	}
+}
@@ -0,0 +1,286 @@
+package httpexpect
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Parsed fixture templates, keyed by SHA-256 of file content, so that
+// the same fixture is parsed only once even if it's loaded by many tests.
+var fixtureTemplates sync.Map
+
+// WithBodyFromFile loads fixture from file, renders it as Go template with
+// given variables, and sets result as request body.
+//
+// Content-Type header is set automatically based on file extension:
+//   - ".json" fixtures are validated and sent as "application/json"
+//   - ".yaml" and ".yml" fixtures are converted to JSON and sent
+//     as "application/json"
+//   - other fixtures are sent as is, with type detected from extension,
+//     or "application/octet-stream" if it's unknown
+//
+// Template is executed with "missingkey=error" option, so referencing
+// undefined variable from a map causes failure.
+//
+// Example:
+//
+//	// testdata/user.json:
+//	// {"name": "{{.Name}}", "email": "{{.Email}}"}
+//
+//	req := NewRequestC(config, "POST", "/users")
+//	req.WithBodyFromFile("testdata/user.json", map[string]interface{}{
+//		"Name":  "john",
+//		"Email": "john@example.com",
+//	})
+func (r *Request) WithBodyFromFile(path string, vars ...interface{}) *Request {
+	opChain := r.chain.enter("WithBodyFromFile(%q)", path)
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithBodyFromFile()") {
+		return r
+	}
+
+	if len(vars) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple vars arguments"),
+			},
+		})
+		return r
+	}
+
+	content, ok := loadFixture(opChain, path, vars...)
+	if !ok {
+		return r
+	}
+
+	var contentType string
+
+	switch fixtureFormat(path) {
+	case "json":
+		if !json.Valid(content) {
+			opChain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{string(content)},
+				Errors: []error{
+					fmt.Errorf("invalid json in fixture %q", path),
+				},
+			})
+			return r
+		}
+		contentType = "application/json; charset=utf-8"
+
+	case "yaml":
+		value, ok := decodeFixture(opChain, path, content)
+		if !ok {
+			return r
+		}
+
+		b, err := r.config.JSONEncoder.Marshal(value)
+		if err != nil {
+			opChain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{value},
+				Errors: []error{
+					fmt.Errorf("can't convert fixture %q to json", path),
+					err,
+				},
+			})
+			return r
+		}
+		content = b
+		contentType = "application/json; charset=utf-8"
+
+	default:
+		contentType = mime.TypeByExtension(filepath.Ext(path))
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+	}
+
+	r.setType(opChain, "WithBodyFromFile()", contentType, false)
+	r.setBody(opChain, "WithBodyFromFile()", bytes.NewReader(content), len(content), false)
+
+	return r
+}
+
+// ExpectedFromFile loads JSON or YAML fixture from file, renders it as
+// Go template with given variables, and returns decoded value.
+//
+// Returned value can be passed to IsEqual, ContainsSubset and other
+// assertions. If fixture can't be loaded, failure is reported and nil
+// is returned.
+//
+// Example:
+//
+//	// testdata/user.yaml:
+//	// name: "{{.Name}}"
+//	// admin: false
+//
+//	e.GET("/users/{id}", id).
+//		Expect().
+//		JSON().Object().
+//		ContainsSubset(e.ExpectedFromFile("testdata/user.yaml",
+//			map[string]interface{}{"Name": "john"}))
+func (e *Expect) ExpectedFromFile(path string, vars ...interface{}) interface{} {
+	opChain := e.chain.enter("ExpectedFromFile(%q)", path)
+	defer opChain.leave()
+
+	if len(vars) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple vars arguments"),
+			},
+		})
+		return nil
+	}
+
+	if format := fixtureFormat(path); format != "json" && format != "yaml" {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unsupported fixture format %q, expected json or yaml",
+					filepath.Ext(path)),
+			},
+		})
+		return nil
+	}
+
+	content, ok := loadFixture(opChain, path, vars...)
+	if !ok {
+		return nil
+	}
+
+	value, ok := decodeFixture(opChain, path, content)
+	if !ok {
+		return nil
+	}
+
+	return value
+}
+
+func fixtureFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return "json"
+	case ".yaml", ".yml":
+		return "yaml"
+	default:
+		return ""
+	}
+}
+
+func loadFixture(opChain *chain, path string, vars ...interface{}) ([]byte, bool) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				fmt.Errorf("failed to read fixture %q", path),
+				err,
+			},
+		})
+		return nil, false
+	}
+
+	if len(vars) == 0 || vars[0] == nil {
+		return content, true
+	}
+
+	key := sha256.Sum256(content)
+
+	var tmpl *template.Template
+
+	if cached, ok := fixtureTemplates.Load(key); ok {
+		tmpl = cached.(*template.Template)
+	} else {
+		tmpl, err = template.New(filepath.Base(path)).
+			Option("missingkey=error").
+			Parse(string(content))
+		if err != nil {
+			opChain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{string(content)},
+				Errors: []error{
+					fmt.Errorf("invalid template in fixture %q", path),
+					err,
+				},
+			})
+			return nil, false
+		}
+		fixtureTemplates.Store(key, tmpl)
+	}
+
+	var buf bytes.Buffer
+
+	if err := tmpl.Execute(&buf, vars[0]); err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{vars[0]},
+			Errors: []error{
+				fmt.Errorf("failed to render fixture %q", path),
+				err,
+			},
+		})
+		return nil, false
+	}
+
+	return buf.Bytes(), true
+}
+
+// decodeFixture decodes JSON or YAML fixture into canonical form,
+// i.e. the same form as produced by decoding JSON.
+func decodeFixture(opChain *chain, path string, content []byte) (interface{}, bool) {
+	var (
+		value interface{}
+		err   error
+	)
+
+	switch fixtureFormat(path) {
+	case "json":
+		err = json.Unmarshal(content, &value)
+
+	case "yaml":
+		var raw interface{}
+		if err = yaml.Unmarshal(content, &raw); err == nil {
+			var b []byte
+			if b, err = json.Marshal(raw); err == nil {
+				err = json.Unmarshal(b, &value)
+			}
+		}
+	}
+
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{string(content)},
+			Errors: []error{
+				fmt.Errorf("failed to decode fixture %q", path),
+				err,
+			},
+		})
+		return nil, false
+	}
+
+	return value, true
+}
@@ -0,0 +1,263 @@
+package httpexpect
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFixture(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestFixture_BodyFromFile(t *testing.T) {
+	cases := []struct {
+		name     string
+		file     string
+		content  string
+		vars     []interface{}
+		result   chainResult
+		wantType string
+		wantBody string
+	}{
+		{
+			name:     "json",
+			file:     "user.json",
+			content:  `{"name": "john"}`,
+			result:   success,
+			wantType: "application/json; charset=utf-8",
+			wantBody: `{"name": "john"}`,
+		},
+		{
+			name:    "json template",
+			file:    "user.json",
+			content: `{"name": "{{.Name}}", "age": {{.Age}}}`,
+			vars: []interface{}{
+				map[string]interface{}{"Name": "john", "Age": 30},
+			},
+			result:   success,
+			wantType: "application/json; charset=utf-8",
+			wantBody: `{"name": "john", "age": 30}`,
+		},
+		{
+			name:    "json template struct",
+			file:    "user.json",
+			content: `{"name": "{{.Name}}"}`,
+			vars: []interface{}{
+				struct{ Name string }{"jane"},
+			},
+			result:   success,
+			wantType: "application/json; charset=utf-8",
+			wantBody: `{"name": "jane"}`,
+		},
+		{
+			name:     "yaml",
+			file:     "user.yaml",
+			content:  "name: john\ntags:\n  - a\n  - b\n",
+			result:   success,
+			wantType: "application/json; charset=utf-8",
+			wantBody: `{"name":"john","tags":["a","b"]}`,
+		},
+		{
+			name:    "yml template",
+			file:    "user.yml",
+			content: "name: {{.Name}}\n",
+			vars: []interface{}{
+				map[string]interface{}{"Name": "john"},
+			},
+			result:   success,
+			wantType: "application/json; charset=utf-8",
+			wantBody: `{"name":"john"}`,
+		},
+		{
+			name:     "text",
+			file:     "hello.txt",
+			content:  "hello",
+			result:   success,
+			wantType: "text/plain; charset=utf-8",
+			wantBody: "hello",
+		},
+		{
+			name:     "unknown extension",
+			file:     "data.unknownext",
+			content:  "raw",
+			result:   success,
+			wantType: "application/octet-stream",
+			wantBody: "raw",
+		},
+		{
+			name:    "invalid json",
+			file:    "user.json",
+			content: `{"name": `,
+			result:  failure,
+		},
+		{
+			name:    "invalid yaml",
+			file:    "user.yaml",
+			content: "name: [",
+			result:  failure,
+		},
+		{
+			name:    "invalid template",
+			file:    "user.json",
+			content: `{"name": "{{.Name"}`,
+			vars: []interface{}{
+				map[string]interface{}{"Name": "john"},
+			},
+			result: failure,
+		},
+		{
+			name:    "missing variable",
+			file:    "user.json",
+			content: `{"name": "{{.Name}}"}`,
+			vars: []interface{}{
+				map[string]interface{}{"Other": "john"},
+			},
+			result: failure,
+		},
+		{
+			name:    "multiple vars",
+			file:    "user.json",
+			content: `{}`,
+			vars:    []interface{}{nil, nil},
+			result:  failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := writeFixture(t, tc.file, tc.content)
+
+			client := &mockClient{}
+
+			config := Config{
+				Client:   client,
+				Reporter: newMockReporter(t),
+			}
+
+			req := NewRequestC(config, http.MethodPost, "/")
+			req.WithBodyFromFile(path, tc.vars...)
+			req.Expect()
+			req.chain.assert(t, tc.result)
+
+			if tc.result {
+				assert.Equal(t, tc.wantType, client.req.Header.Get("Content-Type"))
+
+				body, err := io.ReadAll(client.req.Body)
+				require.NoError(t, err)
+				assert.Equal(t, tc.wantBody, string(body))
+			}
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		config := Config{
+			Client:   &mockClient{},
+			Reporter: newMockReporter(t),
+		}
+
+		req := NewRequestC(config, http.MethodPost, "/")
+		req.WithBodyFromFile(filepath.Join(t.TempDir(), "missing.json"))
+		req.chain.assert(t, failure)
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		path := writeFixture(t, "user.json", `{}`)
+
+		config := Config{
+			Client:   &mockClient{},
+			Reporter: newMockReporter(t),
+		}
+
+		req := NewRequestC(config, http.MethodPost, "/")
+		req.WithText("hello")
+		req.WithBodyFromFile(path)
+		req.Validate()
+		req.chain.assert(t, failure)
+	})
+}
+
+func TestFixture_ExpectedFromFile(t *testing.T) {
+	cases := []struct {
+		name    string
+		file    string
+		content string
+		vars    []interface{}
+		result  chainResult
+		want    interface{}
+	}{
+		{
+			name:    "json",
+			file:    "user.json",
+			content: `{"name": "john", "age": 30}`,
+			result:  success,
+			want:    map[string]interface{}{"name": "john", "age": 30.0},
+		},
+		{
+			name:    "yaml template",
+			file:    "user.yaml",
+			content: "name: {{.Name}}\nage: 30\nadmin: false\n",
+			vars: []interface{}{
+				map[string]interface{}{"Name": "john"},
+			},
+			result: success,
+			want: map[string]interface{}{
+				"name": "john", "age": 30.0, "admin": false,
+			},
+		},
+		{
+			name:    "unsupported format",
+			file:    "user.txt",
+			content: "john",
+			result:  failure,
+		},
+		{
+			name:    "invalid json",
+			file:    "user.json",
+			content: `{`,
+			result:  failure,
+		},
+		{
+			name:    "yaml with non-string keys",
+			file:    "user.yaml",
+			content: "1: one\n",
+			result:  success,
+			want:    map[string]interface{}{"1": "one"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := writeFixture(t, tc.file, tc.content)
+
+			e := WithConfig(Config{
+				Reporter: newMockReporter(t),
+			})
+
+			value := e.ExpectedFromFile(path, tc.vars...)
+			e.chain.assert(t, tc.result)
+
+			assert.Equal(t, tc.want, value)
+		})
+	}
+
+	t.Run("compare", func(t *testing.T) {
+		path := writeFixture(t, "user.yaml", "name: {{.Name}}\n")
+
+		e := WithConfig(Config{
+			Reporter: newMockReporter(t),
+		})
+
+		obj := e.Object(map[string]interface{}{"name": "john", "id": 1})
+		obj.ContainsSubset(e.ExpectedFromFile(path,
+			map[string]interface{}{"Name": "john"}))
+		obj.chain.assert(t, success)
+	})
+}
@@ -6,6 +6,8 @@ import (
 	"flag"
 	"fmt"
 	"math"
+	"math/big"
+	"net/http"
 	"net/http/httputil"
 	"os"
 	"path/filepath"
@@ -15,11 +17,13 @@ import (
 	"sync"
 	"testing"
 	"text/template"
+	"time"
 
 	"github.com/TylerBrock/colorjson"
 	"github.com/fatih/color"
 	"github.com/mattn/go-isatty"
 	"github.com/mitchellh/go-wordwrap"
+	"github.com/pmezard/go-difflib/difflib"
 	"github.com/sanity-io/litter"
 	"github.com/yudai/gojsondiff"
 	"github.com/yudai/gojsondiff/formatter"
@@ -31,6 +35,16 @@ type Formatter interface {
 	FormatFailure(*AssertionContext, *AssertionFailure) string
 }
 
+// FormattableValue may be implemented by user types to control how their
+// values are printed by DefaultFormatter in failure messages.
+//
+// By default, values are printed using String method, if any, or JSON
+// encoding, or Go syntax representation. If value implements
+// FormattableValue, the result of FormatValue is printed instead.
+type FormattableValue interface {
+	FormatValue() string
+}
+
 // DefaultFormatter is the default Formatter implementation.
 //
 // DefaultFormatter gathers values from AssertionContext and AssertionFailure,
@@ -61,6 +75,19 @@ type DefaultFormatter struct {
 	// Exclude HTTP response from failure report.
 	DisableResponses bool
 
+	// Include breadcrumbs into failure report: request method and URL,
+	// response status, round-trip time, and correlation ID.
+	EnableBreadcrumbs bool
+
+	// Exclude seed of Config.RandSource from failure report.
+	// The seed is included only if any random value was used, see RandSource.
+	DisableRandSeed bool
+
+	// Header used to find correlation ID included into breadcrumbs.
+	// It is looked up in response headers first, then in request headers.
+	// Default is Config.RequestIDHeader if set, or "X-Request-ID" otherwise.
+	CorrelationHeader string
+
 	// Thousand separator.
 	// Default is DigitSeparatorUnderscore.
 	DigitSeparator DigitSeparator
@@ -69,6 +96,19 @@ type DefaultFormatter struct {
 	// Default is FloatFormatAuto.
 	FloatFormat FloatFormat
 
+	// Maximum number of significant digits when printing floats.
+	// Default is zero, which means that the smallest number of digits
+	// needed to identify the value uniquely is used.
+	FloatPrecision int
+
+	// Decimal exponent from which FloatFormatAuto switches to scientific
+	// notation. Floats with absolute value >= 10^FloatExponentThreshold or
+	// < 10^-FloatExponentThreshold are printed in scientific notation, and
+	// other floats are printed in decimal notation.
+	// Default is zero, which means that scientific notation is used for
+	// non-integer floats in the same cases as in %g format.
+	FloatExponentThreshold int
+
 	// Defines whether to print stacktrace on failure and in what format.
 	// Default is StacktraceModeDisabled.
 	StacktraceMode StacktraceMode
@@ -81,6 +121,17 @@ type DefaultFormatter struct {
 	// Use zero for default width, and negative value to disable wrapping.
 	LineWidth int
 
+	// Maximum number of lines printed for every value (actual, expected,
+	// reference, diff, request, response). Remaining lines are replaced
+	// with "... N more lines" marker.
+	// Use zero to disable truncation.
+	MaxLines int
+
+	// Number of unchanged lines printed around every change in unified diffs
+	// of multi-line strings, e.g. response bodies.
+	// Use zero for default number, and negative value to print only changes.
+	DiffContextLines int
+
 	// If not empty, used to format success messages.
 	// If empty, default template is used.
 	SuccessTemplate string
@@ -134,6 +185,9 @@ const (
 
 	// Do not separate
 	DigitSeparatorNone
+
+	// Separate using space
+	DigitSeparatorSpace
 )
 
 // FloatFormat defines the format in which all floats are printed.
@@ -224,6 +278,17 @@ type FormatData struct {
 	HaveDiff bool
 	Diff     string
 
+	HaveBreadcrumbs   bool
+	RequestMethod     string
+	RequestURL        string
+	ResponseStatus    string
+	ResponseDuration  string
+	CorrelationHeader string
+	CorrelationID     string
+
+	HaveRandSeed bool
+	RandSeed     string
+
 	HaveRequest bool
 	Request     string
 
@@ -282,6 +347,10 @@ func (f *DefaultFormatter) buildFormatData(
 
 	f.fillGeneral(&data, ctx)
 
+	if failure != nil && ctx.Redactor != nil {
+		failure = redactFailure(ctx.Redactor, failure)
+	}
+
 	if failure != nil {
 		data.AssertType = failure.Type.String()
 		data.AssertSeverity = failure.Severity.String()
@@ -306,9 +375,17 @@ func (f *DefaultFormatter) buildFormatData(
 			f.fillDelta(&data, ctx, failure)
 		}
 
+		f.fillBreadcrumbs(&data, ctx, failure)
+		f.fillRandSeed(&data, ctx, failure)
 		f.fillRequest(&data, ctx, failure)
 		f.fillResponse(&data, ctx, failure)
 		f.fillStacktrace(&data, ctx, failure)
+
+		if ctx.Redactor != nil {
+			f.redactData(&data, ctx.Redactor)
+		}
+
+		f.truncateData(&data)
 	}
 
 	return &data
@@ -540,11 +617,101 @@ func (f *DefaultFormatter) fillDelta(
 	data.Delta = f.formatValue(failure.Delta.Value)
 }
 
+func (f *DefaultFormatter) fillBreadcrumbs(
+	data *FormatData, ctx *AssertionContext, failure *AssertionFailure,
+) {
+	if !f.EnableBreadcrumbs {
+		return
+	}
+
+	var httpResp *http.Response
+	if ctx.Response != nil {
+		httpResp = ctx.Response.httpResp
+	}
+
+	httpReq := contextHTTPRequest(ctx)
+	if httpReq == nil {
+		return
+	}
+
+	data.HaveBreadcrumbs = true
+	data.RequestMethod = httpReq.Method
+
+	if httpReq.URL != nil {
+		if ctx.Redactor != nil {
+			data.RequestURL = ctx.Redactor.redactURL(httpReq.URL).String()
+		} else {
+			data.RequestURL = httpReq.URL.String()
+		}
+	}
+
+	if httpResp != nil {
+		if httpResp.Status != "" {
+			data.ResponseStatus = httpResp.Status
+		} else {
+			data.ResponseStatus = fmt.Sprintf("%d %s",
+				httpResp.StatusCode, http.StatusText(httpResp.StatusCode))
+		}
+
+		if ctx.Response.rtt != nil {
+			data.ResponseDuration = ctx.Response.rtt.String()
+		}
+	}
+
+	header := f.CorrelationHeader
+	if header == "" {
+		switch {
+		case ctx.Request != nil:
+			header = ctx.Request.config.RequestIDHeader
+		case ctx.Response != nil:
+			header = ctx.Response.config.RequestIDHeader
+		}
+	}
+	header = requestIDHeaderOrDefault(header)
+
+	var id string
+	if httpResp != nil {
+		id = httpResp.Header.Get(header)
+	}
+	if id == "" {
+		id = httpReq.Header.Get(header)
+	}
+
+	if id != "" {
+		if ctx.Redactor != nil && ctx.Redactor.isRedactedHeader(header) {
+			id = ctx.Redactor.replacement()
+		}
+
+		data.CorrelationHeader = http.CanonicalHeaderKey(header)
+		data.CorrelationID = id
+	}
+}
+
+func (f *DefaultFormatter) fillRandSeed(
+	data *FormatData, ctx *AssertionContext, failure *AssertionFailure,
+) {
+	if f.DisableRandSeed || !ctx.RandSource.wasUsed() {
+		return
+	}
+
+	data.HaveRandSeed = true
+	data.RandSeed = strconv.FormatInt(ctx.RandSource.Seed(), 10)
+}
+
 func (f *DefaultFormatter) fillRequest(
 	data *FormatData, ctx *AssertionContext, failure *AssertionFailure,
 ) {
 	if !f.DisableRequests && ctx.Request != nil && ctx.Request.httpReq != nil {
-		dump, err := httputil.DumpRequest(ctx.Request.httpReq, false)
+		httpReq := ctx.Request.httpReq
+
+		if ctx.Redactor != nil {
+			reqCopy := *httpReq
+			reqCopy.URL = ctx.Redactor.redactURL(httpReq.URL)
+			reqCopy.Header = ctx.Redactor.RedactHeader(httpReq.Header)
+			httpReq = &reqCopy
+		}
+
+		dump, err := httputil.DumpRequest(httpReq, false)
 		if err != nil {
 			return
 		}
@@ -558,7 +725,15 @@ func (f *DefaultFormatter) fillResponse(
 	data *FormatData, ctx *AssertionContext, failure *AssertionFailure,
 ) {
 	if !f.DisableResponses && ctx.Response != nil && ctx.Response.httpResp != nil {
-		dump, err := httputil.DumpResponse(ctx.Response.httpResp, false)
+		httpResp := ctx.Response.httpResp
+
+		if ctx.Redactor != nil {
+			respCopy := *httpResp
+			respCopy.Header = ctx.Redactor.RedactHeader(httpResp.Header)
+			httpResp = &respCopy
+		}
+
+		dump, err := httputil.DumpResponse(httpResp, false)
 		if err != nil {
 			return
 		}
@@ -603,7 +778,74 @@ func (f *DefaultFormatter) fillStacktrace(
 	}
 }
 
+func (f *DefaultFormatter) redactData(data *FormatData, redactor *Redactor) {
+	for n := range data.Errors {
+		data.Errors[n] = redactor.RedactString(data.Errors[n])
+	}
+	data.Actual = redactor.RedactString(data.Actual)
+	for n := range data.Expected {
+		data.Expected[n] = redactor.RedactString(data.Expected[n])
+	}
+	data.Reference = redactor.RedactString(data.Reference)
+	data.Diff = redactor.RedactString(data.Diff)
+	data.RequestURL = redactor.RedactString(data.RequestURL)
+	data.CorrelationID = redactor.RedactString(data.CorrelationID)
+	data.Request = redactor.RedactString(data.Request)
+	data.Response = redactor.RedactString(data.Response)
+}
+
+func (f *DefaultFormatter) truncateData(data *FormatData) {
+	if f.MaxLines <= 0 {
+		return
+	}
+
+	data.Actual = f.truncateLines(data.Actual)
+	for n := range data.Expected {
+		data.Expected[n] = f.truncateLines(data.Expected[n])
+	}
+	data.Reference = f.truncateLines(data.Reference)
+	data.Diff = f.truncateLines(data.Diff)
+	data.Request = f.truncateLines(data.Request)
+	data.Response = f.truncateLines(data.Response)
+}
+
+func (f *DefaultFormatter) truncateLines(text string) string {
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+
+	if len(lines) <= f.MaxLines {
+		return text
+	}
+
+	more := len(lines) - f.MaxLines
+
+	return strings.Join(lines[:f.MaxLines], "\n") +
+		fmt.Sprintf("\n... %d more %s", more, pluralize(more, "line", "lines"))
+}
+
+func pluralize(n int, singular, plural string) string {
+	if n == 1 {
+		return singular
+	}
+	return plural
+}
+
 func (f *DefaultFormatter) formatValue(value interface{}) string {
+	if fv, ok := value.(FormattableValue); ok && !refIsNil(value) {
+		return fv.FormatValue()
+	}
+
+	if flt := extractBigFloat(value); flt != nil {
+		return f.reformatNumber(f.formatBigFloatValue(flt))
+	}
+
+	if num := extractBigInt(value); num != nil {
+		return f.reformatNumber(num.String())
+	}
+
+	if tm := extractTime(value); tm != nil {
+		return tm.Format(time.RFC3339Nano)
+	}
+
 	if flt := extractFloat32(value); flt != nil {
 		return f.reformatNumber(f.formatFloatValue(*flt, 32))
 	}
@@ -636,23 +878,91 @@ func (f *DefaultFormatter) formatValue(value interface{}) string {
 func (f *DefaultFormatter) formatFloatValue(value float64, bits int) string {
 	switch f.FloatFormat {
 	case FloatFormatAuto:
+		if f.FloatExponentThreshold > 0 {
+			if f.isLargeExponent(value) {
+				return f.formatScientific(value, bits)
+			} else {
+				return f.formatDecimal(value, bits)
+			}
+		}
 		if _, frac := math.Modf(value); frac != 0 {
-			return strconv.FormatFloat(value, 'g', -1, bits)
+			return strconv.FormatFloat(value, 'g', f.floatPrecision(), bits)
 		} else {
-			return strconv.FormatFloat(value, 'f', -1, bits)
+			return f.formatDecimal(value, bits)
 		}
 
 	case FloatFormatDecimal:
-		return strconv.FormatFloat(value, 'f', -1, bits)
+		return f.formatDecimal(value, bits)
 
 	case FloatFormatScientific:
-		return strconv.FormatFloat(value, 'e', -1, bits)
+		return f.formatScientific(value, bits)
 
 	default:
 		return fmt.Sprintf("%v", value)
 	}
 }
 
+func (f *DefaultFormatter) floatPrecision() int {
+	if f.FloatPrecision > 0 {
+		return f.FloatPrecision
+	}
+	return -1
+}
+
+func (f *DefaultFormatter) isLargeExponent(value float64) bool {
+	if value == 0 || math.IsInf(value, 0) || math.IsNaN(value) {
+		return false
+	}
+
+	exp := int(math.Floor(math.Log10(math.Abs(value))))
+
+	return exp >= f.FloatExponentThreshold || exp < -f.FloatExponentThreshold
+}
+
+func (f *DefaultFormatter) formatDecimal(value float64, bits int) string {
+	if f.FloatPrecision > 0 && !math.IsInf(value, 0) && !math.IsNaN(value) {
+		// round to given number of significant digits, and then
+		// print the rounded value with the smallest number of digits
+		s := strconv.FormatFloat(value, 'e', f.FloatPrecision-1, bits)
+		if rounded, err := strconv.ParseFloat(s, 64); err == nil {
+			return strconv.FormatFloat(rounded, 'f', -1, 64)
+		}
+	}
+
+	return strconv.FormatFloat(value, 'f', -1, bits)
+}
+
+func (f *DefaultFormatter) formatScientific(value float64, bits int) string {
+	if f.FloatPrecision > 0 {
+		s := strconv.FormatFloat(value, 'e', f.FloatPrecision-1, bits)
+		if rounded, err := strconv.ParseFloat(s, 64); err == nil {
+			return strconv.FormatFloat(rounded, 'e', -1, 64)
+		}
+	}
+
+	return strconv.FormatFloat(value, 'e', -1, bits)
+}
+
+func (f *DefaultFormatter) formatBigFloatValue(value *big.Float) string {
+	switch f.FloatFormat {
+	case FloatFormatAuto:
+		if value.IsInt() {
+			return value.Text('f', -1)
+		} else {
+			return value.Text('g', -1)
+		}
+
+	case FloatFormatDecimal:
+		return value.Text('f', -1)
+
+	case FloatFormatScientific:
+		return value.Text('e', -1)
+
+	default:
+		return value.String()
+	}
+}
+
 func (f *DefaultFormatter) formatTypedValue(value interface{}) string {
 	if refIsNum(value) {
 		return fmt.Sprintf("%T(%v)", value, f.formatValue(value))
@@ -703,6 +1013,13 @@ func (f *DefaultFormatter) formatListValue(value interface{}) []string {
 }
 
 func (f *DefaultFormatter) formatDiff(expected, actual interface{}) (string, bool) {
+	if se, ok := expected.(string); ok {
+		if sa, ok := actual.(string); ok {
+			return f.formatTextDiff(se, sa)
+		}
+		return "", false
+	}
+
 	differ := gojsondiff.New()
 
 	var diff gojsondiff.Diff
@@ -742,6 +1059,40 @@ func (f *DefaultFormatter) formatDiff(expected, actual interface{}) (string, boo
 	return diffText, true
 }
 
+func (f *DefaultFormatter) formatTextDiff(expected, actual string) (string, bool) {
+	// single-line strings are easier to compare without diff
+	if !strings.Contains(expected, "\n") && !strings.Contains(actual, "\n") {
+		return "", false
+	}
+
+	if expected == actual {
+		return "", false
+	}
+
+	context := f.DiffContextLines
+	switch {
+	case context == 0:
+		context = defaultDiffContextLines
+	case context < 0:
+		context = 0
+	}
+
+	diff := difflib.UnifiedDiff{
+		A:        difflib.SplitLines(expected),
+		B:        difflib.SplitLines(actual),
+		FromFile: "expected",
+		ToFile:   "actual",
+		Context:  context,
+	}
+
+	str, err := difflib.GetUnifiedDiffString(diff)
+	if err != nil || str == "" {
+		return "", false
+	}
+
+	return strings.TrimSuffix(str, "\n"), true
+}
+
 func (f *DefaultFormatter) reformatNumber(numStr string) string {
 	signPart, intPart, fracPart, expPart := f.decomposeNumber(numStr)
 	if intPart == "" {
@@ -800,6 +1151,9 @@ func (f *DefaultFormatter) applySeparator(numStr string, dir int) string {
 	case DigitSeparatorApostrophe:
 		separator = "'"
 		break
+	case DigitSeparatorSpace:
+		separator = " "
+		break
 	case DigitSeparatorComma:
 		separator = ","
 		break
@@ -855,6 +1209,39 @@ func extractFloat64(value interface{}) *float64 {
 	}
 }
 
+func extractBigFloat(value interface{}) *big.Float {
+	switch f := value.(type) {
+	case big.Float:
+		return &f
+	case *big.Float:
+		return f
+	default:
+		return nil
+	}
+}
+
+func extractBigInt(value interface{}) *big.Int {
+	switch n := value.(type) {
+	case big.Int:
+		return &n
+	case *big.Int:
+		return n
+	default:
+		return nil
+	}
+}
+
+func extractTime(value interface{}) *time.Time {
+	switch t := value.(type) {
+	case time.Time:
+		return &t
+	case *time.Time:
+		return t
+	default:
+		return nil
+	}
+}
+
 func exctractRange(value interface{}) *AssertionRange {
 	switch rng := value.(type) {
 	case AssertionRange:
@@ -912,8 +1299,11 @@ func colorMode() int {
 }
 
 const (
-	defaultIndent    = "  "
-	defaultLineWidth = 60
+	defaultIndent           = "  "
+	defaultLineWidth        = 60
+	defaultDiffContextLines = 3
+
+	defaultCorrelationHeader = "X-Request-ID"
 )
 
 var defaultColors = map[string]color.Attribute{
@@ -1112,6 +1502,7 @@ var defaultTemplateFuncs = template.FuncMap{
 		}{
 			{"---", color.FgWhite},
 			{"+++", color.FgWhite},
+			{"@@", color.FgCyan},
 			{"-", color.FgRed},
 			{"+", color.FgGreen},
 		}
@@ -1157,6 +1548,21 @@ test name: {{ .TestName | color $.EnableColors "Cyan" }}
 
 request name: {{ .RequestName | color $.EnableColors "Cyan" }}
 {{- end -}}
+{{- if .HaveBreadcrumbs }}
+
+endpoint: {{ .RequestMethod }} {{ .RequestURL | color $.EnableColors "Cyan" }}
+{{- if .ResponseStatus }}
+status: {{ .ResponseStatus | color $.EnableColors "Cyan" }}
+{{- if .ResponseDuration }} in {{ .ResponseDuration }}{{ end -}}
+{{- end -}}
+{{- if .CorrelationID }}
+{{ .CorrelationHeader }}: {{ .CorrelationID | color $.EnableColors "Cyan" }}
+{{- end -}}
+{{- end -}}
+{{- if .HaveRandSeed }}
+
+random seed: {{ .RandSeed | color $.EnableColors "Cyan" }}
+{{- end -}}
 {{- if .HaveRequest }}
 
 request: {{ .Request | colorhttp $.EnableColors false | indent | trim }}
@@ -1,7 +1,11 @@
 package httpexpect
 
 import (
+	"errors"
 	"fmt"
+	"math/big"
+	"net/http"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -601,6 +605,234 @@ func TestFormatter_FailureContext(t *testing.T) {
 	}
 }
 
+func TestFormatter_Breadcrumbs(t *testing.T) {
+	newContext := func() *AssertionContext {
+		httpReq, _ := http.NewRequest("GET", "http://example.com/users?token=secret", nil)
+		httpReq.Header.Set("X-Request-ID", "req-id")
+
+		rtt := 15 * time.Millisecond
+
+		return &AssertionContext{
+			Request: &Request{httpReq: httpReq},
+			Response: &Response{
+				httpResp: &http.Response{
+					StatusCode: http.StatusInternalServerError,
+					Header: http.Header{
+						"X-Request-Id":     {"resp-id"},
+						"X-Correlation-Id": {"corr-id"},
+					},
+					Request: httpReq,
+				},
+				rtt: &rtt,
+			},
+		}
+	}
+
+	failure := &AssertionFailure{
+		Type: AssertValid,
+		Errors: []error{
+			errors.New("unexpected token=secret"),
+		},
+	}
+
+	cases := []struct {
+		name  string
+		fmt   DefaultFormatter
+		ctx   func() *AssertionContext
+		check func(t *testing.T, fd *FormatData)
+	}{
+		{
+			name: "EnableBreadcrumbs",
+			fmt: DefaultFormatter{
+				EnableBreadcrumbs: true,
+			},
+			ctx: newContext,
+			check: func(t *testing.T, fd *FormatData) {
+				assert.True(t, fd.HaveBreadcrumbs)
+				assert.Equal(t, "GET", fd.RequestMethod)
+				assert.Equal(t, "http://example.com/users?token=secret", fd.RequestURL)
+				assert.Equal(t, "500 Internal Server Error", fd.ResponseStatus)
+				assert.Equal(t, "15ms", fd.ResponseDuration)
+				assert.Equal(t, "X-Request-Id", fd.CorrelationHeader)
+				assert.Equal(t, "resp-id", fd.CorrelationID)
+			},
+		},
+		{
+			name: "CorrelationHeader",
+			fmt: DefaultFormatter{
+				EnableBreadcrumbs: true,
+				CorrelationHeader: "x-correlation-id",
+			},
+			ctx: newContext,
+			check: func(t *testing.T, fd *FormatData) {
+				assert.Equal(t, "X-Correlation-Id", fd.CorrelationHeader)
+				assert.Equal(t, "corr-id", fd.CorrelationID)
+			},
+		},
+		{
+			name: "correlation ID from request",
+			fmt: DefaultFormatter{
+				EnableBreadcrumbs: true,
+			},
+			ctx: func() *AssertionContext {
+				ctx := newContext()
+				ctx.Response.httpResp.Header = nil
+				return ctx
+			},
+			check: func(t *testing.T, fd *FormatData) {
+				assert.Equal(t, "req-id", fd.CorrelationID)
+			},
+		},
+		{
+			name: "request only",
+			fmt: DefaultFormatter{
+				EnableBreadcrumbs: true,
+			},
+			ctx: func() *AssertionContext {
+				ctx := newContext()
+				ctx.Response = nil
+				return ctx
+			},
+			check: func(t *testing.T, fd *FormatData) {
+				assert.True(t, fd.HaveBreadcrumbs)
+				assert.Equal(t, "GET", fd.RequestMethod)
+				assert.Equal(t, "", fd.ResponseStatus)
+				assert.Equal(t, "", fd.ResponseDuration)
+				assert.Equal(t, "req-id", fd.CorrelationID)
+			},
+		},
+		{
+			name: "response only",
+			fmt: DefaultFormatter{
+				EnableBreadcrumbs: true,
+			},
+			ctx: func() *AssertionContext {
+				ctx := newContext()
+				ctx.Request = nil
+				return ctx
+			},
+			check: func(t *testing.T, fd *FormatData) {
+				assert.True(t, fd.HaveBreadcrumbs)
+				assert.Equal(t, "GET", fd.RequestMethod)
+				assert.Equal(t, "500 Internal Server Error", fd.ResponseStatus)
+			},
+		},
+		{
+			name: "no request",
+			fmt: DefaultFormatter{
+				EnableBreadcrumbs: true,
+			},
+			ctx: func() *AssertionContext {
+				return &AssertionContext{}
+			},
+			check: func(t *testing.T, fd *FormatData) {
+				assert.False(t, fd.HaveBreadcrumbs)
+			},
+		},
+		{
+			name: "redactor",
+			fmt: DefaultFormatter{
+				EnableBreadcrumbs: true,
+			},
+			ctx: func() *AssertionContext {
+				ctx := newContext()
+				ctx.Redactor = &Redactor{
+					Headers: []string{"X-Request-ID"},
+					Patterns: []*regexp.Regexp{
+						regexp.MustCompile(`secret`),
+					},
+				}
+				return ctx
+			},
+			check: func(t *testing.T, fd *FormatData) {
+				assert.NotContains(t, fd.RequestURL, "secret")
+				assert.NotContains(t, fd.CorrelationID, "resp-id")
+				assert.Equal(t, []string{"unexpected token=<redacted>"}, fd.Errors)
+			},
+		},
+		{
+			name: "default options",
+			fmt:  DefaultFormatter{},
+			ctx:  newContext,
+			check: func(t *testing.T, fd *FormatData) {
+				assert.False(t, fd.HaveBreadcrumbs)
+				assert.Equal(t, "", fd.RequestURL)
+				assert.Equal(t, "", fd.CorrelationID)
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fd := tc.fmt.buildFormatData(tc.ctx(), failure)
+			tc.check(t, fd)
+		})
+	}
+
+	t.Run("template", func(t *testing.T) {
+		formatter := &DefaultFormatter{
+			ColorMode:         ColorModeNever,
+			EnableBreadcrumbs: true,
+		}
+
+		msg := formatter.FormatFailure(newContext(), failure)
+
+		assert.Contains(t, msg,
+			"endpoint: GET http://example.com/users?token=secret\n"+
+				"status: 500 Internal Server Error in 15ms\n"+
+				"X-Request-Id: resp-id\n")
+	})
+}
+
+func TestFormatter_RandSeed(t *testing.T) {
+	failure := &AssertionFailure{
+		Type: AssertValid,
+	}
+
+	t.Run("used", func(t *testing.T) {
+		source := NewRandSource(42)
+		source.intn(10)
+
+		formatter := &DefaultFormatter{
+			ColorMode: ColorModeNever,
+		}
+
+		ctx := &AssertionContext{RandSource: source}
+
+		fd := formatter.buildFormatData(ctx, failure)
+		assert.True(t, fd.HaveRandSeed)
+		assert.Equal(t, "42", fd.RandSeed)
+
+		msg := formatter.FormatFailure(ctx, failure)
+		assert.Contains(t, msg, "random seed: 42")
+	})
+
+	t.Run("not used", func(t *testing.T) {
+		formatter := &DefaultFormatter{}
+
+		fd := formatter.buildFormatData(&AssertionContext{
+			RandSource: NewRandSource(42),
+		}, failure)
+		assert.False(t, fd.HaveRandSeed)
+
+		fd = formatter.buildFormatData(&AssertionContext{}, failure)
+		assert.False(t, fd.HaveRandSeed)
+	})
+
+	t.Run("DisableRandSeed", func(t *testing.T) {
+		source := NewRandSource(42)
+		source.intn(10)
+
+		formatter := &DefaultFormatter{
+			DisableRandSeed: true,
+		}
+
+		fd := formatter.buildFormatData(&AssertionContext{RandSource: source}, failure)
+		assert.False(t, fd.HaveRandSeed)
+		assert.Equal(t, "", fd.RandSeed)
+	})
+}
+
 func TestFormatter_FloatFormat(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -829,6 +1061,120 @@ func TestFormatter_FloatFields(t *testing.T) {
 	}
 }
 
+func TestFormatter_FloatPrecision(t *testing.T) {
+	cases := []struct {
+		name      string
+		format    FloatFormat
+		precision int
+		threshold int
+		value     interface{}
+		wantText  string
+	}{
+		{
+			name:      "auto precision",
+			format:    FloatFormatAuto,
+			precision: 4,
+			value:     float64(1.23456789),
+			wantText:  "1.235",
+		},
+		{
+			name:      "auto precision large",
+			format:    FloatFormatAuto,
+			precision: 12,
+			value:     float64(12345678.9),
+			wantText:  "12_345_678.9",
+		},
+		{
+			name:      "decimal precision",
+			format:    FloatFormatDecimal,
+			precision: 3,
+			value:     float64(0.000123456),
+			wantText:  "0.000_123",
+		},
+		{
+			name:      "decimal precision integer",
+			format:    FloatFormatDecimal,
+			precision: 3,
+			value:     float64(123456),
+			wantText:  "123_000",
+		},
+		{
+			name:      "scientific precision",
+			format:    FloatFormatScientific,
+			precision: 3,
+			value:     float32(1.23456),
+			wantText:  "1.23e+00",
+		},
+		{
+			name:      "precision ignored for integers",
+			format:    FloatFormatAuto,
+			precision: 3,
+			value:     int64(123456),
+			wantText:  "123_456",
+		},
+		{
+			name:      "threshold decimal",
+			format:    FloatFormatAuto,
+			threshold: 15,
+			value:     float64(1234567890.5),
+			wantText:  "1_234_567_890.5",
+		},
+		{
+			name:      "threshold small decimal",
+			format:    FloatFormatAuto,
+			threshold: 15,
+			value:     float64(0.00001),
+			wantText:  "0.000_01",
+		},
+		{
+			name:      "threshold large",
+			format:    FloatFormatAuto,
+			threshold: 6,
+			value:     float64(123456789),
+			wantText:  "1.234_567_89e+08",
+		},
+		{
+			name:      "threshold small",
+			format:    FloatFormatAuto,
+			threshold: 3,
+			value:     float64(0.00001),
+			wantText:  "1e-05",
+		},
+		{
+			name:      "threshold zero value",
+			format:    FloatFormatAuto,
+			threshold: 3,
+			value:     float64(0),
+			wantText:  "0",
+		},
+		{
+			name:      "threshold and precision",
+			format:    FloatFormatAuto,
+			precision: 2,
+			threshold: 3,
+			value:     float64(123456),
+			wantText:  "1.2e+05",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			formatter := DefaultFormatter{
+				FloatFormat:            tc.format,
+				FloatPrecision:         tc.precision,
+				FloatExponentThreshold: tc.threshold,
+			}
+			formatData := formatter.buildFormatData(
+				&AssertionContext{},
+				&AssertionFailure{
+					Type:   AssertValid,
+					Actual: &AssertionValue{tc.value},
+				})
+			assert.Equal(t, tc.wantText, formatData.Actual)
+		})
+	}
+}
+
 func TestFormatter_DigitSeparator(t *testing.T) {
 	cases := []struct {
 		name      string
@@ -988,6 +1334,13 @@ func TestFormatter_DigitSeparator(t *testing.T) {
 			value:     float64(12345678),
 			wantText:  "12345678",
 		},
+		{
+			name:      "space",
+			separator: DigitSeparatorSpace,
+			format:    FloatFormatDecimal,
+			value:     float64(12345678.25),
+			wantText:  "12 345 678.25",
+		},
 	}
 
 	for _, tc := range cases {
@@ -1031,6 +1384,9 @@ func TestFormatter_FormatValue(t *testing.T) {
 		check(fn("hello"))
 		check(fn(time.Second))
 		check(fn(time.Unix(0, 0)))
+		check(fn(big.NewFloat(123)))
+		check(fn(big.NewInt(123)))
+		check(fn(formattableValue{}))
 		check(fn([]interface{}{1, 2}))
 		check(fn(map[string]string{"a": "b"}))
 		check(fn(make(chan int)))
@@ -1065,6 +1421,98 @@ func TestFormatter_FormatValue(t *testing.T) {
 	})
 }
 
+type formattableValue struct {
+	id int
+}
+
+func (v formattableValue) FormatValue() string {
+	return fmt.Sprintf("value #%d", v.id)
+}
+
+func TestFormatter_CustomValues(t *testing.T) {
+	bigFloat, _, _ := big.ParseFloat("12345678901234567890.5", 10, 100, big.ToNearestEven)
+	bigInt, _ := new(big.Int).SetString("12345678901234567890", 10)
+
+	tm := time.Date(2023, 6, 1, 12, 30, 0, 500, time.UTC)
+
+	cases := []struct {
+		name     string
+		format   FloatFormat
+		value    interface{}
+		wantText string
+	}{
+		{
+			name:     "big float auto",
+			format:   FloatFormatAuto,
+			value:    bigFloat,
+			wantText: "1.234_567_890_123_456_789_05e+19",
+		},
+		{
+			name:     "big float decimal",
+			format:   FloatFormatDecimal,
+			value:    bigFloat,
+			wantText: "12_345_678_901_234_567_890.5",
+		},
+		{
+			name:     "big float scientific",
+			format:   FloatFormatScientific,
+			value:    *bigFloat,
+			wantText: "1.234_567_890_123_456_789_05e+19",
+		},
+		{
+			name:     "big float integer",
+			format:   FloatFormatAuto,
+			value:    big.NewFloat(1000000),
+			wantText: "1_000_000",
+		},
+		{
+			name:     "big int",
+			format:   FloatFormatAuto,
+			value:    bigInt,
+			wantText: "12_345_678_901_234_567_890",
+		},
+		{
+			name:     "time",
+			format:   FloatFormatAuto,
+			value:    tm,
+			wantText: "2023-06-01T12:30:00.0000005Z",
+		},
+		{
+			name:     "time pointer",
+			format:   FloatFormatAuto,
+			value:    &tm,
+			wantText: "2023-06-01T12:30:00.0000005Z",
+		},
+		{
+			name:     "formattable value",
+			format:   FloatFormatAuto,
+			value:    formattableValue{id: 5},
+			wantText: "value #5",
+		},
+		{
+			name:     "formattable pointer",
+			format:   FloatFormatAuto,
+			value:    &formattableValue{id: 6},
+			wantText: "value #6",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			formatter := DefaultFormatter{
+				FloatFormat: tc.format,
+			}
+			formatData := formatter.buildFormatData(
+				&AssertionContext{},
+				&AssertionFailure{
+					Type:   AssertValid,
+					Actual: &AssertionValue{tc.value},
+				})
+			assert.Equal(t, tc.wantText, formatData.Actual)
+		})
+	}
+}
+
 func TestFormatter_FormatDiff(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		check := func(a, b interface{}) {
@@ -1076,6 +1524,8 @@ func TestFormatter_FormatDiff(t *testing.T) {
 
 		check(map[string]interface{}{"a": 1}, map[string]interface{}{})
 		check([]interface{}{"a"}, []interface{}{})
+		check("foo\nbar", "foo\nbaz")
+		check("foo", "foo\n")
 	})
 
 	t.Run("failure", func(t *testing.T) {
@@ -1093,6 +1543,107 @@ func TestFormatter_FormatDiff(t *testing.T) {
 
 		check(map[string]interface{}{}, map[string]interface{}{})
 		check([]interface{}{}, []interface{}{})
+		check("foo\nbar", "foo\nbar")
+		check("foo\nbar", []interface{}{})
+	})
+}
+
+func TestFormatter_TextDiff(t *testing.T) {
+	expected := "l1\nl2\nl3\nl4\nl5\nl6\nl7\nl8\nl9"
+	actual := "l1\nl2\nl3\nl4\nXX\nl6\nl7\nl8\nl9"
+
+	t.Run("default context", func(t *testing.T) {
+		formatter := &DefaultFormatter{}
+
+		diff, ok := formatter.formatDiff(expected, actual)
+		assert.True(t, ok)
+		assert.Equal(t,
+			"--- expected\n+++ actual\n@@ -2,7 +2,7 @@\n"+
+				" l2\n l3\n l4\n-l5\n+XX\n l6\n l7\n l8",
+			diff)
+	})
+
+	t.Run("custom context", func(t *testing.T) {
+		formatter := &DefaultFormatter{
+			DiffContextLines: 1,
+		}
+
+		diff, ok := formatter.formatDiff(expected, actual)
+		assert.True(t, ok)
+		assert.Equal(t,
+			"--- expected\n+++ actual\n@@ -4,3 +4,3 @@\n l4\n-l5\n+XX\n l6",
+			diff)
+	})
+
+	t.Run("no context", func(t *testing.T) {
+		formatter := &DefaultFormatter{
+			DiffContextLines: -1,
+		}
+
+		diff, ok := formatter.formatDiff(expected, actual)
+		assert.True(t, ok)
+		assert.Equal(t,
+			"--- expected\n+++ actual\n@@ -5 +5 @@\n-l5\n+XX",
+			diff)
+	})
+
+	t.Run("failure message", func(t *testing.T) {
+		formatter := &DefaultFormatter{}
+
+		failure := &AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{actual},
+			Expected: &AssertionValue{expected},
+		}
+
+		msg := formatter.FormatFailure(&AssertionContext{}, failure)
+		assert.Contains(t, msg, "diff:\n  --- expected\n  +++ actual\n")
+		assert.Contains(t, msg, "  -l5\n  +XX\n")
+	})
+}
+
+func TestFormatter_MaxLines(t *testing.T) {
+	value := map[string]interface{}{
+		"a": 1,
+		"b": 2,
+		"c": 3,
+		"d": 4,
+	}
+
+	failure := &AssertionFailure{
+		Type:      AssertEqual,
+		Actual:    &AssertionValue{value},
+		Expected:  &AssertionValue{value},
+		Reference: &AssertionValue{"short"},
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		formatter := &DefaultFormatter{}
+
+		data := formatter.buildFormatData(&AssertionContext{}, failure)
+		assert.Equal(t, 6, len(strings.Split(data.Actual, "\n")))
+		assert.NotContains(t, data.Actual, "more")
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		formatter := &DefaultFormatter{
+			MaxLines: 3,
+		}
+
+		data := formatter.buildFormatData(&AssertionContext{}, failure)
+
+		assert.Equal(t, "{\n  \"a\": 1,\n  \"b\": 2,\n... 3 more lines", data.Actual)
+		assert.Equal(t, []string{data.Actual}, data.Expected)
+		assert.Equal(t, `"short"`, data.Reference)
+	})
+
+	t.Run("singular", func(t *testing.T) {
+		formatter := &DefaultFormatter{
+			MaxLines: 5,
+		}
+
+		data := formatter.buildFormatData(&AssertionContext{}, failure)
+		assert.True(t, strings.HasSuffix(data.Actual, "\n... 1 more line"))
 	})
 }
 
@@ -0,0 +1,756 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Fuzzer sends mutated versions of a template request and checks that
+// every response satisfies given invariants.
+//
+// Template request is defined using WithQuery, WithHeader, and WithJSON.
+// Fuzzer generates mutations of every query parameter, header, and JSON
+// field of the template: type flips (e.g. number replaced with string),
+// boundary values (e.g. huge numbers and empty strings), and injection
+// strings (e.g. SQL and HTML injections). Each mutation changes exactly
+// one value of the template.
+//
+// Invariants are global properties that should hold for any input, like
+// "server never responds with 5xx" or "every error response matches
+// error schema". They are defined using Invariant, NoServerErrors, and
+// ErrorSchema.
+//
+// Run sends all mutations sequentially. RunInput sends a single mutation
+// chosen by arbitrary input bytes and is intended for Go native fuzzing,
+// see Seed for an example.
+//
+// Fuzzer is created using Expect.Fuzz.
+type Fuzzer struct {
+	noCopy     noCopy
+	chain      *chain
+	expect     *Expect
+	method     string
+	path       string
+	pathArgs   []interface{}
+	query      []fuzzParam
+	headers    []fuzzParam
+	body       interface{}
+	hasBody    bool
+	invariants []func(*Response)
+}
+
+type fuzzParam struct {
+	key   string
+	value string
+}
+
+// FuzzTarget defines which part of template request is mutated.
+type FuzzTarget string
+
+const (
+	// Mutation of query parameter.
+	FuzzQuery FuzzTarget = "query"
+
+	// Mutation of header.
+	FuzzHeader FuzzTarget = "header"
+
+	// Mutation of JSON body field.
+	FuzzJSON FuzzTarget = "json"
+)
+
+// FuzzKind defines the class of a mutation.
+type FuzzKind string
+
+const (
+	// Value replaced with a value of another type.
+	FuzzTypeFlip FuzzKind = "type-flip"
+
+	// Value replaced with a boundary value of the same type.
+	FuzzBoundary FuzzKind = "boundary"
+
+	// Value replaced with an injection string.
+	FuzzInjection FuzzKind = "injection"
+
+	// Value replaced with raw fuzzer input (see Fuzzer.RunInput).
+	FuzzRaw FuzzKind = "raw"
+)
+
+// FuzzMutation describes a single change of template request.
+type FuzzMutation struct {
+	// Mutated part of request.
+	Target FuzzTarget
+
+	// Query parameter name, header name, or JSON path of the field,
+	// e.g. "$.user.age" or "$.tags[0]".
+	Name string
+
+	// Class of mutation.
+	Kind FuzzKind
+
+	// New value.
+	// For FuzzQuery and FuzzHeader, it's always a string.
+	Value interface{}
+}
+
+// String returns human-readable description of mutation.
+func (m FuzzMutation) String() string {
+	value, err := json.Marshal(m.Value)
+	if err != nil {
+		value = []byte(fmt.Sprint(m.Value))
+	}
+
+	s := string(value)
+	if len(s) > 64 {
+		s = s[:64] + "..."
+	}
+
+	return fmt.Sprintf("%s %s %s=%s", m.Kind, m.Target, m.Name, s)
+}
+
+// FuzzReport describes results of Fuzzer.Run.
+type FuzzReport struct {
+	// Number of sent mutations.
+	Total int
+
+	// Mutations for which at least one invariant failed.
+	Failures []FuzzMutation
+}
+
+// Failed returns true if any invariant failed for any mutation.
+func (r *FuzzReport) Failed() bool {
+	return len(r.Failures) != 0
+}
+
+// FuzzSeeder is implemented by *testing.F.
+type FuzzSeeder interface {
+	Add(args ...interface{})
+}
+
+var fuzzInjections = []string{
+	"' OR '1'='1",
+	"\"; DROP TABLE users; --",
+	"<script>alert(1)</script>",
+	"../../../../etc/passwd",
+	"${jndi:ldap://example.com/a}",
+	"{{7*7}}",
+	"%00",
+	"$(id)",
+}
+
+var fuzzHeaderInjections = []string{
+	"' OR '1'='1",
+	"<script>alert(1)</script>",
+	"../../../../etc/passwd",
+	"${jndi:ldap://example.com/a}",
+}
+
+// Fuzz returns a new Fuzzer instance for given method and path.
+//
+// Request for every mutation is created in the same way as by
+// Expect.Request, hence builders and matchers attached to Expect
+// are applied too.
+//
+// Example:
+//
+//	e := httpexpect.Default(t, "http://example.com")
+//
+//	e.Fuzz("POST", "/users").
+//		WithQuery("notify", "true").
+//		WithHeader("X-Tenant", "acme").
+//		WithJSON(map[string]interface{}{
+//			"name": "alice",
+//			"age":  30,
+//		}).
+//		NoServerErrors().
+//		ErrorSchema(errorSchema).
+//		Run()
+func (e *Expect) Fuzz(method, path string, pathargs ...interface{}) *Fuzzer {
+	opChain := e.chain.enter("Fuzz(%q)", method)
+	defer opChain.leave()
+
+	return &Fuzzer{
+		chain:    opChain.clone(),
+		expect:   e,
+		method:   method,
+		path:     path,
+		pathArgs: pathargs,
+	}
+}
+
+// WithQuery adds query parameter to template request.
+//
+// value is converted to string in the same way as by Request.WithQuery.
+func (f *Fuzzer) WithQuery(key string, value interface{}) *Fuzzer {
+	opChain := f.chain.enter("WithQuery()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return f
+	}
+
+	f.query = append(f.query, fuzzParam{key, fmt.Sprint(value)})
+
+	return f
+}
+
+// WithHeader adds header to template request.
+func (f *Fuzzer) WithHeader(key, value string) *Fuzzer {
+	opChain := f.chain.enter("WithHeader()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return f
+	}
+
+	f.headers = append(f.headers, fuzzParam{key, value})
+
+	return f
+}
+
+// WithJSON sets JSON body of template request.
+//
+// object should be marshallable with json.Marshal. Every field of the
+// object, including nested fields and array elements, is mutated.
+func (f *Fuzzer) WithJSON(object interface{}) *Fuzzer {
+	opChain := f.chain.enter("WithJSON()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return f
+	}
+
+	body, ok := canonValue(opChain, object)
+	if !ok {
+		return f
+	}
+
+	f.body = body
+	f.hasBody = true
+
+	return f
+}
+
+// Invariant adds a function that is invoked for every response.
+//
+// fn should perform assertions on the response. If an assertion fails,
+// the failure is reported and its path includes the mutation.
+//
+// Example:
+//
+//	fuzzer.Invariant(func(resp *httpexpect.Response) {
+//		resp.Header("Content-Type").NotEmpty()
+//	})
+func (f *Fuzzer) Invariant(fn func(resp *Response)) *Fuzzer {
+	opChain := f.chain.enter("Invariant()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return f
+	}
+
+	if fn == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil function argument"),
+			},
+		})
+		return f
+	}
+
+	f.invariants = append(f.invariants, fn)
+
+	return f
+}
+
+// NoServerErrors adds invariant that checks that response status
+// doesn't belong to Status5xx range.
+func (f *Fuzzer) NoServerErrors() *Fuzzer {
+	return f.Invariant(func(resp *Response) {
+		opChain := resp.chain.enter("NoServerErrors()")
+		defer opChain.leave()
+
+		if opChain.failed() {
+			return
+		}
+
+		if resp.httpResp.StatusCode >= 500 && resp.httpResp.StatusCode < 600 {
+			opChain.fail(AssertionFailure{
+				Type:   AssertNotBelongs,
+				Actual: &AssertionValue{statusCodeText(resp.httpResp.StatusCode)},
+				Expected: &AssertionValue{AssertionList{
+					statusRangeText(int(Status5xx)),
+				}},
+				Errors: []error{
+					errors.New("expected: http status does not belong to given range"),
+				},
+			})
+		}
+	})
+}
+
+// ErrorSchema adds invariant that checks that every Status4xx response
+// has JSON body matching given schema.
+//
+// schema is interpreted in the same way as by Value.Schema.
+func (f *Fuzzer) ErrorSchema(schema interface{}) *Fuzzer {
+	return f.Invariant(func(resp *Response) {
+		if resp.chain.failed() {
+			return
+		}
+
+		if resp.httpResp.StatusCode >= 400 && resp.httpResp.StatusCode < 500 {
+			resp.JSON().Schema(schema)
+		}
+	})
+}
+
+// Mutations returns all mutations of template request, in the order
+// in which they are sent by Run.
+func (f *Fuzzer) Mutations() []FuzzMutation {
+	var mutations []FuzzMutation
+
+	for _, p := range f.query {
+		mutations = append(mutations,
+			fuzzStringMutations(FuzzQuery, p.key, fuzzInjections)...)
+	}
+
+	for _, p := range f.headers {
+		mutations = append(mutations,
+			fuzzStringMutations(FuzzHeader, p.key, fuzzHeaderInjections)...)
+	}
+
+	if f.hasBody {
+		for _, field := range fuzzJSONFields("$", f.body) {
+			mutations = append(mutations, fuzzJSONMutations(field)...)
+		}
+	}
+
+	return mutations
+}
+
+// Run sends every mutation of template request and checks invariants.
+//
+// Mutations are sent sequentially, in the order returned by Mutations.
+// Failures are reported as usual; the returned report additionally
+// lists mutations that caused failures.
+//
+// Example:
+//
+//	report := fuzzer.Run()
+//	for _, m := range report.Failures {
+//		t.Logf("failed mutation: %s", m)
+//	}
+func (f *Fuzzer) Run() *FuzzReport {
+	opChain := f.chain.enter("Run()")
+	defer opChain.leave()
+
+	report := &FuzzReport{}
+
+	if opChain.failed() {
+		return report
+	}
+
+	if len(f.invariants) == 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected Run() call without invariants"),
+			},
+		})
+		return report
+	}
+
+	for _, m := range f.Mutations() {
+		report.Total++
+
+		if !f.send(opChain, m) {
+			report.Failures = append(report.Failures, m)
+		}
+	}
+
+	return report
+}
+
+// RunInput sends a single mutation of template request selected by data,
+// and checks invariants.
+//
+// RunInput is intended to be invoked from the function passed to
+// testing.F.Fuzz. First two bytes of data select mutated value and
+// mutation, and remaining bytes, if any, are used as raw value instead
+// of predefined mutation. Use Seed to populate fuzzing corpus.
+//
+// Example:
+//
+//	func FuzzUsers(f *testing.F) {
+//		newFuzzer := func(t *testing.T) *httpexpect.Fuzzer {
+//			return httpexpect.Default(t, "http://example.com").
+//				Fuzz("POST", "/users").
+//				WithJSON(map[string]interface{}{"name": "alice"}).
+//				NoServerErrors()
+//		}
+//
+//		newFuzzer(&testing.T{}).Seed(f)
+//
+//		f.Fuzz(func(t *testing.T, data []byte) {
+//			newFuzzer(t).RunInput(data)
+//		})
+//	}
+func (f *Fuzzer) RunInput(data []byte) {
+	opChain := f.chain.enter("RunInput()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return
+	}
+
+	if len(f.invariants) == 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected RunInput() call without invariants"),
+			},
+		})
+		return
+	}
+
+	m, ok := f.decodeInput(data)
+	if !ok {
+		return
+	}
+
+	f.send(opChain, m)
+}
+
+// Seed adds every mutation returned by Mutations to fuzzing corpus.
+//
+// seeder is normally *testing.F. Added entries have single []byte
+// argument, which is accepted by RunInput.
+func (f *Fuzzer) Seed(seeder FuzzSeeder) {
+	opChain := f.chain.enter("Seed()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return
+	}
+
+	for _, in := range f.encodeInputs() {
+		seeder.Add(in)
+	}
+}
+
+func (f *Fuzzer) encodeInputs() [][]byte {
+	var inputs [][]byte
+
+	targets := f.targets()
+
+	for ti, target := range targets {
+		mutations := f.targetMutations(target)
+
+		for mi := range mutations {
+			inputs = append(inputs, []byte{byte(ti), byte(mi)})
+		}
+	}
+
+	return inputs
+}
+
+func (f *Fuzzer) decodeInput(data []byte) (FuzzMutation, bool) {
+	targets := f.targets()
+
+	if len(targets) == 0 || len(data) < 2 {
+		return FuzzMutation{}, false
+	}
+
+	target := targets[int(data[0])%len(targets)]
+
+	if len(data) > 2 {
+		return FuzzMutation{
+			Target: target.Target,
+			Name:   target.Name,
+			Kind:   FuzzRaw,
+			Value:  string(data[2:]),
+		}, true
+	}
+
+	mutations := f.targetMutations(target)
+
+	return mutations[int(data[1])%len(mutations)], true
+}
+
+// targets returns one placeholder mutation per mutated value.
+func (f *Fuzzer) targets() []FuzzMutation {
+	var targets []FuzzMutation
+
+	for _, p := range f.query {
+		targets = append(targets, FuzzMutation{Target: FuzzQuery, Name: p.key})
+	}
+
+	for _, p := range f.headers {
+		targets = append(targets, FuzzMutation{Target: FuzzHeader, Name: p.key})
+	}
+
+	if f.hasBody {
+		for _, field := range fuzzJSONFields("$", f.body) {
+			targets = append(targets,
+				FuzzMutation{Target: FuzzJSON, Name: field.path, Value: field.value})
+		}
+	}
+
+	return targets
+}
+
+func (f *Fuzzer) targetMutations(target FuzzMutation) []FuzzMutation {
+	switch target.Target {
+	case FuzzQuery:
+		return fuzzStringMutations(FuzzQuery, target.Name, fuzzInjections)
+	case FuzzHeader:
+		return fuzzStringMutations(FuzzHeader, target.Name, fuzzHeaderInjections)
+	default:
+		return fuzzJSONMutations(fuzzJSONField{target.Name, target.Value})
+	}
+}
+
+// send sends template request with applied mutation and returns false
+// if any assertion failed.
+func (f *Fuzzer) send(opChain *chain, m FuzzMutation) bool {
+	mutationChain := opChain.enter("Mutation(%q)", m.String())
+	defer mutationChain.leave()
+
+	// Detach mutation from opChain, otherwise after the first failure
+	// all following mutations would be skipped as failed.
+	mutationChain.setRoot()
+
+	defer func() {
+		if mutationChain.treeFailed() {
+			opChain.setFailedChildren()
+		}
+	}()
+
+	e := f.expect.withChain(mutationChain)
+
+	req := e.Request(f.method, f.path, f.pathArgs...)
+
+	for _, p := range f.query {
+		value := p.value
+		if m.Target == FuzzQuery && m.Name == p.key {
+			value = m.Value.(string)
+		}
+		req.WithQuery(p.key, value)
+	}
+
+	for _, p := range f.headers {
+		value := p.value
+		if m.Target == FuzzHeader && m.Name == p.key {
+			value = m.Value.(string)
+		}
+		req.WithHeader(p.key, value)
+	}
+
+	if f.hasBody {
+		body := f.body
+		if m.Target == FuzzJSON {
+			body = fuzzReplaceJSON(f.body, "$", m.Name, m.Value)
+		}
+		req.WithJSON(body)
+	}
+
+	resp := req.Expect()
+
+	for _, fn := range f.invariants {
+		fn(resp)
+	}
+
+	return !mutationChain.treeFailed()
+}
+
+func fuzzStringMutations(
+	target FuzzTarget, name string, injections []string,
+) []FuzzMutation {
+	var mutations []FuzzMutation
+
+	add := func(kind FuzzKind, value string) {
+		mutations = append(mutations, FuzzMutation{
+			Target: target,
+			Name:   name,
+			Kind:   kind,
+			Value:  value,
+		})
+	}
+
+	for _, v := range []string{"true", "null", "[]", "{}", "0"} {
+		add(FuzzTypeFlip, v)
+	}
+
+	for _, v := range []string{
+		"",
+		"-1",
+		strconv.FormatInt(math.MaxInt64, 10),
+		strconv.FormatInt(math.MinInt64, 10),
+		"1e308",
+		"NaN",
+		strings.Repeat("a", 4096),
+	} {
+		add(FuzzBoundary, v)
+	}
+
+	for _, v := range injections {
+		add(FuzzInjection, v)
+	}
+
+	return mutations
+}
+
+func fuzzJSONMutations(field fuzzJSONField) []FuzzMutation {
+	var mutations []FuzzMutation
+
+	add := func(kind FuzzKind, value interface{}) {
+		mutations = append(mutations, FuzzMutation{
+			Target: FuzzJSON,
+			Name:   field.path,
+			Kind:   kind,
+			Value:  value,
+		})
+	}
+
+	flips := []interface{}{
+		nil,
+		"string",
+		float64(1),
+		true,
+		[]interface{}{},
+		map[string]interface{}{},
+	}
+
+	for _, v := range flips {
+		if fuzzSameType(field.value, v) {
+			continue
+		}
+		add(FuzzTypeFlip, v)
+	}
+
+	switch field.value.(type) {
+	case float64:
+		for _, v := range []float64{
+			0, -1, math.MaxInt32, math.MinInt32, math.MaxInt64, -math.MaxFloat64,
+			math.MaxFloat64, 0.5,
+		} {
+			add(FuzzBoundary, v)
+		}
+
+	case string:
+		for _, v := range []string{
+			"", " ", strings.Repeat("a", 65536), "\u0000", "\U0001F600",
+		} {
+			add(FuzzBoundary, v)
+		}
+
+	case []interface{}:
+		add(FuzzBoundary, []interface{}{})
+		add(FuzzBoundary, []interface{}{nil})
+	}
+
+	if _, ok := field.value.(string); ok {
+		for _, v := range fuzzInjections {
+			add(FuzzInjection, v)
+		}
+	}
+
+	return mutations
+}
+
+func fuzzSameType(a, b interface{}) bool {
+	switch a.(type) {
+	case nil:
+		return b == nil
+	case string:
+		_, ok := b.(string)
+		return ok
+	case float64:
+		_, ok := b.(float64)
+		return ok
+	case bool:
+		_, ok := b.(bool)
+		return ok
+	case []interface{}:
+		_, ok := b.([]interface{})
+		return ok
+	case map[string]interface{}:
+		_, ok := b.(map[string]interface{})
+		return ok
+	}
+	return false
+}
+
+type fuzzJSONField struct {
+	path  string
+	value interface{}
+}
+
+// fuzzJSONFields returns all fields of canonical JSON value, except root,
+// ordered by path.
+func fuzzJSONFields(path string, value interface{}) []fuzzJSONField {
+	var fields []fuzzJSONField
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			childPath := path + "." + k
+			fields = append(fields, fuzzJSONField{childPath, v[k]})
+			fields = append(fields, fuzzJSONFields(childPath, v[k])...)
+		}
+
+	case []interface{}:
+		for i, elem := range v {
+			childPath := fmt.Sprintf("%s[%d]", path, i)
+			fields = append(fields, fuzzJSONField{childPath, elem})
+			fields = append(fields, fuzzJSONFields(childPath, elem)...)
+		}
+	}
+
+	return fields
+}
+
+// fuzzReplaceJSON returns a copy of canonical JSON value in which field
+// with given path is replaced with replacement.
+func fuzzReplaceJSON(
+	value interface{}, path, target string, replacement interface{},
+) interface{} {
+	if path == target {
+		return replacement
+	}
+
+	if !strings.HasPrefix(target, path) {
+		return value
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, elem := range v {
+			out[k] = fuzzReplaceJSON(elem, path+"."+k, target, replacement)
+		}
+		return out
+
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, elem := range v {
+			out[i] = fuzzReplaceJSON(
+				elem, fmt.Sprintf("%s[%d]", path, i), target, replacement)
+		}
+		return out
+	}
+
+	return value
+}
@@ -0,0 +1,269 @@
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockFuzzSeeder struct {
+	inputs [][]byte
+}
+
+func (s *mockFuzzSeeder) Add(args ...interface{}) {
+	s.inputs = append(s.inputs, args[0].([]byte))
+}
+
+func newFuzzClient(t *testing.T) ClientFunc {
+	return ClientFunc(func(req *http.Request) (*http.Response, error) {
+		var body map[string]interface{}
+		if req.Body != nil {
+			b, _ := io.ReadAll(req.Body)
+			_ = json.Unmarshal(b, &body)
+		}
+
+		// server crashes if age is not a number
+		if _, ok := body["age"].(float64); !ok {
+			return &http.Response{
+				StatusCode: http.StatusInternalServerError,
+				Body:       http.NoBody,
+			}, nil
+		}
+
+		if req.URL.Query().Get("limit") != "10" {
+			return &http.Response{
+				StatusCode: http.StatusBadRequest,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body:       io.NopCloser(bytes.NewBufferString(`{"error":"bad limit"}`)),
+			}, nil
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       http.NoBody,
+		}, nil
+	})
+}
+
+func TestFuzz_FailedChain(t *testing.T) {
+	reporter := newMockReporter(t)
+	chain := newChainWithDefaults("test", reporter, flagFailed)
+
+	e := &Expect{
+		config: newMockConfig(reporter),
+		chain:  chain,
+	}
+
+	fuzzer := e.Fuzz("GET", "/")
+	fuzzer.chain.assert(t, failure)
+
+	fuzzer.WithQuery("a", 1)
+	fuzzer.WithHeader("b", "c")
+	fuzzer.WithJSON(map[string]interface{}{"d": 1})
+	fuzzer.Invariant(func(resp *Response) {})
+	fuzzer.NoServerErrors()
+
+	report := fuzzer.Run()
+	assert.Equal(t, 0, report.Total)
+	assert.False(t, report.Failed())
+
+	fuzzer.RunInput([]byte{0, 0})
+
+	seeder := &mockFuzzSeeder{}
+	fuzzer.Seed(seeder)
+	assert.Empty(t, seeder.inputs)
+}
+
+func TestFuzz_Mutations(t *testing.T) {
+	e := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: newMockReporter(t),
+		Client:   newFuzzClient(t),
+	})
+
+	fuzzer := e.Fuzz("POST", "/users").
+		WithQuery("limit", 10).
+		WithHeader("X-Tenant", "acme").
+		WithJSON(map[string]interface{}{
+			"name": "alice",
+			"age":  30,
+			"tags": []interface{}{"a"},
+		})
+
+	mutations := fuzzer.Mutations()
+	require.NotEmpty(t, mutations)
+
+	names := map[string]bool{}
+	kinds := map[FuzzKind]bool{}
+
+	for _, m := range mutations {
+		names[string(m.Target)+":"+m.Name] = true
+		kinds[m.Kind] = true
+
+		if m.Target == FuzzJSON && m.Name == "$.age" {
+			_, isNumber := m.Value.(float64)
+			if m.Kind == FuzzTypeFlip {
+				assert.False(t, isNumber)
+			} else {
+				assert.True(t, isNumber)
+			}
+		}
+	}
+
+	assert.Equal(t, map[string]bool{
+		"query:limit":     true,
+		"header:X-Tenant": true,
+		"json:$.age":      true,
+		"json:$.name":     true,
+		"json:$.tags":     true,
+		"json:$.tags[0]":  true,
+	}, names)
+
+	assert.Equal(t, map[FuzzKind]bool{
+		FuzzTypeFlip:  true,
+		FuzzBoundary:  true,
+		FuzzInjection: true,
+	}, kinds)
+}
+
+func TestFuzz_Run(t *testing.T) {
+	t.Run("failures", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		e := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Reporter: reporter,
+			Client:   newFuzzClient(t),
+		})
+
+		fuzzer := e.Fuzz("POST", "/users").
+			WithQuery("limit", 10).
+			WithJSON(map[string]interface{}{
+				"age": 30,
+			}).
+			NoServerErrors().
+			ErrorSchema(`{"type": "object", "required": ["error"]}`)
+
+		report := fuzzer.Run()
+
+		assert.Equal(t, len(fuzzer.Mutations()), report.Total)
+		assert.True(t, report.Failed())
+		assert.True(t, reporter.reported)
+		fuzzer.chain.assert(t, failure)
+
+		for _, m := range report.Failures {
+			assert.Equal(t, FuzzJSON, m.Target)
+			assert.Equal(t, "$.age", m.Name)
+			assert.Equal(t, FuzzTypeFlip, m.Kind)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		e := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Reporter: reporter,
+			Client:   newFuzzClient(t),
+		})
+
+		report := e.Fuzz("POST", "/users").
+			WithQuery("limit", 10).
+			WithJSON(map[string]interface{}{
+				"age":  30,
+				"name": "alice",
+			}).
+			Invariant(func(resp *Response) {
+				resp.StatusList(http.StatusOK, http.StatusBadRequest, http.StatusInternalServerError)
+			}).
+			Run()
+
+		assert.Greater(t, report.Total, 0)
+		assert.False(t, report.Failed())
+		assert.False(t, reporter.reported)
+	})
+
+	t.Run("no invariants", func(t *testing.T) {
+		e := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Reporter: newMockReporter(t),
+			Client:   newFuzzClient(t),
+		})
+
+		fuzzer := e.Fuzz("POST", "/users").WithQuery("limit", 10)
+		fuzzer.Run()
+		fuzzer.chain.assert(t, failure)
+	})
+}
+
+func TestFuzz_RunInput(t *testing.T) {
+	newFuzzer := func(reporter Reporter) *Fuzzer {
+		e := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Reporter: reporter,
+			Client:   newFuzzClient(t),
+		})
+
+		return e.Fuzz("POST", "/users").
+			WithQuery("limit", 10).
+			WithJSON(map[string]interface{}{
+				"age": 30,
+			}).
+			NoServerErrors()
+	}
+
+	seeder := &mockFuzzSeeder{}
+	newFuzzer(newMockReporter(t)).Seed(seeder)
+
+	assert.Equal(t, len(newFuzzer(newMockReporter(t)).Mutations()), len(seeder.inputs))
+
+	failed := 0
+	for _, in := range seeder.inputs {
+		reporter := newMockReporter(t)
+		newFuzzer(reporter).RunInput(in)
+		if reporter.reported {
+			failed++
+		}
+	}
+	assert.Greater(t, failed, 0)
+
+	t.Run("raw query", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		newFuzzer(reporter).RunInput([]byte("\x00\x00anything"))
+		assert.False(t, reporter.reported)
+	})
+
+	t.Run("raw json", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		newFuzzer(reporter).RunInput([]byte("\x01\x00anything"))
+		assert.True(t, reporter.reported)
+	})
+
+	t.Run("short input", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		newFuzzer(reporter).RunInput([]byte{0})
+		assert.False(t, reporter.reported)
+	})
+}
+
+func TestFuzz_ReplaceJSON(t *testing.T) {
+	orig := map[string]interface{}{
+		"a":  map[string]interface{}{"b": []interface{}{1.0, 2.0}},
+		"ab": "x",
+	}
+
+	out := fuzzReplaceJSON(orig, "$", "$.a.b[1]", "y")
+
+	assert.Equal(t, map[string]interface{}{
+		"a":  map[string]interface{}{"b": []interface{}{1.0, "y"}},
+		"ab": "x",
+	}, out)
+
+	assert.Equal(t, 2.0,
+		orig["a"].(map[string]interface{})["b"].([]interface{})[1])
+}
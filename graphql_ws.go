@@ -0,0 +1,466 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/gorilla/websocket"
+)
+
+// GraphQLWebsocketProtocol is the WebSocket subprotocol implemented by
+// GraphQLWebsocket.
+const GraphQLWebsocketProtocol = "graphql-transport-ws"
+
+// graphql-ws message types.
+const (
+	graphqlConnectionInit = "connection_init"
+	graphqlConnectionAck  = "connection_ack"
+	graphqlPing           = "ping"
+	graphqlPong           = "pong"
+	graphqlSubscribe      = "subscribe"
+	graphqlNext           = "next"
+	graphqlError          = "error"
+	graphqlComplete       = "complete"
+)
+
+// GraphQLWebsocket implements client side of graphql-ws protocol
+// (graphql-transport-ws subprotocol) on top of Websocket.
+//
+// Server pings are answered automatically. Messages that belong to
+// other subscriptions are queued until they are requested, so multiple
+// subscriptions may be interleaved on the same connection.
+//
+// Subprotocol should be requested when WebSocket connection is established.
+//
+// Example:
+//
+//	ws := e.GET("/graphql").WithWebsocketUpgrade().
+//		WithHeader("Sec-WebSocket-Protocol", GraphQLWebsocketProtocol).
+//		Expect().
+//		Status(http.StatusSwitchingProtocols).
+//		Websocket()
+//	defer ws.Disconnect()
+//
+//	gql := ws.GraphQL().Init()
+//
+//	sub := gql.Subscribe(`subscription { counter }`)
+//	sub.Next().Path("$.data.counter").IsEqual(1)
+//	sub.Next().Path("$.data.counter").IsEqual(2)
+//	sub.Complete()
+type GraphQLWebsocket struct {
+	noCopy noCopy
+	chain  *chain
+	ws     *Websocket
+
+	lastID  int
+	pending []map[string]interface{}
+}
+
+// GraphQL returns a new GraphQLWebsocket instance that speaks graphql-ws
+// protocol over this connection.
+//
+// Example:
+//
+//	gql := ws.GraphQL().Init()
+func (ws *Websocket) GraphQL() *GraphQLWebsocket {
+	opChain := ws.chain.enter("GraphQL()")
+	defer opChain.leave()
+
+	ws.checkUnusable(opChain, "GraphQL()")
+
+	return &GraphQLWebsocket{
+		chain: opChain.clone(),
+		ws:    ws,
+	}
+}
+
+// Init sends "connection_init" message and waits for "connection_ack".
+//
+// Optional payload is sent as connection_init payload, e.g. to pass
+// authentication parameters.
+//
+// Example:
+//
+//	gql := ws.GraphQL().Init(map[string]interface{}{
+//		"token": "secret",
+//	})
+func (g *GraphQLWebsocket) Init(payload ...interface{}) *GraphQLWebsocket {
+	opChain := g.chain.enter("Init()")
+	defer opChain.leave()
+
+	if g.ws.checkUnusable(opChain, "Init()") {
+		return g
+	}
+
+	if len(payload) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple payload arguments"),
+			},
+		})
+		return g
+	}
+
+	msg := map[string]interface{}{
+		"type": graphqlConnectionInit,
+	}
+	if len(payload) != 0 && payload[0] != nil {
+		msg["payload"] = payload[0]
+	}
+
+	if !g.write(opChain, msg) {
+		return g
+	}
+
+	g.receive(opChain, func(msg map[string]interface{}) bool {
+		return msg["type"] == graphqlConnectionAck
+	})
+
+	return g
+}
+
+// Subscribe sends "subscribe" message and returns a new GraphQLSubscription
+// instance to inspect events of the operation.
+//
+// Optional variables are sent along with the query. Subscription ID is
+// generated automatically.
+//
+// Example:
+//
+//	sub := gql.Subscribe(`subscription($id: ID!) { user(id: $id) { name } }`,
+//		map[string]interface{}{"id": "1"})
+func (g *GraphQLWebsocket) Subscribe(
+	query string, variables ...map[string]interface{},
+) *GraphQLSubscription {
+	opChain := g.chain.enter("Subscribe()")
+	defer opChain.leave()
+
+	if g.ws.checkUnusable(opChain, "Subscribe()") {
+		return newGraphQLSubscription(opChain, g, "")
+	}
+
+	if len(variables) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple variables arguments"),
+			},
+		})
+		return newGraphQLSubscription(opChain, g, "")
+	}
+
+	g.lastID++
+	id := strconv.Itoa(g.lastID)
+
+	payload := map[string]interface{}{
+		"query": query,
+	}
+	if len(variables) != 0 && variables[0] != nil {
+		payload["variables"] = variables[0]
+	}
+
+	g.write(opChain, map[string]interface{}{
+		"id":      id,
+		"type":    graphqlSubscribe,
+		"payload": payload,
+	})
+
+	return newGraphQLSubscription(opChain, g, id)
+}
+
+func (g *GraphQLWebsocket) write(opChain *chain, msg map[string]interface{}) bool {
+	b, err := g.ws.config.JSONEncoder.Marshal(msg)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{msg},
+			Errors: []error{
+				errors.New("invalid graphql-ws message"),
+				err,
+			},
+		})
+		return false
+	}
+
+	g.ws.writeMessage(opChain, websocket.TextMessage, b)
+
+	return !opChain.failed()
+}
+
+// receive returns first message matching predicate, either from queue of
+// previously read messages or from connection. Non-matching messages are
+// queued, pings are answered.
+func (g *GraphQLWebsocket) receive(
+	opChain *chain, match func(map[string]interface{}) bool,
+) (map[string]interface{}, bool) {
+	for n, msg := range g.pending {
+		if match(msg) {
+			g.pending = append(g.pending[:n], g.pending[n+1:]...)
+			return msg, true
+		}
+	}
+
+	for {
+		wm := g.ws.readMessage(opChain)
+		if wm == nil {
+			return nil, false
+		}
+
+		if wm.typ == websocket.CloseMessage {
+			opChain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{string(wm.content)},
+				Errors: []error{
+					errors.New("expected: graphql-ws message"),
+					fmt.Errorf("websocket closed with code %d", wm.closeCode),
+				},
+			})
+			return nil, false
+		}
+
+		if wm.typ != websocket.TextMessage {
+			opChain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{wsMessageType(wm.typ)},
+				Errors: []error{
+					errors.New("expected: graphql-ws text message"),
+				},
+			})
+			return nil, false
+		}
+
+		var msg map[string]interface{}
+
+		err := g.ws.config.JSONDecoder.Unmarshal(wm.content, &msg)
+		if err == nil && msg == nil {
+			err = errors.New("message is not an object")
+		}
+
+		if err != nil {
+			opChain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{string(wm.content)},
+				Errors: []error{
+					errors.New("expected: graphql-ws message is valid json object"),
+					err,
+				},
+			})
+			return nil, false
+		}
+
+		if _, ok := msg["type"].(string); !ok {
+			opChain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{msg},
+				Errors: []error{
+					errors.New(`expected: graphql-ws message has "type" field`),
+				},
+			})
+			return nil, false
+		}
+
+		switch msg["type"] {
+		case graphqlPing:
+			if !g.write(opChain, map[string]interface{}{"type": graphqlPong}) {
+				return nil, false
+			}
+			continue
+
+		case graphqlPong:
+			continue
+		}
+
+		if match(msg) {
+			return msg, true
+		}
+
+		g.pending = append(g.pending, msg)
+	}
+}
+
+// GraphQLSubscription provides methods to inspect events of a single
+// graphql-ws subscription.
+type GraphQLSubscription struct {
+	noCopy noCopy
+	chain  *chain
+	gql    *GraphQLWebsocket
+
+	id   string
+	done bool
+}
+
+func newGraphQLSubscription(
+	parent *chain, gql *GraphQLWebsocket, id string,
+) *GraphQLSubscription {
+	return &GraphQLSubscription{
+		chain: parent.clone(),
+		gql:   gql,
+		id:    id,
+	}
+}
+
+// ID returns subscription ID sent to server.
+func (s *GraphQLSubscription) ID() string {
+	return s.id
+}
+
+// Next waits for "next" message of the subscription and returns a new
+// Object instance with its payload, i.e. execution result with "data"
+// and optional "errors" fields.
+//
+// Example:
+//
+//	sub.Next().Path("$.data.counter").IsEqual(1)
+func (s *GraphQLSubscription) Next() *Object {
+	opChain := s.chain.enter("Next()")
+	defer opChain.leave()
+
+	msg, ok := s.expect(opChain, "Next()", graphqlNext)
+	if !ok {
+		return newObject(opChain, nil)
+	}
+
+	payload, ok := msg["payload"].(map[string]interface{})
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{msg},
+			Errors: []error{
+				errors.New(`expected: "next" message payload is object`),
+			},
+		})
+		return newObject(opChain, nil)
+	}
+
+	return newObject(opChain, payload)
+}
+
+// Error waits for "error" message of the subscription and returns a new
+// Array instance with GraphQL errors from its payload.
+//
+// Example:
+//
+//	sub.Error().Value(0).Object().HasValue("message", "not found")
+func (s *GraphQLSubscription) Error() *Array {
+	opChain := s.chain.enter("Error()")
+	defer opChain.leave()
+
+	msg, ok := s.expect(opChain, "Error()", graphqlError)
+	if !ok {
+		return newArray(opChain, nil)
+	}
+
+	s.done = true
+
+	payload, ok := msg["payload"].([]interface{})
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{msg},
+			Errors: []error{
+				errors.New(`expected: "error" message payload is array`),
+			},
+		})
+		return newArray(opChain, nil)
+	}
+
+	return newArray(opChain, payload)
+}
+
+// Complete waits for "complete" message of the subscription, i.e. succeeds
+// if server finished the subscription.
+//
+// Example:
+//
+//	sub.Next()
+//	sub.Complete()
+func (s *GraphQLSubscription) Complete() *GraphQLSubscription {
+	opChain := s.chain.enter("Complete()")
+	defer opChain.leave()
+
+	if _, ok := s.expect(opChain, "Complete()", graphqlComplete); ok {
+		s.done = true
+	}
+
+	return s
+}
+
+// Stop sends "complete" message to server, i.e. asks server to finish
+// the subscription.
+//
+// Example:
+//
+//	sub.Next()
+//	sub.Stop()
+func (s *GraphQLSubscription) Stop() *GraphQLSubscription {
+	opChain := s.chain.enter("Stop()")
+	defer opChain.leave()
+
+	if s.checkUnusable(opChain, "Stop()") {
+		return s
+	}
+
+	if s.gql.write(opChain, map[string]interface{}{
+		"id":   s.id,
+		"type": graphqlComplete,
+	}) {
+		s.done = true
+	}
+
+	return s
+}
+
+func (s *GraphQLSubscription) checkUnusable(opChain *chain, where string) bool {
+	if s.gql.ws.checkUnusable(opChain, where) {
+		return true
+	}
+
+	if s.done {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected %s call for finished graphql subscription %q",
+					where, s.id),
+			},
+		})
+		return true
+	}
+
+	return false
+}
+
+func (s *GraphQLSubscription) expect(
+	opChain *chain, where string, typ string,
+) (map[string]interface{}, bool) {
+	if s.checkUnusable(opChain, where) {
+		return nil, false
+	}
+
+	msg, ok := s.gql.receive(opChain, func(msg map[string]interface{}) bool {
+		return msg["id"] == s.id
+	})
+	if !ok {
+		return nil, false
+	}
+
+	if msg["type"] != typ {
+		if msg["type"] == graphqlError || msg["type"] == graphqlComplete {
+			s.done = true
+		}
+
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{msg["type"]},
+			Expected: &AssertionValue{typ},
+			Errors: []error{
+				fmt.Errorf("expected: graphql-ws message type is %q", typ),
+				fmt.Errorf("message: %v", msg),
+			},
+		})
+		return nil, false
+	}
+
+	return msg, true
+}
@@ -0,0 +1,318 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newGraphQLWebsocketServer() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upgrader := websocket.Upgrader{
+			Subprotocols: []string{GraphQLWebsocketProtocol},
+		}
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		send := func(msg map[string]interface{}) {
+			_ = conn.WriteJSON(msg)
+		}
+
+		for {
+			var msg map[string]interface{}
+			if err := conn.ReadJSON(&msg); err != nil {
+				return
+			}
+
+			switch msg["type"] {
+			case "connection_init":
+				if payload, ok := msg["payload"].(map[string]interface{}); ok &&
+					payload["token"] == "bad" {
+					_ = conn.WriteMessage(websocket.CloseMessage,
+						websocket.FormatCloseMessage(4403, "Forbidden"))
+					return
+				}
+				send(map[string]interface{}{"type": "ping"})
+				send(map[string]interface{}{"type": "connection_ack"})
+
+			case "subscribe":
+				id := msg["id"]
+				payload := msg["payload"].(map[string]interface{})
+
+				switch payload["query"] {
+				case "counter":
+					step := 1.0
+					if vars, ok := payload["variables"].(map[string]interface{}); ok {
+						step = vars["step"].(float64)
+					}
+					for i := 1.0; i <= 2; i++ {
+						send(map[string]interface{}{
+							"id":   id,
+							"type": "next",
+							"payload": map[string]interface{}{
+								"data": map[string]interface{}{"counter": i * step},
+							},
+						})
+					}
+					send(map[string]interface{}{"id": id, "type": "complete"})
+
+				case "error":
+					send(map[string]interface{}{
+						"id":   id,
+						"type": "error",
+						"payload": []interface{}{
+							map[string]interface{}{"message": "boom"},
+						},
+					})
+
+				case "invalid":
+					_ = conn.WriteMessage(websocket.TextMessage, []byte("{"))
+				}
+
+			case "complete":
+				send(map[string]interface{}{
+					"id":   msg["id"],
+					"type": "next",
+					"payload": map[string]interface{}{
+						"data": map[string]interface{}{"stopped": msg["id"]},
+					},
+				})
+			}
+		}
+	})
+}
+
+func TestGraphQLWebsocket_Subscribe(t *testing.T) {
+	newWs := func(t *testing.T) *Websocket {
+		server := httptest.NewServer(newGraphQLWebsocketServer())
+		t.Cleanup(server.Close)
+
+		header := http.Header{"Sec-WebSocket-Protocol": {GraphQLWebsocketProtocol}}
+
+		conn, _, err := websocket.DefaultDialer.Dial(
+			"ws"+strings.TrimPrefix(server.URL, "http"), header)
+		require.NoError(t, err)
+
+		t.Cleanup(func() {
+			_ = conn.Close()
+		})
+
+		ws := NewWebsocketC(Config{
+			Reporter: newMockReporter(t),
+		}, conn)
+
+		assert.Equal(t, GraphQLWebsocketProtocol, ws.Subprotocol().Raw())
+
+		return ws
+	}
+
+	t.Run("next and complete", func(t *testing.T) {
+		ws := newWs(t)
+
+		gql := ws.GraphQL().Init()
+		gql.chain.assert(t, success)
+
+		sub := gql.Subscribe("counter")
+		assert.Equal(t, "1", sub.ID())
+
+		sub.Next().Path("$.data.counter").IsEqual(1)
+		sub.Next().Path("$.data.counter").IsEqual(2)
+		sub.Complete()
+		sub.chain.assert(t, success)
+
+		sub.Next()
+		sub.chain.assert(t, failure)
+	})
+
+	t.Run("variables", func(t *testing.T) {
+		ws := newWs(t)
+
+		sub := ws.GraphQL().Init().Subscribe("counter",
+			map[string]interface{}{"step": 10})
+
+		sub.Next().Path("$.data.counter").IsEqual(10)
+		sub.Next().Path("$.data.counter").IsEqual(20)
+		sub.Complete()
+		sub.chain.assert(t, success)
+	})
+
+	t.Run("interleaved", func(t *testing.T) {
+		ws := newWs(t)
+
+		gql := ws.GraphQL().Init()
+
+		sub1 := gql.Subscribe("counter")
+		sub2 := gql.Subscribe("counter", map[string]interface{}{"step": 5})
+
+		sub2.Next().Path("$.data.counter").IsEqual(5)
+		sub2.Next().Path("$.data.counter").IsEqual(10)
+		sub2.Complete()
+
+		sub1.Next().Path("$.data.counter").IsEqual(1)
+		sub1.Next().Path("$.data.counter").IsEqual(2)
+		sub1.Complete()
+
+		sub1.chain.assert(t, success)
+		sub2.chain.assert(t, success)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		ws := newWs(t)
+
+		sub := ws.GraphQL().Init().Subscribe("error")
+
+		sub.Error().IsEqual([]interface{}{
+			map[string]interface{}{"message": "boom"},
+		})
+		sub.chain.assert(t, success)
+	})
+
+	t.Run("unexpected error", func(t *testing.T) {
+		ws := newWs(t)
+
+		sub := ws.GraphQL().Init().Subscribe("error")
+
+		sub.Next()
+		sub.chain.assert(t, failure)
+	})
+
+	t.Run("unexpected next", func(t *testing.T) {
+		ws := newWs(t)
+
+		sub := ws.GraphQL().Init().Subscribe("counter")
+
+		sub.Complete()
+		sub.chain.assert(t, failure)
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		ws := newWs(t)
+
+		gql := ws.GraphQL().Init()
+
+		sub := gql.Subscribe("none")
+		sub.Stop()
+		sub.chain.assert(t, success)
+
+		sub.Stop()
+		sub.chain.assert(t, failure)
+	})
+
+	t.Run("invalid message", func(t *testing.T) {
+		ws := newWs(t)
+
+		sub := ws.GraphQL().Init().Subscribe("invalid")
+
+		sub.Next()
+		sub.chain.assert(t, failure)
+	})
+
+	t.Run("init rejected", func(t *testing.T) {
+		ws := newWs(t)
+
+		gql := ws.GraphQL().Init(map[string]interface{}{"token": "bad"})
+		gql.chain.assert(t, failure)
+	})
+}
+
+func TestGraphQLWebsocket_Messages(t *testing.T) {
+	var written []map[string]interface{}
+
+	conn := &mockGraphQLConn{
+		onWrite: func(data []byte) {
+			var msg map[string]interface{}
+			require.NoError(t, json.Unmarshal(data, &msg))
+			written = append(written, msg)
+		},
+		read: []string{
+			`{"type":"connection_ack"}`,
+		},
+	}
+
+	ws := NewWebsocketC(Config{
+		Reporter: newMockReporter(t),
+	}, conn)
+
+	gql := ws.GraphQL().Init(map[string]interface{}{"token": "secret"})
+	gql.Subscribe("subscription { a }", map[string]interface{}{"x": 1})
+	gql.chain.assert(t, success)
+
+	assert.Equal(t, []map[string]interface{}{
+		{
+			"type":    "connection_init",
+			"payload": map[string]interface{}{"token": "secret"},
+		},
+		{
+			"id":   "1",
+			"type": "subscribe",
+			"payload": map[string]interface{}{
+				"query":     "subscription { a }",
+				"variables": map[string]interface{}{"x": 1.0},
+			},
+		},
+	}, written)
+}
+
+func TestGraphQLWebsocket_Usage(t *testing.T) {
+	t.Run("nil conn", func(t *testing.T) {
+		ws := NewWebsocketC(Config{
+			Reporter: newMockReporter(t),
+		}, nil)
+
+		gql := ws.GraphQL()
+		gql.chain.assert(t, failure)
+	})
+
+	t.Run("multiple payloads", func(t *testing.T) {
+		ws := NewWebsocketC(Config{
+			Reporter: newMockReporter(t),
+		}, &mockGraphQLConn{})
+
+		gql := ws.GraphQL().Init("a", "b")
+		gql.chain.assert(t, failure)
+	})
+
+	t.Run("multiple variables", func(t *testing.T) {
+		ws := NewWebsocketC(Config{
+			Reporter: newMockReporter(t),
+		}, &mockGraphQLConn{})
+
+		sub := ws.GraphQL().Subscribe("query",
+			map[string]interface{}{}, map[string]interface{}{})
+		sub.chain.assert(t, failure)
+	})
+}
+
+type mockGraphQLConn struct {
+	mockWebsocketConn
+	read    []string
+	onWrite func([]byte)
+}
+
+func (mc *mockGraphQLConn) ReadMessage() (int, []byte, error) {
+	if len(mc.read) == 0 {
+		return websocket.CloseMessage, nil, &websocket.CloseError{
+			Code: websocket.CloseNormalClosure,
+		}
+	}
+	msg := mc.read[0]
+	mc.read = mc.read[1:]
+	return websocket.TextMessage, []byte(msg), nil
+}
+
+func (mc *mockGraphQLConn) WriteMessage(typ int, data []byte) error {
+	if mc.onWrite != nil {
+		mc.onWrite(data)
+	}
+	return nil
+}
@@ -0,0 +1,501 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// HAL provides methods to inspect HAL (Hypertext Application Language)
+// resource and to follow its links.
+//
+// See https://datatracker.ietf.org/doc/html/draft-kelly-json-hal for the
+// specification.
+type HAL struct {
+	noCopy noCopy
+	config Config
+	chain  *chain
+	origin *Expect
+	base   *url.URL
+	value  map[string]interface{}
+}
+
+// HAL returns a new HAL instance with response body decoded as HAL resource.
+//
+// HAL succeeds if Content-Type header is "application/hal+json" and body is
+// a JSON object. Expected media type can be overridden via ContentOpts.
+//
+// Links of the resource can be followed using FollowRel, which issues a new
+// request through the same Expect instance that sent this response, so that
+// tests don't need to hard-code URLs.
+//
+// Example:
+//
+//	hal := e.GET("/orders").Expect().Status(http.StatusOK).HAL()
+//	hal.Link("self").IsEqual("/orders")
+//
+//	hal.FollowRel("next").
+//		Expect().
+//		Status(http.StatusOK).
+//		HAL().Embedded("orders").Array().NotEmpty()
+func (r *Response) HAL(options ...ContentOpts) *HAL {
+	opChain := r.chain.enter("HAL()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newHAL(opChain, r.config, nil, nil, nil)
+	}
+
+	if len(options) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple options arguments"),
+			},
+		})
+		return newHAL(opChain, r.config, nil, nil, nil)
+	}
+
+	if !r.checkContentOptions(opChain, options, "application/hal+json") {
+		return newHAL(opChain, r.config, nil, nil, nil)
+	}
+
+	content, ok := r.getContent(opChain, "HAL()")
+	if !ok {
+		return newHAL(opChain, r.config, nil, nil, nil)
+	}
+
+	value, ok := r.decodeJSON(opChain, content, content)
+	if !ok {
+		return newHAL(opChain, r.config, nil, nil, nil)
+	}
+
+	resource, ok := value.(map[string]interface{})
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				errors.New("expected: HAL resource is an object"),
+			},
+		})
+		return newHAL(opChain, r.config, nil, nil, nil)
+	}
+
+	var base *url.URL
+	if r.httpResp.Request != nil {
+		base = r.httpResp.Request.URL
+	}
+
+	return newHAL(opChain, r.config, r.origin, base, resource)
+}
+
+func newHAL(
+	parent *chain, config Config, origin *Expect, base *url.URL,
+	value map[string]interface{},
+) *HAL {
+	return &HAL{
+		config: config,
+		chain:  parent.clone(),
+		origin: origin,
+		base:   base,
+		value:  value,
+	}
+}
+
+// Alias is similar to Value.Alias.
+func (h *HAL) Alias(name string) *HAL {
+	opChain := h.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	h.chain.setAlias(name)
+	return h
+}
+
+// Resource returns a new Object instance with the whole resource.
+func (h *HAL) Resource() *Object {
+	opChain := h.chain.enter("Resource()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	return newObject(opChain, h.value)
+}
+
+// Links returns a new Object instance with "_links" of the resource.
+// If resource has no links, empty object is returned.
+//
+// Example:
+//
+//	hal.Links().ContainsKey("self")
+func (h *HAL) Links() *Object {
+	opChain := h.chain.enter("Links()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	links, ok := h.links(opChain)
+	if !ok {
+		return newObject(opChain, nil)
+	}
+
+	return newObject(opChain, links)
+}
+
+// Link returns a new String instance with "href" of link with given
+// relation. If there are multiple links with this relation, the first
+// one is used.
+//
+// Example:
+//
+//	hal.Link("self").IsEqual("/orders/1")
+func (h *HAL) Link(rel string) *String {
+	opChain := h.chain.enter("Link(%q)", rel)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	link, ok := h.link(opChain, rel)
+	if !ok {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, link["href"].(string))
+}
+
+// HasLink succeeds if resource has link with given relation.
+//
+// Example:
+//
+//	hal.HasLink("next")
+func (h *HAL) HasLink(rel string) *HAL {
+	opChain := h.chain.enter("HasLink(%q)", rel)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return h
+	}
+
+	h.link(opChain, rel)
+
+	return h
+}
+
+// NotHasLink succeeds if resource has no link with given relation.
+//
+// Example:
+//
+//	hal.NotHasLink("next")
+func (h *HAL) NotHasLink(rel string) *HAL {
+	opChain := h.chain.enter("NotHasLink(%q)", rel)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return h
+	}
+
+	links, ok := h.links(opChain)
+	if !ok {
+		return h
+	}
+
+	if _, ok := links[rel]; ok {
+		opChain.fail(AssertionFailure{
+			Type:     AssertNotContainsKey,
+			Actual:   &AssertionValue{links},
+			Expected: &AssertionValue{rel},
+			Errors: []error{
+				fmt.Errorf("expected: resource has no %q link", rel),
+			},
+		})
+	}
+
+	return h
+}
+
+// Embedded returns a new Value instance with embedded resource or array
+// of resources from "_embedded" with given relation.
+//
+// Example:
+//
+//	hal.Embedded("orders").Array().Length().IsEqual(2)
+func (h *HAL) Embedded(rel string) *Value {
+	opChain := h.chain.enter("Embedded(%q)", rel)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newValue(opChain, nil)
+	}
+
+	embedded, _ := h.value["_embedded"].(map[string]interface{})
+
+	value, ok := embedded[rel]
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{h.value["_embedded"]},
+			Expected: &AssertionValue{rel},
+			Errors: []error{
+				fmt.Errorf("expected: resource has embedded %q", rel),
+			},
+		})
+		return newValue(opChain, nil)
+	}
+
+	return newValue(opChain, value)
+}
+
+// FollowRel returns a new GET Request for link with given relation.
+//
+// Request is created by the same Expect instance that sent this response,
+// so all its builders and matchers are applied. Relative links are
+// resolved against URL of the request that produced this response.
+//
+// If link is templated, optional variables are used to expand it.
+// Simple "{var}" expressions and "{?var,...}" and "{&var,...}" query
+// expressions are supported.
+//
+// Fails if response was not created by Expect instance.
+//
+// Example:
+//
+//	hal.FollowRel("next").Expect().Status(http.StatusOK)
+//
+//	hal.FollowRel("find", map[string]interface{}{"id": 42}).
+//		Expect().
+//		Status(http.StatusOK)
+func (h *HAL) FollowRel(rel string, vars ...map[string]interface{}) *Request {
+	opChain := h.chain.enter("FollowRel(%q)", rel)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newRequest(followChain(opChain), h.config, http.MethodGet, "")
+	}
+
+	if len(vars) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple vars arguments"),
+			},
+		})
+		return newRequest(followChain(opChain), h.config, http.MethodGet, "")
+	}
+
+	if h.origin == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected FollowRel() call for response" +
+					" that was not created by Expect"),
+			},
+		})
+		return newRequest(followChain(opChain), h.config, http.MethodGet, "")
+	}
+
+	link, ok := h.link(opChain, rel)
+	if !ok {
+		return newRequest(followChain(opChain), h.config, http.MethodGet, "")
+	}
+
+	href := link["href"].(string)
+
+	if templated, _ := link["templated"].(bool); templated {
+		var v map[string]interface{}
+		if len(vars) != 0 {
+			v = vars[0]
+		}
+
+		expanded, err := expandURITemplate(href, v)
+		if err != nil {
+			opChain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{href},
+				Errors: []error{
+					errors.New("expected: valid link template"),
+					err,
+				},
+			})
+			return newRequest(followChain(opChain), h.config, http.MethodGet, "")
+		}
+
+		href = expanded
+	}
+
+	return followLink(opChain, h.origin, h.base, href)
+}
+
+// followLink returns GET request to href created by origin.
+// Relative href is resolved against base URL, if it's known, or appended
+// to base URL of origin otherwise.
+func followLink(opChain *chain, origin *Expect, base *url.URL, href string) *Request {
+	target, err := url.Parse(href)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{href},
+			Errors: []error{
+				errors.New("expected: valid link href"),
+				err,
+			},
+		})
+		return newRequest(followChain(opChain), origin.config, http.MethodGet, "")
+	}
+
+	if base == nil && !target.IsAbs() {
+		// no request URL, path is appended to base URL of Expect
+		return origin.newRequest(followChain(opChain), http.MethodGet, href)
+	}
+
+	if base != nil {
+		target = base.ResolveReference(target)
+	}
+
+	return origin.newRequest(followChain(opChain), http.MethodGet, "").
+		WithURL(target.String())
+}
+
+// followChain returns chain for request created by FollowRel or NextPage.
+// Request gets its own context, but its failures are propagated to opChain.
+func followChain(opChain *chain) *chain {
+	reqChain := opChain.clone()
+	reqChain.clearRequest()
+
+	return reqChain
+}
+
+func (h *HAL) links(opChain *chain) (map[string]interface{}, bool) {
+	value, ok := h.value["_links"]
+	if !ok {
+		return map[string]interface{}{}, true
+	}
+
+	links, ok := value.(map[string]interface{})
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				errors.New(`expected: "_links" is an object`),
+			},
+		})
+		return nil, false
+	}
+
+	return links, true
+}
+
+func (h *HAL) link(opChain *chain, rel string) (map[string]interface{}, bool) {
+	links, ok := h.links(opChain)
+	if !ok {
+		return nil, false
+	}
+
+	value, ok := links[rel]
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{links},
+			Expected: &AssertionValue{rel},
+			Errors: []error{
+				fmt.Errorf("expected: resource has %q link", rel),
+			},
+		})
+		return nil, false
+	}
+
+	if arr, ok := value.([]interface{}); ok && len(arr) != 0 {
+		value = arr[0]
+	}
+
+	link, ok := value.(map[string]interface{})
+	if ok {
+		_, ok = link["href"].(string)
+	}
+
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				fmt.Errorf(`expected: %q link is an object with "href" string`, rel),
+			},
+		})
+		return nil, false
+	}
+
+	return link, true
+}
+
+// expandURITemplate expands subset of RFC 6570 URI template: simple
+// string expansion ("{var}") and form-style query expansion ("{?var}"
+// and "{&var}"). Undefined variables are omitted.
+func expandURITemplate(tmpl string, vars map[string]interface{}) (string, error) {
+	var sb strings.Builder
+
+	for {
+		start := strings.IndexByte(tmpl, '{')
+		if start < 0 {
+			break
+		}
+
+		end := strings.IndexByte(tmpl[start:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("unclosed expression at offset %d", start)
+		}
+		end += start
+
+		sb.WriteString(tmpl[:start])
+
+		expr := tmpl[start+1 : end]
+		tmpl = tmpl[end+1:]
+
+		var op byte
+		if expr != "" && (expr[0] == '?' || expr[0] == '&') {
+			op = expr[0]
+			expr = expr[1:]
+		}
+
+		if expr == "" {
+			return "", errors.New("empty expression")
+		}
+
+		names := strings.Split(expr, ",")
+
+		if op == 0 {
+			parts := make([]string, 0, len(names))
+			for _, name := range names {
+				if v, ok := vars[name]; ok {
+					parts = append(parts, url.PathEscape(fmt.Sprint(v)))
+				}
+			}
+			sb.WriteString(strings.Join(parts, ","))
+			continue
+		}
+
+		sep := string(op)
+		for _, name := range names {
+			v, ok := vars[name]
+			if !ok {
+				continue
+			}
+			sb.WriteString(sep)
+			sb.WriteString(url.QueryEscape(name))
+			sb.WriteByte('=')
+			sb.WriteString(url.QueryEscape(fmt.Sprint(v)))
+			sep = "&"
+		}
+	}
+
+	sb.WriteString(tmpl)
+
+	return sb.String(), nil
+}
@@ -0,0 +1,316 @@
+package httpexpect
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newHALHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	write := func(w http.ResponseWriter, body string) {
+		w.Header().Set("Content-Type", "application/hal+json")
+		_, _ = w.Write([]byte(body))
+	}
+
+	mux.HandleFunc("/api/orders", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			write(w, `{
+				"_links": {
+					"self": {"href": "/api/orders?page=2"},
+					"prev": {"href": "orders"}
+				},
+				"_embedded": {"orders": [{"id": 3}]}
+			}`)
+			return
+		}
+		write(w, `{
+			"_links": {
+				"self": {"href": "/api/orders"},
+				"next": {"href": "orders?page=2"},
+				"find": {"href": "/api/orders/{id}{?fields}", "templated": true},
+				"curies": [{"name": "acme", "href": "/docs/{rel}", "templated": true}],
+				"external": {"href": "http://other.example.com/api/orders/1"}
+			},
+			"_embedded": {"orders": [{"id": 1}, {"id": 2}]},
+			"total": 3
+		}`)
+	})
+
+	mux.HandleFunc("/api/orders/1", func(w http.ResponseWriter, r *http.Request) {
+		write(w, `{
+			"_links": {"self": {"href": "/api/orders/1"}},
+			"id": 1,
+			"host": "`+r.Host+`",
+			"fields": "`+r.URL.Query().Get("fields")+`",
+			"token": "`+r.Header.Get("X-Token")+`"
+		}`)
+	})
+
+	return mux
+}
+
+func newHALExpect(t *testing.T) *Expect {
+	return WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: newMockReporter(t),
+		Client: &http.Client{
+			Transport: NewBinder(newHALHandler()),
+		},
+	})
+}
+
+func TestHAL_Resource(t *testing.T) {
+	e := newHALExpect(t)
+
+	hal := e.GET("/api/orders").Expect().HAL()
+
+	hal.Resource().HasValue("total", 3)
+	hal.Links().ContainsKey("self")
+	hal.Link("self").IsEqual("/api/orders")
+	hal.Link("curies").IsEqual("/docs/{rel}")
+	hal.HasLink("next")
+	hal.NotHasLink("prev")
+	hal.Embedded("orders").Array().Length().IsEqual(2)
+	hal.chain.assert(t, success)
+
+	hal.Link("missing")
+	hal.chain.assert(t, failure)
+
+	hal.chain.clear()
+
+	hal.Embedded("missing")
+	hal.chain.assert(t, failure)
+
+	hal.chain.clear()
+
+	hal.NotHasLink("next")
+	hal.chain.assert(t, failure)
+}
+
+func TestHAL_FollowRel(t *testing.T) {
+	t.Run("relative link", func(t *testing.T) {
+		e := newHALExpect(t)
+
+		page2 := e.GET("/api/orders").Expect().HAL().FollowRel("next").Expect().HAL()
+
+		page2.Link("self").IsEqual("/api/orders?page=2")
+		page2.Embedded("orders").Array().Length().IsEqual(1)
+		page2.chain.assert(t, success)
+
+		page1 := page2.FollowRel("prev").Expect().HAL()
+		page1.Link("self").IsEqual("/api/orders")
+		page1.chain.assert(t, success)
+	})
+
+	t.Run("templated link", func(t *testing.T) {
+		e := newHALExpect(t)
+
+		resp := e.GET("/api/orders").Expect().HAL().
+			FollowRel("find", map[string]interface{}{"id": 1, "fields": "id,total"}).
+			Expect()
+
+		resp.Status(http.StatusOK)
+		resp.HAL().Resource().HasValue("id", 1).HasValue("fields", "id,total")
+		resp.chain.assert(t, success)
+	})
+
+	t.Run("absolute link", func(t *testing.T) {
+		e := newHALExpect(t)
+
+		resp := e.GET("/api/orders").Expect().HAL().FollowRel("external").Expect()
+
+		resp.HAL().Resource().HasValue("host", "other.example.com")
+		resp.chain.assert(t, success)
+	})
+
+	t.Run("builders", func(t *testing.T) {
+		e := newHALExpect(t).Builder(func(req *Request) {
+			req.WithHeader("X-Token", "secret")
+		})
+
+		resp := e.GET("/api/orders").Expect().HAL().
+			FollowRel("find", map[string]interface{}{"id": 1}).
+			Expect()
+
+		resp.HAL().Resource().HasValue("token", "secret")
+		resp.chain.assert(t, success)
+	})
+
+	t.Run("missing link", func(t *testing.T) {
+		e := newHALExpect(t)
+
+		hal := e.GET("/api/orders").Expect().HAL()
+
+		req := hal.FollowRel("missing")
+		req.chain.assert(t, failure)
+		hal.chain.assert(t, failure)
+	})
+
+	t.Run("not created by expect", func(t *testing.T) {
+		resp := NewResponse(newMockReporter(t), &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/hal+json"}},
+			Body:       newMockBody(`{"_links":{"next":{"href":"/next"}}}`),
+		})
+
+		hal := resp.HAL()
+		hal.chain.assert(t, success)
+
+		hal.FollowRel("next")
+		hal.chain.assert(t, failure)
+	})
+
+	t.Run("multiple vars", func(t *testing.T) {
+		e := newHALExpect(t)
+
+		hal := e.GET("/api/orders").Expect().HAL()
+
+		hal.FollowRel("find", map[string]interface{}{}, map[string]interface{}{})
+		hal.chain.assert(t, failure)
+	})
+}
+
+func TestHAL_Invalid(t *testing.T) {
+	cases := []struct {
+		name        string
+		contentType string
+		body        string
+		options     []ContentOpts
+		result      chainResult
+	}{
+		{
+			name:        "custom media type",
+			contentType: "application/json",
+			body:        `{}`,
+			options:     []ContentOpts{{MediaType: "application/json"}},
+			result:      success,
+		},
+		{
+			name:        "wrong media type",
+			contentType: "application/json",
+			body:        `{}`,
+			result:      failure,
+		},
+		{
+			name:        "not object",
+			contentType: "application/hal+json",
+			body:        `[]`,
+			result:      failure,
+		},
+		{
+			name:        "multiple options",
+			contentType: "application/hal+json",
+			body:        `{}`,
+			options:     []ContentOpts{{}, {}},
+			result:      failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := NewResponse(newMockReporter(t), &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": {tc.contentType}},
+				Body:       newMockBody(tc.body),
+			})
+
+			hal := resp.HAL(tc.options...)
+			hal.chain.assert(t, tc.result)
+		})
+	}
+
+	t.Run("invalid link", func(t *testing.T) {
+		resp := NewResponse(newMockReporter(t), &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/hal+json"}},
+			Body:       newMockBody(`{"_links":{"self":{"title":"no href"}}}`),
+		})
+
+		hal := resp.HAL()
+		hal.Link("self")
+		hal.chain.assert(t, failure)
+	})
+}
+
+func TestHAL_ExpandURITemplate(t *testing.T) {
+	cases := []struct {
+		tmpl   string
+		vars   map[string]interface{}
+		result string
+		err    bool
+	}{
+		{
+			tmpl:   "/orders",
+			result: "/orders",
+		},
+		{
+			tmpl:   "/orders/{id}",
+			vars:   map[string]interface{}{"id": 42},
+			result: "/orders/42",
+		},
+		{
+			tmpl:   "/orders/{id}",
+			vars:   map[string]interface{}{"id": "a b/c"},
+			result: "/orders/a%20b%2Fc",
+		},
+		{
+			tmpl:   "/orders{?page,size}",
+			vars:   map[string]interface{}{"page": 2, "size": 10},
+			result: "/orders?page=2&size=10",
+		},
+		{
+			tmpl:   "/orders{?page,size}",
+			vars:   map[string]interface{}{"size": 10},
+			result: "/orders?size=10",
+		},
+		{
+			tmpl:   "/orders?sort=id{&page}",
+			vars:   map[string]interface{}{"page": 3},
+			result: "/orders?sort=id&page=3",
+		},
+		{
+			tmpl:   "/orders/{id}{?fields}",
+			result: "/orders/",
+		},
+		{
+			tmpl: "/orders/{id",
+			err:  true,
+		},
+		{
+			tmpl: "/orders/{}",
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.tmpl, func(t *testing.T) {
+			result, err := expandURITemplate(tc.tmpl, tc.vars)
+
+			if tc.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.result, result)
+			}
+		})
+	}
+}
+
+func TestHAL_FailedChain(t *testing.T) {
+	chain := newMockChain(t, flagFailed)
+
+	hal := newHAL(chain, newMockConfig(newMockReporter(t)), nil, nil, nil)
+	hal.chain.assert(t, failure)
+
+	hal.Alias("foo")
+	hal.Resource().chain.assert(t, failure)
+	hal.Links().chain.assert(t, failure)
+	hal.Link("self").chain.assert(t, failure)
+	hal.HasLink("self")
+	hal.NotHasLink("self")
+	hal.Embedded("foo").chain.assert(t, failure)
+	hal.FollowRel("self").chain.assert(t, failure)
+}
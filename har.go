@@ -0,0 +1,260 @@
+package httpexpect
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// HAR defines HTTP Archive, a JSON format used by browsers and proxies
+// to export captured HTTP traffic.
+//
+// Only fields needed to replay requests are defined, other fields
+// are ignored when HAR is loaded.
+//
+// See http://www.softwareishard.com/blog/har-12-spec/
+type HAR struct {
+	Log HARLog `json:"log"`
+}
+
+// HARLog defines root object of HAR.
+type HARLog struct {
+	Version string     `json:"version"`
+	Creator HARCreator `json:"creator"`
+	Entries []HAREntry `json:"entries"`
+}
+
+// HARCreator defines application which created HAR.
+type HARCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// HAREntry defines a single captured request and its response.
+type HAREntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         HARRequest  `json:"request"`
+	Response        HARResponse `json:"response"`
+}
+
+// HARRequest defines captured request.
+type HARRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []HARNameValue `json:"headers"`
+	QueryString []HARNameValue `json:"queryString"`
+	Cookies     []HARNameValue `json:"cookies"`
+	PostData    *HARPostData   `json:"postData,omitempty"`
+}
+
+// HARResponse defines captured response.
+type HARResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []HARNameValue `json:"headers"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Content     HARContent     `json:"content"`
+}
+
+// HARNameValue defines header, query parameter, or cookie.
+type HARNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// HARPostData defines request body.
+type HARPostData struct {
+	MimeType string     `json:"mimeType"`
+	Text     string     `json:"text"`
+	Params   []HARParam `json:"params,omitempty"`
+}
+
+// HARParam defines posted form parameter.
+type HARParam struct {
+	Name        string `json:"name"`
+	Value       string `json:"value,omitempty"`
+	FileName    string `json:"fileName,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+}
+
+// HARContent defines response body.
+type HARContent struct {
+	Size     int64  `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+// LoadHAR reads HAR from a file.
+//
+// Example:
+//
+//	har, err := httpexpect.LoadHAR("testdata/checkout.har")
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//
+//	for _, entry := range har.Log.Entries {
+//		e.FromHAR(entry).
+//			Expect().
+//			Status(entry.Response.Status)
+//	}
+func LoadHAR(path string) (*HAR, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var har HAR
+
+	if err := json.Unmarshal(data, &har); err != nil {
+		return nil, fmt.Errorf("can't parse HAR file %q: %w", path, err)
+	}
+
+	return &har, nil
+}
+
+// Headers which are not copied from HAR entry, because they are either
+// set automatically, or would break replaying.
+var harSkipHeaders = map[string]bool{
+	"Host":              true,
+	"Content-Length":    true,
+	"Connection":        true,
+	"Accept-Encoding":   true,
+	"Transfer-Encoding": true,
+	"Keep-Alive":        true,
+	"Upgrade":           true,
+}
+
+// FromHAR returns a new Request instance constructed from HAR entry.
+//
+// Request method, URL, headers, and body are copied from the entry.
+// Cookies are sent as is, via "Cookie" header copied from the entry.
+// HTTP/2 pseudo-headers and headers which are set automatically or would
+// break replaying (like "Host", "Content-Length", and "Accept-Encoding")
+// are skipped.
+//
+// If Config.BaseURL is set, only path and query are taken from the entry
+// URL, and request is sent to BaseURL. This allows replaying traffic
+// captured in production against a test server. Otherwise, full entry
+// URL is used.
+//
+// Path escaping and query are kept exactly as recorded. Query parameters
+// added using WithQuery are appended to recorded ones.
+//
+// Builders and matchers attached via Expect.Builder and Expect.Matcher are
+// applied to the request the same way as for Expect.Request, so builders
+// can be used to modify captured data, e.g. to replace authentication.
+//
+// Example:
+//
+//	har, _ := httpexpect.LoadHAR("testdata/checkout.har")
+//
+//	e := httpexpect.Default(t, server.URL)
+//
+//	e.FromHAR(har.Log.Entries[0]).
+//		Expect().
+//		Status(http.StatusOK)
+func (e *Expect) FromHAR(entry HAREntry) *Request {
+	opChain := e.chain.enter("FromHAR(%q, %q)", entry.Request.Method, entry.Request.URL)
+	defer opChain.leave()
+
+	method := entry.Request.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	u, err := url.Parse(entry.Request.URL)
+	if err != nil || (e.config.BaseURL == "" && !u.IsAbs()) {
+		if err == nil {
+			err = errors.New("url is not absolute")
+		}
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{entry.Request.URL},
+			Errors: []error{
+				errors.New("invalid url in HAR entry"),
+				err,
+			},
+		})
+		return newRequest(opChain, e.config, method, "")
+	}
+
+	var body []byte
+
+	if postData := entry.Request.PostData; postData != nil {
+		if postData.Text != "" {
+			body = []byte(postData.Text)
+		} else if len(postData.Params) != 0 {
+			form := url.Values{}
+			for _, p := range postData.Params {
+				form.Add(p.Name, p.Value)
+			}
+			body = []byte(form.Encode())
+		}
+	}
+
+	// path is escaped, so that path parameters are not interpolated
+	req := e.newRequest(opChain, method, u.EscapedPath())
+
+	req.mu.Lock()
+	req.pathEscaped = true
+	req.rawQuery = u.RawQuery
+	req.mu.Unlock()
+
+	if e.config.BaseURL == "" {
+		req.WithURL((&url.URL{
+			Scheme: u.Scheme,
+			User:   u.User,
+			Host:   u.Host,
+		}).String())
+	}
+
+	hasContentType := false
+
+	for _, h := range entry.Request.Headers {
+		name := http.CanonicalHeaderKey(h.Name)
+
+		if strings.HasPrefix(name, ":") || harSkipHeaders[name] {
+			continue
+		}
+
+		if name == "Content-Type" {
+			hasContentType = true
+		}
+
+		req.WithHeader(name, h.Value)
+	}
+
+	if body != nil {
+		if !hasContentType && entry.Request.PostData.MimeType != "" {
+			req.WithHeader("Content-Type", entry.Request.PostData.MimeType)
+		}
+
+		req.WithBytes(body)
+	}
+
+	return req
+}
+
+// ResponseBody returns decoded response body from HAR entry.
+//
+// Response body is optional in HAR, so it may be empty even if
+// original response had body.
+func (entry *HAREntry) ResponseBody() ([]byte, error) {
+	content := entry.Response.Content
+
+	if content.Encoding == "base64" {
+		return base64.StdEncoding.DecodeString(content.Text)
+	}
+
+	return []byte(content.Text), nil
+}
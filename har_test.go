@@ -0,0 +1,314 @@
+package httpexpect
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const harTestData = `{
+  "log": {
+    "version": "1.2",
+    "creator": {"name": "WebInspector", "version": "537.36"},
+    "entries": [
+      {
+        "startedDateTime": "2023-01-01T00:00:00.000Z",
+        "time": 12.5,
+        "request": {
+          "method": "POST",
+          "url": "https://prod.example.com/api/users?lang=en&x=1",
+          "httpVersion": "HTTP/2.0",
+          "headers": [
+            {"name": ":authority", "value": "prod.example.com"},
+            {"name": "content-type", "value": "application/json"},
+            {"name": "accept-encoding", "value": "gzip, br"},
+            {"name": "content-length", "value": "16"},
+            {"name": "x-request-id", "value": "abc"},
+            {"name": "cookie", "value": "session=123"}
+          ],
+          "queryString": [
+            {"name": "lang", "value": "en"},
+            {"name": "x", "value": "1"}
+          ],
+          "cookies": [
+            {"name": "session", "value": "123"}
+          ],
+          "postData": {
+            "mimeType": "application/json",
+            "text": "{\"name\":\"john\"}"
+          }
+        },
+        "response": {
+          "status": 201,
+          "statusText": "Created",
+          "httpVersion": "HTTP/2.0",
+          "headers": [],
+          "cookies": [],
+          "content": {
+            "size": 11,
+            "mimeType": "application/json",
+            "text": "eyJpZCI6MX0=",
+            "encoding": "base64"
+          }
+        }
+      },
+      {
+        "request": {
+          "method": "POST",
+          "url": "https://prod.example.com/login",
+          "headers": [],
+          "postData": {
+            "mimeType": "application/x-www-form-urlencoded",
+            "params": [
+              {"name": "user", "value": "john"},
+              {"name": "password", "value": "secret"}
+            ]
+          }
+        },
+        "response": {
+          "status": 200,
+          "content": {"text": "ok"}
+        }
+      }
+    ]
+  }
+}`
+
+func loadTestHAR(t *testing.T) *HAR {
+	path := filepath.Join(t.TempDir(), "test.har")
+	require.NoError(t, os.WriteFile(path, []byte(harTestData), 0o600))
+
+	har, err := LoadHAR(path)
+	require.NoError(t, err)
+
+	return har
+}
+
+func TestHAR_Load(t *testing.T) {
+	har := loadTestHAR(t)
+
+	assert.Equal(t, "1.2", har.Log.Version)
+	assert.Equal(t, "WebInspector", har.Log.Creator.Name)
+	require.Equal(t, 2, len(har.Log.Entries))
+
+	entry := har.Log.Entries[0]
+
+	assert.Equal(t, "POST", entry.Request.Method)
+	assert.Equal(t, 201, entry.Response.Status)
+	assert.Equal(t, 6, len(entry.Request.Headers))
+
+	body, err := entry.ResponseBody()
+	require.NoError(t, err)
+	assert.Equal(t, `{"id":1}`, string(body))
+
+	body, err = har.Log.Entries[1].ResponseBody()
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(body))
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := LoadHAR(filepath.Join(t.TempDir(), "missing.har"))
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "invalid.har")
+		require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+
+		_, err := LoadHAR(path)
+		assert.Error(t, err)
+	})
+}
+
+func TestHAR_FromHAR(t *testing.T) {
+	har := loadTestHAR(t)
+
+	var (
+		lastReq  *http.Request
+		lastBody []byte
+	)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastReq = r
+		lastBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	t.Run("base url", func(t *testing.T) {
+		e := WithConfig(Config{
+			BaseURL:  "http://test.example.com",
+			Reporter: newMockReporter(t),
+			Client: &http.Client{
+				Transport: NewBinder(handler),
+			},
+		})
+
+		req := e.FromHAR(har.Log.Entries[0])
+		req.chain.assert(t, success)
+
+		req.Expect().Status(http.StatusCreated)
+
+		require.NotNil(t, lastReq)
+		assert.Equal(t, "POST", lastReq.Method)
+		assert.Equal(t, "test.example.com", lastReq.Host)
+		assert.Equal(t, "/api/users", lastReq.URL.Path)
+		assert.Equal(t, "lang=en&x=1", lastReq.URL.RawQuery)
+
+		assert.Equal(t, "application/json", lastReq.Header.Get("Content-Type"))
+		assert.Equal(t, "abc", lastReq.Header.Get("X-Request-Id"))
+		assert.Equal(t, "session=123", lastReq.Header.Get("Cookie"))
+		assert.Empty(t, lastReq.Header.Get("Accept-Encoding"))
+		assert.Empty(t, lastReq.Header.Get(":authority"))
+
+		assert.Equal(t, `{"name":"john"}`, string(lastBody))
+	})
+
+	t.Run("full url", func(t *testing.T) {
+		e := WithConfig(Config{
+			Reporter: newMockReporter(t),
+			Client: &http.Client{
+				Transport: NewBinder(handler),
+			},
+		})
+
+		req := e.FromHAR(har.Log.Entries[0])
+		req.chain.assert(t, success)
+
+		req.Expect().Status(http.StatusCreated)
+
+		require.NotNil(t, lastReq)
+		assert.Equal(t, "https://prod.example.com/api/users?lang=en&x=1", lastReq.URL.String())
+		assert.Equal(t, "/api/users", lastReq.URL.Path)
+		assert.Equal(t, "lang=en&x=1", lastReq.URL.RawQuery)
+	})
+
+	t.Run("form params", func(t *testing.T) {
+		e := WithConfig(Config{
+			BaseURL:  "http://test.example.com",
+			Reporter: newMockReporter(t),
+			Client: &http.Client{
+				Transport: NewBinder(handler),
+			},
+		})
+
+		req := e.FromHAR(har.Log.Entries[1])
+		req.chain.assert(t, success)
+
+		req.Expect().Status(http.StatusCreated)
+
+		require.NotNil(t, lastReq)
+		assert.Equal(t, "/login", lastReq.URL.Path)
+		assert.Equal(t, "application/x-www-form-urlencoded",
+			lastReq.Header.Get("Content-Type"))
+		assert.Equal(t, "password=secret&user=john", string(lastBody))
+	})
+
+	t.Run("builders", func(t *testing.T) {
+		e := WithConfig(Config{
+			BaseURL:  "http://test.example.com",
+			Reporter: newMockReporter(t),
+			Client: &http.Client{
+				Transport: NewBinder(handler),
+			},
+		}).Builder(func(req *Request) {
+			req.WithHeader("Authorization", "Bearer test")
+		})
+
+		e.FromHAR(har.Log.Entries[0]).
+			Expect().
+			Status(http.StatusCreated)
+
+		require.NotNil(t, lastReq)
+		assert.Equal(t, "Bearer test", lastReq.Header.Get("Authorization"))
+	})
+
+	t.Run("escaping", func(t *testing.T) {
+		entry := HAREntry{
+			Request: HARRequest{
+				Method: "GET",
+				URL:    "https://prod.example.com/files/a%2Fb/%7Bid%7D?z=1&a=x+y&z=%202",
+			},
+		}
+
+		cases := []struct {
+			baseURL     string
+			path        string
+			escapedPath string
+		}{
+			{
+				baseURL:     "",
+				path:        "/files/a/b/{id}",
+				escapedPath: "/files/a%2Fb/%7Bid%7D",
+			},
+			{
+				baseURL:     "http://test.example.com/v1",
+				path:        "/v1/files/a/b/{id}",
+				escapedPath: "/v1/files/a%2Fb/%7Bid%7D",
+			},
+		}
+
+		for _, tc := range cases {
+			e := WithConfig(Config{
+				BaseURL:  tc.baseURL,
+				Reporter: newMockReporter(t),
+				Client: &http.Client{
+					Transport: NewBinder(handler),
+				},
+			})
+
+			e.FromHAR(entry).
+				Expect().
+				Status(http.StatusCreated)
+
+			require.NotNil(t, lastReq)
+			assert.Equal(t, tc.path, lastReq.URL.Path)
+			assert.Equal(t, tc.escapedPath, lastReq.URL.EscapedPath())
+			assert.Equal(t, "z=1&a=x+y&z=%202", lastReq.URL.RawQuery)
+		}
+	})
+
+	t.Run("added query", func(t *testing.T) {
+		e := WithConfig(Config{
+			BaseURL:  "http://test.example.com",
+			Reporter: newMockReporter(t),
+			Client: &http.Client{
+				Transport: NewBinder(handler),
+			},
+		})
+
+		e.FromHAR(har.Log.Entries[0]).
+			WithQuery("b", "2").
+			Expect().
+			Status(http.StatusCreated)
+
+		require.NotNil(t, lastReq)
+		assert.Equal(t, "lang=en&x=1&b=2", lastReq.URL.RawQuery)
+	})
+
+	t.Run("invalid url", func(t *testing.T) {
+		e := WithConfig(Config{
+			BaseURL:  "http://test.example.com",
+			Reporter: newMockReporter(t),
+		})
+
+		req := e.FromHAR(HAREntry{
+			Request: HARRequest{Method: "GET", URL: "http://bad url\x7f"},
+		})
+		req.chain.assert(t, failure)
+	})
+
+	t.Run("relative url", func(t *testing.T) {
+		e := WithConfig(Config{
+			Reporter: newMockReporter(t),
+		})
+
+		req := e.FromHAR(HAREntry{
+			Request: HARRequest{Method: "GET", URL: "/path"},
+		})
+		req.chain.assert(t, failure)
+	})
+}
@@ -0,0 +1,91 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+)
+
+// HeaderCasing defines how names of response headers are treated.
+//
+// HTTP/2 and HTTP/3 transmit header names in lowercase, while HTTP/1.1
+// servers usually use canonical form, like "Content-Type". http.Client
+// canonicalizes names of received headers for all protocols, but handlers
+// invoked directly (see Binder and FastBinder) may put any names into
+// the header map. Header lookup, e.g. Response.Header, expects canonical
+// names, and Response.Headers returns names as is.
+type HeaderCasing int
+
+const (
+	// HeaderCasingAsIs keeps header names as is.
+	HeaderCasingAsIs HeaderCasing = iota
+
+	// HeaderCasingCanonical converts header names to canonical form when
+	// response is created, merging values of headers which names differ
+	// only in case. Tests behave identically regardless of protocol and
+	// of how handler sets headers.
+	HeaderCasingCanonical
+
+	// HeaderCasingStrict keeps header names as is, and checks every
+	// response received by Request using Response.HasCanonicalHeaders.
+	HeaderCasingStrict
+)
+
+// HasCanonicalHeaders succeeds if names of all response headers are in
+// canonical form, as returned by http.CanonicalHeaderKey.
+//
+// HasCanonicalHeaders is invoked automatically for every response if
+// Config.HeaderCasing is HeaderCasingStrict.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.HasCanonicalHeaders()
+func (r *Response) HasCanonicalHeaders() *Response {
+	opChain := r.chain.enter("HasCanonicalHeaders()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	var names []string
+
+	for name := range r.httpResp.Header {
+		if http.CanonicalHeaderKey(name) != name {
+			names = append(names, name)
+		}
+	}
+
+	if len(names) == 0 {
+		return r
+	}
+
+	sort.Strings(names)
+
+	errs := []error{
+		errors.New("expected: response header names are in canonical form"),
+	}
+	for _, name := range names {
+		errs = append(errs, fmt.Errorf("header %q should be %q",
+			name, http.CanonicalHeaderKey(name)))
+	}
+
+	opChain.fail(AssertionFailure{
+		Type:   AssertValid,
+		Actual: &AssertionValue{names},
+		Errors: errs,
+	})
+
+	return r
+}
+
+func hasCanonicalNames(header http.Header) bool {
+	for name := range header {
+		if http.CanonicalHeaderKey(name) != name {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,172 @@
+package httpexpect
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeaderCasing_HasCanonicalHeaders(t *testing.T) {
+	cases := []struct {
+		name   string
+		header http.Header
+		result chainResult
+	}{
+		{
+			name:   "empty",
+			header: http.Header{},
+			result: success,
+		},
+		{
+			name: "canonical",
+			header: http.Header{
+				"Content-Type": {"text/plain"},
+				"X-Request-Id": {"123"},
+			},
+			result: success,
+		},
+		{
+			name: "lowercase",
+			header: http.Header{
+				"Content-Type": {"text/plain"},
+				"x-request-id": {"123"},
+			},
+			result: failure,
+		},
+		{
+			name: "uppercase",
+			header: http.Header{
+				"CONTENT-TYPE": {"text/plain"},
+			},
+			result: failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			resp := NewResponse(reporter, &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     tc.header,
+				Body:       http.NoBody,
+			})
+
+			resp.HasCanonicalHeaders()
+			resp.chain.assert(t, tc.result)
+		})
+	}
+
+	t.Run("failure message", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		resp := NewResponseC(Config{
+			AssertionHandler: handler,
+		}, &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"x-b": {"1"},
+				"x-a": {"2"},
+			},
+			Body: http.NoBody,
+		})
+
+		resp.HasCanonicalHeaders()
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, &AssertionValue{[]string{"x-a", "x-b"}},
+			handler.failure.Actual)
+		assert.Equal(t, 3, len(handler.failure.Errors))
+	})
+}
+
+func TestHeaderCasing_Config(t *testing.T) {
+	header := func() http.Header {
+		return http.Header{
+			"content-type": {"application/json"},
+			"x-foo":        {"a"},
+			"X-Foo":        {"b"},
+		}
+	}
+
+	client := ClientFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     header(),
+			Body:       http.NoBody,
+			Request:    req,
+		}, nil
+	})
+
+	t.Run("as is", func(t *testing.T) {
+		e := WithConfig(Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		})
+
+		resp := e.GET("/").Expect()
+		resp.chain.assert(t, success)
+
+		resp.Header("Content-Type").IsEmpty()
+		resp.Headers().ContainsKey("content-type")
+		resp.chain.assert(t, success)
+	})
+
+	t.Run("canonical", func(t *testing.T) {
+		e := WithConfig(Config{
+			Client:       client,
+			Reporter:     newMockReporter(t),
+			HeaderCasing: HeaderCasingCanonical,
+		})
+
+		resp := e.GET("/").Expect()
+		resp.chain.assert(t, success)
+
+		resp.Header("Content-Type").IsEqual("application/json")
+		resp.Headers().NotContainsKey("content-type")
+		resp.HasCanonicalHeaders()
+		resp.chain.assert(t, success)
+
+		assert.ElementsMatch(t, []string{"a", "b"}, resp.httpResp.Header.Values("X-Foo"))
+	})
+
+	t.Run("canonical original not modified", func(t *testing.T) {
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     header(),
+			Body:       http.NoBody,
+		}
+
+		resp := NewResponseC(Config{
+			Reporter:     newMockReporter(t),
+			HeaderCasing: HeaderCasingCanonical,
+		}, httpResp)
+
+		resp.HasCanonicalHeaders()
+		resp.chain.assert(t, success)
+
+		assert.Equal(t, header(), httpResp.Header)
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		e := WithConfig(Config{
+			Client:       client,
+			Reporter:     newMockReporter(t),
+			HeaderCasing: HeaderCasingStrict,
+		})
+
+		e.GET("/").Expect().chain.assert(t, failure)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		assert.Panics(t, func() {
+			WithConfig(Config{
+				Client:       client,
+				Reporter:     newMockReporter(t),
+				HeaderCasing: HeaderCasing(-1),
+			})
+		})
+	})
+}
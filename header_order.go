@@ -0,0 +1,174 @@
+package httpexpect
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"net/http/httptrace"
+	"strings"
+	"sync"
+)
+
+// maxHeaderOrderBytes limits how much of response head is buffered
+// by headerOrderConn.
+const maxHeaderOrderBytes = 1 << 20
+
+// RecordHeaderOrder wraps dial function of http.Transport, so that order
+// of response headers is recorded and available via Response.HeaderOrder.
+//
+// http.Header is a map, so net/http doesn't preserve order in which headers
+// were received. RecordHeaderOrder wraps every connection and parses raw
+// response head read from it. Only HTTP/1.x is supported.
+//
+// It can be used both for Transport.DialContext and Transport.DialTLSContext.
+// For https, wrap DialTLSContext, because headers can't be recorded from
+// encrypted stream. If dial is nil, net.Dialer with default options is used.
+//
+// Example:
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		BaseURL:  "http://example.com",
+//		Reporter: httpexpect.NewAssertReporter(t),
+//		Client: &http.Client{
+//			Transport: &http.Transport{
+//				DialContext: httpexpect.RecordHeaderOrder(nil),
+//			},
+//		},
+//	})
+//
+//	e.GET("/").Expect().
+//		HeaderOrder().ConsistsOf("Content-Type", "Set-Cookie", "Set-Cookie")
+func RecordHeaderOrder(
+	dial func(ctx context.Context, network, addr string) (net.Conn, error),
+) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := dial(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+
+		return &headerOrderConn{Conn: conn}, nil
+	}
+}
+
+// headerOrderConn records header names of the last response head
+// read from connection.
+type headerOrderConn struct {
+	net.Conn
+
+	mu    sync.Mutex
+	buf   []byte
+	read  bool
+	done  bool
+	names []string
+}
+
+func (c *headerOrderConn) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	// first write after reading starts a new exchange
+	if c.read {
+		c.buf = nil
+		c.read = false
+		c.done = false
+		c.names = nil
+	}
+	c.mu.Unlock()
+
+	return c.Conn.Write(p)
+}
+
+func (c *headerOrderConn) Read(p []byte) (int, error) {
+	n, err := c.Conn.Read(p)
+
+	if n > 0 {
+		c.mu.Lock()
+		c.read = true
+		if !c.done {
+			c.buf = append(c.buf, p[:n]...)
+			c.parse()
+		}
+		c.mu.Unlock()
+	}
+
+	return n, err
+}
+
+// parse extracts header names from buffered response head.
+// Interim 1xx responses are skipped.
+func (c *headerOrderConn) parse() {
+	for {
+		idx := bytes.Index(c.buf, []byte("\r\n\r\n"))
+		if idx < 0 {
+			if len(c.buf) > maxHeaderOrderBytes {
+				c.buf = nil
+				c.done = true
+			}
+			return
+		}
+
+		lines := strings.Split(string(c.buf[:idx]), "\r\n")
+		c.buf = c.buf[idx+4:]
+
+		if isInterimStatusLine(lines[0]) {
+			continue
+		}
+
+		c.names = parseHeaderNames(lines[1:])
+		c.buf = nil
+		c.done = true
+
+		return
+	}
+}
+
+func (c *headerOrderConn) headerOrder() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.done || c.names == nil {
+		return nil
+	}
+
+	return append([]string(nil), c.names...)
+}
+
+func isInterimStatusLine(line string) bool {
+	fields := strings.Fields(line)
+
+	return len(fields) >= 2 && len(fields[1]) == 3 && fields[1][0] == '1'
+}
+
+func parseHeaderNames(lines []string) []string {
+	names := []string{}
+
+	for _, line := range lines {
+		// obsolete line folding
+		if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+			continue
+		}
+
+		if colon := strings.IndexByte(line, ':'); colon > 0 {
+			names = append(names, strings.TrimSpace(line[:colon]))
+		}
+	}
+
+	return names
+}
+
+// headerOrderOf returns recorded header order for connection,
+// or nil if connection doesn't record it.
+func headerOrderOf(info *httptrace.GotConnInfo) []string {
+	if info == nil {
+		return nil
+	}
+
+	if conn, ok := info.Conn.(*headerOrderConn); ok {
+		return conn.headerOrder()
+	}
+
+	return nil
+}
@@ -0,0 +1,196 @@
+package httpexpect
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRawResponseServer(t *testing.T, responses map[string]string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			conn, buf, err := w.(http.Hijacker).Hijack()
+			require.NoError(t, err)
+
+			_, _ = buf.WriteString(responses[r.URL.Path])
+			_ = buf.Flush()
+
+			// keep connection open for keep-alive requests
+			go func() {
+				defer conn.Close()
+
+				reader := bufio.NewReader(conn)
+				for {
+					req, err := http.ReadRequest(reader)
+					if err != nil {
+						return
+					}
+					_, _ = conn.Write([]byte(responses[req.URL.Path]))
+				}
+			}()
+		}))
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestHeaderOrder_Response(t *testing.T) {
+	server := newRawResponseServer(t, map[string]string{
+		"/first": "HTTP/1.1 200 OK\r\n" +
+			"X-Zeta: 1\r\n" +
+			"set-cookie: a=1\r\n" +
+			"X-Alpha: 2\r\n" +
+			"Set-Cookie: b=2\r\n" +
+			"Content-Length: 2\r\n" +
+			"\r\n" +
+			"ok",
+		"/second": "HTTP/1.1 200 OK\r\n" +
+			"Content-Length: 0\r\n" +
+			"X-Second: 1\r\n" +
+			"\r\n",
+		"/interim": "HTTP/1.1 103 Early Hints\r\n" +
+			"Link: </style.css>\r\n" +
+			"\r\n" +
+			"HTTP/1.1 200 OK\r\n" +
+			"Content-Length: 0\r\n" +
+			"X-Final: 1\r\n" +
+			"\r\n",
+	})
+
+	newExpect := func(t *testing.T, client Client) *Expect {
+		return WithConfig(Config{
+			BaseURL:  server.URL,
+			Client:   client,
+			Reporter: newMockReporter(t),
+		})
+	}
+
+	recordingClient := &http.Client{
+		Transport: &http.Transport{
+			DialContext: RecordHeaderOrder(nil),
+		},
+	}
+
+	t.Run("order and duplicates", func(t *testing.T) {
+		e := newExpect(t, recordingClient)
+
+		resp := e.GET("/first").Expect()
+
+		resp.HeaderOrder().IsEqual([]interface{}{
+			"X-Zeta", "set-cookie", "X-Alpha", "Set-Cookie", "Content-Length",
+		})
+		resp.HeaderOccurrences("Set-Cookie").IsEqual(2)
+		resp.HeaderOccurrences("x-alpha").IsEqual(1)
+		resp.HeaderOccurrences("X-Missing").IsEqual(0)
+		resp.Body().IsEqual("ok")
+
+		resp.chain.assert(t, success)
+	})
+
+	t.Run("keep-alive", func(t *testing.T) {
+		e := newExpect(t, recordingClient)
+
+		e.GET("/first").Expect().
+			HeaderOrder().Length().IsEqual(5)
+
+		resp := e.GET("/second").Expect()
+		resp.ConnectionReused().IsTrue()
+		resp.HeaderOrder().ConsistsOf("Content-Length", "X-Second")
+
+		resp.chain.assert(t, success)
+	})
+
+	t.Run("interim response", func(t *testing.T) {
+		e := newExpect(t, recordingClient)
+
+		resp := e.GET("/interim").Expect()
+		resp.HeaderOrder().ConsistsOf("Content-Length", "X-Final")
+
+		resp.chain.assert(t, success)
+	})
+
+	t.Run("not recorded", func(t *testing.T) {
+		e := newExpect(t, &http.Client{})
+
+		resp := e.GET("/first").Expect()
+
+		resp.HeaderOccurrences("Set-Cookie").IsEqual(2)
+		resp.chain.assert(t, success)
+
+		resp.HeaderOrder().chain.assert(t, failure)
+	})
+}
+
+func TestHeaderOrder_DialError(t *testing.T) {
+	dial := RecordHeaderOrder(
+		func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return nil, errors.New("test error")
+		})
+
+	conn, err := dial(context.Background(), "tcp", "example.com:80")
+	assert.Error(t, err)
+	assert.Nil(t, conn)
+}
+
+func TestHeaderOrder_Parse(t *testing.T) {
+	t.Run("split reads", func(t *testing.T) {
+		client, server := net.Pipe()
+		defer client.Close()
+		defer server.Close()
+
+		conn := &headerOrderConn{Conn: client}
+
+		go func() {
+			_, _ = server.Write([]byte("HTTP/1.1 200 OK\r\nX-A: 1\r"))
+			_, _ = server.Write([]byte("\nX-B: 2\r\n"))
+			_, _ = server.Write([]byte(" folded\r\n\r\nbody"))
+		}()
+
+		buf := make([]byte, 100)
+		for conn.headerOrder() == nil {
+			_, err := conn.Read(buf)
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, []string{"X-A", "X-B"}, conn.headerOrder())
+	})
+
+	t.Run("new exchange", func(t *testing.T) {
+		client, server := net.Pipe()
+		defer client.Close()
+		defer server.Close()
+
+		conn := &headerOrderConn{
+			Conn:  client,
+			read:  true,
+			done:  true,
+			names: []string{"X-A"},
+		}
+
+		go func() {
+			_, _ = io.Copy(io.Discard, server)
+		}()
+
+		_, err := conn.Write([]byte("GET / HTTP/1.1\r\n\r\n"))
+		require.NoError(t, err)
+
+		assert.Nil(t, conn.headerOrder())
+	})
+
+	t.Run("status line", func(t *testing.T) {
+		assert.True(t, isInterimStatusLine("HTTP/1.1 100 Continue"))
+		assert.True(t, isInterimStatusLine("HTTP/1.1 103 Early Hints"))
+		assert.False(t, isInterimStatusLine("HTTP/1.1 200 OK"))
+		assert.False(t, isInterimStatusLine("HTTP/1.1 1000 Bad"))
+		assert.False(t, isInterimStatusLine(""))
+	})
+}
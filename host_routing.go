@@ -0,0 +1,245 @@
+package httpexpect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// hostRouter is http.RoundTripper that implements Config.HostOverrides,
+// Config.HostTransports, and Config.Resolver.
+//
+// Requests to hosts listed in transports are sent via corresponding
+// transport. Other requests are sent via base transport, which dials
+// overridden addresses, or addresses returned by resolver, instead of
+// resolving host names using system resolver.
+type hostRouter struct {
+	base       http.RoundTripper
+	overrides  map[string]string
+	transports map[string]http.RoundTripper
+	resolver   Resolver
+}
+
+// newHostClient returns a copy of client that routes requests according
+// to host overrides, per-host transports, and custom resolver.
+func newHostClient(
+	client Client,
+	overrides map[string]string,
+	transports map[string]http.RoundTripper,
+	resolver Resolver,
+) (Client, error) {
+	httpClient, ok := client.(*http.Client)
+	if !ok {
+		return nil, fmt.Errorf(
+			"host overrides and transports require *http.Client, got %T", client)
+	}
+
+	if _, ok := httpClient.Transport.(*hostRouter); ok {
+		// already wrapped
+		return client, nil
+	}
+
+	router := &hostRouter{
+		base:       httpClient.Transport,
+		overrides:  overrides,
+		transports: transports,
+		resolver:   resolver,
+	}
+
+	if len(overrides) != 0 || resolver != nil {
+		base, err := newOverrideTransport(httpClient.Transport, router.dial)
+		if err != nil {
+			return nil, err
+		}
+		router.base = base
+	}
+
+	clientCopy := *httpClient
+	clientCopy.Transport = router
+
+	return &clientCopy, nil
+}
+
+func newOverrideTransport(
+	base http.RoundTripper,
+	dialer func(ctx context.Context, dial dialFunc, network, addr string) (net.Conn, error),
+) (*http.Transport, error) {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	baseTransport, ok := base.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf(
+			"host overrides require *http.Transport, got %T", base)
+	}
+
+	transport := baseTransport.Clone()
+
+	dial := transport.DialContext
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+
+	transport.DialContext = func(
+		ctx context.Context, network, addr string,
+	) (net.Conn, error) {
+		return dialer(ctx, dial, network, addr)
+	}
+
+	return transport, nil
+}
+
+type dialFunc = func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// dial connects to overridden address if there is one, or to address
+// returned by resolver otherwise.
+func (hr *hostRouter) dial(
+	ctx context.Context, dial dialFunc, network, addr string,
+) (net.Conn, error) {
+	if target, ok := hr.lookup(addr); ok {
+		return dial(ctx, network, target)
+	}
+
+	if hr.resolver == nil {
+		return dial(ctx, network, addr)
+	}
+
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	// IP addresses don't need resolving
+	if net.ParseIP(host) != nil {
+		return dial(ctx, network, addr)
+	}
+
+	addrs, err := hr.resolver.LookupHost(ctx, host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve host %q: %w", host, err)
+	}
+
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("failed to resolve host %q: no addresses", host)
+	}
+
+	var lastErr error
+
+	for _, ip := range addrs {
+		conn, err := dial(ctx, network, net.JoinHostPort(ip, port))
+		if err == nil {
+			return conn, nil
+		}
+		lastErr = err
+	}
+
+	return nil, lastErr
+}
+
+// RoundTrip implements http.RoundTripper.RoundTrip.
+func (hr *hostRouter) RoundTrip(req *http.Request) (*http.Response, error) {
+	if rt, ok := hr.transport(req.URL); ok {
+		return rt.RoundTrip(req)
+	}
+
+	if hr.base == nil {
+		return http.DefaultTransport.RoundTrip(req)
+	}
+
+	return hr.base.RoundTrip(req)
+}
+
+// transport returns transport for URL from HostTransports.
+// Key "host" matches only default port of URL scheme, so that both
+// "https://host/" and "https://host:443/" match "host" and "host:443".
+func (hr *hostRouter) transport(u *url.URL) (http.RoundTripper, bool) {
+	if len(hr.transports) == 0 {
+		return nil, false
+	}
+
+	host, port := u.Hostname(), u.Port()
+
+	defaultPort := schemeDefaultPort(u.Scheme)
+	if port == "" {
+		port = defaultPort
+	}
+
+	if port != "" {
+		if rt, ok := hr.transports[net.JoinHostPort(host, port)]; ok {
+			return rt, true
+		}
+	}
+
+	if port == defaultPort {
+		if rt, ok := hr.transports[host]; ok {
+			return rt, true
+		}
+	}
+
+	return nil, false
+}
+
+func schemeDefaultPort(scheme string) string {
+	switch strings.ToLower(scheme) {
+	case "http", "ws":
+		return "80"
+	case "https", "wss":
+		return "443"
+	default:
+		return ""
+	}
+}
+
+// lookup returns overridden address for "host:port" address.
+// Overrides for "host:port" take precedence over overrides for "host".
+func (hr *hostRouter) lookup(addr string) (string, bool) {
+	if target, ok := hr.overrides[addr]; ok {
+		return target, true
+	}
+
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", false
+	}
+
+	target, ok := hr.overrides[host]
+	if !ok {
+		return "", false
+	}
+
+	// if target has no port, keep original one
+	if _, _, err := net.SplitHostPort(target); err != nil {
+		target = net.JoinHostPort(target, port)
+	}
+
+	return target, true
+}
+
+func validateHostOverrides(overrides map[string]string) error {
+	for host, target := range overrides {
+		if host == "" || strings.Contains(host, "/") {
+			return fmt.Errorf("invalid host %q in Config.HostOverrides", host)
+		}
+		if target == "" || strings.Contains(target, "/") {
+			return fmt.Errorf("invalid address %q in Config.HostOverrides", target)
+		}
+	}
+	return nil
+}
+
+func validateHostTransports(transports map[string]http.RoundTripper) error {
+	for host, transport := range transports {
+		if host == "" || strings.Contains(host, "/") {
+			return fmt.Errorf("invalid host %q in Config.HostTransports", host)
+		}
+		if transport == nil {
+			return errors.New("nil transport in Config.HostTransports")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,354 @@
+package httpexpect
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockRoundTripper struct {
+	name  string
+	count int
+}
+
+func (rt *mockRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.count++
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       http.NoBody,
+		Header:     http.Header{"X-Transport": {rt.name}},
+		Request:    req,
+	}, nil
+}
+
+func TestHostRouting_Overrides(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(r.Host))
+		}))
+	defer server.Close()
+
+	addr := server.Listener.Addr().String()
+	ip := addr[:strings.LastIndex(addr, ":")]
+	port := addr[strings.LastIndex(addr, ":")+1:]
+
+	cases := []struct {
+		name      string
+		baseURL   string
+		overrides map[string]string
+	}{
+		{
+			name:      "host:port to ip:port",
+			baseURL:   "http://canary.example.invalid:1234",
+			overrides: map[string]string{"canary.example.invalid:1234": addr},
+		},
+		{
+			name:      "host to ip:port",
+			baseURL:   "http://canary.example.invalid:1234",
+			overrides: map[string]string{"canary.example.invalid": addr},
+		},
+		{
+			name:      "host to ip",
+			baseURL:   "http://canary.example.invalid:" + port,
+			overrides: map[string]string{"canary.example.invalid": ip},
+		},
+		{
+			name:    "host:port takes precedence",
+			baseURL: "http://canary.example.invalid:1234",
+			overrides: map[string]string{
+				"canary.example.invalid":      "192.0.2.1:1",
+				"canary.example.invalid:1234": addr,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &http.Client{}
+
+			e := WithConfig(Config{
+				BaseURL:       tc.baseURL,
+				Reporter:      newMockReporter(t),
+				Client:        client,
+				HostOverrides: tc.overrides,
+			})
+
+			host := strings.TrimPrefix(tc.baseURL, "http://")
+
+			e.GET("/").Expect().
+				Status(http.StatusOK).
+				Body().IsEqual(host)
+
+			// original client is not modified
+			assert.Nil(t, client.Transport)
+		})
+	}
+}
+
+func TestHostRouting_Transports(t *testing.T) {
+	base := &mockRoundTripper{name: "base"}
+	byHost := &mockRoundTripper{name: "host"}
+	byHostPort := &mockRoundTripper{name: "hostport"}
+
+	e := WithConfig(Config{
+		Reporter: newMockReporter(t),
+		Client:   &http.Client{Transport: base},
+		HostTransports: map[string]http.RoundTripper{
+			"a.example.com":      byHost,
+			"a.example.com:8080": byHostPort,
+		},
+	})
+
+	e.GET("/").WithURL("http://a.example.com").Expect().
+		Header("X-Transport").IsEqual("host")
+
+	e.GET("/").WithURL("http://a.example.com:8080").Expect().
+		Header("X-Transport").IsEqual("hostport")
+
+	e.GET("/").WithURL("http://b.example.com").Expect().
+		Header("X-Transport").IsEqual("base")
+
+	assert.Equal(t, 1, base.count)
+	assert.Equal(t, 1, byHost.count)
+	assert.Equal(t, 1, byHostPort.count)
+
+	t.Run("default ports", func(t *testing.T) {
+		cases := []struct {
+			name      string
+			key       string
+			url       string
+			transport string
+		}{
+			{
+				name:      "https host:443 key, no port in url",
+				key:       "a.example.com:443",
+				url:       "https://a.example.com/",
+				transport: "custom",
+			},
+			{
+				name:      "https host key, default port in url",
+				key:       "a.example.com",
+				url:       "https://a.example.com:443/",
+				transport: "custom",
+			},
+			{
+				name:      "http host:80 key, no port in url",
+				key:       "a.example.com:80",
+				url:       "http://a.example.com/",
+				transport: "custom",
+			},
+			{
+				name:      "host key, non-default port in url",
+				key:       "a.example.com",
+				url:       "https://a.example.com:8443/",
+				transport: "base",
+			},
+			{
+				name:      "host:443 key, http url",
+				key:       "a.example.com:443",
+				url:       "http://a.example.com/",
+				transport: "base",
+			},
+			{
+				name:      "ipv6",
+				key:       "[::1]:443",
+				url:       "https://[::1]/",
+				transport: "custom",
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				e := WithConfig(Config{
+					Reporter: newMockReporter(t),
+					Client:   &http.Client{Transport: &mockRoundTripper{name: "base"}},
+					HostTransports: map[string]http.RoundTripper{
+						tc.key: &mockRoundTripper{name: "custom"},
+					},
+				})
+
+				e.GET("/").WithURL(tc.url).Expect().
+					Header("X-Transport").IsEqual(tc.transport)
+			})
+		}
+	})
+}
+
+func TestHostRouting_Resolver(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(r.Host))
+		}))
+	defer server.Close()
+
+	addr := server.Listener.Addr().String()
+	port := addr[strings.LastIndex(addr, ":")+1:]
+
+	baseURL := "http://backend.example.invalid:" + port
+
+	newExpect := func(t *testing.T, resolver Resolver) *Expect {
+		return WithConfig(Config{
+			BaseURL:  baseURL,
+			Reporter: newMockReporter(t),
+			Client: &http.Client{
+				Transport: &http.Transport{},
+			},
+			Resolver: resolver,
+		})
+	}
+
+	t.Run("resolve", func(t *testing.T) {
+		var hosts []string
+
+		e := newExpect(t, ResolverFunc(
+			func(ctx context.Context, host string) ([]string, error) {
+				hosts = append(hosts, host)
+				return []string{"127.0.0.1"}, nil
+			}))
+
+		resp := e.GET("/").Expect()
+
+		resp.Body().IsEqual("backend.example.invalid:" + port)
+		resp.ResolvedAddr().IsEqual("127.0.0.1")
+		resp.chain.assert(t, success)
+
+		assert.Equal(t, []string{"backend.example.invalid"}, hosts)
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		e := newExpect(t, ResolverFunc(
+			func(ctx context.Context, host string) ([]string, error) {
+				// nothing listens on 127.0.0.2, so connection is refused
+				return []string{"127.0.0.2", "127.0.0.1"}, nil
+			}))
+
+		resp := e.GET("/").Expect()
+
+		resp.ResolvedAddr().IsEqual("127.0.0.1")
+		resp.chain.assert(t, success)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		e := newExpect(t, ResolverFunc(
+			func(ctx context.Context, host string) ([]string, error) {
+				return nil, errors.New("test error")
+			}))
+
+		e.GET("/").Expect().chain.assert(t, failure)
+	})
+
+	t.Run("no addresses", func(t *testing.T) {
+		e := newExpect(t, ResolverFunc(
+			func(ctx context.Context, host string) ([]string, error) {
+				return nil, nil
+			}))
+
+		e.GET("/").Expect().chain.assert(t, failure)
+	})
+
+	t.Run("overrides take precedence", func(t *testing.T) {
+		e := WithConfig(Config{
+			BaseURL:  baseURL,
+			Reporter: newMockReporter(t),
+			HostOverrides: map[string]string{
+				"backend.example.invalid": "127.0.0.1",
+			},
+			Resolver: ResolverFunc(
+				func(ctx context.Context, host string) ([]string, error) {
+					return nil, errors.New("unexpected call")
+				}),
+		})
+
+		resp := e.GET("/").Expect()
+
+		resp.ResolvedAddr().IsEqual("127.0.0.1")
+		resp.chain.assert(t, success)
+	})
+
+	t.Run("ip address", func(t *testing.T) {
+		e := newExpect(t, ResolverFunc(
+			func(ctx context.Context, host string) ([]string, error) {
+				return nil, errors.New("unexpected call")
+			}))
+
+		resp := e.GET("/").WithURL(server.URL).Expect()
+
+		resp.ResolvedAddr().IsEqual("127.0.0.1")
+		resp.chain.assert(t, success)
+	})
+}
+
+func TestHostRouting_Config(t *testing.T) {
+	t.Run("idempotent", func(t *testing.T) {
+		config := Config{
+			Reporter:      newMockReporter(t),
+			HostOverrides: map[string]string{"a.example.com": "127.0.0.1"},
+		}.withDefaults()
+
+		client := config.Client
+		require.IsType(t, &hostRouter{}, client.(*http.Client).Transport)
+
+		config = config.withDefaults()
+		assert.Same(t, client, config.Client)
+	})
+
+	t.Run("unsupported client", func(t *testing.T) {
+		assert.Panics(t, func() {
+			Config{
+				Reporter:      newMockReporter(t),
+				Client:        &mockClient{},
+				HostOverrides: map[string]string{"a.example.com": "127.0.0.1"},
+			}.withDefaults()
+		})
+
+		assert.Panics(t, func() {
+			Config{
+				Reporter: newMockReporter(t),
+				Client: &http.Client{
+					Transport: &mockRoundTripper{},
+				},
+				HostOverrides: map[string]string{"a.example.com": "127.0.0.1"},
+			}.withDefaults()
+		})
+	})
+
+	t.Run("validate", func(t *testing.T) {
+		config := Config{
+			Reporter: newMockReporter(t),
+		}.withDefaults()
+
+		assert.Panics(t, func() {
+			badConfig := config
+			badConfig.HostOverrides = map[string]string{"": "127.0.0.1"}
+			badConfig.validate()
+		})
+
+		assert.Panics(t, func() {
+			badConfig := config
+			badConfig.HostOverrides = map[string]string{"a.example.com": ""}
+			badConfig.validate()
+		})
+
+		assert.Panics(t, func() {
+			badConfig := config
+			badConfig.HostTransports = map[string]http.RoundTripper{
+				"a.example.com": nil,
+			}
+			badConfig.validate()
+		})
+
+		assert.Panics(t, func() {
+			badConfig := config
+			badConfig.HostTransports = map[string]http.RoundTripper{
+				"http://a.example.com": &mockRoundTripper{},
+			}
+			badConfig.validate()
+		})
+	})
+}
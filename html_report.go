@@ -0,0 +1,433 @@
+package httpexpect
+
+import (
+	"bytes"
+	"errors"
+	"html/template"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HTMLReportHandler is an AssertionHandler that writes HTML report for
+// every failed test into a directory.
+//
+// HTMLReportHandler wraps another AssertionHandler (typically
+// DefaultAssertionHandler), which is invoked for every assertion as usual.
+// In addition, for every failure with SeverityError, HTMLReportHandler
+// adds an entry to report of the failed test, with assertion errors,
+// actual and expected values, diff, timing, and full request and response
+// dumps, including headers and bodies.
+//
+// Every test gets its own report file, named after the test, containing all
+// failures of that test. Dir also gets "index.html" with links to all reports.
+// Reports can be opened directly in browser, or served locally using Serve.
+//
+// Sensitive headers are redacted before writing, see RedactHeaders. If
+// Config.Redactor is set, it is applied to the report as well.
+//
+// Example:
+//
+//	reports := &httpexpect.HTMLReportHandler{
+//		Handler: &httpexpect.DefaultAssertionHandler{
+//			Formatter: &httpexpect.DefaultFormatter{},
+//			Reporter:  httpexpect.NewAssertReporter(t),
+//		},
+//		Dir:    "test-reports",
+//		Logger: t,
+//	}
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		BaseURL:          "http://example.com",
+//		AssertionHandler: reports,
+//	})
+type HTMLReportHandler struct {
+	// Wrapped handler.
+	// Should not be nil.
+	Handler AssertionHandler
+
+	// Directory for report files.
+	// Created if doesn't exist.
+	//
+	// If empty, report of every test is written to "report.html" in
+	// artifacts directory of the test (see Config.ArtifactsDir), and
+	// "index.html" is not written.
+	Dir string
+
+	// Formatter used to format actual and expected values and diff.
+	// If nil, DefaultFormatter is used.
+	// Colors are always disabled.
+	Formatter *DefaultFormatter
+
+	// Names of headers which values are replaced with "<redacted>".
+	// If nil, DefaultRedactHeaders is used.
+	RedactHeaders []string
+
+	// If not nil, invoked for request and response bodies before writing
+	// them, and may be used to redact sensitive data.
+	RedactBody func(body []byte) []byte
+
+	// If not nil, path of the report is printed to Logger after it's
+	// written for the first time. Typically testing.T.
+	Logger Logger
+
+	// If not nil, invoked when report can't be written.
+	ErrorLogger Logger
+
+	mu      sync.Mutex
+	reports map[string]*htmlReport
+}
+
+type htmlReport struct {
+	TestName string
+	FileName string
+	Failures []htmlReportFailure
+
+	dir string
+}
+
+type htmlReportFailure struct {
+	Time     string
+	Data     *FormatData
+	Request  string
+	Response string
+}
+
+// Success implements AssertionHandler.Success.
+func (h *HTMLReportHandler) Success(ctx *AssertionContext) {
+	if h.Handler == nil {
+		panic("HTMLReportHandler.Handler is nil")
+	}
+
+	h.Handler.Success(ctx)
+}
+
+// Failure implements AssertionHandler.Failure.
+func (h *HTMLReportHandler) Failure(
+	ctx *AssertionContext, failure *AssertionFailure,
+) {
+	if h.Handler == nil {
+		panic("HTMLReportHandler.Handler is nil")
+	}
+
+	if failure.Severity == SeverityError {
+		if err := h.addFailure(ctx, failure); err != nil && h.ErrorLogger != nil {
+			h.ErrorLogger.Logf("failed to write html report: %s", err)
+		}
+	}
+
+	h.Handler.Failure(ctx, failure)
+}
+
+// FileServer returns http.Handler that serves reports from Dir.
+//
+// Example:
+//
+//	mux := http.NewServeMux()
+//	mux.Handle("/reports/", http.StripPrefix("/reports", reports.FileServer()))
+func (h *HTMLReportHandler) FileServer() http.Handler {
+	return http.FileServer(http.Dir(h.Dir))
+}
+
+// Serve serves reports from Dir on given address until server fails.
+//
+// Typically invoked after tests are finished, e.g. from TestMain when
+// an environment variable is set, to browse reports locally.
+//
+// Example:
+//
+//	if os.Getenv("SERVE_REPORTS") != "" {
+//		log.Fatal(reports.Serve("localhost:8080"))
+//	}
+func (h *HTMLReportHandler) Serve(addr string) error {
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           h.FileServer(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	return server.ListenAndServe()
+}
+
+func (h *HTMLReportHandler) addFailure(
+	ctx *AssertionContext, failure *AssertionFailure,
+) error {
+	formatter := DefaultFormatter{}
+	if h.Formatter != nil {
+		formatter = *h.Formatter
+	}
+	formatter.ColorMode = ColorModeNever
+	formatter.DisableRequests = true
+	formatter.DisableResponses = true
+
+	dumper := AttachmentHandler{
+		RedactHeaders: h.RedactHeaders,
+		RedactBody:    h.RedactBody,
+	}
+
+	entry := htmlReportFailure{
+		Time:     time.Now().Format(time.RFC3339Nano),
+		Data:     formatter.buildFormatData(ctx, failure),
+		Request:  string(dumper.dumpRequest(ctx)),
+		Response: string(dumper.dumpResponse(ctx)),
+	}
+
+	if h.Dir == "" && ctx.ArtifactsDir == "" {
+		return errors.New(
+			"neither HTMLReportHandler.Dir nor Config.ArtifactsDir is set")
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.reports == nil {
+		h.reports = make(map[string]*htmlReport)
+	}
+
+	report := h.reports[ctx.TestName]
+	created := report == nil
+
+	if created {
+		if h.Dir != "" {
+			report = &htmlReport{
+				TestName: ctx.TestName,
+				FileName: h.reportFileName(ctx.TestName),
+				dir:      h.Dir,
+			}
+		} else {
+			report = &htmlReport{
+				TestName: ctx.TestName,
+				FileName: "report.html",
+				dir:      ctx.ArtifactsDir,
+			}
+		}
+		h.reports[ctx.TestName] = report
+	}
+
+	report.Failures = append(report.Failures, entry)
+
+	if err := os.MkdirAll(report.dir, 0o755); err != nil {
+		return err
+	}
+
+	path := filepath.Join(report.dir, report.FileName)
+
+	if err := h.writeTemplate(path, htmlReportTemplate, report); err != nil {
+		return err
+	}
+
+	if h.Dir != "" {
+		if err := h.writeIndex(); err != nil {
+			return err
+		}
+	}
+
+	if created && h.Logger != nil {
+		if absPath, err := filepath.Abs(path); err == nil {
+			path = absPath
+		}
+		h.Logger.Logf("html report: %s", path)
+	}
+
+	return nil
+}
+
+// reportFileName returns unique file name for test report.
+// Must be called with mutex locked.
+func (h *HTMLReportHandler) reportFileName(testName string) string {
+	base := attachmentFileName(testName)
+	if base == "index" {
+		base = "index_"
+	}
+
+	name := base + ".html"
+
+	for n := 2; h.hasFileName(name); n++ {
+		name = base + "-" + strconv.Itoa(n) + ".html"
+	}
+
+	return name
+}
+
+func (h *HTMLReportHandler) hasFileName(name string) bool {
+	for _, report := range h.reports {
+		if report.dir == h.Dir && report.FileName == name {
+			return true
+		}
+	}
+	return false
+}
+
+// writeIndex rewrites index.html with links to all reports.
+// Must be called with mutex locked.
+func (h *HTMLReportHandler) writeIndex() error {
+	reports := make([]*htmlReport, 0, len(h.reports))
+	for _, report := range h.reports {
+		if report.dir == h.Dir {
+			reports = append(reports, report)
+		}
+	}
+
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].FileName < reports[j].FileName
+	})
+
+	return h.writeTemplate(
+		filepath.Join(h.Dir, "index.html"), htmlIndexTemplate, reports)
+}
+
+func (h *HTMLReportHandler) writeTemplate(
+	path string, tmpl *template.Template, data interface{},
+) error {
+	var b bytes.Buffer
+
+	if err := tmpl.Execute(&b, data); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, b.Bytes(), 0o644) //nolint:gosec
+}
+
+const htmlReportStyle = `
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+h1 { font-size: 1.4em; }
+h2 { font-size: 1.2em; border-bottom: 1px solid #ccc; padding-bottom: 0.2em; }
+h3 { font-size: 1em; margin-bottom: 0.3em; }
+pre { background: #f6f8fa; padding: 0.8em; overflow-x: auto; }
+table { border-collapse: collapse; }
+td { padding: 0.2em 1em 0.2em 0; vertical-align: top; }
+td:first-child { color: #666; }
+.errors { color: #b00020; }
+.diff-add { color: #116329; }
+.diff-del { color: #b00020; }
+</style>
+`
+
+var htmlReportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"diff": htmlDiffLines,
+	"join": strings.Join,
+	"inc": func(i int) int {
+		return i + 1
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{ .TestName }}</title>` + htmlReportStyle + `</head>
+<body>
+<p><a href="index.html">All reports</a></p>
+<h1>{{ if .TestName }}{{ .TestName }}{{ else }}(unnamed test){{ end }}</h1>
+{{ range $i, $f := .Failures }}
+{{ with $f.Data }}
+<h2>Failure #{{ inc $i }}{{ if .AssertPath }}: {{ join .AssertPath "." }}{{ end }}</h2>
+<table>
+<tr><td>time</td><td>{{ $f.Time }}</td></tr>
+{{ if .RequestName }}<tr><td>request name</td><td>{{ .RequestName }}</td></tr>{{ end }}
+<tr><td>assertion</td><td>{{ .AssertType }}</td></tr>
+{{ if .HaveBreadcrumbs }}
+<tr><td>request</td><td>{{ .RequestMethod }} {{ .RequestURL }}</td></tr>
+{{ if .ResponseStatus }}<tr><td>status</td><td>{{ .ResponseStatus }}</td></tr>{{ end }}
+{{ if .ResponseDuration }}
+<tr><td>duration</td><td>{{ .ResponseDuration }}</td></tr>
+{{ end }}
+{{ if .CorrelationID }}
+<tr><td>{{ .CorrelationHeader }}</td><td>{{ .CorrelationID }}</td></tr>
+{{ end }}
+{{ end }}
+</table>
+{{ if .Errors }}
+<h3>Errors</h3>
+<pre class="errors">{{ range .Errors }}{{ . }}
+{{ end }}</pre>
+{{ end }}
+{{ if .HaveExpected }}
+<h3>Expected{{ if .ExpectedKind }} {{ .ExpectedKind }}{{ end }}</h3>
+<pre>{{ range .Expected }}{{ . }}
+{{ end }}</pre>
+{{ end }}
+{{ if .HaveActual }}
+<h3>Actual</h3>
+<pre>{{ .Actual }}</pre>
+{{ end }}
+{{ if .HaveReference }}
+<h3>Reference</h3>
+<pre>{{ .Reference }}</pre>
+{{ end }}
+{{ if .HaveDelta }}
+<h3>Delta</h3>
+<pre>{{ .Delta }}</pre>
+{{ end }}
+{{ if .HaveDiff }}
+<h3>Diff</h3>
+<pre>{{ range diff .Diff }}<span class="{{ .Class }}">{{ .Text }}</span>
+{{ end }}</pre>
+{{ end }}
+{{ if $f.Request }}
+<h3>Request</h3>
+<pre>{{ $f.Request }}</pre>
+{{ end }}
+{{ if $f.Response }}
+<h3>Response</h3>
+<pre>{{ $f.Response }}</pre>
+{{ end }}
+{{ if .HaveStacktrace }}
+<h3>Stacktrace</h3>
+<pre>{{ range .Stacktrace }}{{ . }}
+{{ end }}</pre>
+{{ end }}
+{{ end }}
+{{ end }}
+</body>
+</html>
+`))
+
+var htmlIndexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Failure reports</title>` + htmlReportStyle + `</head>
+<body>
+<h1>Failure reports</h1>
+<table>
+{{ range . }}
+<tr>
+<td><a href="{{ .FileName }}">
+{{- if .TestName }}{{ .TestName }}{{ else }}(unnamed test){{ end -}}
+</a></td>
+<td>{{ len .Failures }} failure(s)</td>
+</tr>
+{{ end }}
+</table>
+</body>
+</html>
+`))
+
+type htmlDiffLine struct {
+	Class string
+	Text  string
+}
+
+func htmlDiffLines(diff string) []htmlDiffLine {
+	var lines []htmlDiffLine
+
+	for _, text := range strings.Split(strings.TrimSuffix(diff, "\n"), "\n") {
+		line := htmlDiffLine{Text: text}
+
+		switch {
+		case strings.HasPrefix(text, "+"):
+			line.Class = "diff-add"
+		case strings.HasPrefix(text, "-"):
+			line.Class = "diff-del"
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines
+}
@@ -0,0 +1,255 @@
+package httpexpect
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTMLReportHandler_Failure(t *testing.T) {
+	client := ClientFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {"application/json"},
+				"Set-Cookie":   {"session=secret"},
+			},
+			Body: io.NopCloser(bytes.NewBufferString(
+				`{"name":"<script>","password":"qwerty"}`)),
+			Request: req,
+		}, nil
+	})
+
+	newExpect := func(testName string, handler *HTMLReportHandler) *Expect {
+		return WithConfig(Config{
+			TestName:         testName,
+			Client:           client,
+			AssertionHandler: handler,
+		})
+	}
+
+	readFile := func(t *testing.T, path string) string {
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		return string(content)
+	}
+
+	t.Run("report", func(t *testing.T) {
+		dir := t.TempDir()
+		wrapped := &mockAssertionHandler{}
+		logger := newMockLogger(t)
+
+		e := newExpect("TestUser/get", &HTMLReportHandler{
+			Handler: wrapped,
+			Dir:     dir,
+			Logger:  logger,
+		})
+
+		e.POST("/user").
+			WithHeader("Authorization", "Bearer token").
+			WithText("id=1").
+			Expect().
+			JSON().Object().
+			IsEqual(map[string]interface{}{
+				"name":     "alice",
+				"password": "qwerty",
+			})
+
+		assert.Equal(t, 1, wrapped.failureCalled)
+
+		path := filepath.Join(dir, "TestUser_get.html")
+
+		assert.True(t, logger.logged)
+		assert.Contains(t, logger.lastMessage, path)
+
+		report := readFile(t, path)
+
+		assert.Contains(t, report, "<h1>TestUser/get</h1>")
+		assert.Contains(t, report, "POST /user HTTP/1.1")
+		assert.Contains(t, report, "Authorization: &lt;redacted&gt;")
+		assert.Contains(t, report, "id=1")
+		assert.Contains(t, report, "200 OK")
+		assert.Contains(t, report, "Set-Cookie: &lt;redacted&gt;")
+		assert.Contains(t, report, "<h3>Diff</h3>")
+		assert.Contains(t, report, `class="diff-add"`)
+		assert.Contains(t, report, "&lt;script&gt;")
+
+		assert.NotContains(t, report, "<script>")
+		assert.NotContains(t, report, "Bearer token")
+		assert.NotContains(t, report, "session=secret")
+
+		index := readFile(t, filepath.Join(dir, "index.html"))
+
+		assert.Contains(t, index, `href="TestUser_get.html"`)
+		assert.Contains(t, index, "1 failure(s)")
+	})
+
+	t.Run("multiple failures", func(t *testing.T) {
+		dir := t.TempDir()
+
+		handler := &HTMLReportHandler{
+			Handler: &mockAssertionHandler{},
+			Dir:     dir,
+		}
+
+		e := newExpect("TestFoo", handler)
+
+		e.GET("/first").Expect().Status(http.StatusNotFound)
+		e.GET("/second").Expect().Status(http.StatusNotFound)
+
+		e = newExpect("TestBar", handler)
+
+		e.GET("/third").Expect().Status(http.StatusNotFound)
+
+		report := readFile(t, filepath.Join(dir, "TestFoo.html"))
+
+		assert.Contains(t, report, "Failure #1")
+		assert.Contains(t, report, "Failure #2")
+		assert.Contains(t, report, "GET /first HTTP/1.1")
+		assert.Contains(t, report, "GET /second HTTP/1.1")
+		assert.NotContains(t, report, "GET /third HTTP/1.1")
+
+		index := readFile(t, filepath.Join(dir, "index.html"))
+
+		assert.Contains(t, index, `href="TestFoo.html"`)
+		assert.Contains(t, index, `href="TestBar.html"`)
+		assert.Contains(t, index, "2 failure(s)")
+	})
+
+	t.Run("redact options", func(t *testing.T) {
+		dir := t.TempDir()
+
+		e := newExpect("TestRedact", &HTMLReportHandler{
+			Handler:       &mockAssertionHandler{},
+			Dir:           dir,
+			RedactHeaders: []string{"Content-Type"},
+			RedactBody: func(body []byte) []byte {
+				return bytes.ReplaceAll(body, []byte("qwerty"), []byte("***"))
+			},
+		})
+
+		e.GET("/").Expect().Status(http.StatusNotFound)
+
+		report := readFile(t, filepath.Join(dir, "TestRedact.html"))
+
+		assert.Contains(t, report, "Content-Type: &lt;redacted&gt;")
+		assert.Contains(t, report, "Set-Cookie: session=secret")
+		assert.Contains(t, report, `&#34;password&#34;:&#34;***&#34;`)
+	})
+
+	t.Run("write error", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "file")
+		require.NoError(t, os.WriteFile(file, nil, 0o644))
+
+		wrapped := &mockAssertionHandler{}
+		logger := newMockLogger(t)
+
+		e := newExpect("TestError", &HTMLReportHandler{
+			Handler:     wrapped,
+			Dir:         filepath.Join(file, "reports"),
+			ErrorLogger: logger,
+		})
+
+		e.GET("/").Expect().Status(http.StatusNotFound)
+
+		assert.Equal(t, 1, wrapped.failureCalled)
+		assert.True(t, logger.logged)
+		assert.Contains(t, logger.lastMessage, "failed to write html report")
+	})
+}
+
+func TestHTMLReportHandler_Severity(t *testing.T) {
+	dir := t.TempDir()
+	wrapped := &mockAssertionHandler{}
+
+	handler := &HTMLReportHandler{
+		Handler: wrapped,
+		Dir:     dir,
+	}
+
+	ctx := &AssertionContext{
+		TestName: "TestSeverity",
+	}
+
+	handler.Success(ctx)
+	assert.Equal(t, 1, wrapped.successCalled)
+
+	handler.Failure(ctx, &AssertionFailure{Severity: SeverityLog})
+	assert.Equal(t, 1, wrapped.failureCalled)
+	assert.NoFileExists(t, filepath.Join(dir, "TestSeverity.html"))
+
+	handler.Failure(ctx, &AssertionFailure{Severity: SeverityError})
+	assert.Equal(t, 2, wrapped.failureCalled)
+	assert.FileExists(t, filepath.Join(dir, "TestSeverity.html"))
+}
+
+func TestHTMLReportHandler_NilHandler(t *testing.T) {
+	handler := &HTMLReportHandler{}
+
+	assert.Panics(t, func() {
+		handler.Success(&AssertionContext{})
+	})
+	assert.Panics(t, func() {
+		handler.Failure(&AssertionContext{}, &AssertionFailure{})
+	})
+}
+
+func TestHTMLReportHandler_FileNames(t *testing.T) {
+	dir := t.TempDir()
+
+	handler := &HTMLReportHandler{
+		Handler: &mockAssertionHandler{},
+		Dir:     dir,
+	}
+
+	for _, name := range []string{"Test/a", "Test a", "index", ""} {
+		handler.Failure(
+			&AssertionContext{TestName: name},
+			&AssertionFailure{Severity: SeverityError})
+	}
+
+	assert.FileExists(t, filepath.Join(dir, "Test_a.html"))
+	assert.FileExists(t, filepath.Join(dir, "Test_a-2.html"))
+	assert.FileExists(t, filepath.Join(dir, "index_.html"))
+	assert.FileExists(t, filepath.Join(dir, "unnamed.html"))
+	assert.FileExists(t, filepath.Join(dir, "index.html"))
+}
+
+func TestHTMLReportHandler_FileServer(t *testing.T) {
+	dir := t.TempDir()
+
+	handler := &HTMLReportHandler{
+		Handler: &mockAssertionHandler{},
+		Dir:     dir,
+	}
+
+	handler.Failure(
+		&AssertionContext{TestName: "TestServe"},
+		&AssertionFailure{Severity: SeverityError})
+
+	e := WithConfig(Config{
+		Client:   &http.Client{Transport: NewBinder(handler.FileServer())},
+		Reporter: newMockReporter(t),
+	})
+
+	e.GET("/").
+		Expect().
+		Status(http.StatusOK).
+		Body().Contains(`href="TestServe.html"`)
+
+	e.GET("/TestServe.html").
+		Expect().
+		Status(http.StatusOK).
+		Body().Contains("<h1>TestServe</h1>")
+
+	rec := httptest.NewRecorder()
+	handler.FileServer().ServeHTTP(rec, httptest.NewRequest("GET", "/missing.html", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
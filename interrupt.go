@@ -0,0 +1,121 @@
+package httpexpect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	errBodyInterrupted = errors.New("request body interrupted")
+	errClientAborted   = errors.New("request aborted by client")
+)
+
+// interruptReader reads at most limit bytes from the underlying
+// reader and then fails with errBodyInterrupted.
+//
+// Used by Request.WithBodyInterrupt to simulate upload which is
+// interrupted in the middle of the body.
+type interruptReader struct {
+	reader io.ReadCloser
+	limit  int64
+}
+
+func newInterruptReader(reader io.ReadCloser, limit int64) *interruptReader {
+	return &interruptReader{
+		reader: reader,
+		limit:  limit,
+	}
+}
+
+func (r *interruptReader) Read(p []byte) (int, error) {
+	if r.limit <= 0 {
+		return 0, errBodyInterrupted
+	}
+
+	if int64(len(p)) > r.limit {
+		p = p[:r.limit]
+	}
+
+	// if body is shorter than limit, reader reports io.EOF
+	// and body is sent completely
+	n, err := r.reader.Read(p)
+	r.limit -= int64(n)
+
+	return n, err
+}
+
+func (r *interruptReader) Close() error {
+	return r.reader.Close()
+}
+
+// clientAbort cancels request context after the given duration.
+//
+// Used by Request.WithClientAbort to simulate client which drops
+// connection before receiving response.
+type clientAbort struct {
+	timer   *time.Timer
+	cancel  context.CancelFunc
+	aborted int32
+}
+
+func startClientAbort(
+	ctx context.Context, after time.Duration,
+) (context.Context, *clientAbort) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	ca := &clientAbort{
+		cancel: cancel,
+	}
+
+	ca.timer = time.AfterFunc(after, func() {
+		atomic.StoreInt32(&ca.aborted, 1)
+		cancel()
+	})
+
+	return ctx, ca
+}
+
+// stop disarms timer and wraps err if request was aborted.
+//
+// Context is canceled immediately if there is no response body,
+// otherwise it is canceled when response body is closed, so that
+// body can still be read.
+func (ca *clientAbort) stop(resp *http.Response, err error) error {
+	ca.timer.Stop()
+
+	if resp != nil && resp.Body != nil && resp.Body != http.NoBody {
+		resp.Body = &cancelReadCloser{
+			reader: resp.Body,
+			cancel: ca.cancel,
+		}
+	} else {
+		ca.cancel()
+	}
+
+	if err != nil && atomic.LoadInt32(&ca.aborted) != 0 {
+		return fmt.Errorf("%w: %s", errClientAborted, err.Error())
+	}
+
+	return err
+}
+
+// cancelReadCloser cancels context when reader is closed.
+type cancelReadCloser struct {
+	reader io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (r *cancelReadCloser) Read(p []byte) (int, error) {
+	return r.reader.Read(p)
+}
+
+func (r *cancelReadCloser) Close() error {
+	err := r.reader.Close()
+	r.cancel()
+	return err
+}
@@ -0,0 +1,248 @@
+package httpexpect
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterrupt_Reader(t *testing.T) {
+	t.Run("interrupted", func(t *testing.T) {
+		r := newInterruptReader(io.NopCloser(strings.NewReader("0123456789")), 4)
+
+		b, err := io.ReadAll(r)
+		assert.Equal(t, errBodyInterrupted, err)
+		assert.Equal(t, "0123", string(b))
+	})
+
+	t.Run("zero", func(t *testing.T) {
+		r := newInterruptReader(io.NopCloser(strings.NewReader("0123456789")), 0)
+
+		b, err := io.ReadAll(r)
+		assert.Equal(t, errBodyInterrupted, err)
+		assert.Empty(t, b)
+	})
+
+	t.Run("short body", func(t *testing.T) {
+		r := newInterruptReader(io.NopCloser(strings.NewReader("0123")), 10)
+
+		b, err := io.ReadAll(r)
+		assert.NoError(t, err)
+		assert.Equal(t, "0123", string(b))
+	})
+}
+
+func TestInterrupt_BodyInterrupt(t *testing.T) {
+	readHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write(b)
+	})
+
+	t.Run("server", func(t *testing.T) {
+		server := httptest.NewServer(readHandler)
+		defer server.Close()
+
+		req := NewRequestC(Config{
+			BaseURL:  server.URL,
+			Reporter: newMockReporter(t),
+		}, "POST", "/")
+
+		req.WithBytes(bytes.Repeat([]byte("x"), 1000)).
+			WithBodyInterrupt(100).
+			ExpectError().
+			Contains("request body interrupted")
+
+		req.chain.assert(t, success)
+	})
+
+	t.Run("handler", func(t *testing.T) {
+		req := NewRequestC(Config{
+			BaseURL:  "http://example.com",
+			Reporter: newMockReporter(t),
+		}, "POST", "/")
+
+		resp := req.WithHandler(readHandler).
+			WithText("0123456789").
+			WithBodyInterrupt(5).
+			Expect()
+
+		resp.Status(http.StatusBadRequest).
+			Body().Contains("request body interrupted")
+
+		resp.chain.assert(t, success)
+	})
+
+	t.Run("short body", func(t *testing.T) {
+		req := NewRequestC(Config{
+			BaseURL:  "http://example.com",
+			Reporter: newMockReporter(t),
+		}, "POST", "/")
+
+		resp := req.WithHandler(readHandler).
+			WithText("0123456789").
+			WithBodyInterrupt(100).
+			Expect()
+
+		resp.Status(http.StatusOK).
+			Body().IsEqual("0123456789")
+
+		resp.chain.assert(t, success)
+	})
+
+	t.Run("no retries", func(t *testing.T) {
+		server := httptest.NewServer(readHandler)
+		defer server.Close()
+
+		attempts := 0
+
+		req := NewRequestC(Config{
+			BaseURL:  server.URL,
+			Reporter: newMockReporter(t),
+		}, "POST", "/")
+
+		req.WithText("0123456789").
+			WithBodyInterrupt(5).
+			WithRetryPolicy(RetryAllErrors).
+			WithMaxRetries(3).
+			WithRetryDelay(0, 0).
+			WithTransformer(func(*http.Request) {
+				attempts++
+			}).
+			ExpectError()
+
+		req.chain.assert(t, success)
+		assert.Equal(t, 1, attempts)
+	})
+}
+
+func TestInterrupt_ClientAbort(t *testing.T) {
+	done := make(chan struct{})
+	defer close(done)
+
+	slowHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-done:
+		}
+	})
+
+	fastHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	t.Run("aborted", func(t *testing.T) {
+		server := httptest.NewServer(slowHandler)
+		defer server.Close()
+
+		req := NewRequestC(Config{
+			BaseURL:  server.URL,
+			Reporter: newMockReporter(t),
+		}, "GET", "/")
+
+		req.WithClientAbort(10 * time.Millisecond).
+			ExpectError().
+			Contains("request aborted by client")
+
+		req.chain.assert(t, success)
+	})
+
+	t.Run("completed", func(t *testing.T) {
+		server := httptest.NewServer(fastHandler)
+		defer server.Close()
+
+		req := NewRequestC(Config{
+			BaseURL:  server.URL,
+			Reporter: newMockReporter(t),
+		}, "GET", "/")
+
+		req.WithClientAbort(time.Minute).
+			Expect().
+			Status(http.StatusNoContent)
+
+		req.chain.assert(t, success)
+	})
+}
+
+func TestInterrupt_ClientAbortStop(t *testing.T) {
+	t.Run("no response", func(t *testing.T) {
+		ctx, abort := startClientAbort(context.Background(), time.Minute)
+
+		err := abort.stop(nil, errors.New("test"))
+		assert.Error(t, err)
+		assert.False(t, errors.Is(err, errClientAborted))
+
+		assert.Error(t, ctx.Err())
+	})
+
+	t.Run("no body", func(t *testing.T) {
+		ctx, abort := startClientAbort(context.Background(), time.Minute)
+
+		resp := &http.Response{
+			Body: http.NoBody,
+		}
+
+		err := abort.stop(resp, nil)
+		assert.NoError(t, err)
+
+		assert.Error(t, ctx.Err())
+	})
+
+	t.Run("body", func(t *testing.T) {
+		ctx, abort := startClientAbort(context.Background(), time.Minute)
+
+		body := newMockBody("test")
+		resp := &http.Response{
+			Body: body,
+		}
+
+		err := abort.stop(resp, nil)
+		assert.NoError(t, err)
+
+		assert.NoError(t, ctx.Err())
+
+		b, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, "test", string(b))
+
+		assert.NoError(t, ctx.Err())
+
+		assert.NoError(t, resp.Body.Close())
+		assert.Equal(t, 1, body.closeCount)
+
+		assert.Error(t, ctx.Err())
+	})
+}
+
+func TestInterrupt_ExpectError(t *testing.T) {
+	t.Run("response received", func(t *testing.T) {
+		req := NewRequestC(Config{
+			Client:   &mockClient{},
+			Reporter: newMockReporter(t),
+		}, "GET", "/")
+
+		req.ExpectError().chain.assert(t, failure)
+		req.chain.assert(t, failure)
+	})
+
+	t.Run("websocket", func(t *testing.T) {
+		req := NewRequestC(Config{
+			Client:   &mockClient{},
+			Reporter: newMockReporter(t),
+		}, "GET", "/").WithWebsocketUpgrade()
+
+		req.ExpectError().chain.assert(t, failure)
+		req.chain.assert(t, failure)
+	})
+}
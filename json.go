@@ -50,36 +50,43 @@ func jsonSchema(opChain *chain, value, schema interface{}) {
 		return
 	}
 
-	getString := func(in interface{}) (out string, ok bool) {
-		ok = true
-		defer func() {
-			if err := recover(); err != nil {
-				ok = false
-			}
-		}()
-		out = reflect.ValueOf(in).Convert(reflect.TypeOf("")).String()
+	schemaLoader, schemaData := jsonSchemaLoader(schema)
+
+	valueLoader := gojsonschema.NewGoLoader(value)
+
+	result, err := gojsonschema.Validate(schemaLoader, valueLoader)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{schema},
+			Errors: []error{
+				errors.New("expected: valid json schema"),
+				err,
+			},
+		})
 		return
 	}
 
-	var schemaLoader gojsonschema.JSONLoader
-	var schemaData interface{}
+	if !result.Valid() {
+		opChain.fail(AssertionFailure{
+			Type:     AssertMatchSchema,
+			Actual:   &AssertionValue{value},
+			Expected: &AssertionValue{schemaData},
+			Errors: append([]error{
+				errors.New("expected: value matches given json schema"),
+			}, jsonSchemaErrors(result)...),
+		})
+	}
+}
 
-	if str, ok := getString(schema); ok {
-		if ok, _ := regexp.MatchString(`^\w+://`, str); ok {
-			schemaLoader = gojsonschema.NewReferenceLoader(str)
-			schemaData = str
-		} else {
-			schemaLoader = gojsonschema.NewStringLoader(str)
-			schemaData, _ = schemaLoader.LoadJSON()
-		}
-	} else {
-		schemaLoader = gojsonschema.NewGoLoader(schema)
-		schemaData = schema
+func jsonEverySchema(opChain *chain, values []interface{}, schema interface{}) {
+	if opChain.failed() {
+		return
 	}
 
-	valueLoader := gojsonschema.NewGoLoader(value)
+	schemaLoader, schemaData := jsonSchemaLoader(schema)
 
-	result, err := gojsonschema.Validate(schemaLoader, valueLoader)
+	compiled, err := gojsonschema.NewSchema(schemaLoader)
 	if err != nil {
 		opChain.fail(AssertionFailure{
 			Type:   AssertValid,
@@ -92,18 +99,70 @@ func jsonSchema(opChain *chain, value, schema interface{}) {
 		return
 	}
 
-	if !result.Valid() {
-		errors := []error{
-			errors.New("expected: value matches given json schema"),
+	var (
+		failedIndices []int
+		failedErrors  []error
+	)
+
+	for index, value := range values {
+		result, err := compiled.Validate(gojsonschema.NewGoLoader(value))
+		if err != nil {
+			failedIndices = append(failedIndices, index)
+			failedErrors = append(failedErrors,
+				fmt.Errorf("element at index %d: %s", index, err))
+			continue
 		}
-		for _, err := range result.Errors() {
-			errors = append(errors, fmt.Errorf("%s", err))
+
+		if !result.Valid() {
+			failedIndices = append(failedIndices, index)
+			for _, err := range jsonSchemaErrors(result) {
+				failedErrors = append(failedErrors,
+					fmt.Errorf("element at index %d: %w", index, err))
+			}
 		}
+	}
+
+	if len(failedIndices) != 0 {
 		opChain.fail(AssertionFailure{
 			Type:     AssertMatchSchema,
-			Actual:   &AssertionValue{value},
+			Actual:   &AssertionValue{values},
 			Expected: &AssertionValue{schemaData},
-			Errors:   errors,
+			Errors: append([]error{
+				errors.New("expected: every array element matches given json schema"),
+				fmt.Errorf("elements at indices %v do not match", failedIndices),
+			}, failedErrors...),
 		})
 	}
 }
+
+func jsonSchemaLoader(schema interface{}) (gojsonschema.JSONLoader, interface{}) {
+	getString := func(in interface{}) (out string, ok bool) {
+		ok = true
+		defer func() {
+			if err := recover(); err != nil {
+				ok = false
+			}
+		}()
+		out = reflect.ValueOf(in).Convert(reflect.TypeOf("")).String()
+		return
+	}
+
+	if str, ok := getString(schema); ok {
+		if ok, _ := regexp.MatchString(`^\w+://`, str); ok {
+			return gojsonschema.NewReferenceLoader(str), str
+		}
+		loader := gojsonschema.NewStringLoader(str)
+		data, _ := loader.LoadJSON()
+		return loader, data
+	}
+
+	return gojsonschema.NewGoLoader(schema), schema
+}
+
+func jsonSchemaErrors(result *gojsonschema.Result) []error {
+	var errs []error
+	for _, err := range result.Errors() {
+		errs = append(errs, newSchemaError(err))
+	}
+	return errs
+}
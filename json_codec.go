@@ -0,0 +1,189 @@
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// JSONEncoder encodes Go values into JSON.
+//
+// It is used by Request.WithJSON, Websocket.WriteJSON, and
+// Websocket.CloseWithJSON. Default implementation uses encoding/json.
+//
+// Some third-party libraries, like json-iterator, implement this interface
+// directly. JSONEncoderFunc can be used to adapt a function.
+type JSONEncoder interface {
+	Marshal(value interface{}) ([]byte, error)
+}
+
+// JSONEncoderFunc is an adapter that allows a function to be used
+// as the JSONEncoder.
+//
+// Example:
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		Reporter:    t,
+//		JSONEncoder: httpexpect.JSONEncoderFunc(gojson.Marshal),
+//	})
+type JSONEncoderFunc func(value interface{}) ([]byte, error)
+
+// Marshal implements JSONEncoder.Marshal.
+func (f JSONEncoderFunc) Marshal(value interface{}) ([]byte, error) {
+	return f(value)
+}
+
+// JSONDecoder decodes JSON into Go values.
+//
+// It is used by Response.JSON, Response.JSONP, and WebsocketMessage.JSON.
+// Default implementation uses encoding/json.
+//
+// Decoder controls how the body is parsed, e.g. whether duplicate keys
+// or big numbers are accepted. After decoding, the value is converted
+// to canonical form as usual, so numbers are still represented as float64.
+//
+// Some third-party libraries, like json-iterator, implement this interface
+// directly. JSONDecoderFunc can be used to adapt a function.
+type JSONDecoder interface {
+	Unmarshal(data []byte, target interface{}) error
+}
+
+// JSONDecoderFunc is an adapter that allows a function to be used
+// as the JSONDecoder.
+//
+// Example:
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		Reporter:    t,
+//		JSONDecoder: httpexpect.JSONDecoderFunc(gojson.Unmarshal),
+//	})
+type JSONDecoderFunc func(data []byte, target interface{}) error
+
+// Unmarshal implements JSONDecoder.Unmarshal.
+func (f JSONDecoderFunc) Unmarshal(data []byte, target interface{}) error {
+	return f(data, target)
+}
+
+type defaultJSONEncoder struct{}
+
+func (defaultJSONEncoder) Marshal(value interface{}) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+type defaultJSONDecoder struct{}
+
+func (defaultJSONDecoder) Unmarshal(data []byte, target interface{}) error {
+	return json.Unmarshal(data, target)
+}
+
+func jsonEncoderOrDefault(encoder JSONEncoder) JSONEncoder {
+	if encoder == nil {
+		return defaultJSONEncoder{}
+	}
+	return encoder
+}
+
+func jsonDecoderOrDefault(decoder JSONDecoder) JSONDecoder {
+	if decoder == nil {
+		return defaultJSONDecoder{}
+	}
+	return decoder
+}
+
+// findDuplicateKeys returns paths of duplicate keys in JSON objects,
+// e.g. "$.items[0].id". Every path is reported once.
+func findDuplicateKeys(data []byte) ([]string, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var duplicates []string
+
+	var walk func(path string) error
+	walk = func(path string) error {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		delim, ok := tok.(json.Delim)
+		if !ok {
+			return nil
+		}
+
+		switch delim {
+		case '{':
+			seen := map[string]int{}
+			for dec.More() {
+				tok, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				key, ok := tok.(string)
+				if !ok {
+					return fmt.Errorf("unexpected object key %v", tok)
+				}
+				keyPath := path + "." + key
+				seen[key]++
+				if seen[key] == 2 {
+					duplicates = append(duplicates, keyPath)
+				}
+				if err := walk(keyPath); err != nil {
+					return err
+				}
+			}
+
+		case '[':
+			for i := 0; dec.More(); i++ {
+				if err := walk(fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+
+		// closing delimiter
+		_, err = dec.Token()
+		return err
+	}
+
+	if err := walk("$"); err != nil {
+		return nil, err
+	}
+
+	return duplicates, nil
+}
+
+// jsonDepthExceeds reports whether nesting depth of objects and arrays
+// in JSON data is greater than limit. Data is not validated.
+func jsonDepthExceeds(data []byte, limit int) bool {
+	depth := 0
+	inString := false
+	escaped := false
+
+	for _, c := range data {
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			continue
+		}
+
+		switch c {
+		case '"':
+			inString = true
+		case '{', '[':
+			depth++
+			if depth > limit {
+				return true
+			}
+		case '}', ']':
+			depth--
+		}
+	}
+
+	return false
+}
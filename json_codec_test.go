@@ -0,0 +1,297 @@
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONCodec_Func(t *testing.T) {
+	encoder := JSONEncoderFunc(func(value interface{}) ([]byte, error) {
+		return []byte(`"encoded"`), nil
+	})
+
+	b, err := encoder.Marshal(123)
+	assert.NoError(t, err)
+	assert.Equal(t, `"encoded"`, string(b))
+
+	decoder := JSONDecoderFunc(func(data []byte, target interface{}) error {
+		*(target.(*interface{})) = string(data)
+		return nil
+	})
+
+	var value interface{}
+	err = decoder.Unmarshal([]byte("data"), &value)
+	assert.NoError(t, err)
+	assert.Equal(t, "data", value)
+}
+
+func TestJSONCodec_Default(t *testing.T) {
+	config := Config{
+		Reporter: newMockReporter(t),
+	}.withDefaults()
+
+	b, err := config.JSONEncoder.Marshal(map[string]interface{}{"a": 1})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":1}`, string(b))
+
+	var value interface{}
+	err = config.JSONDecoder.Unmarshal([]byte(`{"a":1}`), &value)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": 1.0}, value)
+
+	assert.Equal(t, defaultJSONEncoder{}, jsonEncoderOrDefault(nil))
+	assert.Equal(t, defaultJSONDecoder{}, jsonDecoderOrDefault(nil))
+}
+
+func TestJSONCodec_Request(t *testing.T) {
+	client := &mockClient{}
+
+	var encoded interface{}
+
+	config := Config{
+		Client:   client,
+		Reporter: newMockReporter(t),
+		JSONEncoder: JSONEncoderFunc(func(value interface{}) ([]byte, error) {
+			encoded = value
+			return []byte(`{"custom":true}`), nil
+		}),
+	}
+
+	t.Run("custom encoder", func(t *testing.T) {
+		req := NewRequestC(config, "POST", "url").
+			WithJSON(map[string]interface{}{"key": "value"})
+
+		resp := req.Expect()
+		resp.chain.assert(t, success)
+
+		assert.Equal(t, map[string]interface{}{"key": "value"}, encoded)
+		assert.Equal(t, `{"custom":true}`, resp.Body().Raw())
+	})
+
+	t.Run("encoder error", func(t *testing.T) {
+		config := config
+		config.JSONEncoder = JSONEncoderFunc(func(value interface{}) ([]byte, error) {
+			return nil, errors.New("encoder error")
+		})
+
+		req := NewRequestC(config, "POST", "url").
+			WithJSON(map[string]interface{}{"key": "value"})
+
+		req.chain.assert(t, failure)
+	})
+}
+
+func TestJSONCodec_Response(t *testing.T) {
+	newResp := func(decoder JSONDecoder, contentType, body string) *Response {
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {contentType},
+			},
+			Body: io.NopCloser(bytes.NewBufferString(body)),
+		}
+
+		return NewResponseC(Config{
+			Reporter:    newMockReporter(t),
+			JSONDecoder: decoder,
+		}, httpResp)
+	}
+
+	useNumber := JSONDecoderFunc(func(data []byte, target interface{}) error {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		return dec.Decode(target)
+	})
+
+	failing := JSONDecoderFunc(func(data []byte, target interface{}) error {
+		return errors.New("decoder error")
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		resp := newResp(useNumber, "application/json", `{"a": 123}`)
+
+		resp.JSON().Object().HasValue("a", 123)
+		resp.chain.assert(t, success)
+	})
+
+	t.Run("JSON error", func(t *testing.T) {
+		resp := newResp(failing, "application/json", `{"a": 123}`)
+
+		resp.JSON()
+		resp.chain.assert(t, failure)
+	})
+
+	t.Run("JSONP", func(t *testing.T) {
+		resp := newResp(useNumber, "application/javascript", `cb({"a": 123})`)
+
+		resp.JSONP("cb").Object().HasValue("a", 123)
+		resp.chain.assert(t, success)
+	})
+
+	t.Run("JSONP error", func(t *testing.T) {
+		resp := newResp(failing, "application/javascript", `cb({"a": 123})`)
+
+		resp.JSONP("cb")
+		resp.chain.assert(t, failure)
+	})
+}
+
+func TestJSONCodec_WebsocketMessage(t *testing.T) {
+	decoded := false
+
+	config := Config{
+		Reporter: newMockReporter(t),
+		JSONDecoder: JSONDecoderFunc(func(data []byte, target interface{}) error {
+			decoded = true
+			return json.Unmarshal(data, target)
+		}),
+	}
+
+	msg := NewWebsocketMessageC(config, websocket.TextMessage, []byte(`{"a":1}`))
+
+	msg.JSON().Object().HasValue("a", 1)
+	msg.chain.assert(t, success)
+
+	assert.True(t, decoded)
+}
+
+func TestJSONCodec_DuplicateKeys(t *testing.T) {
+	cases := []struct {
+		name       string
+		data       string
+		duplicates []string
+	}{
+		{
+			name: "no duplicates",
+			data: `{"a": 1, "b": {"a": 2}, "c": [{"a": 3}, {"a": 4}]}`,
+		},
+		{
+			name: "scalar",
+			data: `123`,
+		},
+		{
+			name:       "root",
+			data:       `{"a": 1, "a": 2, "a": 3}`,
+			duplicates: []string{"$.a"},
+		},
+		{
+			name:       "nested",
+			data:       `{"a": {"b": 1, "c": 2, "b": 3}, "d": [{"e": 1}, {"e": 2, "e": 3}]}`,
+			duplicates: []string{"$.a.b", "$.d[1].e"},
+		},
+		{
+			name:       "root array",
+			data:       `[{"a": 1}, [{"b": 1, "b": 2}]]`,
+			duplicates: []string{"$[1][0].b"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			duplicates, err := findDuplicateKeys([]byte(tc.data))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.duplicates, duplicates)
+		})
+	}
+
+	t.Run("invalid json", func(t *testing.T) {
+		_, err := findDuplicateKeys([]byte(`{"a": `))
+		assert.Error(t, err)
+	})
+}
+
+func TestJSONCodec_StrictJSON(t *testing.T) {
+	newResp := func(strict bool, contentType, body string) *Response {
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {contentType},
+			},
+			Body: io.NopCloser(bytes.NewBufferString(body)),
+		}
+
+		return NewResponseC(Config{
+			Reporter:   newMockReporter(t),
+			StrictJSON: strict,
+		}, httpResp)
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		resp := newResp(false, "application/json", `{"a": 1, "a": 2}`)
+		resp.JSON().Object().HasValue("a", 2)
+		resp.chain.assert(t, success)
+
+		resp = newResp(true, "application/json", `{"a": 1, "b": 2}`)
+		resp.JSON().Object().HasValue("a", 1)
+		resp.chain.assert(t, success)
+
+		resp = newResp(true, "application/json", `{"a": 1, "a": 2}`)
+		resp.JSON()
+		resp.chain.assert(t, failure)
+	})
+
+	t.Run("JSONP", func(t *testing.T) {
+		resp := newResp(false, "application/javascript", `cb({"a": 1, "a": 2})`)
+		resp.JSONP("cb")
+		resp.chain.assert(t, success)
+
+		resp = newResp(true, "application/javascript", `cb({"a": 1, "a": 2})`)
+		resp.JSONP("cb")
+		resp.chain.assert(t, failure)
+	})
+
+	t.Run("warning", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {"application/json"},
+			},
+			Body: io.NopCloser(bytes.NewBufferString(`{"a": {"b": 1, "b": 2}}`)),
+		}
+
+		resp := NewResponseC(Config{
+			AssertionHandler: handler,
+			StrictJSON:       true,
+		}, httpResp)
+
+		resp.Warn().JSON()
+
+		assert.NotNil(t, handler.failure)
+		assert.Equal(t, SeverityWarning, handler.failure.Severity)
+		assert.Equal(t, []error{
+			errors.New("expected: json objects don't have duplicate keys"),
+			errors.New("duplicate key at $.a.b"),
+		}, handler.failure.Errors)
+	})
+}
+
+func TestJSONCodec_Depth(t *testing.T) {
+	cases := []struct {
+		data    string
+		limit   int
+		exceeds bool
+	}{
+		{`1`, 1, false},
+		{`{}`, 1, false},
+		{`{"a": []}`, 1, true},
+		{`{"a": []}`, 2, false},
+		{`[[], [], {}]`, 2, false},
+		{`["[[[", "\"[[["]`, 1, false},
+		{`["\\", [1]]`, 1, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.data, func(t *testing.T) {
+			assert.Equal(t, tc.exceeds, jsonDepthExceeds([]byte(tc.data), tc.limit))
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// JSONFormatter is a Formatter implementation that produces machine-readable
+// messages.
+//
+// Every message is a single-line JSON object (see FailureRecord), so that
+// test output can be collected and processed by CI tooling, e.g. to
+// aggregate and categorize assertion failures across many tests.
+//
+// Example:
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		Reporter:  httpexpect.NewAssertReporter(t),
+//		Formatter: &httpexpect.JSONFormatter{},
+//	})
+type JSONFormatter struct {
+	// Exclude HTTP request from failure record.
+	DisableRequests bool
+
+	// Exclude HTTP response from failure record.
+	DisableResponses bool
+
+	// Exclude stacktrace from failure record.
+	DisableStacktrace bool
+}
+
+// FailureRecord defines JSON object produced by JSONFormatter.
+type FailureRecord struct {
+	// "success" or "failure".
+	Status string `json:"status"`
+
+	// Failure code, a stable identifier of the failed check, which can be
+	// used to categorize failures, e.g. "AssertEqual".
+	// Empty for success records.
+	Code string `json:"code,omitempty"`
+
+	// Failure severity, e.g. "SeverityError".
+	// Empty for success records.
+	Severity string `json:"severity,omitempty"`
+
+	TestName    string   `json:"test_name,omitempty"`
+	RequestName string   `json:"request_name,omitempty"`
+	Path        []string `json:"path"`
+
+	Errors []string `json:"errors,omitempty"`
+
+	// Individual JSON Schema validation errors, if failure is caused by
+	// schema mismatch (see SchemaError).
+	SchemaErrors []*SchemaError `json:"schema_errors,omitempty"`
+
+	Actual    interface{} `json:"actual,omitempty"`
+	Expected  interface{} `json:"expected,omitempty"`
+	Reference interface{} `json:"reference,omitempty"`
+	Delta     interface{} `json:"delta,omitempty"`
+
+	Request  *FailureRecordRequest  `json:"request,omitempty"`
+	Response *FailureRecordResponse `json:"response,omitempty"`
+
+	Stacktrace []string `json:"stacktrace,omitempty"`
+}
+
+// FailureRecordRequest holds HTTP request metadata in FailureRecord.
+type FailureRecordRequest struct {
+	Method  string              `json:"method"`
+	URL     string              `json:"url"`
+	Headers map[string][]string `json:"headers,omitempty"`
+}
+
+// FailureRecordResponse holds HTTP response metadata in FailureRecord.
+type FailureRecordResponse struct {
+	StatusCode    int                 `json:"status_code"`
+	Headers       map[string][]string `json:"headers,omitempty"`
+	RoundTripTime string              `json:"round_trip_time,omitempty"`
+}
+
+// FormatSuccess implements Formatter.FormatSuccess.
+func (f *JSONFormatter) FormatSuccess(ctx *AssertionContext) string {
+	record := FailureRecord{
+		Status: "success",
+	}
+
+	f.fillContext(&record, ctx)
+
+	return f.encode(&record)
+}
+
+// FormatFailure implements Formatter.FormatFailure.
+func (f *JSONFormatter) FormatFailure(
+	ctx *AssertionContext, failure *AssertionFailure,
+) string {
+	record := FailureRecord{
+		Status:   "failure",
+		Code:     failure.Type.String(),
+		Severity: failure.Severity.String(),
+	}
+
+	if ctx.Redactor != nil {
+		failure = redactFailure(ctx.Redactor, failure)
+	}
+
+	f.fillContext(&record, ctx)
+
+	for _, err := range failure.Errors {
+		if refIsNil(err) {
+			continue
+		}
+		record.Errors = append(record.Errors, ctx.Redactor.RedactString(err.Error()))
+	}
+
+	for _, schemaErr := range failure.SchemaErrors() {
+		schemaErrCopy := *schemaErr
+		schemaErrCopy.Message = ctx.Redactor.RedactString(schemaErr.Message)
+
+		record.SchemaErrors = append(record.SchemaErrors, &schemaErrCopy)
+	}
+
+	if failure.Actual != nil {
+		record.Actual = jsonRecordValue(failure.Actual.Value)
+	}
+	if failure.Expected != nil {
+		record.Expected = jsonRecordValue(failure.Expected.Value)
+	}
+	if failure.Reference != nil {
+		record.Reference = jsonRecordValue(failure.Reference.Value)
+	}
+	if failure.Delta != nil {
+		record.Delta = jsonRecordValue(failure.Delta.Value)
+	}
+
+	if !f.DisableRequests && ctx.Request != nil && ctx.Request.httpReq != nil {
+		httpReq := ctx.Request.httpReq
+
+		record.Request = &FailureRecordRequest{
+			Method:  httpReq.Method,
+			Headers: ctx.Redactor.RedactHeader(httpReq.Header),
+		}
+		if httpReq.URL != nil {
+			record.Request.URL = ctx.Redactor.redactURL(httpReq.URL).String()
+		}
+	}
+
+	if !f.DisableResponses && ctx.Response != nil && ctx.Response.httpResp != nil {
+		httpResp := ctx.Response.httpResp
+
+		record.Response = &FailureRecordResponse{
+			StatusCode: httpResp.StatusCode,
+			Headers:    ctx.Redactor.RedactHeader(httpResp.Header),
+		}
+		if ctx.Response.rtt != nil {
+			record.Response.RoundTripTime = ctx.Response.rtt.String()
+		}
+	}
+
+	if !f.DisableStacktrace {
+		for _, entry := range failure.Stacktrace {
+			if entry.IsEntrypoint {
+				break
+			}
+			record.Stacktrace = append(record.Stacktrace,
+				fmt.Sprintf("%s() at %s:%d", entry.FuncName, entry.File, entry.Line))
+		}
+	}
+
+	return f.encode(&record)
+}
+
+func (f *JSONFormatter) fillContext(record *FailureRecord, ctx *AssertionContext) {
+	record.TestName = ctx.TestName
+	record.RequestName = ctx.RequestName
+	record.Path = ctx.Path
+
+	if record.Path == nil {
+		record.Path = []string{}
+	}
+}
+
+func (f *JSONFormatter) encode(record *FailureRecord) string {
+	b, err := json.Marshal(record)
+	if err != nil {
+		panic(err)
+	}
+
+	return string(b)
+}
+
+// Convert value to something that can be encoded to JSON.
+// Values that can't be encoded are replaced with their string representation.
+func jsonRecordValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case http.Header:
+		return map[string][]string(v)
+
+	case AssertionRange:
+		return map[string]interface{}{
+			"min": jsonRecordValue(v.Min),
+			"max": jsonRecordValue(v.Max),
+		}
+
+	case AssertionList:
+		list := make([]interface{}, 0, len(v))
+		for _, e := range v {
+			list = append(list, jsonRecordValue(e))
+		}
+		return list
+	}
+
+	if _, err := json.Marshal(value); err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return value
+}
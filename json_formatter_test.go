@@ -0,0 +1,171 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONFormatter_Success(t *testing.T) {
+	formatter := &JSONFormatter{}
+
+	msg := formatter.FormatSuccess(&AssertionContext{
+		TestName: "TestFoo",
+		Path:     []string{"Request()", "Expect()"},
+	})
+
+	assert.False(t, strings.Contains(msg, "\n"))
+
+	var record FailureRecord
+	require.NoError(t, json.Unmarshal([]byte(msg), &record))
+
+	assert.Equal(t, FailureRecord{
+		Status:   "success",
+		TestName: "TestFoo",
+		Path:     []string{"Request()", "Expect()"},
+	}, record)
+}
+
+func TestJSONFormatter_Failure(t *testing.T) {
+	rtt := time.Second
+
+	ctx := &AssertionContext{
+		TestName:    "TestFoo",
+		RequestName: "MyRequest",
+		Path:        []string{"Request()", "Expect()", "Status()"},
+		Request: &Request{
+			httpReq: &http.Request{
+				Method: "GET",
+				URL:    &url.URL{Scheme: "http", Host: "example.com", Path: "/path"},
+				Header: http.Header{"Accept": {"application/json"}},
+			},
+		},
+		Response: &Response{
+			httpResp: &http.Response{
+				StatusCode: http.StatusNotFound,
+				Header:     http.Header{"Content-Type": {"text/plain"}},
+			},
+			rtt: &rtt,
+		},
+	}
+
+	failure := &AssertionFailure{
+		Type:     AssertEqual,
+		Severity: SeverityError,
+		Errors: []error{
+			errors.New("unexpected status"),
+			nil,
+		},
+		Actual:   &AssertionValue{404},
+		Expected: &AssertionValue{map[string]interface{}{"foo": "bar"}},
+		Stacktrace: []StacktraceEntry{
+			{FuncName: "TestFoo", File: "foo_test.go", Line: 10},
+			{FuncName: "tRunner", File: "testing.go", Line: 20, IsEntrypoint: true},
+		},
+	}
+
+	t.Run("all fields", func(t *testing.T) {
+		formatter := &JSONFormatter{}
+
+		msg := formatter.FormatFailure(ctx, failure)
+
+		var record FailureRecord
+		require.NoError(t, json.Unmarshal([]byte(msg), &record))
+
+		assert.Equal(t, FailureRecord{
+			Status:      "failure",
+			Code:        "AssertEqual",
+			Severity:    "SeverityError",
+			TestName:    "TestFoo",
+			RequestName: "MyRequest",
+			Path:        []string{"Request()", "Expect()", "Status()"},
+			Errors:      []string{"unexpected status"},
+			Actual:      404.0,
+			Expected:    map[string]interface{}{"foo": "bar"},
+			Request: &FailureRecordRequest{
+				Method:  "GET",
+				URL:     "http://example.com/path",
+				Headers: map[string][]string{"Accept": {"application/json"}},
+			},
+			Response: &FailureRecordResponse{
+				StatusCode:    http.StatusNotFound,
+				Headers:       map[string][]string{"Content-Type": {"text/plain"}},
+				RoundTripTime: "1s",
+			},
+			Stacktrace: []string{"TestFoo() at foo_test.go:10"},
+		}, record)
+	})
+
+	t.Run("disabled fields", func(t *testing.T) {
+		formatter := &JSONFormatter{
+			DisableRequests:   true,
+			DisableResponses:  true,
+			DisableStacktrace: true,
+		}
+
+		msg := formatter.FormatFailure(ctx, failure)
+
+		var record FailureRecord
+		require.NoError(t, json.Unmarshal([]byte(msg), &record))
+
+		assert.Nil(t, record.Request)
+		assert.Nil(t, record.Response)
+		assert.Nil(t, record.Stacktrace)
+	})
+}
+
+func TestJSONFormatter_Values(t *testing.T) {
+	cases := []struct {
+		name  string
+		value interface{}
+		want  interface{}
+	}{
+		{
+			name:  "range",
+			value: AssertionRange{Min: 1, Max: 2},
+			want:  map[string]interface{}{"min": 1.0, "max": 2.0},
+		},
+		{
+			name:  "list",
+			value: AssertionList{"a", "b"},
+			want:  []interface{}{"a", "b"},
+		},
+		{
+			name:  "nan",
+			value: math.NaN(),
+			want:  "NaN",
+		},
+		{
+			name:  "func",
+			value: (func())(nil),
+			want:  "<nil>",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			formatter := &JSONFormatter{}
+
+			msg := formatter.FormatFailure(&AssertionContext{}, &AssertionFailure{
+				Type:     AssertInRange,
+				Actual:   &AssertionValue{tc.value},
+				Expected: &AssertionValue{tc.value},
+			})
+
+			var record FailureRecord
+			require.NoError(t, json.Unmarshal([]byte(msg), &record))
+
+			assert.Equal(t, tc.want, record.Actual)
+			assert.Equal(t, tc.want, record.Expected)
+			assert.Equal(t, []string{}, record.Path)
+		})
+	}
+}
@@ -0,0 +1,691 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// JSONAPI provides methods to inspect JSON:API document.
+//
+// See https://jsonapi.org/format/ for the specification.
+type JSONAPI struct {
+	noCopy noCopy
+	config Config
+	chain  *chain
+	origin *Expect
+	base   *url.URL
+	value  map[string]interface{}
+}
+
+// JSONAPI returns a new JSONAPI instance with response body decoded as
+// JSON:API document.
+//
+// JSONAPI succeeds if Content-Type header is "application/vnd.api+json",
+// and body is a JSON object that contains at least one of "data", "errors"
+// or "meta" members, and doesn't contain both "data" and "errors".
+//
+// Expected media type can be overridden via ContentOpts.
+//
+// Example:
+//
+//	doc := resp.JSONAPI()
+//	doc.Resource().HasType("articles")
+//	doc.Resource().Attribute("title").IsEqual("Hello")
+//
+//	resp.JSONAPI(ContentOpts{MediaType: "application/json"})
+func (r *Response) JSONAPI(options ...ContentOpts) *JSONAPI {
+	opChain := r.chain.enter("JSONAPI()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newJSONAPI(opChain, r.config, nil, nil, nil)
+	}
+
+	if len(options) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple options arguments"),
+			},
+		})
+		return newJSONAPI(opChain, r.config, nil, nil, nil)
+	}
+
+	if !r.checkContentOptions(opChain, options, "application/vnd.api+json") {
+		return newJSONAPI(opChain, r.config, nil, nil, nil)
+	}
+
+	content, ok := r.getContent(opChain, "JSONAPI()")
+	if !ok {
+		return newJSONAPI(opChain, r.config, nil, nil, nil)
+	}
+
+	value, ok := r.decodeJSON(opChain, content, content)
+	if !ok {
+		return newJSONAPI(opChain, r.config, nil, nil, nil)
+	}
+
+	doc, ok := value.(map[string]interface{})
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				errors.New("expected: JSON:API document is an object"),
+			},
+		})
+		return newJSONAPI(opChain, r.config, nil, nil, nil)
+	}
+
+	_, hasData := doc["data"]
+	_, hasErrors := doc["errors"]
+	_, hasMeta := doc["meta"]
+
+	if !hasData && !hasErrors && !hasMeta {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{doc},
+			Errors: []error{
+				errors.New(`expected: JSON:API document contains` +
+					` "data", "errors" or "meta"`),
+			},
+		})
+		return newJSONAPI(opChain, r.config, nil, nil, nil)
+	}
+
+	if hasData && hasErrors {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{doc},
+			Errors: []error{
+				errors.New(`expected: JSON:API document doesn't contain` +
+					` both "data" and "errors"`),
+			},
+		})
+		return newJSONAPI(opChain, r.config, nil, nil, nil)
+	}
+
+	var base *url.URL
+	if r.httpResp.Request != nil {
+		base = r.httpResp.Request.URL
+	}
+
+	return newJSONAPI(opChain, r.config, r.origin, base, doc)
+}
+
+func newJSONAPI(
+	parent *chain, config Config, origin *Expect, base *url.URL,
+	value map[string]interface{},
+) *JSONAPI {
+	return &JSONAPI{
+		config: config,
+		chain:  parent.clone(),
+		origin: origin,
+		base:   base,
+		value:  value,
+	}
+}
+
+// Alias is similar to Value.Alias.
+func (j *JSONAPI) Alias(name string) *JSONAPI {
+	opChain := j.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	j.chain.setAlias(name)
+	return j
+}
+
+// Document returns a new Object instance with the whole document.
+func (j *JSONAPI) Document() *Object {
+	opChain := j.chain.enter("Document()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	return newObject(opChain, j.value)
+}
+
+// Data returns a new Value instance with primary data of the document.
+// Primary data may be a resource object, an array of resource objects,
+// or null.
+//
+// Example:
+//
+//	doc.Data().Array().Length().IsEqual(10)
+func (j *JSONAPI) Data() *Value {
+	opChain := j.chain.enter("Data()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newValue(opChain, nil)
+	}
+
+	data, ok := j.member(opChain, "data")
+	if !ok {
+		return newValue(opChain, nil)
+	}
+
+	return newValue(opChain, data)
+}
+
+// Resource returns a new JSONAPIResource instance for primary data,
+// which should be a single resource object.
+//
+// Example:
+//
+//	doc.Resource().HasType("articles").ID().IsEqual("1")
+func (j *JSONAPI) Resource() *JSONAPIResource {
+	opChain := j.chain.enter("Resource()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newJSONAPIResource(opChain, nil)
+	}
+
+	data, ok := j.member(opChain, "data")
+	if !ok {
+		return newJSONAPIResource(opChain, nil)
+	}
+
+	res, ok := data.(map[string]interface{})
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{data},
+			Errors: []error{
+				errors.New(`expected: "data" is a single resource object`),
+			},
+		})
+		return newJSONAPIResource(opChain, nil)
+	}
+
+	return newJSONAPIResource(opChain, res)
+}
+
+// Resources returns JSONAPIResource instances for primary data, which
+// should be an array of resource objects.
+//
+// Example:
+//
+//	for _, res := range doc.Resources() {
+//		res.HasType("articles")
+//	}
+func (j *JSONAPI) Resources() []*JSONAPIResource {
+	opChain := j.chain.enter("Resources()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return []*JSONAPIResource{}
+	}
+
+	data, ok := j.member(opChain, "data")
+	if !ok {
+		return []*JSONAPIResource{}
+	}
+
+	return j.resourceList(opChain, "data", data)
+}
+
+// Included returns JSONAPIResource instances for resources from
+// "included" member. If there is no such member, empty slice is returned.
+//
+// Example:
+//
+//	doc.Included()[0].HasType("people")
+func (j *JSONAPI) Included() []*JSONAPIResource {
+	opChain := j.chain.enter("Included()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return []*JSONAPIResource{}
+	}
+
+	included, ok := j.value["included"]
+	if !ok {
+		return []*JSONAPIResource{}
+	}
+
+	return j.resourceList(opChain, "included", included)
+}
+
+// Meta returns a new Object instance with "meta" member of the document.
+//
+// Example:
+//
+//	doc.Meta().HasValue("total", 42)
+func (j *JSONAPI) Meta() *Object {
+	opChain := j.chain.enter("Meta()")
+	defer opChain.leave()
+
+	return jsonapiObject(opChain, j.value, "meta")
+}
+
+// Links returns a new Object instance with "links" member of the document.
+//
+// Example:
+//
+//	doc.Links().HasValue("self", "/articles?page=2")
+func (j *JSONAPI) Links() *Object {
+	opChain := j.chain.enter("Links()")
+	defer opChain.leave()
+
+	return jsonapiObject(opChain, j.value, "links")
+}
+
+// NextLink returns a new String instance with URL of the next page,
+// taken from "links.next" member. Link may be either a string or a link
+// object with "href" member.
+//
+// If document has no next link, e.g. on the last page, empty string is
+// returned. To fetch the next page, use NextPage.
+//
+// Example:
+//
+//	doc.NextLink().NotEmpty()
+func (j *JSONAPI) NextLink() *String {
+	opChain := j.chain.enter("NextLink()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	next, _ := j.nextLink(opChain)
+
+	return newString(opChain, next)
+}
+
+// NextPage returns a new Request instance that fetches the next page,
+// i.e. link returned by NextLink.
+//
+// Link is resolved relative to URL of the request that produced this
+// document, and request is sent through the same Expect instance.
+// If document has no next link, failure is reported.
+//
+// Example:
+//
+//	doc := e.GET("/articles").Expect().Status(http.StatusOK).JSONAPI()
+//	for doc.NextLink().Raw() != "" {
+//		doc = doc.NextPage().Expect().Status(http.StatusOK).JSONAPI()
+//	}
+func (j *JSONAPI) NextPage() *Request {
+	opChain := j.chain.enter("NextPage()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newRequest(followChain(opChain), j.config, http.MethodGet, "")
+	}
+
+	if j.origin == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected NextPage() call for response" +
+					" that was not created by Expect"),
+			},
+		})
+		return newRequest(followChain(opChain), j.config, http.MethodGet, "")
+	}
+
+	next, ok := j.nextLink(opChain)
+	if !ok {
+		return newRequest(followChain(opChain), j.config, http.MethodGet, "")
+	}
+
+	if next == "" {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{j.value["links"]},
+			Errors: []error{
+				errors.New(`expected: document has "links.next" member`),
+			},
+		})
+		return newRequest(followChain(opChain), j.config, http.MethodGet, "")
+	}
+
+	return followLink(opChain, j.origin, j.base, next)
+}
+
+func (j *JSONAPI) nextLink(opChain *chain) (string, bool) {
+	links, _ := j.value["links"].(map[string]interface{})
+
+	switch next := links["next"].(type) {
+	case nil:
+		return "", true
+
+	case string:
+		return next, true
+
+	case map[string]interface{}:
+		if href, ok := next["href"].(string); ok {
+			return href, true
+		}
+	}
+
+	opChain.fail(AssertionFailure{
+		Type:   AssertValid,
+		Actual: &AssertionValue{links["next"]},
+		Errors: []error{
+			errors.New(`expected: "links.next" is a string or a link object`),
+		},
+	})
+	return "", false
+}
+
+// Errors returns a new Array instance with error objects from "errors"
+// member of the document.
+//
+// Example:
+//
+//	doc.Errors().Length().IsEqual(1)
+func (j *JSONAPI) Errors() *Array {
+	opChain := j.chain.enter("Errors()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	errs, ok := j.member(opChain, "errors")
+	if !ok {
+		return newArray(opChain, nil)
+	}
+
+	arr, ok := errs.([]interface{})
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{errs},
+			Errors: []error{
+				errors.New(`expected: "errors" is an array`),
+			},
+		})
+		return newArray(opChain, nil)
+	}
+
+	return newArray(opChain, arr)
+}
+
+// HasError succeeds if document contains error object with all given
+// members, e.g. "status", "code" or "title".
+//
+// Note that JSON:API requires "status" to be a string.
+//
+// Example:
+//
+//	doc.HasError(map[string]interface{}{
+//		"status": "422",
+//		"code":   "invalid_email",
+//	})
+func (j *JSONAPI) HasError(fields map[string]interface{}) *JSONAPI {
+	opChain := j.chain.enter("HasError()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return j
+	}
+
+	errs, ok := j.member(opChain, "errors")
+	if !ok {
+		return j
+	}
+
+	expected, ok := canonMap(opChain, fields)
+	if !ok {
+		return j
+	}
+
+	list, _ := errs.([]interface{})
+
+	for _, e := range list {
+		if obj, ok := e.(map[string]interface{}); ok && isSubset(obj, expected) {
+			return j
+		}
+	}
+
+	opChain.fail(AssertionFailure{
+		Type:     AssertContainsSubset,
+		Actual:   &AssertionValue{errs},
+		Expected: &AssertionValue{expected},
+		Errors: []error{
+			errors.New("expected: document contains matching error object"),
+		},
+	})
+
+	return j
+}
+
+func (j *JSONAPI) member(opChain *chain, name string) (interface{}, bool) {
+	value, ok := j.value[name]
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{j.value},
+			Expected: &AssertionValue{name},
+			Errors: []error{
+				fmt.Errorf("expected: JSON:API document contains %q", name),
+			},
+		})
+		return nil, false
+	}
+
+	return value, true
+}
+
+func (j *JSONAPI) resourceList(
+	opChain *chain, name string, value interface{},
+) []*JSONAPIResource {
+	list, ok := value.([]interface{})
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				fmt.Errorf("expected: %q is an array of resource objects", name),
+			},
+		})
+		return []*JSONAPIResource{}
+	}
+
+	ret := make([]*JSONAPIResource, 0, len(list))
+
+	for index, element := range list {
+		func() {
+			resChain := opChain.replace("%s[%d]", name, index)
+			defer resChain.leave()
+
+			res, ok := element.(map[string]interface{})
+			if !ok {
+				resChain.fail(AssertionFailure{
+					Type:   AssertValid,
+					Actual: &AssertionValue{element},
+					Errors: []error{
+						errors.New("expected: resource object"),
+					},
+				})
+			}
+
+			ret = append(ret, newJSONAPIResource(resChain, res))
+		}()
+	}
+
+	return ret
+}
+
+// JSONAPIResource provides methods to inspect JSON:API resource object.
+type JSONAPIResource struct {
+	noCopy noCopy
+	chain  *chain
+	value  map[string]interface{}
+}
+
+func newJSONAPIResource(parent *chain, value map[string]interface{}) *JSONAPIResource {
+	return &JSONAPIResource{chain: parent.clone(), value: value}
+}
+
+// Raw returns underlying resource object.
+func (r *JSONAPIResource) Raw() map[string]interface{} {
+	return r.value
+}
+
+// ID returns a new String instance with resource "id".
+func (r *JSONAPIResource) ID() *String {
+	opChain := r.chain.enter("ID()")
+	defer opChain.leave()
+
+	return jsonapiString(opChain, r.value, "id")
+}
+
+// Type returns a new String instance with resource "type".
+func (r *JSONAPIResource) Type() *String {
+	opChain := r.chain.enter("Type()")
+	defer opChain.leave()
+
+	return jsonapiString(opChain, r.value, "type")
+}
+
+// HasType succeeds if resource has given "type".
+//
+// Example:
+//
+//	res.HasType("articles")
+func (r *JSONAPIResource) HasType(typ string) *JSONAPIResource {
+	opChain := r.chain.enter("HasType(%q)", typ)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if actual := r.value["type"]; actual != typ {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{actual},
+			Expected: &AssertionValue{typ},
+			Errors: []error{
+				errors.New("expected: resource types are equal"),
+			},
+		})
+	}
+
+	return r
+}
+
+// Attributes returns a new Object instance with resource "attributes".
+func (r *JSONAPIResource) Attributes() *Object {
+	opChain := r.chain.enter("Attributes()")
+	defer opChain.leave()
+
+	return jsonapiObject(opChain, r.value, "attributes")
+}
+
+// Attribute returns a new Value instance with given resource attribute.
+//
+// Example:
+//
+//	res.Attribute("title").IsEqual("Hello")
+func (r *JSONAPIResource) Attribute(name string) *Value {
+	opChain := r.chain.enter("Attribute(%q)", name)
+	defer opChain.leave()
+
+	attrs := jsonapiObject(opChain, r.value, "attributes")
+
+	return attrs.Value(name)
+}
+
+// Relationships returns a new Object instance with resource "relationships".
+func (r *JSONAPIResource) Relationships() *Object {
+	opChain := r.chain.enter("Relationships()")
+	defer opChain.leave()
+
+	return jsonapiObject(opChain, r.value, "relationships")
+}
+
+// Relationship returns a new Value instance with resource linkage ("data"
+// member) of given relationship, i.e. a resource identifier object,
+// an array of them, or null.
+//
+// Example:
+//
+//	res.Relationship("author").Object().HasValue("id", "9")
+func (r *JSONAPIResource) Relationship(name string) *Value {
+	opChain := r.chain.enter("Relationship(%q)", name)
+	defer opChain.leave()
+
+	rels := jsonapiObject(opChain, r.value, "relationships")
+
+	return rels.Value(name).Object().Value("data")
+}
+
+// Links returns a new Object instance with resource "links".
+func (r *JSONAPIResource) Links() *Object {
+	opChain := r.chain.enter("Links()")
+	defer opChain.leave()
+
+	return jsonapiObject(opChain, r.value, "links")
+}
+
+// Meta returns a new Object instance with resource "meta".
+func (r *JSONAPIResource) Meta() *Object {
+	opChain := r.chain.enter("Meta()")
+	defer opChain.leave()
+
+	return jsonapiObject(opChain, r.value, "meta")
+}
+
+func jsonapiObject(opChain *chain, value map[string]interface{}, name string) *Object {
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	member, ok := value[name]
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{value},
+			Expected: &AssertionValue{name},
+			Errors: []error{
+				fmt.Errorf("expected: object contains %q", name),
+			},
+		})
+		return newObject(opChain, nil)
+	}
+
+	obj, ok := member.(map[string]interface{})
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{member},
+			Errors: []error{
+				fmt.Errorf("expected: %q is an object", name),
+			},
+		})
+		return newObject(opChain, nil)
+	}
+
+	return newObject(opChain, obj)
+}
+
+func jsonapiString(opChain *chain, value map[string]interface{}, name string) *String {
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	str, ok := value[name].(string)
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				fmt.Errorf("expected: %q is a string", name),
+			},
+		})
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, str)
+}
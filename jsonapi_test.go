@@ -0,0 +1,391 @@
+package httpexpect
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newJSONAPIResponse(t *testing.T, contentType, body string) *Response {
+	return NewResponse(newMockReporter(t), &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {contentType}},
+		Body:       newMockBody(body),
+	})
+}
+
+func TestJSONAPI_Document(t *testing.T) {
+	cases := []struct {
+		name        string
+		contentType string
+		body        string
+		options     []ContentOpts
+		result      chainResult
+	}{
+		{
+			name:        "data",
+			contentType: "application/vnd.api+json",
+			body:        `{"data":null}`,
+			result:      success,
+		},
+		{
+			name:        "errors",
+			contentType: "application/vnd.api+json",
+			body:        `{"errors":[]}`,
+			result:      success,
+		},
+		{
+			name:        "meta",
+			contentType: "application/vnd.api+json",
+			body:        `{"meta":{}}`,
+			result:      success,
+		},
+		{
+			name:        "custom media type",
+			contentType: "application/json",
+			body:        `{"data":null}`,
+			options:     []ContentOpts{{MediaType: "application/json"}},
+			result:      success,
+		},
+		{
+			name:        "wrong media type",
+			contentType: "application/json",
+			body:        `{"data":null}`,
+			result:      failure,
+		},
+		{
+			name:        "not object",
+			contentType: "application/vnd.api+json",
+			body:        `[]`,
+			result:      failure,
+		},
+		{
+			name:        "no top-level members",
+			contentType: "application/vnd.api+json",
+			body:        `{"links":{}}`,
+			result:      failure,
+		},
+		{
+			name:        "data and errors",
+			contentType: "application/vnd.api+json",
+			body:        `{"data":null,"errors":[]}`,
+			result:      failure,
+		},
+		{
+			name:        "invalid json",
+			contentType: "application/vnd.api+json",
+			body:        `{`,
+			result:      failure,
+		},
+		{
+			name:        "multiple options",
+			contentType: "application/vnd.api+json",
+			body:        `{"data":null}`,
+			options:     []ContentOpts{{}, {}},
+			result:      failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := newJSONAPIResponse(t, tc.contentType, tc.body)
+
+			doc := resp.JSONAPI(tc.options...)
+			doc.chain.assert(t, tc.result)
+		})
+	}
+}
+
+func TestJSONAPI_Resource(t *testing.T) {
+	resp := newJSONAPIResponse(t, "application/vnd.api+json", `{
+		"data": {
+			"type": "articles",
+			"id": "1",
+			"attributes": {"title": "Hello", "views": 10},
+			"relationships": {
+				"author": {"data": {"type": "people", "id": "9"}}
+			},
+			"links": {"self": "/articles/1"}
+		},
+		"included": [
+			{"type": "people", "id": "9", "attributes": {"name": "Dan"}}
+		]
+	}`)
+
+	doc := resp.JSONAPI()
+
+	res := doc.Resource()
+	res.HasType("articles")
+	res.ID().IsEqual("1")
+	res.Type().IsEqual("articles")
+	res.Attributes().HasValue("views", 10)
+	res.Attribute("title").IsEqual("Hello")
+	res.Relationships().ContainsKey("author")
+	res.Relationship("author").Object().HasValue("id", "9")
+	res.Links().HasValue("self", "/articles/1")
+	res.chain.assert(t, success)
+
+	assert.Equal(t, "1", res.Raw()["id"])
+
+	included := doc.Included()
+	require.Equal(t, 1, len(included))
+	included[0].HasType("people").Attribute("name").IsEqual("Dan")
+	included[0].chain.assert(t, success)
+
+	doc.chain.assert(t, success)
+
+	t.Run("wrong type", func(t *testing.T) {
+		res := newJSONAPIResponse(t, "application/vnd.api+json",
+			`{"data":{"type":"articles","id":"1"}}`).JSONAPI().Resource()
+
+		res.HasType("people")
+		res.chain.assert(t, failure)
+	})
+
+	t.Run("missing attributes", func(t *testing.T) {
+		res := newJSONAPIResponse(t, "application/vnd.api+json",
+			`{"data":{"type":"articles","id":"1"}}`).JSONAPI().Resource()
+
+		res.Attribute("title")
+		res.chain.assert(t, failure)
+	})
+
+	t.Run("missing meta", func(t *testing.T) {
+		res := newJSONAPIResponse(t, "application/vnd.api+json",
+			`{"data":{"type":"articles","id":"1"}}`).JSONAPI().Resource()
+
+		res.Meta()
+		res.chain.assert(t, failure)
+	})
+
+	t.Run("collection", func(t *testing.T) {
+		doc := newJSONAPIResponse(t, "application/vnd.api+json",
+			`{"data":[{"type":"articles","id":"1"}]}`).JSONAPI()
+
+		doc.Resource()
+		doc.chain.assert(t, failure)
+	})
+}
+
+func TestJSONAPI_Resources(t *testing.T) {
+	t.Run("collection", func(t *testing.T) {
+		doc := newJSONAPIResponse(t, "application/vnd.api+json", `{
+			"data": [
+				{"type": "articles", "id": "1"},
+				{"type": "articles", "id": "2"}
+			],
+			"meta": {"total": 2}
+		}`).JSONAPI()
+
+		resources := doc.Resources()
+		require.Equal(t, 2, len(resources))
+
+		resources[0].ID().IsEqual("1")
+		resources[1].ID().IsEqual("2")
+
+		doc.Data().Array().Length().IsEqual(2)
+		doc.Meta().HasValue("total", 2)
+		doc.Included()
+
+		doc.chain.assert(t, success)
+	})
+
+	t.Run("not collection", func(t *testing.T) {
+		doc := newJSONAPIResponse(t, "application/vnd.api+json",
+			`{"data":{"type":"articles","id":"1"}}`).JSONAPI()
+
+		assert.Empty(t, doc.Resources())
+		doc.chain.assert(t, failure)
+	})
+
+	t.Run("invalid element", func(t *testing.T) {
+		doc := newJSONAPIResponse(t, "application/vnd.api+json",
+			`{"data":[{"type":"articles","id":"1"}, 123]}`).JSONAPI()
+
+		resources := doc.Resources()
+		require.Equal(t, 2, len(resources))
+
+		resources[0].chain.assert(t, success)
+		resources[1].chain.assert(t, failure)
+	})
+}
+
+func TestJSONAPI_Errors(t *testing.T) {
+	doc := newJSONAPIResponse(t, "application/vnd.api+json", `{
+		"errors": [
+			{"status": "422", "code": "invalid_email", "title": "Invalid email"},
+			{"status": "422", "code": "too_short"}
+		]
+	}`).JSONAPI()
+
+	doc.Errors().Length().IsEqual(2)
+	doc.HasError(map[string]interface{}{"status": "422", "code": "too_short"})
+	doc.chain.assert(t, success)
+
+	doc.HasError(map[string]interface{}{"status": "400"})
+	doc.chain.assert(t, failure)
+
+	doc.chain.clear()
+
+	doc.Data()
+	doc.chain.assert(t, failure)
+}
+
+func TestJSONAPI_NextLink(t *testing.T) {
+	cases := []struct {
+		name   string
+		body   string
+		link   string
+		result chainResult
+	}{
+		{
+			name:   "string",
+			body:   `{"data":[],"links":{"next":"/articles?page=2"}}`,
+			link:   "/articles?page=2",
+			result: success,
+		},
+		{
+			name:   "link object",
+			body:   `{"data":[],"links":{"next":{"href":"/articles?page=3"}}}`,
+			link:   "/articles?page=3",
+			result: success,
+		},
+		{
+			name:   "null",
+			body:   `{"data":[],"links":{"next":null}}`,
+			link:   "",
+			result: success,
+		},
+		{
+			name:   "no links",
+			body:   `{"data":[]}`,
+			link:   "",
+			result: success,
+		},
+		{
+			name:   "invalid",
+			body:   `{"data":[],"links":{"next":123}}`,
+			result: failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := newJSONAPIResponse(t, "application/vnd.api+json", tc.body).JSONAPI()
+
+			next := doc.NextLink()
+			next.chain.assert(t, tc.result)
+
+			if tc.result {
+				next.IsEqual(tc.link)
+			}
+		})
+	}
+}
+
+func TestJSONAPI_NextPage(t *testing.T) {
+	pages := map[string]string{
+		"/api/articles": `{"data":[{"type":"articles","id":"1"}],` +
+			`"links":{"next":"http://example.com/api/articles?page%5Bnumber%5D=2"}}`,
+		"/api/articles?page[number]=2": `{"data":[{"type":"articles","id":"2"}],` +
+			`"links":{"next":{"href":"articles?page%5Bnumber%5D=3"}}}`,
+		"/api/articles?page[number]=3": `{"data":[{"type":"articles","id":"3"}],` +
+			`"links":{"next":null}}`,
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query, _ := url.QueryUnescape(r.URL.RawQuery)
+
+		body, ok := pages[strings.TrimSuffix(r.URL.Path+"?"+query, "?")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		_, _ = w.Write([]byte(body))
+	})
+
+	newExpect := func(t *testing.T) *Expect {
+		return WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Reporter: newMockReporter(t),
+			Client: &http.Client{
+				Transport: NewBinder(handler),
+			},
+		})
+	}
+
+	t.Run("walk pages", func(t *testing.T) {
+		e := newExpect(t)
+
+		var ids []string
+
+		doc := e.GET("/api/articles").Expect().Status(http.StatusOK).JSONAPI()
+		ids = append(ids, doc.Resources()[0].ID().Raw())
+
+		for doc.NextLink().Raw() != "" {
+			doc = doc.NextPage().Expect().Status(http.StatusOK).JSONAPI()
+			ids = append(ids, doc.Resources()[0].ID().Raw())
+		}
+
+		assert.Equal(t, []string{"1", "2", "3"}, ids)
+
+		e.chain.assert(t, success)
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		e := newExpect(t)
+
+		doc := e.GET("/api/articles").WithQuery("page[number]", 3).
+			Expect().JSONAPI()
+		doc.chain.assert(t, success)
+
+		req := doc.NextPage()
+		req.chain.assert(t, failure)
+		doc.chain.assert(t, failure)
+	})
+
+	t.Run("no origin", func(t *testing.T) {
+		doc := newJSONAPIResponse(t, "application/vnd.api+json",
+			`{"data":[],"links":{"next":"/articles?page=2"}}`).JSONAPI()
+
+		req := doc.NextPage()
+		req.chain.assert(t, failure)
+		doc.chain.assert(t, failure)
+	})
+}
+
+func TestJSONAPI_FailedChain(t *testing.T) {
+	chain := newMockChain(t, flagFailed)
+
+	doc := newJSONAPI(chain, newMockConfig(newMockReporter(t)), nil, nil, nil)
+	doc.chain.assert(t, failure)
+
+	doc.Alias("foo")
+	doc.Document().chain.assert(t, failure)
+	doc.Data().chain.assert(t, failure)
+	doc.Resource().chain.assert(t, failure)
+	doc.Meta().chain.assert(t, failure)
+	doc.Links().chain.assert(t, failure)
+	doc.NextLink().chain.assert(t, failure)
+	doc.Errors().chain.assert(t, failure)
+	doc.HasError(map[string]interface{}{})
+
+	assert.Empty(t, doc.Resources())
+	assert.Empty(t, doc.Included())
+
+	res := newJSONAPIResource(chain, nil)
+	res.ID().chain.assert(t, failure)
+	res.Type().chain.assert(t, failure)
+	res.HasType("foo")
+	res.Attributes().chain.assert(t, failure)
+	res.Attribute("foo").chain.assert(t, failure)
+	res.Relationships().chain.assert(t, failure)
+	res.Relationship("foo").chain.assert(t, failure)
+	res.Links().chain.assert(t, failure)
+	res.Meta().chain.assert(t, failure)
+}
@@ -0,0 +1,130 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Chain is passed to custom matchers to report failures.
+//
+// It is a thin public wrapper for the assertion chain that is used by all
+// builtin matchers. Failures reported via Chain are handled exactly like
+// failures of builtin assertions: they are passed to AssertionHandler,
+// formatted by Formatter, and mark the matcher as failed.
+type Chain struct {
+	chain *chain
+}
+
+// Fail reports assertion failure.
+//
+// AssertionFailure should have Type and Errors set. Which of Actual,
+// Expected, and other fields should be set depends on Type (see comments
+// for AssertionType constants). Subsequent calls are ignored.
+//
+// Example:
+//
+//	opChain.Fail(httpexpect.AssertionFailure{
+//		Type:   httpexpect.AssertValid,
+//		Actual: &httpexpect.AssertionValue{actual},
+//		Errors: []error{
+//			errors.New("expected: valid SKU"),
+//		},
+//	})
+func (c *Chain) Fail(failure AssertionFailure) {
+	c.chain.fail(failure)
+}
+
+// Failed returns true if failure was already reported.
+func (c *Chain) Failed() bool {
+	return c.chain.failed()
+}
+
+// MatcherFunc defines custom assertion registered using RegisterMatcher.
+//
+// actual is the value being checked, in canonical form (see NewValue).
+// args are arguments passed to Value.Assert.
+type MatcherFunc func(opChain *Chain, actual interface{}, args ...interface{})
+
+var matcherRegistry = struct {
+	sync.RWMutex
+	matchers map[string]MatcherFunc
+}{
+	matchers: make(map[string]MatcherFunc),
+}
+
+// RegisterMatcher registers custom assertion with given name.
+//
+// Registered matcher can be invoked using Value.Assert. This allows to
+// implement organization-specific checks that integrate with assertion
+// reporting and formatting the same way as builtin checks.
+//
+// If name is empty or matcher is nil, the function panics. If a matcher with
+// the same name is already registered, it is replaced.
+//
+// Example:
+//
+//	httpexpect.RegisterMatcher("IsValidSKU",
+//		func(opChain *httpexpect.Chain, actual interface{}, args ...interface{}) {
+//			s, ok := actual.(string)
+//			if !ok || !skuRegexp.MatchString(s) {
+//				opChain.Fail(httpexpect.AssertionFailure{
+//					Type:   httpexpect.AssertValid,
+//					Actual: &httpexpect.AssertionValue{actual},
+//					Errors: []error{
+//						errors.New("expected: valid SKU"),
+//					},
+//				})
+//			}
+//		})
+//
+//	e.GET("/item/1").Expect().
+//		JSON().Path("$.sku").Assert("IsValidSKU")
+func RegisterMatcher(name string, matcher MatcherFunc) {
+	if name == "" {
+		panic("matcher name is empty")
+	}
+	if matcher == nil {
+		panic("matcher is nil")
+	}
+
+	matcherRegistry.Lock()
+	defer matcherRegistry.Unlock()
+
+	matcherRegistry.matchers[name] = matcher
+}
+
+// UnregisterMatcher removes custom assertion registered using RegisterMatcher.
+// Does nothing if there is no matcher with given name.
+func UnregisterMatcher(name string) {
+	matcherRegistry.Lock()
+	defer matcherRegistry.Unlock()
+
+	delete(matcherRegistry.matchers, name)
+}
+
+func lookupMatcher(opChain *chain, name string) (MatcherFunc, bool) {
+	matcherRegistry.RLock()
+	defer matcherRegistry.RUnlock()
+
+	matcher, ok := matcherRegistry.matchers[name]
+	if !ok {
+		names := []string{}
+		for k := range matcherRegistry.matchers {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unknown matcher %q", name),
+				errors.New("registered matchers: " + fmt.Sprint(names)),
+			},
+		})
+		return nil, false
+	}
+
+	return matcher, true
+}
@@ -0,0 +1,113 @@
+package httpexpect
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatcherRegistry_Assert(t *testing.T) {
+	var (
+		gotActual interface{}
+		gotArgs   []interface{}
+	)
+
+	RegisterMatcher("HasPrefix",
+		func(opChain *Chain, actual interface{}, args ...interface{}) {
+			gotActual = actual
+			gotArgs = args
+
+			s, _ := actual.(string)
+			if !strings.HasPrefix(s, args[0].(string)) {
+				opChain.Fail(AssertionFailure{
+					Type:   AssertValid,
+					Actual: &AssertionValue{actual},
+					Errors: []error{
+						errors.New("expected: string has prefix"),
+					},
+				})
+			}
+		})
+	defer UnregisterMatcher("HasPrefix")
+
+	t.Run("success", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, "SKU-123")
+		value.Assert("HasPrefix", "SKU-")
+		value.chain.assert(t, success)
+
+		assert.Equal(t, "SKU-123", gotActual)
+		assert.Equal(t, []interface{}{"SKU-"}, gotArgs)
+		assert.False(t, reporter.reported)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, "ABC-123")
+		value.Assert("HasPrefix", "SKU-")
+		value.chain.assert(t, failure)
+
+		assert.True(t, reporter.reported)
+	})
+
+	t.Run("canonical value", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		value := NewValueC(Config{AssertionHandler: handler}, 123)
+		value.Assert("HasPrefix", "1")
+		value.chain.assert(t, failure)
+
+		assert.Equal(t, 123.0, gotActual)
+		assert.Equal(t, 1, handler.failureCalled)
+		assert.Equal(t, AssertValid, handler.failure.Type)
+		assert.Equal(t, []string{"Value()", `Assert("HasPrefix")`},
+			handler.ctx.Path)
+	})
+
+	t.Run("unknown matcher", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, "SKU-123")
+		value.Assert("NoSuchMatcher")
+		value.chain.assert(t, failure)
+	})
+}
+
+func TestMatcherRegistry_Chain(t *testing.T) {
+	chain := newMockChain(t).enter("test")
+
+	opChain := &Chain{chain}
+	assert.False(t, opChain.Failed())
+
+	opChain.Fail(testFailure())
+	assert.True(t, opChain.Failed())
+
+	chain.leave()
+}
+
+func TestMatcherRegistry_Unregister(t *testing.T) {
+	RegisterMatcher("Temp", func(*Chain, interface{}, ...interface{}) {})
+
+	value := NewValue(newMockReporter(t), nil)
+	value.Assert("Temp")
+	value.chain.assert(t, success)
+
+	UnregisterMatcher("Temp")
+
+	value.Assert("Temp")
+	value.chain.assert(t, failure)
+}
+
+func TestMatcherRegistry_Panics(t *testing.T) {
+	assert.Panics(t, func() {
+		RegisterMatcher("", func(*Chain, interface{}, ...interface{}) {})
+	})
+
+	assert.Panics(t, func() {
+		RegisterMatcher("Foo", nil)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"time"
 )
 
 // Number provides methods to inspect attached float64 value
@@ -1327,6 +1328,72 @@ func (n *Number) NotFinite() *Number {
 	return n
 }
 
+// AsDuration returns a new Duration instance with number interpreted as
+// a count of given units. Default unit is time.Second.
+//
+// It allows to compare numeric fields like "expires_in" or "timeout_ms"
+// with time.Duration values using Duration methods. Fractional numbers
+// are rounded to the nearest nanosecond.
+//
+// Example:
+//
+//	number := NewNumber(t, 3600)
+//	number.AsDuration().IsEqual(time.Hour)
+//
+//	number := NewNumber(t, 1500)
+//	number.AsDuration(time.Millisecond).IsEqual(1500 * time.Millisecond)
+func (n *Number) AsDuration(unit ...time.Duration) *Duration {
+	opChain := n.chain.enter("AsDuration()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newDuration(opChain, nil)
+	}
+
+	if len(unit) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple unit arguments"),
+			},
+		})
+		return newDuration(opChain, nil)
+	}
+
+	durationUnit := time.Second
+	if len(unit) != 0 {
+		durationUnit = unit[0]
+	}
+
+	if durationUnit <= 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected non-positive unit: %v", durationUnit),
+			},
+		})
+		return newDuration(opChain, nil)
+	}
+
+	nanos := math.Round(n.value * float64(durationUnit))
+
+	if math.IsNaN(nanos) || nanos < math.MinInt64 || nanos >= math.MaxInt64 {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{n.value},
+			Errors: []error{
+				fmt.Errorf("expected: number fits into duration in units of %v",
+					durationUnit),
+			},
+		})
+		return newDuration(opChain, nil)
+	}
+
+	value := time.Duration(nanos)
+
+	return newDuration(opChain, &value)
+}
+
 type intBoundary struct {
 	val  *big.Int
 	sign int
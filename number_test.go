@@ -3,6 +3,7 @@ package httpexpect
 import (
 	"math"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -40,6 +41,7 @@ func TestNumber_FailedChain(t *testing.T) {
 	value.NotUint()
 	value.IsFinite()
 	value.NotFinite()
+	value.AsDuration().chain.assert(t, failure)
 }
 
 func TestNumber_Constructors(t *testing.T) {
@@ -1223,3 +1225,92 @@ func TestNumber_IsFinite(t *testing.T) {
 		})
 	}
 }
+
+func TestNumber_AsDuration(t *testing.T) {
+	cases := []struct {
+		name   string
+		value  float64
+		unit   []time.Duration
+		result chainResult
+		want   time.Duration
+	}{
+		{
+			name:   "seconds",
+			value:  3600,
+			result: success,
+			want:   time.Hour,
+		},
+		{
+			name:   "fractional seconds",
+			value:  1.5,
+			result: success,
+			want:   1500 * time.Millisecond,
+		},
+		{
+			name:   "negative",
+			value:  -2,
+			result: success,
+			want:   -2 * time.Second,
+		},
+		{
+			name:   "milliseconds",
+			value:  250,
+			unit:   []time.Duration{time.Millisecond},
+			result: success,
+			want:   250 * time.Millisecond,
+		},
+		{
+			name:   "NaN",
+			value:  math.NaN(),
+			result: failure,
+		},
+		{
+			name:   "+Inf",
+			value:  math.Inf(+1),
+			result: failure,
+		},
+		{
+			name:   "overflow",
+			value:  1e12,
+			unit:   []time.Duration{time.Hour},
+			result: failure,
+		},
+		{
+			name:   "zero unit",
+			value:  1,
+			unit:   []time.Duration{0},
+			result: failure,
+		},
+		{
+			name:   "multiple units",
+			value:  1,
+			unit:   []time.Duration{time.Second, time.Second},
+			result: failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			value := NewNumber(reporter, tc.value)
+			duration := value.AsDuration(tc.unit...)
+			value.chain.assert(t, tc.result)
+			duration.chain.assert(t, tc.result)
+
+			if tc.result {
+				assert.Equal(t, tc.want, duration.Raw())
+			}
+		})
+	}
+
+	t.Run("compare with field", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		object := NewObject(reporter, map[string]interface{}{"expires_in": 3600})
+		object.Value("expires_in").Number().AsDuration().
+			IsEqual(time.Hour).
+			InRange(59*time.Minute, 61*time.Minute)
+		object.chain.assert(t, success)
+	})
+}
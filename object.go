@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"regexp"
 	"sort"
 )
 
@@ -116,6 +117,63 @@ func (o *Object) Decode(target interface{}) *Object {
 	return o
 }
 
+// ToMap returns object fields converted to Go map with values of type V.
+//
+// Every field value is converted in the same way as by ValueOf. If some
+// values can't be converted, a single failure is reported, listing key,
+// expected Go type, and actual JSON type of every such value, and nil
+// is returned.
+//
+// ToMap is a bridge from fluent assertions to ordinary Go logic.
+//
+// Example:
+//
+//	object := NewObject(t, map[string]interface{}{"a": 1, "b": 2})
+//
+//	counts := ToMap[int](object)
+//	total := counts["a"] + counts["b"]
+func ToMap[V any](o *Object) map[string]V {
+	var zero V
+
+	targetType := reflect.TypeOf(&zero).Elem()
+
+	opChain := o.chain.enter("ToMap[%s]()", targetType)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return nil
+	}
+
+	result := make(map[string]V, len(o.value))
+
+	var errs []error
+
+	for _, kv := range o.sortedKV() {
+		converted, ok := convertValue[V](kv.val)
+		if !ok {
+			errs = append(errs, fmt.Errorf(
+				"value at key %q: actual value is JSON %s",
+				kv.key, jsonTypeName(kv.val)))
+			continue
+		}
+		result[kv.key] = converted
+	}
+
+	if len(errs) != 0 {
+		opChain.fail(AssertionFailure{
+			Type:   AssertType,
+			Actual: &AssertionValue{o.value},
+			Errors: append([]error{
+				fmt.Errorf("expected: every value can be converted to %s",
+					targetType),
+			}, errs...),
+		})
+		return nil
+	}
+
+	return result
+}
+
 // Alias is similar to Value.Alias.
 func (o *Object) Alias(name string) *Object {
 	opChain := o.chain.enter("Alias(%q)", name)
@@ -219,6 +277,122 @@ func (o *Object) Value(key string) *Value {
 	return newValue(opChain, value)
 }
 
+// Field returns a new Value instance with value for given key.
+//
+// Unlike Value, Field doesn't report failure if key is missing. Instead,
+// it returns Value marked as absent, which can be checked using
+// Value.IsAbsent. This allows to distinguish missing fields from fields
+// that are present but null.
+//
+// Example:
+//
+//	object := NewObject(t, map[string]interface{}{"foo": nil})
+//	object.Field("foo").IsNull()
+//	object.Field("bar").IsAbsent()
+func (o *Object) Field(key string) *Value {
+	opChain := o.chain.enter("Field(%q)", key)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newValue(opChain, nil)
+	}
+
+	value, ok := o.value[key]
+	if !ok {
+		return newAbsentValue(opChain)
+	}
+
+	return newValue(opChain, value)
+}
+
+// Fields succeeds if object fields match given expectations.
+//
+// For every key in fields, expectation may be either a FieldMatcher,
+// like Nullable or Absent, or a regular value. Regular value requires
+// field to be present and equal to it after converting both to canonical
+// form; in particular, nil requires field to be present and null.
+// Fields not mentioned in the map are not checked.
+//
+// Example:
+//
+//	object := NewObject(t, map[string]interface{}{
+//		"id":      123,
+//		"name":    "john",
+//		"deleted": nil,
+//	})
+//
+//	object.Fields(map[string]interface{}{
+//		"id":      123,
+//		"deleted": nil,
+//		"email":   Absent(),
+//		"name":    Nullable[string](),
+//	})
+func (o *Object) Fields(fields map[string]interface{}) *Object {
+	opChain := o.chain.enter("Fields()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return o
+	}
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		actual, present := o.value[key]
+
+		if matcher, ok := fields[key].(FieldMatcher); ok {
+			matcher.MatchField(&Chain{opChain}, key, actual, present)
+		} else {
+			matchFieldValue(opChain, o.value, key, fields[key])
+		}
+
+		if opChain.failed() {
+			break
+		}
+	}
+
+	return o
+}
+
+func matchFieldValue(
+	opChain *chain, object map[string]interface{}, key string, value interface{},
+) {
+	actual, present := object[key]
+	if !present {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{object},
+			Expected: &AssertionValue{key},
+			Errors: []error{
+				fmt.Errorf("expected: map contains key %q", key),
+			},
+		})
+		return
+	}
+
+	expected, ok := canonValue(opChain, value)
+	if !ok {
+		return
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{actual},
+			Expected: &AssertionValue{value},
+			Errors: []error{
+				fmt.Errorf(
+					"expected: map value for key %q is equal to given value",
+					key),
+			},
+		})
+	}
+}
+
 // HasValue succeeds if object's value for given key is equal to given value.
 // Before comparison, both values are converted to canonical form.
 //
@@ -324,6 +498,159 @@ func (o *Object) NotHasValue(key string, value interface{}) *Object {
 	return o
 }
 
+// HasValueMatching succeeds if object's value for given key is a string
+// matching given regexp, or an array containing at least one such string.
+//
+// Arrays are accepted to make it convenient to check headers returned
+// by Response.Headers, where every header is an array of values.
+// regexp.Compile is used to construct regexp.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.Headers().HasValueMatching("Content-Type", `^application/json\b`)
+func (o *Object) HasValueMatching(key string, re string) *Object {
+	opChain := o.chain.enter("HasValueMatching(%q)", key)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return o
+	}
+
+	rx, err := regexp.Compile(re)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{re},
+			Errors: []error{
+				errors.New("expected: valid regexp"),
+				err,
+			},
+		})
+		return o
+	}
+
+	if !containsKey(opChain, o.value, key) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{o.value},
+			Expected: &AssertionValue{key},
+			Errors: []error{
+				errors.New("expected: map contains key"),
+			},
+		})
+		return o
+	}
+
+	for _, elem := range objectValueElements(o.value[key]) {
+		if s, ok := elem.(string); ok && rx.MatchString(s) {
+			return o
+		}
+	}
+
+	opChain.fail(AssertionFailure{
+		Type:     AssertMatchRegexp,
+		Actual:   &AssertionValue{o.value[key]},
+		Expected: &AssertionValue{re},
+		Errors: []error{
+			fmt.Errorf(
+				"expected: map value for key %q matches regexp",
+				key),
+		},
+	})
+
+	return o
+}
+
+// HasValueSatisfying succeeds if given predicate returns true for object's
+// value for given key. If value is an array, predicate is invoked for every
+// element, and should return true for at least one of them.
+//
+// Like in Find, if there are any failed assertions in the predicate
+// function, it's treated as if it returned false.
+//
+// This is useful for complicated values, like Set-Cookie headers returned
+// by Response.Headers.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.Headers().HasValueSatisfying("Set-Cookie", func(value *httpexpect.Value) bool {
+//		return strings.HasPrefix(value.String().Raw(), "session=") &&
+//			strings.Contains(value.String().Raw(), "HttpOnly")
+//	})
+func (o *Object) HasValueSatisfying(key string, fn func(value *Value) bool) *Object {
+	opChain := o.chain.enter("HasValueSatisfying(%q)", key)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return o
+	}
+
+	if fn == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil function argument"),
+			},
+		})
+		return o
+	}
+
+	if !containsKey(opChain, o.value, key) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{o.value},
+			Expected: &AssertionValue{key},
+			Errors: []error{
+				errors.New("expected: map contains key"),
+			},
+		})
+		return o
+	}
+
+	for n, elem := range objectValueElements(o.value[key]) {
+		satisfied := false
+
+		func() {
+			valueChain := opChain.replace("HasValueSatisfying[%d]", n)
+			defer valueChain.leave()
+
+			valueChain.setRoot()
+			valueChain.setSeverity(SeverityLog)
+
+			if fn(newValue(valueChain, elem)) && !valueChain.treeFailed() {
+				satisfied = true
+			}
+		}()
+
+		if satisfied {
+			return o
+		}
+	}
+
+	opChain.fail(AssertionFailure{
+		Type:   AssertValid,
+		Actual: &AssertionValue{o.value[key]},
+		Errors: []error{
+			fmt.Errorf(
+				"expected: map value for key %q matches predicate",
+				key),
+		},
+	})
+
+	return o
+}
+
+// objectValueElements returns elements of value if it's an array,
+// or value itself otherwise.
+func objectValueElements(value interface{}) []interface{} {
+	if arr, ok := value.([]interface{}); ok {
+		return arr
+	}
+	return []interface{}{value}
+}
+
 // Deprecated: use HasValue instead.
 func (o *Object) ValueEqual(key string, value interface{}) *Object {
 	return o.HasValue(key, value)
@@ -513,6 +840,49 @@ func (o *Object) Transform(fn func(key string, value interface{}) interface{}) *
 	return newObject(opChain, transformedObject)
 }
 
+// Sample returns a new object with n key-value pairs of the original object,
+// chosen randomly using given seed.
+//
+// Sample allows to check a property over a subset of a huge object, keeping
+// test runtime bounded. The same seed always selects the same keys, so
+// a failure can be reproduced. If n is greater than or equal to number of
+// keys, the whole object is returned.
+//
+// Example:
+//
+//	object := NewObject(t, usersByID)
+//	object.Sample(100, 42).Every(func(key string, value *httpexpect.Value) {
+//		value.Object().HasValue("id", key)
+//	})
+func (o *Object) Sample(n int, seed int64) *Object {
+	opChain := o.chain.enter("Sample(%d, %d)", n, seed)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	if n < 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected negative sample size %d", n),
+			},
+		})
+		return newObject(opChain, nil)
+	}
+
+	kvs := o.sortedKV()
+
+	sampledObject := map[string]interface{}{}
+
+	for _, index := range sampleIndices(len(kvs), n, seed) {
+		sampledObject[kvs[index].key] = kvs[index].val
+	}
+
+	return newObject(opChain, sampledObject)
+}
+
 // Find accepts a function that returns a boolean, runs it over the object
 // elements, and returns the first element on which it returned true.
 //
@@ -787,11 +1157,13 @@ func (o *Object) Empty() *Object {
 //
 // value should be map[string]interface{} or struct.
 //
+// Optional CompareOptions can be given to customize comparison.
+//
 // Example:
 //
 //	object := NewObject(t, map[string]interface{}{"foo": 123})
 //	object.IsEqual(map[string]interface{}{"foo": 123})
-func (o *Object) IsEqual(value interface{}) *Object {
+func (o *Object) IsEqual(value interface{}, opts ...CompareOptions) *Object {
 	opChain := o.chain.enter("IsEqual()")
 	defer opChain.leave()
 
@@ -804,7 +1176,12 @@ func (o *Object) IsEqual(value interface{}) *Object {
 		return o
 	}
 
-	if !reflect.DeepEqual(expected, o.value) {
+	compareOpts, ok := getCompareOptions(opChain, opts)
+	if !ok {
+		return o
+	}
+
+	if !compareValues(compareOpts, o.value, expected) {
 		opChain.fail(AssertionFailure{
 			Type:     AssertEqual,
 			Actual:   &AssertionValue{o.value},
@@ -823,11 +1200,13 @@ func (o *Object) IsEqual(value interface{}) *Object {
 //
 // value should be map[string]interface{} or struct.
 //
+// Optional CompareOptions can be given to customize comparison.
+//
 // Example:
 //
 //	object := NewObject(t, map[string]interface{}{"foo": 123})
 //	object.IsEqual(map[string]interface{}{"bar": 123})
-func (o *Object) NotEqual(value interface{}) *Object {
+func (o *Object) NotEqual(value interface{}, opts ...CompareOptions) *Object {
 	opChain := o.chain.enter("NotEqual()")
 	defer opChain.leave()
 
@@ -840,7 +1219,12 @@ func (o *Object) NotEqual(value interface{}) *Object {
 		return o
 	}
 
-	if reflect.DeepEqual(expected, o.value) {
+	compareOpts, ok := getCompareOptions(opChain, opts)
+	if !ok {
+		return o
+	}
+
+	if compareValues(compareOpts, o.value, expected) {
 		opChain.fail(AssertionFailure{
 			Type:     AssertNotEqual,
 			Actual:   &AssertionValue{o.value},
@@ -1,10 +1,14 @@
 package httpexpect
 
 import (
+	"errors"
+	"fmt"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestObject_FailedChain(t *testing.T) {
@@ -18,9 +22,12 @@ func TestObject_FailedChain(t *testing.T) {
 		var target interface{}
 		value.Decode(&target)
 
+		assert.Nil(t, ToMap[int](value))
+
 		value.Keys().chain.assert(t, failure)
 		value.Values().chain.assert(t, failure)
 		value.Value("foo").chain.assert(t, failure)
+		value.Field("foo").chain.assert(t, failure)
 
 		value.IsEmpty()
 		value.NotEmpty()
@@ -36,6 +43,11 @@ func TestObject_FailedChain(t *testing.T) {
 		value.NotContainsSubset(nil)
 		value.HasValue("foo", nil)
 		value.NotHasValue("foo", nil)
+		value.HasValueMatching("foo", ".*")
+		value.HasValueSatisfying("foo", func(value *Value) bool {
+			return true
+		})
+		value.Fields(map[string]interface{}{"foo": nil})
 
 		assert.NotNil(t, value.Iter())
 		assert.Equal(t, 0, len(value.Iter()))
@@ -50,6 +62,7 @@ func TestObject_FailedChain(t *testing.T) {
 			value.String().NotEmpty()
 			return true
 		})
+		value.Sample(1, 0).chain.assert(t, failure)
 		value.Find(func(key string, value *Value) bool {
 			value.String().NotEmpty()
 			return true
@@ -256,6 +269,52 @@ func TestObject_Decode(t *testing.T) {
 	})
 }
 
+func TestObject_ToMap(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		assert.Equal(t, map[string]int{"a": 1, "b": 2},
+			ToMap[int](NewObject(reporter, map[string]interface{}{"a": 1, "b": 2})))
+
+		assert.Equal(t, map[string][]string{"a": {"x", "y"}},
+			ToMap[[]string](NewObject(reporter, map[string]interface{}{
+				"a": []interface{}{"x", "y"},
+			})))
+
+		assert.Equal(t, map[string]interface{}{"a": "x", "b": nil},
+			ToMap[interface{}](NewObject(reporter, map[string]interface{}{
+				"a": "x",
+				"b": nil,
+			})))
+
+		assert.Equal(t, map[string]int{},
+			ToMap[int](NewObject(reporter, map[string]interface{}{})))
+
+		assert.False(t, reporter.reported)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		object := NewObjectC(Config{AssertionHandler: handler},
+			map[string]interface{}{"c": nil, "a": 1, "b": "two"})
+
+		assert.Nil(t, ToMap[int](object))
+		object.chain.assert(t, failure)
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertType, handler.failure.Type)
+		assert.Equal(t, []string{"Object()", "ToMap[int]()"}, handler.ctx.Path)
+		require.Equal(t, 3, len(handler.failure.Errors))
+		assert.Equal(t, "expected: every value can be converted to int",
+			handler.failure.Errors[0].Error())
+		assert.Equal(t, `value at key "b": actual value is JSON string`,
+			handler.failure.Errors[1].Error())
+		assert.Equal(t, `value at key "c": actual value is JSON null`,
+			handler.failure.Errors[2].Error())
+	})
+}
+
 func TestObject_Alias(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -1313,6 +1372,236 @@ func TestObject_HasValue(t *testing.T) {
 	})
 }
 
+func TestObject_HasValueMatching(t *testing.T) {
+	testObj := map[string]interface{}{
+		"foo": "application/json; charset=utf-8",
+		"bar": []interface{}{"text/plain", "text/html"},
+		"baz": 123,
+	}
+
+	cases := []struct {
+		name   string
+		key    string
+		re     string
+		result chainResult
+	}{
+		{"string match", "foo", `^application/json\b`, success},
+		{"string mismatch", "foo", `^text/`, failure},
+		{"array match", "bar", `html$`, success},
+		{"array mismatch", "bar", `json`, failure},
+		{"not string", "baz", `123`, failure},
+		{"missing key", "qux", `.*`, failure},
+		{"invalid regexp", "foo", `[`, failure},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			NewObject(reporter, testObj).HasValueMatching(tc.key, tc.re).
+				chain.assert(t, tc.result)
+		})
+	}
+}
+
+func TestObject_HasValueSatisfying(t *testing.T) {
+	testObj := map[string]interface{}{
+		"foo": "session=abc; HttpOnly",
+		"bar": []interface{}{"a=1", "session=abc; HttpOnly", 123},
+	}
+
+	isSession := func(value *Value) bool {
+		s := value.String().Raw()
+		return strings.HasPrefix(s, "session=") && strings.Contains(s, "HttpOnly")
+	}
+
+	isLarge := func(value *Value) bool {
+		return value.Number().Raw() > 100
+	}
+
+	cases := []struct {
+		name   string
+		key    string
+		fn     func(value *Value) bool
+		result chainResult
+	}{
+		{"string satisfied", "foo", isSession, success},
+		{"array satisfied", "bar", isSession, success},
+		{"array element with failed assertion", "bar", isLarge, success},
+		{"not satisfied", "foo", isLarge, failure},
+		{"missing key", "qux", isSession, failure},
+		{"nil function", "foo", nil, failure},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			NewObject(reporter, testObj).HasValueSatisfying(tc.key, tc.fn).
+				chain.assert(t, tc.result)
+		})
+	}
+}
+
+func TestObject_Field(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	object := NewObject(reporter, map[string]interface{}{
+		"null":  nil,
+		"zero":  0,
+		"empty": "",
+	})
+
+	cases := []struct {
+		key        string
+		wantNull   chainResult
+		wantAbsent chainResult
+	}{
+		{key: "null", wantNull: success, wantAbsent: failure},
+		{key: "zero", wantNull: failure, wantAbsent: failure},
+		{key: "empty", wantNull: failure, wantAbsent: failure},
+		{key: "missing", wantNull: failure, wantAbsent: success},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.key, func(t *testing.T) {
+			object.Field(tc.key).chain.assert(t, success)
+
+			object.Field(tc.key).IsNull().chain.assert(t, tc.wantNull)
+			object.Field(tc.key).IsAbsent().chain.assert(t, tc.wantAbsent)
+			object.Field(tc.key).NotAbsent().chain.assert(t, !tc.wantAbsent)
+
+			object.Field(tc.key).NotNull().
+				chain.assert(t, !tc.wantNull && !tc.wantAbsent)
+		})
+	}
+
+	object.chain.assert(t, success)
+
+	value := object.Field("zero")
+	value.Number().IsEqual(0)
+	value.chain.assert(t, success)
+	assert.Equal(t, 0.0, value.Raw())
+}
+
+func TestObject_Fields(t *testing.T) {
+	value := map[string]interface{}{
+		"id":      123,
+		"name":    "john",
+		"deleted": nil,
+		"tags":    []interface{}{"a"},
+	}
+
+	cases := []struct {
+		name   string
+		fields map[string]interface{}
+		result chainResult
+	}{
+		{
+			name:   "empty",
+			fields: map[string]interface{}{},
+			result: success,
+		},
+		{
+			name: "values",
+			fields: map[string]interface{}{
+				"id":      123,
+				"name":    "john",
+				"deleted": nil,
+				"tags":    []string{"a"},
+			},
+			result: success,
+		},
+		{
+			name: "matchers",
+			fields: map[string]interface{}{
+				"id":      Nullable[int](),
+				"deleted": Nullable[int](),
+				"tags":    Nullable[[]string](),
+				"email":   Absent(),
+			},
+			result: success,
+		},
+		{
+			name: "value mismatch",
+			fields: map[string]interface{}{
+				"id": 456,
+			},
+			result: failure,
+		},
+		{
+			name: "null mismatch",
+			fields: map[string]interface{}{
+				"name": nil,
+			},
+			result: failure,
+		},
+		{
+			name: "null does not match absent",
+			fields: map[string]interface{}{
+				"email": nil,
+			},
+			result: failure,
+		},
+		{
+			name: "absent does not match null",
+			fields: map[string]interface{}{
+				"deleted": Absent(),
+			},
+			result: failure,
+		},
+		{
+			name: "nullable does not match absent",
+			fields: map[string]interface{}{
+				"email": Nullable[string](),
+			},
+			result: failure,
+		},
+		{
+			name: "nullable type mismatch",
+			fields: map[string]interface{}{
+				"name": Nullable[int](),
+			},
+			result: failure,
+		},
+		{
+			name: "custom matcher",
+			fields: map[string]interface{}{
+				"id": mockFieldMatcher(func(
+					opChain *Chain, key string, value interface{}, present bool,
+				) {
+					if !present || value != 123.0 {
+						opChain.Fail(AssertionFailure{
+							Type:   AssertValid,
+							Actual: &AssertionValue{value},
+							Errors: []error{errors.New("bad")},
+						})
+					}
+				}),
+			},
+			result: success,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			NewObject(reporter, value).Fields(tc.fields).
+				chain.assert(t, tc.result)
+		})
+	}
+}
+
+type mockFieldMatcher func(
+	opChain *Chain, key string, value interface{}, present bool)
+
+func (m mockFieldMatcher) MatchField(
+	opChain *Chain, key string, value interface{}, present bool,
+) {
+	m(opChain, key, value, present)
+}
+
 func TestObject_Iter(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -1711,6 +2000,63 @@ func TestObject_Filter(t *testing.T) {
 	})
 }
 
+func TestObject_Sample(t *testing.T) {
+	elements := map[string]interface{}{}
+	for i := 0; i < 1000; i++ {
+		elements[fmt.Sprintf("key%04d", i)] = float64(i)
+	}
+
+	t.Run("subset", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		object := NewObject(reporter, elements)
+
+		sample := object.Sample(10, 42)
+		sample.Keys().Length().IsEqual(10)
+		sample.Every(func(key string, value *Value) {
+			object.HasValue(key, value.Raw())
+		})
+
+		assert.Equal(t, sample.Raw(), object.Sample(10, 42).Raw())
+		assert.NotEqual(t, sample.Raw(), object.Sample(10, 43).Raw())
+		assert.Equal(t, 1000, len(object.Raw()))
+
+		object.chain.assert(t, success)
+		sample.chain.assert(t, success)
+	})
+
+	t.Run("whole object", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		object := NewObject(reporter, map[string]interface{}{"foo": 1, "bar": 2})
+
+		assert.Equal(t, map[string]interface{}{"foo": 1.0, "bar": 2.0},
+			object.Sample(5, 42).Raw())
+		assert.Equal(t, map[string]interface{}{}, object.Sample(0, 42).Raw())
+
+		object.chain.assert(t, success)
+	})
+
+	t.Run("failed element", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		object := NewObject(reporter, elements)
+
+		sample := object.Sample(10, 42)
+		sample.Every(func(_ string, value *Value) {
+			value.Number().Lt(0)
+		})
+
+		sample.chain.assert(t, failure)
+	})
+
+	t.Run("invalid argument", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		object := NewObject(reporter, elements)
+
+		sample := object.Sample(-1, 42)
+		object.chain.assert(t, failure)
+		sample.chain.assert(t, failure)
+	})
+}
+
 func TestObject_Find(t *testing.T) {
 	t.Run("elements of same type", func(t *testing.T) {
 		reporter := newMockReporter(t)
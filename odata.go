@@ -0,0 +1,313 @@
+package httpexpect
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+// OData provides methods to inspect OData JSON response envelope.
+//
+// Collection responses wrap items into "value" array and may contain
+// control information like "@odata.count" and "@odata.nextLink".
+type OData struct {
+	noCopy noCopy
+	config Config
+	chain  *chain
+	origin *Expect
+	base   *url.URL
+	value  map[string]interface{}
+}
+
+// OData returns a new OData instance with response body decoded as
+// OData JSON envelope.
+//
+// OData succeeds if Content-Type header is "application/json" (format
+// parameters like "odata.metadata" are allowed), and body is a JSON object.
+//
+// Expected media type can be overridden via ContentOpts.
+//
+// Example:
+//
+//	odata := resp.OData()
+//	odata.Count().IsEqual(42)
+//	odata.Value().Length().IsEqual(10)
+func (r *Response) OData(options ...ContentOpts) *OData {
+	opChain := r.chain.enter("OData()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newOData(opChain, r.config, nil, nil, nil)
+	}
+
+	if len(options) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple options arguments"),
+			},
+		})
+		return newOData(opChain, r.config, nil, nil, nil)
+	}
+
+	if !r.checkContentOptions(opChain, options, "application/json") {
+		return newOData(opChain, r.config, nil, nil, nil)
+	}
+
+	content, ok := r.getContent(opChain, "OData()")
+	if !ok {
+		return newOData(opChain, r.config, nil, nil, nil)
+	}
+
+	value, ok := r.decodeJSON(opChain, content, content)
+	if !ok {
+		return newOData(opChain, r.config, nil, nil, nil)
+	}
+
+	envelope, ok := value.(map[string]interface{})
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				errors.New("expected: OData response is an object"),
+			},
+		})
+		return newOData(opChain, r.config, nil, nil, nil)
+	}
+
+	var base *url.URL
+	if r.httpResp.Request != nil {
+		base = r.httpResp.Request.URL
+	}
+
+	return newOData(opChain, r.config, r.origin, base, envelope)
+}
+
+func newOData(
+	parent *chain, config Config, origin *Expect, base *url.URL,
+	value map[string]interface{},
+) *OData {
+	return &OData{
+		config: config,
+		chain:  parent.clone(),
+		origin: origin,
+		base:   base,
+		value:  value,
+	}
+}
+
+// Alias is similar to Value.Alias.
+func (o *OData) Alias(name string) *OData {
+	opChain := o.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	o.chain.setAlias(name)
+	return o
+}
+
+// Envelope returns a new Object instance with the whole response object.
+func (o *OData) Envelope() *Object {
+	opChain := o.chain.enter("Envelope()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	return newObject(opChain, o.value)
+}
+
+// Value returns a new Array instance with collection items from "value".
+//
+// Example:
+//
+//	odata.Value().Value(0).Object().HasValue("Name", "Milk")
+func (o *OData) Value() *Array {
+	opChain := o.chain.enter("Value()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	value, ok := o.value["value"]
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{o.value},
+			Expected: &AssertionValue{"value"},
+			Errors: []error{
+				errors.New(`expected: OData collection contains "value"`),
+			},
+		})
+		return newArray(opChain, nil)
+	}
+
+	arr, ok := value.([]interface{})
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				errors.New(`expected: "value" is an array`),
+			},
+		})
+		return newArray(opChain, nil)
+	}
+
+	return newArray(opChain, arr)
+}
+
+// Count returns a new Number instance with total number of items from
+// "@odata.count". Fails if response has no count, i.e. it was not
+// requested with $count=true.
+//
+// Example:
+//
+//	odata.Count().IsEqual(42)
+func (o *OData) Count() *Number {
+	opChain := o.chain.enter("Count()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, 0)
+	}
+
+	value, ok := o.value["@odata.count"]
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{o.value},
+			Expected: &AssertionValue{"@odata.count"},
+			Errors: []error{
+				errors.New(`expected: OData response contains "@odata.count"`),
+			},
+		})
+		return newNumber(opChain, 0)
+	}
+
+	count, ok := value.(float64)
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				errors.New(`expected: "@odata.count" is a number`),
+			},
+		})
+		return newNumber(opChain, 0)
+	}
+
+	return newNumber(opChain, count)
+}
+
+// Context returns a new String instance with "@odata.context".
+// If response has no context, empty string is returned.
+func (o *OData) Context() *String {
+	opChain := o.chain.enter("Context()")
+	defer opChain.leave()
+
+	return o.annotation(opChain, "@odata.context")
+}
+
+// NextLink returns a new String instance with URL of the next page,
+// taken from "@odata.nextLink".
+//
+// If response has no next link, e.g. on the last page, empty string is
+// returned. To fetch the next page, use NextPage.
+//
+// Example:
+//
+//	odata.NextLink().NotEmpty()
+func (o *OData) NextLink() *String {
+	opChain := o.chain.enter("NextLink()")
+	defer opChain.leave()
+
+	return o.annotation(opChain, "@odata.nextLink")
+}
+
+// NextPage returns a new Request instance that fetches the next page,
+// i.e. link returned by NextLink.
+//
+// Link is resolved relative to URL of the request that produced this
+// response, and request is sent through the same Expect instance.
+// If response has no next link, failure is reported.
+//
+// Example:
+//
+//	odata := e.GET("/People").Expect().Status(http.StatusOK).OData()
+//	for odata.NextLink().Raw() != "" {
+//		odata = odata.NextPage().Expect().Status(http.StatusOK).OData()
+//	}
+func (o *OData) NextPage() *Request {
+	opChain := o.chain.enter("NextPage()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newRequest(followChain(opChain), o.config, http.MethodGet, "")
+	}
+
+	if o.origin == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected NextPage() call for response" +
+					" that was not created by Expect"),
+			},
+		})
+		return newRequest(followChain(opChain), o.config, http.MethodGet, "")
+	}
+
+	next := o.annotation(opChain, "@odata.nextLink")
+	if opChain.failed() {
+		return newRequest(followChain(opChain), o.config, http.MethodGet, "")
+	}
+
+	if next.Raw() == "" {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{o.value},
+			Errors: []error{
+				errors.New(`expected: response has "@odata.nextLink"`),
+			},
+		})
+		return newRequest(followChain(opChain), o.config, http.MethodGet, "")
+	}
+
+	return followLink(opChain, o.origin, o.base, next.Raw())
+}
+
+// DeltaLink returns a new String instance with "@odata.deltaLink".
+// If response has no delta link, empty string is returned.
+func (o *OData) DeltaLink() *String {
+	opChain := o.chain.enter("DeltaLink()")
+	defer opChain.leave()
+
+	return o.annotation(opChain, "@odata.deltaLink")
+}
+
+func (o *OData) annotation(opChain *chain, name string) *String {
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	value, ok := o.value[name]
+	if !ok {
+		return newString(opChain, "")
+	}
+
+	str, ok := value.(string)
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				errors.New("expected: OData annotation is a string"),
+			},
+		})
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, str)
+}
@@ -0,0 +1,206 @@
+package httpexpect
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newODataResponse(t *testing.T, contentType, body string) *Response {
+	return NewResponse(newMockReporter(t), &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {contentType}},
+		Body:       newMockBody(body),
+	})
+}
+
+func TestOData_Envelope(t *testing.T) {
+	resp := newODataResponse(t, "application/json;odata.metadata=minimal", `{
+		"@odata.context": "$metadata#Products",
+		"@odata.count": 42,
+		"@odata.nextLink": "Products?$skip=2",
+		"value": [
+			{"ID": 1, "Name": "Bread"},
+			{"ID": 2, "Name": "Milk"}
+		]
+	}`)
+
+	odata := resp.OData()
+
+	odata.Context().IsEqual("$metadata#Products")
+	odata.Count().IsEqual(42)
+	odata.NextLink().IsEqual("Products?$skip=2")
+	odata.DeltaLink().IsEmpty()
+	odata.Value().Length().IsEqual(2)
+	odata.Value().Value(1).Object().HasValue("Name", "Milk")
+	odata.Envelope().ContainsKey("value")
+
+	odata.chain.assert(t, success)
+}
+
+func TestOData_LastPage(t *testing.T) {
+	odata := newODataResponse(t, "application/json", `{"value": []}`).OData()
+
+	odata.NextLink().IsEmpty()
+	odata.Context().IsEmpty()
+	odata.Value().IsEmpty()
+	odata.chain.assert(t, success)
+
+	odata.Count()
+	odata.chain.assert(t, failure)
+}
+
+func TestOData_NextPage(t *testing.T) {
+	pages := map[string]string{
+		"/odata/Products": `{"value":[{"ID":1}],` +
+			`"@odata.nextLink":"Products?$skip=1"}`,
+		"/odata/Products?$skip=1": `{"value":[{"ID":2}],` +
+			`"@odata.nextLink":"http://example.com/odata/Products?$skip=2"}`,
+		"/odata/Products?$skip=2": `{"value":[{"ID":3}]}`,
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query, _ := url.QueryUnescape(r.URL.RawQuery)
+
+		body, ok := pages[strings.TrimSuffix(r.URL.Path+"?"+query, "?")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json;odata.metadata=minimal")
+		_, _ = w.Write([]byte(body))
+	})
+
+	newExpect := func(t *testing.T) *Expect {
+		return WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Reporter: newMockReporter(t),
+			Client: &http.Client{
+				Transport: NewBinder(handler),
+			},
+		})
+	}
+
+	t.Run("walk pages", func(t *testing.T) {
+		e := newExpect(t)
+
+		var ids []float64
+
+		odata := e.GET("/odata/Products").Expect().Status(http.StatusOK).OData()
+		ids = append(ids, odata.Value().Value(0).Object().Value("ID").Number().Raw())
+
+		for odata.NextLink().Raw() != "" {
+			odata = odata.NextPage().Expect().Status(http.StatusOK).OData()
+			ids = append(ids, odata.Value().Value(0).Object().Value("ID").Number().Raw())
+		}
+
+		assert.Equal(t, []float64{1, 2, 3}, ids)
+
+		e.chain.assert(t, success)
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		e := newExpect(t)
+
+		odata := e.GET("/odata/Products").WithQuery("$skip", 2).
+			Expect().OData()
+		odata.chain.assert(t, success)
+
+		req := odata.NextPage()
+		req.chain.assert(t, failure)
+		odata.chain.assert(t, failure)
+	})
+
+	t.Run("no origin", func(t *testing.T) {
+		odata := newODataResponse(t, "application/json",
+			`{"value":[],"@odata.nextLink":"Products?$skip=2"}`).OData()
+
+		req := odata.NextPage()
+		req.chain.assert(t, failure)
+		odata.chain.assert(t, failure)
+	})
+}
+
+func TestOData_Invalid(t *testing.T) {
+	cases := []struct {
+		name        string
+		contentType string
+		body        string
+		check       func(*OData)
+	}{
+		{
+			name:        "wrong media type",
+			contentType: "text/plain",
+			body:        `{"value":[]}`,
+		},
+		{
+			name:        "not object",
+			contentType: "application/json",
+			body:        `[]`,
+		},
+		{
+			name:        "value not array",
+			contentType: "application/json",
+			body:        `{"value":{}}`,
+			check: func(o *OData) {
+				o.Value()
+			},
+		},
+		{
+			name:        "missing value",
+			contentType: "application/json",
+			body:        `{"ID":1}`,
+			check: func(o *OData) {
+				o.Value()
+			},
+		},
+		{
+			name:        "count not number",
+			contentType: "application/json",
+			body:        `{"@odata.count":"42"}`,
+			check: func(o *OData) {
+				o.Count()
+			},
+		},
+		{
+			name:        "next link not string",
+			contentType: "application/json",
+			body:        `{"@odata.nextLink":1}`,
+			check: func(o *OData) {
+				o.NextLink()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			odata := newODataResponse(t, tc.contentType, tc.body).OData()
+
+			if tc.check != nil {
+				odata.chain.assert(t, success)
+				tc.check(odata)
+			}
+
+			odata.chain.assert(t, failure)
+		})
+	}
+}
+
+func TestOData_FailedChain(t *testing.T) {
+	chain := newMockChain(t, flagFailed)
+
+	odata := newOData(chain, newMockConfig(newMockReporter(t)), nil, nil, nil)
+	odata.chain.assert(t, failure)
+
+	odata.Alias("foo")
+	odata.Envelope().chain.assert(t, failure)
+	odata.Value().chain.assert(t, failure)
+	odata.Count().chain.assert(t, failure)
+	odata.Context().chain.assert(t, failure)
+	odata.NextLink().chain.assert(t, failure)
+	odata.DeltaLink().chain.assert(t, failure)
+	odata.NextPage().chain.assert(t, failure)
+}
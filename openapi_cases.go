@@ -0,0 +1,619 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// OpenAPICases generates test cases from examples in OpenAPI 3 document,
+// so that documented examples are continuously verified against the
+// live service using Expect.RunCases.
+//
+// Spec may be in JSON or YAML format. Local references ("$ref" starting
+// with "#/") are resolved.
+//
+// A test case is generated for every example of every response that has
+// numeric status code. For such case:
+//   - request is built from operation method and path
+//   - path, query, header, and cookie parameters are taken from "example"
+//     or "examples" of parameter or of its schema
+//   - request body is taken from "example" or "examples" of request body
+//
+// If parameter or request body has an example with the same name as
+// response example, it's used; otherwise, the first one (in alphabetical
+// order) is used. This allows to describe, for instance, a request with
+// non-existent ID in a "not-found" example of both parameter and response.
+//
+// Response of the case is checked as follows:
+//   - status should be equal to response status code
+//   - JSON response body is expected to contain example object as a subset
+//     (see Object.ContainsSubset), or be equal to example of other types;
+//     text response body is expected to be equal to example; otherwise
+//     response is expected to have given media type
+//
+// JSON media types are preferred when request body or response defines
+// several media types. Responses without examples, and responses with
+// status ranges (like "4XX") and "default" responses are skipped.
+//
+// Returns error if spec can't be parsed, or if a required parameter or
+// request body of an operation that has response examples has no example.
+//
+// Example:
+//
+//	spec, err := os.ReadFile("openapi.yaml")
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//
+//	cases, err := httpexpect.OpenAPICases(spec)
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//
+//	e := httpexpect.Default(t, "http://example.com")
+//	e.RunCases(t, cases)
+func OpenAPICases(spec []byte) ([]TestCase, error) {
+	doc, err := parseOpenAPIDocument(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	paths, _ := doc["paths"].(map[string]interface{})
+
+	var cases []TestCase
+
+	for _, path := range sortedMapKeys(paths) {
+		item, err := openAPIResolveMap(doc, paths[path])
+		if err != nil {
+			return nil, fmt.Errorf("invalid OpenAPI path %s: %w", path, err)
+		}
+
+		for _, method := range openAPIMethods {
+			if _, ok := item[method]; !ok {
+				continue
+			}
+
+			op, err := openAPIResolveMap(doc, item[method])
+			if err == nil {
+				var opCases []TestCase
+				opCases, err = openAPIOperationCases(
+					doc, strings.ToUpper(method), path, item["parameters"], op)
+				cases = append(cases, opCases...)
+			}
+			if err != nil {
+				return nil, fmt.Errorf(
+					"invalid OpenAPI operation %s %s: %w",
+					strings.ToUpper(method), path, err)
+			}
+		}
+	}
+
+	return cases, nil
+}
+
+func parseOpenAPIDocument(spec []byte) (map[string]interface{}, error) {
+	var doc interface{}
+
+	if err := json.Unmarshal(spec, &doc); err != nil {
+		var raw interface{}
+		if yamlErr := yaml.Unmarshal(spec, &raw); yamlErr != nil {
+			return nil, fmt.Errorf("invalid OpenAPI spec: %w", yamlErr)
+		}
+		doc = normalizeYAML(raw)
+	}
+
+	docMap, ok := doc.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid OpenAPI spec: expected object, got %s",
+			jsonTypeName(doc))
+	}
+
+	return docMap, nil
+}
+
+// YAML mappings may have non-string keys, like status codes;
+// convert them to strings and numbers to float64, as in JSON.
+func normalizeYAML(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, elem := range v {
+			v[key] = normalizeYAML(elem)
+		}
+		return v
+
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, elem := range v {
+			m[fmt.Sprint(key)] = normalizeYAML(elem)
+		}
+		return m
+
+	case []interface{}:
+		for i, elem := range v {
+			v[i] = normalizeYAML(elem)
+		}
+		return v
+
+	case int:
+		return float64(v)
+
+	case uint64:
+		return float64(v)
+	}
+
+	return value
+}
+
+type openAPIParam struct {
+	name     string
+	in       string
+	examples map[string]interface{}
+	value    interface{}
+	hasValue bool
+}
+
+func openAPIOperationCases(
+	doc map[string]interface{},
+	method, path string,
+	pathParams interface{},
+	op map[string]interface{},
+) ([]TestCase, error) {
+	type responseExample struct {
+		status    int
+		mediaType string
+		name      string
+		value     interface{}
+	}
+
+	var examples []responseExample
+
+	responses, _ := op["responses"].(map[string]interface{})
+
+	for _, statusStr := range sortedMapKeys(responses) {
+		status, err := strconv.Atoi(statusStr)
+		if err != nil || status < 100 || status > 599 {
+			continue
+		}
+
+		response, err := openAPIResolveMap(doc, responses[statusStr])
+		if err != nil {
+			return nil, fmt.Errorf("response %s: %w", statusStr, err)
+		}
+
+		mediaType, media, err := openAPIMedia(doc, response["content"])
+		if err != nil {
+			return nil, fmt.Errorf("response %s: %w", statusStr, err)
+		}
+		if media == nil {
+			continue
+		}
+
+		names, values, err := openAPIExamples(doc, media)
+		if err != nil {
+			return nil, fmt.Errorf("response %s: %w", statusStr, err)
+		}
+
+		for _, name := range names {
+			examples = append(examples, responseExample{
+				status:    status,
+				mediaType: mediaType,
+				name:      name,
+				value:     values[name],
+			})
+		}
+	}
+
+	if len(examples) == 0 {
+		return nil, nil
+	}
+
+	params, err := openAPIParams(doc, pathParams, op["parameters"])
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		bodyType     string
+		bodyNames    []string
+		bodyExamples map[string]interface{}
+	)
+
+	if op["requestBody"] != nil {
+		body, err := openAPIResolveMap(doc, op["requestBody"])
+		if err != nil {
+			return nil, fmt.Errorf("request body: %w", err)
+		}
+
+		var media map[string]interface{}
+
+		bodyType, media, err = openAPIMedia(doc, body["content"])
+		if err != nil {
+			return nil, fmt.Errorf("request body: %w", err)
+		}
+
+		if media != nil {
+			bodyNames, bodyExamples, err = openAPIExamples(doc, media)
+			if err != nil {
+				return nil, fmt.Errorf("request body: %w", err)
+			}
+		}
+
+		required, _ := body["required"].(bool)
+		if required && len(bodyNames) == 0 {
+			return nil, fmt.Errorf("no example for required request body")
+		}
+
+		if len(bodyNames) != 0 {
+			err := checkOpenAPIBody(bodyType, bodyExamples[bodyNames[0]])
+			if err != nil {
+				return nil, fmt.Errorf("request body: %w", err)
+			}
+		}
+	}
+
+	cases := make([]TestCase, 0, len(examples))
+
+	for _, ex := range examples {
+		ex := ex
+
+		name := fmt.Sprintf("%s %s %d", method, path, ex.status)
+		if ex.name != "" {
+			name += " " + ex.name
+		}
+
+		var mutators []func(req *Request)
+
+		for _, p := range params {
+			if !p.hasValue {
+				continue
+			}
+			value := p.value
+			if v, ok := p.examples[ex.name]; ok {
+				value = v
+			}
+			mutators = append(mutators, openAPIParamMutator(p.name, p.in, value))
+		}
+
+		if len(bodyNames) != 0 {
+			bodyName := bodyNames[0]
+			if _, ok := bodyExamples[ex.name]; ok {
+				bodyName = ex.name
+			}
+			mutators = append(mutators,
+				openAPIBodyMutator(bodyType, bodyExamples[bodyName]))
+		}
+
+		cases = append(cases, TestCase{
+			Name:    name,
+			Method:  method,
+			Path:    path,
+			Request: mutators,
+			Status:  ex.status,
+			Check: func(resp *Response) {
+				checkOpenAPIResponse(resp, ex.mediaType, ex.value)
+			},
+		})
+	}
+
+	return cases, nil
+}
+
+func openAPIParamMutator(name, in string, value interface{}) func(req *Request) {
+	value = openAPIParamValue(value)
+
+	switch in {
+	case "path":
+		return func(req *Request) {
+			req.WithPath(name, value)
+		}
+
+	case "query":
+		if reflect.ValueOf(value).Kind() == reflect.Slice {
+			return func(req *Request) {
+				req.WithQuerySlice(name, value, QueryRepeat)
+			}
+		}
+		return func(req *Request) {
+			req.WithQuery(name, value)
+		}
+
+	case "header":
+		return func(req *Request) {
+			req.WithHeader(name, fmt.Sprint(value))
+		}
+
+	case "cookie":
+		return func(req *Request) {
+			req.WithCookie(name, fmt.Sprint(value))
+		}
+	}
+
+	return func(req *Request) {}
+}
+
+// Format numbers decoded as float64 without exponent, so that example
+// 1000000 becomes "1000000" rather than "1e+06".
+func openAPIParamValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+
+	case []interface{}:
+		ret := make([]interface{}, len(v))
+		for i := range v {
+			ret[i] = openAPIParamValue(v[i])
+		}
+		return ret
+	}
+
+	return value
+}
+
+// Merge path-level and operation-level parameters; the latter
+// override the former with the same name and location.
+func openAPIParams(
+	doc map[string]interface{}, pathParams, opParams interface{},
+) ([]openAPIParam, error) {
+	var (
+		params []openAPIParam
+		index  = map[string]int{}
+	)
+
+	for _, list := range []interface{}{pathParams, opParams} {
+		items, _ := list.([]interface{})
+
+		for _, item := range items {
+			param, err := openAPIResolveMap(doc, item)
+			if err != nil {
+				return nil, fmt.Errorf("parameter: %w", err)
+			}
+
+			name, _ := param["name"].(string)
+			in, _ := param["in"].(string)
+
+			if name == "" || in == "" {
+				return nil, fmt.Errorf("parameter without name or location")
+			}
+
+			p := openAPIParam{name: name, in: in}
+
+			p.examples, p.value, p.hasValue, err = openAPIParamExamples(doc, param)
+			if err != nil {
+				return nil, fmt.Errorf("parameter %q: %w", name, err)
+			}
+
+			required, _ := param["required"].(bool)
+			if (required || in == "path") && !p.hasValue {
+				return nil, fmt.Errorf("no example for required %s parameter %q",
+					in, name)
+			}
+
+			key := in + "/" + name
+			if n, ok := index[key]; ok {
+				params[n] = p
+			} else {
+				index[key] = len(params)
+				params = append(params, p)
+			}
+		}
+	}
+
+	return params, nil
+}
+
+// Returns named examples of parameter, and default value, which is the
+// first named example, or example or default value from schema.
+func openAPIParamExamples(
+	doc map[string]interface{}, param map[string]interface{},
+) (map[string]interface{}, interface{}, bool, error) {
+	names, values, err := openAPIExamples(doc, param)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if len(names) != 0 {
+		return values, values[names[0]], true, nil
+	}
+
+	if param["schema"] != nil {
+		schema, err := openAPIResolveMap(doc, param["schema"])
+		if err != nil {
+			return nil, nil, false, err
+		}
+		for _, key := range []string{"example", "default"} {
+			if value, ok := schema[key]; ok {
+				return nil, value, true, nil
+			}
+		}
+	}
+
+	return nil, nil, false, nil
+}
+
+// Returns examples of media type or parameter object, sorted by name.
+// Single "example" has empty name.
+func openAPIExamples(
+	doc map[string]interface{}, obj map[string]interface{},
+) ([]string, map[string]interface{}, error) {
+	values := map[string]interface{}{}
+
+	if examples, ok := obj["examples"].(map[string]interface{}); ok {
+		for name, raw := range examples {
+			example, err := openAPIResolveMap(doc, raw)
+			if err != nil {
+				return nil, nil, fmt.Errorf("example %q: %w", name, err)
+			}
+			// examples with "externalValue" only are skipped
+			if value, ok := example["value"]; ok {
+				values[name] = value
+			}
+		}
+	} else if value, ok := obj["example"]; ok {
+		values[""] = value
+	}
+
+	return sortedMapKeys(values), values, nil
+}
+
+// Returns preferred media type from content map and its media type object.
+func openAPIMedia(
+	doc map[string]interface{}, content interface{},
+) (string, map[string]interface{}, error) {
+	contentMap, _ := content.(map[string]interface{})
+
+	types := sortedMapKeys(contentMap)
+	if len(types) == 0 {
+		return "", nil, nil
+	}
+
+	mediaType := types[0]
+	for _, t := range types {
+		if isJSONMediaType(strings.ToLower(t)) {
+			mediaType = t
+			break
+		}
+	}
+
+	media, err := openAPIResolveMap(doc, contentMap[mediaType])
+	if err != nil {
+		return "", nil, fmt.Errorf("media type %s: %w", mediaType, err)
+	}
+
+	return mediaType, media, nil
+}
+
+func checkOpenAPIBody(mediaType string, value interface{}) error {
+	switch {
+	case isJSONMediaType(strings.ToLower(mediaType)):
+		return nil
+
+	case strings.EqualFold(mediaType, "application/x-www-form-urlencoded"):
+		if _, ok := value.(map[string]interface{}); ok {
+			return nil
+		}
+
+	default:
+		if _, ok := value.(string); ok {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unsupported %s example of media type %s",
+		jsonTypeName(value), mediaType)
+}
+
+func openAPIBodyMutator(mediaType string, value interface{}) func(req *Request) {
+	switch {
+	case isJSONMediaType(strings.ToLower(mediaType)):
+		return func(req *Request) {
+			if !strings.EqualFold(mediaType, "application/json") {
+				req.WithHeader("Content-Type", mediaType)
+			}
+			req.WithJSON(value)
+		}
+
+	case strings.EqualFold(mediaType, "application/x-www-form-urlencoded"):
+		return func(req *Request) {
+			req.WithForm(value)
+		}
+
+	default:
+		return func(req *Request) {
+			req.WithHeader("Content-Type", mediaType)
+			req.WithBytes([]byte(fmt.Sprint(value)))
+		}
+	}
+}
+
+func checkOpenAPIResponse(resp *Response, mediaType string, example interface{}) {
+	opts := ContentOpts{MediaType: strings.ToLower(mediaType)}
+
+	if isJSONMediaType(opts.MediaType) {
+		value := resp.JSON(opts)
+
+		if obj, ok := example.(map[string]interface{}); ok {
+			value.Object().ContainsSubset(obj)
+		} else {
+			value.IsEqual(example)
+		}
+		return
+	}
+
+	if text, ok := example.(string); ok {
+		resp.Text(opts).IsEqual(text)
+		return
+	}
+
+	resp.HasContentType(opts.MediaType)
+}
+
+func openAPIResolveMap(
+	doc map[string]interface{}, value interface{},
+) (map[string]interface{}, error) {
+	resolved, err := openAPIResolve(doc, value)
+	if err != nil {
+		return nil, err
+	}
+
+	m, ok := resolved.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected object, got %s", jsonTypeName(resolved))
+	}
+
+	return m, nil
+}
+
+// Follow local "$ref" references, like "#/components/schemas/User".
+func openAPIResolve(doc map[string]interface{}, value interface{}) (interface{}, error) {
+	const maxDepth = 32
+
+	for depth := 0; ; depth++ {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return value, nil
+		}
+
+		ref, ok := m["$ref"].(string)
+		if !ok {
+			return value, nil
+		}
+
+		if depth == maxDepth {
+			return nil, fmt.Errorf("too deep or cyclic reference %q", ref)
+		}
+
+		if !strings.HasPrefix(ref, "#/") {
+			return nil, fmt.Errorf("unsupported non-local reference %q", ref)
+		}
+
+		var target interface{} = doc
+
+		for _, segment := range strings.Split(ref[2:], "/") {
+			segment = strings.NewReplacer("~1", "/", "~0", "~").Replace(segment)
+
+			obj, ok := target.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("unresolved reference %q", ref)
+			}
+			if target, ok = obj[segment]; !ok {
+				return nil, fmt.Errorf("unresolved reference %q", ref)
+			}
+		}
+
+		value = target
+	}
+}
+
+func sortedMapKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,358 @@
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const openAPICasesJSON = `{
+  "openapi": "3.0.0",
+  "paths": {
+    "/users/{id}": {
+      "parameters": [
+        {"$ref": "#/components/parameters/UserID"}
+      ],
+      "get": {
+        "parameters": [
+          {"name": "fields", "in": "query",
+           "schema": {"type": "array", "example": ["name", "email"]}},
+          {"name": "X-Tenant", "in": "header", "required": true,
+           "examples": {"acme": {"value": "acme"}}}
+        ],
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "examples": {
+                  "john": {"$ref": "#/components/examples/John"}
+                }
+              }
+            }
+          },
+          "404": {
+            "content": {
+              "text/plain": {
+                "examples": {"missing": {"value": "not found"}}
+              }
+            }
+          },
+          "default": {
+            "content": {
+              "application/json": {"example": {"error": "unknown"}}
+            }
+          }
+        }
+      }
+    },
+    "/users": {
+      "get": {
+        "responses": {
+          "200": {
+            "content": {
+              "application/xml": {"example": "<users/>"},
+              "application/json": {"example": [{"id": 1}]}
+            }
+          }
+        }
+      },
+      "post": {
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "examples": {
+                "alice": {"value": {"name": "alice"}},
+                "bob": {"value": {"name": "bob"}}
+              }
+            }
+          }
+        },
+        "responses": {
+          "201": {
+            "content": {
+              "application/json": {
+                "examples": {
+                  "bob": {"value": {"id": 2, "name": "bob"}}
+                }
+              }
+            }
+          },
+          "400": {
+            "description": "no examples"
+          }
+        }
+      }
+    }
+  },
+  "components": {
+    "parameters": {
+      "UserID": {"name": "id", "in": "path", "required": true,
+                 "examples": {"john": {"value": 1}, "missing": {"value": 9}}}
+    },
+    "examples": {
+      "John": {"value": {"id": 1, "name": "john"}}
+    }
+  }
+}`
+
+const openAPICasesYAML = `
+openapi: 3.0.0
+paths:
+  /items/{name}:
+    put:
+      parameters:
+        - name: name
+          in: path
+          example: box
+      requestBody:
+        content:
+          application/x-www-form-urlencoded:
+            example:
+              size: 3
+      responses:
+        200:
+          content:
+            application/vnd.api+json:
+              example:
+                name: box
+                size: 3
+`
+
+func TestOpenAPICases_Generate(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		cases, err := OpenAPICases([]byte(openAPICasesJSON))
+		require.NoError(t, err)
+
+		var names []string
+		for _, c := range cases {
+			names = append(names, c.Name)
+		}
+
+		assert.Equal(t, []string{
+			"GET /users 200",
+			"POST /users 201 bob",
+			"GET /users/{id} 200 john",
+			"GET /users/{id} 404 missing",
+		}, names)
+
+		assert.Equal(t, "POST", cases[1].Method)
+		assert.Equal(t, "/users", cases[1].Path)
+		assert.Equal(t, http.StatusCreated, cases[1].Status)
+		assert.Equal(t, 1, len(cases[1].Request))
+
+		assert.Equal(t, "/users/{id}", cases[2].Path)
+		assert.Equal(t, 3, len(cases[2].Request))
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		cases, err := OpenAPICases([]byte(openAPICasesYAML))
+		require.NoError(t, err)
+
+		require.Equal(t, 1, len(cases))
+		assert.Equal(t, "PUT /items/{name} 200", cases[0].Name)
+		assert.Equal(t, http.StatusOK, cases[0].Status)
+		assert.Equal(t, 2, len(cases[0].Request))
+	})
+
+	t.Run("no examples", func(t *testing.T) {
+		cases, err := OpenAPICases([]byte(`{"paths": {"/": {"get": {}}}}`))
+		require.NoError(t, err)
+		assert.Empty(t, cases)
+	})
+}
+
+func TestOpenAPICases_Errors(t *testing.T) {
+	cases := []struct {
+		name string
+		spec string
+	}{
+		{
+			name: "invalid spec",
+			spec: `{"paths": [`,
+		},
+		{
+			name: "not an object",
+			spec: `[1, 2]`,
+		},
+		{
+			name: "missing path param example",
+			spec: `{"paths": {"/users/{id}": {"get": {
+				"parameters": [{"name": "id", "in": "path"}],
+				"responses": {"200": {"content": {
+					"text/plain": {"example": "ok"}}}}}}}}`,
+		},
+		{
+			name: "missing required body example",
+			spec: `{"paths": {"/users": {"post": {
+				"requestBody": {"required": true, "content": {
+					"application/json": {}}},
+				"responses": {"200": {"content": {
+					"text/plain": {"example": "ok"}}}}}}}}`,
+		},
+		{
+			name: "unresolved reference",
+			spec: `{"paths": {"/users": {"get": {
+				"responses": {"200": {"$ref": "#/components/responses/OK"}}}}}}`,
+		},
+		{
+			name: "cyclic reference",
+			spec: `{"paths": {"/users": {"get": {
+				"responses": {"200": {"$ref": "#/components/a"}}}}},
+				"components": {"a": {"$ref": "#/components/a"}}}`,
+		},
+		{
+			name: "non-local reference",
+			spec: `{"paths": {"/users": {"get": {
+				"responses": {"200": {"$ref": "other.json#/OK"}}}}}}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := OpenAPICases([]byte(tc.spec))
+			assert.Error(t, err)
+			assert.Nil(t, result)
+		})
+	}
+}
+
+func TestOpenAPICases_ParamValues(t *testing.T) {
+	const spec = `{
+  "openapi": "3.0.0",
+  "paths": {
+    "/items/{id}": {
+      "get": {
+        "parameters": [
+          {"name": "id", "in": "path", "example": 1000000},
+          {"name": "ids", "in": "query", "example": [2000000, 0.5]},
+          {"name": "X-Limit", "in": "header", "example": 3000000},
+          {"name": "offset", "in": "cookie", "example": 4000000}
+        ],
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {"example": {"ok": true}}
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+	cases, err := OpenAPICases([]byte(spec))
+	require.NoError(t, err)
+	require.Equal(t, 1, len(cases))
+
+	var httpReq *http.Request
+
+	e := WithConfig(Config{
+		Client: ClientFunc(func(req *http.Request) (*http.Response, error) {
+			httpReq = req
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body:       io.NopCloser(bytes.NewBufferString(`{"ok":true}`)),
+			}, nil
+		}),
+		Reporter: newMockReporter(t),
+	})
+
+	e.RunCases(t, cases)
+	e.chain.assert(t, success)
+
+	require.NotNil(t, httpReq)
+
+	assert.Equal(t, "/items/1000000", httpReq.URL.Path)
+	assert.Equal(t, []string{"2000000", "0.5"}, httpReq.URL.Query()["ids"])
+	assert.Equal(t, "3000000", httpReq.Header.Get("X-Limit"))
+
+	cookie, err := httpReq.Cookie("offset")
+	require.NoError(t, err)
+	assert.Equal(t, "4000000", cookie.Value)
+}
+
+func TestOpenAPICases_Run(t *testing.T) {
+	respond := func(status int, contentType, body string) *http.Response {
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{"Content-Type": {contentType}},
+			Body:       io.NopCloser(bytes.NewBufferString(body)),
+		}
+	}
+
+	newClient := func(t *testing.T, userName string) Client {
+		return ClientFunc(func(req *http.Request) (*http.Response, error) {
+			switch {
+			case req.Method == "GET" && req.URL.Path == "/users":
+				return respond(http.StatusOK, "application/json",
+					`[{"id":1}]`), nil
+
+			case req.Method == "POST" && req.URL.Path == "/users":
+				var body map[string]interface{}
+				require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+				assert.Equal(t, map[string]interface{}{"name": "bob"}, body)
+
+				return respond(http.StatusCreated, "application/json",
+					`{"id":2,"name":"bob","admin":false}`), nil
+
+			case req.URL.Path == "/users/1":
+				assert.Equal(t, "acme", req.Header.Get("X-Tenant"))
+				assert.Equal(t, []string{"name", "email"},
+					req.URL.Query()["fields"])
+
+				return respond(http.StatusOK, "application/json",
+					`{"id":1,"name":"`+userName+`"}`), nil
+
+			case req.URL.Path == "/items/box":
+				assert.NoError(t, req.ParseForm())
+				assert.Equal(t, "3", req.PostForm.Get("size"))
+
+				return respond(http.StatusOK, "application/vnd.api+json",
+					`{"name":"box","size":3}`), nil
+			}
+
+			return respond(http.StatusNotFound, "text/plain", "not found"), nil
+		})
+	}
+
+	t.Run("success", func(t *testing.T) {
+		for _, spec := range []string{openAPICasesJSON, openAPICasesYAML} {
+			cases, err := OpenAPICases([]byte(spec))
+			require.NoError(t, err)
+
+			e := WithConfig(Config{
+				Client:   newClient(t, "john"),
+				Reporter: newMockReporter(t),
+			})
+
+			e.RunCases(t, cases)
+
+			e.chain.assert(t, success)
+		}
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		cases, err := OpenAPICases([]byte(openAPICasesJSON))
+		require.NoError(t, err)
+
+		handler := &mockAssertionHandler{}
+
+		e := WithConfig(Config{
+			Client:           newClient(t, "jane"),
+			AssertionHandler: handler,
+		})
+
+		e.RunCases(t, cases)
+
+		assert.Equal(t, 1, handler.failureCalled)
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertContainsSubset, handler.failure.Type)
+	})
+}
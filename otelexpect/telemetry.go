@@ -0,0 +1,288 @@
+// Package otelexpect implements httpexpect.Telemetry using OpenTelemetry.
+//
+// It is a separate module, so that httpexpect itself doesn't depend on
+// OpenTelemetry.
+package otelexpect
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gavv/httpexpect/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Telemetry enables OpenTelemetry instrumentation of requests.
+//
+// When Telemetry is set in httpexpect.Config, httpexpect produces:
+//   - a span per request, with a child span per every attempt
+//     (there are several attempts when retries are enabled)
+//   - span events for assertion failures related to request or response
+//   - counter of sent requests ("httpexpect.requests")
+//   - histogram of request durations ("httpexpect.request.duration")
+//   - counter of assertion failures ("httpexpect.assertion.failures")
+//
+// Trace context of the attempt span is injected into request headers
+// using Propagator, so if the server under test is instrumented too,
+// its spans become children of the test spans.
+//
+// Example:
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		BaseURL:  "http://example.com",
+//		Reporter: httpexpect.NewAssertReporter(t),
+//		Telemetry: &otelexpect.Telemetry{
+//			TracerProvider: tracerProvider,
+//			MeterProvider:  meterProvider,
+//		},
+//	})
+type Telemetry struct {
+	// Provider used to create spans.
+	// If nil, global provider is used (see otel.GetTracerProvider).
+	TracerProvider trace.TracerProvider
+
+	// Provider used to create metrics.
+	// If nil, global provider is used (see otel.GetMeterProvider).
+	MeterProvider metric.MeterProvider
+
+	// Propagator used to inject trace context into requests.
+	// If nil, global propagator is used (see otel.GetTextMapPropagator).
+	Propagator propagation.TextMapPropagator
+
+	once sync.Once
+
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+
+	requestCounter  metric.Int64Counter
+	requestDuration metric.Float64Histogram
+	failureCounter  metric.Int64Counter
+}
+
+const telemetryScope = "github.com/gavv/httpexpect/v2"
+
+var _ httpexpect.Telemetry = (*Telemetry)(nil)
+
+func (t *Telemetry) init() {
+	t.once.Do(func() {
+		tracerProvider := t.TracerProvider
+		if tracerProvider == nil {
+			tracerProvider = otel.GetTracerProvider()
+		}
+
+		meterProvider := t.MeterProvider
+		if meterProvider == nil {
+			meterProvider = otel.GetMeterProvider()
+		}
+
+		t.propagator = t.Propagator
+		if t.propagator == nil {
+			t.propagator = otel.GetTextMapPropagator()
+		}
+
+		t.tracer = tracerProvider.Tracer(telemetryScope)
+
+		meter := meterProvider.Meter(telemetryScope)
+		noopMeter := metricnoop.NewMeterProvider().Meter(telemetryScope)
+
+		var err error
+
+		t.requestCounter, err = meter.Int64Counter("httpexpect.requests",
+			metric.WithDescription("Number of sent HTTP requests, including retries."))
+		if err != nil {
+			otel.Handle(err)
+			t.requestCounter, _ = noopMeter.Int64Counter("httpexpect.requests")
+		}
+
+		t.requestDuration, err = meter.Float64Histogram("httpexpect.request.duration",
+			metric.WithDescription("Duration of HTTP requests, including retries."),
+			metric.WithUnit("s"))
+		if err != nil {
+			otel.Handle(err)
+			t.requestDuration, _ = noopMeter.Float64Histogram("httpexpect.request.duration")
+		}
+
+		t.failureCounter, err = meter.Int64Counter("httpexpect.assertion.failures",
+			metric.WithDescription("Number of failed assertions."))
+		if err != nil {
+			otel.Handle(err)
+			t.failureCounter, _ = noopMeter.Int64Counter("httpexpect.assertion.failures")
+		}
+	})
+}
+
+// StartRequest implements httpexpect.Telemetry.
+func (t *Telemetry) StartRequest(
+	ctx context.Context, info httpexpect.TelemetryRequest,
+) httpexpect.TelemetryRequestSpan {
+	t.init()
+
+	ctx, span := t.tracer.Start(ctx, "HTTP "+info.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(requestAttributes(info)...))
+
+	return &requestSpan{
+		telemetry: t,
+		info:      info,
+		ctx:       ctx,
+		span:      span,
+	}
+}
+
+// Span of single request.
+type requestSpan struct {
+	mu sync.Mutex
+
+	telemetry *Telemetry
+	info      httpexpect.TelemetryRequest
+
+	// Context with request span.
+	ctx  context.Context
+	span trace.Span
+
+	// Set when request span is ended.
+	ended bool
+}
+
+// StartAttempt implements httpexpect.TelemetryRequestSpan.
+func (rs *requestSpan) StartAttempt(
+	ctx context.Context, attempt int, header http.Header,
+) (context.Context, httpexpect.TelemetryAttemptSpan) {
+	// attempt span is a child of request span, while request context
+	// may be overwritten on every attempt (e.g. to set timeout)
+	parentCtx := trace.ContextWithSpan(ctx, rs.span)
+
+	ctx, span := rs.telemetry.tracer.Start(parentCtx,
+		"HTTP "+rs.info.Method+" attempt",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(requestAttributes(rs.info)...),
+		trace.WithAttributes(attribute.Int("httpexpect.attempt", attempt)))
+
+	rs.telemetry.propagator.Inject(ctx, propagation.HeaderCarrier(header))
+
+	return ctx, &attemptSpan{
+		telemetry: rs.telemetry,
+		info:      rs.info,
+		ctx:       ctx,
+		span:      span,
+	}
+}
+
+// RecordFailure implements httpexpect.TelemetryRequestSpan.
+// If request span is already ended, event is added to a new child span.
+func (rs *requestSpan) RecordFailure(failure httpexpect.TelemetryFailure) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	attrs := []attribute.KeyValue{
+		attribute.String("httpexpect.assertion.type", failure.Type.String()),
+		attribute.String("httpexpect.assertion.severity", failure.Severity.String()),
+		attribute.String("httpexpect.assertion.path", failure.Path),
+	}
+	if len(failure.Errors) != 0 {
+		attrs = append(attrs,
+			attribute.StringSlice("httpexpect.assertion.errors", failure.Errors))
+	}
+
+	rs.telemetry.failureCounter.Add(rs.ctx, 1, metric.WithAttributes(
+		attribute.String("httpexpect.assertion.type", failure.Type.String()),
+		attribute.String("httpexpect.assertion.severity", failure.Severity.String()),
+	))
+
+	span := rs.span
+	if rs.ended {
+		_, span = rs.telemetry.tracer.Start(rs.ctx, "httpexpect.assertion")
+		defer span.End()
+	}
+
+	span.AddEvent("assertion failure", trace.WithAttributes(attrs...))
+
+	if failure.Severity == httpexpect.SeverityError {
+		span.SetStatus(codes.Error, "assertion failed")
+	}
+}
+
+// End implements httpexpect.TelemetryRequestSpan.
+func (rs *requestSpan) End(resp *http.Response, err error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if rs.ended {
+		return
+	}
+
+	setSpanResult(rs.span, resp, err)
+
+	rs.span.End()
+	rs.ended = true
+}
+
+// Span of single attempt.
+type attemptSpan struct {
+	telemetry *Telemetry
+	info      httpexpect.TelemetryRequest
+
+	ctx  context.Context
+	span trace.Span
+}
+
+// End implements httpexpect.TelemetryAttemptSpan.
+// Also records request metrics.
+func (as *attemptSpan) End(resp *http.Response, err error, elapsed time.Duration) {
+	setSpanResult(as.span, resp, err)
+	as.span.End()
+
+	attrs := []attribute.KeyValue{
+		attribute.String("http.method", as.info.Method),
+	}
+	if resp != nil {
+		attrs = append(attrs, attribute.Int("http.status_code", resp.StatusCode))
+	} else {
+		attrs = append(attrs, attribute.Bool("error", true))
+	}
+
+	as.telemetry.requestCounter.Add(as.ctx, 1, metric.WithAttributes(attrs...))
+	as.telemetry.requestDuration.Record(as.ctx, elapsed.Seconds(),
+		metric.WithAttributes(attrs...))
+}
+
+func requestAttributes(info httpexpect.TelemetryRequest) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		attribute.String("http.method", info.Method),
+	}
+
+	if info.URL != "" {
+		attrs = append(attrs, attribute.String("http.url", info.URL))
+	}
+
+	if info.TestName != "" {
+		attrs = append(attrs, attribute.String("httpexpect.test_name", info.TestName))
+	}
+
+	return attrs
+}
+
+func setSpanResult(span trace.Span, resp *http.Response, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	if resp != nil {
+		span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode))
+
+		if resp.StatusCode >= 400 {
+			span.SetStatus(codes.Error, strconv.Itoa(resp.StatusCode))
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package otelexpect
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type telemetryTestEnv struct {
+	spans  *tracetest.SpanRecorder
+	reader sdkmetric.Reader
+	config httpexpect.Config
+}
+
+type mockReporter struct {
+	t        *testing.T
+	reported bool
+}
+
+func newMockReporter(t *testing.T) *mockReporter {
+	return &mockReporter{t: t}
+}
+
+func (r *mockReporter) Errorf(message string, args ...interface{}) {
+	r.t.Logf("Fail: "+message, args...)
+	r.reported = true
+}
+
+func newTelemetryTestEnv(t *testing.T, handler http.Handler) *telemetryTestEnv {
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+
+	return &telemetryTestEnv{
+		spans:  spans,
+		reader: reader,
+		config: httpexpect.Config{
+			BaseURL:  "http://example.com",
+			Reporter: newMockReporter(t),
+			Client: &http.Client{
+				Transport: httpexpect.NewBinder(handler),
+			},
+			Telemetry: &Telemetry{
+				TracerProvider: sdktrace.NewTracerProvider(
+					sdktrace.WithSpanProcessor(spans)),
+				MeterProvider: sdkmetric.NewMeterProvider(
+					sdkmetric.WithReader(reader)),
+				Propagator: propagation.TraceContext{},
+			},
+		},
+	}
+}
+
+func (env *telemetryTestEnv) metrics(t *testing.T) map[string]metricdata.Aggregation {
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, env.reader.Collect(context.Background(), &rm))
+
+	result := map[string]metricdata.Aggregation{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			result[m.Name] = m.Data
+		}
+	}
+
+	return result
+}
+
+func TestTelemetry_Spans(t *testing.T) {
+	var traceparent string
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("Traceparent")
+	})
+
+	env := newTelemetryTestEnv(t, handler)
+
+	httpexpect.NewRequestC(env.config, "GET", "/path").
+		Expect().
+		Status(http.StatusOK)
+
+	spans := env.spans.Ended()
+	require.Equal(t, 2, len(spans))
+
+	attempt, request := spans[0], spans[1]
+
+	assert.Equal(t, "HTTP GET", request.Name())
+	assert.Equal(t, "HTTP GET attempt", attempt.Name())
+
+	assert.Equal(t, request.SpanContext().SpanID(), attempt.Parent().SpanID())
+	assert.Equal(t, request.SpanContext().TraceID(), attempt.SpanContext().TraceID())
+
+	assert.Contains(t, request.Attributes(),
+		attribute.String("http.url", "http://example.com/path"))
+	assert.Contains(t, attempt.Attributes(),
+		attribute.Int("http.status_code", http.StatusOK))
+	assert.Contains(t, attempt.Attributes(),
+		attribute.Int("httpexpect.attempt", 1))
+
+	assert.Contains(t, traceparent, attempt.SpanContext().SpanID().String())
+}
+
+func TestTelemetry_Retries(t *testing.T) {
+	var count int
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		if count < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	})
+
+	env := newTelemetryTestEnv(t, handler)
+
+	httpexpect.NewRequestC(env.config, "GET", "/path").
+		WithMaxRetries(2).
+		WithRetryDelay(0, 0).
+		Expect().
+		Status(http.StatusOK)
+
+	spans := env.spans.Ended()
+	require.Equal(t, 4, len(spans))
+
+	request := spans[3]
+	assert.Equal(t, "HTTP GET", request.Name())
+	assert.NotEqual(t, codes.Error, request.Status().Code)
+
+	for n, attempt := range spans[:3] {
+		assert.Equal(t, "HTTP GET attempt", attempt.Name())
+		assert.Equal(t, request.SpanContext().SpanID(), attempt.Parent().SpanID())
+		assert.Contains(t, attempt.Attributes(),
+			attribute.Int("httpexpect.attempt", n+1))
+	}
+
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.NotEqual(t, codes.Error, spans[2].Status().Code)
+
+	metrics := env.metrics(t)
+
+	counter, ok := metrics["httpexpect.requests"].(metricdata.Sum[int64])
+	require.True(t, ok)
+
+	var total int64
+	for _, dp := range counter.DataPoints {
+		total += dp.Value
+	}
+	assert.Equal(t, int64(3), total)
+
+	histogram, ok := metrics["httpexpect.request.duration"].(metricdata.Histogram[float64])
+	require.True(t, ok)
+
+	var histCount uint64
+	for _, dp := range histogram.DataPoints {
+		histCount += dp.Count
+	}
+	assert.Equal(t, uint64(3), histCount)
+}
+
+func TestTelemetry_Failures(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	env := newTelemetryTestEnv(t, handler)
+
+	httpexpect.NewRequestC(env.config, "GET", "/path").
+		Expect().
+		Status(http.StatusOK)
+
+	spans := env.spans.Ended()
+	require.Equal(t, 3, len(spans))
+
+	request, assertion := spans[1], spans[2]
+
+	assert.Equal(t, "httpexpect.assertion", assertion.Name())
+	assert.Equal(t, request.SpanContext().SpanID(), assertion.Parent().SpanID())
+	assert.Equal(t, codes.Error, assertion.Status().Code)
+
+	require.Equal(t, 1, len(assertion.Events()))
+
+	event := assertion.Events()[0]
+	assert.Equal(t, "assertion failure", event.Name)
+	assert.Contains(t, event.Attributes,
+		attribute.String("httpexpect.assertion.type", httpexpect.AssertEqual.String()))
+
+	metrics := env.metrics(t)
+
+	counter, ok := metrics["httpexpect.assertion.failures"].(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Equal(t, 1, len(counter.DataPoints))
+	assert.Equal(t, int64(1), counter.DataPoints[0].Value)
+}
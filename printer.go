@@ -2,18 +2,23 @@ package httpexpect
 
 import (
 	"bytes"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httputil"
 	"strings"
+	"sync/atomic"
 	"time"
+	"unicode/utf8"
 
 	"github.com/gorilla/websocket"
 	"moul.io/http2curl/v2"
 )
 
 // Printer is used to print requests and responses.
-// CompactPrinter, DebugPrinter, and CurlPrinter implement this interface.
+// CompactPrinter, DebugPrinter, CurlPrinter, and LogPrinter implement this interface.
 type Printer interface {
 	// Request is called before request is sent.
 	// It is allowed to read and close request body, or ignore it.
@@ -40,6 +45,41 @@ type WebsocketPrinter interface {
 	WebsocketRead(typ int, content []byte, closeCode int)
 }
 
+// ExchangePrinter is used to print request together with its response.
+//
+// If a Printer also implements ExchangePrinter, its Exchange method is invoked
+// once per every sent request (including retries), after response is received
+// or an error occurs, and its Request and Response methods are not invoked.
+//
+// LogPrinter implements this interface.
+type ExchangePrinter interface {
+	Printer
+
+	// Exchange is called after response is received or request fails.
+	// It is allowed to read and close request and response bodies, or ignore them.
+	Exchange(*PrinterExchange)
+}
+
+// PrinterExchange holds request and response passed to ExchangePrinter.
+type PrinterExchange struct {
+	// Sent request.
+	Request *http.Request
+
+	// Received response.
+	// Nil if request failed.
+	Response *http.Response
+
+	// Error returned by client.
+	// Nil if response was received.
+	Error error
+
+	// Round-trip time.
+	Duration time.Duration
+
+	// Set if debugging was enabled for request via Request.WithDebug.
+	Debug bool
+}
+
 // CompactPrinter implements Printer.
 // Prints requests in compact form. Does not print responses.
 type CompactPrinter struct {
@@ -123,6 +163,10 @@ func (p DebugPrinter) Response(resp *http.Response, duration time.Duration) {
 	}
 
 	dump, err := httputil.DumpResponse(resp, p.body)
+	if errors.Is(err, errBodyTooLarge) {
+		// body exceeds Config.MaxBodyBytes, print only headers
+		dump, err = httputil.DumpResponse(resp, false)
+	}
 	if err != nil {
 		panic(err)
 	}
@@ -170,3 +214,232 @@ func (p DebugPrinter) WebsocketRead(typ int, content []byte, closeCode int) {
 	fmt.Fprintf(b, "\n")
 	p.logger.Logf(b.String())
 }
+
+// LogMode defines which requests and responses are printed by LogPrinter.
+type LogMode int
+
+const (
+	// Print all requests and responses.
+	LogAll LogMode = iota
+
+	// Print only failed requests (see LogPrinter.IsFailure).
+	LogFailures
+
+	// Print failed requests and every N-th of other requests
+	// (see LogPrinter.SampleRate).
+	LogSampled
+
+	// Print only requests for which Request.WithDebug was called.
+	LogDebug
+)
+
+// Default limits for LogPrinter.
+const (
+	DefaultLogMaxBodySize   = 4096
+	DefaultLogMaxBinarySize = 256
+)
+
+// LogPrinter implements Printer and ExchangePrinter.
+// Prints requests together with their responses, with configurable body
+// size limits and sampling.
+//
+// Unlike DebugPrinter, LogPrinter:
+//   - truncates large bodies (see MaxBodySize)
+//   - prints binary bodies as a hexdump preview (see MaxBinarySize)
+//   - prints request, response, or error as a single log message
+//   - can print only failed requests or a sample of requests (see Mode);
+//     requests for which Request.WithDebug was called are always printed
+//
+// Example:
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		Reporter: httpexpect.NewAssertReporter(t),
+//		Printers: []httpexpect.Printer{
+//			&httpexpect.LogPrinter{
+//				Logger:      t,
+//				Mode:        httpexpect.LogSampled,
+//				SampleRate:  10,
+//				MaxBodySize: 1024,
+//			},
+//		},
+//	})
+type LogPrinter struct {
+	// Logger used to print messages.
+	// Should not be nil.
+	Logger Logger
+
+	// Defines which requests are printed.
+	// Default is LogAll.
+	Mode LogMode
+
+	// When Mode is LogSampled, every SampleRate-th request which is not
+	// failed is printed. Zero or one means that all requests are printed.
+	SampleRate int
+
+	// Maximum number of bytes of text body to print. Remaining bytes
+	// are truncated. If zero, DefaultLogMaxBodySize is used.
+	// If negative, bodies are not printed.
+	MaxBodySize int
+
+	// Maximum number of bytes of binary body to print as hexdump.
+	// If zero, DefaultLogMaxBinarySize is used.
+	MaxBinarySize int
+
+	// Defines whether request is failed.
+	// If nil, request is failed if it returned error or response
+	// status code is 4xx or 5xx.
+	IsFailure func(resp *http.Response, err error) bool
+
+	counter uint64
+}
+
+// Request implements Printer.Request.
+// Does nothing, see Exchange.
+func (*LogPrinter) Request(*http.Request) {
+}
+
+// Response implements Printer.Response.
+// Does nothing, see Exchange.
+func (*LogPrinter) Response(*http.Response, time.Duration) {
+}
+
+// Exchange implements ExchangePrinter.Exchange.
+func (p *LogPrinter) Exchange(exchange *PrinterExchange) {
+	if exchange == nil || !p.shouldPrint(exchange) {
+		return
+	}
+
+	b := &bytes.Buffer{}
+
+	if req := exchange.Request; req != nil {
+		if dump, err := httputil.DumpRequest(req, false); err == nil {
+			b.Write(normalizeNewlines(dump))
+		}
+		p.writeBody(b, req.Body)
+	}
+
+	if resp := exchange.Response; resp != nil {
+		writeSeparator(b)
+		if dump, err := httputil.DumpResponse(resp, false); err == nil {
+			lines := strings.SplitN(string(normalizeNewlines(dump)), "\n", 2)
+			fmt.Fprintf(b, "%s %s\n", lines[0], exchange.Duration)
+			if len(lines) > 1 {
+				b.WriteString(lines[1])
+			}
+		}
+		p.writeBody(b, resp.Body)
+	}
+
+	if exchange.Error != nil {
+		writeSeparator(b)
+		fmt.Fprintf(b, "error: %s %s\n", exchange.Error, exchange.Duration)
+	}
+
+	p.Logger.Logf("%s", b.String())
+}
+
+func (p *LogPrinter) shouldPrint(exchange *PrinterExchange) bool {
+	if exchange.Debug {
+		return true
+	}
+
+	switch p.Mode {
+	case LogAll:
+		return true
+
+	case LogFailures:
+		return p.isFailure(exchange)
+
+	case LogSampled:
+		if p.isFailure(exchange) {
+			return true
+		}
+		n := atomic.AddUint64(&p.counter, 1)
+		return p.SampleRate <= 1 || (n-1)%uint64(p.SampleRate) == 0
+
+	case LogDebug:
+		return false
+	}
+
+	return true
+}
+
+func (p *LogPrinter) isFailure(exchange *PrinterExchange) bool {
+	if p.IsFailure != nil {
+		return p.IsFailure(exchange.Response, exchange.Error)
+	}
+
+	return exchange.Error != nil ||
+		(exchange.Response != nil && exchange.Response.StatusCode >= 400)
+}
+
+func (p *LogPrinter) writeBody(b *bytes.Buffer, body io.ReadCloser) {
+	if body == nil || body == http.NoBody || p.MaxBodySize < 0 {
+		return
+	}
+
+	defer body.Close()
+
+	content, err := io.ReadAll(body)
+	if err != nil || len(content) == 0 {
+		return
+	}
+
+	if isBinary(content) {
+		maxSize := p.MaxBinarySize
+		if maxSize <= 0 {
+			maxSize = DefaultLogMaxBinarySize
+		}
+
+		fmt.Fprintf(b, "[binary data, %d bytes]\n", len(content))
+
+		if len(content) > maxSize {
+			b.WriteString(hex.Dump(content[:maxSize]))
+			fmt.Fprintf(b, "... %d more byte(s)\n", len(content)-maxSize)
+		} else {
+			b.WriteString(hex.Dump(content))
+		}
+	} else {
+		maxSize := p.MaxBodySize
+		if maxSize == 0 {
+			maxSize = DefaultLogMaxBodySize
+		}
+
+		if len(content) > maxSize {
+			// don't cut utf-8 sequence in the middle
+			n := maxSize
+			for n > 0 && !utf8.RuneStart(content[n]) {
+				n--
+			}
+			b.Write(content[:n])
+			fmt.Fprintf(b, "\n... %d more byte(s)\n", len(content)-n)
+		} else {
+			b.Write(content)
+			if !bytes.HasSuffix(content, []byte("\n")) {
+				b.WriteString("\n")
+			}
+		}
+	}
+}
+
+// Ensures that there is an empty line at the end of non-empty buffer.
+func writeSeparator(b *bytes.Buffer) {
+	if b.Len() != 0 && !bytes.HasSuffix(b.Bytes(), []byte("\n\n")) {
+		b.WriteString("\n")
+	}
+}
+
+// Returns true if content doesn't look like text.
+func isBinary(content []byte) bool {
+	if !utf8.Valid(content) {
+		return true
+	}
+
+	for _, c := range content {
+		if c < 0x20 && c != '\t' && c != '\n' && c != '\r' {
+			return true
+		}
+	}
+
+	return false
+}
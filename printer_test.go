@@ -5,7 +5,9 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -56,6 +58,183 @@ func TestPrinter_Debug(t *testing.T) {
 	printer.Response(nil, 0)
 }
 
+func TestPrinter_Log(t *testing.T) {
+	newExchange := func(status int, reqBody, respBody []byte) *PrinterExchange {
+		req, _ := http.NewRequest("POST", "http://example.com/path",
+			bytes.NewReader(reqBody))
+
+		return &PrinterExchange{
+			Request: req,
+			Response: &http.Response{
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				StatusCode: status,
+				Header:     http.Header{},
+				Body:       io.NopCloser(bytes.NewReader(respBody)),
+			},
+			Duration: time.Millisecond,
+		}
+	}
+
+	t.Run("request and response", func(t *testing.T) {
+		logger := newMockLogger(t)
+		printer := &LogPrinter{Logger: logger}
+
+		printer.Request(nil)
+		printer.Response(nil, 0)
+		assert.False(t, logger.logged)
+
+		printer.Exchange(newExchange(http.StatusOK,
+			[]byte("request body"), []byte("response body")))
+
+		assert.True(t, logger.logged)
+		assert.Contains(t, logger.lastMessage, "POST /path")
+		assert.Contains(t, logger.lastMessage, "request body")
+		assert.Contains(t, logger.lastMessage, "HTTP/1.1 200 OK 1ms")
+		assert.Contains(t, logger.lastMessage, "response body")
+	})
+
+	t.Run("error", func(t *testing.T) {
+		logger := newMockLogger(t)
+		printer := &LogPrinter{Logger: logger}
+
+		exchange := newExchange(http.StatusOK, nil, nil)
+		exchange.Response = nil
+		exchange.Error = errors.New("test error")
+
+		printer.Exchange(exchange)
+
+		assert.Contains(t, logger.lastMessage, "POST /path")
+		assert.Contains(t, logger.lastMessage, "error: test error")
+	})
+
+	t.Run("truncation", func(t *testing.T) {
+		logger := newMockLogger(t)
+		printer := &LogPrinter{Logger: logger, MaxBodySize: 10}
+
+		printer.Exchange(newExchange(http.StatusOK,
+			nil, []byte(strings.Repeat("a", 25))))
+
+		assert.Contains(t, logger.lastMessage, strings.Repeat("a", 10)+"\n")
+		assert.NotContains(t, logger.lastMessage, strings.Repeat("a", 11))
+		assert.Contains(t, logger.lastMessage, "... 15 more byte(s)")
+	})
+
+	t.Run("truncation utf-8", func(t *testing.T) {
+		logger := newMockLogger(t)
+		printer := &LogPrinter{Logger: logger, MaxBodySize: 3}
+
+		printer.Exchange(newExchange(http.StatusOK,
+			nil, []byte("aпривет")))
+
+		assert.Contains(t, logger.lastMessage, "aп\n")
+		assert.Contains(t, logger.lastMessage, "... 10 more byte(s)")
+	})
+
+	t.Run("no body", func(t *testing.T) {
+		logger := newMockLogger(t)
+		printer := &LogPrinter{Logger: logger, MaxBodySize: -1}
+
+		printer.Exchange(newExchange(http.StatusOK,
+			[]byte("request body"), []byte("response body")))
+
+		assert.True(t, logger.logged)
+		assert.NotContains(t, logger.lastMessage, "request body")
+		assert.NotContains(t, logger.lastMessage, "response body")
+	})
+
+	t.Run("binary", func(t *testing.T) {
+		logger := newMockLogger(t)
+		printer := &LogPrinter{Logger: logger, MaxBinarySize: 16}
+
+		content := make([]byte, 40)
+		for i := range content {
+			content[i] = byte(i)
+		}
+
+		printer.Exchange(newExchange(http.StatusOK, nil, content))
+
+		assert.Contains(t, logger.lastMessage, "[binary data, 40 bytes]")
+		assert.Contains(t, logger.lastMessage,
+			"00000000  00 01 02 03 04 05 06 07  08 09 0a 0b 0c 0d 0e 0f")
+		assert.NotContains(t, logger.lastMessage, "00000010")
+		assert.Contains(t, logger.lastMessage, "... 24 more byte(s)")
+	})
+
+	t.Run("mode failures", func(t *testing.T) {
+		logger := newMockLogger(t)
+		printer := &LogPrinter{Logger: logger, Mode: LogFailures}
+
+		printer.Exchange(newExchange(http.StatusOK, nil, nil))
+		assert.False(t, logger.logged)
+
+		printer.Exchange(newExchange(http.StatusNotFound, nil, nil))
+		assert.True(t, logger.logged)
+		assert.Contains(t, logger.lastMessage, "404")
+
+		logger.logged = false
+
+		exchange := newExchange(http.StatusOK, nil, nil)
+		exchange.Response = nil
+		exchange.Error = errors.New("test error")
+
+		printer.Exchange(exchange)
+		assert.True(t, logger.logged)
+	})
+
+	t.Run("mode failures custom", func(t *testing.T) {
+		logger := newMockLogger(t)
+		printer := &LogPrinter{
+			Logger: logger,
+			Mode:   LogFailures,
+			IsFailure: func(resp *http.Response, err error) bool {
+				return resp != nil && resp.StatusCode >= 500
+			},
+		}
+
+		printer.Exchange(newExchange(http.StatusNotFound, nil, nil))
+		assert.False(t, logger.logged)
+
+		printer.Exchange(newExchange(http.StatusBadGateway, nil, nil))
+		assert.True(t, logger.logged)
+	})
+
+	t.Run("mode sampled", func(t *testing.T) {
+		logger := &countingLogger{}
+		printer := &LogPrinter{Logger: logger, Mode: LogSampled, SampleRate: 3}
+
+		for i := 0; i < 9; i++ {
+			printer.Exchange(newExchange(http.StatusOK, nil, nil))
+		}
+		assert.Equal(t, 3, logger.count)
+
+		printer.Exchange(newExchange(http.StatusInternalServerError, nil, nil))
+		assert.Equal(t, 4, logger.count)
+	})
+
+	t.Run("mode debug", func(t *testing.T) {
+		logger := newMockLogger(t)
+		printer := &LogPrinter{Logger: logger, Mode: LogDebug}
+
+		printer.Exchange(newExchange(http.StatusInternalServerError, nil, nil))
+		assert.False(t, logger.logged)
+
+		exchange := newExchange(http.StatusOK, nil, nil)
+		exchange.Debug = true
+
+		printer.Exchange(exchange)
+		assert.True(t, logger.logged)
+	})
+}
+
+type countingLogger struct {
+	count int
+}
+
+func (l *countingLogger) Logf(string, ...interface{}) {
+	l.count++
+}
+
 func TestPrinter_Panics(t *testing.T) {
 	t.Run("CurlPrinter", func(t *testing.T) {
 		curl := NewCurlPrinter(t)
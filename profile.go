@@ -0,0 +1,316 @@
+package httpexpect
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Profile defines settings of a named environment, like "dev", "staging",
+// or "prod".
+//
+// Profiles allow the same test suite to target different deployments
+// without code edits. Profile is selected using Expect.WithProfile.
+//
+// Profiles can be defined in Config.Profiles, loaded from a JSON file
+// using LoadProfiles, or defined via environment variables (see
+// ProfileFromEnv).
+type Profile struct {
+	// BaseURL overrides Config.BaseURL.
+	// May be empty.
+	BaseURL string `json:"base_url"`
+
+	// Headers are added to every request, see Config.DefaultHeaders.
+	// May be nil.
+	Headers map[string]string `json:"headers"`
+
+	// TLS defines TLS settings of the HTTP client.
+	// May be nil.
+	TLS *ProfileTLS `json:"tls"`
+}
+
+// ProfileTLS defines TLS settings of a Profile.
+type ProfileTLS struct {
+	// Disable verification of server certificate.
+	InsecureSkipVerify bool `json:"insecure_skip_verify"`
+
+	// Server name used to verify certificate.
+	// May be empty.
+	ServerName string `json:"server_name"`
+
+	// Path to PEM file with CA certificates used to verify server.
+	// May be empty.
+	CAFile string `json:"ca_file"`
+
+	// Paths to PEM files with client certificate and key.
+	// May be empty.
+	CertFile string `json:"cert_file"`
+	KeyFile  string `json:"key_file"`
+}
+
+// LoadProfiles reads profiles from a JSON file.
+//
+// The file should contain an object that maps profile names to profiles:
+//
+//	{
+//	  "staging": {
+//	    "base_url": "https://staging.example.com",
+//	    "headers": {"X-Api-Key": "secret"},
+//	    "tls": {"ca_file": "/etc/ssl/staging-ca.pem"}
+//	  }
+//	}
+//
+// Example:
+//
+//	profiles, err := httpexpect.LoadProfiles("profiles.json")
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		Reporter: httpexpect.NewAssertReporter(t),
+//		Profiles: profiles,
+//	})
+func LoadProfiles(path string) (map[string]Profile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var profiles map[string]Profile
+
+	if err := json.Unmarshal(data, &profiles); err != nil {
+		return nil, fmt.Errorf("can't parse profiles file %q: %w", path, err)
+	}
+
+	return profiles, nil
+}
+
+// ProfileFromEnv constructs profile from environment variables.
+//
+// Variable names are formed from "HTTPEXPECT_", upper-cased profile name,
+// and a suffix. Dashes in profile name are replaced with underscores.
+// Supported variables (for profile "staging"):
+//
+//	HTTPEXPECT_STAGING_BASE_URL              - Profile.BaseURL
+//	HTTPEXPECT_STAGING_HEADERS               - Profile.Headers, "Key=Value,Key=Value"
+//	HTTPEXPECT_STAGING_TLS_INSECURE          - ProfileTLS.InsecureSkipVerify
+//	HTTPEXPECT_STAGING_TLS_SERVER_NAME       - ProfileTLS.ServerName
+//	HTTPEXPECT_STAGING_TLS_CA_FILE           - ProfileTLS.CAFile
+//	HTTPEXPECT_STAGING_TLS_CERT_FILE         - ProfileTLS.CertFile
+//	HTTPEXPECT_STAGING_TLS_KEY_FILE          - ProfileTLS.KeyFile
+//
+// Returns false if none of the variables is set.
+//
+// Example:
+//
+//	profile, ok := httpexpect.ProfileFromEnv("staging")
+func ProfileFromEnv(name string) (Profile, bool) {
+	prefix := "HTTPEXPECT_" +
+		strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+
+	var (
+		profile Profile
+		found   bool
+	)
+
+	lookup := func(suffix string) string {
+		value, ok := os.LookupEnv(prefix + suffix)
+		if ok {
+			found = true
+		}
+		return value
+	}
+
+	profile.BaseURL = lookup("BASE_URL")
+
+	if headers := lookup("HEADERS"); headers != "" {
+		profile.Headers = make(map[string]string)
+
+		for _, pair := range strings.Split(headers, ",") {
+			kv := strings.SplitN(pair, "=", 2)
+			if len(kv) != 2 {
+				continue
+			}
+			profile.Headers[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+		}
+	}
+
+	tlsSettings := ProfileTLS{
+		ServerName: lookup("TLS_SERVER_NAME"),
+		CAFile:     lookup("TLS_CA_FILE"),
+		CertFile:   lookup("TLS_CERT_FILE"),
+		KeyFile:    lookup("TLS_KEY_FILE"),
+	}
+
+	if insecure := lookup("TLS_INSECURE"); insecure != "" {
+		tlsSettings.InsecureSkipVerify, _ = strconv.ParseBool(insecure)
+	}
+
+	if tlsSettings != (ProfileTLS{}) {
+		profile.TLS = &tlsSettings
+	}
+
+	return profile, found
+}
+
+// WithProfile returns a copy of Expect instance configured using
+// profile with given name.
+//
+// Profile is looked up in Config.Profiles first. If it's not found there,
+// ProfileFromEnv is used. If profile is not found at all, or its TLS settings
+// can't be applied, failure is reported.
+//
+// Profile BaseURL replaces Config.BaseURL, profile Headers are added to
+// Config.DefaultHeaders (replacing defaults with the same name), and profile
+// TLS settings are applied to Config.Client, which should be *http.Client
+// in this case.
+//
+// Example:
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		Reporter: httpexpect.NewAssertReporter(t),
+//		Profiles: map[string]httpexpect.Profile{
+//			"dev":     {BaseURL: "http://localhost:8080"},
+//			"staging": {BaseURL: "https://staging.example.com"},
+//		},
+//	})
+//
+//	e.WithProfile(os.Getenv("TEST_PROFILE")).
+//		GET("/health").
+//		Expect().
+//		Status(http.StatusOK)
+func (e *Expect) WithProfile(name string) *Expect {
+	opChain := e.chain.enter("WithProfile(%q)", name)
+	defer opChain.leave()
+
+	ret := e.clone()
+
+	if opChain.failed() {
+		return ret
+	}
+
+	profile, ok := e.config.Profiles[name]
+	if !ok {
+		profile, ok = ProfileFromEnv(name)
+	}
+
+	if !ok {
+		names := []string{}
+		for k := range e.config.Profiles {
+			names = append(names, k)
+		}
+
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{names},
+			Expected: &AssertionValue{name},
+			Errors: []error{
+				errors.New("expected: profile with given name is defined"),
+			},
+		})
+		// requests built from returned instance should not be sent
+		ret.chain = opChain.clone()
+		return ret
+	}
+
+	if profile.BaseURL != "" {
+		ret.config.BaseURL = profile.BaseURL
+	}
+
+	if profile.TLS != nil {
+		client, err := profileClient(ret.config.Client, profile.TLS)
+		if err != nil {
+			opChain.fail(AssertionFailure{
+				Type: AssertUsage,
+				Errors: []error{
+					fmt.Errorf("can't apply TLS settings of profile %q", name),
+					err,
+				},
+			})
+			ret.chain = opChain.clone()
+			return ret
+		}
+		ret.config.Client = client
+	}
+
+	if len(profile.Headers) != 0 {
+		headers := canonicalHeader(ret.config.DefaultHeaders)
+		for k, v := range profile.Headers {
+			headers.Set(k, v)
+		}
+
+		ret.config.DefaultHeaders = headers
+	}
+
+	return ret
+}
+
+func profileClient(client Client, settings *ProfileTLS) (Client, error) {
+	httpClient, ok := client.(*http.Client)
+	if !ok {
+		return nil, errors.New("TLS settings can be used only if Client is *http.Client")
+	}
+
+	var transport *http.Transport
+
+	switch t := httpClient.Transport.(type) {
+	case nil:
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		transport = t.Clone()
+	default:
+		return nil, errors.New(
+			"TLS settings can be used only if Client.Transport is *http.Transport")
+	}
+
+	tlsConfig, err := settings.config()
+	if err != nil {
+		return nil, err
+	}
+
+	transport.TLSClientConfig = tlsConfig
+
+	clientCopy := *httpClient
+	clientCopy.Transport = transport
+
+	return &clientCopy, nil
+}
+
+func (settings *ProfileTLS) config() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: settings.InsecureSkipVerify, //nolint:gosec
+		ServerName:         settings.ServerName,
+	}
+
+	if settings.CAFile != "" {
+		data, err := os.ReadFile(settings.CAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no certificates found in %q", settings.CAFile)
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if settings.CertFile != "" || settings.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(settings.CertFile, settings.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,202 @@
+package httpexpect
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfile_Config(t *testing.T) {
+	client := &mockClient{}
+
+	e := WithConfig(Config{
+		BaseURL:  "http://default.example.com",
+		Client:   client,
+		Reporter: newMockReporter(t),
+		Profiles: map[string]Profile{
+			"staging": {
+				BaseURL: "http://staging.example.com",
+				Headers: map[string]string{
+					"X-Api-Key": "secret",
+				},
+			},
+		},
+	})
+
+	e.WithProfile("staging").GET("/path").Expect()
+	e.chain.assert(t, success)
+
+	require.NotNil(t, client.req)
+	assert.Equal(t, "http://staging.example.com/path", client.req.URL.String())
+	assert.Equal(t, "secret", client.req.Header.Get("X-Api-Key"))
+
+	e.GET("/path").Expect()
+	e.chain.assert(t, success)
+
+	assert.Equal(t, "http://default.example.com/path", client.req.URL.String())
+	assert.Equal(t, "", client.req.Header.Get("X-Api-Key"))
+}
+
+func TestProfile_Env(t *testing.T) {
+	t.Setenv("HTTPEXPECT_MY_PROD_BASE_URL", "http://prod.example.com")
+	t.Setenv("HTTPEXPECT_MY_PROD_HEADERS", "X-Foo=foo, X-Bar=bar")
+
+	profile, ok := ProfileFromEnv("my-prod")
+	require.True(t, ok)
+
+	assert.Equal(t, "http://prod.example.com", profile.BaseURL)
+	assert.Equal(t, map[string]string{"X-Foo": "foo", "X-Bar": "bar"}, profile.Headers)
+	assert.Nil(t, profile.TLS)
+
+	_, ok = ProfileFromEnv("unknown")
+	assert.False(t, ok)
+
+	client := &mockClient{}
+
+	e := WithConfig(Config{
+		Client:   client,
+		Reporter: newMockReporter(t),
+	})
+
+	e.WithProfile("my-prod").GET("/path").Expect()
+	e.chain.assert(t, success)
+
+	require.NotNil(t, client.req)
+	assert.Equal(t, "http://prod.example.com/path", client.req.URL.String())
+	assert.Equal(t, "foo", client.req.Header.Get("X-Foo"))
+	assert.Equal(t, "bar", client.req.Header.Get("X-Bar"))
+}
+
+func TestProfile_TLS(t *testing.T) {
+	t.Run("http client", func(t *testing.T) {
+		client := &http.Client{Jar: NewCookieJar()}
+
+		e := WithConfig(Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+			Profiles: map[string]Profile{
+				"dev": {
+					TLS: &ProfileTLS{
+						InsecureSkipVerify: true,
+						ServerName:         "example.com",
+					},
+				},
+			},
+		})
+
+		p := e.WithProfile("dev")
+		e.chain.assert(t, success)
+
+		profileClient, ok := p.config.Client.(*http.Client)
+		require.True(t, ok)
+		assert.NotSame(t, client, profileClient)
+		assert.Equal(t, client.Jar, profileClient.Jar)
+
+		transport, ok := profileClient.Transport.(*http.Transport)
+		require.True(t, ok)
+		assert.True(t, transport.TLSClientConfig.InsecureSkipVerify)
+		assert.Equal(t, "example.com", transport.TLSClientConfig.ServerName)
+
+		assert.Nil(t, client.Transport)
+	})
+
+	t.Run("custom client", func(t *testing.T) {
+		e := WithConfig(Config{
+			Client:   &mockClient{},
+			Reporter: newMockReporter(t),
+			Profiles: map[string]Profile{
+				"dev": {
+					TLS: &ProfileTLS{InsecureSkipVerify: true},
+				},
+			},
+		})
+
+		e.WithProfile("dev")
+		e.chain.assert(t, failure)
+	})
+
+	t.Run("bad ca file", func(t *testing.T) {
+		e := WithConfig(Config{
+			Reporter: newMockReporter(t),
+			Profiles: map[string]Profile{
+				"dev": {
+					TLS: &ProfileTLS{CAFile: "/non/existent/file.pem"},
+				},
+			},
+		})
+
+		profile := e.WithProfile("dev")
+		e.chain.assert(t, failure)
+		profile.chain.assert(t, failure)
+	})
+}
+
+func TestProfile_NotFound(t *testing.T) {
+	client := &mockClient{}
+
+	e := WithConfig(Config{
+		Client:   client,
+		Reporter: newMockReporter(t),
+		Profiles: map[string]Profile{
+			"dev": {BaseURL: "http://localhost"},
+		},
+	})
+
+	profile := e.WithProfile("no-such-profile")
+	e.chain.assert(t, failure)
+	profile.chain.assert(t, failure)
+
+	profile.GET("/health").Expect().chain.assert(t, failure)
+	assert.Nil(t, client.req)
+}
+
+func TestProfile_Load(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("good", func(t *testing.T) {
+		path := filepath.Join(dir, "good.json")
+
+		err := os.WriteFile(path, []byte(`{
+			"dev": {"base_url": "http://localhost:8080"},
+			"staging": {
+				"base_url": "https://staging.example.com",
+				"headers": {"X-Api-Key": "secret"},
+				"tls": {"insecure_skip_verify": true}
+			}
+		}`), 0600)
+		require.NoError(t, err)
+
+		profiles, err := LoadProfiles(path)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]Profile{
+			"dev": {
+				BaseURL: "http://localhost:8080",
+			},
+			"staging": {
+				BaseURL: "https://staging.example.com",
+				Headers: map[string]string{"X-Api-Key": "secret"},
+				TLS:     &ProfileTLS{InsecureSkipVerify: true},
+			},
+		}, profiles)
+	})
+
+	t.Run("bad json", func(t *testing.T) {
+		path := filepath.Join(dir, "bad.json")
+
+		err := os.WriteFile(path, []byte(`{"dev":`), 0600)
+		require.NoError(t, err)
+
+		_, err = LoadProfiles(path)
+		assert.Error(t, err)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := LoadProfiles(filepath.Join(dir, "missing.json"))
+		assert.Error(t, err)
+	})
+}
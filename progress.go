@@ -0,0 +1,373 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Byte size units, handy for bandwidth assertions.
+//
+// Example:
+//
+//	resp.Throughput().Ge(10 * httpexpect.MB)
+const (
+	KB = 1 << 10
+	MB = 1 << 20
+	GB = 1 << 30
+)
+
+// ProgressFunc is invoked during transfer of request or response body.
+//
+// transferred is the number of bytes transferred so far, and total is the
+// expected body size from Content-Length, or -1 if it's unknown.
+type ProgressFunc func(transferred, total int64)
+
+// WithUploadProgress sets callback that is invoked every time a chunk of
+// request body is sent.
+//
+// If request is retried, progress starts from zero on every attempt.
+// Callback may be invoked from a goroutine of HTTP client.
+//
+// Example:
+//
+//	req := NewRequestC(config, "POST", "/upload")
+//	req.WithBytes(data)
+//	req.WithUploadProgress(func(sent, total int64) {
+//		log.Printf("uploaded %d of %d bytes", sent, total)
+//	})
+func (r *Request) WithUploadProgress(fn ProgressFunc) *Request {
+	opChain := r.chain.enter("WithUploadProgress()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithUploadProgress()") {
+		return r
+	}
+
+	if fn == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil argument"),
+			},
+		})
+		return r
+	}
+
+	r.uploadProgress = fn
+
+	return r
+}
+
+// WithDownloadProgress sets callback that is invoked every time a chunk of
+// response body is received.
+//
+// Response body is read lazily, when it's needed by an assertion or by
+// Response.Reader, so the callback is invoked at that time.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "/download")
+//	req.WithDownloadProgress(func(received, total int64) {
+//		log.Printf("downloaded %d of %d bytes", received, total)
+//	})
+func (r *Request) WithDownloadProgress(fn ProgressFunc) *Request {
+	opChain := r.chain.enter("WithDownloadProgress()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithDownloadProgress()") {
+		return r
+	}
+
+	if fn == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil argument"),
+			},
+		})
+		return r
+	}
+
+	r.downloadProgress = fn
+
+	return r
+}
+
+// BytesSent returns a new Number instance with the number of bytes of
+// request body sent in the last attempt.
+//
+// Reports failure if response was not produced by Request (e.g. it was
+// created using NewResponse), or if it is a WebSocket response.
+//
+// Example:
+//
+//	resp := req.WithBytes(data).Expect()
+//	resp.BytesSent().IsEqual(len(data))
+func (r *Response) BytesSent() *Number {
+	opChain := r.chain.enter("BytesSent()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, 0)
+	}
+
+	if !r.checkTransfer(opChain, true) {
+		return newNumber(opChain, 0)
+	}
+
+	sent, _ := r.transfer.upload.stats()
+
+	return newNumber(opChain, float64(sent))
+}
+
+// BytesReceived returns a new Number instance with the number of bytes of
+// response body received.
+//
+// Response body is read fully before counting bytes.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.BytesReceived().Le(MB)
+func (r *Response) BytesReceived() *Number {
+	opChain := r.chain.enter("BytesReceived()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, 0)
+	}
+
+	if !r.checkTransfer(opChain, false) {
+		return newNumber(opChain, 0)
+	}
+
+	if _, ok := r.getContent(opChain, "BytesReceived()"); !ok {
+		return newNumber(opChain, 0)
+	}
+
+	received, _ := r.transfer.download.stats()
+
+	return newNumber(opChain, float64(received))
+}
+
+// UploadThroughput returns a new Number instance with the rate at which
+// request body was sent in the last attempt, in bytes per second.
+//
+// Rate is measured from the moment when HTTP client started reading request
+// body till the moment when it finished reading it.
+//
+// Reports failure if request had no body, if transfer took no measurable
+// time, or if transfer statistics are not available (see BytesSent).
+//
+// Example:
+//
+//	resp := req.WithBytes(data).Expect()
+//	resp.UploadThroughput().Le(MB) // server should limit upload rate
+func (r *Response) UploadThroughput() *Number {
+	opChain := r.chain.enter("UploadThroughput()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, 0)
+	}
+
+	if !r.checkTransfer(opChain, true) {
+		return newNumber(opChain, 0)
+	}
+
+	rate, err := r.transfer.upload.throughput()
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("failed to compute request body upload throughput"),
+				err,
+			},
+		})
+		return newNumber(opChain, 0)
+	}
+
+	return newNumber(opChain, rate)
+}
+
+// Throughput returns a new Number instance with the rate at which response
+// body was received, in bytes per second.
+//
+// Response body is read fully before computing the rate. Rate is measured
+// from the first read of response body till the end of body.
+//
+// Reports failure if response had no body, or if transfer took no
+// measurable time.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.Throughput().Ge(10 * MB)
+func (r *Response) Throughput() *Number {
+	opChain := r.chain.enter("Throughput()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, 0)
+	}
+
+	if !r.checkTransfer(opChain, false) {
+		return newNumber(opChain, 0)
+	}
+
+	if _, ok := r.getContent(opChain, "Throughput()"); !ok {
+		return newNumber(opChain, 0)
+	}
+
+	rate, err := r.transfer.download.throughput()
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("failed to compute response body download throughput"),
+				err,
+			},
+		})
+		return newNumber(opChain, 0)
+	}
+
+	return newNumber(opChain, rate)
+}
+
+func (r *Response) checkTransfer(opChain *chain, upload bool) bool {
+	if r.transfer == nil || (upload && !r.transfer.sent) {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("transfer statistics are not available for this response"),
+			},
+		})
+		return false
+	}
+
+	return true
+}
+
+// Transfer statistics of request and response bodies.
+// Tracker is nil if corresponding body was empty.
+type transferStats struct {
+	// True if request was sent by us, so upload statistics are known.
+	sent bool
+
+	upload   *progressReader
+	download *progressReader
+}
+
+// progressReader counts bytes read from underlying reader, measures
+// transfer time, and reports progress to callback.
+type progressReader struct {
+	mu sync.Mutex
+
+	reader io.ReadCloser
+	now    func() time.Time
+	total  int64
+	fn     ProgressFunc
+
+	transferred int64
+	start       time.Time
+	end         time.Time
+	done        bool
+}
+
+func newProgressReader(
+	reader io.ReadCloser, total int64, fn ProgressFunc,
+) *progressReader {
+	if total <= 0 {
+		total = -1
+	}
+
+	return &progressReader{
+		reader: reader,
+		now:    time.Now,
+		total:  total,
+		fn:     fn,
+	}
+}
+
+func (p *progressReader) Read(buf []byte) (int, error) {
+	p.mu.Lock()
+	if p.start.IsZero() {
+		p.start = p.now()
+	}
+	p.mu.Unlock()
+
+	n, err := p.reader.Read(buf)
+
+	p.mu.Lock()
+	p.transferred += int64(n)
+	if err == io.EOF && !p.done {
+		p.end = p.now()
+		p.done = true
+	}
+	transferred := p.transferred
+	p.mu.Unlock()
+
+	if n > 0 && p.fn != nil {
+		p.fn(transferred, p.total)
+	}
+
+	return n, err
+}
+
+func (p *progressReader) Close() error {
+	p.mu.Lock()
+	if !p.start.IsZero() && !p.done {
+		p.end = p.now()
+		p.done = true
+	}
+	p.mu.Unlock()
+
+	return p.reader.Close()
+}
+
+func (p *progressReader) stats() (int64, time.Duration) {
+	if p == nil {
+		return 0, 0
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.done {
+		return p.transferred, 0
+	}
+
+	return p.transferred, p.end.Sub(p.start)
+}
+
+func (p *progressReader) throughput() (float64, error) {
+	transferred, elapsed := p.stats()
+
+	if transferred == 0 {
+		return 0, errors.New("no body was transferred")
+	}
+
+	if elapsed <= 0 {
+		return 0, fmt.Errorf("transfer of %d bytes took no measurable time",
+			transferred)
+	}
+
+	return float64(transferred) / elapsed.Seconds(), nil
+}
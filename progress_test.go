@@ -0,0 +1,305 @@
+package httpexpect
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Returns chunks of given size and invokes step on every chunk.
+type stepReader struct {
+	reader io.Reader
+	chunk  int
+	step   func()
+}
+
+func (r *stepReader) Read(p []byte) (int, error) {
+	if len(p) > r.chunk {
+		p = p[:r.chunk]
+	}
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		r.step()
+	}
+	return n, err
+}
+
+func TestProgress_Reader(t *testing.T) {
+	t.Run("progress", func(t *testing.T) {
+		clock := NewFakeClock(time.Unix(0, 0))
+
+		var calls [][2]int64
+
+		r := newProgressReader(io.NopCloser(&stepReader{
+			reader: strings.NewReader(strings.Repeat("x", 30)),
+			chunk:  10,
+			step: func() {
+				clock.Advance(time.Second)
+			},
+		}), 30, func(transferred, total int64) {
+			calls = append(calls, [2]int64{transferred, total})
+		})
+		r.now = clock.Now
+
+		b, err := io.ReadAll(r)
+		assert.NoError(t, err)
+		assert.Equal(t, 30, len(b))
+
+		assert.Equal(t, [][2]int64{{10, 30}, {20, 30}, {30, 30}}, calls)
+
+		transferred, elapsed := r.stats()
+		assert.Equal(t, int64(30), transferred)
+		assert.Equal(t, 3*time.Second, elapsed)
+
+		rate, err := r.throughput()
+		assert.NoError(t, err)
+		assert.Equal(t, 10.0, rate)
+	})
+
+	t.Run("unknown total", func(t *testing.T) {
+		var total int64
+
+		r := newProgressReader(io.NopCloser(strings.NewReader("xx")), 0,
+			func(_, t int64) {
+				total = t
+			})
+
+		_, err := io.ReadAll(r)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(-1), total)
+	})
+
+	t.Run("closed early", func(t *testing.T) {
+		clock := NewFakeClock(time.Unix(0, 0))
+
+		r := newProgressReader(io.NopCloser(strings.NewReader("xxxx")), 4, nil)
+		r.now = clock.Now
+
+		buf := make([]byte, 2)
+		_, err := r.Read(buf)
+		assert.NoError(t, err)
+
+		clock.Advance(time.Second)
+		assert.NoError(t, r.Close())
+
+		rate, err := r.throughput()
+		assert.NoError(t, err)
+		assert.Equal(t, 2.0, rate)
+	})
+
+	t.Run("no body", func(t *testing.T) {
+		var r *progressReader
+
+		_, err := r.throughput()
+		assert.Error(t, err)
+	})
+
+	t.Run("no time", func(t *testing.T) {
+		clock := NewFakeClock(time.Unix(0, 0))
+
+		r := newProgressReader(io.NopCloser(strings.NewReader("xx")), 2, nil)
+		r.now = clock.Now
+
+		_, err := io.ReadAll(r)
+		assert.NoError(t, err)
+
+		_, err = r.throughput()
+		assert.Error(t, err)
+	})
+}
+
+func TestProgress_Request(t *testing.T) {
+	t.Run("throughput", func(t *testing.T) {
+		client := ClientFunc(func(req *http.Request) (*http.Response, error) {
+			// read half of request body, wait, read the rest
+			half := make([]byte, 50)
+			_, err := io.ReadFull(req.Body, half)
+			require.NoError(t, err)
+
+			time.Sleep(10 * time.Millisecond)
+
+			_, err = io.ReadAll(req.Body)
+			require.NoError(t, err)
+
+			// send response body in 10 chunks, waiting after each
+			return &http.Response{
+				StatusCode:    http.StatusOK,
+				ContentLength: 200,
+				Body: io.NopCloser(&stepReader{
+					reader: strings.NewReader(strings.Repeat("y", 200)),
+					chunk:  20,
+					step: func() {
+						time.Sleep(time.Millisecond)
+					},
+				}),
+			}, nil
+		})
+
+		var uploaded, downloaded []int64
+
+		// fake clock should not affect throughput measurement
+		req := NewRequestC(Config{
+			Client:   client,
+			Clock:    NewFakeClock(time.Unix(0, 0)),
+			Reporter: newMockReporter(t),
+		}, "POST", "/upload")
+
+		resp := req.
+			WithBytes(bytes.Repeat([]byte("x"), 100)).
+			WithUploadProgress(func(sent, total int64) {
+				assert.Equal(t, int64(100), total)
+				uploaded = append(uploaded, sent)
+			}).
+			WithDownloadProgress(func(received, total int64) {
+				assert.Equal(t, int64(200), total)
+				downloaded = append(downloaded, received)
+			}).
+			Expect()
+
+		resp.BytesSent().IsEqual(100)
+		resp.UploadThroughput().Gt(0).Le(100 / 0.01)
+
+		resp.BytesReceived().IsEqual(200)
+		resp.Throughput().Gt(0).Le(200 / 0.01)
+
+		assert.Equal(t, []int64{50, 100}, uploaded)
+		assert.Equal(t, 10, len(downloaded))
+		assert.Equal(t, int64(200), downloaded[len(downloaded)-1])
+
+		req.chain.assert(t, success)
+	})
+
+	t.Run("http server", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				b, _ := io.ReadAll(r.Body)
+				_, _ = w.Write(b)
+				_, _ = w.Write(b)
+			}))
+		defer server.Close()
+
+		var uploaded, downloaded int64
+
+		req := NewRequestC(Config{
+			BaseURL:  server.URL,
+			Reporter: newMockReporter(t),
+		}, "POST", "/")
+
+		resp := req.
+			WithText(strings.Repeat("x", KB)).
+			WithUploadProgress(func(sent, _ int64) {
+				uploaded = sent
+			}).
+			WithDownloadProgress(func(received, _ int64) {
+				downloaded = received
+			}).
+			Expect()
+
+		resp.BytesSent().IsEqual(KB)
+		resp.BytesReceived().IsEqual(2 * KB)
+
+		req.chain.assert(t, success)
+
+		assert.Equal(t, int64(KB), uploaded)
+		assert.Equal(t, int64(2*KB), downloaded)
+	})
+
+	t.Run("no body", func(t *testing.T) {
+		client := &mockClient{
+			resp: http.Response{
+				StatusCode: http.StatusNoContent,
+				Body:       http.NoBody,
+			},
+		}
+
+		newResp := func() *Response {
+			return NewRequestC(Config{
+				Client:   client,
+				Reporter: newMockReporter(t),
+			}, "GET", "/").Expect()
+		}
+
+		resp := newResp()
+		resp.BytesSent().IsEqual(0)
+		resp.BytesReceived().IsEqual(0)
+		resp.chain.assert(t, success)
+
+		resp = newResp()
+		resp.UploadThroughput()
+		resp.chain.assert(t, failure)
+
+		resp = newResp()
+		resp.Throughput()
+		resp.chain.assert(t, failure)
+	})
+}
+
+func TestProgress_Response(t *testing.T) {
+	newHTTPResp := func() *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       newMockBody("body"),
+		}
+	}
+
+	t.Run("bytes received", func(t *testing.T) {
+		resp := NewResponse(newMockReporter(t), newHTTPResp())
+
+		resp.BytesReceived().IsEqual(4)
+		resp.Body().IsEqual("body")
+
+		resp.chain.assert(t, success)
+	})
+
+	t.Run("throughput", func(t *testing.T) {
+		httpResp := newHTTPResp()
+		httpResp.Body = io.NopCloser(&stepReader{
+			reader: strings.NewReader("body"),
+			chunk:  1,
+			step: func() {
+				time.Sleep(time.Millisecond)
+			},
+		})
+
+		resp := NewResponseC(Config{
+			Clock:    NewFakeClock(time.Unix(0, 0)),
+			Reporter: newMockReporter(t),
+		}, httpResp)
+
+		resp.Throughput().Gt(0).Le(4 / 0.004)
+
+		resp.chain.assert(t, success)
+	})
+
+	t.Run("bytes sent", func(t *testing.T) {
+		resp := NewResponse(newMockReporter(t), newHTTPResp())
+
+		resp.BytesSent()
+
+		resp.chain.assert(t, failure)
+	})
+
+	t.Run("upload throughput", func(t *testing.T) {
+		resp := NewResponse(newMockReporter(t), newHTTPResp())
+
+		resp.UploadThroughput()
+
+		resp.chain.assert(t, failure)
+	})
+
+	t.Run("reader", func(t *testing.T) {
+		resp := NewResponse(newMockReporter(t), newHTTPResp())
+
+		resp.Reader()
+		resp.Throughput()
+
+		resp.chain.assert(t, failure)
+	})
+}
@@ -0,0 +1,567 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PrometheusMetrics provides methods to inspect metrics in Prometheus text
+// exposition format.
+//
+// PrometheusMetrics is returned by Response.PrometheusMetrics.
+type PrometheusMetrics struct {
+	noCopy  noCopy
+	chain   *chain
+	samples []PrometheusSample
+	types   map[string]string
+}
+
+// PrometheusSample is a single sample parsed from Prometheus text
+// exposition format.
+type PrometheusSample struct {
+	// Sample name, e.g. "http_requests_total" or "request_duration_bucket".
+	Name string
+
+	// Sample labels.
+	Labels map[string]string
+
+	// Sample value.
+	Value float64
+}
+
+// PrometheusMetric provides methods to inspect samples of a single metric.
+//
+// PrometheusMetric is returned by PrometheusMetrics.Metric.
+type PrometheusMetric struct {
+	noCopy  noCopy
+	chain   *chain
+	name    string
+	typ     string
+	samples []PrometheusSample
+}
+
+// PrometheusMetrics parses response body in Prometheus text exposition
+// format and returns a new PrometheusMetrics instance.
+//
+// PrometheusMetrics succeeds if response contains "text/plain" Content-Type
+// header with empty or "utf-8" charset, and if body can be parsed.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.PrometheusMetrics().
+//		Metric("http_requests_total").
+//		WithLabels(map[string]string{"code": "200"}).
+//		Value().Gt(0)
+func (r *Response) PrometheusMetrics(options ...ContentOpts) *PrometheusMetrics {
+	opChain := r.chain.enter("PrometheusMetrics()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newPrometheusMetrics(opChain, nil, nil)
+	}
+
+	if len(options) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple options arguments"),
+			},
+		})
+		return newPrometheusMetrics(opChain, nil, nil)
+	}
+
+	if !r.checkContentOptions(opChain, options, "text/plain") {
+		return newPrometheusMetrics(opChain, nil, nil)
+	}
+
+	content, ok := r.getContent(opChain, "PrometheusMetrics()")
+	if !ok {
+		return newPrometheusMetrics(opChain, nil, nil)
+	}
+
+	samples, types, err := parsePrometheusText(string(content))
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{string(content)},
+			Errors: []error{
+				errors.New("failed to parse prometheus metrics"),
+				err,
+			},
+		})
+		return newPrometheusMetrics(opChain, nil, nil)
+	}
+
+	return newPrometheusMetrics(opChain, samples, types)
+}
+
+func newPrometheusMetrics(
+	parent *chain, samples []PrometheusSample, types map[string]string,
+) *PrometheusMetrics {
+	return &PrometheusMetrics{
+		chain:   parent.clone(),
+		samples: samples,
+		types:   types,
+	}
+}
+
+// Raw returns all parsed samples.
+func (pm *PrometheusMetrics) Raw() []PrometheusSample {
+	return pm.samples
+}
+
+// Alias is similar to Value.Alias.
+func (pm *PrometheusMetrics) Alias(name string) *PrometheusMetrics {
+	opChain := pm.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	pm.chain.setAlias(name)
+	return pm
+}
+
+// Metric returns a new PrometheusMetric instance with all samples with
+// given name.
+//
+// Name is a sample name, as it appears in exposition format, e.g. for
+// histograms "request_duration_bucket", "request_duration_sum", and
+// "request_duration_count" are separate metrics.
+//
+// If there are no samples with given name, failure is reported.
+//
+// Example:
+//
+//	metrics := resp.PrometheusMetrics()
+//	metrics.Metric("http_requests_total").Sum().Gt(0)
+func (pm *PrometheusMetrics) Metric(name string) *PrometheusMetric {
+	opChain := pm.chain.enter("Metric(%q)", name)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newPrometheusMetric(opChain, name, "", nil)
+	}
+
+	samples := pm.find(name)
+
+	if len(samples) == 0 {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{pm.names()},
+			Expected: &AssertionValue{name},
+			Errors: []error{
+				errors.New("expected: metrics contain metric with given name"),
+			},
+		})
+		return newPrometheusMetric(opChain, name, "", nil)
+	}
+
+	return newPrometheusMetric(opChain, name, pm.typeOf(name), samples)
+}
+
+// ContainsMetric succeeds if there is at least one sample with given name.
+//
+// Example:
+//
+//	metrics := resp.PrometheusMetrics()
+//	metrics.ContainsMetric("http_requests_total")
+func (pm *PrometheusMetrics) ContainsMetric(name string) *PrometheusMetrics {
+	opChain := pm.chain.enter("ContainsMetric(%q)", name)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return pm
+	}
+
+	if len(pm.find(name)) == 0 {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{pm.names()},
+			Expected: &AssertionValue{name},
+			Errors: []error{
+				errors.New("expected: metrics contain metric with given name"),
+			},
+		})
+	}
+
+	return pm
+}
+
+// NotContainsMetric succeeds if there are no samples with given name.
+//
+// Example:
+//
+//	metrics := resp.PrometheusMetrics()
+//	metrics.NotContainsMetric("debug_only_metric")
+func (pm *PrometheusMetrics) NotContainsMetric(name string) *PrometheusMetrics {
+	opChain := pm.chain.enter("NotContainsMetric(%q)", name)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return pm
+	}
+
+	if len(pm.find(name)) != 0 {
+		opChain.fail(AssertionFailure{
+			Type:     AssertNotContainsKey,
+			Actual:   &AssertionValue{pm.names()},
+			Expected: &AssertionValue{name},
+			Errors: []error{
+				errors.New("expected: metrics do not contain metric with given name"),
+			},
+		})
+	}
+
+	return pm
+}
+
+func (pm *PrometheusMetrics) find(name string) []PrometheusSample {
+	var result []PrometheusSample
+
+	for _, s := range pm.samples {
+		if s.Name == name {
+			result = append(result, s)
+		}
+	}
+
+	return result
+}
+
+func (pm *PrometheusMetrics) names() []string {
+	seen := map[string]bool{}
+	names := []string{}
+
+	for _, s := range pm.samples {
+		if !seen[s.Name] {
+			seen[s.Name] = true
+			names = append(names, s.Name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Returns type of metric family to which sample with given name belongs.
+func (pm *PrometheusMetrics) typeOf(name string) string {
+	if typ, ok := pm.types[name]; ok {
+		return typ
+	}
+
+	for _, suffix := range []string{"_bucket", "_sum", "_count", "_total"} {
+		if strings.HasSuffix(name, suffix) {
+			if typ, ok := pm.types[strings.TrimSuffix(name, suffix)]; ok {
+				return typ
+			}
+		}
+	}
+
+	return ""
+}
+
+func newPrometheusMetric(
+	parent *chain, name, typ string, samples []PrometheusSample,
+) *PrometheusMetric {
+	return &PrometheusMetric{
+		chain:   parent.clone(),
+		name:    name,
+		typ:     typ,
+		samples: samples,
+	}
+}
+
+// Raw returns samples of the metric.
+func (m *PrometheusMetric) Raw() []PrometheusSample {
+	return m.samples
+}
+
+// Alias is similar to Value.Alias.
+func (m *PrometheusMetric) Alias(name string) *PrometheusMetric {
+	opChain := m.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	m.chain.setAlias(name)
+	return m
+}
+
+// WithLabels returns a new PrometheusMetric instance with samples which
+// have all given labels with given values. Other labels of samples are
+// not checked.
+//
+// If there are no such samples, failure is reported.
+//
+// Example:
+//
+//	metric := resp.PrometheusMetrics().Metric("http_requests_total")
+//	metric.WithLabels(map[string]string{
+//		"method": "GET",
+//		"code":   "200",
+//	}).Value().Gt(0)
+func (m *PrometheusMetric) WithLabels(labels map[string]string) *PrometheusMetric {
+	opChain := m.chain.enter("WithLabels()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newPrometheusMetric(opChain, m.name, m.typ, nil)
+	}
+
+	var samples []PrometheusSample
+
+	for _, s := range m.samples {
+		if hasPrometheusLabels(s, labels) {
+			samples = append(samples, s)
+		}
+	}
+
+	if len(samples) == 0 {
+		actual := make([]map[string]string, 0, len(m.samples))
+		for _, s := range m.samples {
+			actual = append(actual, s.Labels)
+		}
+
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsElement,
+			Actual:   &AssertionValue{actual},
+			Expected: &AssertionValue{labels},
+			Errors: []error{
+				fmt.Errorf("expected: metric %q has sample with given labels", m.name),
+			},
+		})
+		return newPrometheusMetric(opChain, m.name, m.typ, nil)
+	}
+
+	return newPrometheusMetric(opChain, m.name, m.typ, samples)
+}
+
+// Type returns a new String instance with metric type declared in
+// "# TYPE" comment, e.g. "counter", "gauge", or "histogram".
+//
+// If type is not declared, the string is empty.
+//
+// Example:
+//
+//	metric := resp.PrometheusMetrics().Metric("http_requests_total")
+//	metric.Type().IsEqual("counter")
+func (m *PrometheusMetric) Type() *String {
+	opChain := m.chain.enter("Type()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, m.typ)
+}
+
+// Value returns a new Number instance with value of the single sample of
+// the metric.
+//
+// If metric has several samples (e.g. with different labels), failure is
+// reported; use WithLabels to select single sample, or Sum to get sum
+// of all samples.
+//
+// Example:
+//
+//	metric := resp.PrometheusMetrics().Metric("process_open_fds")
+//	metric.Value().Gt(0)
+func (m *PrometheusMetric) Value() *Number {
+	opChain := m.chain.enter("Value()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, 0)
+	}
+
+	if len(m.samples) != 1 {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{m.samples},
+			Errors: []error{
+				fmt.Errorf("expected: metric %q has exactly one sample, but it has %d",
+					m.name, len(m.samples)),
+			},
+		})
+		return newNumber(opChain, 0)
+	}
+
+	return newNumber(opChain, m.samples[0].Value)
+}
+
+// Sum returns a new Number instance with sum of values of all samples of
+// the metric.
+//
+// Example:
+//
+//	metric := resp.PrometheusMetrics().Metric("http_requests_total")
+//	metric.Sum().IsEqual(100)
+func (m *PrometheusMetric) Sum() *Number {
+	opChain := m.chain.enter("Sum()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, 0)
+	}
+
+	var sum float64
+	for _, s := range m.samples {
+		sum += s.Value
+	}
+
+	return newNumber(opChain, sum)
+}
+
+func hasPrometheusLabels(sample PrometheusSample, labels map[string]string) bool {
+	for k, v := range labels {
+		if actual, ok := sample.Labels[k]; !ok || actual != v {
+			return false
+		}
+	}
+	return true
+}
+
+// Parses Prometheus text exposition format.
+// Returns samples and metric types from "# TYPE" comments.
+func parsePrometheusText(text string) ([]PrometheusSample, map[string]string, error) {
+	var samples []PrometheusSample
+
+	types := map[string]string{}
+
+	for n, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "#") {
+			fields := strings.Fields(line[1:])
+			if len(fields) >= 3 && fields[0] == "TYPE" {
+				types[fields[1]] = fields[2]
+			}
+			continue
+		}
+
+		sample, err := parsePrometheusSample(line)
+		if err != nil {
+			return nil, nil, fmt.Errorf("line %d: %w", n+1, err)
+		}
+
+		samples = append(samples, sample)
+	}
+
+	return samples, types, nil
+}
+
+func parsePrometheusSample(line string) (PrometheusSample, error) {
+	sample := PrometheusSample{
+		Labels: map[string]string{},
+	}
+
+	n := strings.IndexAny(line, "{ \t")
+	if n <= 0 {
+		return sample, fmt.Errorf("invalid sample %q", line)
+	}
+
+	sample.Name = line[:n]
+	rest := line[n:]
+
+	if strings.HasPrefix(rest, "{") {
+		var err error
+		rest, err = parsePrometheusLabels(rest[1:], sample.Labels)
+		if err != nil {
+			return sample, err
+		}
+	}
+
+	// value may be followed by timestamp
+	fields := strings.Fields(rest)
+	if len(fields) == 0 || len(fields) > 2 {
+		return sample, fmt.Errorf("invalid sample %q", line)
+	}
+
+	value, err := parsePrometheusValue(fields[0])
+	if err != nil {
+		return sample, fmt.Errorf("invalid value in sample %q", line)
+	}
+
+	sample.Value = value
+
+	return sample, nil
+}
+
+// Parses labels after opening '{' and returns text after closing '}'.
+func parsePrometheusLabels(s string, labels map[string]string) (string, error) {
+	for {
+		s = strings.TrimLeft(s, " \t")
+
+		if strings.HasPrefix(s, "}") {
+			return s[1:], nil
+		}
+
+		eq := strings.Index(s, "=")
+		if eq <= 0 {
+			return "", errors.New("invalid label")
+		}
+
+		name := strings.TrimSpace(s[:eq])
+		s = strings.TrimLeft(s[eq+1:], " \t")
+
+		if !strings.HasPrefix(s, `"`) {
+			return "", fmt.Errorf("invalid value of label %q", name)
+		}
+		s = s[1:]
+
+		var value strings.Builder
+		closed := false
+
+		for i := 0; i < len(s); i++ {
+			c := s[i]
+			if c == '\\' && i+1 < len(s) {
+				i++
+				switch s[i] {
+				case 'n':
+					value.WriteByte('\n')
+				default:
+					value.WriteByte(s[i])
+				}
+				continue
+			}
+			if c == '"' {
+				s = s[i+1:]
+				closed = true
+				break
+			}
+			value.WriteByte(c)
+		}
+
+		if !closed {
+			return "", fmt.Errorf("unterminated value of label %q", name)
+		}
+
+		labels[name] = value.String()
+
+		s = strings.TrimLeft(s, " \t")
+		if strings.HasPrefix(s, ",") {
+			s = s[1:]
+		} else if !strings.HasPrefix(s, "}") {
+			return "", errors.New("invalid labels")
+		}
+	}
+}
+
+func parsePrometheusValue(s string) (float64, error) {
+	switch s {
+	case "+Inf", "Inf":
+		return math.Inf(1), nil
+	case "-Inf":
+		return math.Inf(-1), nil
+	case "NaN":
+		return math.NaN(), nil
+	}
+
+	return strconv.ParseFloat(s, 64)
+}
@@ -0,0 +1,285 @@
+package httpexpect
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const prometheusTestMetrics = `# HELP http_requests_total Total number of HTTP requests.
+# TYPE http_requests_total counter
+http_requests_total{method="GET",code="200"} 10
+http_requests_total{method="GET",code="500"} 2
+http_requests_total{method="POST",code="200"} 5 1395066363000
+
+# HELP process_open_fds Number of open file descriptors.
+# TYPE process_open_fds gauge
+process_open_fds 42
+
+# TYPE request_duration_seconds histogram
+request_duration_seconds_bucket{le="0.1"} 3
+request_duration_seconds_bucket{le="+Inf"} 4
+request_duration_seconds_sum 1.5
+request_duration_seconds_count 4
+
+weird_labels{path="/a\"b\\c\nd", empty=""} +Inf
+no_type -1.5e3
+`
+
+func newPrometheusTestResponse(t *testing.T, reporter Reporter, body string) *Response {
+	httpResp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"Content-Type": {"text/plain; version=0.0.4; charset=utf-8"},
+		},
+		Body: io.NopCloser(bytes.NewBufferString(body)),
+	}
+
+	return NewResponse(reporter, httpResp)
+}
+
+func TestPrometheus_FailedChain(t *testing.T) {
+	reporter := newMockReporter(t)
+	chain := newChainWithDefaults("test", reporter, flagFailed)
+
+	metrics := newPrometheusMetrics(chain, nil, nil)
+	metrics.chain.assert(t, failure)
+
+	metrics.Alias("foo")
+	metrics.ContainsMetric("foo")
+	metrics.NotContainsMetric("foo")
+
+	metric := metrics.Metric("foo")
+	metric.chain.assert(t, failure)
+
+	metric.Alias("foo")
+	metric.WithLabels(map[string]string{"foo": "bar"}).chain.assert(t, failure)
+	metric.Type().chain.assert(t, failure)
+	metric.Value().chain.assert(t, failure)
+	metric.Sum().chain.assert(t, failure)
+}
+
+func TestPrometheus_Parse(t *testing.T) {
+	samples, types, err := parsePrometheusText(prometheusTestMetrics)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		"http_requests_total":      "counter",
+		"process_open_fds":         "gauge",
+		"request_duration_seconds": "histogram",
+	}, types)
+
+	require.Equal(t, 10, len(samples))
+
+	assert.Equal(t, PrometheusSample{
+		Name:   "http_requests_total",
+		Labels: map[string]string{"method": "GET", "code": "200"},
+		Value:  10,
+	}, samples[0])
+
+	assert.Equal(t, PrometheusSample{
+		Name:   "http_requests_total",
+		Labels: map[string]string{"method": "POST", "code": "200"},
+		Value:  5,
+	}, samples[2])
+
+	assert.Equal(t, PrometheusSample{
+		Name:   "process_open_fds",
+		Labels: map[string]string{},
+		Value:  42,
+	}, samples[3])
+
+	assert.Equal(t, map[string]string{"path": "/a\"b\\c\nd", "empty": ""},
+		samples[8].Labels)
+	assert.True(t, math.IsInf(samples[8].Value, 1))
+
+	assert.Equal(t, -1500.0, samples[9].Value)
+
+	invalid := []string{
+		`metric`,
+		`metric abc`,
+		`metric 1 2 3`,
+		`metric{label} 1`,
+		`metric{label=value} 1`,
+		`metric{label="value} 1`,
+		`metric{a="1" b="2"} 1`,
+		`{label="value"} 1`,
+	}
+
+	for _, text := range invalid {
+		t.Run(text, func(t *testing.T) {
+			_, _, err := parsePrometheusText(text)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestPrometheus_Metric(t *testing.T) {
+	t.Run("value", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		resp := newPrometheusTestResponse(t, reporter, prometheusTestMetrics)
+
+		metrics := resp.PrometheusMetrics()
+		metrics.chain.assert(t, success)
+
+		assert.Equal(t, 10, len(metrics.Raw()))
+
+		metric := metrics.Metric("process_open_fds")
+		metric.chain.assert(t, success)
+
+		metric.Type().IsEqual("gauge")
+		metric.Value().IsEqual(42)
+		metric.chain.assert(t, success)
+	})
+
+	t.Run("labels", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		resp := newPrometheusTestResponse(t, reporter, prometheusTestMetrics)
+
+		metric := resp.PrometheusMetrics().Metric("http_requests_total")
+		metric.chain.assert(t, success)
+
+		metric.Type().IsEqual("counter")
+		metric.Sum().IsEqual(17)
+
+		metric.WithLabels(map[string]string{"method": "GET"}).Sum().IsEqual(12)
+
+		metric.WithLabels(map[string]string{"method": "GET", "code": "200"}).
+			Value().IsEqual(10)
+
+		metric.WithLabels(map[string]string{}).Sum().IsEqual(17)
+
+		metric.chain.assert(t, success)
+	})
+
+	t.Run("histogram", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		resp := newPrometheusTestResponse(t, reporter, prometheusTestMetrics)
+
+		metrics := resp.PrometheusMetrics()
+
+		metrics.Metric("request_duration_seconds_bucket").Type().IsEqual("histogram")
+		metrics.Metric("request_duration_seconds_bucket").
+			WithLabels(map[string]string{"le": "+Inf"}).
+			Value().IsEqual(4)
+		metrics.Metric("request_duration_seconds_count").Value().IsEqual(4)
+		metrics.Metric("no_type").Type().IsEmpty()
+
+		metrics.chain.assert(t, success)
+	})
+
+	t.Run("contains", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		resp := newPrometheusTestResponse(t, reporter, prometheusTestMetrics)
+
+		metrics := resp.PrometheusMetrics()
+
+		metrics.ContainsMetric("process_open_fds")
+		metrics.chain.assert(t, success)
+
+		metrics.NotContainsMetric("bad_metric")
+		metrics.chain.assert(t, success)
+
+		metrics.ContainsMetric("bad_metric")
+		metrics.chain.assert(t, failure)
+
+		metrics.chain.clear()
+
+		metrics.NotContainsMetric("process_open_fds")
+		metrics.chain.assert(t, failure)
+	})
+
+	t.Run("missing metric", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		resp := newPrometheusTestResponse(t, reporter, prometheusTestMetrics)
+
+		metric := resp.PrometheusMetrics().Metric("bad_metric")
+		metric.chain.assert(t, failure)
+	})
+
+	t.Run("missing labels", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		resp := newPrometheusTestResponse(t, reporter, prometheusTestMetrics)
+
+		metric := resp.PrometheusMetrics().Metric("http_requests_total").
+			WithLabels(map[string]string{"code": "404"})
+		metric.chain.assert(t, failure)
+	})
+
+	t.Run("multiple samples", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		resp := newPrometheusTestResponse(t, reporter, prometheusTestMetrics)
+
+		value := resp.PrometheusMetrics().Metric("http_requests_total").Value()
+		value.chain.assert(t, failure)
+	})
+}
+
+func TestPrometheus_Response(t *testing.T) {
+	t.Run("invalid body", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		resp := newPrometheusTestResponse(t, reporter, "metric{ 1\n")
+
+		metrics := resp.PrometheusMetrics()
+		metrics.chain.assert(t, failure)
+	})
+
+	t.Run("empty body", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		resp := newPrometheusTestResponse(t, reporter, "")
+
+		metrics := resp.PrometheusMetrics()
+		metrics.chain.assert(t, success)
+
+		metrics.NotContainsMetric("foo")
+		metrics.chain.assert(t, success)
+	})
+
+	t.Run("bad content type", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {"application/json"},
+			},
+			Body: io.NopCloser(bytes.NewBufferString("metric 1\n")),
+		}
+
+		metrics := NewResponse(reporter, httpResp).PrometheusMetrics()
+		metrics.chain.assert(t, failure)
+	})
+
+	t.Run("content opts", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {"application/openmetrics-text; version=1.0.0"},
+			},
+			Body: io.NopCloser(bytes.NewBufferString("metric 1\n")),
+		}
+
+		metrics := NewResponse(reporter, httpResp).PrometheusMetrics(ContentOpts{
+			MediaType: "application/openmetrics-text",
+		})
+		metrics.chain.assert(t, success)
+
+		metrics.Metric("metric").Value().IsEqual(1)
+		metrics.chain.assert(t, success)
+	})
+
+	t.Run("multiple opts", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		resp := newPrometheusTestResponse(t, reporter, prometheusTestMetrics)
+
+		metrics := resp.PrometheusMetrics(ContentOpts{}, ContentOpts{})
+		metrics.chain.assert(t, failure)
+	})
+}
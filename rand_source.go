@@ -0,0 +1,154 @@
+package httpexpect
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+// RandSource is a deterministic source of random numbers used by
+// randomized helpers: default Faker and multipart boundaries.
+//
+// Two sources created with the same seed produce the same sequence of
+// values. If any value was taken from the source, its seed is printed in
+// failure messages, so that a failure involving random inputs can be
+// reproduced by setting Config.RandSource to NewRandSource(seed).
+//
+// If the source was created automatically by Expect, only values taken
+// by Faker cause printing of the seed; multipart boundaries don't.
+//
+// RandSource is safe for concurrent use. However, the sequence of values
+// is reproducible only if the order of requests is the same.
+type RandSource struct {
+	mu   sync.Mutex
+	seed int64
+	rand *rand.Rand
+	used bool
+
+	// True if source was created automatically instead of
+	// being set by user in Config.RandSource.
+	implicit bool
+}
+
+// NewRandSource returns a new RandSource with given seed.
+//
+// Example:
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		Reporter:   httpexpect.NewAssertReporter(t),
+//		RandSource: httpexpect.NewRandSource(1681234567),
+//	})
+func NewRandSource(seed int64) *RandSource {
+	return &RandSource{
+		seed: seed,
+		rand: rand.New(rand.NewSource(seed)), //nolint:gosec
+	}
+}
+
+func newRandomRandSource() *RandSource {
+	s := NewRandSource(time.Now().UnixNano())
+	s.implicit = true
+
+	return s
+}
+
+// Seed returns seed used to create RandSource.
+func (s *RandSource) Seed() int64 {
+	return s.seed
+}
+
+// wasUsed returns true if any value was taken from the source.
+func (s *RandSource) wasUsed() bool {
+	if s == nil {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.used
+}
+
+func (s *RandSource) intn(n int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.used = true
+
+	return s.rand.Intn(n)
+}
+
+func (s *RandSource) read(b []byte) {
+	s.fill(b, true)
+}
+
+func (s *RandSource) fill(b []byte, markUsed bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if markUsed {
+		s.used = true
+	}
+
+	_, _ = s.rand.Read(b)
+}
+
+// RequestIDGenerator returns a function that generates version 4 UUIDs
+// using values from the source. It can be used as Config.RequestIDGenerator.
+//
+// By default, request IDs are generated using crypto/rand and don't depend
+// on RandSource. Use this generator if request IDs should be reproducible
+// from the seed too.
+//
+// Example:
+//
+//	source := httpexpect.NewRandSource(1681234567)
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		Reporter:           httpexpect.NewAssertReporter(t),
+//		RandSource:         source,
+//		RequestIDHeader:    "X-Request-ID",
+//		RequestIDGenerator: source.RequestIDGenerator(),
+//	})
+func (s *RandSource) RequestIDGenerator() func() string {
+	return func() string {
+		var b [16]byte
+		s.read(b[:])
+
+		return formatUUID(b)
+	}
+}
+
+// boundary returns random multipart boundary, in the same format as
+// generated by multipart.Writer.
+func (s *RandSource) boundary() string {
+	var b [30]byte
+
+	// Boundaries don't affect test data, so there is no need to
+	// print seed of implicit source because of them.
+	s.fill(b[:], !s.implicit)
+
+	return fmt.Sprintf("%x", b[:])
+}
+
+// sampleIndices returns n distinct indices in range [0; total), chosen
+// randomly using given seed and sorted in ascending order.
+// If n >= total, all indices are returned.
+func sampleIndices(total, n int, seed int64) []int {
+	var indices []int
+
+	if n >= total {
+		indices = make([]int, total)
+		for i := range indices {
+			indices[i] = i
+		}
+		return indices
+	}
+
+	indices = rand.New(rand.NewSource(seed)).Perm(total)[:n] //nolint:gosec
+	sort.Ints(indices)
+
+	return indices
+}
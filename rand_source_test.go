@@ -0,0 +1,206 @@
+package httpexpect
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRandSource_Determinism(t *testing.T) {
+	generate := func(s *RandSource) []interface{} {
+		var b [8]byte
+		s.read(b[:])
+
+		return []interface{}{
+			s.intn(1000),
+			b,
+			s.boundary(),
+		}
+	}
+
+	s1 := NewRandSource(123)
+	s2 := NewRandSource(123)
+	s3 := NewRandSource(456)
+
+	assert.Equal(t, int64(123), s1.Seed())
+
+	assert.False(t, s1.wasUsed())
+
+	v1 := generate(s1)
+	v2 := generate(s2)
+	v3 := generate(s3)
+
+	assert.True(t, s1.wasUsed())
+
+	assert.Equal(t, v1, v2)
+	assert.NotEqual(t, v1, v3)
+
+	assert.Len(t, v1[2], 60)
+
+	var nilSource *RandSource
+	assert.False(t, nilSource.wasUsed())
+}
+
+func TestRandSource_Config(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		e := WithConfig(Config{
+			Reporter: newMockReporter(t),
+		})
+
+		require.NotNil(t, e.config.RandSource)
+		assert.Equal(t, e.config.RandSource.Seed(), e.Faker().Seed())
+	})
+
+	t.Run("faker", func(t *testing.T) {
+		generate := func() []string {
+			e := WithConfig(Config{
+				Reporter:   newMockReporter(t),
+				RandSource: NewRandSource(42),
+			})
+
+			values := []string{
+				e.Faker().Email(),
+				e.Faker().UUID(),
+			}
+
+			assert.True(t, e.config.RandSource.wasUsed())
+
+			return values
+		}
+
+		assert.Equal(t, generate(), generate())
+	})
+
+	t.Run("explicit faker", func(t *testing.T) {
+		source := NewRandSource(42)
+
+		e := WithConfig(Config{
+			Reporter:   newMockReporter(t),
+			RandSource: source,
+			Faker:      NewFaker(123),
+		})
+
+		e.Faker().Email()
+
+		assert.Equal(t, int64(123), e.Faker().Seed())
+		assert.False(t, source.wasUsed())
+	})
+
+	t.Run("multipart boundary", func(t *testing.T) {
+		generate := func() string {
+			client := &mockClient{}
+
+			e := WithConfig(Config{
+				Client:     client,
+				Reporter:   newMockReporter(t),
+				RandSource: NewRandSource(42),
+			})
+
+			e.POST("/").
+				WithMultipart().
+				WithFormField("foo", "bar").
+				Expect()
+
+			return client.req.Header.Get("Content-Type")
+		}
+
+		contentType := generate()
+
+		assert.Contains(t, contentType, "multipart/form-data; boundary=")
+		assert.Equal(t, contentType, generate())
+	})
+
+	t.Run("implicit source", func(t *testing.T) {
+		e := WithConfig(Config{
+			Client:   &mockClient{},
+			Reporter: newMockReporter(t),
+		})
+
+		e.POST("/").
+			WithMultipart().
+			WithFormField("foo", "bar").
+			Expect()
+
+		// boundaries don't cause printing of seed
+		assert.False(t, e.config.RandSource.wasUsed())
+
+		e.Faker().Email()
+
+		assert.True(t, e.config.RandSource.wasUsed())
+	})
+
+	t.Run("explicit source", func(t *testing.T) {
+		e := WithConfig(Config{
+			Client:     &mockClient{},
+			Reporter:   newMockReporter(t),
+			RandSource: NewRandSource(42),
+		})
+
+		e.POST("/").
+			WithMultipart().
+			WithFormField("foo", "bar").
+			Expect()
+
+		assert.True(t, e.config.RandSource.wasUsed())
+	})
+
+	t.Run("request id", func(t *testing.T) {
+		generate := func() string {
+			client := &mockClient{}
+
+			source := NewRandSource(42)
+
+			e := WithConfig(Config{
+				Client:             client,
+				Reporter:           newMockReporter(t),
+				RandSource:         source,
+				RequestIDHeader:    "X-Request-ID",
+				RequestIDGenerator: source.RequestIDGenerator(),
+			})
+
+			e.GET("/").Expect()
+
+			return client.req.Header.Get("X-Request-ID")
+		}
+
+		id := generate()
+
+		assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[0-9a-f]{4}-[0-9a-f]{12}$`, id)
+		assert.Equal(t, id, generate())
+	})
+
+	t.Run("request id not from source", func(t *testing.T) {
+		client := &mockClient{}
+
+		e := WithConfig(Config{
+			Client:          client,
+			Reporter:        newMockReporter(t),
+			RandSource:      NewRandSource(42),
+			RequestIDHeader: "X-Request-ID",
+		})
+
+		e.GET("/").Expect()
+
+		assert.NotEmpty(t, client.req.Header.Get("X-Request-ID"))
+		assert.False(t, e.config.RandSource.wasUsed())
+	})
+
+	t.Run("failure context", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		source := NewRandSource(42)
+
+		e := WithConfig(Config{
+			Client:           &mockClient{},
+			AssertionHandler: handler,
+			RandSource:       source,
+		})
+
+		e.GET("/").Expect().Status(http.StatusTeapot)
+
+		require.NotNil(t, handler.failure)
+		assert.Same(t, source, handler.ctx.RandSource)
+	})
+}
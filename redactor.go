@@ -0,0 +1,420 @@
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Redactor hides sensitive data, like authorization tokens and personal
+// information, in printer output and failure messages.
+//
+// Redactor is set via Config.Redactor and is applied to:
+//   - requests and responses passed to Config.Printers
+//     (including CurlPrinter and DebugPrinter)
+//   - request and response dumps in failure messages produced by
+//     DefaultFormatter and JSONFormatter
+//   - actual and expected values in failure messages, including values
+//     checked via Response.Header and similar methods
+//   - dumps written by AttachmentHandler
+//   - URLs and error messages passed to Config.Telemetry
+//
+// Redactor never modifies requests and responses themselves, it always
+// works on copies.
+//
+// Example:
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		Reporter: httpexpect.NewAssertReporter(t),
+//		Printers: []httpexpect.Printer{
+//			httpexpect.NewDebugPrinter(t, true),
+//		},
+//		Redactor: &httpexpect.Redactor{
+//			Headers:   []string{"Authorization", "X-Api-Key"},
+//			JSONPaths: []string{"$.password", "$..ssn"},
+//			Patterns: []*regexp.Regexp{
+//				regexp.MustCompile(`token=[^&\s]+`),
+//			},
+//		},
+//	})
+type Redactor struct {
+	// Names of headers which values should be redacted.
+	// Matched case-insensitively.
+	Headers []string
+
+	// JSON paths of fields in JSON bodies which values should be redacted.
+	//
+	// Supported syntax:
+	//   - "$.a.b" - field "b" of object "a" of root object
+	//   - "$.a[*].b", "$.a.*.b" - field "b" of every element of "a"
+	//   - "$.a[0].b" - field "b" of first element of array "a"
+	//   - "$..b" - field "b" at any depth
+	JSONPaths []string
+
+	// Regular expressions applied to URLs, header values, and bodies.
+	// Every match is redacted.
+	Patterns []*regexp.Regexp
+
+	// String used instead of redacted values.
+	// Default is "<redacted>".
+	Replacement string
+
+	once     sync.Once
+	paths    [][]jsonPathSegment
+	pathsErr error
+}
+
+type jsonPathSegment struct {
+	name      string
+	any       bool
+	recursive bool
+}
+
+const defaultRedactReplacement = "<redacted>"
+
+func (rd *Redactor) replacement() string {
+	if rd.Replacement != "" {
+		return rd.Replacement
+	}
+	return defaultRedactReplacement
+}
+
+func (rd *Redactor) compile() error {
+	rd.once.Do(func() {
+		for _, path := range rd.JSONPaths {
+			segments, err := parseJSONPath(path)
+			if err != nil {
+				rd.pathsErr = err
+				return
+			}
+			rd.paths = append(rd.paths, segments)
+		}
+	})
+
+	return rd.pathsErr
+}
+
+func parseJSONPath(path string) ([]jsonPathSegment, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("invalid JSON path %q: should start with '$'", path)
+	}
+
+	var segments []jsonPathSegment
+
+	s := path[1:]
+
+	readName := func() string {
+		n := strings.IndexAny(s, ".[")
+		if n < 0 {
+			n = len(s)
+		}
+		name := s[:n]
+		s = s[n:]
+		return name
+	}
+
+	for s != "" {
+		switch {
+		case strings.HasPrefix(s, ".."):
+			s = s[2:]
+			name := readName()
+			if name == "" {
+				return nil, fmt.Errorf("invalid JSON path %q: empty field name", path)
+			}
+			segments = append(segments, jsonPathSegment{
+				name:      name,
+				any:       name == "*",
+				recursive: true,
+			})
+
+		case strings.HasPrefix(s, "."):
+			s = s[1:]
+			name := readName()
+			if name == "" {
+				return nil, fmt.Errorf("invalid JSON path %q: empty field name", path)
+			}
+			segments = append(segments, jsonPathSegment{
+				name: name,
+				any:  name == "*",
+			})
+
+		case strings.HasPrefix(s, "["):
+			n := strings.Index(s, "]")
+			if n < 0 {
+				return nil, fmt.Errorf("invalid JSON path %q: missing ']'", path)
+			}
+			index := s[1:n]
+			s = s[n+1:]
+			if index != "*" {
+				if _, err := strconv.Atoi(index); err != nil {
+					return nil, fmt.Errorf("invalid JSON path %q: bad index %q",
+						path, index)
+				}
+			}
+			segments = append(segments, jsonPathSegment{
+				name: index,
+				any:  index == "*",
+			})
+
+		default:
+			return nil, fmt.Errorf("invalid JSON path %q", path)
+		}
+	}
+
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("invalid JSON path %q: empty path", path)
+	}
+
+	return segments, nil
+}
+
+// RedactString returns a copy of string with all matches of Patterns redacted.
+func (rd *Redactor) RedactString(s string) string {
+	if rd == nil {
+		return s
+	}
+
+	for _, re := range rd.Patterns {
+		s = re.ReplaceAllString(s, rd.replacement())
+	}
+
+	return s
+}
+
+// RedactHeader returns a copy of header with values of Headers redacted,
+// and with all matches of Patterns redacted in other values.
+func (rd *Redactor) RedactHeader(header http.Header) http.Header {
+	if rd == nil || header == nil {
+		return header
+	}
+
+	result := make(http.Header, len(header))
+
+	for name, values := range header {
+		redacted := make([]string, len(values))
+
+		for i, value := range values {
+			if rd.isRedactedHeader(name) {
+				redacted[i] = rd.replacement()
+			} else {
+				redacted[i] = rd.RedactString(value)
+			}
+		}
+
+		result[name] = redacted
+	}
+
+	return result
+}
+
+func (rd *Redactor) isRedactedHeader(name string) bool {
+	for _, h := range rd.Headers {
+		if strings.EqualFold(h, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactBody returns a copy of body with redacted JSONPaths (if body is
+// valid JSON) and Patterns.
+func (rd *Redactor) RedactBody(body []byte) []byte {
+	if rd == nil || len(body) == 0 {
+		return body
+	}
+
+	if len(rd.JSONPaths) != 0 {
+		var value interface{}
+		if err := json.Unmarshal(body, &value); err == nil {
+			value = rd.RedactValue(value)
+			if b, err := json.Marshal(value); err == nil {
+				body = b
+			}
+		}
+	}
+
+	return []byte(rd.RedactString(string(body)))
+}
+
+// RedactValue returns a copy of canonical JSON value (i.e. value consisting
+// of map[string]interface{}, []interface{}, and primitive types) with
+// redacted JSONPaths. Original value is not modified.
+//
+// Panics if JSONPaths contain invalid path.
+func (rd *Redactor) RedactValue(value interface{}) interface{} {
+	if rd == nil {
+		return value
+	}
+
+	if err := rd.compile(); err != nil {
+		panic(err)
+	}
+
+	for _, path := range rd.paths {
+		value = rd.redactPath(value, path)
+	}
+
+	return value
+}
+
+func (rd *Redactor) redactPath(value interface{}, path []jsonPathSegment) interface{} {
+	if len(path) == 0 {
+		return rd.replacement()
+	}
+
+	seg := path[0]
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			if seg.any || seg.name == key {
+				child = rd.redactPath(child, path[1:])
+			}
+			if seg.recursive {
+				child = rd.redactPath(child, path)
+			}
+			result[key] = child
+		}
+		return result
+
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, child := range v {
+			if !seg.recursive && (seg.any || seg.name == strconv.Itoa(i)) {
+				child = rd.redactPath(child, path[1:])
+			}
+			if seg.recursive {
+				child = rd.redactPath(child, path)
+			}
+			result[i] = child
+		}
+		return result
+
+	default:
+		return value
+	}
+}
+
+// Returns redacted copy of URL.
+func (rd *Redactor) redactURL(u *url.URL) *url.URL {
+	if rd == nil || u == nil || len(rd.Patterns) == 0 {
+		return u
+	}
+
+	parsed, err := url.Parse(rd.RedactString(u.String()))
+	if err != nil {
+		return u
+	}
+
+	return parsed
+}
+
+// Returns redacted copy of request, suitable for printing.
+// Request body is read using bodyWrapper or GetBody, if possible.
+func (rd *Redactor) redactRequest(req *http.Request) *http.Request {
+	if rd == nil || req == nil {
+		return req
+	}
+
+	reqCopy := *req
+
+	reqCopy.URL = rd.redactURL(req.URL)
+	reqCopy.Header = rd.RedactHeader(req.Header)
+
+	if req.Body != nil && req.Body != http.NoBody {
+		var body io.ReadCloser
+
+		if bw, ok := req.Body.(*bodyWrapper); ok {
+			body, _ = bw.GetBody()
+		} else if req.GetBody != nil {
+			body, _ = req.GetBody()
+		}
+
+		if body != nil {
+			reqCopy.Body, reqCopy.ContentLength = rd.redactReader(body)
+			reqCopy.GetBody = nil
+		} else {
+			reqCopy.Body = http.NoBody
+			reqCopy.ContentLength = 0
+		}
+	}
+
+	return &reqCopy
+}
+
+// Returns redacted copy of response, suitable for printing.
+// Response body is read using bodyWrapper, if possible.
+func (rd *Redactor) redactResponse(resp *http.Response) *http.Response {
+	if rd == nil || resp == nil {
+		return resp
+	}
+
+	respCopy := *resp
+
+	respCopy.Header = rd.RedactHeader(resp.Header)
+
+	if resp.Body != nil && resp.Body != http.NoBody {
+		var body io.ReadCloser
+
+		if bw, ok := resp.Body.(*bodyWrapper); ok {
+			body, _ = bw.GetBody()
+		}
+
+		if body != nil {
+			respCopy.Body, respCopy.ContentLength = rd.redactReader(body)
+		} else {
+			respCopy.Body = http.NoBody
+			respCopy.ContentLength = 0
+		}
+	}
+
+	return &respCopy
+}
+
+func (rd *Redactor) redactReader(body io.ReadCloser) (io.ReadCloser, int64) {
+	defer body.Close()
+
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return http.NoBody, 0
+	}
+
+	content = rd.RedactBody(content)
+
+	return io.NopCloser(bytes.NewReader(content)), int64(len(content))
+}
+
+// Returns copy of failure with redacted values.
+// If failure is about value of one of Headers, values are fully redacted.
+// Otherwise, JSONPaths are applied to values, and Patterns to strings.
+func redactFailure(rd *Redactor, failure *AssertionFailure) *AssertionFailure {
+	failureCopy := *failure
+
+	redact := func(v *AssertionValue) *AssertionValue {
+		if v == nil {
+			return nil
+		}
+		if failure.header != "" && rd.isRedactedHeader(failure.header) {
+			return &AssertionValue{rd.replacement()}
+		}
+		value := rd.RedactValue(v.Value)
+		if s, ok := value.(string); ok {
+			value = rd.RedactString(s)
+		}
+		return &AssertionValue{value}
+	}
+
+	failureCopy.Actual = redact(failure.Actual)
+	failureCopy.Expected = redact(failure.Expected)
+	failureCopy.Reference = redact(failure.Reference)
+
+	return &failureCopy
+}
@@ -0,0 +1,443 @@
+package httpexpect
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactor_ParsePath(t *testing.T) {
+	cases := []struct {
+		path  string
+		valid bool
+	}{
+		{"$.a", true},
+		{"$.a.b", true},
+		{"$.a[*].b", true},
+		{"$.a.*.b", true},
+		{"$.a[0].b", true},
+		{"$..b", true},
+		{"$..a.b", true},
+		{"", false},
+		{"$", false},
+		{"a.b", false},
+		{"$.", false},
+		{"$..", false},
+		{"$.a[", false},
+		{"$.a[x]", false},
+		{"$a", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.path, func(t *testing.T) {
+			_, err := parseJSONPath(tc.path)
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestRedactor_Value(t *testing.T) {
+	value := map[string]interface{}{
+		"password": "secret",
+		"user": map[string]interface{}{
+			"name": "john",
+			"ssn":  "123",
+		},
+		"cards": []interface{}{
+			map[string]interface{}{"number": "1111", "type": "visa"},
+			map[string]interface{}{"number": "2222", "type": "mc"},
+		},
+		"nested": []interface{}{
+			map[string]interface{}{
+				"ssn": "456",
+			},
+		},
+	}
+
+	t.Run("field", func(t *testing.T) {
+		rd := &Redactor{JSONPaths: []string{"$.password", "$.user.ssn"}}
+
+		result := rd.RedactValue(value).(map[string]interface{})
+
+		assert.Equal(t, "<redacted>", result["password"])
+		assert.Equal(t, "<redacted>", result["user"].(map[string]interface{})["ssn"])
+		assert.Equal(t, "john", result["user"].(map[string]interface{})["name"])
+	})
+
+	t.Run("wildcard", func(t *testing.T) {
+		rd := &Redactor{JSONPaths: []string{"$.cards[*].number"}}
+
+		result := rd.RedactValue(value).(map[string]interface{})
+		cards := result["cards"].([]interface{})
+
+		assert.Equal(t, "<redacted>", cards[0].(map[string]interface{})["number"])
+		assert.Equal(t, "<redacted>", cards[1].(map[string]interface{})["number"])
+		assert.Equal(t, "visa", cards[0].(map[string]interface{})["type"])
+	})
+
+	t.Run("index", func(t *testing.T) {
+		rd := &Redactor{JSONPaths: []string{"$.cards[1].number"}}
+
+		result := rd.RedactValue(value).(map[string]interface{})
+		cards := result["cards"].([]interface{})
+
+		assert.Equal(t, "1111", cards[0].(map[string]interface{})["number"])
+		assert.Equal(t, "<redacted>", cards[1].(map[string]interface{})["number"])
+	})
+
+	t.Run("recursive", func(t *testing.T) {
+		rd := &Redactor{JSONPaths: []string{"$..ssn"}}
+
+		result := rd.RedactValue(value).(map[string]interface{})
+
+		assert.Equal(t, "<redacted>",
+			result["user"].(map[string]interface{})["ssn"])
+		assert.Equal(t, "<redacted>",
+			result["nested"].([]interface{})[0].(map[string]interface{})["ssn"])
+	})
+
+	t.Run("replacement", func(t *testing.T) {
+		rd := &Redactor{JSONPaths: []string{"$.password"}, Replacement: "***"}
+
+		result := rd.RedactValue(value).(map[string]interface{})
+
+		assert.Equal(t, "***", result["password"])
+	})
+
+	t.Run("original not modified", func(t *testing.T) {
+		rd := &Redactor{JSONPaths: []string{"$.password", "$..ssn", "$.cards[*].number"}}
+
+		_ = rd.RedactValue(value)
+
+		assert.Equal(t, "secret", value["password"])
+		assert.Equal(t, "123", value["user"].(map[string]interface{})["ssn"])
+		assert.Equal(t, "1111",
+			value["cards"].([]interface{})[0].(map[string]interface{})["number"])
+	})
+
+	t.Run("non-json value", func(t *testing.T) {
+		rd := &Redactor{JSONPaths: []string{"$.password"}}
+
+		assert.Equal(t, "str", rd.RedactValue("str"))
+		assert.Equal(t, 123, rd.RedactValue(123))
+	})
+
+	t.Run("invalid path", func(t *testing.T) {
+		rd := &Redactor{JSONPaths: []string{"password"}}
+
+		assert.Panics(t, func() {
+			rd.RedactValue(value)
+		})
+	})
+}
+
+func TestRedactor_Header(t *testing.T) {
+	rd := &Redactor{
+		Headers:  []string{"authorization"},
+		Patterns: []*regexp.Regexp{regexp.MustCompile(`token=\w+`)},
+	}
+
+	header := http.Header{
+		"Authorization": {"Bearer secret"},
+		"Cookie":        {"token=abc; lang=en"},
+		"Accept":        {"text/plain"},
+	}
+
+	result := rd.RedactHeader(header)
+
+	assert.Equal(t, []string{"<redacted>"}, result["Authorization"])
+	assert.Equal(t, []string{"<redacted>; lang=en"}, result["Cookie"])
+	assert.Equal(t, []string{"text/plain"}, result["Accept"])
+
+	assert.Equal(t, []string{"Bearer secret"}, header["Authorization"])
+	assert.Equal(t, []string{"token=abc; lang=en"}, header["Cookie"])
+}
+
+func TestRedactor_Body(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		rd := &Redactor{JSONPaths: []string{"$.password"}}
+
+		result := rd.RedactBody([]byte(`{"user":"john","password":"secret"}`))
+
+		assert.JSONEq(t, `{"user":"john","password":"<redacted>"}`, string(result))
+	})
+
+	t.Run("not json", func(t *testing.T) {
+		rd := &Redactor{JSONPaths: []string{"$.password"}}
+
+		result := rd.RedactBody([]byte(`password=secret`))
+
+		assert.Equal(t, `password=secret`, string(result))
+	})
+
+	t.Run("patterns", func(t *testing.T) {
+		rd := &Redactor{
+			Patterns: []*regexp.Regexp{regexp.MustCompile(`password=\w+`)},
+		}
+
+		result := rd.RedactBody([]byte(`user=john&password=secret`))
+
+		assert.Equal(t, `user=john&<redacted>`, string(result))
+	})
+
+	t.Run("string", func(t *testing.T) {
+		rd := &Redactor{
+			Patterns: []*regexp.Regexp{
+				regexp.MustCompile(`\d{4}-\d{4}`),
+			},
+			Replacement: "XXXX",
+		}
+
+		assert.Equal(t, "card XXXX", rd.RedactString("card 1234-5678"))
+	})
+}
+
+func TestRedactor_Nil(t *testing.T) {
+	var rd *Redactor
+
+	header := http.Header{"Authorization": {"secret"}}
+	body := []byte(`{"password":"secret"}`)
+
+	assert.Equal(t, "secret", rd.RedactString("secret"))
+	assert.Equal(t, header, rd.RedactHeader(header))
+	assert.Equal(t, body, rd.RedactBody(body))
+	assert.Equal(t, "secret", rd.RedactValue("secret"))
+
+	req, _ := http.NewRequest("GET", "http://example.com", nil)
+	assert.Same(t, req, rd.redactRequest(req))
+
+	resp := &http.Response{}
+	assert.Same(t, resp, rd.redactResponse(resp))
+}
+
+func TestRedactor_RequestResponse(t *testing.T) {
+	rd := &Redactor{
+		Headers:   []string{"Authorization"},
+		JSONPaths: []string{"$.password"},
+		Patterns:  []*regexp.Regexp{regexp.MustCompile(`token=\w+`)},
+	}
+
+	t.Run("request", func(t *testing.T) {
+		req, err := http.NewRequest("POST", "http://example.com/path?token=abc",
+			bytes.NewBufferString(`{"password":"secret"}`))
+		require.NoError(t, err)
+
+		req.Header.Set("Authorization", "Bearer secret")
+
+		result := rd.redactRequest(req)
+
+		assert.Equal(t, "<redacted>", result.Header.Get("Authorization"))
+		assert.Equal(t, "Bearer secret", req.Header.Get("Authorization"))
+
+		assert.NotContains(t, result.URL.String(), "abc")
+		assert.Contains(t, req.URL.String(), "token=abc")
+
+		b, err := io.ReadAll(result.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"password":"<redacted>"}`, string(b))
+
+		b, err = io.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.Equal(t, `{"password":"secret"}`, string(b))
+	})
+
+	t.Run("response", func(t *testing.T) {
+		resp := &http.Response{
+			Header: http.Header{"Authorization": {"secret"}},
+			Body: newBodyWrapper(
+				io.NopCloser(bytes.NewBufferString(`{"password":"secret"}`)), nil),
+		}
+
+		result := rd.redactResponse(resp)
+
+		assert.Equal(t, "<redacted>", result.Header.Get("Authorization"))
+		assert.Equal(t, "secret", resp.Header.Get("Authorization"))
+
+		b, err := io.ReadAll(result.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"password":"<redacted>"}`, string(b))
+
+		b, err = io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, `{"password":"secret"}`, string(b))
+	})
+}
+
+type redactorTestLogger struct {
+	messages []string
+}
+
+func (l *redactorTestLogger) Logf(message string, args ...interface{}) {
+	l.messages = append(l.messages, fmt.Sprintf(message, args...))
+}
+
+func TestRedactor_Printer(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Session", "session-secret")
+		_, _ = w.Write([]byte(`{"token":"token-secret","id":1}`))
+	})
+
+	logger := &redactorTestLogger{}
+
+	e := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: newMockReporter(t),
+		Client: &http.Client{
+			Transport: NewBinder(handler),
+		},
+		Printers: []Printer{
+			NewDebugPrinter(logger, true),
+		},
+		Redactor: &Redactor{
+			Headers:   []string{"Authorization", "X-Session"},
+			JSONPaths: []string{"$.password", "$.token"},
+		},
+	})
+
+	e.POST("/login").
+		WithHeader("Authorization", "Bearer auth-secret").
+		WithJSON(map[string]interface{}{
+			"user":     "john",
+			"password": "password-secret",
+		}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("token").IsEqual("token-secret")
+
+	output := strings.Join(logger.messages, "\n")
+
+	assert.Contains(t, output, "<redacted>")
+	assert.Contains(t, output, "john")
+	assert.NotContains(t, output, "auth-secret")
+	assert.NotContains(t, output, "password-secret")
+	assert.NotContains(t, output, "session-secret")
+	assert.NotContains(t, output, "token-secret")
+}
+
+func TestRedactor_Formatter(t *testing.T) {
+	rd := &Redactor{
+		Headers:   []string{"Authorization"},
+		JSONPaths: []string{"$.password"},
+	}
+
+	req, err := http.NewRequest("GET", "http://example.com", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer auth-secret")
+
+	ctx := &AssertionContext{
+		Request:  &Request{httpReq: req},
+		Redactor: rd,
+	}
+
+	failure := &AssertionFailure{
+		Type: AssertEqual,
+		Actual: &AssertionValue{
+			map[string]interface{}{"password": "password-secret"},
+		},
+		Expected: &AssertionValue{
+			map[string]interface{}{"password": "other-secret"},
+		},
+	}
+
+	t.Run("default formatter", func(t *testing.T) {
+		f := &DefaultFormatter{}
+
+		output := f.FormatFailure(ctx, failure)
+
+		assert.Contains(t, output, "<redacted>")
+		assert.NotContains(t, output, "auth-secret")
+		assert.NotContains(t, output, "password-secret")
+		assert.NotContains(t, output, "other-secret")
+	})
+
+	t.Run("json formatter", func(t *testing.T) {
+		f := &JSONFormatter{}
+
+		output := f.FormatFailure(ctx, failure)
+
+		assert.Contains(t, output, `\u003credacted\u003e`)
+		assert.NotContains(t, output, "auth-secret")
+		assert.NotContains(t, output, "password-secret")
+		assert.NotContains(t, output, "other-secret")
+	})
+
+	assert.Equal(t, "Bearer auth-secret", req.Header.Get("Authorization"))
+	assert.Equal(t, "password-secret",
+		failure.Actual.Value.(map[string]interface{})["password"])
+}
+
+func TestRedactor_HeaderFailure(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Session", "session-secret")
+		w.Header().Set("X-Trace", "trace token=token-secret")
+	})
+
+	reporter := &redactorTestReporter{}
+
+	e := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: reporter,
+		Client: &http.Client{
+			Transport: NewBinder(handler),
+		},
+		Redactor: &Redactor{
+			Headers: []string{"X-Session"},
+			Patterns: []*regexp.Regexp{
+				regexp.MustCompile(`token=[^&\s]+`),
+			},
+		},
+	})
+
+	t.Run("redacted header", func(t *testing.T) {
+		reporter.messages = nil
+
+		e.GET("/").Expect().
+			Header("X-Session").IsEqual("other-secret")
+
+		require.Equal(t, 1, len(reporter.messages))
+		assert.Contains(t, reporter.messages[0], "<redacted>")
+		assert.NotContains(t, reporter.messages[0], "session-secret")
+		assert.NotContains(t, reporter.messages[0], "other-secret")
+	})
+
+	t.Run("derived assertion", func(t *testing.T) {
+		reporter.messages = nil
+
+		e.GET("/").Expect().
+			Header("X-Session").Length().IsEqual(1)
+
+		require.Equal(t, 1, len(reporter.messages))
+		assert.NotContains(t, reporter.messages[0], "session-secret")
+	})
+
+	t.Run("patterns", func(t *testing.T) {
+		reporter.messages = nil
+
+		e.GET("/").Expect().
+			Header("X-Trace").IsEmpty()
+
+		require.Equal(t, 1, len(reporter.messages))
+		assert.Contains(t, reporter.messages[0], "trace <redacted>")
+		assert.NotContains(t, reporter.messages[0], "token-secret")
+	})
+}
+
+type redactorTestReporter struct {
+	messages []string
+}
+
+func (r *redactorTestReporter) Errorf(message string, args ...interface{}) {
+	r.messages = append(r.messages, fmt.Sprintf(message, args...))
+}
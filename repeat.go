@@ -0,0 +1,453 @@
+package httpexpect
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// RepeatedRequest sends the same request many times, possibly concurrently,
+// and collects aggregate statistics.
+//
+// It can be used for lightweight performance smoke tests that reuse
+// existing request builders. RepeatedRequest is created using Request.Repeat.
+type RepeatedRequest struct {
+	noCopy      noCopy
+	chain       *chain
+	req         *Request
+	count       int
+	concurrency int
+}
+
+// LoadResult holds aggregate statistics of requests sent by
+// RepeatedRequest.Expect.
+type LoadResult struct {
+	noCopy   noCopy
+	chain    *chain
+	samples  []LoadSample
+	duration time.Duration
+}
+
+// LoadSample describes a single request sent by RepeatedRequest.
+type LoadSample struct {
+	// HTTP status code.
+	// Zero if request failed.
+	StatusCode int
+
+	// Round-trip time, including reading response body.
+	Latency time.Duration
+
+	// Error returned by client, if any.
+	Error error
+}
+
+// Failed returns true if request failed or if response status code is 5xx.
+func (s LoadSample) Failed() bool {
+	return s.Error != nil || s.StatusCode >= 500
+}
+
+// Repeat returns a new RepeatedRequest that will send this request n times.
+//
+// By default, requests are sent sequentially; use RepeatedRequest.Concurrency
+// to send them concurrently. Request is sent by RepeatedRequest.Expect, which
+// returns LoadResult with aggregate statistics instead of Response.
+//
+// Every repetition sends the same request: URL, headers, and body are
+// prepared once. Printers, retries, and response matchers are not applied
+// to repeated requests.
+//
+// Example:
+//
+//	e.GET("/health").
+//		Repeat(1000).
+//		Concurrency(20).
+//		Expect().
+//		StatusCount(http.StatusOK).IsEqual(1000)
+func (r *Request) Repeat(n int) *RepeatedRequest {
+	opChain := r.chain.enter("Repeat(%d)", n)
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !opChain.failed() && r.checkOrder(opChain, "Repeat()") {
+		if n <= 0 {
+			opChain.fail(AssertionFailure{
+				Type: AssertUsage,
+				Errors: []error{
+					fmt.Errorf("unexpected non-positive repeat count: %d", n),
+				},
+			})
+		} else if r.wsUpgrade {
+			opChain.fail(AssertionFailure{
+				Type: AssertUsage,
+				Errors: []error{
+					errors.New("unexpected Repeat() call for websocket request"),
+				},
+			})
+		}
+	}
+
+	return &RepeatedRequest{
+		chain:       opChain.clone(),
+		req:         r,
+		count:       n,
+		concurrency: 1,
+	}
+}
+
+// Concurrency sets maximum number of requests sent in parallel.
+//
+// Default is 1, which means that requests are sent sequentially.
+//
+// Example:
+//
+//	e.GET("/health").Repeat(100).Concurrency(10).Expect()
+func (rr *RepeatedRequest) Concurrency(c int) *RepeatedRequest {
+	opChain := rr.chain.enter("Concurrency(%d)", c)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return rr
+	}
+
+	if c <= 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected non-positive concurrency: %d", c),
+			},
+		})
+		return rr
+	}
+
+	rr.concurrency = c
+
+	return rr
+}
+
+// Expect sends all requests, waits until they are completed, and returns
+// LoadResult with aggregate statistics.
+//
+// Transport errors and unexpected statuses are not reported as failures
+// by Expect itself; use LoadResult methods to check them.
+//
+// Example:
+//
+//	result := e.GET("/health").Repeat(100).Concurrency(10).Expect()
+//
+//	result.ErrorRate().Le(0.01)
+//	result.Latency(99).Le(200 * time.Millisecond)
+func (rr *RepeatedRequest) Expect() *LoadResult {
+	opChain := rr.chain.enter("Expect()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newLoadResult(opChain, nil, 0)
+	}
+
+	r := rr.req
+
+	if !r.prepare(opChain) {
+		return newLoadResult(opChain, nil, 0)
+	}
+
+	body, ok := r.encodeReusable(opChain)
+	if !ok {
+		return newLoadResult(opChain, nil, 0)
+	}
+
+	samples := make([]LoadSample, rr.count)
+
+	indexes := make(chan int, rr.count)
+	for i := 0; i < rr.count; i++ {
+		indexes <- i
+	}
+	close(indexes)
+
+	concurrency := rr.concurrency
+	if concurrency > rr.count {
+		concurrency = rr.count
+	}
+
+	var wg sync.WaitGroup
+
+	start := time.Now()
+
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+				samples[i] = rr.send(body)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return newLoadResult(opChain, samples, time.Since(start))
+}
+
+func (rr *RepeatedRequest) send(body []byte) LoadSample {
+	r := rr.req
+
+	httpReq := r.cloneReusable(body)
+
+	start := time.Now()
+
+	resp, err := r.config.Client.Do(httpReq)
+	if err != nil {
+		return LoadSample{
+			Latency: time.Since(start),
+			Error:   err,
+		}
+	}
+
+	_, err = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	return LoadSample{
+		StatusCode: resp.StatusCode,
+		Latency:    time.Since(start),
+		Error:      err,
+	}
+}
+
+// encodeReusable prepares request to be sent many times.
+// Returns request body, which should be passed to cloneReusable.
+func (r *Request) encodeReusable(opChain *chain) ([]byte, bool) {
+	if !r.applyProviders(opChain) {
+		return nil, false
+	}
+
+	if !r.encodeRequest(opChain) {
+		return nil, false
+	}
+
+	for _, transform := range r.transformers {
+		transform(r.httpReq)
+
+		if opChain.failed() {
+			return nil, false
+		}
+	}
+
+	var body []byte
+
+	if r.httpReq.Body != nil && r.httpReq.Body != http.NoBody {
+		var err error
+		body, err = io.ReadAll(r.httpReq.Body)
+		_ = r.httpReq.Body.Close()
+
+		if err != nil {
+			opChain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					errors.New("failed to read request body"),
+					err,
+				},
+			})
+			return nil, false
+		}
+	}
+
+	return body, true
+}
+
+// cloneReusable returns a copy of request prepared by encodeReusable.
+func (r *Request) cloneReusable(body []byte) *http.Request {
+	httpReq := r.httpReq.Clone(r.httpReq.Context())
+
+	if body != nil {
+		httpReq.Body = io.NopCloser(bytes.NewReader(body))
+		httpReq.ContentLength = int64(len(body))
+		httpReq.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+	} else {
+		httpReq.Body = http.NoBody
+	}
+
+	return httpReq
+}
+
+func newLoadResult(
+	parent *chain, samples []LoadSample, duration time.Duration,
+) *LoadResult {
+	return &LoadResult{
+		chain:    parent.clone(),
+		samples:  samples,
+		duration: duration,
+	}
+}
+
+// Raw returns all collected samples, in the order of repetitions.
+func (lr *LoadResult) Raw() []LoadSample {
+	return lr.samples
+}
+
+// Alias is similar to Value.Alias.
+func (lr *LoadResult) Alias(name string) *LoadResult {
+	opChain := lr.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	lr.chain.setAlias(name)
+	return lr
+}
+
+// Count returns a new Number instance with number of sent requests.
+//
+// Example:
+//
+//	result := e.GET("/health").Repeat(100).Expect()
+//	result.Count().IsEqual(100)
+func (lr *LoadResult) Count() *Number {
+	opChain := lr.chain.enter("Count()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, 0)
+	}
+
+	return newNumber(opChain, float64(len(lr.samples)))
+}
+
+// StatusCount returns a new Number instance with number of responses
+// with given status code.
+//
+// Example:
+//
+//	result := e.GET("/health").Repeat(100).Expect()
+//	result.StatusCount(http.StatusOK).IsEqual(100)
+//	result.StatusCount(http.StatusTooManyRequests).IsEqual(0)
+func (lr *LoadResult) StatusCount(code int) *Number {
+	opChain := lr.chain.enter("StatusCount(%d)", code)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, 0)
+	}
+
+	n := 0
+	for _, s := range lr.samples {
+		if s.Error == nil && s.StatusCode == code {
+			n++
+		}
+	}
+
+	return newNumber(opChain, float64(n))
+}
+
+// ErrorRate returns a new Number instance with fraction of failed requests,
+// from 0 to 1.
+//
+// Request is considered failed if it returned transport error, or if
+// response status code is 5xx (see LoadSample.Failed).
+//
+// Example:
+//
+//	result := e.GET("/health").Repeat(100).Expect()
+//	result.ErrorRate().Le(0.01)
+func (lr *LoadResult) ErrorRate() *Number {
+	opChain := lr.chain.enter("ErrorRate()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, 0)
+	}
+
+	if len(lr.samples) == 0 {
+		return newNumber(opChain, 0)
+	}
+
+	n := 0
+	for _, s := range lr.samples {
+		if s.Failed() {
+			n++
+		}
+	}
+
+	return newNumber(opChain, float64(n)/float64(len(lr.samples)))
+}
+
+// Latency returns a new Duration instance with given latency percentile.
+//
+// percentile should be in range (0; 100]. For example, Latency(50) returns
+// median, and Latency(100) returns maximum latency. Percentile is computed
+// using nearest-rank method over all requests, including failed ones.
+//
+// Example:
+//
+//	result := e.GET("/health").Repeat(100).Expect()
+//	result.Latency(50).Le(20 * time.Millisecond)
+//	result.Latency(99).Le(200 * time.Millisecond)
+func (lr *LoadResult) Latency(percentile float64) *Duration {
+	opChain := lr.chain.enter("Latency(%v)", percentile)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newDuration(opChain, nil)
+	}
+
+	if !(percentile > 0 && percentile <= 100) {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected percentile %v, expected value in range (0; 100]",
+					percentile),
+			},
+		})
+		return newDuration(opChain, nil)
+	}
+
+	if len(lr.samples) == 0 {
+		return newDuration(opChain, nil)
+	}
+
+	latencies := make([]time.Duration, 0, len(lr.samples))
+	for _, s := range lr.samples {
+		latencies = append(latencies, s.Latency)
+	}
+
+	sort.Slice(latencies, func(i, j int) bool {
+		return latencies[i] < latencies[j]
+	})
+
+	rank := int(math.Ceil(percentile / 100 * float64(len(latencies))))
+	if rank < 1 {
+		rank = 1
+	}
+
+	value := latencies[rank-1]
+
+	return newDuration(opChain, &value)
+}
+
+// Duration returns a new Duration instance with total wall-clock time
+// spent on sending all requests.
+//
+// Example:
+//
+//	result := e.GET("/health").Repeat(100).Concurrency(10).Expect()
+//	result.Duration().Le(5 * time.Second)
+func (lr *LoadResult) Duration() *Duration {
+	opChain := lr.chain.enter("Duration()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newDuration(opChain, nil)
+	}
+
+	duration := lr.duration
+
+	return newDuration(opChain, &duration)
+}
@@ -0,0 +1,234 @@
+package httpexpect
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepeat_FailedChain(t *testing.T) {
+	reporter := newMockReporter(t)
+	config := newMockConfig(reporter)
+	chain := newChainWithDefaults("test", reporter, flagFailed)
+
+	req := newRequest(chain, config, "GET", "")
+
+	rr := req.Repeat(10)
+	rr.chain.assert(t, failure)
+
+	rr.Concurrency(2)
+
+	result := rr.Expect()
+	result.chain.assert(t, failure)
+
+	result.Alias("foo")
+	result.Count().chain.assert(t, failure)
+	result.StatusCount(http.StatusOK).chain.assert(t, failure)
+	result.ErrorRate().chain.assert(t, failure)
+	result.Latency(50).chain.assert(t, failure)
+	result.Duration().chain.assert(t, failure)
+
+	assert.Nil(t, result.Raw())
+}
+
+func TestRepeat_Basic(t *testing.T) {
+	var count int32
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&count, 1)
+
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != "request body" || r.Header.Get("X-Test") != "value" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		switch {
+		case n%10 == 0:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	})
+
+	reporter := newMockReporter(t)
+
+	config := Config{
+		BaseURL:  "http://example.com",
+		Reporter: reporter,
+		Client: &http.Client{
+			Transport: NewBinder(handler),
+		},
+	}
+
+	req := NewRequestC(config, "POST", "/path").
+		WithHeader("X-Test", "value").
+		WithText("request body")
+
+	result := req.Repeat(50).Concurrency(5).Expect()
+	result.chain.assert(t, success)
+
+	assert.Equal(t, int32(50), atomic.LoadInt32(&count))
+	assert.Equal(t, 50, len(result.Raw()))
+
+	result.Count().IsEqual(50)
+	result.StatusCount(http.StatusOK).IsEqual(45)
+	result.StatusCount(http.StatusServiceUnavailable).IsEqual(5)
+	result.StatusCount(http.StatusBadRequest).IsEqual(0)
+	result.ErrorRate().IsEqual(0.1)
+	result.Latency(50).Le(time.Second)
+	result.Latency(100).Ge(0)
+	result.Duration().Le(10 * time.Second)
+
+	result.chain.assert(t, success)
+
+	t.Run("expect after repeat", func(t *testing.T) {
+		req.Expect().chain.assert(t, failure)
+	})
+}
+
+func TestRepeat_Concurrency(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		current int
+		peak    int
+	)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		current++
+		if current > peak {
+			peak = current
+		}
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		current--
+		mu.Unlock()
+	})
+
+	config := Config{
+		BaseURL:  "http://example.com",
+		Reporter: newMockReporter(t),
+		Client: &http.Client{
+			Transport: NewBinder(handler),
+		},
+	}
+
+	t.Run("sequential", func(t *testing.T) {
+		peak = 0
+
+		result := NewRequestC(config, "GET", "/").Repeat(5).Expect()
+		result.chain.assert(t, success)
+
+		assert.Equal(t, 1, peak)
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		peak = 0
+
+		result := NewRequestC(config, "GET", "/").Repeat(8).Concurrency(4).Expect()
+		result.chain.assert(t, success)
+
+		assert.LessOrEqual(t, peak, 4)
+		assert.Greater(t, peak, 1)
+	})
+}
+
+func TestRepeat_Errors(t *testing.T) {
+	config := Config{
+		BaseURL:  "http://example.com",
+		Reporter: newMockReporter(t),
+		Client: &mockClient{
+			err: errors.New("test error"),
+		},
+	}
+
+	result := NewRequestC(config, "GET", "/").Repeat(4).Expect()
+	result.chain.assert(t, success)
+
+	for _, s := range result.Raw() {
+		assert.Error(t, s.Error)
+		assert.True(t, s.Failed())
+	}
+
+	result.ErrorRate().IsEqual(1)
+	result.StatusCount(0).IsEqual(0)
+	result.chain.assert(t, success)
+}
+
+func TestRepeat_Latency(t *testing.T) {
+	samples := []LoadSample{}
+	for i := 1; i <= 10; i++ {
+		samples = append(samples, LoadSample{
+			StatusCode: http.StatusOK,
+			Latency:    time.Duration(i) * time.Millisecond,
+		})
+	}
+
+	cases := []struct {
+		percentile float64
+		expected   time.Duration
+	}{
+		{1, 1 * time.Millisecond},
+		{10, 1 * time.Millisecond},
+		{50, 5 * time.Millisecond},
+		{90, 9 * time.Millisecond},
+		{95, 10 * time.Millisecond},
+		{100, 10 * time.Millisecond},
+	}
+
+	for _, tc := range cases {
+		result := newLoadResult(newMockChain(t), samples, 0)
+
+		latency := result.Latency(tc.percentile)
+		latency.chain.assert(t, success)
+
+		assert.Equal(t, tc.expected, latency.Raw())
+	}
+
+	for _, percentile := range []float64{0, -1, 101} {
+		result := newLoadResult(newMockChain(t), samples, 0)
+
+		result.Latency(percentile).chain.assert(t, failure)
+	}
+}
+
+func TestRepeat_Usage(t *testing.T) {
+	config := Config{
+		BaseURL:  "http://example.com",
+		Reporter: newMockReporter(t),
+		Client:   &mockClient{},
+	}
+
+	t.Run("zero count", func(t *testing.T) {
+		rr := NewRequestC(config, "GET", "/").Repeat(0)
+		rr.chain.assert(t, failure)
+	})
+
+	t.Run("zero concurrency", func(t *testing.T) {
+		rr := NewRequestC(config, "GET", "/").Repeat(1).Concurrency(0)
+		rr.chain.assert(t, failure)
+	})
+
+	t.Run("websocket", func(t *testing.T) {
+		rr := NewRequestC(config, "GET", "/").WithWebsocketUpgrade().Repeat(1)
+		rr.chain.assert(t, failure)
+	})
+
+	t.Run("repeat after expect", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/")
+		req.Expect()
+
+		rr := req.Repeat(1)
+		rr.chain.assert(t, failure)
+	})
+}
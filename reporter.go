@@ -72,3 +72,18 @@ func NewPanicReporter() *PanicReporter {
 func (r *PanicReporter) Errorf(message string, args ...interface{}) {
 	panic(fmt.Sprintf(message, args...))
 }
+
+// DetachedReporter is a struct that implements the Reporter interface
+// and ignores all failures.
+// Useful when assertions are used outside of tests, e.g. in health
+// checks, and failures are inspected using Response.Result.
+type DetachedReporter struct{}
+
+// NewDetachedReporter returns a new DetachedReporter object.
+func NewDetachedReporter() *DetachedReporter {
+	return &DetachedReporter{}
+}
+
+// Errorf implements Reporter.Errorf.
+func (r *DetachedReporter) Errorf(message string, args ...interface{}) {
+}
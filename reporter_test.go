@@ -63,3 +63,11 @@ func TestReporter_PanicReporter(t *testing.T) {
 		reporter.Errorf("test")
 	})
 }
+
+func TestReporter_DetachedReporter(t *testing.T) {
+	reporter := NewDetachedReporter()
+
+	assert.NotPanics(t, func() {
+		reporter.Errorf("test")
+	})
+}
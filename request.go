@@ -3,13 +3,14 @@ package httpexpect
 import (
 	"bytes"
 	"context"
-	"encoding/json"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"net"
 	"net/http"
+	"net/http/httptrace"
 	"net/url"
 	"os"
 	"reflect"
@@ -23,6 +24,8 @@ import (
 	"github.com/google/go-querystring/query"
 	"github.com/gorilla/websocket"
 	"github.com/imkira/go-interpol"
+	"github.com/valyala/fasthttp"
+	"golang.org/x/net/http/httpguts"
 )
 
 // Request provides methods to incrementally build http.Request object,
@@ -36,18 +39,36 @@ type Request struct {
 	redirectPolicy RedirectPolicy
 	maxRedirects   int
 
-	retryPolicy   RetryPolicy
-	maxRetries    int
-	minRetryDelay time.Duration
-	maxRetryDelay time.Duration
-	sleepFn       func(d time.Duration) <-chan time.Time
+	retryPolicy    RetryPolicy
+	maxRetries     int
+	maxConnRetries int
+	minRetryDelay  time.Duration
+	maxRetryDelay  time.Duration
+	sleepFn        func(d time.Duration) <-chan time.Time
 
 	timeout time.Duration
 
+	bodyThrottle       int64
+	bodyInterrupt      bool
+	bodyInterruptAfter int64
+	clientAbort        bool
+	clientAbortAfter   time.Duration
+
+	uploadProgress   ProgressFunc
+	downloadProgress ProgressFunc
+	transfer         *transferStats
+
+	cache *ResponseCache
+
 	httpReq *http.Request
 	path    string
 	query   url.Values
 
+	// Set by Expect.FromHAR to keep recorded path escaping and
+	// query as is.
+	pathEscaped bool
+	rawQuery    string
+
 	form        url.Values
 	formbuf     *bytes.Buffer
 	multipart   *multipart.Writer
@@ -58,10 +79,41 @@ type Request struct {
 	forceType    bool
 	expectCalled bool
 
+	usageErrors      []error
+	pathProviders    map[string]bool
+	pathInterpolated bool
+
 	wsUpgrade bool
 
-	transformers []func(*http.Request)
-	matchers     []func(*Response)
+	wsHandshakeTimeout time.Duration
+
+	transformers  []func(*http.Request)
+	matchers      []func(*Response)
+	providers     []func(opChain *chain)
+	completeHooks []func(RequestStats)
+
+	skipDefaultAssertions bool
+	strictTransfer        bool
+
+	debug bool
+
+	telemetry *requestTelemetry
+
+	connStats *connStats
+
+	attempts    int
+	connRetries int
+	stats       *RequestStats
+
+	namePrefix string
+
+	origin *Expect
+
+	requestID string
+
+	failures *failureLog
+
+	goroutine uint64
 }
 
 // Deprecated: use NewRequestC instead.
@@ -113,6 +165,9 @@ func newRequest(
 ) *Request {
 	config.validate()
 
+	config.Clock = clockOrDefault(config.Clock)
+	config.JSONEncoder = jsonEncoderOrDefault(config.JSONEncoder)
+
 	r := &Request{
 		config: config,
 		chain:  parent.clone(),
@@ -124,14 +179,29 @@ func newRequest(
 		maxRetries:    0,
 		minRetryDelay: time.Millisecond * 50,
 		maxRetryDelay: time.Second * 5,
-		sleepFn: func(d time.Duration) <-chan time.Time {
-			return time.After(d)
-		},
+		sleepFn:       config.Clock.After,
+
+		strictTransfer: config.StrictTransfer,
+
 		multipartFn: func(w io.Writer) *multipart.Writer {
-			return multipart.NewWriter(w)
+			mw := multipart.NewWriter(w)
+			if config.RandSource != nil {
+				_ = mw.SetBoundary(config.RandSource.boundary())
+			}
+			return mw
 		},
 	}
 
+	if config.Parallel {
+		r.goroutine = goroutineID()
+	}
+
+	r.telemetry = newRequestTelemetry(config.Telemetry, config.Redactor)
+
+	r.failures = &failureLog{}
+
+	r.namePrefix = r.chain.requestName()
+
 	opChain := r.chain.enter("")
 	defer opChain.leave()
 
@@ -145,6 +215,8 @@ func newRequest(
 
 func (r *Request) initPath(opChain *chain, path string, pathargs ...interface{}) {
 	if len(pathargs) != 0 {
+		r.pathInterpolated = true
+
 		var n int
 
 		var err error
@@ -213,10 +285,45 @@ func (r *Request) Alias(name string) *Request {
 	return r
 }
 
+// Warn makes failures of this request and its response to be reported
+// as warnings.
+//
+// Failures get SeverityWarning. They are printed using Config.WarningLogger,
+// but don't cause test failure. See also Expect.Warn and Response.Warn.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "/path")
+//	req.Warn()
+//	req.Expect().Header("X-Request-ID").NotEmpty()
+func (r *Request) Warn() *Request {
+	opChain := r.chain.enter("Warn()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "Warn()") {
+		return r
+	}
+
+	r.chain.setSeverity(SeverityWarning)
+
+	return r
+}
+
 // WithName sets convenient request name.
-// This name will be included in assertion reports for this request.
+// This name will be included in assertion reports for this request
+// and its response, and in RequestStats passed to OnComplete callbacks.
 // It does not affect assertion chain path, inlike Alias.
 //
+// If request is sent from a Scenario step, name is prefixed with names
+// of scenario and step, e.g. "user lifecycle / create / Login Request".
+//
 // Example:
 //
 //	req := NewRequestC(config, "POST", "/api/login")
@@ -236,7 +343,7 @@ func (r *Request) WithName(name string) *Request {
 		return r
 	}
 
-	r.chain.setRequestName(name)
+	r.chain.setRequestName(joinRequestName(r.namePrefix, name))
 
 	return r
 }
@@ -278,8 +385,9 @@ func (r *Request) WithReporter(reporter Reporter) *Request {
 	}
 
 	handler := &DefaultAssertionHandler{
-		Reporter:  reporter,
-		Formatter: r.config.Formatter,
+		Reporter:      reporter,
+		Formatter:     r.config.Formatter,
+		WarningLogger: r.config.WarningLogger,
 	}
 	r.chain.setHandler(handler)
 
@@ -368,6 +476,106 @@ func (r *Request) WithMatcher(matcher func(*Response)) *Request {
 	return r
 }
 
+// WithoutDefaultAssertions disables Config.DefaultResponseAssertions for
+// this request.
+//
+// Matchers attached via Expect.Matcher and Request.WithMatcher are still
+// invoked.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "/crash")
+//	req.WithoutDefaultAssertions()
+//	req.Expect().Status(http.StatusInternalServerError)
+func (r *Request) WithoutDefaultAssertions() *Request {
+	opChain := r.chain.enter("WithoutDefaultAssertions()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithoutDefaultAssertions()") {
+		return r
+	}
+
+	r.skipDefaultAssertions = true
+
+	return r
+}
+
+// WithStrictTransfer enables or disables checks of HTTP transfer semantics
+// for response to this request, overriding Config.StrictTransfer.
+//
+// If enabled, response is checked using Response.HasStrictTransfer,
+// before default assertions and matchers.
+//
+// Example:
+//
+//	req := NewRequestC(config, "DELETE", "/users/1")
+//	req.WithStrictTransfer(true)
+//	req.Expect().Status(http.StatusNoContent)
+func (r *Request) WithStrictTransfer(enabled bool) *Request {
+	opChain := r.chain.enter("WithStrictTransfer()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithStrictTransfer()") {
+		return r
+	}
+
+	r.strictTransfer = enabled
+
+	return r
+}
+
+// WithDebug enables printing of this request and its response by printers
+// that support sampling, like LogPrinter, regardless of their LogMode.
+//
+// This is handy when LogPrinter is configured to print only failures or
+// a sample of requests, and you're interested in a particular request.
+//
+// Example:
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		Reporter: httpexpect.NewAssertReporter(t),
+//		Printers: []httpexpect.Printer{
+//			&httpexpect.LogPrinter{Logger: t, Mode: httpexpect.LogDebug},
+//		},
+//	})
+//
+//	e.GET("/path").WithDebug().
+//		Expect().
+//		Status(http.StatusOK)
+func (r *Request) WithDebug() *Request {
+	opChain := r.chain.enter("WithDebug()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithDebug()") {
+		return r
+	}
+
+	r.debug = true
+
+	return r
+}
+
 // WithTransformer attaches a transform to the Request.
 // All attachhed transforms are invoked in the Expect methods for
 // http.Request struct, after it's encoded and before it's sent.
@@ -455,6 +663,10 @@ func (r *Request) WithClient(client Client) *Request {
 // because the client may contain some state shared among requests like a cookie
 // jar. Otherwise, the whole client is overwritten with a new client.
 //
+// WebSocket dialer is also overwritten with NewWebsocketDialer, so websocket
+// requests (see WithWebsocketUpgrade) are sent to the handler via in-memory
+// connection, without a listener.
+//
 // Example:
 //
 //	req := NewRequestC(config, "GET", "/path")
@@ -484,18 +696,65 @@ func (r *Request) WithHandler(handler http.Handler) *Request {
 		return r
 	}
 
+	r.setTransport(NewBinder(handler))
+	r.config.WebsocketDialer = NewWebsocketDialer(handler)
+
+	return r
+}
+
+// WithFastHandler configures client to invoke the given fasthttp handler
+// directly.
+//
+// It's like WithHandler, but uses FastBinder and NewFastWebsocketDialer
+// instead of Binder and NewWebsocketDialer, so services built on top of
+// fasthttp can be tested without starting a server.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "/path")
+//	req.WithFastHandler(myServer.someFastHandler)
+func (r *Request) WithFastHandler(handler fasthttp.RequestHandler) *Request {
+	opChain := r.chain.enter("WithFastHandler()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithFastHandler()") {
+		return r
+	}
+
+	if handler == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil argument"),
+			},
+		})
+		return r
+	}
+
+	r.setTransport(NewFastBinder(handler))
+	r.config.WebsocketDialer = NewFastWebsocketDialer(handler)
+
+	return r
+}
+
+func (r *Request) setTransport(transport http.RoundTripper) {
 	if client, ok := r.config.Client.(*http.Client); ok {
 		clientCopy := *client
-		clientCopy.Transport = NewBinder(handler)
+		clientCopy.Transport = transport
 		r.config.Client = &clientCopy
 	} else {
 		r.config.Client = &http.Client{
-			Transport: NewBinder(handler),
+			Transport: transport,
 			Jar:       NewCookieJar(),
 		}
 	}
-
-	return r
 }
 
 // WithContext sets the context.
@@ -541,23 +800,22 @@ func (r *Request) WithContext(ctx context.Context) *Request {
 	return r
 }
 
-// WithTimeout sets a timeout duration for the request.
-//
-// Will attach to the request a context.WithTimeout around the Config.Context
-// or any context set WithContext. If these are nil, the new context will be
-// created on top of a context.Background().
+// WithContextValue adds key-value pair to the request context.
 //
-// Any retries will continue after one is cancelled.
-// If the intended behavior is to stop any further retries, use WithContext or
-// Config.Context.
+// Value is stored in a context derived from Config.Context or from the
+// context set by WithContext, so it should be called after WithContext.
+// Handler invoked via Binder (see WithHandler) can retrieve the value
+// from its request context. This is useful to test handlers that rely
+// on values set by middleware, like authenticated user.
 //
 // Example:
 //
 //	req := NewRequestC(config, "GET", "/path")
-//	req.WithTimeout(time.Duration(3)*time.Second)
+//	req.WithHandler(handler)
+//	req.WithContextValue(userKey{}, "john")
 //	req.Expect().Status(http.StatusOK)
-func (r *Request) WithTimeout(timeout time.Duration) *Request {
-	opChain := r.chain.enter("WithTimeout()")
+func (r *Request) WithContextValue(key, value interface{}) *Request {
+	opChain := r.chain.enter("WithContextValue()")
 	defer opChain.leave()
 
 	r.mu.Lock()
@@ -567,69 +825,47 @@ func (r *Request) WithTimeout(timeout time.Duration) *Request {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithTimeout()") {
+	if !r.checkOrder(opChain, "WithContextValue()") {
 		return r
 	}
 
-	r.timeout = timeout
-
-	return r
-}
-
-// RedirectPolicy defines how redirection responses are handled.
-//
-// Status codes 307, 308 require resending body. They are followed only if
-// redirect policy is FollowAllRedirects.
-//
-// Status codes 301, 302, 303 don't require resending body. On such redirect,
-// http.Client automatically switches HTTP method to GET, if it's not GET or
-// HEAD already. These redirects are followed if redirect policy is either
-// FollowAllRedirects or FollowRedirectsWithoutBody.
-//
-// Default redirect policy is FollowRedirectsWithoutBody.
-type RedirectPolicy int
-
-const (
-	// indicates that WithRedirectPolicy was not called
-	defaultRedirectPolicy RedirectPolicy = iota
+	if key == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil key"),
+			},
+		})
+		return r
+	}
 
-	// DontFollowRedirects forbids following any redirects.
-	// Redirection response is returned to the user and can be inspected.
-	DontFollowRedirects
+	ctx := r.config.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
 
-	// FollowAllRedirects allows following any redirects, including those
-	// which require resending body.
-	FollowAllRedirects
+	r.config.Context = context.WithValue(ctx, key, value)
 
-	// FollowRedirectsWithoutBody allows following only redirects which
-	// don't require resending body.
-	// If redirect requires resending body, it's not followed, and redirection
-	// response is returned instead.
-	FollowRedirectsWithoutBody
-)
+	return r
+}
 
-// WithRedirectPolicy sets policy for redirection response handling.
-//
-// How redirect is handled depends on both response status code and
-// redirect policy. See comments for RedirectPolicy for details.
+// WithTimeout sets a timeout duration for the request.
 //
-// Default redirect policy is defined by Client implementation.
-// Default behavior of http.Client corresponds to FollowRedirectsWithoutBody.
+// Will attach to the request a context.WithTimeout around the Config.Context
+// or any context set WithContext. If these are nil, the new context will be
+// created on top of a context.Background().
 //
-// This method can be used only if Client interface points to
-// *http.Client struct, since we rely on it in redirect handling.
+// Any retries will continue after one is cancelled.
+// If the intended behavior is to stop any further retries, use WithContext or
+// Config.Context.
 //
 // Example:
 //
-//	req1 := NewRequestC(config, "POST", "/path")
-//	req1.WithRedirectPolicy(FollowAllRedirects)
-//	req1.Expect().Status(http.StatusOK)
-//
-//	req2 := NewRequestC(config, "POST", "/path")
-//	req2.WithRedirectPolicy(DontFollowRedirects)
-//	req2.Expect().Status(http.StatusPermanentRedirect)
-func (r *Request) WithRedirectPolicy(policy RedirectPolicy) *Request {
-	opChain := r.chain.enter("WithRedirectPolicy()")
+//	req := NewRequestC(config, "GET", "/path")
+//	req.WithTimeout(time.Duration(3)*time.Second)
+//	req.Expect().Status(http.StatusOK)
+func (r *Request) WithTimeout(timeout time.Duration) *Request {
+	opChain := r.chain.enter("WithTimeout()")
 	defer opChain.leave()
 
 	r.mu.Lock()
@@ -639,32 +875,33 @@ func (r *Request) WithRedirectPolicy(policy RedirectPolicy) *Request {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithRedirectPolicy()") {
+	if !r.checkOrder(opChain, "WithTimeout()") {
 		return r
 	}
 
-	r.redirectPolicy = policy
+	r.timeout = timeout
 
 	return r
 }
 
-// WithMaxRedirects sets maximum number of redirects to follow.
-//
-// If the number of redirects exceedes this limit, request is failed.
+// WithCache enables caching of response in given ResponseCache.
 //
-// Default limit is defined by Client implementation.
-// Default behavior of http.Client corresponds to maximum of 10-1 redirects.
+// If cache already has a response for request with the same method, URL,
+// and headers, request is not sent, and a copy of cached response is
+// returned. Otherwise, request is sent and its response is stored in
+// cache. See ResponseCache for details.
 //
-// This method can be used only if Client interface points to
-// *http.Client struct, since we rely on it in redirect handling.
+// Only GET and HEAD requests can be cached.
 //
 // Example:
 //
-//	req1 := NewRequestC(config, "POST", "/path")
-//	req1.WithMaxRedirects(1)
-//	req1.Expect().Status(http.StatusOK)
-func (r *Request) WithMaxRedirects(maxRedirects int) *Request {
-	opChain := r.chain.enter("WithMaxRedirects()")
+//	cache := NewResponseCache()
+//
+//	req := NewRequestC(config, "GET", "/flags")
+//	req.WithCache(cache)
+//	req.Expect().Status(http.StatusOK)
+func (r *Request) WithCache(cache *ResponseCache) *Request {
+	opChain := r.chain.enter("WithCache()")
 	defer opChain.leave()
 
 	r.mu.Lock()
@@ -674,14 +911,287 @@ func (r *Request) WithMaxRedirects(maxRedirects int) *Request {
 		return r
 	}
 
-	if !r.checkOrder(opChain, "WithMaxRedirects()") {
+	if !r.checkOrder(opChain, "WithCache()") {
 		return r
 	}
 
-	if maxRedirects < 0 {
+	if cache == nil {
 		opChain.fail(AssertionFailure{
-			Type:   AssertValid,
-			Actual: &AssertionValue{maxRedirects},
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil argument"),
+			},
+		})
+		return r
+	}
+
+	if r.httpReq.Method != http.MethodGet && r.httpReq.Method != http.MethodHead {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected WithCache() call for %s request:"+
+					" only GET and HEAD requests can be cached", r.httpReq.Method),
+			},
+		})
+		return r
+	}
+
+	r.cache = cache
+
+	return r
+}
+
+// WithBodyThrottle limits the rate at which request body is sent.
+//
+// Body is sent in small chunks with pauses between them, so that on average
+// no more than bytesPerSecond bytes are sent per second. This is useful to
+// test server read timeouts and buffering behavior of reverse proxies.
+//
+// Throttling is applied to every attempt, if request is retried. Sending
+// stops early if request context is cancelled, e.g. by WithTimeout or
+// WithClientAbort.
+//
+// Example:
+//
+//	req := NewRequestC(config, "POST", "/upload")
+//	req.WithBytes(data)
+//	req.WithBodyThrottle(1024)
+//	req.Expect().Status(http.StatusRequestTimeout)
+func (r *Request) WithBodyThrottle(bytesPerSecond int64) *Request {
+	opChain := r.chain.enter("WithBodyThrottle()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithBodyThrottle()") {
+		return r
+	}
+
+	if bytesPerSecond <= 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected non-positive argument"),
+			},
+		})
+		return r
+	}
+
+	r.bodyThrottle = bytesPerSecond
+
+	return r
+}
+
+// WithBodyInterrupt interrupts sending request body after given number
+// of bytes.
+//
+// First afterBytes bytes of the body are sent, and then reading body fails,
+// so the upload is aborted in the middle. Content-Length, if known, is not
+// changed, so server sees a truncated body. This is useful to test how
+// server handles interrupted uploads.
+//
+// When request is sent over network, client usually fails to send request,
+// and ExpectError should be used instead of Expect. When request is sent to
+// in-process handler (see WithHandler), handler gets an error when reading
+// the body, and the response written by handler can be inspected as usual.
+//
+// If body is shorter than afterBytes, it is sent completely.
+//
+// Example:
+//
+//	req := NewRequestC(config, "POST", "/upload")
+//	req.WithBytes(largeFile)
+//	req.WithBodyInterrupt(1024)
+//	req.ExpectError().Contains("request body interrupted")
+func (r *Request) WithBodyInterrupt(afterBytes int64) *Request {
+	opChain := r.chain.enter("WithBodyInterrupt()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithBodyInterrupt()") {
+		return r
+	}
+
+	if afterBytes < 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected negative argument"),
+			},
+		})
+		return r
+	}
+
+	r.bodyInterrupt = true
+	r.bodyInterruptAfter = afterBytes
+
+	return r
+}
+
+// WithClientAbort aborts request if it's not completed after given duration.
+//
+// Unlike WithTimeout, which is intended to limit waiting time, WithClientAbort
+// simulates a client which drops connection in the middle of the exchange,
+// e.g. when user closes browser tab. Request context is cancelled and
+// connection is closed. Aborted requests are not retried.
+//
+// If response is received before the deadline, it is returned as usual.
+// Otherwise, sending request fails, and ExpectError should be used instead
+// of Expect to inspect the error.
+//
+// Example:
+//
+//	req := NewRequestC(config, "POST", "/slow")
+//	req.WithClientAbort(100 * time.Millisecond)
+//	req.ExpectError().Contains("request aborted by client")
+func (r *Request) WithClientAbort(after time.Duration) *Request {
+	opChain := r.chain.enter("WithClientAbort()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithClientAbort()") {
+		return r
+	}
+
+	if after < 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected negative argument"),
+			},
+		})
+		return r
+	}
+
+	r.clientAbort = true
+	r.clientAbortAfter = after
+
+	return r
+}
+
+// RedirectPolicy defines how redirection responses are handled.
+//
+// Status codes 307, 308 require resending body. They are followed only if
+// redirect policy is FollowAllRedirects.
+//
+// Status codes 301, 302, 303 don't require resending body. On such redirect,
+// http.Client automatically switches HTTP method to GET, if it's not GET or
+// HEAD already. These redirects are followed if redirect policy is either
+// FollowAllRedirects or FollowRedirectsWithoutBody.
+//
+// Default redirect policy is FollowRedirectsWithoutBody.
+type RedirectPolicy int
+
+const (
+	// indicates that WithRedirectPolicy was not called
+	defaultRedirectPolicy RedirectPolicy = iota
+
+	// DontFollowRedirects forbids following any redirects.
+	// Redirection response is returned to the user and can be inspected.
+	DontFollowRedirects
+
+	// FollowAllRedirects allows following any redirects, including those
+	// which require resending body.
+	FollowAllRedirects
+
+	// FollowRedirectsWithoutBody allows following only redirects which
+	// don't require resending body.
+	// If redirect requires resending body, it's not followed, and redirection
+	// response is returned instead.
+	FollowRedirectsWithoutBody
+)
+
+// WithRedirectPolicy sets policy for redirection response handling.
+//
+// How redirect is handled depends on both response status code and
+// redirect policy. See comments for RedirectPolicy for details.
+//
+// Default redirect policy is defined by Client implementation.
+// Default behavior of http.Client corresponds to FollowRedirectsWithoutBody.
+//
+// This method can be used only if Client interface points to
+// *http.Client struct, since we rely on it in redirect handling.
+//
+// Example:
+//
+//	req1 := NewRequestC(config, "POST", "/path")
+//	req1.WithRedirectPolicy(FollowAllRedirects)
+//	req1.Expect().Status(http.StatusOK)
+//
+//	req2 := NewRequestC(config, "POST", "/path")
+//	req2.WithRedirectPolicy(DontFollowRedirects)
+//	req2.Expect().Status(http.StatusPermanentRedirect)
+func (r *Request) WithRedirectPolicy(policy RedirectPolicy) *Request {
+	opChain := r.chain.enter("WithRedirectPolicy()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithRedirectPolicy()") {
+		return r
+	}
+
+	r.redirectPolicy = policy
+
+	return r
+}
+
+// WithMaxRedirects sets maximum number of redirects to follow.
+//
+// If the number of redirects exceedes this limit, request is failed.
+//
+// Default limit is defined by Client implementation.
+// Default behavior of http.Client corresponds to maximum of 10-1 redirects.
+//
+// This method can be used only if Client interface points to
+// *http.Client struct, since we rely on it in redirect handling.
+//
+// Example:
+//
+//	req1 := NewRequestC(config, "POST", "/path")
+//	req1.WithMaxRedirects(1)
+//	req1.Expect().Status(http.StatusOK)
+func (r *Request) WithMaxRedirects(maxRedirects int) *Request {
+	opChain := r.chain.enter("WithMaxRedirects()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithMaxRedirects()") {
+		return r
+	}
+
+	if maxRedirects < 0 {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{maxRedirects},
 			Errors: []error{
 				errors.New("invalid negative argument"),
 			},
@@ -806,6 +1316,59 @@ func (r *Request) WithMaxRetries(maxRetries int) *Request {
 	return r
 }
 
+// WithMaxConnRetries sets maximum number of retry attempts for
+// connection-level errors.
+//
+// Connection-level errors are errors that happen before any HTTP response
+// is received: connection refused, connection reset, unexpected EOF, and
+// TLS handshake failure. Such errors are retried up to maxConnRetries times
+// regardless of retry policy, and these retries are counted separately from
+// retries allowed by WithMaxRetries.
+//
+// Other errors and HTTP error statuses are still handled by retry policy
+// and WithMaxRetries. Delay between attempts is defined by WithRetryDelay()
+// for both kinds of retries.
+//
+// Default number of connection retries is zero, i.e. connection-level
+// errors are handled by retry policy as any other errors.
+//
+// Example:
+//
+//	req := NewRequestC(config, "POST", "/path")
+//	req.WithMaxConnRetries(3) // service may be still starting
+//	req.WithMaxRetries(1)     // and may respond with 503 once
+//	req.Expect().Status(http.StatusOK)
+func (r *Request) WithMaxConnRetries(maxConnRetries int) *Request {
+	opChain := r.chain.enter("WithMaxConnRetries()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithMaxConnRetries()") {
+		return r
+	}
+
+	if maxConnRetries < 0 {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{maxConnRetries},
+			Errors: []error{
+				errors.New("invalid negative argument"),
+			},
+		})
+		return r
+	}
+
+	r.maxConnRetries = maxConnRetries
+
+	return r
+}
+
 // WithRetryDelay sets minimum and maximum delay between retries.
 //
 // If multiple retry attempts happen, delay between attempts starts from
@@ -866,6 +1429,13 @@ func (r *Request) WithRetryDelay(minDelay, maxDelay time.Duration) *Request {
 // the Websocket instance. This instance can be used to send messages to the
 // server, to inspect the received messages, and to close the websocket.
 //
+// Handshake request includes the same headers as regular requests, including
+// Config.DefaultHeaders. If Config.Client is *http.Client with cookie jar,
+// cookies from the jar are sent with handshake request, and cookies set by
+// handshake response are stored into the jar, so HTTP and websocket requests
+// share authentication state. This is not done if dialer is *websocket.Dialer
+// with its own Jar.
+//
 // Example:
 //
 //	req := NewRequestC(config, "GET", "/path")
@@ -936,6 +1506,49 @@ func (r *Request) WithWebsocketDialer(dialer WebsocketDialer) *Request {
 	return r
 }
 
+// WithWebsocketHandshakeTimeout sets timeout for websocket handshake.
+//
+// Zero timeout means no timeout. Timeout is applied per attempt, and
+// requires dialer implementing DialContext method, like websocket.Dialer.
+// Otherwise, sending request fails.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "/path")
+//	req.WithWebsocketUpgrade()
+//	req.WithWebsocketHandshakeTimeout(5 * time.Second)
+//	ws := req.Expect().Status(http.StatusSwitchingProtocols).Websocket()
+//	defer ws.Disconnect()
+func (r *Request) WithWebsocketHandshakeTimeout(timeout time.Duration) *Request {
+	opChain := r.chain.enter("WithWebsocketHandshakeTimeout()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithWebsocketHandshakeTimeout()") {
+		return r
+	}
+
+	if timeout < 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected negative timeout"),
+			},
+		})
+		return r
+	}
+
+	r.wsHandshakeTimeout = timeout
+
+	return r
+}
+
 // WithPath substitutes named parameters in url path.
 //
 // value is converted to string using fmt.Sprint(). If there is no named
@@ -969,6 +1582,53 @@ func (r *Request) WithPath(key string, value interface{}) *Request {
 	return r
 }
 
+// WithPathFrom substitutes named parameter in url path with a value
+// from given provider.
+//
+// Unlike WithPath, value is evaluated when request is sent, i.e. during
+// Expect. This allows to build a request that depends on results of
+// previous requests or on environment without imperative extraction code.
+// See ValueProvider.
+//
+// Value is converted to string using fmt.Sprint(). If there is no named
+// parameter '{key}' in url path, or provider fails, failure is reported
+// and request is not sent.
+//
+// Example:
+//
+//	user := e.POST("/users").WithJSON(newUser).Expect()
+//
+//	e.GET("/users/{id}").
+//		WithPathFrom("id", FromResponse(user, "$.id")).
+//		Expect()
+func (r *Request) WithPathFrom(key string, provider ValueProvider) *Request {
+	opChain := r.chain.enter("WithPathFrom()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithPathFrom()") {
+		return r
+	}
+
+	if r.pathProviders == nil {
+		r.pathProviders = make(map[string]bool)
+	}
+	r.pathProviders[strings.ToLower(key)] = true
+	r.pathInterpolated = true
+
+	r.withProvider(opChain, provider, func(opChain *chain, value interface{}) {
+		r.withPath(opChain, key, value)
+	})
+
+	return r
+}
+
 // WithPathObject substitutes multiple named parameters in url path.
 //
 // object should be map or struct. If object is struct, it's converted
@@ -1037,17 +1697,15 @@ func (r *Request) WithPathObject(object interface{}) *Request {
 }
 
 func (r *Request) withPath(opChain *chain, key string, value interface{}) {
+	r.pathInterpolated = true
+
 	found := false
 
 	path, err := interpol.WithFunc(r.path, func(k string, w io.Writer) error {
 		if strings.EqualFold(k, key) {
 			if value == nil {
-				opChain.fail(AssertionFailure{
-					Type: AssertUsage,
-					Errors: []error{
-						fmt.Errorf("unexpected nil interpol argument %q", k),
-					},
-				})
+				r.usageError(opChain,
+					fmt.Errorf("unexpected nil interpol argument %q", k))
 			} else {
 				mustWrite(w, fmt.Sprint(value))
 				found = true
@@ -1073,12 +1731,8 @@ func (r *Request) withPath(opChain *chain, key string, value interface{}) {
 	}
 
 	if !found {
-		opChain.fail(AssertionFailure{
-			Type: AssertUsage,
-			Errors: []error{
-				fmt.Errorf("key %q not found in interpol string", key),
-			},
-		})
+		r.usageError(opChain,
+			fmt.Errorf("key %q not found in interpol string", key))
 		return
 	}
 
@@ -1128,6 +1782,45 @@ func (r *Request) WithQuery(key string, value interface{}) *Request {
 	return r
 }
 
+// WithQueryFrom adds query parameter to request URL with a value
+// from given provider.
+//
+// Unlike WithQuery, value is evaluated when request is sent, i.e. during
+// Expect. See ValueProvider.
+//
+// Value is converted to string using fmt.Sprint(). If provider fails,
+// failure is reported and request is not sent.
+//
+// Example:
+//
+//	e.GET("/orders").
+//		WithQueryFrom("user_id", FromEnv(e.Env(), "user_id")).
+//		Expect()
+func (r *Request) WithQueryFrom(key string, provider ValueProvider) *Request {
+	opChain := r.chain.enter("WithQueryFrom()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithQueryFrom()") {
+		return r
+	}
+
+	r.withProvider(opChain, provider, func(opChain *chain, value interface{}) {
+		if r.query == nil {
+			r.query = make(url.Values)
+		}
+		r.query.Add(key, fmt.Sprint(value))
+	})
+
+	return r
+}
+
 // WithQueryObject adds multiple query parameters to request URL.
 //
 // object is converted to query string using github.com/google/go-querystring
@@ -1324,40 +2017,181 @@ func (r *Request) WithHeaders(headers map[string]string) *Request {
 		return r
 	}
 
-	for k, v := range headers {
-		r.withHeader(k, v)
-	}
+	for k, v := range headers {
+		r.withHeader(opChain, k, v)
+	}
+
+	return r
+}
+
+// WithHeaderFrom adds header to request with a value from given provider.
+//
+// Unlike WithHeader, value is evaluated when request is sent, i.e. during
+// Expect. See ValueProvider.
+//
+// Value is converted to string using fmt.Sprint(). If provider fails,
+// failure is reported and request is not sent.
+//
+// Example:
+//
+//	login := e.POST("/login").WithJSON(credentials).Expect()
+//
+//	e.GET("/profile").
+//		WithHeaderFrom("X-Session", FromResponse(login, "$.session")).
+//		Expect()
+func (r *Request) WithHeaderFrom(k string, provider ValueProvider) *Request {
+	opChain := r.chain.enter("WithHeaderFrom()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithHeaderFrom()") {
+		return r
+	}
+
+	r.withProvider(opChain, provider, func(opChain *chain, value interface{}) {
+		r.withHeader(opChain, k, fmt.Sprint(value))
+	})
+
+	return r
+}
+
+// WithHeader adds given single header to request.
+//
+// Example:
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	req.WithHeader("Content-Type", "application/json")
+func (r *Request) WithHeader(k, v string) *Request {
+	opChain := r.chain.enter("WithHeader()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithHeader()") {
+		return r
+	}
+
+	r.withHeader(opChain, k, v)
+
+	return r
+}
+
+// WithGRPCMetadata adds gRPC metadata to request sent to grpc-gateway.
+//
+// grpc-gateway forwards headers with "Grpc-Metadata-" prefix to gRPC
+// service as metadata, with the prefix removed. This method adds such
+// header for given key.
+//
+// grpc-gateway runtime.ServeMux implements http.Handler, so it can be
+// tested in-process using WithHandler or Binder.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "/v1/users/1")
+//	req.WithHandler(gatewayMux)
+//	req.WithGRPCMetadata("tenant-id", "42")
+func (r *Request) WithGRPCMetadata(key, value string) *Request {
+	opChain := r.chain.enter("WithGRPCMetadata()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithGRPCMetadata()") {
+		return r
+	}
+
+	if key == "" {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected empty metadata key"),
+			},
+		})
+		return r
+	}
+
+	r.withHeader(opChain, grpcMetadataHeaderPrefix+key, value)
+
+	return r
+}
+
+const grpcMetadataHeaderPrefix = "Grpc-Metadata-"
+
+func (r *Request) withProvider(
+	opChain *chain,
+	provider ValueProvider,
+	apply func(opChain *chain, value interface{}),
+) {
+	if provider == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil provider"),
+			},
+		})
+		return
+	}
+
+	r.providers = append(r.providers, func(opChain *chain) {
+		value := provider.Provide(&Chain{opChain})
+		if opChain.failed() {
+			return
+		}
 
-	return r
-}
+		if value == nil {
+			opChain.fail(AssertionFailure{
+				Type: AssertUsage,
+				Errors: []error{
+					errors.New("unexpected nil value from provider"),
+				},
+			})
+			return
+		}
 
-// WithHeader adds given single header to request.
-//
-// Example:
-//
-//	req := NewRequestC(config, "PUT", "http://example.com/path")
-//	req.WithHeader("Content-Type", "application/json")
-func (r *Request) WithHeader(k, v string) *Request {
-	opChain := r.chain.enter("WithHeader()")
-	defer opChain.leave()
+		apply(opChain, value)
+	})
+}
 
-	r.mu.Lock()
-	defer r.mu.Unlock()
+// Evaluate providers registered by WithPathFrom and similar methods.
+func (r *Request) applyProviders(opChain *chain) bool {
+	for _, provide := range r.providers {
+		provide(opChain)
 
-	if opChain.failed() {
-		return r
+		if opChain.failed() {
+			return false
+		}
 	}
 
-	if !r.checkOrder(opChain, "WithHeader()") {
-		return r
-	}
+	return true
+}
 
-	r.withHeader(k, v)
+func (r *Request) withHeader(opChain *chain, k, v string) {
+	if !httpguts.ValidHeaderFieldName(k) {
+		r.usageError(opChain, fmt.Errorf("invalid header name %q", k))
+		return
+	}
 
-	return r
-}
+	if !httpguts.ValidHeaderFieldValue(v) {
+		r.usageError(opChain, fmt.Errorf("invalid value %q for header %q", v, k))
+		return
+	}
 
-func (r *Request) withHeader(k, v string) {
 	switch http.CanonicalHeaderKey(k) {
 	case "Host":
 		r.httpReq.Host = v
@@ -1494,6 +2328,82 @@ func (r *Request) WithHost(host string) *Request {
 	return r
 }
 
+// WithRemoteAddr sets remote address seen by the handler, e.g. "10.0.0.1:1234".
+//
+// It is used by Binder and FastBinder, which invoke handler directly
+// (see WithHandler), and overrides Binder.RemoteAddr. Real network clients
+// ignore it. This is useful to test middleware that relies on client
+// address, like rate limiters or IP filters.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "/path")
+//	req.WithHandler(handler)
+//	req.WithRemoteAddr("10.0.0.1:1234")
+func (r *Request) WithRemoteAddr(addr string) *Request {
+	opChain := r.chain.enter("WithRemoteAddr()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithRemoteAddr()") {
+		return r
+	}
+
+	r.httpReq.RemoteAddr = addr
+
+	return r
+}
+
+// WithTLSState sets TLS connection state seen by the handler.
+//
+// It is used by Binder and FastBinder, which invoke handler directly
+// (see WithHandler), and overrides Binder.TLS. Unlike Binder.TLS, it
+// is used regardless of URL scheme. Real network clients ignore it.
+// This is useful to test middleware that inspects client certificates.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "/path")
+//	req.WithHandler(handler)
+//	req.WithTLSState(&tls.ConnectionState{
+//		PeerCertificates: []*x509.Certificate{clientCert},
+//	})
+func (r *Request) WithTLSState(state *tls.ConnectionState) *Request {
+	opChain := r.chain.enter("WithTLSState()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithTLSState()") {
+		return r
+	}
+
+	if state == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil argument"),
+			},
+		})
+		return r
+	}
+
+	r.httpReq.TLS = state
+
+	return r
+}
+
 // WithProto sets HTTP protocol version.
 //
 // proto should have form of "HTTP/{major}.{minor}", e.g. "HTTP/1.1".
@@ -1672,7 +2582,7 @@ func (r *Request) WithJSON(object interface{}) *Request {
 		return r
 	}
 
-	b, err := json.Marshal(object)
+	b, err := r.config.JSONEncoder.Marshal(object)
 
 	if err != nil {
 		opChain.fail(AssertionFailure{
@@ -1692,6 +2602,108 @@ func (r *Request) WithJSON(object interface{}) *Request {
 	return r
 }
 
+// WithJSONTemplate is like WithJSON, but also replaces placeholders like
+// "{{faker.email}}" in string values with random values generated by
+// Config.Faker.
+//
+// Supported placeholders are: faker.first_name, faker.last_name,
+// faker.name, faker.word, faker.username, faker.email, faker.uuid, and
+// faker.int. Same placeholder is replaced with the same value within a
+// request. If string consists of "{{faker.int}}" only, it is replaced
+// with a number.
+//
+// If values map is given, generated values are stored into it under
+// placeholder name, e.g. "faker.email", so that they can be used in
+// later assertions.
+//
+// Example:
+//
+//	values := map[string]interface{}{}
+//
+//	req := NewRequestC(config, "POST", "http://example.com/users")
+//	req.WithJSONTemplate(map[string]interface{}{
+//		"email": "{{faker.email}}",
+//		"age":   "{{faker.int}}",
+//	}, values)
+//	req.Expect().JSON().Object().
+//		HasValue("email", values["faker.email"]).
+//		HasValue("age", values["faker.int"])
+func (r *Request) WithJSONTemplate(
+	object interface{}, values ...map[string]interface{},
+) *Request {
+	opChain := r.chain.enter("WithJSONTemplate()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithJSONTemplate()") {
+		return r
+	}
+
+	if len(values) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple values arguments"),
+			},
+		})
+		return r
+	}
+
+	b, err := r.config.JSONEncoder.Marshal(object)
+
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{object},
+			Errors: []error{
+				errors.New("invalid json object"),
+				err,
+			},
+		})
+		return r
+	}
+
+	faker := r.config.Faker
+	if faker == nil {
+		source := r.config.RandSource
+		if source == nil {
+			source = newRandomRandSource()
+		}
+		faker = newSourceFaker(source)
+	}
+
+	b, generated, err := faker.expandJSON(b)
+
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{object},
+			Errors: []error{
+				errors.New("invalid placeholder in json template"),
+				err,
+			},
+		})
+		return r
+	}
+
+	if len(values) != 0 && values[0] != nil {
+		for key, value := range generated {
+			values[0][key] = value
+		}
+	}
+
+	r.setType(opChain, "WithJSONTemplate()", "application/json; charset=utf-8", false)
+	r.setBody(opChain, "WithJSONTemplate()", bytes.NewReader(b), len(b), false)
+
+	return r
+}
+
 // WithForm sets Content-Type header to "application/x-www-form-urlencoded"
 // or (if WithMultipart() was called) "multipart/form-data", converts given
 // object to url.Values using github.com/ajg/form, and adds it to request body.
@@ -2013,6 +3025,47 @@ func (r *Request) WithMultipart() *Request {
 	return r
 }
 
+// Validate checks request builder for usage errors and reports all of them
+// at once as a single failure.
+//
+// Usage errors include conflicting body and content type setters, missing
+// path parameters, and invalid header names and values. Builder methods don't
+// fail on such errors immediately, but collect them, so that all of them are
+// reported together.
+//
+// Path parameter is missing if path interpolation was used (via pathargs,
+// WithPath, WithPathObject, or WithPathFrom), but the parameter was left
+// unsubstituted.
+//
+// Validate is called automatically by Expect, so calling it explicitly is
+// needed only to check request before sending it.
+//
+// Example:
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/{user}/{repo}", "gavv")
+//	req.WithText("hello")
+//	req.WithJSON(map[string]interface{}{"foo": 123})
+//	req.Validate() // reports both missing "repo" and conflicting bodies
+func (r *Request) Validate() *Request {
+	opChain := r.chain.enter("Validate()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "Validate()") {
+		return r
+	}
+
+	r.validate(opChain)
+
+	return r
+}
+
 // Expect constructs http.Request, sends it, receives http.Response, and
 // returns a new Response instance.
 //
@@ -2036,28 +3089,151 @@ func (r *Request) Expect() *Response {
 
 	if resp == nil {
 		resp = newResponse(responseOpts{
-			config: r.config,
-			chain:  opChain,
+			config:   r.config,
+			chain:    opChain,
+			failures: r.failures,
 		})
 	}
 
 	return resp
 }
 
+// ExpectError constructs http.Request, sends it, and expects that sending
+// fails on client side, e.g. because the request was interrupted using
+// WithBodyInterrupt or WithClientAbort.
+//
+// Returns a new String instance with error message. If response is received
+// instead, failure is reported.
+//
+// Like Expect, it should be called only once for a Request instance.
+// WebSocket requests are not supported.
+//
+// Example:
+//
+//	req := NewRequestC(config, "POST", "http://example.com/upload")
+//	req.WithBytes(data)
+//	req.WithBodyInterrupt(100)
+//	req.ExpectError().Contains("request body interrupted")
+func (r *Request) ExpectError() *String {
+	opChain := r.chain.enter("ExpectError()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	if r.wsUpgrade {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected ExpectError() call for websocket request"),
+			},
+		})
+		return newString(opChain, "")
+	}
+
+	if !r.prepare(opChain) {
+		return newString(opChain, "")
+	}
+
+	if !r.build(opChain) {
+		return newString(opChain, "")
+	}
+
+	resp, _, _, err := r.roundTrip()
+
+	defer r.notifyComplete()
+
+	if err == nil {
+		if resp.Body != nil {
+			_ = resp.Body.Close()
+		}
+
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("expected: sending http request fails"),
+				fmt.Errorf("but got response with status %d", resp.StatusCode),
+			},
+		})
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, err.Error())
+}
+
+// Build constructs http.Request without sending it.
+//
+// Returned request is the same request that Expect would send: builders,
+// value providers, defaults, and transformers are applied, and body is
+// encoded. It can be inspected in tests or sent using another transport.
+//
+// If request can't be built, failure is reported as usual, and Build
+// returns nil request and non-nil error, which is *CheckError.
+//
+// Like Expect, Build should be called only once for a Request instance,
+// and there should not be any more calls of Expect or other WithXXX methods
+// on the same Request instance after it.
+//
+// Example:
+//
+//	httpReq, err := e.POST("/users").
+//		WithJSON(user).
+//		Build()
+//	if err == nil {
+//		resp, err := otherClient.Do(httpReq)
+//	}
+func (r *Request) Build() (*http.Request, error) {
+	opChain := r.chain.enter("Build()")
+
+	ok := r.prepare(opChain) && r.build(opChain)
+
+	// failures are recorded when chain is left
+	opChain.leave()
+
+	if !ok {
+		return nil, &CheckError{Failures: r.failures.get()}
+	}
+
+	return r.httpReq, nil
+}
+
 func (r *Request) expect(opChain *chain) *Response {
 	if !r.prepare(opChain) {
 		return nil
 	}
 
+	return r.complete(opChain)
+}
+
+// complete sends prepared request and applies default assertions and
+// matchers to response. Should be called after successful prepare().
+func (r *Request) complete(opChain *chain) *Response {
 	// after return from prepare(), all subsequent calls to WithXXX and Expect will
 	// abort early due to checkOrder(); so we can safely proceed without a lock
 
+	defer r.notifyComplete()
+
 	resp := r.execute(opChain)
 
 	if resp == nil {
 		return nil
 	}
 
+	if r.strictTransfer && !r.wsUpgrade {
+		resp.HasStrictTransfer()
+	}
+
+	if r.config.HeaderCasing == HeaderCasingStrict {
+		resp.HasCanonicalHeaders()
+	}
+
+	if !r.skipDefaultAssertions {
+		for _, assertion := range r.config.DefaultResponseAssertions {
+			assertion(resp)
+		}
+	}
+
 	for _, matcher := range r.matchers {
 		matcher(resp)
 	}
@@ -2079,56 +3255,112 @@ func (r *Request) prepare(opChain *chain) bool {
 
 	r.expectCalled = true
 
-	return true
+	return r.validate(opChain)
 }
 
-func (r *Request) execute(opChain *chain) *Response {
-	if !r.encodeRequest(opChain) {
-		return nil
-	}
-
-	if r.wsUpgrade {
-		if !r.encodeWebsocketRequest(opChain) {
-			return nil
-		}
-	}
-
-	for _, transform := range r.transformers {
-		transform(r.httpReq)
-
-		if opChain.failed() {
-			return nil
-		}
+func (r *Request) execute(opChain *chain) *Response {
+	if !r.build(opChain) {
+		return nil
 	}
 
 	var (
 		httpResp *http.Response
 		websock  *websocket.Conn
+		tracer   *connTracer
 		elapsed  time.Duration
 	)
 	if r.wsUpgrade {
 		httpResp, websock, elapsed = r.sendWebsocketRequest(opChain)
 	} else {
-		httpResp, elapsed = r.sendRequest(opChain)
+		httpResp, tracer, elapsed = r.sendRequest(opChain)
 	}
 
 	if httpResp == nil {
 		return nil
 	}
 
+	var (
+		connInfo   *httptrace.GotConnInfo
+		earlyHints []http.Header
+	)
+	if tracer != nil {
+		connInfo = tracer.gotConn()
+		earlyHints = tracer.earlyHints()
+	}
+
 	return newResponse(responseOpts{
-		config:    r.config,
-		chain:     opChain,
-		httpResp:  httpResp,
-		websocket: websock,
-		rtt:       []time.Duration{elapsed},
+		config:     r.config,
+		chain:      opChain,
+		httpResp:   httpResp,
+		websocket:  websock,
+		connInfo:   connInfo,
+		earlyHints: earlyHints,
+		origin:     r.origin,
+		requestID:  r.requestID,
+		failures:   r.failures,
+		transfer:   r.transfer,
+		rtt:        []time.Duration{elapsed},
 	})
 }
 
+func (r *Request) build(opChain *chain) bool {
+	if !r.applyProviders(opChain) {
+		return false
+	}
+
+	if !r.encodeRequest(opChain) {
+		return false
+	}
+
+	if r.wsUpgrade {
+		if !r.encodeWebsocketRequest(opChain) {
+			return false
+		}
+	}
+
+	for _, transform := range r.transformers {
+		transform(r.httpReq)
+
+		if opChain.failed() {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (r *Request) encodeRequest(opChain *chain) bool {
-	r.httpReq.URL.Path = concatPaths(r.httpReq.URL.Path, r.path)
+	if r.pathEscaped {
+		rawPath := concatPaths(r.httpReq.URL.EscapedPath(), r.path)
+
+		path, err := url.PathUnescape(rawPath)
+		if err != nil {
+			opChain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{rawPath},
+				Errors: []error{
+					errors.New("invalid escaped path"),
+					err,
+				},
+			})
+			return false
+		}
+
+		r.httpReq.URL.Path = path
+		r.httpReq.URL.RawPath = rawPath
+	} else {
+		r.httpReq.URL.Path = concatPaths(r.httpReq.URL.Path, r.path)
+	}
 
-	if r.query != nil {
+	r.applyDefaults()
+
+	if r.rawQuery != "" {
+		// keep recorded order and encoding, and append added parameters
+		r.httpReq.URL.RawQuery = r.rawQuery
+		if len(r.query) != 0 {
+			r.httpReq.URL.RawQuery += "&" + r.query.Encode()
+		}
+	} else if r.query != nil {
 		r.httpReq.URL.RawQuery = r.query.Encode()
 	}
 
@@ -2162,6 +3394,8 @@ func (r *Request) encodeRequest(opChain *chain) bool {
 
 	r.setupRedirects(opChain)
 
+	r.setupRequestID()
+
 	return true
 }
 
@@ -2190,23 +3424,104 @@ func (r *Request) encodeWebsocketRequest(opChain *chain) bool {
 	return true
 }
 
-func (r *Request) sendRequest(opChain *chain) (*http.Response, time.Duration) {
-	resp, elapsed, err := r.retryRequest(func() (*http.Response, error) {
-		return r.config.Client.Do(r.httpReq)
-	})
+func (r *Request) sendRequest(opChain *chain) (
+	*http.Response, *connTracer, time.Duration,
+) {
+	resp, tracer, elapsed, err := r.roundTrip()
 
 	if err != nil {
 		opChain.fail(AssertionFailure{
-			Type: AssertOperation,
-			Errors: []error{
-				errors.New("failed to send http request"),
-				err,
-			},
+			Type:   AssertOperation,
+			Errors: r.sendErrors("failed to send http request", err),
 		})
-		return nil, 0
+		return nil, nil, 0
+	}
+
+	return resp, tracer, elapsed
+}
+
+func (r *Request) roundTrip() (
+	*http.Response, *connTracer, time.Duration, error,
+) {
+	var tracer *connTracer
+
+	var (
+		cached *responseCacheEntry
+		hit    bool
+	)
+	if r.cache != nil {
+		cached, hit = r.cache.lookup(r.config.Client, r.httpReq, r.config.RequestIDHeader)
+	}
+
+	// if there was no cached response, we should store our own
+	pending := cached != nil && !hit
+
+	resp, elapsed, err := r.retryRequest(func() (*http.Response, error) {
+		tracer = newConnTracer(r.connStats)
+
+		ctx := httptrace.WithClientTrace(r.httpReq.Context(), tracer.trace())
+
+		var abort *clientAbort
+		if r.clientAbort {
+			ctx, abort = startClientAbort(ctx, r.clientAbortAfter)
+		}
+
+		httpReq := r.httpReq.WithContext(ctx)
+
+		if httpReq.Body != nil && httpReq.Body != http.NoBody {
+			if r.bodyThrottle > 0 {
+				httpReq.Body = newThrottleReader(ctx, httpReq.Body, r.bodyThrottle)
+			}
+			if r.bodyInterrupt {
+				httpReq.Body = newInterruptReader(httpReq.Body, r.bodyInterruptAfter)
+			}
+		}
+
+		// statistics of the last attempt are reported
+		r.transfer = &transferStats{sent: true}
+
+		if httpReq.Body != nil && httpReq.Body != http.NoBody {
+			r.transfer.upload = newProgressReader(httpReq.Body,
+				httpReq.ContentLength, r.uploadProgress)
+			httpReq.Body = r.transfer.upload
+		}
+
+		var (
+			resp *http.Response
+			err  error
+		)
+		if hit {
+			// cached response is served only instead of the first attempt,
+			// retries (if any) are sent to the network
+			hit = false
+			if httpReq.Body != nil {
+				_ = httpReq.Body.Close()
+			}
+			resp = cached.response(httpReq)
+		} else {
+			resp, err = r.config.Client.Do(httpReq)
+		}
+
+		if abort != nil {
+			err = abort.stop(resp, err)
+		}
+
+		if resp != nil && resp.Body != nil && resp.Body != http.NoBody {
+			r.transfer.download = newProgressReader(resp.Body,
+				resp.ContentLength, r.downloadProgress)
+			resp.Body = r.transfer.download
+		}
+
+		return resp, err
+	})
+
+	if pending {
+		r.cache.store(cached, resp, err == nil && !r.shouldRetry(resp, nil))
 	}
 
-	return resp, elapsed
+	r.recordTiming(tracer)
+
+	return resp, tracer, elapsed, err
 }
 
 func (r *Request) sendWebsocketRequest(opChain *chain) (
@@ -2214,18 +3529,14 @@ func (r *Request) sendWebsocketRequest(opChain *chain) (
 ) {
 	var conn *websocket.Conn
 	resp, elapsed, err := r.retryRequest(func() (resp *http.Response, err error) {
-		conn, resp, err = r.config.WebsocketDialer.Dial(
-			r.httpReq.URL.String(), r.httpReq.Header)
+		conn, resp, err = r.dialWebsocket()
 		return resp, err
 	})
 
 	if err != nil && err != websocket.ErrBadHandshake {
 		opChain.fail(AssertionFailure{
-			Type: AssertOperation,
-			Errors: []error{
-				errors.New("failed to send websocket request"),
-				err,
-			},
+			Type:   AssertOperation,
+			Errors: r.sendErrors("failed to send websocket request", err),
 		})
 		return nil, nil, 0
 	}
@@ -2243,8 +3554,155 @@ func (r *Request) sendWebsocketRequest(opChain *chain) (
 	return resp, conn, elapsed
 }
 
+// Dial websocket, applying handshake timeout and sharing cookies
+// with the HTTP client jar.
+func (r *Request) dialWebsocket() (*websocket.Conn, *http.Response, error) {
+	dialer := r.config.WebsocketDialer
+	wsURL := r.httpReq.URL.String()
+
+	header := r.httpReq.Header
+	jar := r.websocketJar()
+
+	if jar != nil {
+		header = header.Clone()
+		for _, cookie := range jar.Cookies(websocketHTTPURL(r.httpReq.URL)) {
+			(&http.Request{Header: header}).AddCookie(cookie)
+		}
+	}
+
+	var (
+		conn *websocket.Conn
+		resp *http.Response
+		err  error
+	)
+
+	if r.wsHandshakeTimeout > 0 {
+		ctxDialer, ok := dialer.(websocketContextDialer)
+		if !ok {
+			return nil, nil, fmt.Errorf(
+				"handshake timeout requires dialer with DialContext method, got %T",
+				dialer)
+		}
+
+		ctx, cancel := context.WithTimeout(r.httpReq.Context(), r.wsHandshakeTimeout)
+		defer cancel()
+
+		conn, resp, err = ctxDialer.DialContext(ctx, wsURL, header)
+	} else {
+		conn, resp, err = dialer.Dial(wsURL, header)
+	}
+
+	if jar != nil && resp != nil {
+		if cookies := resp.Cookies(); len(cookies) != 0 {
+			jar.SetCookies(websocketHTTPURL(r.httpReq.URL), cookies)
+		}
+	}
+
+	return conn, resp, err
+}
+
+// Returns jar of HTTP client, unless websocket dialer has its own jar.
+func (r *Request) websocketJar() http.CookieJar {
+	if dialer, ok := r.config.WebsocketDialer.(*websocket.Dialer); ok &&
+		dialer.Jar != nil {
+		return nil
+	}
+
+	if client, ok := r.config.Client.(*http.Client); ok {
+		return client.Jar
+	}
+
+	return nil
+}
+
+type websocketContextDialer interface {
+	DialContext(ctx context.Context, url string, reqH http.Header) (
+		*websocket.Conn, *http.Response, error)
+}
+
+// Cookie jars work only with http and https URLs.
+func websocketHTTPURL(u *url.URL) *url.URL {
+	httpURL := *u
+
+	switch httpURL.Scheme {
+	case "ws":
+		httpURL.Scheme = "http"
+	case "wss":
+		httpURL.Scheme = "https"
+	}
+
+	return &httpURL
+}
+
+// sendErrors describes error returned by client after all attempts.
+func (r *Request) sendErrors(message string, err error) []error {
+	errs := []error{
+		errors.New(message),
+		err,
+	}
+
+	kind := classifyTransportError(err)
+
+	if kind.isConnection() {
+		errs = append(errs, fmt.Errorf("error category: %s (connection-level error)", kind))
+	} else {
+		errs = append(errs, fmt.Errorf("error category: %s", kind))
+	}
+
+	if r.attempts > 1 {
+		errs = append(errs, fmt.Errorf("gave up after %d attempts (%d connection retries)",
+			r.attempts, r.connRetries))
+	}
+
+	return errs
+}
+
+func (r *Request) printExchange(
+	printer ExchangePrinter,
+	reqBody *bodyWrapper,
+	resp *http.Response,
+	err error,
+	elapsed time.Duration,
+) {
+	if reqBody != nil {
+		reqBody.Rewind()
+	}
+
+	exchange := &PrinterExchange{
+		Request:  r.config.Redactor.redactRequest(r.httpReq),
+		Error:    err,
+		Duration: elapsed,
+		Debug:    r.debug,
+	}
+
+	if resp != nil {
+		if resp.Body != nil {
+			resp.Body.(*bodyWrapper).Rewind()
+		}
+		exchange.Response = r.config.Redactor.redactResponse(resp)
+	}
+
+	printer.Exchange(exchange)
+}
+
 func (r *Request) retryRequest(reqFunc func() (*http.Response, error)) (
 	*http.Response, time.Duration, error,
+) {
+	r.telemetry.startRequest(r)
+
+	start := time.Now()
+	resp, elapsed, err := r.retryAttempts(reqFunc)
+	total := time.Since(start)
+
+	r.telemetry.endRequest(resp, err)
+
+	r.recordStats(resp, err, r.attempts, elapsed, total)
+
+	return resp, elapsed, err
+}
+
+func (r *Request) retryAttempts(reqFunc func() (*http.Response, error)) (
+	*http.Response, time.Duration, error,
 ) {
 	if r.httpReq.Body != nil && r.httpReq.Body != http.NoBody {
 		if _, ok := r.httpReq.Body.(*bodyWrapper); !ok {
@@ -2254,15 +3712,30 @@ func (r *Request) retryRequest(reqFunc func() (*http.Response, error)) (
 
 	reqBody, _ := r.httpReq.Body.(*bodyWrapper)
 
+	budget := r.config.RetryBudget
+	endpoint := retryBudgetEndpoint(r.httpReq)
+
+	if budget != nil {
+		if err := budget.allow(endpoint, r.config.Clock.Now()); err != nil {
+			return nil, 0, err
+		}
+	}
+
 	delay := r.minRetryDelay
 	i := 0
+	retries := 0
+
+	var retryDelay time.Duration
 
 	for {
 		for _, printer := range r.config.Printers {
+			if _, ok := printer.(ExchangePrinter); ok {
+				continue
+			}
 			if reqBody != nil {
 				reqBody.Rewind()
 			}
-			printer.Request(r.httpReq)
+			printer.Request(r.config.Redactor.redactRequest(r.httpReq))
 		}
 
 		if reqBody != nil {
@@ -2282,31 +3755,67 @@ func (r *Request) retryRequest(reqFunc func() (*http.Response, error)) (
 			r.httpReq = r.httpReq.WithContext(ctx)
 		}
 
+		attemptSpan := r.telemetry.startAttempt(r, i+1)
+
 		start := time.Now()
 		resp, err := reqFunc()
 		elapsed := time.Since(start)
 
+		r.attempts++
+
+		r.telemetry.endAttempt(attemptSpan, resp, err, elapsed)
+
 		if resp != nil && resp.Body != nil {
-			resp.Body = newBodyWrapper(resp.Body, cancelFn)
+			bw := newBodyWrapper(resp.Body, cancelFn)
+			if r.config.BodySpoolThreshold > 0 {
+				bw.EnableSpooling(r.config.BodySpoolThreshold)
+			}
+			if r.config.MaxBodyBytes > 0 {
+				bw.SetLimit(r.config.MaxBodyBytes)
+			}
+			resp.Body = bw
 		} else if cancelFn != nil {
 			cancelFn()
 		}
 
-		if resp != nil {
-			for _, printer := range r.config.Printers {
+		for _, printer := range r.config.Printers {
+			if exchangePrinter, ok := printer.(ExchangePrinter); ok {
+				r.printExchange(exchangePrinter, reqBody, resp, err, elapsed)
+				continue
+			}
+			if resp != nil {
 				if resp.Body != nil {
 					resp.Body.(*bodyWrapper).Rewind()
 				}
-				printer.Response(resp, elapsed)
+				printer.Response(r.config.Redactor.redactResponse(resp), elapsed)
+			}
+		}
+
+		if budget != nil {
+			if i > 0 {
+				budget.spend(retryDelay + elapsed)
 			}
+			budget.record(endpoint, isFailedAttempt(resp, err), r.config.Clock.Now())
 		}
 
 		i++
-		if i == r.maxRetries+1 {
-			return resp, elapsed, err
+		if r.maxConnRetries > 0 && r.isConnRetry(err) {
+			if r.connRetries == r.maxConnRetries {
+				return resp, elapsed, err
+			}
+			r.connRetries++
+		} else {
+			if retries == r.maxRetries {
+				return resp, elapsed, err
+			}
+
+			if !r.shouldRetry(resp, err) {
+				return resp, elapsed, err
+			}
+			retries++
 		}
 
-		if !r.shouldRetry(resp, err) {
+		if budget != nil && !budget.acquire(endpoint, delay) {
 			return resp, elapsed, err
 		}
 
@@ -2314,6 +3823,8 @@ func (r *Request) retryRequest(reqFunc func() (*http.Response, error)) (
 			resp.Body.Close()
 		}
 
+		retryDelay = delay
+
 		if configCtx := r.config.Context; configCtx != nil {
 			select {
 			case <-configCtx.Done():
@@ -2331,6 +3842,15 @@ func (r *Request) retryRequest(reqFunc func() (*http.Response, error)) (
 	}
 }
 
+func (r *Request) isConnRetry(err error) bool {
+	// deliberately interrupted requests are never retried
+	if errors.Is(err, errBodyInterrupted) || errors.Is(err, errClientAborted) {
+		return false
+	}
+
+	return classifyTransportError(err).isConnection()
+}
+
 func (r *Request) shouldRetry(resp *http.Response, err error) bool {
 	var (
 		isTemporaryNetworkError bool // Deprecated
@@ -2339,6 +3859,11 @@ func (r *Request) shouldRetry(resp *http.Response, err error) bool {
 		isHTTPError             bool
 	)
 
+	// deliberately interrupted requests are never retried
+	if errors.Is(err, errBodyInterrupted) || errors.Is(err, errClientAborted) {
+		return false
+	}
+
 	if netErr, ok := err.(net.Error); ok {
 		//nolint
 		isTemporaryNetworkError = netErr.Temporary()
@@ -2438,6 +3963,20 @@ func (r *Request) setupRedirects(opChain *chain) {
 	}
 }
 
+func (r *Request) setupRequestID() {
+	header := r.config.RequestIDHeader
+	if header == "" {
+		return
+	}
+
+	r.requestID = r.httpReq.Header.Get(header)
+
+	if r.requestID == "" {
+		r.requestID = generateRequestID(r.config.RequestIDGenerator)
+		r.httpReq.Header.Set(header, r.requestID)
+	}
+}
+
 var typeErr = `ambiguous request "Content-Type" header values:
   first set by %s:
     %q
@@ -2455,13 +3994,8 @@ func (r *Request) setType(
 		previousType := r.httpReq.Header.Get("Content-Type")
 
 		if previousType != "" && previousType != newType {
-			opChain.fail(AssertionFailure{
-				Type: AssertUsage,
-				Errors: []error{
-					fmt.Errorf(typeErr,
-						r.typeSetter, previousType, newSetter, newType),
-				},
-			})
+			r.usageError(opChain, fmt.Errorf(typeErr,
+				r.typeSetter, previousType, newSetter, newType))
 			return
 		}
 	}
@@ -2478,12 +4012,7 @@ func (r *Request) setBody(
 	opChain *chain, setter string, reader io.Reader, len int, overwrite bool,
 ) {
 	if !overwrite && r.bodySetter != "" {
-		opChain.fail(AssertionFailure{
-			Type: AssertUsage,
-			Errors: []error{
-				fmt.Errorf(bodyErr, r.bodySetter, setter),
-			},
-		})
+		r.usageError(opChain, fmt.Errorf(bodyErr, r.bodySetter, setter))
 		return
 	}
 
@@ -2502,7 +4031,77 @@ func (r *Request) setBody(
 	r.bodySetter = setter
 }
 
+// usageError reports builder usage error.
+// Before Expect, errors are collected and reported together by validate;
+// after Expect (e.g. from providers), error is reported immediately.
+func (r *Request) usageError(opChain *chain, err error) {
+	if r.expectCalled {
+		opChain.fail(AssertionFailure{
+			Type:   AssertUsage,
+			Errors: []error{err},
+		})
+		return
+	}
+
+	r.usageErrors = append(r.usageErrors, err)
+}
+
+// validate reports all collected usage errors, as well as problems that
+// can be detected only when request is fully built, as a single failure.
+func (r *Request) validate(opChain *chain) bool {
+	errs := append([]error(nil), r.usageErrors...)
+
+	if r.bodySetter != "" && (r.form != nil || r.multipart != nil) &&
+		r.bodySetter != "WithMultipart()" {
+		errs = append(errs,
+			fmt.Errorf(bodyErr, r.bodySetter, "WithForm() or WithFormField()"))
+	}
+
+	if r.wsUpgrade && r.bodySetter != "" {
+		errs = append(errs, fmt.Errorf(websocketErr, r.bodySetter))
+	}
+
+	for _, key := range r.missingPathParams() {
+		errs = append(errs,
+			fmt.Errorf("missing value for path parameter %q", key))
+	}
+
+	if len(errs) == 0 {
+		return true
+	}
+
+	opChain.fail(AssertionFailure{
+		Type:   AssertUsage,
+		Errors: errs,
+	})
+
+	return false
+}
+
+// missingPathParams returns names of path parameters that were neither
+// substituted, nor have a pending provider.
+// If path interpolation was never used, braces are treated literally.
+func (r *Request) missingPathParams() []string {
+	if !r.pathInterpolated {
+		return nil
+	}
+
+	var keys []string
+
+	_, _ = interpol.WithFunc(r.path, func(k string, w io.Writer) error {
+		if !r.pathProviders[strings.ToLower(k)] {
+			keys = append(keys, k)
+		}
+		return nil
+	})
+
+	return keys
+}
+
 func (r *Request) checkOrder(opChain *chain, funcCall string) bool {
+	if !r.checkGoroutine(opChain, funcCall) {
+		return false
+	}
 	if r.expectCalled {
 		opChain.fail(AssertionFailure{
 			Type: AssertUsage,
@@ -2515,6 +4114,29 @@ func (r *Request) checkOrder(opChain *chain, funcCall string) bool {
 	return true
 }
 
+func (r *Request) checkGoroutine(opChain *chain, funcCall string) bool {
+	if !r.config.Parallel {
+		return true
+	}
+	// If goroutine ID can't be determined, skip the check instead
+	// of reporting false failures.
+	id := goroutineID()
+	if id == 0 || r.goroutine == 0 {
+		return true
+	}
+	if id != r.goroutine {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected call to %s from goroutine %d:"+
+					" request was created by goroutine %d", funcCall, id, r.goroutine),
+			},
+		})
+		return false
+	}
+	return true
+}
+
 func concatPaths(a, b string) string {
 	if a == "" {
 		return b
@@ -0,0 +1,40 @@
+package httpexpect
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+// generateRequestID returns new request ID for Config.RequestIDHeader.
+// If generator is nil, random UUID is generated using crypto/rand.
+func generateRequestID(generator func() string) string {
+	if generator != nil {
+		return generator()
+	}
+
+	return newUUID()
+}
+
+// newUUID returns random version 4 UUID generated using crypto/rand.
+func newUUID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+
+	return formatUUID(b)
+}
+
+// formatUUID formats random bytes as version 4 UUID.
+func formatUUID(b [16]byte) string {
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // variant 10
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// requestIDHeaderOrDefault returns header used for request IDs.
+func requestIDHeaderOrDefault(header string) string {
+	if header == "" {
+		return defaultCorrelationHeader
+	}
+	return header
+}
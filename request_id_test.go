@@ -0,0 +1,200 @@
+package httpexpect
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestID_Generate(t *testing.T) {
+	t.Run("default generator", func(t *testing.T) {
+		client := &mockClient{}
+
+		config := Config{
+			Client:          client,
+			Reporter:        newMockReporter(t),
+			RequestIDHeader: "X-Request-ID",
+		}
+
+		NewRequestC(config, "GET", "url").Expect().chain.assert(t, success)
+		id1 := client.req.Header.Get("X-Request-ID")
+
+		NewRequestC(config, "GET", "url").Expect().chain.assert(t, success)
+		id2 := client.req.Header.Get("X-Request-ID")
+
+		uuid := regexp.MustCompile(
+			`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+		assert.Regexp(t, uuid, id1)
+		assert.Regexp(t, uuid, id2)
+		assert.NotEqual(t, id1, id2)
+	})
+
+	t.Run("custom generator", func(t *testing.T) {
+		client := &mockClient{}
+
+		config := Config{
+			Client:             client,
+			Reporter:           newMockReporter(t),
+			RequestIDHeader:    "X-Trace",
+			RequestIDGenerator: func() string { return "trace-123" },
+		}
+
+		NewRequestC(config, "GET", "url").Expect().chain.assert(t, success)
+		assert.Equal(t, "trace-123", client.req.Header.Get("X-Trace"))
+	})
+
+	t.Run("header already set", func(t *testing.T) {
+		client := &mockClient{}
+
+		config := Config{
+			Client:          client,
+			Reporter:        newMockReporter(t),
+			RequestIDHeader: "X-Request-ID",
+		}
+
+		NewRequestC(config, "GET", "url").
+			WithHeader("X-Request-ID", "manual").
+			Expect().chain.assert(t, success)
+		assert.Equal(t, "manual", client.req.Header.Get("X-Request-ID"))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		client := &mockClient{}
+
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}
+
+		NewRequestC(config, "GET", "url").Expect().chain.assert(t, success)
+		assert.Empty(t, client.req.Header)
+	})
+
+	t.Run("websocket", func(t *testing.T) {
+		var header http.Header
+
+		dialer := WebsocketDialerFunc(func(
+			_ string, h http.Header,
+		) (*websocket.Conn, *http.Response, error) {
+			header = h
+			return &websocket.Conn{}, &http.Response{}, nil
+		})
+
+		config := Config{
+			Reporter:           newMockReporter(t),
+			WebsocketDialer:    dialer,
+			RequestIDHeader:    "X-Request-ID",
+			RequestIDGenerator: func() string { return "ws-id" },
+		}
+
+		NewRequestC(config, "GET", "url").
+			WithWebsocketUpgrade().
+			Expect().chain.assert(t, success)
+		assert.Equal(t, "ws-id", header.Get("X-Request-ID"))
+	})
+}
+
+func TestRequestID_EchoesRequestID(t *testing.T) {
+	newConfig := func(client Client) Config {
+		return Config{
+			Client:             client,
+			Reporter:           newMockReporter(t),
+			RequestIDHeader:    "X-Request-ID",
+			RequestIDGenerator: func() string { return "req-id" },
+		}
+	}
+
+	t.Run("echoed", func(t *testing.T) {
+		// mockClient copies request headers to response
+		resp := NewRequestC(newConfig(&mockClient{}), "GET", "url").Expect()
+
+		resp.EchoesRequestID()
+		resp.chain.assert(t, success)
+	})
+
+	t.Run("not echoed", func(t *testing.T) {
+		client := ClientFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"X-Request-Id": {"other-id"}},
+				Body:       http.NoBody,
+				Request:    req,
+			}, nil
+		})
+
+		resp := NewRequestC(newConfig(client), "GET", "url").Expect()
+
+		resp.EchoesRequestID()
+		resp.chain.assert(t, failure)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		client := ClientFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       http.NoBody,
+				Request:    req,
+			}, nil
+		})
+
+		resp := NewRequestC(newConfig(client), "GET", "url").Expect()
+
+		resp.EchoesRequestID()
+		resp.chain.assert(t, failure)
+	})
+
+	t.Run("manual response", func(t *testing.T) {
+		httpReq, _ := http.NewRequest("GET", "http://example.com", nil)
+		httpReq.Header.Set("X-Request-ID", "manual-id")
+
+		resp := NewResponse(newMockReporter(t), &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"X-Request-Id": {"manual-id"}},
+			Request:    httpReq,
+		})
+
+		resp.EchoesRequestID()
+		resp.chain.assert(t, success)
+	})
+
+	t.Run("no request id", func(t *testing.T) {
+		resp := NewResponse(newMockReporter(t), &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"X-Request-Id": {"id"}},
+		})
+
+		resp.EchoesRequestID()
+		resp.chain.assert(t, failure)
+	})
+
+	t.Run("warning copy", func(t *testing.T) {
+		resp := NewRequestC(newConfig(&mockClient{}), "GET", "url").Expect()
+
+		resp.Warn().EchoesRequestID()
+		resp.chain.assert(t, success)
+	})
+}
+
+func TestRequestID_Formatter(t *testing.T) {
+	httpReq, _ := http.NewRequest("GET", "http://example.com", nil)
+	httpReq.Header.Set("X-Trace", "trace-id")
+
+	ctx := &AssertionContext{
+		Request: &Request{
+			config:  Config{RequestIDHeader: "X-Trace"},
+			httpReq: httpReq,
+		},
+	}
+
+	formatter := DefaultFormatter{
+		EnableBreadcrumbs: true,
+	}
+	fd := formatter.buildFormatData(ctx, &AssertionFailure{Type: AssertValid})
+
+	assert.Equal(t, "X-Trace", fd.CorrelationHeader)
+	assert.Equal(t, "trace-id", fd.CorrelationID)
+}
@@ -0,0 +1,214 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// QuerySliceStyle defines how WithQuerySlice encodes a list of values.
+type QuerySliceStyle int
+
+const (
+	// QueryRepeat repeats parameter for every value: "a=1&a=2".
+	QueryRepeat QuerySliceStyle = iota
+
+	// QueryBrackets repeats parameter with "[]" suffix: "a[]=1&a[]=2".
+	QueryBrackets
+
+	// QueryComma joins values using comma: "a=1,2".
+	QueryComma
+
+	// QueryPipe joins values using pipe: "a=1|2".
+	QueryPipe
+
+	// QuerySpace joins values using space: "a=1 2".
+	QuerySpace
+)
+
+// WithQueryTime adds query parameter with time value to request URL.
+//
+// Time is formatted using given layout, as in time.Time.Format.
+// If layout is empty, time.RFC3339 is used.
+//
+// Example:
+//
+//	t := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+//
+//	req := NewRequestC(config, "GET", "http://example.com/path")
+//	req.WithQueryTime("since", t, "")
+//	req.WithQueryTime("day", t, "2006-01-02")
+//	// URL is now http://example.com/path?day=2024-01-02&since=2024-01-02T00%3A00%3A00Z
+func (r *Request) WithQueryTime(key string, t time.Time, layout string) *Request {
+	opChain := r.chain.enter("WithQueryTime()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithQueryTime()") {
+		return r
+	}
+
+	if layout == "" {
+		layout = time.RFC3339
+	}
+
+	r.addQuery(key, t.Format(layout))
+
+	return r
+}
+
+// WithQueryBool adds query parameter with boolean value to request URL.
+//
+// Value is formatted as "true" or "false".
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "http://example.com/path")
+//	req.WithQueryBool("active", true)
+//	// URL is now http://example.com/path?active=true
+func (r *Request) WithQueryBool(key string, value bool) *Request {
+	opChain := r.chain.enter("WithQueryBool()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithQueryBool()") {
+		return r
+	}
+
+	r.addQuery(key, strconv.FormatBool(value))
+
+	return r
+}
+
+// WithQuerySlice adds query parameter with a list of values to request URL.
+//
+// values should be a slice or array. Every element is converted to string
+// using fmt.Sprint(), except time.Time, which is formatted using
+// time.RFC3339. style defines how elements are encoded, see QuerySliceStyle.
+//
+// If values is empty, no parameters are added.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "http://example.com/path")
+//	req.WithQuerySlice("id", []int{1, 2}, QueryRepeat)
+//	req.WithQuerySlice("tag", []string{"a", "b"}, QueryComma)
+//	// URL is now http://example.com/path?id=1&id=2&tag=a%2Cb
+func (r *Request) WithQuerySlice(
+	key string, values interface{}, style QuerySliceStyle,
+) *Request {
+	opChain := r.chain.enter("WithQuerySlice()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithQuerySlice()") {
+		return r
+	}
+
+	if values == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil argument"),
+			},
+		})
+		return r
+	}
+
+	v := reflect.ValueOf(values)
+
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected %T argument, expected slice or array", values),
+			},
+		})
+		return r
+	}
+
+	var sep string
+
+	switch style {
+	case QueryRepeat, QueryBrackets:
+	case QueryComma:
+		sep = ","
+	case QueryPipe:
+		sep = "|"
+	case QuerySpace:
+		sep = " "
+	default:
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected query slice style: %d", style),
+			},
+		})
+		return r
+	}
+
+	if v.Len() == 0 {
+		return r
+	}
+
+	strs := make([]string, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		strs = append(strs, formatQueryValue(v.Index(i).Interface()))
+	}
+
+	switch style {
+	case QueryRepeat:
+		for _, s := range strs {
+			r.addQuery(key, s)
+		}
+	case QueryBrackets:
+		for _, s := range strs {
+			r.addQuery(key+"[]", s)
+		}
+	default:
+		r.addQuery(key, strings.Join(strs, sep))
+	}
+
+	return r
+}
+
+func (r *Request) addQuery(key, value string) {
+	if r.query == nil {
+		r.query = make(url.Values)
+	}
+	r.query.Add(key, value)
+}
+
+func formatQueryValue(value interface{}) string {
+	switch v := value.(type) {
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case *time.Time:
+		if v != nil {
+			return v.Format(time.RFC3339)
+		}
+	}
+	return fmt.Sprint(value)
+}
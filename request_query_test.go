@@ -0,0 +1,118 @@
+package httpexpect
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequest_QueryTyped(t *testing.T) {
+	tm := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	cases := []struct {
+		name    string
+		prepare func(req *Request)
+		url     string
+	}{
+		{
+			name: "time default layout",
+			prepare: func(req *Request) {
+				req.WithQueryTime("t", tm, "")
+			},
+			url: "http://example.com/path?t=2024-01-02T03%3A04%3A05Z",
+		},
+		{
+			name: "time custom layout",
+			prepare: func(req *Request) {
+				req.WithQueryTime("t", tm, "2006-01-02")
+			},
+			url: "http://example.com/path?t=2024-01-02",
+		},
+		{
+			name: "bool",
+			prepare: func(req *Request) {
+				req.WithQueryBool("a", true).WithQueryBool("b", false)
+			},
+			url: "http://example.com/path?a=true&b=false",
+		},
+		{
+			name: "slice repeat",
+			prepare: func(req *Request) {
+				req.WithQuerySlice("a", []int{1, 2, 3}, QueryRepeat)
+			},
+			url: "http://example.com/path?a=1&a=2&a=3",
+		},
+		{
+			name: "slice brackets",
+			prepare: func(req *Request) {
+				req.WithQuerySlice("a", []string{"x", "y"}, QueryBrackets)
+			},
+			url: "http://example.com/path?a%5B%5D=x&a%5B%5D=y",
+		},
+		{
+			name: "slice comma",
+			prepare: func(req *Request) {
+				req.WithQuerySlice("a", []string{"x", "y"}, QueryComma)
+			},
+			url: "http://example.com/path?a=x%2Cy",
+		},
+		{
+			name: "slice pipe",
+			prepare: func(req *Request) {
+				req.WithQuerySlice("a", [2]int{1, 2}, QueryPipe)
+			},
+			url: "http://example.com/path?a=1%7C2",
+		},
+		{
+			name: "slice space",
+			prepare: func(req *Request) {
+				req.WithQuerySlice("a", []interface{}{1, "x"}, QuerySpace)
+			},
+			url: "http://example.com/path?a=1+x",
+		},
+		{
+			name: "slice of times",
+			prepare: func(req *Request) {
+				req.WithQuerySlice("t", []time.Time{tm}, QueryRepeat)
+			},
+			url: "http://example.com/path?t=2024-01-02T03%3A04%3A05Z",
+		},
+		{
+			name: "empty slice",
+			prepare: func(req *Request) {
+				req.WithQuerySlice("a", []int{}, QueryComma)
+			},
+			url: "http://example.com/path",
+		},
+		{
+			name: "combined",
+			prepare: func(req *Request) {
+				req.WithQuery("a", "foo").
+					WithQueryBool("b", true).
+					WithQuerySlice("a", []string{"bar"}, QueryRepeat)
+			},
+			url: "http://example.com/path?a=foo&a=bar&b=true",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &mockClient{}
+
+			config := Config{
+				BaseURL:  "http://example.com",
+				Client:   client,
+				Reporter: newMockReporter(t),
+			}
+
+			req := NewRequestC(config, "GET", "/path")
+			tc.prepare(req)
+
+			req.Expect()
+			req.chain.assert(t, success)
+
+			assert.Equal(t, tc.url, client.req.URL.String())
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package httpexpect
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+// RequestStats contains statistics of a single request, delivered to
+// callbacks registered using Request.OnComplete.
+type RequestStats struct {
+	// Request name set by Request.WithName, including names of enclosing
+	// scenario and step, if any.
+	Name string
+
+	// Request method and URL.
+	Method string
+	URL    string
+
+	// Response status code, or zero if no response was received.
+	StatusCode int
+
+	// Size of request body, or -1 if unknown.
+	RequestSize int64
+
+	// Size of response body as reported by server, or -1 if unknown
+	// or no response was received.
+	ResponseSize int64
+
+	// Number of retry attempts made after the first attempt.
+	Retries int
+
+	// Number of retries caused by connection-level errors, included
+	// in Retries. See Request.WithMaxConnRetries.
+	ConnRetries int
+
+	// Durations of request phases.
+	Timing RequestTiming
+
+	// Error returned by client, if request failed.
+	Error error
+}
+
+// RequestTiming contains durations of request phases.
+//
+// Phase durations are collected using net/http/httptrace for the last
+// attempt, so they're available only for clients that use http.Transport.
+// Phases that didn't happen (e.g. DNS lookup when connection was reused)
+// are zero.
+type RequestTiming struct {
+	// Time spent on DNS lookup.
+	DNSLookup time.Duration
+
+	// Time spent on establishing TCP connection.
+	Connect time.Duration
+
+	// Time spent on TLS handshake.
+	TLSHandshake time.Duration
+
+	// Time since start of attempt until first byte of response.
+	TimeToFirstByte time.Duration
+
+	// Duration of the last attempt.
+	RoundTrip time.Duration
+
+	// Duration of all attempts, including delays between retries.
+	Total time.Duration
+}
+
+// OnComplete registers a callback that is invoked when the request
+// is completed.
+//
+// Callback is invoked once per request, after response is received and
+// matchers are applied, or after sending request failed. It receives
+// status, sizes, timing phases, and retry count of the request, which
+// may be used to report custom metrics.
+//
+// If request was not sent (e.g. because it can't be built), callback
+// is not invoked.
+//
+// Callbacks may be registered for all requests using Expect.Builder.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "/path")
+//	req.OnComplete(func(stats httpexpect.RequestStats) {
+//		metrics.Observe(stats.URL, stats.StatusCode, stats.Timing.Total)
+//	})
+func (r *Request) OnComplete(callback func(stats RequestStats)) *Request {
+	opChain := r.chain.enter("OnComplete()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "OnComplete()") {
+		return r
+	}
+
+	if callback == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil argument"),
+			},
+		})
+		return r
+	}
+
+	r.completeHooks = append(r.completeHooks, callback)
+
+	return r
+}
+
+func (r *Request) recordStats(
+	resp *http.Response, err error, attempts int, elapsed, total time.Duration,
+) {
+	if len(r.completeHooks) == 0 {
+		return
+	}
+
+	stats := &RequestStats{
+		Name:         r.chain.requestName(),
+		Method:       r.httpReq.Method,
+		URL:          r.httpReq.URL.String(),
+		RequestSize:  r.httpReq.ContentLength,
+		ResponseSize: -1,
+		Error:        err,
+	}
+
+	if r.httpReq.Body == nil || r.httpReq.Body == http.NoBody {
+		stats.RequestSize = 0
+	}
+
+	if attempts > 1 {
+		stats.Retries = attempts - 1
+		stats.ConnRetries = r.connRetries
+	}
+
+	if resp != nil {
+		stats.StatusCode = resp.StatusCode
+		stats.ResponseSize = resp.ContentLength
+	}
+
+	stats.Timing.RoundTrip = elapsed
+	stats.Timing.Total = total
+
+	r.stats = stats
+}
+
+func (r *Request) recordTiming(tracer *connTracer) {
+	if r.stats == nil || tracer == nil {
+		return
+	}
+
+	timing := tracer.timing()
+
+	timing.RoundTrip = r.stats.Timing.RoundTrip
+	timing.Total = r.stats.Timing.Total
+
+	r.stats.Timing = timing
+}
+
+func (r *Request) notifyComplete() {
+	if r.stats == nil {
+		return
+	}
+
+	stats := *r.stats
+
+	for _, hook := range r.completeHooks {
+		hook(stats)
+	}
+}
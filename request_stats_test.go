@@ -0,0 +1,212 @@
+package httpexpect
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestStats_Success(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+
+	client := &mockClient{
+		resp: http.Response{
+			StatusCode:    http.StatusCreated,
+			ContentLength: 5,
+		},
+		cb: func(*http.Request) {
+			clock.Advance(time.Hour)
+			time.Sleep(10 * time.Millisecond)
+		},
+	}
+
+	config := Config{
+		BaseURL:  "http://example.com",
+		Client:   client,
+		Clock:    clock,
+		Reporter: newMockReporter(t),
+	}
+
+	var calls []RequestStats
+
+	req := NewRequestC(config, http.MethodPost, "/path").
+		WithName("create").
+		WithText("hello").
+		OnComplete(func(stats RequestStats) {
+			calls = append(calls, stats)
+		})
+
+	req.Expect().Status(http.StatusCreated)
+	req.chain.assert(t, success)
+
+	require.Equal(t, 1, len(calls))
+
+	stats := calls[0]
+	assert.Equal(t, "create", stats.Name)
+	assert.Equal(t, http.MethodPost, stats.Method)
+	assert.Equal(t, "http://example.com/path", stats.URL)
+	assert.Equal(t, http.StatusCreated, stats.StatusCode)
+	assert.Equal(t, int64(5), stats.RequestSize)
+	assert.Equal(t, int64(5), stats.ResponseSize)
+	assert.Equal(t, 0, stats.Retries)
+	assert.True(t, stats.Timing.RoundTrip >= 10*time.Millisecond)
+	assert.True(t, stats.Timing.RoundTrip < time.Hour)
+	assert.True(t, stats.Timing.Total >= stats.Timing.RoundTrip)
+	assert.True(t, stats.Timing.Total < time.Hour)
+	assert.NoError(t, stats.Error)
+}
+
+func TestRequestStats_Retries(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+
+	callCount := 0
+
+	client := &mockClient{
+		cb: func(*http.Request) {
+			callCount++
+			clock.Advance(time.Hour)
+			time.Sleep(10 * time.Millisecond)
+		},
+	}
+	client.resp.StatusCode = http.StatusServiceUnavailable
+
+	config := Config{
+		Client:   client,
+		Clock:    clock,
+		Reporter: newMockReporter(t),
+	}
+
+	var calls []RequestStats
+
+	req := NewRequestC(config, http.MethodGet, "/path").
+		WithMaxRetries(2).
+		WithRetryDelay(0, 0).
+		OnComplete(func(stats RequestStats) {
+			calls = append(calls, stats)
+		}).
+		OnComplete(func(stats RequestStats) {
+			calls = append(calls, stats)
+		})
+	req.sleepFn = mockSleep
+
+	req.Expect()
+
+	assert.Equal(t, 3, callCount)
+
+	require.Equal(t, 2, len(calls))
+	assert.Equal(t, calls[0], calls[1])
+
+	stats := calls[0]
+	assert.Equal(t, http.StatusServiceUnavailable, stats.StatusCode)
+	assert.Equal(t, int64(0), stats.RequestSize)
+	assert.Equal(t, 2, stats.Retries)
+	assert.True(t, stats.Timing.RoundTrip >= 10*time.Millisecond)
+	assert.True(t, stats.Timing.Total >= 30*time.Millisecond)
+	assert.True(t, stats.Timing.Total < time.Hour)
+}
+
+func TestRequestStats_Error(t *testing.T) {
+	clientErr := errors.New("connection refused")
+
+	config := Config{
+		Client:   &mockClient{err: clientErr},
+		Reporter: newMockReporter(t),
+	}
+
+	t.Run("expect", func(t *testing.T) {
+		var calls []RequestStats
+
+		req := NewRequestC(config, http.MethodGet, "/path").
+			WithRetryPolicy(DontRetry).
+			OnComplete(func(stats RequestStats) {
+				calls = append(calls, stats)
+			})
+
+		req.Expect()
+		req.chain.assert(t, failure)
+
+		require.Equal(t, 1, len(calls))
+		assert.Equal(t, 0, calls[0].StatusCode)
+		assert.Equal(t, int64(-1), calls[0].ResponseSize)
+		assert.Equal(t, clientErr, calls[0].Error)
+	})
+
+	t.Run("expect error", func(t *testing.T) {
+		var calls []RequestStats
+
+		req := NewRequestC(config, http.MethodGet, "/path").
+			WithRetryPolicy(DontRetry).
+			OnComplete(func(stats RequestStats) {
+				calls = append(calls, stats)
+			})
+
+		req.ExpectError().IsEqual(clientErr.Error())
+		req.chain.assert(t, success)
+
+		require.Equal(t, 1, len(calls))
+		assert.Equal(t, clientErr, calls[0].Error)
+	})
+
+	t.Run("not sent", func(t *testing.T) {
+		var calls []RequestStats
+
+		req := NewRequestC(config, http.MethodGet, "/path").
+			WithWebsocketUpgrade().
+			WithText("hello").
+			OnComplete(func(stats RequestStats) {
+				calls = append(calls, stats)
+			})
+
+		req.Expect()
+		req.chain.assert(t, failure)
+
+		assert.Equal(t, 0, len(calls))
+	})
+
+	t.Run("nil callback", func(t *testing.T) {
+		req := NewRequestC(config, http.MethodGet, "/path").
+			OnComplete(nil)
+		req.chain.assert(t, failure)
+	})
+}
+
+func TestRequestStats_Timing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("hello"))
+		}))
+	defer server.Close()
+
+	e := WithConfig(Config{
+		BaseURL:  server.URL,
+		Reporter: newMockReporter(t),
+		Client: &http.Client{
+			Transport: &http.Transport{},
+		},
+	})
+
+	var calls []RequestStats
+
+	e.GET("/").
+		OnComplete(func(stats RequestStats) {
+			calls = append(calls, stats)
+		}).
+		Expect().
+		Status(http.StatusOK)
+
+	e.chain.assert(t, success)
+
+	require.Equal(t, 1, len(calls))
+
+	timing := calls[0].Timing
+	assert.Equal(t, time.Duration(0), timing.TLSHandshake)
+	assert.True(t, timing.Connect > time.Duration(0))
+	assert.True(t, timing.TimeToFirstByte > time.Duration(0))
+	assert.True(t, timing.TimeToFirstByte <= timing.RoundTrip)
+	assert.True(t, timing.RoundTrip <= timing.Total)
+}
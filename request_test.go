@@ -3,22 +3,27 @@ package httpexpect
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"mime"
 	"mime/multipart"
+	"net"
 	"net/http"
 	neturl "net/url"
 	"os"
 	"path/filepath"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
 )
 
 func TestRequest_FailedChain(t *testing.T) {
@@ -31,51 +36,85 @@ func TestRequest_FailedChain(t *testing.T) {
 	req.chain.assert(t, failure)
 
 	req.Alias("foo")
+	req.Warn()
 	req.WithName("foo")
 	req.WithReporter(reporter)
 	req.WithAssertionHandler(assertionHandler)
 	req.WithMatcher(func(resp *Response) {
 	})
+	req.WithoutDefaultAssertions()
+	req.WithStrictTransfer(true)
+	req.WithDebug()
 	req.WithTransformer(func(r *http.Request) {
 	})
 	req.WithClient(&http.Client{})
 	req.WithHandler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	req.WithFastHandler(func(*fasthttp.RequestCtx) {})
 	req.WithContext(context.TODO())
+	req.WithContextValue("foo", "bar")
 	req.WithTimeout(0)
+	req.WithCache(NewResponseCache())
+	req.WithBodyThrottle(1)
+	req.WithBodyInterrupt(0)
+	req.WithUploadProgress(func(int64, int64) {})
+	req.WithDownloadProgress(func(int64, int64) {})
+	req.WithClientAbort(0)
 	req.WithRedirectPolicy(FollowAllRedirects)
 	req.WithMaxRedirects(1)
 	req.WithRetryPolicy(RetryAllErrors)
 	req.WithMaxRetries(1)
+	req.WithMaxConnRetries(1)
+	req.WithCredentials("foo")
+	req.WithBasicAuthFromEnv("FOO", "BAR")
+	req.WithCodec("application/x-foo", BodyDecoderFunc(
+		func([]byte, interface{}) error { return nil }))
 	req.WithRetryDelay(time.Millisecond, time.Millisecond)
 	req.WithWebsocketUpgrade()
 	req.WithWebsocketDialer(
 		NewWebsocketDialer(
 			http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})))
+	req.WithWebsocketHandshakeTimeout(time.Second)
 	req.WithPath("foo", "bar")
 	req.WithPathObject(map[string]interface{}{"foo": "bar"})
 	req.WithQuery("foo", "bar")
 	req.WithQueryObject(map[string]interface{}{"foo": "bar"})
 	req.WithQueryString("foo=bar")
+	req.WithQueryTime("foo", time.Now(), "")
+	req.WithQueryBool("foo", true)
+	req.WithQuerySlice("foo", []string{"bar"}, QueryRepeat)
 	req.WithURL("http://example.com")
 	req.WithHeaders(map[string]string{"foo": "bar"})
 	req.WithHeader("foo", "bar")
+	req.WithGRPCMetadata("foo", "bar")
 	req.WithCookies(map[string]string{"foo": "bar"})
 	req.WithCookie("foo", "bar")
 	req.WithBasicAuth("foo", "bar")
 	req.WithHost("127.0.0.1")
+	req.WithRemoteAddr("127.0.0.1:1234")
+	req.WithTLSState(&tls.ConnectionState{})
 	req.WithProto("HTTP/1.1")
 	req.WithChunked(strings.NewReader("foo"))
 	req.WithBytes([]byte("foo"))
+	req.WithBodyFromFile("foo.json")
+	req.OnComplete(func(RequestStats) {})
 	req.WithText("foo")
 	req.WithJSON(map[string]string{"foo": "bar"})
+	req.WithJSONTemplate(map[string]string{"foo": "bar"})
 	req.WithForm(map[string]string{"foo": "bar"})
 	req.WithFormField("foo", "bar")
 	req.WithFile("foo", "bar", strings.NewReader("baz"))
 	req.WithFileBytes("foo", "bar", []byte("baz"))
 	req.WithMultipart()
+	req.Validate()
 
 	resp := req.Expect()
 	resp.chain.assert(t, failure)
+
+	req.ExpectError().chain.assert(t, failure)
+
+	httpReq, err := req.Build()
+	assert.Nil(t, httpReq)
+	assert.Error(t, err)
 }
 
 func TestRequest_Constructors(t *testing.T) {
@@ -367,6 +406,144 @@ func TestRequest_Matchers(t *testing.T) {
 	assert.Same(t, resp, resps[0])
 }
 
+func TestRequest_DefaultAssertions(t *testing.T) {
+	t.Run("enabled", func(t *testing.T) {
+		var calls []string
+
+		config := Config{
+			Client:   &mockClient{},
+			Reporter: newMockReporter(t),
+			DefaultResponseAssertions: []func(*Response){
+				func(r *Response) {
+					calls = append(calls, "default1")
+				},
+				func(r *Response) {
+					calls = append(calls, "default2")
+				},
+			},
+		}
+
+		req := NewRequestC(config, "GET", "/")
+
+		req.WithMatcher(func(r *Response) {
+			calls = append(calls, "matcher")
+		})
+
+		req.Expect()
+
+		assert.Equal(t, []string{"default1", "default2", "matcher"}, calls)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		var calls []string
+
+		config := Config{
+			Client:   &mockClient{},
+			Reporter: newMockReporter(t),
+			DefaultResponseAssertions: []func(*Response){
+				func(r *Response) {
+					calls = append(calls, "default")
+				},
+			},
+		}
+
+		req := NewRequestC(config, "GET", "/")
+
+		req.WithMatcher(func(r *Response) {
+			calls = append(calls, "matcher")
+		})
+
+		req.WithoutDefaultAssertions()
+		req.chain.assert(t, success)
+
+		req.Expect()
+
+		assert.Equal(t, []string{"matcher"}, calls)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		config := Config{
+			Client: &mockClient{
+				resp: http.Response{
+					StatusCode: http.StatusInternalServerError,
+				},
+			},
+			Reporter: newMockReporter(t),
+			DefaultResponseAssertions: []func(*Response){
+				func(r *Response) {
+					r.StatusRange(Status2xx)
+				},
+			},
+		}
+
+		resp := NewRequestC(config, "GET", "/").Expect()
+		resp.chain.assert(t, failure)
+	})
+}
+
+func TestRequest_Debug(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("response body"))
+	})
+
+	logger := newMockLogger(t)
+
+	config := Config{
+		Reporter: newMockReporter(t),
+		Client: &http.Client{
+			Transport: NewBinder(handler),
+		},
+		Printers: []Printer{
+			&LogPrinter{Logger: logger, Mode: LogDebug},
+		},
+	}
+
+	NewRequestC(config, "GET", "http://example.com/normal").
+		Expect().
+		Status(http.StatusOK)
+
+	assert.False(t, logger.logged)
+
+	NewRequestC(config, "GET", "http://example.com/debug").
+		WithDebug().
+		Expect().
+		Status(http.StatusOK)
+
+	assert.True(t, logger.logged)
+	assert.Contains(t, logger.lastMessage, "GET http://example.com/debug")
+	assert.Contains(t, logger.lastMessage, "response body")
+}
+
+func TestRequest_MaxBodyBytes(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(strings.Repeat("x", 1000)))
+	})
+
+	logger := newMockLogger(t)
+
+	config := Config{
+		Reporter: newMockReporter(t),
+		Client: &http.Client{
+			Transport: NewBinder(handler),
+		},
+		Printers: []Printer{
+			NewDebugPrinter(logger, true),
+		},
+		MaxBodyBytes: 10,
+	}
+
+	resp := NewRequestC(config, "GET", "http://example.com").Expect()
+	resp.chain.assert(t, success)
+
+	// printer didn't read body beyond the limit
+	assert.True(t, logger.logged)
+	assert.NotContains(t, logger.lastMessage, strings.Repeat("x", 11))
+	assert.LessOrEqual(t, len(resp.httpResp.Body.(*bodyWrapper).memBytes), 10)
+
+	resp.Body()
+	resp.chain.assert(t, failure)
+}
+
 func TestRequest_Transformers(t *testing.T) {
 	client := &mockClient{}
 
@@ -545,6 +722,57 @@ func TestRequest_Handler(t *testing.T) {
 	})
 }
 
+func TestRequest_FastHandler(t *testing.T) {
+	t.Run("basic", func(t *testing.T) {
+		var path string
+		handler := func(ctx *fasthttp.RequestCtx) {
+			path = string(ctx.Path())
+			ctx.SetStatusCode(http.StatusAccepted)
+		}
+
+		config := Config{
+			BaseURL:  "http://example.com",
+			Reporter: newMockReporter(t),
+		}
+
+		req := NewRequestC(config, "GET", "/path").WithFastHandler(handler)
+
+		resp := req.Expect()
+		resp.chain.assert(t, success)
+
+		resp.Status(http.StatusAccepted)
+		resp.chain.assert(t, success)
+
+		assert.Equal(t, "/path", path)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		config := Config{
+			Reporter: newMockReporter(t),
+		}
+
+		req := NewRequestC(config, "GET", "/")
+		req.WithFastHandler(nil)
+		req.chain.assert(t, failure)
+	})
+
+	t.Run("reuse client", func(t *testing.T) {
+		client := &http.Client{
+			Jar: NewCookieJar(),
+		}
+
+		config := Config{
+			Reporter: newMockReporter(t),
+			Client:   client,
+		}
+
+		req := NewRequestC(config, "GET", "/")
+		req.WithFastHandler(func(*fasthttp.RequestCtx) {})
+		assert.Same(t, client.Jar, req.config.Client.(*http.Client).Jar)
+		assert.Nil(t, client.Transport)
+	})
+}
+
 func TestRequest_Proto(t *testing.T) {
 	cases := []struct {
 		name   string
@@ -987,11 +1215,10 @@ func TestRequest_PathConstruct(t *testing.T) {
 			result:      success,
 		},
 		{
-			name:        "incomplete",
-			path:        "/{arg1}/{arg2}",
-			pathArgs:    []interface{}{"foo"},
-			expectedURL: "http://example.com/foo/%7Barg2%7D",
-			result:      success,
+			name:     "incomplete",
+			path:     "/{arg1}/{arg2}",
+			pathArgs: []interface{}{"foo"},
+			result:   failure,
 		},
 		{
 			name:     "nil arg",
@@ -1020,7 +1247,9 @@ func TestRequest_PathConstruct(t *testing.T) {
 				assert.Equal(t, tc.expectedURL,
 					client.req.URL.String())
 			} else {
+				req.Expect()
 				req.chain.assert(t, failure)
+				assert.Nil(t, client.req)
 			}
 		})
 	}
@@ -1050,10 +1279,9 @@ func TestRequest_Path(t *testing.T) {
 		req := NewRequestC(config, "GET", "/{arg1}/{arg2}/{arg3}")
 		req.WithPath("ARG3", "baz")
 		req.WithPath("arg2", "bar")
-		req.Expect().chain.assert(t, success)
-		require.NotNil(t, client.req)
-		assert.Equal(t, "http://example.com/%7Barg1%7D/bar/baz",
-			client.req.URL.String())
+		req.chain.assert(t, success)
+		req.Expect().chain.assert(t, failure)
+		req.chain.assert(t, failure)
 	})
 
 	t.Run("invalid path", func(t *testing.T) {
@@ -1066,12 +1294,18 @@ func TestRequest_Path(t *testing.T) {
 	t.Run("invalid key", func(t *testing.T) {
 		req := NewRequestC(config, "GET", "{arg}")
 		req.WithPath("BAD", "value")
+		req.chain.assert(t, success)
+
+		req.Validate()
 		req.chain.assert(t, failure)
 	})
 
 	t.Run("invalid value", func(t *testing.T) {
 		req := NewRequestC(config, "GET", "{arg}")
 		req.WithPath("arg", nil)
+		req.chain.assert(t, success)
+
+		req.Validate()
 		req.chain.assert(t, failure)
 	})
 }
@@ -1117,10 +1351,9 @@ func TestRequest_PathObject(t *testing.T) {
 			"arg2": "bar",
 			"ARG3": "baz",
 		})
-		req.Expect().chain.assert(t, success)
-		require.NotNil(t, client.req)
-		assert.Equal(t, "http://example.com/%7Barg1%7D/bar/baz",
-			client.req.URL.String())
+		req.chain.assert(t, success)
+		req.Expect().chain.assert(t, failure)
+		req.chain.assert(t, failure)
 	})
 
 	t.Run("struct", func(t *testing.T) {
@@ -1149,13 +1382,14 @@ func TestRequest_PathObject(t *testing.T) {
 			Arg2: "bar",
 			Arg3: "baz",
 		})
-		req.Expect().chain.assert(t, success)
-		require.NotNil(t, client.req)
-		assert.Equal(t, "http://example.com/%7Barg1%7D/bar/baz",
-			client.req.URL.String())
+		req.chain.assert(t, success)
+		req.Expect().chain.assert(t, failure)
+		req.chain.assert(t, failure)
 	})
 
 	t.Run("struct tags", func(t *testing.T) {
+		client.req = nil
+
 		req := NewRequestC(config, "GET", "/{arg1}/{arg2}/{arg3}")
 		req.WithPathObject(struct {
 			Arg1 string
@@ -1166,10 +1400,9 @@ func TestRequest_PathObject(t *testing.T) {
 			A2:   "bar",
 			Arg3: "baz",
 		})
-		req.Expect().chain.assert(t, success)
-		require.NotNil(t, client.req)
-		assert.Equal(t, "http://example.com/foo/bar/%7Barg3%7D",
-			client.req.URL.String())
+		req.chain.assert(t, success)
+		req.Expect().chain.assert(t, failure)
+		assert.Nil(t, client.req)
 	})
 
 	t.Run("struct pointer", func(t *testing.T) {
@@ -1219,6 +1452,9 @@ func TestRequest_PathObject(t *testing.T) {
 		req.WithPathObject(map[string]interface{}{
 			"arg": nil,
 		})
+		req.chain.assert(t, success)
+
+		req.Validate()
 		req.chain.assert(t, failure)
 	})
 
@@ -1235,6 +1471,9 @@ func TestRequest_PathObject(t *testing.T) {
 		req.WithPathObject(map[string]interface{}{
 			"BAD": nil,
 		})
+		req.chain.assert(t, success)
+
+		req.Validate()
 		req.chain.assert(t, failure)
 	})
 
@@ -1318,6 +1557,26 @@ func TestRequest_Headers(t *testing.T) {
 	assert.Same(t, &client.resp, resp.Raw())
 }
 
+func TestRequest_GRPCMetadata(t *testing.T) {
+	client := &mockClient{}
+
+	config := Config{
+		Client:   client,
+		Reporter: newMockReporter(t),
+	}
+
+	req := NewRequestC(config, "GET", "url").
+		WithGRPCMetadata("tenant-id", "42").
+		WithGRPCMetadata("Trace", "abc")
+
+	req.Expect().chain.assert(t, success)
+
+	assert.Equal(t, http.Header{
+		"Grpc-Metadata-Tenant-Id": {"42"},
+		"Grpc-Metadata-Trace":     {"abc"},
+	}, client.req.Header)
+}
+
 func TestRequest_Cookies(t *testing.T) {
 	client := &mockClient{}
 
@@ -1390,7 +1649,7 @@ func TestRequest_Host(t *testing.T) {
 			expectedHost: "example2.com",
 			setupFunc: func(req *Request) {
 				req.WithHost("example1.com")
-				req.withHeader("HOST", "example2.com")
+				req.withHeader(req.chain, "HOST", "example2.com")
 			},
 		},
 		{
@@ -1400,7 +1659,7 @@ func TestRequest_Host(t *testing.T) {
 			expectedHost: "example1.com",
 			setupFunc: func(req *Request) {
 				req.WithHost("example2.com")
-				req.withHeader("HOST", "example1.com")
+				req.withHeader(req.chain, "HOST", "example1.com")
 			},
 		},
 	}
@@ -1916,6 +2175,23 @@ func TestRequest_BodyJSON(t *testing.T) {
 		assert.Same(t, &client.resp, resp.Raw())
 	})
 
+	t.Run("placeholders", func(t *testing.T) {
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+			Faker:    NewFaker(1),
+		}
+
+		req := NewRequestC(config, "GET", "url")
+
+		req.WithJSON(map[string]interface{}{"key": "{{faker.bad}}"})
+
+		resp := req.Expect()
+		resp.chain.assert(t, success)
+
+		assert.Equal(t, `{"key":"{{faker.bad}}"}`, resp.Body().Raw())
+	})
+
 	t.Run("marshal error", func(t *testing.T) {
 		req := NewRequestC(config, "GET", "url")
 
@@ -1928,6 +2204,105 @@ func TestRequest_BodyJSON(t *testing.T) {
 	})
 }
 
+func TestRequest_BodyJSONTemplate(t *testing.T) {
+	client := &mockClient{}
+
+	config := Config{
+		Client:   client,
+		Reporter: newMockReporter(t),
+		Faker:    NewFaker(1),
+	}
+
+	t.Run("placeholders", func(t *testing.T) {
+		values := map[string]interface{}{}
+
+		req := NewRequestC(config, "GET", "url")
+
+		req.WithJSONTemplate(map[string]interface{}{
+			"email": "{{faker.email}}",
+			"id":    "{{faker.uuid}}",
+			"age":   "{{faker.int}}",
+		}, values)
+		req.chain.assert(t, success)
+
+		require.Equal(t, 3, len(values))
+
+		assert.NotEmpty(t, values["faker.email"])
+		assert.NotEmpty(t, values["faker.uuid"])
+		assert.IsType(t, 0, values["faker.int"])
+
+		resp := req.Expect()
+		resp.chain.assert(t, success)
+
+		assert.Equal(t, "application/json; charset=utf-8",
+			client.req.Header.Get("Content-Type"))
+
+		resp.JSON().Object().
+			HasValue("email", values["faker.email"]).
+			HasValue("id", values["faker.uuid"]).
+			HasValue("age", values["faker.int"])
+		resp.chain.assert(t, success)
+	})
+
+	t.Run("no values", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "url")
+
+		req.WithJSONTemplate(map[string]interface{}{"email": "{{faker.email}}"})
+
+		resp := req.Expect()
+		resp.chain.assert(t, success)
+
+		resp.JSON().Object().Value("email").String().NotEmpty().Contains("@")
+		resp.chain.assert(t, success)
+	})
+
+	t.Run("separate values", func(t *testing.T) {
+		values1 := map[string]interface{}{}
+		values2 := map[string]interface{}{}
+
+		NewRequestC(config, "GET", "url").
+			WithJSONTemplate(map[string]interface{}{"id": "{{faker.uuid}}"}, values1)
+		NewRequestC(config, "GET", "url").
+			WithJSONTemplate(map[string]interface{}{"id": "{{faker.uuid}}"}, values2)
+
+		assert.NotEqual(t, values1["faker.uuid"], values2["faker.uuid"])
+	})
+
+	t.Run("default faker", func(t *testing.T) {
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}
+
+		req := NewRequestC(config, "GET", "url")
+
+		req.WithJSONTemplate(map[string]interface{}{"name": "{{faker.name}}"})
+		req.chain.assert(t, success)
+	})
+
+	t.Run("bad placeholder", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "url")
+
+		req.WithJSONTemplate(map[string]interface{}{"foo": "{{faker.bad}}"})
+		req.chain.assert(t, failure)
+	})
+
+	t.Run("multiple values", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "url")
+
+		req.WithJSONTemplate(map[string]interface{}{},
+			map[string]interface{}{}, map[string]interface{}{})
+		req.chain.assert(t, failure)
+	})
+
+	t.Run("marshal error", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "url")
+
+		req.WithJSONTemplate(func() {})
+		req.chain.assert(t, failure)
+	})
+}
+
 func TestRequest_ContentLength(t *testing.T) {
 	client := &mockClient{}
 	config := Config{
@@ -2124,21 +2499,189 @@ func TestRequest_Websocket(t *testing.T) {
 			WithWebsocketUpgrade()
 		req.Expect().chain.assert(t, failure)
 	})
-}
 
-func TestRequest_RedirectsDontFollow(t *testing.T) {
-	t.Run("no body", func(t *testing.T) {
-		reporter := newMockReporter(t)
+	t.Run("cookies shared with jar", func(t *testing.T) {
+		var sessionCookie string
 
-		tp := newMockRedirectTransport()
-		tp.assertFn = func(r *http.Request) {
-			assert.Equal(t, http.NoBody, r.Body)
-		}
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if c, err := r.Cookie("session"); err == nil {
+				sessionCookie = c.Value
+			}
 
-		config := Config{
-			Client:   &http.Client{Transport: tp},
-			Reporter: reporter,
-		}
+			upgrader := websocket.Upgrader{}
+			conn, err := upgrader.Upgrade(w, r, http.Header{
+				"Set-Cookie": {"ws=yes; Path=/"},
+			})
+			if err != nil {
+				return
+			}
+			_ = conn.Close()
+		})
+
+		jar := NewCookieJar()
+
+		baseURL, _ := neturl.Parse("http://example.com")
+		jar.SetCookies(baseURL, []*http.Cookie{{Name: "session", Value: "123"}})
+
+		config := Config{
+			Reporter:        newMockReporter(t),
+			Client:          &http.Client{Jar: jar},
+			WebsocketDialer: NewWebsocketDialer(handler),
+			BaseURL:         "http://example.com",
+		}
+
+		req := NewRequestC(config, "GET", "/ws").WithWebsocketUpgrade()
+		req.Expect().
+			Status(http.StatusSwitchingProtocols).
+			Websocket().
+			Disconnect()
+		req.chain.assert(t, success)
+
+		assert.Equal(t, "123", sessionCookie)
+
+		var names []string
+		for _, c := range jar.Cookies(baseURL) {
+			names = append(names, c.Name)
+		}
+		assert.ElementsMatch(t, []string{"session", "ws"}, names)
+	})
+
+	t.Run("dialer with own jar", func(t *testing.T) {
+		var header http.Header
+
+		dialer := &websocket.Dialer{
+			Jar: NewCookieJar(),
+			NetDial: func(network, addr string) (net.Conn, error) {
+				return nil, errors.New("not connected")
+			},
+		}
+
+		clientJar := NewCookieJar()
+
+		baseURL, _ := neturl.Parse("http://example.com")
+		clientJar.SetCookies(baseURL, []*http.Cookie{{Name: "session", Value: "123"}})
+
+		config := Config{
+			Reporter: newMockReporter(t),
+			Client:   &http.Client{Jar: clientJar},
+			WebsocketDialer: WebsocketDialerFunc(func(
+				url string, h http.Header,
+			) (*websocket.Conn, *http.Response, error) {
+				header = h
+				return dialer.Dial(url, h)
+			}),
+			BaseURL: "http://example.com",
+		}
+
+		// WebsocketDialerFunc is not *websocket.Dialer, so client jar is used
+		NewRequestC(config, "GET", "/ws").WithWebsocketUpgrade().Expect()
+		assert.Equal(t, "session=123", header.Get("Cookie"))
+
+		// *websocket.Dialer with own jar manages cookies itself
+		config.WebsocketDialer = dialer
+		assert.Nil(t, NewRequestC(config, "GET", "/ws").websocketJar())
+
+		config.WebsocketDialer = &websocket.Dialer{}
+		assert.Equal(t, clientJar, NewRequestC(config, "GET", "/ws").websocketJar())
+	})
+
+	t.Run("handshake timeout", func(t *testing.T) {
+		var deadline time.Time
+
+		dialer := &mockContextDialer{
+			dialFn: func(ctx context.Context) error {
+				deadline, _ = ctx.Deadline()
+				<-ctx.Done()
+				return ctx.Err()
+			},
+		}
+
+		config := Config{
+			Reporter:        newMockReporter(t),
+			WebsocketDialer: dialer,
+		}
+
+		start := time.Now()
+
+		req := NewRequestC(config, "GET", "url").
+			WithWebsocketUpgrade().
+			WithWebsocketHandshakeTimeout(10 * time.Millisecond)
+		req.Expect().chain.assert(t, failure)
+
+		assert.False(t, deadline.IsZero())
+		assert.True(t, deadline.Sub(start) <= time.Second)
+		assert.Equal(t, 0, dialer.dialCalled)
+	})
+
+	t.Run("no handshake timeout", func(t *testing.T) {
+		dialer := &mockContextDialer{}
+
+		config := Config{
+			Reporter:        newMockReporter(t),
+			WebsocketDialer: dialer,
+		}
+
+		req := NewRequestC(config, "GET", "url").WithWebsocketUpgrade()
+		req.Expect().chain.assert(t, success)
+
+		assert.Equal(t, 1, dialer.dialCalled)
+	})
+
+	t.Run("handshake timeout not supported", func(t *testing.T) {
+		dialer := WebsocketDialerFunc(func(
+			_ string, _ http.Header,
+		) (*websocket.Conn, *http.Response, error) {
+			return &websocket.Conn{}, &http.Response{}, nil
+		})
+
+		config := Config{
+			Reporter:        newMockReporter(t),
+			WebsocketDialer: dialer,
+		}
+
+		req := NewRequestC(config, "GET", "url").
+			WithWebsocketUpgrade().
+			WithWebsocketHandshakeTimeout(time.Second)
+		req.Expect().chain.assert(t, failure)
+	})
+}
+
+type mockContextDialer struct {
+	dialFn     func(ctx context.Context) error
+	dialCalled int
+}
+
+func (d *mockContextDialer) Dial(
+	url string, h http.Header,
+) (*websocket.Conn, *http.Response, error) {
+	d.dialCalled++
+	return &websocket.Conn{}, &http.Response{}, nil
+}
+
+func (d *mockContextDialer) DialContext(
+	ctx context.Context, url string, h http.Header,
+) (*websocket.Conn, *http.Response, error) {
+	if d.dialFn != nil {
+		if err := d.dialFn(ctx); err != nil {
+			return nil, nil, err
+		}
+	}
+	return &websocket.Conn{}, &http.Response{}, nil
+}
+
+func TestRequest_RedirectsDontFollow(t *testing.T) {
+	t.Run("no body", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		tp := newMockRedirectTransport()
+		tp.assertFn = func(r *http.Request) {
+			assert.Equal(t, http.NoBody, r.Body)
+		}
+
+		config := Config{
+			Client:   &http.Client{Transport: tp},
+			Reporter: reporter,
+		}
 
 		req := NewRequestC(config, http.MethodPut, "/url").
 			WithRedirectPolicy(DontFollowRedirects)
@@ -3221,6 +3764,170 @@ func TestRequest_RetriesLimit(t *testing.T) {
 	assert.Equal(t, 1+3, callCount)
 }
 
+func TestRequest_RetriesConnection(t *testing.T) {
+	connReset := &net.OpError{
+		Op:  "read",
+		Net: "tcp",
+		Err: syscall.ECONNRESET,
+	}
+
+	t.Run("connection error", func(t *testing.T) {
+		callCount := 0
+
+		client := &mockClient{
+			err: connReset,
+			cb: func(req *http.Request) {
+				callCount++
+			},
+		}
+
+		handler := &mockAssertionHandler{}
+
+		config := Config{
+			Client:           client,
+			AssertionHandler: handler,
+		}
+
+		req := NewRequestC(config, http.MethodGet, "/url").
+			WithRetryPolicy(DontRetry).
+			WithMaxRetries(1).
+			WithMaxConnRetries(2).
+			WithRetryDelay(0, 0)
+		req.sleepFn = mockSleep
+		req.chain.assert(t, success)
+
+		resp := req.Expect()
+		resp.chain.assert(t, failure)
+
+		// Should retry regardless of policy, until max conn retries is reached
+		assert.Equal(t, 1+2, callCount)
+		assert.Equal(t, 2, req.connRetries)
+
+		require.NotNil(t, handler.failure)
+		assert.Contains(t, fmt.Sprint(handler.failure.Errors),
+			"error category: connection reset (connection-level error)")
+		assert.Contains(t, fmt.Sprint(handler.failure.Errors),
+			"gave up after 3 attempts (2 connection retries)")
+	})
+
+	t.Run("timeout error", func(t *testing.T) {
+		callCount := 0
+
+		client := &mockClient{
+			err: &mockNetError{
+				isTimeout: true,
+			},
+			cb: func(req *http.Request) {
+				callCount++
+			},
+		}
+
+		handler := &mockAssertionHandler{}
+
+		config := Config{
+			Client:           client,
+			AssertionHandler: handler,
+		}
+
+		req := NewRequestC(config, http.MethodGet, "/url").
+			WithRetryPolicy(RetryTimeoutErrors).
+			WithMaxRetries(1).
+			WithMaxConnRetries(3).
+			WithRetryDelay(0, 0)
+		req.sleepFn = mockSleep
+		req.chain.assert(t, success)
+
+		resp := req.Expect()
+		resp.chain.assert(t, failure)
+
+		// Should use max retries instead of max conn retries
+		assert.Equal(t, 1+1, callCount)
+		assert.Equal(t, 0, req.connRetries)
+
+		require.NotNil(t, handler.failure)
+		assert.Contains(t, fmt.Sprint(handler.failure.Errors),
+			"error category: timeout")
+	})
+
+	t.Run("separate counters", func(t *testing.T) {
+		callCount := 0
+
+		client := &mockClient{
+			resp: http.Response{
+				StatusCode: http.StatusServiceUnavailable,
+			},
+			err: connReset,
+			cb: func(req *http.Request) {
+				callCount++
+			},
+		}
+
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}
+
+		req := NewRequestC(config, http.MethodGet, "/url").
+			WithRetryPolicy(RetryAllErrors).
+			WithMaxRetries(2).
+			WithMaxConnRetries(2).
+			WithRetryDelay(0, 0)
+
+		var stats RequestStats
+		req.OnComplete(func(s RequestStats) {
+			stats = s
+		})
+
+		req.sleepFn = func(time.Duration) <-chan time.Time {
+			// first two attempts fail on connection level,
+			// then server responds with error
+			if callCount == 2 {
+				client.err = nil
+			}
+			return mockSleep(0)
+		}
+		req.chain.assert(t, success)
+
+		resp := req.Expect().
+			Status(http.StatusServiceUnavailable)
+		resp.chain.assert(t, success)
+
+		assert.Equal(t, 2+1+2, callCount)
+		assert.Equal(t, 2, req.connRetries)
+
+		assert.Equal(t, 4, stats.Retries)
+		assert.Equal(t, 2, stats.ConnRetries)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		callCount := 0
+
+		client := &mockClient{
+			err: connReset,
+			cb: func(req *http.Request) {
+				callCount++
+			},
+		}
+
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}
+
+		req := NewRequestC(config, http.MethodGet, "/url").
+			WithRetryPolicy(DontRetry).
+			WithMaxRetries(3)
+		req.sleepFn = mockSleep
+		req.chain.assert(t, success)
+
+		resp := req.Expect()
+		resp.chain.assert(t, failure)
+
+		// Should handle connection errors by retry policy
+		assert.Equal(t, 1, callCount)
+	})
+}
+
 func TestRequest_RetriesDelay(t *testing.T) {
 	t.Run("not exceeded", func(t *testing.T) {
 		callCount := 0
@@ -3450,6 +4157,9 @@ func TestRequest_Conflicts(t *testing.T) {
 				req.chain.assert(t, success)
 
 				tc.fn(req)
+				req.chain.assert(t, success)
+
+				req.Validate()
 				req.chain.assert(t, failure)
 			})
 
@@ -3460,6 +4170,9 @@ func TestRequest_Conflicts(t *testing.T) {
 				req.chain.assert(t, success)
 
 				req.WithText("test")
+				req.chain.assert(t, success)
+
+				req.Validate()
 				req.chain.assert(t, failure)
 			})
 		}
@@ -3488,63 +4201,296 @@ func TestRequest_Conflicts(t *testing.T) {
 				req.chain.assert(t, success)
 
 				req.WithMultipart()
+				req.chain.assert(t, success)
+
+				req.Validate()
 				req.chain.assert(t, failure)
 			})
 		}
 	})
 }
 
-func TestRequest_Usage(t *testing.T) {
-	cases := []struct {
-		name        string
-		client      Client
-		prepFunc    func(req *Request)
-		prepFails   bool
-		expectFails bool
-	}{
-		{
-			name: "WithReporter - nil argument",
-			prepFunc: func(req *Request) {
-				req.WithReporter(nil)
-			},
-			prepFails:   true,
-			expectFails: true,
-		},
-		{
-			name: "WithAssertionHandler - nil argument",
-			prepFunc: func(req *Request) {
-				req.WithAssertionHandler(nil)
-			},
-			prepFails:   true,
-			expectFails: true,
-		},
-		{
-			name: "WithMatcher - nil argument",
-			prepFunc: func(req *Request) {
-				req.WithMatcher(nil)
-			},
-			prepFails:   true,
-			expectFails: true,
-		},
-		{
-			name: "WithTransformer - nil argument",
-			prepFunc: func(req *Request) {
-				req.WithTransformer(nil)
-			},
-			prepFails:   true,
-			expectFails: true,
-		},
-		{
-			name: "WithClient - nil argument",
-			prepFunc: func(req *Request) {
-				req.WithClient(nil)
-			},
-			prepFails:   true,
-			expectFails: true,
-		},
-		{
-			name: "WithHandler - nil argument",
-			prepFunc: func(req *Request) {
+func TestRequest_Build(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		client := &mockClient{}
+
+		e := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Client:   client,
+			Reporter: newMockReporter(t),
+		})
+
+		req := e.Builder(func(req *Request) {
+			req.WithHeader("X-Builder", "1")
+		}).POST("/users/{id}", 123).
+			WithQuery("q", "v").
+			WithJSON(map[string]interface{}{"name": "john"}).
+			WithTransformer(func(r *http.Request) {
+				r.Header.Set("X-Transformer", "2")
+			})
+
+		httpReq, err := req.Build()
+		require.NoError(t, err)
+		require.NotNil(t, httpReq)
+
+		assert.Equal(t, "POST", httpReq.Method)
+		assert.Equal(t, "http://example.com/users/123?q=v", httpReq.URL.String())
+		assert.Equal(t, "1", httpReq.Header.Get("X-Builder"))
+		assert.Equal(t, "2", httpReq.Header.Get("X-Transformer"))
+		assert.Equal(t, "application/json; charset=utf-8",
+			httpReq.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(httpReq.Body)
+		require.NoError(t, err)
+		assert.Equal(t, `{"name":"john"}`, string(body))
+
+		req.chain.assert(t, success)
+
+		// request is not sent
+		assert.Nil(t, client.req)
+
+		// request can't be sent after Build
+		req.Expect().chain.assert(t, failure)
+		assert.Nil(t, client.req)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		config := Config{
+			Client:   &mockClient{},
+			Reporter: newMockReporter(t),
+		}
+
+		req := NewRequestC(config, "GET", "/")
+		req.WithText("foo")
+		req.WithBytes([]byte("bar"))
+
+		httpReq, err := req.Build()
+		assert.Nil(t, httpReq)
+		require.Error(t, err)
+
+		var checkErr *CheckError
+		require.True(t, errors.As(err, &checkErr))
+		assert.Equal(t, 1, len(checkErr.Failures))
+		assert.Equal(t, AssertUsage, checkErr.Failures[0].Failure.Type)
+
+		req.chain.assert(t, failure)
+	})
+}
+
+func TestRequest_Validate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		client := &mockClient{}
+
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}
+
+		req := NewRequestC(config, "GET", "/{arg}")
+		req.WithPath("arg", "foo")
+		req.WithHeader("Name", "value")
+		req.WithText("test")
+
+		req.Validate()
+		req.chain.assert(t, success)
+
+		req.Expect().chain.assert(t, success)
+		require.NotNil(t, client.req)
+		assert.Equal(t, "/foo", client.req.URL.String())
+	})
+
+	t.Run("aggregated", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		config := Config{
+			Client:           &mockClient{},
+			AssertionHandler: handler,
+		}
+
+		req := NewRequestC(config, "GET", "/{arg1}/{arg2}", "foo")
+		req.WithHeader("Bad Name", "value")
+		req.WithHeader("Name", "bad\nvalue")
+		req.WithText("test")
+		req.WithJSON(map[string]interface{}{"a": "b"})
+		req.chain.assert(t, success)
+
+		req.Validate()
+		req.chain.assert(t, failure)
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertUsage, handler.failure.Type)
+		assert.Equal(t, 5, len(handler.failure.Errors))
+		assert.Equal(t, 1, handler.failureCalled)
+	})
+
+	t.Run("websocket", func(t *testing.T) {
+		config := Config{
+			Client:   &mockClient{},
+			Reporter: newMockReporter(t),
+		}
+
+		req := NewRequestC(config, "GET", "/")
+		req.WithText("test")
+		req.WithWebsocketUpgrade()
+		req.chain.assert(t, success)
+
+		req.Expect().chain.assert(t, failure)
+		req.chain.assert(t, failure)
+	})
+
+	t.Run("provider", func(t *testing.T) {
+		client := &mockClient{}
+
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}
+
+		req := NewRequestC(config, "GET", "/{arg}")
+		req.WithPathFrom("arg", ValueProviderFunc(func() (interface{}, error) {
+			return "foo", nil
+		}))
+
+		req.Validate()
+		req.chain.assert(t, success)
+
+		req.Expect().chain.assert(t, success)
+		require.NotNil(t, client.req)
+		assert.Equal(t, "/foo", client.req.URL.String())
+	})
+}
+
+func TestRequest_Usage(t *testing.T) {
+	cases := []struct {
+		name        string
+		client      Client
+		prepFunc    func(req *Request)
+		prepFails   bool
+		expectFails bool
+	}{
+		{
+			name: "WithReporter - nil argument",
+			prepFunc: func(req *Request) {
+				req.WithReporter(nil)
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithAssertionHandler - nil argument",
+			prepFunc: func(req *Request) {
+				req.WithAssertionHandler(nil)
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithMatcher - nil argument",
+			prepFunc: func(req *Request) {
+				req.WithMatcher(nil)
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithTransformer - nil argument",
+			prepFunc: func(req *Request) {
+				req.WithTransformer(nil)
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithClient - nil argument",
+			prepFunc: func(req *Request) {
+				req.WithClient(nil)
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithContextValue - nil key",
+			prepFunc: func(req *Request) {
+				req.WithContextValue(nil, "value")
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithFastHandler - nil argument",
+			prepFunc: func(req *Request) {
+				req.WithFastHandler(nil)
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithGRPCMetadata - empty key",
+			prepFunc: func(req *Request) {
+				req.WithGRPCMetadata("", "value")
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithCache - nil argument",
+			prepFunc: func(req *Request) {
+				req.WithCache(nil)
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithBodyThrottle - zero argument",
+			prepFunc: func(req *Request) {
+				req.WithBodyThrottle(0)
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithUploadProgress - nil argument",
+			prepFunc: func(req *Request) {
+				req.WithUploadProgress(nil)
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithDownloadProgress - nil argument",
+			prepFunc: func(req *Request) {
+				req.WithDownloadProgress(nil)
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithBodyInterrupt - negative argument",
+			prepFunc: func(req *Request) {
+				req.WithBodyInterrupt(-1)
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithClientAbort - negative argument",
+			prepFunc: func(req *Request) {
+				req.WithClientAbort(-1)
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithTLSState - nil argument",
+			prepFunc: func(req *Request) {
+				req.WithTLSState(nil)
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithHandler - nil argument",
+			prepFunc: func(req *Request) {
 				req.WithHandler(nil)
 			},
 			prepFails:   true,
@@ -3574,6 +4520,14 @@ func TestRequest_Usage(t *testing.T) {
 			prepFails:   true,
 			expectFails: true,
 		},
+		{
+			name: "WithMaxConnRetries - negative argument",
+			prepFunc: func(req *Request) {
+				req.WithMaxConnRetries(-1)
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
 		{
 			name: "WithRetryDelay - invalid range",
 			prepFunc: func(req *Request) {
@@ -3582,6 +4536,14 @@ func TestRequest_Usage(t *testing.T) {
 			prepFails:   true,
 			expectFails: true,
 		},
+		{
+			name: "WithWebsocketHandshakeTimeout - negative argument",
+			prepFunc: func(req *Request) {
+				req.WithWebsocketHandshakeTimeout(-1)
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
 		{
 			name: "WithWebsocketDialer - nil argument",
 			prepFunc: func(req *Request) {
@@ -3595,7 +4557,23 @@ func TestRequest_Usage(t *testing.T) {
 			prepFunc: func(req *Request) {
 				req.WithPath("test-key", nil)
 			},
-			prepFails:   true,
+			prepFails:   false,
+			expectFails: true,
+		},
+		{
+			name: "WithHeader - invalid name",
+			prepFunc: func(req *Request) {
+				req.WithHeader("Bad Name", "value")
+			},
+			prepFails:   false,
+			expectFails: true,
+		},
+		{
+			name: "WithHeader - invalid value",
+			prepFunc: func(req *Request) {
+				req.WithHeader("Name", "bad\r\nvalue")
+			},
+			prepFails:   false,
 			expectFails: true,
 		},
 		{
@@ -3606,6 +4584,47 @@ func TestRequest_Usage(t *testing.T) {
 			prepFails:   true,
 			expectFails: true,
 		},
+		{
+			name: "WithCodec - empty media type",
+			prepFunc: func(req *Request) {
+				req.WithCodec("", BodyDecoderFunc(
+					func([]byte, interface{}) error { return nil }))
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithCodec - nil argument",
+			prepFunc: func(req *Request) {
+				req.WithCodec("application/x-foo", nil)
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithQuerySlice - nil argument",
+			prepFunc: func(req *Request) {
+				req.WithQuerySlice("test-query", nil, QueryRepeat)
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithQuerySlice - not a slice",
+			prepFunc: func(req *Request) {
+				req.WithQuerySlice("test-query", 123, QueryRepeat)
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
+		{
+			name: "WithQuerySlice - invalid style",
+			prepFunc: func(req *Request) {
+				req.WithQuerySlice("test-query", []int{1}, QuerySliceStyle(-1))
+			},
+			prepFails:   true,
+			expectFails: true,
+		},
 		{
 			name: "WithURL - invalid url",
 			prepFunc: func(req *Request) {
@@ -3686,6 +4705,102 @@ func TestRequest_Order(t *testing.T) {
 				req.Expect()
 			},
 		},
+		{
+			name: "Warn after Expect",
+			afterFunc: func(req *Request) {
+				req.Warn()
+			},
+		},
+		{
+			name: "Validate after Expect",
+			afterFunc: func(req *Request) {
+				req.Validate()
+			},
+		},
+		{
+			name: "WithoutDefaultAssertions after Expect",
+			afterFunc: func(req *Request) {
+				req.WithoutDefaultAssertions()
+			},
+		},
+		{
+			name: "WithStrictTransfer after Expect",
+			afterFunc: func(req *Request) {
+				req.WithStrictTransfer(true)
+			},
+		},
+		{
+			name: "WithDebug after Expect",
+			afterFunc: func(req *Request) {
+				req.WithDebug()
+			},
+		},
+		{
+			name: "WithContextValue after Expect",
+			afterFunc: func(req *Request) {
+				req.WithContextValue("key", "value")
+			},
+		},
+		{
+			name: "WithFastHandler after Expect",
+			afterFunc: func(req *Request) {
+				req.WithFastHandler(func(*fasthttp.RequestCtx) {})
+			},
+		},
+		{
+			name: "WithGRPCMetadata after Expect",
+			afterFunc: func(req *Request) {
+				req.WithGRPCMetadata("key", "value")
+			},
+		},
+		{
+			name: "WithRemoteAddr after Expect",
+			afterFunc: func(req *Request) {
+				req.WithRemoteAddr("127.0.0.1:1234")
+			},
+		},
+		{
+			name: "WithCache after Expect",
+			afterFunc: func(req *Request) {
+				req.WithCache(NewResponseCache())
+			},
+		},
+		{
+			name: "WithBodyThrottle after Expect",
+			afterFunc: func(req *Request) {
+				req.WithBodyThrottle(1)
+			},
+		},
+		{
+			name: "WithBodyInterrupt after Expect",
+			afterFunc: func(req *Request) {
+				req.WithBodyInterrupt(1)
+			},
+		},
+		{
+			name: "WithClientAbort after Expect",
+			afterFunc: func(req *Request) {
+				req.WithClientAbort(time.Second)
+			},
+		},
+		{
+			name: "Build after Expect",
+			afterFunc: func(req *Request) {
+				_, _ = req.Build()
+			},
+		},
+		{
+			name: "ExpectError after Expect",
+			afterFunc: func(req *Request) {
+				req.ExpectError()
+			},
+		},
+		{
+			name: "WithTLSState after Expect",
+			afterFunc: func(req *Request) {
+				req.WithTLSState(&tls.ConnectionState{})
+			},
+		},
 		{
 			name: "WithName after Expect",
 			afterFunc: func(req *Request) {
@@ -3766,6 +4881,12 @@ func TestRequest_Order(t *testing.T) {
 				req.WithMaxRetries(10)
 			},
 		},
+		{
+			name: "WithMaxConnRetries after Expect",
+			afterFunc: func(req *Request) {
+				req.WithMaxConnRetries(10)
+			},
+		},
 		{
 			name: "WithRetryDelay after Expect",
 			afterFunc: func(req *Request) {
@@ -3778,6 +4899,12 @@ func TestRequest_Order(t *testing.T) {
 				req.WithWebsocketUpgrade()
 			},
 		},
+		{
+			name: "WithWebsocketHandshakeTimeout after Expect",
+			afterFunc: func(req *Request) {
+				req.WithWebsocketHandshakeTimeout(time.Second)
+			},
+		},
 		{
 			name: "WithWebsocketDialer after Expect",
 			afterFunc: func(req *Request) {
@@ -3818,6 +4945,55 @@ func TestRequest_Order(t *testing.T) {
 				req.WithQueryString("a=123&b=hello")
 			},
 		},
+		{
+			name: "WithCredentials after Expect",
+			afterFunc: func(req *Request) {
+				req.WithCredentials("foo")
+			},
+		},
+		{
+			name: "WithUploadProgress after Expect",
+			afterFunc: func(req *Request) {
+				req.WithUploadProgress(func(int64, int64) {})
+			},
+		},
+		{
+			name: "WithDownloadProgress after Expect",
+			afterFunc: func(req *Request) {
+				req.WithDownloadProgress(func(int64, int64) {})
+			},
+		},
+		{
+			name: "WithBasicAuthFromEnv after Expect",
+			afterFunc: func(req *Request) {
+				req.WithBasicAuthFromEnv("FOO", "BAR")
+			},
+		},
+		{
+			name: "WithCodec after Expect",
+			afterFunc: func(req *Request) {
+				req.WithCodec("application/x-foo", BodyDecoderFunc(
+					func([]byte, interface{}) error { return nil }))
+			},
+		},
+		{
+			name: "WithQueryTime after Expect",
+			afterFunc: func(req *Request) {
+				req.WithQueryTime("a", time.Now(), time.RFC3339)
+			},
+		},
+		{
+			name: "WithQueryBool after Expect",
+			afterFunc: func(req *Request) {
+				req.WithQueryBool("a", true)
+			},
+		},
+		{
+			name: "WithQuerySlice after Expect",
+			afterFunc: func(req *Request) {
+				req.WithQuerySlice("a", []int{1, 2}, QueryComma)
+			},
+		},
 		{
 			name: "WithURL after Expect",
 			afterFunc: func(req *Request) {
@@ -3882,6 +5058,18 @@ func TestRequest_Order(t *testing.T) {
 				req.WithBytes(nil)
 			},
 		},
+		{
+			name: "OnComplete after Expect",
+			afterFunc: func(req *Request) {
+				req.OnComplete(func(RequestStats) {})
+			},
+		},
+		{
+			name: "WithBodyFromFile after Expect",
+			afterFunc: func(req *Request) {
+				req.WithBodyFromFile("foo.json")
+			},
+		},
 		{
 			name: "WithText after Expect",
 			afterFunc: func(req *Request) {
@@ -3960,6 +5148,123 @@ func TestRequest_Order(t *testing.T) {
 	}
 }
 
+func TestRequest_Warn(t *testing.T) {
+	reporter := newMockReporter(t)
+	warningLogger := newMockLogger(t)
+
+	config := Config{
+		Client: &mockClient{
+			resp: http.Response{
+				StatusCode: http.StatusNotFound,
+			},
+		},
+		Reporter:      reporter,
+		WarningLogger: warningLogger,
+	}
+
+	req := NewRequestC(config, "GET", "/")
+	req.Warn()
+	req.chain.assert(t, success)
+
+	resp := req.Expect()
+	resp.Status(http.StatusOK)
+	resp.chain.assert(t, failure)
+
+	assert.False(t, reporter.reported)
+	assert.True(t, warningLogger.logged)
+}
+
+func TestRequest_Parallel(t *testing.T) {
+	callFromGoroutine := func(fn func()) {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			fn()
+		}()
+		<-done
+	}
+
+	t.Run("same goroutine", func(t *testing.T) {
+		config := Config{
+			Client:   &mockClient{},
+			Reporter: newMockReporter(t),
+			Parallel: true,
+		}
+
+		req := NewRequestC(config, "GET", "/")
+
+		req.WithHeader("foo", "bar")
+		req.chain.assert(t, success)
+
+		req.Expect()
+		req.chain.assert(t, success)
+	})
+
+	t.Run("WithHeader from another goroutine", func(t *testing.T) {
+		config := Config{
+			Client:   &mockClient{},
+			Reporter: newMockReporter(t),
+			Parallel: true,
+		}
+
+		req := NewRequestC(config, "GET", "/")
+
+		callFromGoroutine(func() {
+			req.WithHeader("foo", "bar")
+		})
+		req.chain.assert(t, failure)
+	})
+
+	t.Run("Expect from another goroutine", func(t *testing.T) {
+		config := Config{
+			Client:   &mockClient{},
+			Reporter: newMockReporter(t),
+			Parallel: true,
+		}
+
+		req := NewRequestC(config, "GET", "/")
+
+		callFromGoroutine(func() {
+			req.Expect()
+		})
+		req.chain.assert(t, failure)
+	})
+
+	t.Run("unknown goroutine", func(t *testing.T) {
+		config := Config{
+			Client:   &mockClient{},
+			Reporter: newMockReporter(t),
+			Parallel: true,
+		}
+
+		req := NewRequestC(config, "GET", "/")
+
+		// goroutine ID couldn't be parsed
+		req.goroutine = 0
+
+		callFromGoroutine(func() {
+			req.WithHeader("foo", "bar")
+			req.Expect()
+		})
+		req.chain.assert(t, success)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		config := Config{
+			Client:   &mockClient{},
+			Reporter: newMockReporter(t),
+		}
+
+		req := NewRequestC(config, "GET", "/")
+
+		callFromGoroutine(func() {
+			req.WithHeader("foo", "bar")
+			req.Expect()
+		})
+		req.chain.assert(t, success)
+	})
+}
+
 func TestRequest_Panics(t *testing.T) {
 	t.Run("RequestFactory is nil", func(t *testing.T) {
 		config := Config{
@@ -6,13 +6,17 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"mime"
+	"net"
 	"net/http"
+	"net/http/httptrace"
 	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/ajg/form"
 	"github.com/gorilla/websocket"
@@ -24,15 +28,22 @@ type Response struct {
 	config Config
 	chain  *chain
 
-	httpResp  *http.Response
-	websocket *websocket.Conn
-	rtt       *time.Duration
+	httpResp   *http.Response
+	websocket  *websocket.Conn
+	connInfo   *httptrace.GotConnInfo
+	earlyHints []http.Header
+	origin     *Expect
+	requestID  string
+	failures   *failureLog
+	transfer   *transferStats
+	rtt        *time.Duration
 
 	content       []byte
 	contentState  contentState
 	contentMethod string
 
-	cookies []*http.Cookie
+	cookies     []*http.Cookie
+	headerOrder []string
 }
 
 type contentState int
@@ -90,22 +101,36 @@ func NewResponseC(
 }
 
 type responseOpts struct {
-	config    Config
-	chain     *chain
-	httpResp  *http.Response
-	websocket *websocket.Conn
-	rtt       []time.Duration
+	config     Config
+	chain      *chain
+	httpResp   *http.Response
+	websocket  *websocket.Conn
+	connInfo   *httptrace.GotConnInfo
+	earlyHints []http.Header
+	origin     *Expect
+	requestID  string
+	failures   *failureLog
+	transfer   *transferStats
+	rtt        []time.Duration
 }
 
 func newResponse(opts responseOpts) *Response {
 	opts.config.validate()
 
+	opts.config.JSONDecoder = jsonDecoderOrDefault(opts.config.JSONDecoder)
+
 	r := &Response{
 		config:       opts.config,
 		chain:        opts.chain.clone(),
+		failures:     opts.failures,
+		transfer:     opts.transfer,
 		contentState: contentPending,
 	}
 
+	if r.failures == nil {
+		r.failures = &failureLog{}
+	}
+
 	opChain := r.chain.enter("")
 	defer opChain.leave()
 
@@ -137,16 +162,45 @@ func newResponse(opts responseOpts) *Response {
 
 	r.httpResp = opts.httpResp
 
+	if r.transfer == nil && opts.websocket == nil {
+		r.transfer = &transferStats{}
+	}
+
 	if r.httpResp.Body != nil && r.httpResp.Body != http.NoBody {
 		if _, ok := r.httpResp.Body.(*bodyWrapper); !ok {
 			respCopy := *r.httpResp
 			r.httpResp = &respCopy
-			r.httpResp.Body = newBodyWrapper(r.httpResp.Body, nil)
+			if r.transfer != nil && r.transfer.download == nil {
+				r.transfer.download = newProgressReader(r.httpResp.Body,
+					r.httpResp.ContentLength, nil)
+				r.httpResp.Body = r.transfer.download
+			}
+			bw := newBodyWrapper(r.httpResp.Body, nil)
+			if r.config.BodySpoolThreshold > 0 {
+				bw.EnableSpooling(r.config.BodySpoolThreshold)
+			}
+			if r.config.MaxBodyBytes > 0 {
+				bw.SetLimit(r.config.MaxBodyBytes)
+			}
+			r.httpResp.Body = bw
+		}
+	}
+
+	if r.config.HeaderCasing == HeaderCasingCanonical {
+		if !hasCanonicalNames(r.httpResp.Header) {
+			respCopy := *r.httpResp
+			r.httpResp = &respCopy
+			r.httpResp.Header = canonicalHeader(r.httpResp.Header)
 		}
 	}
 
 	r.websocket = opts.websocket
+	r.connInfo = opts.connInfo
+	r.earlyHints = opts.earlyHints
+	r.origin = opts.origin
+	r.requestID = opts.requestID
 	r.cookies = r.httpResp.Cookies()
+	r.headerOrder = headerOrderOf(opts.connInfo)
 
 	r.chain.setResponse(r)
 
@@ -154,37 +208,65 @@ func newResponse(opts responseOpts) *Response {
 }
 
 func (r *Response) getContent(opChain *chain, method string) ([]byte, bool) {
+	content, ok, failure := r.loadContent(method)
+
+	if failure != nil {
+		opChain.fail(*failure)
+	}
+
+	return content, ok
+}
+
+// loadContent is like getContent, but returns failure instead of
+// reporting it.
+func (r *Response) loadContent(method string) ([]byte, bool, *AssertionFailure) {
 	switch r.contentState {
 	case contentRetreived:
-		return r.content, true
+		return r.content, true, nil
 
 	case contentFailed:
-		return nil, false
+		return nil, false, nil
 
 	case contentPending:
 		break
 
 	case contentHijacked:
-		opChain.fail(AssertionFailure{
+		failure := &AssertionFailure{
 			Type: AssertUsage,
 			Errors: []error{
 				fmt.Errorf("cannot call %s because Reader() was already called", method),
 			},
-		})
-		return nil, false
+		}
+		return nil, false, failure
 	}
 
 	resp := r.httpResp
 
 	if resp.Body == nil || resp.Body == http.NoBody {
-		return []byte{}, true
+		return []byte{}, true, nil
 	}
 
-	if bw, ok := resp.Body.(*bodyWrapper); ok {
+	bw, _ := resp.Body.(*bodyWrapper)
+	if bw != nil {
 		bw.Rewind()
 	}
 
-	content, err := io.ReadAll(resp.Body)
+	var (
+		content []byte
+		err     error
+	)
+
+	if bw != nil {
+		// share buffer with wrapper instead of making another copy
+		content, err = bw.Bytes()
+	} else {
+		content, err = io.ReadAll(resp.Body)
+	}
+
+	if errors.Is(err, errBodyTooLarge) {
+		err = fmt.Errorf("body exceeds Config.MaxBodyBytes (%d bytes)",
+			r.config.MaxBodyBytes)
+	}
 
 	closeErr := resp.Body.Close()
 	if err == nil {
@@ -192,25 +274,25 @@ func (r *Response) getContent(opChain *chain, method string) ([]byte, bool) {
 	}
 
 	if err != nil {
-		opChain.fail(AssertionFailure{
+		failure := &AssertionFailure{
 			Type: AssertOperation,
 			Errors: []error{
 				errors.New("failed to read response body"),
 				err,
 			},
-		})
+		}
 
 		r.content = nil
 		r.contentState = contentFailed
 
-		return nil, false
+		return nil, false, failure
 	}
 
 	r.content = content
 	r.contentState = contentRetreived
 	r.contentMethod = method
 
-	return r.content, true
+	return r.content, true, nil
 }
 
 // Raw returns underlying http.Response object.
@@ -219,6 +301,58 @@ func (r *Response) Raw() *http.Response {
 	return r.httpResp
 }
 
+// Result returns outcome of all assertions made so far on the request
+// and the response, and non-nil error if any of them failed.
+//
+// Returned error is *CheckError. Warnings (see Warn) are included into
+// CheckResult, but don't cause error.
+//
+// Result allows to use assertions outside of tests, e.g. in health checks
+// or synthetic monitoring binaries. Use it together with DetachedReporter,
+// which doesn't report failures anywhere, and inspect the returned error
+// instead.
+//
+// Example:
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		BaseURL:  "http://example.com",
+//		Reporter: httpexpect.NewDetachedReporter(),
+//	})
+//
+//	resp := e.GET("/health").Expect()
+//	resp.Status(http.StatusOK).JSON().Object().HasValue("status", "ok")
+//
+//	if _, err := resp.Result(); err != nil {
+//		log.Printf("health check failed: %s", err)
+//	}
+func (r *Response) Result() (*CheckResult, error) {
+	result := &CheckResult{}
+
+	if r.httpResp != nil {
+		result.StatusCode = r.httpResp.StatusCode
+	}
+
+	if r.rtt != nil {
+		result.RoundTripTime = *r.rtt
+	}
+
+	for _, failure := range r.failures.get() {
+		switch failure.Failure.Severity {
+		case SeverityError:
+			result.Failures = append(result.Failures, failure)
+		case SeverityWarning:
+			result.Warnings = append(result.Warnings, failure)
+		}
+	}
+
+	if len(result.Failures) != 0 ||
+		(len(result.Warnings) == 0 && r.chain.treeFailed()) {
+		return result, &CheckError{Failures: result.Failures}
+	}
+
+	return result, nil
+}
+
 // Alias is similar to Value.Alias.
 func (r *Response) Alias(name string) *Response {
 	opChain := r.chain.enter("Alias(%q)", name)
@@ -228,6 +362,45 @@ func (r *Response) Alias(name string) *Response {
 	return r
 }
 
+// Warn returns a copy of Response that reports failures as warnings.
+//
+// Failures of assertions made on returned Response and its children get
+// SeverityWarning. They are printed using Config.WarningLogger, but don't
+// cause test failure. Assertions made on original Response are not affected.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.Status(http.StatusOK)
+//	resp.Warn().Header("X-Request-ID").NotEmpty()
+func (r *Response) Warn() *Response {
+	opChain := r.chain.enter("Warn()")
+	defer opChain.leave()
+
+	ret := &Response{
+		config:        r.config,
+		chain:         opChain.clone(),
+		httpResp:      r.httpResp,
+		websocket:     r.websocket,
+		connInfo:      r.connInfo,
+		earlyHints:    r.earlyHints,
+		origin:        r.origin,
+		requestID:     r.requestID,
+		failures:      r.failures,
+		transfer:      r.transfer,
+		rtt:           r.rtt,
+		content:       r.content,
+		contentState:  r.contentState,
+		contentMethod: r.contentMethod,
+		cookies:       r.cookies,
+		headerOrder:   r.headerOrder,
+	}
+
+	ret.chain.setSeverity(SeverityWarning)
+
+	return ret
+}
+
 // RoundTripTime returns a new Duration instance with response round-trip time.
 //
 // The returned duration is the time interval starting just before request is
@@ -249,6 +422,148 @@ func (r *Response) RoundTripTime() *Duration {
 	return newDuration(opChain, r.rtt)
 }
 
+// ConnectionReused returns a new Boolean instance telling whether response
+// was received over a connection reused from a previous request.
+//
+// This can be used to verify keep-alive and connection pooling configuration
+// of the service or gateway. See also Expect.ConnectionStats.
+//
+// Connection info is collected using net/http/httptrace and is available only
+// for clients that use http.Transport. ConnectionReused reports failure if
+// response was received via Binder, FastBinder, or WebSocket dialer, or was
+// constructed manually.
+//
+// Example:
+//
+//	e.GET("/first").Expect()
+//	e.GET("/second").Expect().ConnectionReused().IsTrue()
+func (r *Response) ConnectionReused() *Boolean {
+	opChain := r.chain.enter("ConnectionReused()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newBoolean(opChain, false)
+	}
+
+	if r.connInfo == nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{r.connInfo},
+			Errors: []error{
+				errors.New("expected: connection info is available"),
+				errors.New("connection info is collected only for clients" +
+					" that use http.Transport"),
+			},
+		})
+		return newBoolean(opChain, false)
+	}
+
+	return newBoolean(opChain, r.connInfo.Reused)
+}
+
+// ResolvedAddr returns a new String instance with IP address of the server
+// that served the response, i.e. remote address of the connection without
+// port.
+//
+// It can be used to check which backend served request when host name is
+// resolved to several addresses, e.g. with canary routing or geo-DNS.
+// See also Config.Resolver and Config.HostOverrides.
+//
+// Like ConnectionReused, it requires connection info, which is available
+// only for clients that use http.Transport.
+//
+// Example:
+//
+//	resp := e.GET("/").Expect()
+//	resp.ResolvedAddr().IsEqual("10.0.0.5")
+func (r *Response) ResolvedAddr() *String {
+	opChain := r.chain.enter("ResolvedAddr()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	if r.connInfo == nil || r.connInfo.Conn == nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{r.connInfo},
+			Errors: []error{
+				errors.New("expected: connection info is available"),
+				errors.New("connection info is collected only for clients" +
+					" that use http.Transport"),
+			},
+		})
+		return newString(opChain, "")
+	}
+
+	addr := r.connInfo.Conn.RemoteAddr().String()
+
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+
+	return newString(opChain, addr)
+}
+
+// EchoesRequestID succeeds if response has the same request ID header
+// as the request.
+//
+// Header name is taken from Config.RequestIDHeader, or "X-Request-ID" if
+// it's empty. Request ID is either generated automatically (see
+// Config.RequestIDHeader) or set manually, e.g. using Request.WithHeader.
+//
+// Example:
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		BaseURL:         "http://example.com",
+//		Reporter:        httpexpect.NewAssertReporter(t),
+//		RequestIDHeader: "X-Request-ID",
+//	})
+//
+//	e.GET("/users").Expect().EchoesRequestID()
+func (r *Response) EchoesRequestID() *Response {
+	opChain := r.chain.enter("EchoesRequestID()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	header := requestIDHeaderOrDefault(r.config.RequestIDHeader)
+
+	expected := r.requestID
+	if expected == "" && r.httpResp.Request != nil {
+		expected = r.httpResp.Request.Header.Get(header)
+	}
+
+	if expected == "" {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("request doesn't have %q header", header),
+			},
+		})
+		return r
+	}
+
+	actual := r.httpResp.Header.Get(header)
+
+	if actual != expected {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{actual},
+			Expected: &AssertionValue{expected},
+			Errors: []error{
+				fmt.Errorf("expected: response echoes request ID in %q header",
+					header),
+			},
+		})
+	}
+
+	return r
+}
+
 // Deprecated: use RoundTripTime instead.
 func (r *Response) Duration() *Number {
 	opChain := r.chain.enter("Duration()")
@@ -279,8 +594,16 @@ func (r *Response) Status(status int) *Response {
 		return r
 	}
 
-	r.checkEqual(opChain, "http status",
-		statusCodeText(status), statusCodeText(r.httpResp.StatusCode))
+	if status != r.httpResp.StatusCode {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{statusCodeText(r.httpResp.StatusCode)},
+			Expected: &AssertionValue{statusCodeText(status)},
+			Errors: append([]error{
+				errors.New("unexpected http status value"),
+			}, r.bodyExcerpt()...),
+		})
+	}
 
 	return r
 }
@@ -340,9 +663,9 @@ func (r *Response) StatusRange(rn StatusRange) *Response {
 			Expected: &AssertionValue{AssertionList{
 				statusRangeText(int(rn)),
 			}},
-			Errors: []error{
+			Errors: append([]error{
 				errors.New("expected: http status belongs to given range"),
-			},
+			}, r.bodyExcerpt()...),
 		})
 	}
 
@@ -386,15 +709,134 @@ func (r *Response) StatusList(values ...int) *Response {
 			Type:     AssertBelongs,
 			Actual:   &AssertionValue{statusCodeText(r.httpResp.StatusCode)},
 			Expected: &AssertionValue{AssertionList(statusListText(values))},
-			Errors: []error{
+			Errors: append([]error{
 				errors.New("expected: http status belongs to given list"),
-			},
+			}, r.bodyExcerpt()...),
 		})
 	}
 
 	return r
 }
 
+const defaultStatusBodyExcerpt = 1024
+
+// bodyExcerpt returns bounded excerpt of response body, to be included
+// into failures of status checks. Errors during reading body are ignored.
+//
+// If body was not loaded yet, reads no more than the excerpt needs, and
+// leaves body usable, including by Reader.
+func (r *Response) bodyExcerpt() []error {
+	limit := r.config.StatusBodyExcerpt
+	if limit < 0 {
+		return nil
+	}
+	if limit == 0 {
+		limit = defaultStatusBodyExcerpt
+	}
+
+	content, truncated := r.peekContent(limit)
+	if len(content) == 0 {
+		return nil
+	}
+
+	if truncated {
+		cut := limit
+		for cut > 0 && cut > limit-utf8.UTFMax && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		content = content[:cut]
+	}
+
+	if !utf8.Valid(content) {
+		if truncated {
+			return []error{
+				errors.New("response body: <binary data>"),
+			}
+		}
+		return []error{
+			fmt.Errorf("response body: <%d bytes of binary data>", len(content)),
+		}
+	}
+
+	if truncated {
+		return []error{
+			fmt.Errorf("response body:\n%s\n... (truncated)",
+				r.config.Redactor.RedactString(string(content))),
+		}
+	}
+
+	excerpt := r.config.Redactor.RedactString(prettyJSON(content, r.config.Redactor))
+	if len(excerpt) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(excerpt[cut]) {
+			cut--
+		}
+		excerpt = fmt.Sprintf("%s\n... (%d more bytes)",
+			excerpt[:cut], len(excerpt)-cut)
+	}
+
+	return []error{
+		fmt.Errorf("response body:\n%s", excerpt),
+	}
+}
+
+// peekContent returns up to limit+1 bytes of response body without
+// consuming it; truncated is true if body is longer than limit.
+func (r *Response) peekContent(limit int) (content []byte, truncated bool) {
+	if r.contentState == contentRetreived {
+		return r.content, false
+	}
+
+	if r.contentState != contentPending {
+		return nil, false
+	}
+
+	bw, _ := r.httpResp.Body.(*bodyWrapper)
+	if bw == nil {
+		return nil, false
+	}
+
+	content, err := bw.Peek(int64(limit) + 1)
+	if err != nil {
+		return nil, false
+	}
+
+	return content, len(content) > limit
+}
+
+// prettyJSON returns indented body if it's valid JSON, with JSON paths
+// of redactor redacted, or body as is otherwise.
+func prettyJSON(content []byte, redactor *Redactor) string {
+	var buf bytes.Buffer
+
+	if redactor == nil || len(redactor.JSONPaths) == 0 {
+		// preserve order of keys
+		if json.Indent(&buf, content, "", "  ") != nil {
+			return string(content)
+		}
+		return buf.String()
+	}
+
+	var value interface{}
+
+	dec := json.NewDecoder(bytes.NewReader(content))
+	dec.UseNumber()
+
+	if dec.Decode(&value) != nil || dec.More() {
+		return string(content)
+	}
+
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+
+	if enc.Encode(redactor.RedactValue(value)) != nil {
+		return string(content)
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
 func statusCodeText(code int) string {
 	if s := http.StatusText(code); s != "" {
 		return strconv.Itoa(code) + " " + s
@@ -458,6 +900,8 @@ func (r *Response) Header(header string) *String {
 	opChain := r.chain.enter("Header(%q)", header)
 	defer opChain.leave()
 
+	opChain.setHeader(header)
+
 	if opChain.failed() {
 		return newString(opChain, "")
 	}
@@ -467,6 +911,235 @@ func (r *Response) Header(header string) *String {
 	return newString(opChain, value)
 }
 
+// HeaderDateTime returns a new DateTime instance with given header field
+// parsed as date and time.
+//
+// Header value may be either HTTP-date (e.g. Last-Modified, Expires) or
+// delay-seconds (e.g. Retry-After). In the latter case, the delay is added
+// to the time from response Date header, or to current time if Date header
+// is missing.
+//
+// If header is missing or can't be parsed, HeaderDateTime reports failure
+// and returns empty (but non-nil) instance.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.HeaderDateTime("Last-Modified").Lt(time.Now())
+//	resp.HeaderDateTime("Expires").Gt(time.Now())
+func (r *Response) HeaderDateTime(header string) *DateTime {
+	opChain := r.chain.enter("HeaderDateTime(%q)", header)
+	defer opChain.leave()
+
+	opChain.setHeader(header)
+
+	if opChain.failed() {
+		return newDateTime(opChain, time.Unix(0, 0))
+	}
+
+	value, ok := r.getHeader(opChain, header)
+	if !ok {
+		return newDateTime(opChain, time.Unix(0, 0))
+	}
+
+	if delay, ok := parseDelaySeconds(value); ok {
+		return newDateTime(opChain, r.headerBaseTime().Add(delay))
+	}
+
+	tm, ok := r.parseHeaderDate(opChain, value)
+	if !ok {
+		return newDateTime(opChain, time.Unix(0, 0))
+	}
+
+	return newDateTime(opChain, tm)
+}
+
+// HeaderDuration returns a new Duration instance with given header field
+// parsed as duration.
+//
+// Header value may be either delay-seconds (e.g. Retry-After: 120) or
+// HTTP-date (e.g. Retry-After: Fri, 31 Dec 1999 23:59:59 GMT). In the
+// latter case, the duration is computed relative to the time from response
+// Date header, or to current time if Date header is missing.
+//
+// If header is missing or can't be parsed, HeaderDuration reports failure
+// and returns empty (but non-nil) instance.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.HeaderDuration("Retry-After").InRange(time.Second, time.Minute)
+func (r *Response) HeaderDuration(header string) *Duration {
+	opChain := r.chain.enter("HeaderDuration(%q)", header)
+	defer opChain.leave()
+
+	opChain.setHeader(header)
+
+	if opChain.failed() {
+		return newDuration(opChain, nil)
+	}
+
+	value, ok := r.getHeader(opChain, header)
+	if !ok {
+		return newDuration(opChain, nil)
+	}
+
+	if delay, ok := parseDelaySeconds(value); ok {
+		return newDuration(opChain, &delay)
+	}
+
+	tm, ok := r.parseHeaderDate(opChain, value)
+	if !ok {
+		return newDuration(opChain, nil)
+	}
+
+	delay := tm.Sub(r.headerBaseTime())
+
+	return newDuration(opChain, &delay)
+}
+
+// HeaderOccurrences returns a new Number instance with number of times
+// header with given name occurs in response.
+//
+// Every header line counts as a separate occurrence, so a single line with
+// comma-separated values counts as one. Name is case-insensitive.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.HeaderOccurrences("Set-Cookie").IsEqual(2)
+func (r *Response) HeaderOccurrences(header string) *Number {
+	opChain := r.chain.enter("HeaderOccurrences(%q)", header)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, 0)
+	}
+
+	count := 0
+
+	if r.headerOrder != nil {
+		for _, name := range r.headerOrder {
+			if strings.EqualFold(name, header) {
+				count++
+			}
+		}
+	} else {
+		count = len(r.httpResp.Header.Values(header))
+	}
+
+	return newNumber(opChain, float64(count))
+}
+
+// HeaderOrder returns a new Array instance with names of response headers
+// in the order they were received, including duplicates.
+//
+// Names are reported as sent by server, without canonicalization.
+//
+// Header order is available only if connection was established using dial
+// function wrapped with RecordHeaderOrder. Otherwise, failure is reported.
+//
+// Example:
+//
+//	resp := e.GET("/").Expect()
+//	resp.HeaderOrder().ConsistsOf("Content-Type", "Set-Cookie", "Set-Cookie")
+func (r *Response) HeaderOrder() *Array {
+	opChain := r.chain.enter("HeaderOrder()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	if r.headerOrder == nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{r.headerOrder},
+			Errors: []error{
+				errors.New("expected: header order is available"),
+				errors.New("header order is recorded only for connections" +
+					" established via RecordHeaderOrder"),
+			},
+		})
+		return newArray(opChain, nil)
+	}
+
+	names := make([]interface{}, 0, len(r.headerOrder))
+	for _, name := range r.headerOrder {
+		names = append(names, name)
+	}
+
+	return newArray(opChain, names)
+}
+
+func (r *Response) getHeader(opChain *chain, header string) (string, bool) {
+	values := r.httpResp.Header.Values(header)
+
+	if len(values) == 0 {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{r.httpResp.Header},
+			Expected: &AssertionValue{header},
+			Errors: []error{
+				errors.New("expected: response contains header"),
+			},
+		})
+		return "", false
+	}
+
+	return strings.TrimSpace(values[0]), true
+}
+
+func (r *Response) parseHeaderDate(opChain *chain, value string) (time.Time, bool) {
+	for _, f := range httpDateFormats {
+		if tm, err := time.Parse(f.layout, value); err == nil {
+			return tm, true
+		}
+	}
+
+	var expectedFormats []interface{}
+	for _, f := range httpDateFormats {
+		expectedFormats = append(expectedFormats, f)
+	}
+	expectedFormats = append(expectedFormats, "delay-seconds")
+
+	opChain.fail(AssertionFailure{
+		Type:     AssertMatchFormat,
+		Actual:   &AssertionValue{value},
+		Expected: &AssertionValue{AssertionList(expectedFormats)},
+		Errors: []error{
+			errors.New("expected: header can be parsed to HTTP-date or delay-seconds"),
+		},
+	})
+
+	return time.Time{}, false
+}
+
+// headerBaseTime returns time relative to which delay-seconds headers
+// are interpreted.
+func (r *Response) headerBaseTime() time.Time {
+	if date := r.httpResp.Header.Get("Date"); date != "" {
+		if tm, err := http.ParseTime(date); err == nil {
+			return tm
+		}
+	}
+
+	return r.chain.now()
+}
+
+func parseDelaySeconds(value string) (time.Duration, bool) {
+	if value == "" || strings.TrimLeft(value, "0123456789") != "" {
+		return 0, false
+	}
+
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || seconds > int64(math.MaxInt64/time.Second) {
+		return 0, false
+	}
+
+	return time.Duration(seconds) * time.Second, true
+}
+
 // Cookies returns a new Array instance with all cookie names set by this response.
 // Returned Array contains a String value for every cookie name.
 //
@@ -474,6 +1147,8 @@ func (r *Response) Header(header string) *String {
 // It doesn't return session cookies from previous responses, which may be stored
 // in a cookie jar.
 //
+// See also CookieList, which preserves every cookie, not just its name.
+//
 // Example:
 //
 //	resp := NewResponse(t, response)
@@ -494,6 +1169,30 @@ func (r *Response) Cookies() *Array {
 	return newArray(opChain, names)
 }
 
+// CookieList returns a new CookieList instance with all cookies set by this response.
+// Every Set-Cookie header produces a separate element, so repeated cookies
+// with the same name are preserved.
+//
+// Like Cookies, this returns only cookies set by Set-Cookie headers of this
+// response.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.CookieList().Names().Contains("session")
+//	resp.CookieList().HasCount("session", 1)
+//	resp.CookieList().IsUnique()
+func (r *Response) CookieList() *CookieList {
+	opChain := r.chain.enter("CookieList()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newCookieList(opChain, nil)
+	}
+
+	return newCookieList(opChain, append([]*http.Cookie{}, r.cookies...))
+}
+
 // Cookie returns a new Cookie instance with specified cookie from response.
 //
 // Note that this returns only cookies set by Set-Cookie headers of this response.
@@ -723,6 +1422,184 @@ func (r *Response) HasTransferEncoding(encoding ...string) *Response {
 	return r
 }
 
+// HasConsistentContentLength succeeds if response declares body length using
+// Content-Length header, and the number of bytes in body matches it.
+//
+// It catches proxies and handlers that truncate or pad bodies without
+// updating the header. Failure is reported if:
+//   - response uses chunked transfer encoding, so that body length is not
+//     declared (or both Content-Length and chunked encoding are present)
+//   - Content-Length header is missing, has invalid value, or has multiple
+//     different values
+//   - body length doesn't match Content-Length
+//
+// Responses to HEAD requests and responses with 1xx, 204, and 304 statuses
+// have no body, so for them Content-Length header is optional. If present,
+// it should be valid, and for 1xx and 204 statuses it should be zero.
+// For HEAD and 304, it describes the body that would be sent in response
+// to GET, so its value is not checked.
+//
+// Note that when http.Transport decompresses gzip body transparently, it
+// removes Content-Length header, so this assertion fails for such responses.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.HasConsistentContentLength()
+func (r *Response) HasConsistentContentLength() *Response {
+	opChain := r.chain.enter("HasConsistentContentLength()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if r.isBodyless() {
+		r.checkBodylessContentLength(opChain)
+		return r
+	}
+
+	for _, encoding := range r.httpResp.TransferEncoding {
+		if !strings.EqualFold(encoding, "chunked") {
+			continue
+		}
+
+		errs := []error{
+			errors.New("expected: response body length is declared"),
+			errors.New("unexpected chunked transfer encoding"),
+		}
+		if len(r.httpResp.Header.Values("Content-Length")) != 0 {
+			errs = append(errs,
+				errors.New("response has both Content-Length and chunked encoding"))
+		}
+
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{r.httpResp.TransferEncoding},
+			Errors: errs,
+		})
+		return r
+	}
+
+	declared, ok := r.getContentLength(opChain)
+	if !ok {
+		return r
+	}
+
+	content, ok := r.getContent(opChain, "HasConsistentContentLength()")
+	if !ok {
+		return r
+	}
+
+	if int64(len(content)) != declared {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{len(content)},
+			Expected: &AssertionValue{declared},
+			Errors: []error{
+				errors.New("expected: body length matches Content-Length header"),
+			},
+		})
+		return r
+	}
+
+	return r
+}
+
+func (r *Response) checkBodylessContentLength(opChain *chain) {
+	if len(r.httpResp.Header.Values("Content-Length")) == 0 {
+		return
+	}
+
+	declared, ok := r.getContentLength(opChain)
+	if !ok {
+		return
+	}
+
+	status := r.httpResp.StatusCode
+
+	if declared != 0 &&
+		((status >= 100 && status <= 199) || status == http.StatusNoContent) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{declared},
+			Expected: &AssertionValue{0},
+			Errors: []error{
+				fmt.Errorf("expected: Content-Length header is zero for %d response",
+					status),
+			},
+		})
+	}
+}
+
+func (r *Response) getContentLength(opChain *chain) (int64, bool) {
+	values := r.httpResp.Header.Values("Content-Length")
+
+	if len(values) == 0 {
+		if r.httpResp.ContentLength > 0 {
+			return r.httpResp.ContentLength, true
+		}
+
+		errs := []error{
+			errors.New("expected: response has Content-Length header"),
+		}
+		if r.httpResp.Uncompressed {
+			errs = append(errs,
+				errors.New("header was removed by transport after decompressing body"))
+		}
+
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{r.httpResp.Header},
+			Errors: errs,
+		})
+		return 0, false
+	}
+
+	var length int64 = -1
+
+	for _, value := range values {
+		n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil || n < 0 {
+			opChain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{value},
+				Errors: []error{
+					errors.New("expected: Content-Length header is non-negative integer"),
+				},
+			})
+			return 0, false
+		}
+
+		if length >= 0 && n != length {
+			opChain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{values},
+				Errors: []error{
+					errors.New("expected: Content-Length header values are identical"),
+				},
+			})
+			return 0, false
+		}
+
+		length = n
+	}
+
+	return length, true
+}
+
+func (r *Response) isBodyless() bool {
+	if req := r.httpResp.Request; req != nil && req.Method == http.MethodHead {
+		return true
+	}
+
+	status := r.httpResp.StatusCode
+
+	return (status >= 100 && status <= 199) ||
+		status == http.StatusNoContent ||
+		status == http.StatusNotModified
+}
+
 // Deprecated: use HasContentType instead.
 func (r *Response) ContentType(mediaType string, charset ...string) *Response {
 	return r.HasContentType(mediaType, charset...)
@@ -751,6 +1628,11 @@ type ContentOpts struct {
 // Text succeeds if response contains "text/plain" Content-Type header
 // with empty or "utf-8" charset.
 //
+// Body is transcoded to UTF-8 from charset specified in Content-Type header
+// or detected from byte order mark (see Charset). To accept non-UTF-8 body,
+// specify expected charset in options. If charset is not known, body is
+// returned as is.
+//
 // Example:
 //
 //	resp := NewResponse(t, response)
@@ -758,6 +1640,9 @@ type ContentOpts struct {
 //	resp.Text(ContentOpts{
 //	  MediaType: "text/plain",
 //	}).IsEqual("hello, world!")
+//	resp.Text(ContentOpts{
+//	  Charset: "iso-8859-1",
+//	}).IsEqual("café")
 func (r *Response) Text(options ...ContentOpts) *String {
 	opChain := r.chain.enter("Text()")
 	defer opChain.leave()
@@ -785,6 +1670,11 @@ func (r *Response) Text(options ...ContentOpts) *String {
 		return newString(opChain, "")
 	}
 
+	content, ok = r.decodeContent(opChain, content)
+	if !ok {
+		return newString(opChain, "")
+	}
+
 	return newString(opChain, string(content))
 }
 
@@ -904,9 +1794,33 @@ func (r *Response) getJSON(
 		return nil
 	}
 
-	var value interface{}
+	value, _ := r.decodeJSON(opChain, content, content)
+
+	return value
+}
 
-	if err := json.Unmarshal(content, &value); err != nil {
+// decodeJSON decodes data, which is either the whole response body
+// or its part, checking configured limits.
+func (r *Response) decodeJSON(
+	opChain *chain, content []byte, data []byte,
+) (interface{}, bool) {
+	if r.config.MaxJSONDepth > 0 && jsonDepthExceeds(data, r.config.MaxJSONDepth) {
+		opChain.fail(AssertionFailure{
+			Type: AssertValid,
+			Actual: &AssertionValue{
+				string(content),
+			},
+			Errors: []error{
+				fmt.Errorf("expected: json nesting depth is at most %d",
+					r.config.MaxJSONDepth),
+				errors.New("json nesting depth exceeds Config.MaxJSONDepth"),
+			},
+		})
+		return nil, false
+	}
+
+	value, err := r.unmarshalJSON(data)
+	if err != nil {
 		opChain.fail(AssertionFailure{
 			Type: AssertValid,
 			Actual: &AssertionValue{
@@ -917,10 +1831,90 @@ func (r *Response) getJSON(
 				err,
 			},
 		})
-		return nil
+		return nil, false
 	}
 
-	return value
+	if r.config.StrictJSON && !r.checkDuplicateKeys(opChain, content, data) {
+		return nil, false
+	}
+
+	return value, true
+}
+
+func (r *Response) unmarshalJSON(data []byte) (interface{}, error) {
+	decoder := r.config.JSONDecoder
+
+	if r.config.DecodeTimeout <= 0 {
+		var value interface{}
+		err := decoder.Unmarshal(data, &value)
+		return value, err
+	}
+
+	type result struct {
+		value interface{}
+		err   error
+	}
+
+	// buffered, so that goroutine exits even after timeout
+	done := make(chan result, 1)
+
+	go func() {
+		var value interface{}
+		err := decoder.Unmarshal(data, &value)
+		done <- result{value, err}
+	}()
+
+	// timeout is measured in wall time, even if Config.Clock is fake
+	timer := time.NewTimer(r.config.DecodeTimeout)
+	defer timer.Stop()
+
+	select {
+	case res := <-done:
+		return res.value, res.err
+
+	case <-timer.C:
+		return nil, fmt.Errorf("decoding took longer than Config.DecodeTimeout (%v)",
+			r.config.DecodeTimeout)
+	}
+}
+
+func (r *Response) checkDuplicateKeys(
+	opChain *chain, content []byte, data []byte,
+) bool {
+	duplicates, err := findDuplicateKeys(data)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertValid,
+			Actual: &AssertionValue{
+				string(content),
+			},
+			Errors: []error{
+				errors.New("failed to decode json"),
+				err,
+			},
+		})
+		return false
+	}
+
+	if len(duplicates) != 0 {
+		errs := []error{
+			errors.New("expected: json objects don't have duplicate keys"),
+		}
+		for _, path := range duplicates {
+			errs = append(errs, fmt.Errorf("duplicate key at %s", path))
+		}
+
+		opChain.fail(AssertionFailure{
+			Type: AssertValid,
+			Actual: &AssertionValue{
+				string(content),
+			},
+			Errors: errs,
+		})
+		return false
+	}
+
+	return true
 }
 
 // JSONP returns a new Value instance with JSONP decoded from response body.
@@ -998,21 +1992,7 @@ func (r *Response) getJSONP(
 		return nil
 	}
 
-	var value interface{}
-
-	if err := json.Unmarshal(m[2], &value); err != nil {
-		opChain.fail(AssertionFailure{
-			Type: AssertValid,
-			Actual: &AssertionValue{
-				string(content),
-			},
-			Errors: []error{
-				errors.New("failed to decode json"),
-				err,
-			},
-		})
-		return nil
-	}
+	value, _ := r.decodeJSON(opChain, content, m[2])
 
 	return value
 }
@@ -0,0 +1,269 @@
+package httpexpect
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ResponseCache memoizes responses of idempotent requests.
+//
+// It's intended for expensive setup requests, like fetching feature flags
+// or service configuration, which are made by many tests in a suite but
+// always return the same result. Requests are opted-in individually using
+// Request.WithCache, or for all requests of Expect instance using
+// Expect.Builder.
+//
+// Responses are keyed by method, URL, request headers, except the header
+// configured by Config.RequestIDHeader, and cookies that client's jar adds
+// to request. Only the first request with given key is sent; subsequent
+// requests, including concurrent ones, get a copy of the same response,
+// with its own body reader.
+//
+// Cache lookup is done once per request, before retries. Only the final
+// response is cached, and only if the request would not retry it (see
+// Request.WithRetryPolicy). Failed requests are not cached.
+//
+// Cached responses are kept until invalidated using Invalidate or Clear.
+//
+// ResponseCache is safe for concurrent use.
+type ResponseCache struct {
+	mu      sync.Mutex
+	entries map[string]map[string]*responseCacheEntry
+	hits    int
+	misses  int
+}
+
+type responseCacheEntry struct {
+	key     string
+	variant string
+	done    chan struct{}
+	resp    *http.Response
+	body    []byte
+}
+
+// NewResponseCache returns a new empty ResponseCache.
+//
+// Example:
+//
+//	cache := httpexpect.NewResponseCache()
+//
+//	setup := httpexpect.Default(t, "http://example.com").
+//		Builder(func(req *httpexpect.Request) {
+//			req.WithCache(cache)
+//		})
+//
+//	setup.GET("/flags").
+//		Expect().
+//		Status(http.StatusOK)
+func NewResponseCache() *ResponseCache {
+	return &ResponseCache{
+		entries: make(map[string]map[string]*responseCacheEntry),
+	}
+}
+
+// Invalidate removes cached responses for given method and URL,
+// regardless of request headers.
+//
+// URL should be absolute, as sent by client, e.g. "http://example.com/flags".
+//
+// Example:
+//
+//	cache.Invalidate("GET", server.URL+"/flags")
+func (c *ResponseCache) Invalidate(method, url string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, responseCacheKey(method, url))
+}
+
+// Clear removes all cached responses.
+func (c *ResponseCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[string]map[string]*responseCacheEntry)
+}
+
+// Len returns number of cached responses.
+func (c *ResponseCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	n := 0
+	for _, variants := range c.entries {
+		n += len(variants)
+	}
+
+	return n
+}
+
+// Stats returns number of requests served from cache (hits)
+// and number of requests sent to the network (misses).
+func (c *ResponseCache) Stats() (hits, misses int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.hits, c.misses
+}
+
+// lookup returns cached response entry for request.
+//
+// If there is no cached response, lookup registers a pending entry and
+// returns it with hit set to false; the caller should send request and
+// then call store. Concurrent lookups with the same key wait until pending
+// entry is stored, and retry if it was not cached.
+func (c *ResponseCache) lookup(
+	client Client, req *http.Request, idHeader string,
+) (entry *responseCacheEntry, hit bool) {
+	key := responseCacheKey(req.Method, req.URL.String())
+	variant := responseCacheVariant(req.Header, jarCookies(client, req), idHeader)
+
+	for {
+		c.mu.Lock()
+
+		variants := c.entries[key]
+		if variants == nil {
+			variants = make(map[string]*responseCacheEntry)
+			c.entries[key] = variants
+		}
+
+		entry = variants[variant]
+		if entry == nil {
+			entry = &responseCacheEntry{
+				key:     key,
+				variant: variant,
+				done:    make(chan struct{}),
+			}
+			variants[variant] = entry
+
+			c.misses++
+			c.mu.Unlock()
+
+			return entry, false
+		}
+
+		c.mu.Unlock()
+
+		<-entry.done
+
+		if entry.resp != nil {
+			c.mu.Lock()
+			c.hits++
+			c.mu.Unlock()
+
+			return entry, true
+		}
+	}
+}
+
+// store completes pending entry registered by lookup.
+//
+// If cacheable is true, response is saved; its body is read fully and
+// rewound, so that it remains usable by the caller. Otherwise, entry is
+// removed and requests waiting for it are sent on their own.
+func (c *ResponseCache) store(
+	entry *responseCacheEntry, resp *http.Response, cacheable bool,
+) {
+	defer close(entry.done)
+
+	if cacheable && resp != nil {
+		body, ok := responseCacheBody(resp)
+		if ok {
+			respCopy := *resp
+			respCopy.Body = nil
+
+			entry.resp = &respCopy
+			entry.body = body
+			return
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries[entry.key] != nil && c.entries[entry.key][entry.variant] == entry {
+		delete(c.entries[entry.key], entry.variant)
+	}
+}
+
+// response returns a copy of cached response with its own body.
+func (e *responseCacheEntry) response(req *http.Request) *http.Response {
+	resp := *e.resp
+
+	resp.Header = e.resp.Header.Clone()
+	resp.Trailer = e.resp.Trailer.Clone()
+	resp.Body = io.NopCloser(bytes.NewReader(e.body))
+	resp.Request = req
+
+	return &resp
+}
+
+func responseCacheBody(resp *http.Response) ([]byte, bool) {
+	if resp.Body == nil || resp.Body == http.NoBody {
+		return nil, true
+	}
+
+	bw, ok := resp.Body.(*bodyWrapper)
+	if !ok {
+		return nil, false
+	}
+
+	b, err := bw.Bytes()
+	if err != nil {
+		return nil, false
+	}
+
+	bw.Rewind()
+
+	return append([]byte(nil), b...), true
+}
+
+// jarCookies returns cookies that client's jar will add to request.
+func jarCookies(client Client, req *http.Request) []*http.Cookie {
+	httpClient, ok := client.(*http.Client)
+	if !ok || httpClient.Jar == nil {
+		return nil
+	}
+
+	return httpClient.Jar.Cookies(req.URL)
+}
+
+func responseCacheKey(method, url string) string {
+	return strings.ToUpper(method) + " " + url
+}
+
+func responseCacheVariant(
+	header http.Header, cookies []*http.Cookie, idHeader string,
+) string {
+	idHeader = http.CanonicalHeaderKey(idHeader)
+
+	keys := make([]string, 0, len(header))
+	for k := range header {
+		if k != idHeader {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Strings(keys)
+
+	var b strings.Builder
+
+	for _, k := range keys {
+		b.WriteString(k)
+		b.WriteString(": ")
+		b.WriteString(strings.Join(header[k], ", "))
+		b.WriteString("\n")
+	}
+
+	for _, c := range cookies {
+		b.WriteString("Jar-Cookie: ")
+		b.WriteString(c.String())
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
@@ -0,0 +1,292 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newCacheTestClient(count *int64, fail *int32) Client {
+	return ClientFunc(func(req *http.Request) (*http.Response, error) {
+		n := atomic.AddInt64(count, 1)
+
+		if atomic.LoadInt32(fail) != 0 {
+			return nil, errors.New("network error")
+		}
+
+		time.Sleep(time.Millisecond)
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {"application/json"},
+				"X-Count":      {fmt.Sprint(n)},
+			},
+			Body:    newMockBody(fmt.Sprintf(`{"count": %d}`, n)),
+			Request: req,
+		}, nil
+	})
+}
+
+func TestResponseCache_Hit(t *testing.T) {
+	var (
+		count int64
+		fail  int32
+	)
+
+	cache := NewResponseCache()
+
+	e := WithConfig(Config{
+		BaseURL:         "http://example.com",
+		Client:          newCacheTestClient(&count, &fail),
+		Reporter:        newMockReporter(t),
+		RequestIDHeader: "X-Request-ID",
+	}).Builder(func(req *Request) {
+		req.WithCache(cache)
+	})
+
+	for i := 0; i < 3; i++ {
+		resp := e.GET("/flags").WithHeader("Accept", "application/json").Expect()
+
+		resp.Status(http.StatusOK)
+		resp.Header("X-Count").IsEqual("1")
+		resp.JSON().Object().HasValue("count", 1)
+
+		resp.chain.assert(t, success)
+	}
+
+	assert.Equal(t, int64(1), atomic.LoadInt64(&count))
+	assert.Equal(t, 1, cache.Len())
+
+	hits, misses := cache.Stats()
+	assert.Equal(t, 2, hits)
+	assert.Equal(t, 1, misses)
+
+	t.Run("different headers", func(t *testing.T) {
+		e.GET("/flags").WithHeader("Accept", "text/plain").
+			Expect().
+			Header("X-Count").IsEqual("2")
+
+		assert.Equal(t, 2, cache.Len())
+	})
+
+	t.Run("different url", func(t *testing.T) {
+		e.GET("/config").
+			Expect().
+			Header("X-Count").IsEqual("3")
+
+		assert.Equal(t, 3, cache.Len())
+	})
+
+	t.Run("invalidate", func(t *testing.T) {
+		cache.Invalidate("GET", "http://example.com/flags")
+		assert.Equal(t, 1, cache.Len())
+
+		e.GET("/flags").WithHeader("Accept", "application/json").
+			Expect().
+			Header("X-Count").IsEqual("4")
+
+		assert.Equal(t, 2, cache.Len())
+	})
+
+	t.Run("clear", func(t *testing.T) {
+		cache.Clear()
+		assert.Equal(t, 0, cache.Len())
+
+		e.GET("/config").
+			Expect().
+			Header("X-Count").IsEqual("5")
+	})
+}
+
+func TestResponseCache_Error(t *testing.T) {
+	var (
+		count int64
+		fail  int32 = 1
+	)
+
+	cache := NewResponseCache()
+
+	config := Config{
+		BaseURL:  "http://example.com",
+		Client:   newCacheTestClient(&count, &fail),
+		Reporter: newMockReporter(t),
+	}
+
+	NewRequestC(config, "GET", "/flags").WithCache(cache).
+		Expect().
+		chain.assert(t, failure)
+
+	assert.Equal(t, 0, cache.Len())
+
+	atomic.StoreInt32(&fail, 0)
+
+	NewRequestC(config, "GET", "/flags").WithCache(cache).
+		Expect().
+		Status(http.StatusOK).
+		chain.assert(t, success)
+
+	assert.Equal(t, int64(2), atomic.LoadInt64(&count))
+	assert.Equal(t, 1, cache.Len())
+}
+
+func TestResponseCache_Retries(t *testing.T) {
+	var (
+		count  int64
+		status int32 = http.StatusServiceUnavailable
+	)
+
+	client := ClientFunc(func(req *http.Request) (*http.Response, error) {
+		n := atomic.AddInt64(&count, 1)
+
+		return &http.Response{
+			StatusCode: int(atomic.LoadInt32(&status)),
+			Header:     http.Header{"X-Count": {fmt.Sprint(n)}},
+			Body:       newMockBody(""),
+			Request:    req,
+		}, nil
+	})
+
+	cache := NewResponseCache()
+
+	config := Config{
+		BaseURL:  "http://example.com",
+		Client:   client,
+		Reporter: newMockReporter(t),
+	}
+
+	newReq := func() *Request {
+		req := NewRequestC(config, "GET", "/flags").
+			WithCache(cache).
+			WithRetryPolicy(RetryTimeoutAndServerErrors).
+			WithMaxRetries(1)
+		req.sleepFn = mockSleep
+		return req
+	}
+
+	// retryable response is not cached
+	newReq().Expect().
+		Status(http.StatusServiceUnavailable).
+		Header("X-Count").IsEqual("2")
+
+	assert.Equal(t, 0, cache.Len())
+
+	newReq().Expect().
+		Status(http.StatusServiceUnavailable).
+		Header("X-Count").IsEqual("4")
+
+	assert.Equal(t, int64(4), atomic.LoadInt64(&count))
+
+	// final response is cached
+	atomic.StoreInt32(&status, http.StatusOK)
+
+	newReq().Expect().
+		Status(http.StatusOK).
+		Header("X-Count").IsEqual("5")
+
+	assert.Equal(t, 1, cache.Len())
+
+	newReq().Expect().
+		Status(http.StatusOK).
+		Header("X-Count").IsEqual("5")
+
+	assert.Equal(t, int64(5), atomic.LoadInt64(&count))
+}
+
+func TestResponseCache_Jar(t *testing.T) {
+	jar := NewJar()
+	transport := &mockRoundTripper{}
+
+	cache := NewResponseCache()
+
+	config := Config{
+		BaseURL: "http://example.com",
+		Client: &http.Client{
+			Jar:       jar,
+			Transport: transport,
+		},
+		Reporter: newMockReporter(t),
+	}
+
+	get := func() {
+		NewRequestC(config, "GET", "/me").WithCache(cache).
+			Expect().
+			chain.assert(t, success)
+	}
+
+	get()
+	get()
+	assert.Equal(t, 1, transport.count)
+
+	u, _ := url.Parse("http://example.com/")
+
+	// cookies from jar are part of the key
+	jar.SetCookies(u, []*http.Cookie{{Name: "session", Value: "alice"}})
+
+	get()
+	get()
+	assert.Equal(t, 2, transport.count)
+
+	jar.SetCookies(u, []*http.Cookie{{Name: "session", Value: "bob"}})
+
+	get()
+	assert.Equal(t, 3, transport.count)
+
+	assert.Equal(t, 3, cache.Len())
+}
+
+func TestResponseCache_Concurrency(t *testing.T) {
+	var (
+		count int64
+		fail  int32
+	)
+
+	cache := NewResponseCache()
+
+	config := Config{
+		BaseURL:  "http://example.com",
+		Client:   newCacheTestClient(&count, &fail),
+		Reporter: newMockReporter(t),
+	}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			NewRequestC(config, "GET", "/flags").WithCache(cache).
+				Expect().
+				JSON().Object().HasValue("count", 1)
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, int64(1), atomic.LoadInt64(&count))
+}
+
+func TestResponseCache_Usage(t *testing.T) {
+	config := Config{
+		Client:   &mockClient{},
+		Reporter: newMockReporter(t),
+	}
+
+	for _, method := range []string{"GET", "HEAD"} {
+		req := NewRequestC(config, method, "/").WithCache(NewResponseCache())
+		req.chain.assert(t, success)
+	}
+
+	for _, method := range []string{"POST", "PUT", "DELETE"} {
+		req := NewRequestC(config, method, "/").WithCache(NewResponseCache())
+		req.chain.assert(t, failure)
+	}
+}
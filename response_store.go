@@ -0,0 +1,180 @@
+package httpexpect
+
+import (
+	"errors"
+)
+
+// ResponseStore captures values from response into Environment.
+//
+// ResponseStore is returned by Response.Store. Each method extracts a value
+// from response and puts it into environment under given key. If value
+// can't be extracted, failure is reported and environment is not modified.
+type ResponseStore struct {
+	chain *chain
+	resp  *Response
+	env   *Environment
+}
+
+// Store returns a new ResponseStore instance that can be used to capture
+// values from response into env.
+//
+// If env is nil, environment associated with Expect instance is used
+// (see Expect.Env).
+//
+// This is handy in multi-step scenarios, where values returned by one
+// request are used in subsequent requests.
+//
+// Example:
+//
+//	e.POST("/users").WithJSON(user).
+//		Expect().
+//		Status(http.StatusCreated).
+//		Store(e.Env()).
+//		FromJSON("$.token", "auth_token").
+//		FromHeader("Location", "created_url")
+//
+//	e.GET(e.Env().GetString("created_url")).
+//		WithHeader("Authorization", "Bearer "+e.Env().GetString("auth_token")).
+//		Expect().
+//		Status(http.StatusOK)
+func (r *Response) Store(env *Environment) *ResponseStore {
+	opChain := r.chain.enter("Store()")
+	defer opChain.leave()
+
+	if env == nil {
+		env = opChain.env()
+	}
+
+	return &ResponseStore{
+		chain: opChain.clone(),
+		resp:  r,
+		env:   env,
+	}
+}
+
+// FromJSON decodes response body as JSON, evaluates JSONPath expression,
+// and stores the result in environment under given key.
+//
+// Requirements for response are same as for Response.JSON. Supported
+// JSONPath syntax is same as for Value.Path.
+//
+// Example:
+//
+//	resp.Store(env).FromJSON("$.user.id", "user_id")
+func (s *ResponseStore) FromJSON(path string, key string) *ResponseStore {
+	opChain := s.chain.enter("FromJSON(%q, %q)", path, key)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return s
+	}
+
+	value := s.resp.getJSON(opChain, "FromJSON()")
+	if opChain.failed() {
+		return s
+	}
+
+	result := jsonPath(opChain, value, path)
+	if opChain.failed() {
+		return s
+	}
+
+	s.env.Put(key, result.Raw())
+
+	return s
+}
+
+// FromHeader stores value of given response header in environment under
+// given key.
+//
+// If response does not have such header, reports failure.
+//
+// Example:
+//
+//	resp.Store(env).FromHeader("Location", "created_url")
+func (s *ResponseStore) FromHeader(header string, key string) *ResponseStore {
+	opChain := s.chain.enter("FromHeader(%q, %q)", header, key)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return s
+	}
+
+	values := s.resp.httpResp.Header.Values(header)
+	if len(values) == 0 {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{s.resp.httpResp.Header},
+			Expected: &AssertionValue{header},
+			Errors: []error{
+				errors.New("expected: response contains header with given name"),
+			},
+		})
+		return s
+	}
+
+	s.env.Put(key, values[0])
+
+	return s
+}
+
+// FromCookie stores value of given response cookie in environment under
+// given key.
+//
+// Only cookies set by Set-Cookie headers of this response are inspected,
+// see Response.Cookie. If response does not have such cookie, reports failure.
+//
+// Example:
+//
+//	resp.Store(env).FromCookie("session", "session_id")
+func (s *ResponseStore) FromCookie(name string, key string) *ResponseStore {
+	opChain := s.chain.enter("FromCookie(%q, %q)", name, key)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return s
+	}
+
+	names := []string{}
+	for _, c := range s.resp.cookies {
+		if c.Name == name {
+			s.env.Put(key, c.Value)
+			return s
+		}
+		names = append(names, c.Name)
+	}
+
+	opChain.fail(AssertionFailure{
+		Type:     AssertContainsElement,
+		Actual:   &AssertionValue{names},
+		Expected: &AssertionValue{name},
+		Errors: []error{
+			errors.New("expected: response contains cookie with given name"),
+		},
+	})
+
+	return s
+}
+
+// FromBody stores response body as string in environment under given key.
+//
+// Example:
+//
+//	resp.Store(env).FromBody("raw_body")
+func (s *ResponseStore) FromBody(key string) *ResponseStore {
+	opChain := s.chain.enter("FromBody(%q)", key)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return s
+	}
+
+	content, ok := s.resp.getContent(opChain, "FromBody()")
+	if !ok {
+		return s
+	}
+
+	s.env.Put(key, string(content))
+
+	return s
+}
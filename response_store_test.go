@@ -0,0 +1,139 @@
+package httpexpect
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResponseStore_FailedChain(t *testing.T) {
+	reporter := newMockReporter(t)
+	config := newMockConfig(reporter)
+	chain := newChainWithDefaults("test", reporter, flagFailed)
+
+	resp := newResponse(responseOpts{
+		config:   config,
+		chain:    chain,
+		httpResp: &http.Response{},
+	})
+
+	env := newEnvironment(newMockChain(t))
+
+	store := resp.Store(env)
+	store.chain.assert(t, failure)
+
+	store.FromJSON("$", "key")
+	store.FromHeader("foo", "key")
+	store.FromCookie("foo", "key")
+	store.FromBody("key")
+
+	assert.Equal(t, []string{}, env.List())
+}
+
+func TestResponseStore_Basic(t *testing.T) {
+	newResp := func(t *testing.T) *Response {
+		httpResp := &http.Response{
+			StatusCode: http.StatusCreated,
+			Header: http.Header{
+				"Content-Type": {"application/json"},
+				"Location":     {"/users/123"},
+				"Set-Cookie":   {"session=abc"},
+			},
+			Body: io.NopCloser(bytes.NewBufferString(
+				`{"token":"secret","user":{"id":123}}`)),
+		}
+
+		return NewResponse(newMockReporter(t), httpResp)
+	}
+
+	t.Run("chaining", func(t *testing.T) {
+		resp := newResp(t)
+		env := newEnvironment(newMockChain(t))
+
+		store := resp.Store(env)
+		store.
+			FromJSON("$.token", "auth_token").
+			FromJSON("$.user.id", "user_id").
+			FromHeader("Location", "created_url").
+			FromCookie("session", "session_id").
+			FromBody("body")
+
+		store.chain.assert(t, success)
+		env.chain.assert(t, success)
+
+		assert.Equal(t, "secret", env.GetString("auth_token"))
+		assert.Equal(t, 123.0, env.GetFloat("user_id"))
+		assert.Equal(t, "/users/123", env.GetString("created_url"))
+		assert.Equal(t, "abc", env.GetString("session_id"))
+		assert.Equal(t, `{"token":"secret","user":{"id":123}}`, env.GetString("body"))
+	})
+
+	t.Run("default environment", func(t *testing.T) {
+		resp := newResp(t)
+
+		resp.Store(nil).FromJSON("$.token", "auth_token")
+		resp.chain.assert(t, success)
+
+		assert.Equal(t, "secret", resp.chain.env().GetString("auth_token"))
+	})
+
+	t.Run("bad json path", func(t *testing.T) {
+		resp := newResp(t)
+		env := newEnvironment(newMockChain(t))
+
+		store := resp.Store(env)
+		store.FromJSON("$.missing", "key")
+		store.chain.assert(t, failure)
+
+		assert.False(t, env.Has("key"))
+	})
+
+	t.Run("invalid json path", func(t *testing.T) {
+		resp := newResp(t)
+		env := newEnvironment(newMockChain(t))
+
+		store := resp.Store(env)
+		store.FromJSON("!", "key")
+		store.chain.assert(t, failure)
+
+		assert.False(t, env.Has("key"))
+	})
+
+	t.Run("missing header", func(t *testing.T) {
+		resp := newResp(t)
+		env := newEnvironment(newMockChain(t))
+
+		store := resp.Store(env)
+		store.FromHeader("Missing", "key")
+		store.chain.assert(t, failure)
+
+		assert.False(t, env.Has("key"))
+	})
+
+	t.Run("missing cookie", func(t *testing.T) {
+		resp := newResp(t)
+		env := newEnvironment(newMockChain(t))
+
+		store := resp.Store(env)
+		store.FromCookie("missing", "key")
+		store.chain.assert(t, failure)
+
+		assert.False(t, env.Has("key"))
+	})
+
+	t.Run("stop after failure", func(t *testing.T) {
+		resp := newResp(t)
+		env := newEnvironment(newMockChain(t))
+
+		store := resp.Store(env)
+		store.
+			FromHeader("Missing", "key1").
+			FromHeader("Location", "key2")
+		store.chain.assert(t, failure)
+
+		assert.Equal(t, []string{}, env.List())
+	})
+}
@@ -20,18 +20,36 @@ func TestResponse_FailedChain(t *testing.T) {
 
 		resp.Alias("foo")
 
+		resp.Warn().chain.assert(t, failure)
 		resp.RoundTripTime().chain.assert(t, failure)
 		resp.Duration().chain.assert(t, failure)
+		resp.BytesSent().chain.assert(t, failure)
+		resp.BytesReceived().chain.assert(t, failure)
+		resp.UploadThroughput().chain.assert(t, failure)
+		resp.Throughput().chain.assert(t, failure)
 		resp.Headers().chain.assert(t, failure)
 		resp.Header("foo").chain.assert(t, failure)
+		resp.HeaderDateTime("foo").chain.assert(t, failure)
+		resp.HeaderDuration("foo").chain.assert(t, failure)
+		resp.HeaderOccurrences("foo").chain.assert(t, failure)
+		resp.HeaderOrder().chain.assert(t, failure)
+		resp.ResolvedAddr().chain.assert(t, failure)
+		resp.EarlyHints().chain.assert(t, failure)
+		resp.Charset().chain.assert(t, failure)
+		resp.HasStrictTransfer().chain.assert(t, failure)
 		resp.Cookies().chain.assert(t, failure)
+		resp.CookieList().chain.assert(t, failure)
+		resp.CookiesComply(CookiePolicy{}).chain.assert(t, failure)
 		resp.Cookie("foo").chain.assert(t, failure)
 		resp.Body().chain.assert(t, failure)
 		resp.Text().chain.assert(t, failure)
 		resp.Form().chain.assert(t, failure)
 		resp.JSON().chain.assert(t, failure)
 		resp.JSONP("").chain.assert(t, failure)
+		resp.Decode(&struct{}{}).chain.assert(t, failure)
+		resp.ContentTypeMatchesBody().chain.assert(t, failure)
 		resp.Websocket().chain.assert(t, failure)
+		resp.Store(nil).chain.assert(t, failure)
 
 		resp.Status(123)
 		resp.StatusRange(Status2xx)
@@ -40,6 +58,7 @@ func TestResponse_FailedChain(t *testing.T) {
 		resp.HasContentType("", "")
 		resp.HasContentEncoding("")
 		resp.HasTransferEncoding("")
+		resp.HasConsistentContentLength()
 	}
 
 	t.Run("failed chain", func(t *testing.T) {
@@ -137,6 +156,49 @@ func TestResponse_Alias(t *testing.T) {
 	assert.Equal(t, []string{"foo"}, value.chain.context.AliasedPath)
 }
 
+func TestResponse_Warn(t *testing.T) {
+	reporter := newMockReporter(t)
+	warningLogger := newMockLogger(t)
+
+	config := Config{
+		Reporter:      reporter,
+		WarningLogger: warningLogger,
+	}
+
+	httpResp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"Content-Type": []string{"text/plain"},
+		},
+		Body: io.NopCloser(bytes.NewBufferString("body")),
+	}
+
+	resp := NewResponseC(config, httpResp)
+	resp.chain.assert(t, success)
+
+	resp.Body().IsEqual("body")
+	resp.chain.assert(t, success)
+
+	warn := resp.Warn()
+	resp.chain.assert(t, success)
+	warn.chain.assert(t, success)
+
+	warn.Status(http.StatusNotFound)
+	warn.chain.assert(t, failure)
+	resp.chain.assert(t, success)
+
+	assert.False(t, reporter.reported)
+	assert.True(t, warningLogger.logged)
+
+	resp.Status(http.StatusOK)
+	resp.Text().IsEqual("body")
+	resp.chain.assert(t, success)
+
+	warn2 := resp.Warn()
+	warn2.Text().IsEqual("body")
+	warn2.chain.assert(t, success)
+}
+
 func TestResponse_RoundTripTime(t *testing.T) {
 	t.Run("provided", func(t *testing.T) {
 		duration := time.Second
@@ -278,6 +340,175 @@ func TestResponse_StatusList(t *testing.T) {
 	}
 }
 
+func TestResponse_StatusBodyExcerpt(t *testing.T) {
+	cases := []struct {
+		name     string
+		body     string
+		limit    int
+		redactor *Redactor
+		excerpt  string
+	}{
+		{
+			name:    "text",
+			body:    "internal error",
+			excerpt: "response body:\ninternal error",
+		},
+		{
+			name:    "json",
+			body:    `{"error":"boom"}`,
+			excerpt: "response body:\n{\n  \"error\": \"boom\"\n}",
+		},
+		{
+			name:    "truncated",
+			body:    "0123456789",
+			limit:   4,
+			excerpt: "response body:\n0123\n... (truncated)",
+		},
+		{
+			name:    "truncated utf8",
+			body:    "aéb",
+			limit:   2,
+			excerpt: "response body:\na\n... (truncated)",
+		},
+		{
+			name:    "truncated json",
+			body:    `{"error":"boom"}`,
+			limit:   8,
+			excerpt: "response body:\n{\"error\"\n... (truncated)",
+		},
+		{
+			name:    "binary",
+			body:    "\xff\xfe\x00",
+			excerpt: "response body: <3 bytes of binary data>",
+		},
+		{
+			name:    "truncated binary",
+			body:    "\xff\xfe\x00",
+			limit:   2,
+			excerpt: "response body: <binary data>",
+		},
+		{
+			name: "redacted",
+			body: `{"error":"boom","token":"secret"}`,
+			redactor: &Redactor{
+				JSONPaths: []string{"$.token"},
+			},
+			excerpt: "response body:\n{\n  \"error\": \"boom\",\n  \"token\": \"<redacted>\"\n}",
+		},
+		{
+			name:  "disabled",
+			body:  "internal error",
+			limit: -1,
+		},
+		{
+			name: "empty",
+			body: "",
+		},
+	}
+
+	checks := map[string]func(resp *Response){
+		"Status": func(resp *Response) {
+			resp.Status(http.StatusOK)
+		},
+		"StatusRange": func(resp *Response) {
+			resp.StatusRange(Status2xx)
+		},
+		"StatusList": func(resp *Response) {
+			resp.StatusList(http.StatusOK, http.StatusCreated)
+		},
+	}
+
+	for _, tc := range cases {
+		for checkName, check := range checks {
+			t.Run(tc.name+" "+checkName, func(t *testing.T) {
+				handler := &mockAssertionHandler{}
+
+				resp := NewResponseC(Config{
+					AssertionHandler:  handler,
+					StatusBodyExcerpt: tc.limit,
+					Redactor:          tc.redactor,
+				}, &http.Response{
+					StatusCode: http.StatusInternalServerError,
+					Body:       newMockBody(tc.body),
+				})
+
+				check(resp)
+				resp.chain.assert(t, failure)
+
+				require.NotNil(t, handler.failure)
+
+				if tc.excerpt == "" {
+					assert.Equal(t, 1, len(handler.failure.Errors))
+				} else {
+					require.Equal(t, 2, len(handler.failure.Errors))
+					assert.Equal(t, tc.excerpt, handler.failure.Errors[1].Error())
+				}
+			})
+		}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		resp := NewResponse(newMockReporter(t), &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       newMockBody("ok"),
+		})
+
+		resp.Status(http.StatusOK)
+		resp.chain.assert(t, success)
+
+		// body is not read if status matches
+		assert.Equal(t, contentPending, resp.contentState)
+	})
+
+	t.Run("body", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		resp := NewResponseC(Config{
+			AssertionHandler:  handler,
+			StatusBodyExcerpt: 4,
+		}, &http.Response{
+			StatusCode: http.StatusInternalServerError,
+			Body:       newMockBody("0123456789"),
+		})
+
+		resp.Status(http.StatusOK)
+		resp.chain.assert(t, failure)
+
+		resp.chain.clear()
+
+		resp.Body().IsEqual("0123456789")
+		resp.chain.assert(t, success)
+	})
+
+	t.Run("reader", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		body := newMockBody("0123456789")
+
+		resp := NewResponseC(Config{
+			AssertionHandler:  handler,
+			StatusBodyExcerpt: 4,
+		}, &http.Response{
+			StatusCode: http.StatusInternalServerError,
+			Body:       body,
+		})
+
+		resp.Status(http.StatusOK)
+		resp.chain.assert(t, failure)
+
+		// body is read only partially
+		assert.Equal(t, contentPending, resp.contentState)
+		assert.Equal(t, 0, body.closeCount)
+
+		resp.chain.clear()
+
+		b, err := io.ReadAll(resp.Reader())
+		assert.NoError(t, err)
+		assert.Equal(t, "0123456789", string(b))
+		resp.chain.assert(t, success)
+	})
+}
+
 func TestResponse_Headers(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -306,6 +537,175 @@ func TestResponse_Headers(t *testing.T) {
 
 	resp.Header("Bad-Header").IsEmpty().
 		chain.assert(t, success)
+
+	resp.Headers().HasValueMatching("Second-Header", "^b").
+		chain.assert(t, success)
+
+	resp.Headers().HasValueSatisfying("First-Header", func(value *Value) bool {
+		return value.String().Raw() == "foo"
+	}).
+		chain.assert(t, success)
+}
+
+func TestResponse_HeaderDateTime(t *testing.T) {
+	date := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name     string
+		header   string
+		value    string
+		expected time.Time
+		result   chainResult
+	}{
+		{
+			name:     "RFC1123",
+			header:   "Last-Modified",
+			value:    "Wed, 31 May 2023 12:00:00 GMT",
+			expected: date.Add(-24 * time.Hour),
+			result:   success,
+		},
+		{
+			name:     "RFC850",
+			header:   "Expires",
+			value:    "Friday, 02-Jun-23 12:00:00 GMT",
+			expected: date.Add(24 * time.Hour),
+			result:   success,
+		},
+		{
+			name:     "ANSIC",
+			header:   "Expires",
+			value:    "Thu Jun  1 12:00:10 2023",
+			expected: date.Add(10 * time.Second),
+			result:   success,
+		},
+		{
+			name:     "delay-seconds",
+			header:   "Retry-After",
+			value:    "120",
+			expected: date.Add(2 * time.Minute),
+			result:   success,
+		},
+		{
+			name:   "invalid",
+			header: "Expires",
+			value:  "tomorrow",
+			result: failure,
+		},
+		{
+			name:   "negative",
+			header: "Retry-After",
+			value:  "-1",
+			result: failure,
+		},
+		{
+			name:   "missing",
+			header: "X-Missing",
+			result: failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			header := http.Header{
+				"Date": {date.Format(http.TimeFormat)},
+			}
+			if tc.value != "" {
+				header.Set(tc.header, tc.value)
+			}
+
+			resp := NewResponse(newMockReporter(t), &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     header,
+			})
+
+			dt := resp.HeaderDateTime(tc.header)
+			dt.chain.assert(t, tc.result)
+
+			if tc.result == success {
+				assert.True(t, tc.expected.Equal(dt.Raw()))
+			}
+		})
+	}
+
+	t.Run("delay-seconds without date", func(t *testing.T) {
+		resp := NewResponse(newMockReporter(t), &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Retry-After": {"60"}},
+		})
+
+		dt := resp.HeaderDateTime("Retry-After")
+		dt.chain.assert(t, success)
+
+		dt.InRange(time.Now(), time.Now().Add(2*time.Minute))
+		dt.chain.assert(t, success)
+	})
+}
+
+func TestResponse_HeaderDuration(t *testing.T) {
+	date := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name     string
+		value    string
+		expected time.Duration
+		result   chainResult
+	}{
+		{
+			name:     "delay-seconds",
+			value:    "120",
+			expected: 2 * time.Minute,
+			result:   success,
+		},
+		{
+			name:     "zero",
+			value:    "0",
+			expected: 0,
+			result:   success,
+		},
+		{
+			name:     "HTTP-date",
+			value:    "Thu, 01 Jun 2023 12:05:00 GMT",
+			expected: 5 * time.Minute,
+			result:   success,
+		},
+		{
+			name:   "invalid",
+			value:  "1.5",
+			result: failure,
+		},
+		{
+			name:   "overflow",
+			value:  "99999999999999999999",
+			result: failure,
+		},
+		{
+			name:   "missing",
+			result: failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			header := http.Header{
+				"Date": {date.Format(http.TimeFormat)},
+			}
+			if tc.value != "" {
+				header.Set("Retry-After", tc.value)
+			}
+
+			resp := NewResponse(newMockReporter(t), &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     header,
+			})
+
+			d := resp.HeaderDuration("Retry-After")
+			d.chain.assert(t, tc.result)
+
+			if tc.result == success {
+				assert.Equal(t, tc.expected, d.Raw())
+			}
+		})
+	}
 }
 
 func TestResponse_Cookies(t *testing.T) {
@@ -354,6 +754,37 @@ func TestResponse_Cookies(t *testing.T) {
 		assert.Nil(t, c3.Raw())
 	})
 
+	t.Run("repeated cookies", func(t *testing.T) {
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Set-Cookie": {
+					"foo=aaa",
+					"bar=bbb",
+					"foo=ccc",
+				},
+			},
+			Body: nil,
+		}
+
+		resp := NewResponse(reporter, httpResp)
+		resp.chain.assert(t, success)
+
+		assert.Equal(t, []interface{}{"foo", "bar", "foo"}, resp.Cookies().Raw())
+		resp.chain.assert(t, success)
+
+		cookies := resp.CookieList()
+		cookies.Length().IsEqual(3)
+		cookies.HasCount("foo", 2)
+		cookies.HasCount("bar", 1)
+		cookies.NotUnique()
+		cookies.Get("foo").Value().IsEqual("ccc")
+		cookies.chain.assert(t, success)
+
+		cookies.IsUnique()
+		cookies.chain.assert(t, failure)
+	})
+
 	t.Run("no cookies", func(t *testing.T) {
 		httpResp := &http.Response{
 			StatusCode: http.StatusOK,
@@ -962,6 +1393,238 @@ func TestResponse_TransferEncoding(t *testing.T) {
 	resp.chain.clear()
 }
 
+func TestResponse_ConsistentContentLength(t *testing.T) {
+	cases := []struct {
+		name   string
+		resp   *http.Response
+		result chainResult
+	}{
+		{
+			name: "matching length",
+			resp: &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Length": {"5"}},
+				Body:       newMockBody("hello"),
+			},
+			result: success,
+		},
+		{
+			name: "length from field",
+			resp: &http.Response{
+				StatusCode:    http.StatusOK,
+				ContentLength: 5,
+				Body:          newMockBody("hello"),
+			},
+			result: success,
+		},
+		{
+			name: "empty body",
+			resp: &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Length": {"0"}},
+				Body:       http.NoBody,
+			},
+			result: success,
+		},
+		{
+			name: "repeated identical values",
+			resp: &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Length": {"5", "5"}},
+				Body:       newMockBody("hello"),
+			},
+			result: success,
+		},
+		{
+			name: "truncated body",
+			resp: &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Length": {"10"}},
+				Body:       newMockBody("hello"),
+			},
+			result: failure,
+		},
+		{
+			name: "longer body",
+			resp: &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Length": {"3"}},
+				Body:       newMockBody("hello"),
+			},
+			result: failure,
+		},
+		{
+			name: "missing header",
+			resp: &http.Response{
+				StatusCode:    http.StatusOK,
+				ContentLength: -1,
+				Body:          newMockBody("hello"),
+			},
+			result: failure,
+		},
+		{
+			name: "missing header after decompression",
+			resp: &http.Response{
+				StatusCode:    http.StatusOK,
+				ContentLength: -1,
+				Uncompressed:  true,
+				Body:          newMockBody("hello"),
+			},
+			result: failure,
+		},
+		{
+			name: "invalid header",
+			resp: &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Length": {"abc"}},
+				Body:       newMockBody("hello"),
+			},
+			result: failure,
+		},
+		{
+			name: "negative header",
+			resp: &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Length": {"-5"}},
+				Body:       newMockBody("hello"),
+			},
+			result: failure,
+		},
+		{
+			name: "conflicting values",
+			resp: &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Length": {"5", "6"}},
+				Body:       newMockBody("hello"),
+			},
+			result: failure,
+		},
+		{
+			name: "chunked",
+			resp: &http.Response{
+				StatusCode:       http.StatusOK,
+				TransferEncoding: []string{"chunked"},
+				Body:             newMockBody("hello"),
+			},
+			result: failure,
+		},
+		{
+			name: "chunked with length",
+			resp: &http.Response{
+				StatusCode:       http.StatusOK,
+				TransferEncoding: []string{"chunked"},
+				Header:           http.Header{"Content-Length": {"5"}},
+				Body:             newMockBody("hello"),
+			},
+			result: failure,
+		},
+		{
+			name: "head request",
+			resp: &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Length": {"100"}},
+				Body:       http.NoBody,
+				Request:    &http.Request{Method: http.MethodHead},
+			},
+			result: success,
+		},
+		{
+			name: "not modified",
+			resp: &http.Response{
+				StatusCode: http.StatusNotModified,
+				Header:     http.Header{"Content-Length": {"100"}},
+				Body:       http.NoBody,
+			},
+			result: success,
+		},
+		{
+			name: "head request without header",
+			resp: &http.Response{
+				StatusCode:    http.StatusOK,
+				ContentLength: -1,
+				Body:          http.NoBody,
+				Request:       &http.Request{Method: http.MethodHead},
+			},
+			result: success,
+		},
+		{
+			name: "not modified without header",
+			resp: &http.Response{
+				StatusCode:    http.StatusNotModified,
+				ContentLength: -1,
+				Body:          http.NoBody,
+			},
+			result: success,
+		},
+		{
+			name: "no content without header",
+			resp: &http.Response{
+				StatusCode: http.StatusNoContent,
+				Body:       http.NoBody,
+			},
+			result: success,
+		},
+		{
+			name: "no content with zero length",
+			resp: &http.Response{
+				StatusCode: http.StatusNoContent,
+				Header:     http.Header{"Content-Length": {"0"}},
+				Body:       http.NoBody,
+			},
+			result: success,
+		},
+		{
+			name: "no content with non-zero length",
+			resp: &http.Response{
+				StatusCode: http.StatusNoContent,
+				Header:     http.Header{"Content-Length": {"5"}},
+				Body:       http.NoBody,
+			},
+			result: failure,
+		},
+		{
+			name: "not modified with conflicting values",
+			resp: &http.Response{
+				StatusCode: http.StatusNotModified,
+				Header:     http.Header{"Content-Length": {"5", "6"}},
+				Body:       http.NoBody,
+			},
+			result: failure,
+		},
+		{
+			name: "head request with invalid header",
+			resp: &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Length": {"abc"}},
+				Body:       http.NoBody,
+				Request:    &http.Request{Method: http.MethodHead},
+			},
+			result: failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := NewResponse(newMockReporter(t), tc.resp)
+
+			resp.HasConsistentContentLength()
+			resp.chain.assert(t, tc.result)
+		})
+	}
+
+	t.Run("body still readable", func(t *testing.T) {
+		resp := NewResponse(newMockReporter(t), &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Length": {"5"}},
+			Body:       newMockBody("hello"),
+		})
+
+		resp.HasConsistentContentLength().
+			Body().IsEqual("hello")
+		resp.chain.assert(t, success)
+	})
+}
+
 func TestResponse_Text(t *testing.T) {
 	t.Run("basic", func(t *testing.T) {
 		reporter := newMockReporter(t)
@@ -1873,3 +2536,123 @@ func TestResponse_Usage(t *testing.T) {
 		resp.chain.assert(t, failure)
 	})
 }
+
+func TestResponse_Limits(t *testing.T) {
+	newResp := func(config Config, contentType, body string) *Response {
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {contentType},
+			},
+			Body: newMockBody(body),
+		}
+
+		config.Reporter = newMockReporter(t)
+
+		return NewResponseC(config, httpResp)
+	}
+
+	t.Run("MaxBodyBytes", func(t *testing.T) {
+		config := Config{MaxBodyBytes: 5}
+
+		resp := newResp(config, "text/plain", "12345")
+		resp.Body().IsEqual("12345")
+		resp.chain.assert(t, success)
+
+		resp = newResp(config, "text/plain", "123456")
+		resp.Body()
+		resp.chain.assert(t, failure)
+
+		resp = newResp(config, "application/json", `{"a": 1}`)
+		resp.JSON()
+		resp.chain.assert(t, failure)
+	})
+
+	t.Run("MaxJSONDepth", func(t *testing.T) {
+		config := Config{MaxJSONDepth: 2}
+
+		resp := newResp(config, "application/json", `{"a": [1, "{[{["]}`)
+		resp.JSON().Object().Value("a").Array().HasValue(1, "{[{[")
+		resp.chain.assert(t, success)
+
+		resp = newResp(config, "application/json", `{"a": [{"b": 1}]}`)
+		resp.JSON()
+		resp.chain.assert(t, failure)
+
+		resp = newResp(config, "application/javascript", `cb([[[1]]])`)
+		resp.JSONP("cb")
+		resp.chain.assert(t, failure)
+	})
+
+	t.Run("DecodeTimeout", func(t *testing.T) {
+		unblock := make(chan struct{})
+		defer close(unblock)
+
+		config := Config{
+			DecodeTimeout: time.Millisecond,
+			JSONDecoder: JSONDecoderFunc(func(data []byte, target interface{}) error {
+				<-unblock
+				return nil
+			}),
+		}
+
+		resp := newResp(config, "application/json", `{}`)
+		resp.JSON()
+		resp.chain.assert(t, failure)
+
+		config.DecodeTimeout = time.Minute
+		config.JSONDecoder = nil
+
+		resp = newResp(config, "application/json", `{"a": 1}`)
+		resp.JSON().Object().HasValue("a", 1)
+		resp.chain.assert(t, success)
+
+		now := time.Unix(0, 0)
+		config.Clock = NewFakeClock(now)
+
+		resp = newResp(config, "application/json", `{"a": 1}`)
+		resp.JSON().Object().HasValue("a", 1)
+		resp.chain.assert(t, success)
+		assert.Equal(t, now, config.Clock.Now())
+	})
+}
+
+func TestResponse_BodyBuffer(t *testing.T) {
+	t.Run("shared with wrapper", func(t *testing.T) {
+		resp := NewResponse(newMockReporter(t), &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       newMockBody("test_body"),
+		})
+
+		content, ok := resp.getContent(resp.chain, "Body()")
+		assert.True(t, ok)
+
+		bw := resp.httpResp.Body.(*bodyWrapper)
+		assert.Same(t, &bw.memBytes[0], &content[0])
+
+		resp.Body().IsEqual("test_body")
+		resp.JSON()
+		resp.chain.assert(t, failure)
+	})
+
+	t.Run("spooling", func(t *testing.T) {
+		resp := NewResponseC(Config{
+			Reporter:           newMockReporter(t),
+			BodySpoolThreshold: 4,
+		}, &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {"application/json"},
+			},
+			Body: newMockBody(`{"key": "value"}`),
+		})
+
+		resp.JSON().Object().HasValue("key", "value")
+		resp.Body().IsEqual(`{"key": "value"}`)
+		resp.chain.assert(t, success)
+
+		bw := resp.httpResp.Body.(*bodyWrapper)
+		assert.NotNil(t, bw.spool)
+		assert.Nil(t, bw.memBytes)
+	})
+}
@@ -0,0 +1,209 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var errCircuitOpen = errors.New("circuit breaker is open")
+
+// RetryBudget limits retries across the whole test suite.
+//
+// Retry policy of individual requests (see Request.WithRetryPolicy and
+// Request.WithMaxRetries) is defined per-request, so when a service is
+// down, every test retries on its own and timeouts are multiplied by the
+// number of tests. RetryBudget is shared by all requests via
+// Config.RetryBudget and caps the total amount of retrying.
+//
+// It provides two mechanisms:
+//
+//   - Budget: when MaxRetries retries were made, or MaxRetryTime was spent
+//     on retrying (including delays between attempts), requests are no longer
+//     retried and fail after the first attempt.
+//
+//   - Circuit breaker: when BreakerThreshold consecutive attempts to an
+//     endpoint have failed, the breaker for the endpoint opens and further
+//     requests to it fail immediately, without being sent, with an error that
+//     tells how many attempts have failed. If BreakerCooldown is set, after
+//     cooldown one request is let through; if it succeeds, the breaker closes.
+//
+// An attempt is considered failed if client returned an error (except
+// interruptions requested via Request.WithBodyInterrupt and
+// Request.WithClientAbort) or response has 5xx status code. Endpoint is
+// identified by method, scheme, host, and path, without query.
+//
+// Zero values of limits mean no limit. RetryBudget is safe for concurrent
+// use and should not be copied after first use.
+//
+// Example:
+//
+//	budget := &httpexpect.RetryBudget{
+//		MaxRetries:       100,
+//		MaxRetryTime:     time.Minute,
+//		BreakerThreshold: 5,
+//	}
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		BaseURL:     "http://example.com",
+//		Reporter:    httpexpect.NewAssertReporter(t),
+//		RetryBudget: budget,
+//	})
+type RetryBudget struct {
+	// Maximum total number of retries.
+	// Zero means no limit.
+	MaxRetries int
+
+	// Maximum total time spent on retries, including delays between
+	// attempts and the retried attempts themselves.
+	// Zero means no limit.
+	MaxRetryTime time.Duration
+
+	// Number of consecutive failed attempts to an endpoint after which
+	// the circuit breaker for the endpoint opens.
+	// Zero disables circuit breaker.
+	BreakerThreshold int
+
+	// Time after which an open circuit breaker lets one request through.
+	// Zero means that breaker stays open until Reset.
+	BreakerCooldown time.Duration
+
+	mu        sync.Mutex
+	retries   int
+	retryTime time.Duration
+	breakers  map[string]*circuitBreaker
+}
+
+type circuitBreaker struct {
+	failures int
+	open     bool
+	openedAt time.Time
+}
+
+// Retries returns total number of retries made so far.
+func (b *RetryBudget) Retries() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.retries
+}
+
+// RetryTime returns total time spent on retries so far.
+func (b *RetryBudget) RetryTime() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.retryTime
+}
+
+// Reset restores the whole budget and closes all circuit breakers.
+func (b *RetryBudget) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.retries = 0
+	b.retryTime = 0
+	b.breakers = nil
+}
+
+// allow returns error if circuit breaker for endpoint is open.
+func (b *RetryBudget) allow(endpoint string, now time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	cb := b.breakers[endpoint]
+	if cb == nil || !cb.open {
+		return nil
+	}
+
+	if b.BreakerCooldown > 0 && now.Sub(cb.openedAt) >= b.BreakerCooldown {
+		// half-open: let one attempt through, next failure opens breaker again
+		cb.open = false
+		cb.failures = b.BreakerThreshold - 1
+		return nil
+	}
+
+	return fmt.Errorf("%w for %s after %d consecutive failures",
+		errCircuitOpen, endpoint, cb.failures)
+}
+
+// record updates circuit breaker for endpoint with attempt result.
+func (b *RetryBudget) record(endpoint string, failed bool, now time.Time) {
+	if b.BreakerThreshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !failed {
+		delete(b.breakers, endpoint)
+		return
+	}
+
+	if b.breakers == nil {
+		b.breakers = make(map[string]*circuitBreaker)
+	}
+
+	cb := b.breakers[endpoint]
+	if cb == nil {
+		cb = &circuitBreaker{}
+		b.breakers[endpoint] = cb
+	}
+
+	cb.failures++
+
+	if cb.failures >= b.BreakerThreshold && !cb.open {
+		cb.open = true
+		cb.openedAt = now
+	}
+}
+
+// acquire reserves one retry after given delay.
+// Returns false if retry is not allowed.
+func (b *RetryBudget) acquire(endpoint string, delay time.Duration) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if cb := b.breakers[endpoint]; cb != nil && cb.open {
+		return false
+	}
+
+	if b.MaxRetries > 0 && b.retries >= b.MaxRetries {
+		return false
+	}
+
+	if b.MaxRetryTime > 0 && b.retryTime+delay > b.MaxRetryTime {
+		return false
+	}
+
+	b.retries++
+
+	return true
+}
+
+// spend adds time spent on retry.
+func (b *RetryBudget) spend(d time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.retryTime += d
+}
+
+func retryBudgetEndpoint(req *http.Request) string {
+	if req.URL == nil {
+		return req.Method
+	}
+
+	return req.Method + " " + req.URL.Scheme + "://" + req.URL.Host + req.URL.Path
+}
+
+func isFailedAttempt(resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, errBodyInterrupted) && !errors.Is(err, errClientAborted)
+	}
+
+	return resp != nil && resp.StatusCode >= 500 && resp.StatusCode <= 599
+}
@@ -0,0 +1,212 @@
+package httpexpect
+
+import (
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryBudget_MaxRetries(t *testing.T) {
+	var calls int32
+
+	client := ClientFunc(func(req *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&calls, 1)
+		return &http.Response{
+			StatusCode: http.StatusServiceUnavailable,
+			Body:       newMockBody(""),
+			Request:    req,
+		}, nil
+	})
+
+	budget := &RetryBudget{
+		MaxRetries: 3,
+	}
+
+	e := WithConfig(Config{
+		BaseURL:     "http://example.com",
+		Client:      client,
+		Clock:       NewFakeClock(time.Now()),
+		Reporter:    newMockReporter(t),
+		RetryBudget: budget,
+	})
+
+	e.GET("/a").WithMaxRetries(2).Expect().
+		Status(http.StatusServiceUnavailable)
+
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	assert.Equal(t, 2, budget.Retries())
+
+	// only one retry left in budget
+	e.GET("/b").WithMaxRetries(2).Expect().
+		Status(http.StatusServiceUnavailable)
+
+	assert.Equal(t, int32(5), atomic.LoadInt32(&calls))
+	assert.Equal(t, 3, budget.Retries())
+
+	// budget exhausted, no retries
+	e.GET("/c").WithMaxRetries(2).Expect().
+		Status(http.StatusServiceUnavailable)
+
+	assert.Equal(t, int32(6), atomic.LoadInt32(&calls))
+	assert.Equal(t, 3, budget.Retries())
+
+	budget.Reset()
+	assert.Equal(t, 0, budget.Retries())
+
+	e.GET("/d").WithMaxRetries(2).Expect().
+		Status(http.StatusServiceUnavailable)
+
+	assert.Equal(t, int32(9), atomic.LoadInt32(&calls))
+}
+
+func TestRetryBudget_MaxRetryTime(t *testing.T) {
+	var calls int32
+
+	client := ClientFunc(func(req *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&calls, 1)
+		return nil, &mockNetError{isTimeout: true}
+	})
+
+	budget := &RetryBudget{
+		MaxRetryTime: 3*time.Second + 500*time.Millisecond,
+	}
+
+	e := WithConfig(Config{
+		BaseURL:     "http://example.com",
+		Client:      client,
+		Clock:       NewFakeClock(time.Now()),
+		Reporter:    newMockReporter(t),
+		RetryBudget: budget,
+	})
+
+	// delays: 1s, 2s, then 4s doesn't fit into budget;
+	// retry time includes delays and wall time of retried attempts
+	resp := e.GET("/").
+		WithMaxRetries(10).
+		WithRetryDelay(time.Second, time.Minute).
+		Expect()
+
+	resp.chain.assert(t, failure)
+
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	assert.Equal(t, 2, budget.Retries())
+	assert.True(t, budget.RetryTime() >= 3*time.Second)
+	assert.True(t, budget.RetryTime() < 3*time.Second+500*time.Millisecond)
+}
+
+func TestRetryBudget_CircuitBreaker(t *testing.T) {
+	var (
+		calls  int32
+		status int32 = http.StatusInternalServerError
+	)
+
+	client := ClientFunc(func(req *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&calls, 1)
+		return &http.Response{
+			StatusCode: int(atomic.LoadInt32(&status)),
+			Body:       newMockBody(""),
+			Request:    req,
+		}, nil
+	})
+
+	clock := NewFakeClock(time.Now())
+
+	budget := &RetryBudget{
+		BreakerThreshold: 3,
+		BreakerCooldown:  time.Minute,
+	}
+
+	e := WithConfig(Config{
+		BaseURL:     "http://example.com",
+		Client:      client,
+		Clock:       clock,
+		Reporter:    newMockReporter(t),
+		RetryBudget: budget,
+	})
+
+	t.Run("open", func(t *testing.T) {
+		// breaker opens after third attempt and stops retrying
+		resp := e.GET("/a").WithMaxRetries(5).WithRetryDelay(0, 0).Expect()
+		resp.chain.assert(t, success)
+
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+		assert.Equal(t, 2, budget.Retries())
+
+		// requests to same endpoint are not sent
+		msg := e.GET("/a").WithQuery("q", 1).ExpectError()
+		msg.Contains("circuit breaker is open for GET http://example.com/a")
+		msg.Contains("3 consecutive failures")
+		msg.chain.assert(t, success)
+
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+
+		// other endpoints are not affected
+		e.GET("/b").WithMaxRetries(0).Expect().chain.assert(t, success)
+		e.POST("/a").WithMaxRetries(0).Expect().chain.assert(t, success)
+
+		assert.Equal(t, int32(5), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("half-open failure", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		clock.Advance(time.Minute)
+
+		// one attempt is let through, and its failure opens breaker again
+		e.GET("/a").WithMaxRetries(5).WithRetryDelay(0, 0).Expect()
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+		e.GET("/a").ExpectError()
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("half-open success", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		atomic.StoreInt32(&status, http.StatusOK)
+		clock.Advance(time.Minute)
+
+		e.GET("/a").Expect().Status(http.StatusOK)
+		e.GET("/a").Expect().Status(http.StatusOK)
+
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		atomic.StoreInt32(&status, http.StatusInternalServerError)
+
+		e.GET("/c").WithMaxRetries(5).WithRetryDelay(0, 0).Expect()
+		e.GET("/c").ExpectError()
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+
+		budget.Reset()
+
+		e.GET("/c").WithMaxRetries(0).Expect()
+		assert.Equal(t, int32(4), atomic.LoadInt32(&calls))
+	})
+}
+
+func TestRetryBudget_FailedAttempt(t *testing.T) {
+	cases := []struct {
+		name   string
+		resp   *http.Response
+		err    error
+		failed bool
+	}{
+		{"ok", &http.Response{StatusCode: http.StatusOK}, nil, false},
+		{"4xx", &http.Response{StatusCode: http.StatusNotFound}, nil, false},
+		{"5xx", &http.Response{StatusCode: http.StatusBadGateway}, nil, true},
+		{"error", nil, errors.New("test"), true},
+		{"interrupted", nil, errBodyInterrupted, false},
+		{"aborted", nil, errClientAborted, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.failed, isFailedAttempt(tc.resp, tc.err))
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package httpexpect
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"syscall"
+)
+
+// transportErrorKind is a category of error returned by Client, used to
+// decide whether to retry request and to describe failure.
+type transportErrorKind string
+
+const (
+	transportConnRefused transportErrorKind = "connection refused"
+	transportConnReset   transportErrorKind = "connection reset"
+	transportEOF         transportErrorKind = "unexpected EOF"
+	transportTLS         transportErrorKind = "TLS handshake failure"
+	transportDNS         transportErrorKind = "DNS lookup failure"
+	transportTimeout     transportErrorKind = "timeout"
+	transportOther       transportErrorKind = "other error"
+)
+
+// isConnection returns true for errors that happen on connection level,
+// before any HTTP response is received. Such errors are retried according
+// to WithMaxConnRetries.
+func (kind transportErrorKind) isConnection() bool {
+	switch kind {
+	case transportConnRefused, transportConnReset, transportEOF, transportTLS:
+		return true
+	}
+	return false
+}
+
+func classifyTransportError(err error) transportErrorKind {
+	if err == nil {
+		return ""
+	}
+
+	switch {
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return transportConnRefused
+
+	case errors.Is(err, syscall.ECONNRESET), errors.Is(err, syscall.EPIPE):
+		return transportConnReset
+
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return transportEOF
+	}
+
+	var (
+		dnsErr       *net.DNSError
+		recordErr    tls.RecordHeaderError
+		authorityErr x509.UnknownAuthorityError
+		hostErr      x509.HostnameError
+		certErr      x509.CertificateInvalidError
+	)
+
+	switch {
+	case errors.As(err, &dnsErr):
+		return transportDNS
+
+	case errors.As(err, &recordErr), errors.As(err, &authorityErr),
+		errors.As(err, &hostErr), errors.As(err, &certErr),
+		strings.Contains(err.Error(), "tls: "):
+		return transportTLS
+	}
+
+	if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+		return transportTimeout
+	}
+
+	return transportOther
+}
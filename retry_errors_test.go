@@ -0,0 +1,98 @@
+package httpexpect
+
+import (
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"net/url"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryErrors_Classify(t *testing.T) {
+	cases := []struct {
+		name         string
+		err          error
+		kind         transportErrorKind
+		isConnection bool
+	}{
+		{
+			name: "nil",
+			err:  nil,
+			kind: "",
+		},
+		{
+			name: "connection refused",
+			err: &url.Error{
+				Op:  "Get",
+				URL: "http://localhost",
+				Err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED},
+			},
+			kind:         transportConnRefused,
+			isConnection: true,
+		},
+		{
+			name:         "connection reset",
+			err:          &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET},
+			kind:         transportConnReset,
+			isConnection: true,
+		},
+		{
+			name:         "broken pipe",
+			err:          &net.OpError{Op: "write", Net: "tcp", Err: syscall.EPIPE},
+			kind:         transportConnReset,
+			isConnection: true,
+		},
+		{
+			name:         "eof",
+			err:          &url.Error{Op: "Get", URL: "http://localhost", Err: io.EOF},
+			kind:         transportEOF,
+			isConnection: true,
+		},
+		{
+			name:         "unexpected eof",
+			err:          io.ErrUnexpectedEOF,
+			kind:         transportEOF,
+			isConnection: true,
+		},
+		{
+			name:         "tls handshake",
+			err:          errors.New("remote error: tls: handshake failure"),
+			kind:         transportTLS,
+			isConnection: true,
+		},
+		{
+			name:         "unknown authority",
+			err:          x509.UnknownAuthorityError{},
+			kind:         transportTLS,
+			isConnection: true,
+		},
+		{
+			name: "dns",
+			err:  &net.DNSError{Err: "no such host", Name: "example.invalid"},
+			kind: transportDNS,
+		},
+		{
+			name: "timeout",
+			err:  &mockNetError{isTimeout: true},
+			kind: transportTimeout,
+		},
+		{
+			name: "other",
+			err:  errors.New("test error"),
+			kind: transportOther,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			kind := classifyTransportError(tc.err)
+
+			assert.Equal(t, tc.kind, kind)
+			assert.Equal(t, tc.isConnection, kind.isConnection())
+		})
+	}
+}
@@ -0,0 +1,315 @@
+package httpexpect
+
+import (
+	"errors"
+	"time"
+)
+
+// Scenario runs multi-step API workflow, like "login, create, verify, delete".
+//
+// Scenario consists of ordered named steps. Each step sends one or several
+// requests and returns a Response. Steps share an Environment, which can
+// be used to pass values (tokens, identifiers, URLs) from one step to the
+// following ones, e.g. using Response.Store.
+//
+// Steps are executed by Run in the order in which they were added. If a step
+// fails, remaining steps are skipped. Assertion failures are reported as
+// usual, and their path includes scenario and step names. Scenario and step
+// names are also prepended to names of requests sent from steps (see
+// Request.WithName). Run also returns ScenarioReport, which tells which step
+// failed.
+//
+// Scenario is created using Expect.Scenario.
+type Scenario struct {
+	noCopy noCopy
+	chain  *chain
+	name   string
+	expect *Expect
+	env    *Environment
+	steps  []scenarioStep
+
+	clock Clock
+}
+
+type scenarioStep struct {
+	name string
+	fn   func(e *Expect, env *Environment) *Response
+	opts StepOpts
+}
+
+// StepOpts defines additional options for scenario step.
+type StepOpts struct {
+	// Maximum number of retries if step fails.
+	// Default is zero, which means no retries.
+	Retries int
+
+	// Delay between retries.
+	// Default is zero, which means no delay.
+	RetryDelay time.Duration
+}
+
+// ScenarioReport describes results of Scenario.Run.
+type ScenarioReport struct {
+	// Name of the scenario.
+	Name string
+
+	// Results of every step, in the order of execution.
+	Steps []ScenarioStepReport
+
+	// Name of the failed step.
+	// Empty if all steps succeeded.
+	FailedStep string
+}
+
+// Failed returns true if any scenario step failed.
+func (r *ScenarioReport) Failed() bool {
+	return r.FailedStep != ""
+}
+
+// ScenarioStepReport describes result of a single scenario step.
+type ScenarioStepReport struct {
+	// Name of the step.
+	Name string
+
+	// Number of attempts made, including retries.
+	// Zero if step was skipped.
+	Attempts int
+
+	// Time spent on all attempts.
+	Duration time.Duration
+
+	// Response returned from the last attempt.
+	// May be nil.
+	Response *Response
+
+	// Whether the step failed.
+	Failed bool
+
+	// Whether the step was skipped because one of previous steps failed.
+	Skipped bool
+}
+
+// Scenario returns a new Scenario instance with given name.
+//
+// Scenario Environment is a scope of Expect Environment with the same name
+// as scenario (see Environment.Scope).
+//
+// Example:
+//
+//	e := httpexpect.Default(t, "http://example.com")
+//
+//	e.Scenario("user lifecycle").
+//		Step("login", func(e *httpexpect.Expect, env *httpexpect.Environment) *httpexpect.Response {
+//			resp := e.POST("/login").WithJSON(credentials).
+//				Expect().
+//				Status(http.StatusOK)
+//			resp.Store(env).FromJSON("$.token", "token")
+//			return resp
+//		}).
+//		Step("create", func(e *httpexpect.Expect, env *httpexpect.Environment) *httpexpect.Response {
+//			resp := e.POST("/users").WithJSON(user).
+//				WithHeader("Authorization", "Bearer "+env.GetString("token")).
+//				Expect().
+//				Status(http.StatusCreated)
+//			resp.Store(env).FromHeader("Location", "user_url")
+//			return resp
+//		}).
+//		Step("verify", func(e *httpexpect.Expect, env *httpexpect.Environment) *httpexpect.Response {
+//			return e.GET(env.GetString("user_url")).
+//				Expect().
+//				Status(http.StatusOK)
+//		}, httpexpect.StepOpts{Retries: 3, RetryDelay: time.Second}).
+//		Run()
+func (e *Expect) Scenario(name string) *Scenario {
+	opChain := e.chain.enter("Scenario(%q)", name)
+	defer opChain.leave()
+
+	var env *Environment
+
+	if name == "" {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected empty scenario name"),
+			},
+		})
+		env = opChain.env()
+	} else {
+		env = opChain.env().Scope(name)
+	}
+
+	scenarioChain := opChain.clone()
+	scenarioChain.setRequestName(joinRequestName(scenarioChain.requestName(), name))
+
+	return &Scenario{
+		chain:  scenarioChain,
+		name:   name,
+		expect: e,
+		env:    env,
+		clock:  clockOrDefault(e.config.Clock),
+	}
+}
+
+// Env returns Environment shared by scenario steps.
+func (s *Scenario) Env() *Environment {
+	return s.env
+}
+
+// Step adds a new step to the scenario.
+//
+// fn is invoked by Run with Expect instance that should be used to send
+// requests, and with scenario Environment. fn should return the Response
+// produced by the step, or nil if there is no single response.
+//
+// Step is considered failed if any assertion made on returned Response or
+// on any request or response created using given Expect instance fails.
+//
+// If StepOpts with non-zero Retries is given, failed step is repeated up to
+// Retries times. Failures of all attempts except the last one are not
+// reported as errors, but only logged.
+//
+// Example:
+//
+//	scenario.Step("verify", func(e *httpexpect.Expect, env *httpexpect.Environment) *httpexpect.Response {
+//		return e.GET("/users/{id}", env.GetString("user_id")).
+//			Expect().
+//			Status(http.StatusOK)
+//	}, httpexpect.StepOpts{Retries: 3, RetryDelay: time.Second})
+func (s *Scenario) Step(
+	name string,
+	fn func(e *Expect, env *Environment) *Response,
+	opts ...StepOpts,
+) *Scenario {
+	opChain := s.chain.enter("Step(%q)", name)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return s
+	}
+
+	if fn == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil function argument"),
+			},
+		})
+		return s
+	}
+
+	if len(opts) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple opts arguments"),
+			},
+		})
+		return s
+	}
+
+	step := scenarioStep{
+		name: name,
+		fn:   fn,
+	}
+
+	if len(opts) != 0 {
+		step.opts = opts[0]
+	}
+
+	if step.opts.Retries < 0 || step.opts.RetryDelay < 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected negative Retries or RetryDelay in StepOpts"),
+			},
+		})
+		return s
+	}
+
+	s.steps = append(s.steps, step)
+
+	return s
+}
+
+// Run executes scenario steps in order and returns report.
+//
+// If a step fails, remaining steps are skipped.
+//
+// Example:
+//
+//	report := scenario.Run()
+//	if report.Failed() {
+//		t.Logf("scenario failed at step %q", report.FailedStep)
+//	}
+func (s *Scenario) Run() *ScenarioReport {
+	opChain := s.chain.enter("Run()")
+	defer opChain.leave()
+
+	report := &ScenarioReport{
+		Name:  s.name,
+		Steps: make([]ScenarioStepReport, 0, len(s.steps)),
+	}
+
+	for _, step := range s.steps {
+		stepReport := ScenarioStepReport{
+			Name: step.name,
+		}
+
+		if opChain.failed() {
+			stepReport.Skipped = true
+		} else {
+			s.runStep(opChain, step, &stepReport)
+
+			if stepReport.Failed {
+				report.FailedStep = step.name
+			}
+		}
+
+		report.Steps = append(report.Steps, stepReport)
+	}
+
+	return report
+}
+
+func (s *Scenario) runStep(
+	opChain *chain, step scenarioStep, stepReport *ScenarioStepReport,
+) {
+	stepChain := opChain.enter("Step(%q)", step.name)
+	defer stepChain.leave()
+
+	start := time.Now()
+
+	for attempt := 0; attempt <= step.opts.Retries; attempt++ {
+		if attempt != 0 && step.opts.RetryDelay != 0 {
+			<-s.clock.After(step.opts.RetryDelay)
+		}
+
+		isLast := attempt == step.opts.Retries
+
+		attemptChain := stepChain.clone()
+		attemptChain.setRequestName(
+			joinRequestName(attemptChain.requestName(), step.name))
+
+		// Failures of intermediate attempts should neither fail the test,
+		// nor propagate to the scenario chain.
+		if !isLast {
+			attemptChain.setRoot()
+			attemptChain.setSeverity(SeverityLog)
+		}
+
+		e := s.expect.withChain(attemptChain)
+
+		stepReport.Attempts++
+		stepReport.Response = step.fn(e, s.env)
+
+		if !attemptChain.treeFailed() {
+			stepReport.Duration = time.Since(start)
+			return
+		}
+
+		if isLast {
+			stepReport.Failed = true
+			stepReport.Duration = time.Since(start)
+		}
+	}
+}
@@ -0,0 +1,308 @@
+package httpexpect
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScenario_Basic(t *testing.T) {
+	client := ClientFunc(func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case "/login":
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body:       io.NopCloser(bytes.NewBufferString(`{"token":"secret"}`)),
+			}, nil
+		case "/users":
+			assert.Equal(t, "Bearer secret", req.Header.Get("Authorization"))
+			return &http.Response{
+				StatusCode: http.StatusCreated,
+				Header:     http.Header{"Location": {"/users/1"}},
+				Body:       http.NoBody,
+			}, nil
+		default:
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Body:       http.NoBody,
+			}, nil
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		e := WithConfig(Config{
+			Client:   client,
+			Reporter: reporter,
+		})
+
+		scenario := e.Scenario("lifecycle").
+			Step("login", func(e *Expect, env *Environment) *Response {
+				resp := e.POST("/login").Expect().Status(http.StatusOK)
+				resp.Store(env).FromJSON("$.token", "token")
+				return resp
+			}).
+			Step("create", func(e *Expect, env *Environment) *Response {
+				resp := e.POST("/users").
+					WithHeader("Authorization", "Bearer "+env.GetString("token")).
+					Expect().
+					Status(http.StatusCreated)
+				resp.Store(env).FromHeader("Location", "user_url")
+				return resp
+			})
+
+		report := scenario.Run()
+
+		scenario.chain.assert(t, success)
+		assert.False(t, reporter.reported)
+
+		assert.False(t, report.Failed())
+		assert.Equal(t, "lifecycle", report.Name)
+		assert.Equal(t, "", report.FailedStep)
+		assert.Equal(t, 2, len(report.Steps))
+
+		for _, step := range report.Steps {
+			assert.Equal(t, 1, step.Attempts)
+			assert.False(t, step.Failed)
+			assert.False(t, step.Skipped)
+			assert.NotNil(t, step.Response)
+		}
+
+		assert.Equal(t, "/users/1", scenario.Env().GetString("user_url"))
+		assert.Equal(t, "/users/1", e.Env().GetString("lifecycle.user_url"))
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		e := WithConfig(Config{
+			Client:   client,
+			Reporter: reporter,
+		})
+
+		called := false
+
+		scenario := e.Scenario("lifecycle").
+			Step("login", func(e *Expect, env *Environment) *Response {
+				return e.POST("/login").Expect().Status(http.StatusOK)
+			}).
+			Step("bad", func(e *Expect, env *Environment) *Response {
+				return e.GET("/bad").Expect().Status(http.StatusOK)
+			}).
+			Step("never", func(e *Expect, env *Environment) *Response {
+				called = true
+				return nil
+			})
+
+		report := scenario.Run()
+
+		scenario.chain.assert(t, failure)
+		assert.True(t, reporter.reported)
+		assert.False(t, called)
+
+		assert.True(t, report.Failed())
+		assert.Equal(t, "bad", report.FailedStep)
+		assert.Equal(t, 3, len(report.Steps))
+
+		assert.False(t, report.Steps[0].Failed)
+		assert.True(t, report.Steps[1].Failed)
+		assert.False(t, report.Steps[1].Skipped)
+		assert.Equal(t, 1, report.Steps[1].Attempts)
+		assert.True(t, report.Steps[2].Skipped)
+		assert.Equal(t, 0, report.Steps[2].Attempts)
+	})
+}
+
+func TestScenario_Names(t *testing.T) {
+	client := ClientFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       http.NoBody,
+		}, nil
+	})
+
+	cases := []struct {
+		name     string
+		prepare  func(req *Request)
+		wantName string
+	}{
+		{
+			name:     "named request",
+			prepare:  func(req *Request) { req.WithName("create user happy path") },
+			wantName: "lifecycle / create / create user happy path",
+		},
+		{
+			name:     "renamed request",
+			prepare:  func(req *Request) { req.WithName("foo").WithName("bar") },
+			wantName: "lifecycle / create / bar",
+		},
+		{
+			name:     "unnamed request",
+			prepare:  func(req *Request) {},
+			wantName: "lifecycle / create",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := &mockAssertionHandler{}
+
+			e := WithConfig(Config{
+				Client:           client,
+				AssertionHandler: handler,
+			})
+
+			var stats RequestStats
+
+			e.Scenario("lifecycle").
+				Step("create", func(e *Expect, env *Environment) *Response {
+					req := e.POST("/users").OnComplete(func(s RequestStats) {
+						stats = s
+					})
+					tc.prepare(req)
+					return req.Expect().Status(http.StatusCreated)
+				}).
+				Run()
+
+			assert.NotNil(t, handler.failure)
+			assert.Equal(t, tc.wantName, handler.ctx.RequestName)
+			assert.Equal(t, tc.wantName, stats.Name)
+		})
+	}
+
+	t.Run("outside scenario", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		e := WithConfig(Config{
+			Client:           client,
+			AssertionHandler: handler,
+		})
+
+		e.POST("/users").WithName("create user").
+			Expect().
+			Status(http.StatusCreated)
+
+		assert.NotNil(t, handler.failure)
+		assert.Equal(t, "create user", handler.ctx.RequestName)
+	})
+}
+
+func TestScenario_Retries(t *testing.T) {
+	newExpect := func(reporter Reporter, succeedAfter int) (*Expect, *int) {
+		calls := 0
+
+		e := WithConfig(Config{
+			Client: ClientFunc(func(req *http.Request) (*http.Response, error) {
+				calls++
+				status := http.StatusServiceUnavailable
+				if calls > succeedAfter {
+					status = http.StatusOK
+				}
+				return &http.Response{
+					StatusCode: status,
+					Body:       http.NoBody,
+				}, nil
+			}),
+			Reporter: reporter,
+		})
+
+		return e, &calls
+	}
+
+	step := func(e *Expect, env *Environment) *Response {
+		return e.GET("/").Expect().Status(http.StatusOK)
+	}
+
+	t.Run("succeeded after retries", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		e, calls := newExpect(reporter, 2)
+
+		scenario := e.Scenario("retries").
+			Step("get", step, StepOpts{Retries: 3, RetryDelay: time.Second})
+
+		start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+		clock := NewFakeClock(start)
+		scenario.clock = clock
+
+		report := scenario.Run()
+
+		scenario.chain.assert(t, success)
+		assert.False(t, reporter.reported)
+
+		assert.False(t, report.Failed())
+		assert.Equal(t, 3, report.Steps[0].Attempts)
+		assert.Equal(t, 3, *calls)
+		assert.Equal(t, 2*time.Second, clock.Now().Sub(start))
+		// duration is measured in wall time, so fake delays are not included
+		assert.True(t, report.Steps[0].Duration < 2*time.Second)
+	})
+
+	t.Run("all retries failed", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		e, calls := newExpect(reporter, 100)
+
+		scenario := e.Scenario("retries").
+			Step("get", step, StepOpts{Retries: 2})
+
+		report := scenario.Run()
+
+		scenario.chain.assert(t, failure)
+		assert.True(t, reporter.reported)
+
+		assert.True(t, report.Failed())
+		assert.Equal(t, "get", report.FailedStep)
+		assert.Equal(t, 3, report.Steps[0].Attempts)
+		assert.Equal(t, 3, *calls)
+	})
+}
+
+func TestScenario_Usage(t *testing.T) {
+	step := func(e *Expect, env *Environment) *Response {
+		return nil
+	}
+
+	t.Run("empty name", func(t *testing.T) {
+		e := WithConfig(Config{
+			Reporter: newMockReporter(t),
+		})
+
+		scenario := e.Scenario("")
+		scenario.chain.assert(t, failure)
+
+		report := scenario.Step("foo", step).Run()
+		assert.Equal(t, 0, len(report.Steps))
+	})
+
+	t.Run("nil function", func(t *testing.T) {
+		e := WithConfig(Config{
+			Reporter: newMockReporter(t),
+		})
+
+		scenario := e.Scenario("foo").Step("foo", nil)
+		scenario.chain.assert(t, failure)
+	})
+
+	t.Run("multiple opts", func(t *testing.T) {
+		e := WithConfig(Config{
+			Reporter: newMockReporter(t),
+		})
+
+		scenario := e.Scenario("foo").Step("foo", step, StepOpts{}, StepOpts{})
+		scenario.chain.assert(t, failure)
+	})
+
+	t.Run("negative retries", func(t *testing.T) {
+		e := WithConfig(Config{
+			Reporter: newMockReporter(t),
+		})
+
+		scenario := e.Scenario("foo").Step("foo", step, StepOpts{Retries: -1})
+		scenario.chain.assert(t, failure)
+	})
+}
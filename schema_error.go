@@ -0,0 +1,155 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// SchemaError describes a single JSON Schema validation error.
+//
+// When Schema (or EverySchema) fails, every validation error is added
+// to AssertionFailure.Errors as a separate *SchemaError entry. They can
+// be extracted using AssertionFailure.SchemaErrors or errors.As, and are
+// also included into FailureRecord produced by JSONFormatter.
+type SchemaError struct {
+	// Location of invalid value in the validated document, as JSON Pointer
+	// (RFC 6901), e.g. "/items/0/id". Empty for the document root.
+	InstancePath string `json:"instance_path"`
+
+	// Location of the failed keyword in the schema, as JSON Pointer
+	// fragment, e.g. "#/properties/id/type".
+	//
+	// The validator doesn't report exact schema location, so this path
+	// is derived from InstancePath and Keyword. It is exact for schemas
+	// that use only "properties" and "items", and approximate for schemas
+	// with combinators, "$ref", or pattern properties.
+	SchemaPath string `json:"schema_path"`
+
+	// Schema keyword that failed, e.g. "type" or "required".
+	Keyword string `json:"keyword"`
+
+	// Human-readable description of the error.
+	Message string `json:"message"`
+}
+
+// Error implements error.Error.
+func (e *SchemaError) Error() string {
+	path := e.InstancePath
+	if path == "" {
+		path = "(root)"
+	}
+	if e.Keyword == "" {
+		return fmt.Sprintf("%s: %s", path, e.Message)
+	}
+	return fmt.Sprintf("%s: %s (keyword %q)", path, e.Message, e.Keyword)
+}
+
+// SchemaErrors returns all SchemaError entries from failure Errors.
+// Returns nil if failure is not caused by JSON Schema mismatch.
+//
+// Example:
+//
+//	func (h *MyHandler) Failure(
+//		ctx *httpexpect.AssertionContext, failure *httpexpect.AssertionFailure,
+//	) {
+//		for _, schemaErr := range failure.SchemaErrors() {
+//			log.Printf("%s: %s", schemaErr.InstancePath, schemaErr.Message)
+//		}
+//	}
+func (f *AssertionFailure) SchemaErrors() []*SchemaError {
+	var result []*SchemaError
+
+	for _, err := range f.Errors {
+		var schemaErr *SchemaError
+		if err != nil && errors.As(err, &schemaErr) {
+			result = append(result, schemaErr)
+		}
+	}
+
+	return result
+}
+
+func newSchemaError(resultErr gojsonschema.ResultError) *SchemaError {
+	var segments []string
+
+	if ctx := resultErr.Context(); ctx != nil {
+		// first segment is always "(root)"
+		const sep = "\x00"
+		segments = strings.Split(ctx.String(sep), sep)[1:]
+	}
+
+	instancePath := ""
+	schemaPath := "#"
+
+	for _, seg := range segments {
+		escaped := strings.NewReplacer("~", "~0", "/", "~1").Replace(seg)
+
+		instancePath += "/" + escaped
+
+		if isArrayIndex(seg) {
+			schemaPath += "/items"
+		} else {
+			schemaPath += "/properties/" + escaped
+		}
+	}
+
+	keyword, ok := schemaKeywords[resultErr.Type()]
+	if !ok {
+		keyword = resultErr.Type()
+	}
+
+	if keyword != "" {
+		schemaPath += "/" + keyword
+	}
+
+	return &SchemaError{
+		InstancePath: instancePath,
+		SchemaPath:   schemaPath,
+		Keyword:      keyword,
+		Message:      resultErr.Description(),
+	}
+}
+
+// Maps validator error types to schema keywords.
+var schemaKeywords = map[string]string{
+	"false":                           "",
+	"invalid_type":                    "type",
+	"number_any_of":                   "anyOf",
+	"number_one_of":                   "oneOf",
+	"number_all_of":                   "allOf",
+	"number_not":                      "not",
+	"missing_dependency":              "dependencies",
+	"array_no_additional_items":       "additionalItems",
+	"array_min_items":                 "minItems",
+	"array_max_items":                 "maxItems",
+	"unique":                          "uniqueItems",
+	"array_min_properties":            "minProperties",
+	"array_max_properties":            "maxProperties",
+	"additional_property_not_allowed": "additionalProperties",
+	"invalid_property_pattern":        "patternProperties",
+	"invalid_property_name":           "propertyNames",
+	"string_gte":                      "minLength",
+	"string_lte":                      "maxLength",
+	"multiple_of":                     "multipleOf",
+	"number_gte":                      "minimum",
+	"number_gt":                       "exclusiveMinimum",
+	"number_lte":                      "maximum",
+	"number_lt":                       "exclusiveMaximum",
+	"condition_then":                  "then",
+	"condition_else":                  "else",
+}
+
+func isArrayIndex(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,157 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaError_Fields(t *testing.T) {
+	schema := `{
+		"type": "object",
+		"properties": {
+			"name": {"type": "string", "minLength": 3},
+			"items": {
+				"type": "array",
+				"items": {
+					"type": "object",
+					"properties": {
+						"id": {"type": "integer"}
+					},
+					"required": ["id"]
+				}
+			}
+		},
+		"required": ["name"]
+	}`
+
+	cases := []struct {
+		name     string
+		value    interface{}
+		expected []SchemaError
+	}{
+		{
+			name:  "root",
+			value: map[string]interface{}{},
+			expected: []SchemaError{
+				{
+					InstancePath: "",
+					SchemaPath:   "#/required",
+					Keyword:      "required",
+					Message:      "name is required",
+				},
+			},
+		},
+		{
+			name:  "nested",
+			value: map[string]interface{}{"name": "ab"},
+			expected: []SchemaError{
+				{
+					InstancePath: "/name",
+					SchemaPath:   "#/properties/name/minLength",
+					Keyword:      "minLength",
+					Message:      "String length must be greater than or equal to 3",
+				},
+			},
+		},
+		{
+			name: "array elements",
+			value: map[string]interface{}{
+				"name": "abc",
+				"items": []interface{}{
+					map[string]interface{}{"id": "x"},
+					map[string]interface{}{},
+				},
+			},
+			expected: []SchemaError{
+				{
+					InstancePath: "/items/0/id",
+					SchemaPath:   "#/properties/items/items/properties/id/type",
+					Keyword:      "type",
+					Message:      "Invalid type. Expected: integer, given: string",
+				},
+				{
+					InstancePath: "/items/1",
+					SchemaPath:   "#/properties/items/items/required",
+					Keyword:      "required",
+					Message:      "id is required",
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := &mockAssertionHandler{}
+
+			value := NewValueC(Config{AssertionHandler: handler}, tc.value)
+			value.Schema(schema)
+			value.chain.assert(t, failure)
+
+			require.NotNil(t, handler.failure)
+			assert.Equal(t, AssertMatchSchema, handler.failure.Type)
+
+			schemaErrs := handler.failure.SchemaErrors()
+			require.Equal(t, len(tc.expected), len(schemaErrs))
+
+			for i := range tc.expected {
+				assert.Equal(t, tc.expected[i], *schemaErrs[i])
+			}
+
+			// every validation error is a separate entry
+			assert.Equal(t, len(tc.expected)+1, len(handler.failure.Errors))
+		})
+	}
+}
+
+func TestSchemaError_Error(t *testing.T) {
+	err := &SchemaError{
+		InstancePath: "/items/0/id",
+		Keyword:      "type",
+		Message:      "Invalid type",
+	}
+	assert.Equal(t, `/items/0/id: Invalid type (keyword "type")`, err.Error())
+
+	err = &SchemaError{
+		Message: "False always fails validation",
+	}
+	assert.Equal(t, "(root): False always fails validation", err.Error())
+
+	wrapped := errors.New("other")
+	failure := &AssertionFailure{
+		Errors: []error{wrapped, nil, err},
+	}
+	assert.Equal(t, []*SchemaError{err}, failure.SchemaErrors())
+
+	failure = &AssertionFailure{
+		Errors: []error{wrapped},
+	}
+	assert.Nil(t, failure.SchemaErrors())
+}
+
+func TestSchemaError_JSONFormatter(t *testing.T) {
+	handler := &mockAssertionHandler{}
+
+	value := NewValueC(Config{AssertionHandler: handler},
+		map[string]interface{}{"id": "x"})
+	value.Schema(`{"properties": {"id": {"type": "integer"}}}`)
+
+	require.NotNil(t, handler.failure)
+
+	formatter := &JSONFormatter{}
+	msg := formatter.FormatFailure(handler.ctx, handler.failure)
+
+	var record FailureRecord
+	require.NoError(t, json.Unmarshal([]byte(msg), &record))
+
+	require.Equal(t, 1, len(record.SchemaErrors))
+	assert.Equal(t, SchemaError{
+		InstancePath: "/id",
+		SchemaPath:   "#/properties/id/type",
+		Keyword:      "type",
+		Message:      "Invalid type. Expected: integer, given: string",
+	}, *record.SchemaErrors[0])
+}
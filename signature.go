@@ -0,0 +1,402 @@
+package httpexpect
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Signature provides methods to verify signature of a payload.
+//
+// It supports plain hex-encoded HMAC-SHA256 signatures, GitHub-style
+// ("X-Hub-Signature-256: sha256=<hex>") and Stripe-style
+// ("Stripe-Signature: t=<unix>,v1=<hex>") signatures, and generic
+// timestamped signatures.
+//
+// Timestamped signatures are accepted only if timestamp is within given
+// tolerance from current time, which allows for clock skew between
+// signer and verifier. Current time is provided by Config.Clock.
+//
+// Failures report digest from header and digest computed from payload.
+//
+// Signature can be obtained for received webhooks and responses, or
+// constructed for arbitrary payload, e.g. one that is about to be sent.
+//
+// Example:
+//
+//	sink.Request(0).Signature().
+//		HasStripe("whsec_secret", 5*time.Minute)
+//
+//	resp.Signature().
+//		HasHMAC("X-Signature", "secret")
+//
+//	req, _ := e.POST("/hook").WithJSON(event).Build()
+//	body, _ := io.ReadAll(req.Body)
+//	NewSignature(t, body, req.Header).HasGitHub("secret")
+type Signature struct {
+	noCopy noCopy
+	chain  *chain
+	clock  Clock
+
+	payload []byte
+	header  http.Header
+}
+
+// NewSignature returns a new Signature instance for given payload
+// and headers.
+//
+// If reporter is nil, the function panics.
+//
+// Example:
+//
+//	sig := NewSignature(t, body, header)
+//	sig.HasHMAC("X-Signature", "secret")
+func NewSignature(reporter Reporter, payload []byte, header http.Header) *Signature {
+	return newSignature(newChainWithDefaults("Signature()", reporter),
+		nil, payload, header)
+}
+
+// NewSignatureC returns a new Signature instance with config.
+//
+// Requirements for config are same as for WithConfig function.
+//
+// Example:
+//
+//	sig := NewSignatureC(config, body, header)
+//	sig.HasHMAC("X-Signature", "secret")
+func NewSignatureC(config Config, payload []byte, header http.Header) *Signature {
+	config = config.withDefaults()
+
+	return newSignature(newChainWithConfig("Signature()", config),
+		config.Clock, payload, header)
+}
+
+func newSignature(
+	parent *chain, clock Clock, payload []byte, header http.Header,
+) *Signature {
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &Signature{
+		chain:   parent.clone(),
+		clock:   clockOrDefault(clock),
+		payload: payload,
+		header:  header,
+	}
+}
+
+// Signature returns a new Signature instance for request body and headers.
+//
+// Example:
+//
+//	sink.Request(0).Signature().HasGitHub("secret")
+func (wr *WebhookRequest) Signature() *Signature {
+	opChain := wr.chain.enter("Signature()")
+	defer opChain.leave()
+
+	return newSignature(opChain, wr.config.Clock, wr.rec.body, wr.rec.header)
+}
+
+// Signature returns a new Signature instance for response body and headers.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.Signature().HasHMAC("X-Signature", "secret")
+func (r *Response) Signature() *Signature {
+	opChain := r.chain.enter("Signature()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newSignature(opChain, r.config.Clock, nil, nil)
+	}
+
+	content, ok := r.getContent(opChain, "Signature()")
+	if !ok {
+		return newSignature(opChain, r.config.Clock, nil, nil)
+	}
+
+	return newSignature(opChain, r.config.Clock, content, r.httpResp.Header)
+}
+
+// HasHMAC succeeds if given header contains hex-encoded HMAC-SHA256 of
+// payload computed with given secret.
+//
+// Header value may have "sha256=" prefix.
+//
+// Example:
+//
+//	sig.HasHMAC("X-Signature", "secret")
+func (s *Signature) HasHMAC(header, secret string) *Signature {
+	opChain := s.chain.enter("HasHMAC(%q)", header)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return s
+	}
+
+	value, ok := s.headerValue(opChain, header)
+	if !ok {
+		return s
+	}
+
+	s.checkDigest(opChain, secret, s.payload,
+		[]string{strings.TrimPrefix(value, "sha256=")})
+
+	return s
+}
+
+// HasGitHub succeeds if payload has valid GitHub-style signature, i.e.
+// "X-Hub-Signature-256" header with "sha256=" prefix followed by
+// hex-encoded HMAC-SHA256 of payload.
+//
+// Example:
+//
+//	sig.HasGitHub("secret")
+func (s *Signature) HasGitHub(secret string) *Signature {
+	opChain := s.chain.enter("HasGitHub()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return s
+	}
+
+	value, ok := s.headerValue(opChain, "X-Hub-Signature-256")
+	if !ok {
+		return s
+	}
+
+	if !strings.HasPrefix(value, "sha256=") {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				errors.New(`expected: signature has "sha256=" prefix`),
+			},
+		})
+		return s
+	}
+
+	s.checkDigest(opChain, secret, s.payload,
+		[]string{strings.TrimPrefix(value, "sha256=")})
+
+	return s
+}
+
+// HasStripe succeeds if payload has valid Stripe-style signature, i.e.
+// "Stripe-Signature" header in form "t=<timestamp>,v1=<signature>",
+// where signature is hex-encoded HMAC-SHA256 of "<timestamp>.<payload>".
+//
+// Header may contain multiple v1 signatures, at least one should match.
+// Timestamp should be within given tolerance from current time.
+//
+// Example:
+//
+//	sig.HasStripe("whsec_secret", 5*time.Minute)
+func (s *Signature) HasStripe(secret string, tolerance time.Duration) *Signature {
+	opChain := s.chain.enter("HasStripe()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return s
+	}
+
+	if !s.checkTolerance(opChain, tolerance) {
+		return s
+	}
+
+	value, ok := s.headerValue(opChain, "Stripe-Signature")
+	if !ok {
+		return s
+	}
+
+	var (
+		timestamp  string
+		signatures []string
+	)
+
+	for _, part := range strings.Split(value, ",") {
+		k, v, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch k {
+		case "t":
+			timestamp = v
+		case "v1":
+			signatures = append(signatures, v)
+		}
+	}
+
+	if timestamp == "" || len(signatures) == 0 {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				errors.New(`expected: signature in form "t=<timestamp>,v1=<signature>"`),
+			},
+		})
+		return s
+	}
+
+	if !s.checkTimestamp(opChain, timestamp, tolerance) {
+		return s
+	}
+
+	s.checkDigest(opChain, secret, s.timestamped(timestamp), signatures)
+
+	return s
+}
+
+// HasTimestampedHMAC succeeds if given header contains hex-encoded
+// HMAC-SHA256 of "<timestamp>.<payload>", where timestamp is taken from
+// another header as Unix seconds.
+//
+// Signature header may have "sha256=" prefix.
+// Timestamp should be within given tolerance from current time.
+//
+// Example:
+//
+//	sig.HasTimestampedHMAC("X-Signature", "X-Timestamp", "secret", time.Minute)
+func (s *Signature) HasTimestampedHMAC(
+	header, timestampHeader, secret string, tolerance time.Duration,
+) *Signature {
+	opChain := s.chain.enter("HasTimestampedHMAC(%q, %q)", header, timestampHeader)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return s
+	}
+
+	if !s.checkTolerance(opChain, tolerance) {
+		return s
+	}
+
+	value, ok := s.headerValue(opChain, header)
+	if !ok {
+		return s
+	}
+
+	timestamp, ok := s.headerValue(opChain, timestampHeader)
+	if !ok {
+		return s
+	}
+
+	if !s.checkTimestamp(opChain, timestamp, tolerance) {
+		return s
+	}
+
+	s.checkDigest(opChain, secret, s.timestamped(timestamp),
+		[]string{strings.TrimPrefix(value, "sha256=")})
+
+	return s
+}
+
+func (s *Signature) timestamped(timestamp string) []byte {
+	return append([]byte(timestamp+"."), s.payload...)
+}
+
+func (s *Signature) headerValue(opChain *chain, header string) (string, bool) {
+	value := s.header.Get(header)
+
+	if value == "" {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{s.header},
+			Expected: &AssertionValue{header},
+			Errors: []error{
+				fmt.Errorf("expected: header %q is present", header),
+			},
+		})
+		return "", false
+	}
+
+	return value, true
+}
+
+func (s *Signature) checkTolerance(opChain *chain, tolerance time.Duration) bool {
+	if tolerance < 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected negative tolerance"),
+			},
+		})
+		return false
+	}
+
+	return true
+}
+
+func (s *Signature) checkTimestamp(
+	opChain *chain, timestamp string, tolerance time.Duration,
+) bool {
+	sec, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{timestamp},
+			Errors: []error{
+				errors.New("expected: signature timestamp is unix time in seconds"),
+				err,
+			},
+		})
+		return false
+	}
+
+	now := s.clock.Now()
+	ts := time.Unix(sec, 0)
+
+	if ts.Before(now.Add(-tolerance)) || ts.After(now.Add(tolerance)) {
+		opChain.fail(AssertionFailure{
+			Type:   AssertInRange,
+			Actual: &AssertionValue{ts.UTC()},
+			Expected: &AssertionValue{AssertionRange{
+				Min: now.Add(-tolerance).UTC(),
+				Max: now.Add(tolerance).UTC(),
+			}},
+			Errors: []error{
+				fmt.Errorf("expected: signature timestamp is within %s from now",
+					tolerance),
+			},
+		})
+		return false
+	}
+
+	return true
+}
+
+func (s *Signature) checkDigest(
+	opChain *chain, secret string, signed []byte, signatures []string,
+) bool {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(signed)
+	computed := mac.Sum(nil)
+
+	for _, sig := range signatures {
+		actual, err := hex.DecodeString(sig)
+		if err == nil && hmac.Equal(actual, computed) {
+			return true
+		}
+	}
+
+	var actual interface{} = signatures
+	if len(signatures) == 1 {
+		actual = signatures[0]
+	}
+
+	opChain.fail(AssertionFailure{
+		Type:     AssertEqual,
+		Actual:   &AssertionValue{actual},
+		Expected: &AssertionValue{hex.EncodeToString(computed)},
+		Errors: []error{
+			errors.New("expected: signature digest matches payload"),
+		},
+	})
+
+	return false
+}
@@ -0,0 +1,284 @@
+package httpexpect
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func testSign(secret, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestSignature_HMAC(t *testing.T) {
+	payload := `{"event":"created"}`
+	digest := testSign("secret", payload)
+
+	cases := []struct {
+		name   string
+		header http.Header
+		result chainResult
+	}{
+		{
+			name:   "plain",
+			header: http.Header{"X-Signature": {digest}},
+			result: success,
+		},
+		{
+			name:   "prefixed",
+			header: http.Header{"X-Signature": {"sha256=" + digest}},
+			result: success,
+		},
+		{
+			name:   "wrong digest",
+			header: http.Header{"X-Signature": {testSign("other", payload)}},
+			result: failure,
+		},
+		{
+			name:   "not hex",
+			header: http.Header{"X-Signature": {"zzz"}},
+			result: failure,
+		},
+		{
+			name:   "missing header",
+			header: http.Header{},
+			result: failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			sig := NewSignature(newMockReporter(t), []byte(payload), tc.header)
+
+			sig.HasHMAC("X-Signature", "secret")
+			sig.chain.assert(t, tc.result)
+		})
+	}
+}
+
+func TestSignature_GitHub(t *testing.T) {
+	payload := `{"action":"opened"}`
+	digest := testSign("secret", payload)
+
+	cases := []struct {
+		name   string
+		value  string
+		result chainResult
+	}{
+		{
+			name:   "valid",
+			value:  "sha256=" + digest,
+			result: success,
+		},
+		{
+			name:   "no prefix",
+			value:  digest,
+			result: failure,
+		},
+		{
+			name:   "wrong digest",
+			value:  "sha256=" + testSign("other", payload),
+			result: failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			sig := NewSignature(newMockReporter(t), []byte(payload),
+				http.Header{"X-Hub-Signature-256": {tc.value}})
+
+			sig.HasGitHub("secret")
+			sig.chain.assert(t, tc.result)
+		})
+	}
+}
+
+func TestSignature_Stripe(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	payload := `{"id":"evt_1"}`
+
+	stamp := func(d time.Duration) string {
+		return strconv.FormatInt(now.Add(d).Unix(), 10)
+	}
+
+	sign := func(ts string) string {
+		return testSign("whsec", ts+"."+payload)
+	}
+
+	cases := []struct {
+		name      string
+		value     string
+		tolerance time.Duration
+		result    chainResult
+	}{
+		{
+			name:      "valid",
+			value:     "t=" + stamp(0) + ",v1=" + sign(stamp(0)),
+			tolerance: time.Minute,
+			result:    success,
+		},
+		{
+			name: "multiple signatures",
+			value: "t=" + stamp(0) + ",v1=" + testSign("old", stamp(0)+"."+payload) +
+				",v1=" + sign(stamp(0)) + ",v0=ignored",
+			tolerance: time.Minute,
+			result:    success,
+		},
+		{
+			name:      "past within tolerance",
+			value:     "t=" + stamp(-30*time.Second) + ",v1=" + sign(stamp(-30*time.Second)),
+			tolerance: time.Minute,
+			result:    success,
+		},
+		{
+			name:      "future within tolerance",
+			value:     "t=" + stamp(30*time.Second) + ",v1=" + sign(stamp(30*time.Second)),
+			tolerance: time.Minute,
+			result:    success,
+		},
+		{
+			name:      "expired",
+			value:     "t=" + stamp(-2*time.Minute) + ",v1=" + sign(stamp(-2*time.Minute)),
+			tolerance: time.Minute,
+			result:    failure,
+		},
+		{
+			name:      "too far in future",
+			value:     "t=" + stamp(2*time.Minute) + ",v1=" + sign(stamp(2*time.Minute)),
+			tolerance: time.Minute,
+			result:    failure,
+		},
+		{
+			name:      "signed without timestamp",
+			value:     "t=" + stamp(0) + ",v1=" + testSign("whsec", payload),
+			tolerance: time.Minute,
+			result:    failure,
+		},
+		{
+			name:      "missing timestamp",
+			value:     "v1=" + sign(stamp(0)),
+			tolerance: time.Minute,
+			result:    failure,
+		},
+		{
+			name:      "missing signature",
+			value:     "t=" + stamp(0),
+			tolerance: time.Minute,
+			result:    failure,
+		},
+		{
+			name:      "invalid timestamp",
+			value:     "t=abc,v1=" + sign("abc"),
+			tolerance: time.Minute,
+			result:    failure,
+		},
+		{
+			name:      "negative tolerance",
+			value:     "t=" + stamp(0) + ",v1=" + sign(stamp(0)),
+			tolerance: -time.Minute,
+			result:    failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			sig := NewSignatureC(Config{
+				Reporter: newMockReporter(t),
+				Clock:    NewFakeClock(now),
+			}, []byte(payload), http.Header{"Stripe-Signature": {tc.value}})
+
+			sig.HasStripe("whsec", tc.tolerance)
+			sig.chain.assert(t, tc.result)
+		})
+	}
+}
+
+func TestSignature_TimestampedHMAC(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	payload := "hello"
+
+	newSig := func(t *testing.T, ts, digest string) *Signature {
+		return NewSignatureC(Config{
+			Reporter: newMockReporter(t),
+			Clock:    NewFakeClock(now),
+		}, []byte(payload), http.Header{
+			"X-Timestamp": {ts},
+			"X-Signature": {digest},
+		})
+	}
+
+	ts := strconv.FormatInt(now.Unix(), 10)
+	old := strconv.FormatInt(now.Add(-time.Hour).Unix(), 10)
+
+	t.Run("valid", func(t *testing.T) {
+		sig := newSig(t, ts, "sha256="+testSign("secret", ts+"."+payload))
+		sig.HasTimestampedHMAC("X-Signature", "X-Timestamp", "secret", time.Minute)
+		sig.chain.assert(t, success)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		sig := newSig(t, old, testSign("secret", old+"."+payload))
+		sig.HasTimestampedHMAC("X-Signature", "X-Timestamp", "secret", time.Minute)
+		sig.chain.assert(t, failure)
+	})
+
+	t.Run("tampered timestamp", func(t *testing.T) {
+		sig := newSig(t, ts, testSign("secret", old+"."+payload))
+		sig.HasTimestampedHMAC("X-Signature", "X-Timestamp", "secret", time.Minute)
+		sig.chain.assert(t, failure)
+	})
+
+	t.Run("missing timestamp header", func(t *testing.T) {
+		sig := newSig(t, ts, testSign("secret", ts+"."+payload))
+		sig.HasTimestampedHMAC("X-Signature", "X-Other", "secret", time.Minute)
+		sig.chain.assert(t, failure)
+	})
+}
+
+func TestSignature_Sources(t *testing.T) {
+	payload := "body"
+	digest := testSign("secret", payload)
+
+	t.Run("response", func(t *testing.T) {
+		resp := NewResponse(newMockReporter(t), &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"X-Signature": {digest}},
+			Body:       newMockBody(payload),
+		})
+
+		sig := resp.Signature().HasHMAC("X-Signature", "secret")
+		sig.chain.assert(t, success)
+
+		sig = resp.Signature().HasHMAC("X-Signature", "other")
+		sig.chain.assert(t, failure)
+	})
+
+	t.Run("webhook request", func(t *testing.T) {
+		req := newWebhookRequest(newMockChain(t), newMockConfig(newMockReporter(t)),
+			&webhookRecord{
+				header: http.Header{"X-Hub-Signature-256": {"sha256=" + digest}},
+				body:   []byte(payload),
+			})
+
+		sig := req.Signature().HasGitHub("secret")
+		sig.chain.assert(t, success)
+	})
+
+	t.Run("failed chain", func(t *testing.T) {
+		sig := newSignature(newMockChain(t, flagFailed), nil, []byte(payload),
+			http.Header{"X-Signature": {digest}})
+
+		sig.HasHMAC("X-Signature", "secret")
+		sig.HasGitHub("secret")
+		sig.HasStripe("secret", time.Minute)
+		sig.HasTimestampedHMAC("X-Signature", "X-Timestamp", "secret", time.Minute)
+
+		sig.chain.assert(t, failure)
+	})
+}
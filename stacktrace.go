@@ -3,6 +3,7 @@ package httpexpect
 import (
 	"regexp"
 	"runtime"
+	"strconv"
 )
 
 // Stacktrace entry.
@@ -64,3 +65,23 @@ func stacktrace() []StacktraceEntry {
 
 	return callers
 }
+
+var goroutineIDRe = regexp.MustCompile(`^goroutine (\d+) `)
+
+// Returns numeric ID of current goroutine, or zero if it can't be determined.
+// The ID is parsed from runtime.Stack output, which format is not guaranteed,
+// so callers should treat zero as unknown goroutine.
+// Used by Request to detect usage from multiple goroutines when
+// Config.Parallel is enabled.
+func goroutineID() uint64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+
+	m := goroutineIDRe.FindSubmatch(buf)
+	if m == nil {
+		return 0
+	}
+
+	id, _ := strconv.ParseUint(string(m[1]), 10, 64)
+	return id
+}
@@ -0,0 +1,83 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// HasStrictTransfer succeeds if response follows HTTP transfer semantics.
+//
+// It checks that:
+//   - 204 and 304 responses have no body
+//   - 201 and 3xx responses, except 300 and 304, have Location header
+//   - 405 response has Allow header
+//   - response with non-empty body has Content-Type header
+//
+// All violations are reported as a single failure.
+//
+// HasStrictTransfer is invoked automatically for every response if
+// Config.StrictTransfer is true or if Request.WithStrictTransfer(true)
+// was called. It doesn't consume response body, so Response.Reader can
+// be used after it. If Response.Reader was already called, body checks
+// are skipped.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.HasStrictTransfer()
+func (r *Response) HasStrictTransfer() *Response {
+	opChain := r.chain.enter("HasStrictTransfer()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	// only check if body is empty, without consuming it
+	content, _ := r.peekContent(0)
+	hasBody := len(content) != 0
+
+	var errs []error
+
+	status := r.httpResp.StatusCode
+	header := r.httpResp.Header
+
+	switch {
+	case status == http.StatusNoContent || status == http.StatusNotModified:
+		if hasBody {
+			errs = append(errs,
+				fmt.Errorf("%d response has non-empty body", status))
+		}
+
+	case status == http.StatusCreated ||
+		(status >= 300 && status <= 399 && status != http.StatusMultipleChoices):
+		if header.Get("Location") == "" {
+			errs = append(errs,
+				fmt.Errorf(`%d response has no "Location" header`, status))
+		}
+
+	case status == http.StatusMethodNotAllowed:
+		if len(header.Values("Allow")) == 0 {
+			errs = append(errs,
+				fmt.Errorf(`%d response has no "Allow" header`, status))
+		}
+	}
+
+	if hasBody && header.Get("Content-Type") == "" {
+		errs = append(errs,
+			errors.New(`response with non-empty body has no "Content-Type" header`))
+	}
+
+	if len(errs) != 0 {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{status},
+			Errors: append([]error{
+				errors.New("expected: response follows strict transfer semantics"),
+			}, errs...),
+		})
+	}
+
+	return r
+}
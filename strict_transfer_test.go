@@ -0,0 +1,221 @@
+package httpexpect
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrictTransfer_Response(t *testing.T) {
+	cases := []struct {
+		name   string
+		status int
+		header http.Header
+		body   string
+		result chainResult
+	}{
+		{
+			name:   "200 with body",
+			status: http.StatusOK,
+			header: http.Header{"Content-Type": {"text/plain"}},
+			body:   "ok",
+			result: success,
+		},
+		{
+			name:   "200 without body",
+			status: http.StatusOK,
+			result: success,
+		},
+		{
+			name:   "200 without content type",
+			status: http.StatusOK,
+			body:   "ok",
+			result: failure,
+		},
+		{
+			name:   "204 without body",
+			status: http.StatusNoContent,
+			result: success,
+		},
+		{
+			name:   "204 with body",
+			status: http.StatusNoContent,
+			header: http.Header{"Content-Type": {"text/plain"}},
+			body:   "unexpected",
+			result: failure,
+		},
+		{
+			name:   "304 with body",
+			status: http.StatusNotModified,
+			header: http.Header{"Content-Type": {"text/plain"}},
+			body:   "unexpected",
+			result: failure,
+		},
+		{
+			name:   "201 with location",
+			status: http.StatusCreated,
+			header: http.Header{"Location": {"/users/1"}},
+			result: success,
+		},
+		{
+			name:   "201 without location",
+			status: http.StatusCreated,
+			result: failure,
+		},
+		{
+			name:   "302 without location",
+			status: http.StatusFound,
+			result: failure,
+		},
+		{
+			name:   "308 with location",
+			status: http.StatusPermanentRedirect,
+			header: http.Header{"Location": {"/new"}},
+			result: success,
+		},
+		{
+			name:   "300 without location",
+			status: http.StatusMultipleChoices,
+			result: success,
+		},
+		{
+			name:   "304 without location",
+			status: http.StatusNotModified,
+			result: success,
+		},
+		{
+			name:   "405 with allow",
+			status: http.StatusMethodNotAllowed,
+			header: http.Header{"Allow": {"GET, HEAD"}},
+			result: success,
+		},
+		{
+			name:   "405 with empty allow",
+			status: http.StatusMethodNotAllowed,
+			header: http.Header{"Allow": {""}},
+			result: success,
+		},
+		{
+			name:   "405 without allow",
+			status: http.StatusMethodNotAllowed,
+			result: failure,
+		},
+		{
+			name:   "multiple violations",
+			status: http.StatusCreated,
+			body:   "created",
+			result: failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			header := tc.header
+			if header == nil {
+				header = http.Header{}
+			}
+
+			reporter := newMockReporter(t)
+
+			resp := NewResponse(reporter, &http.Response{
+				StatusCode: tc.status,
+				Header:     header,
+				Body:       newMockBody(tc.body),
+			})
+
+			resp.HasStrictTransfer()
+			resp.chain.assert(t, tc.result)
+
+			if !tc.result {
+				assert.Equal(t, 1, reporter.reportCalled)
+			}
+		})
+	}
+}
+
+func TestStrictTransfer_Reader(t *testing.T) {
+	t.Run("reader after check", func(t *testing.T) {
+		resp := NewResponse(newMockReporter(t), &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"text/plain"}},
+			Body:       newMockBody("body"),
+		})
+
+		resp.HasStrictTransfer()
+		resp.chain.assert(t, success)
+
+		b, err := io.ReadAll(resp.Reader())
+		assert.NoError(t, err)
+		assert.Equal(t, "body", string(b))
+		resp.chain.assert(t, success)
+	})
+
+	t.Run("check after reader", func(t *testing.T) {
+		resp := NewResponse(newMockReporter(t), &http.Response{
+			StatusCode: http.StatusNoContent,
+			Header:     http.Header{},
+			Body:       newMockBody("body"),
+		})
+
+		reader := resp.Reader()
+		resp.chain.assert(t, success)
+
+		resp.HasStrictTransfer()
+		resp.chain.assert(t, success)
+
+		b, err := io.ReadAll(reader)
+		assert.NoError(t, err)
+		assert.Equal(t, "body", string(b))
+	})
+}
+
+func TestStrictTransfer_Config(t *testing.T) {
+	client := ClientFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Header:     http.Header{},
+			Body:       http.NoBody,
+			Request:    req,
+		}, nil
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		e := WithConfig(Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		})
+
+		e.POST("/").Expect().chain.assert(t, success)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		e := WithConfig(Config{
+			Client:         client,
+			Reporter:       newMockReporter(t),
+			StrictTransfer: true,
+		})
+
+		e.POST("/").Expect().chain.assert(t, failure)
+	})
+
+	t.Run("disabled per request", func(t *testing.T) {
+		e := WithConfig(Config{
+			Client:         client,
+			Reporter:       newMockReporter(t),
+			StrictTransfer: true,
+		})
+
+		e.POST("/").WithStrictTransfer(false).Expect().chain.assert(t, success)
+	})
+
+	t.Run("enabled per request", func(t *testing.T) {
+		e := WithConfig(Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		})
+
+		e.POST("/").WithStrictTransfer(true).Expect().chain.assert(t, failure)
+	})
+}
@@ -1,9 +1,18 @@
 package httpexpect
 
 import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"math"
 	"net/http"
+	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
@@ -622,6 +631,135 @@ func (s *String) NotContainsFold(value string) *String {
 	return s
 }
 
+// ContainsJSONValue succeeds if string is a JSON document that contains
+// given value at any location.
+//
+// Given value is converted to canonical JSON form and compared with the
+// document root and every nested value of the document. Objects match if
+// document object contains all fields of given object (recursively), other
+// values match if they are equal.
+//
+// It's useful for quick smoke checks of response bodies, when building a
+// full path query is overkill.
+//
+// Example:
+//
+//	str := NewString(t, `{"users": [{"id": 5, "name": "john"}]}`)
+//	str.ContainsJSONValue(map[string]interface{}{"id": 5})
+//	str.ContainsJSONValue("john")
+func (s *String) ContainsJSONValue(value interface{}) *String {
+	opChain := s.chain.enter("ContainsJSONValue()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return s
+	}
+
+	doc, expected, ok := s.decodeJSONValue(opChain, value)
+	if !ok {
+		return s
+	}
+
+	if !containsJSONValue(doc, expected) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsSubset,
+			Actual:   &AssertionValue{doc},
+			Expected: &AssertionValue{value},
+			Errors: []error{
+				errors.New("expected: JSON document contains given value"),
+			},
+		})
+	}
+
+	return s
+}
+
+// NotContainsJSONValue succeeds if string is a JSON document that doesn't
+// contain given value at any location.
+//
+// See ContainsJSONValue for details.
+//
+// Example:
+//
+//	str := NewString(t, `{"users": [{"id": 5, "name": "john"}]}`)
+//	str.NotContainsJSONValue(map[string]interface{}{"id": 6})
+func (s *String) NotContainsJSONValue(value interface{}) *String {
+	opChain := s.chain.enter("NotContainsJSONValue()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return s
+	}
+
+	doc, expected, ok := s.decodeJSONValue(opChain, value)
+	if !ok {
+		return s
+	}
+
+	if containsJSONValue(doc, expected) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertNotContainsSubset,
+			Actual:   &AssertionValue{doc},
+			Expected: &AssertionValue{value},
+			Errors: []error{
+				errors.New("expected: JSON document does not contain given value"),
+			},
+		})
+	}
+
+	return s
+}
+
+func (s *String) decodeJSONValue(
+	opChain *chain, value interface{},
+) (doc, expected interface{}, ok bool) {
+	if err := json.Unmarshal([]byte(s.value), &doc); err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{s.value},
+			Errors: []error{
+				errors.New("expected: string is a valid JSON document"),
+				err,
+			},
+		})
+		return nil, nil, false
+	}
+
+	expected, ok = canonValue(opChain, value)
+	if !ok {
+		return nil, nil, false
+	}
+
+	return doc, expected, true
+}
+
+func containsJSONValue(doc, value interface{}) bool {
+	if outer, ok := doc.(map[string]interface{}); ok {
+		if inner, ok := value.(map[string]interface{}); ok && isSubset(outer, inner) {
+			return true
+		}
+	} else if reflect.DeepEqual(doc, value) {
+		return true
+	}
+
+	switch d := doc.(type) {
+	case map[string]interface{}:
+		for _, v := range d {
+			if containsJSONValue(v, value) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, v := range d {
+			if containsJSONValue(v, value) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 // HasPrefix succeeds if string has given Go string as prefix
 //
 // Example:
@@ -1259,12 +1397,8 @@ func (s *String) AsDateTime(format ...string) *DateTime {
 			formatList = append(formatList, datetimeFormat{layout: f})
 		}
 	} else {
-		formatList = []datetimeFormat{
-			{http.TimeFormat, "RFC1123+GMT"},
-
-			{time.RFC850, "RFC850"},
-
-			{time.ANSIC, "ANSIC"},
+		formatList = append(formatList, httpDateFormats...)
+		formatList = append(formatList, []datetimeFormat{
 			{time.UnixDate, "Unix"},
 			{time.RubyDate, "Ruby"},
 
@@ -1274,7 +1408,7 @@ func (s *String) AsDateTime(format ...string) *DateTime {
 			{time.RFC822Z, "RFC822Z"},
 			{time.RFC3339, "RFC3339"},
 			{time.RFC3339Nano, "RFC3339+nano"},
-		}
+		}...)
 	}
 
 	var (
@@ -1320,6 +1454,286 @@ func (s *String) AsDateTime(format ...string) *DateTime {
 	return newDateTime(opChain, tm)
 }
 
+// AsDuration parses duration from string and returns a new Duration instance
+// with result.
+//
+// Accepts Go duration syntax (e.g. "1h30m", "250ms"), see time.ParseDuration,
+// and ISO 8601 durations (e.g. "PT5M", "P1DT2H", "PT0.5S"). In ISO 8601
+// durations, a day is 24 hours and a week is 7 days; years and months are
+// not supported because they don't have fixed length.
+//
+// If the string can't be parsed, AsDuration reports failure and returns
+// empty (but non-nil) instance.
+//
+// Example:
+//
+//	str := NewString(t, "PT5M")
+//	str.AsDuration().IsEqual(5 * time.Minute)
+//
+//	str := NewString(t, "1m30s")
+//	str.AsDuration().IsEqual(90 * time.Second)
+func (s *String) AsDuration() *Duration {
+	opChain := s.chain.enter("AsDuration()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newDuration(opChain, nil)
+	}
+
+	if d, err := time.ParseDuration(s.value); err == nil {
+		return newDuration(opChain, &d)
+	}
+
+	d, err := parseISO8601Duration(s.value)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertMatchFormat,
+			Actual: &AssertionValue{s.value},
+			Expected: &AssertionValue{AssertionList{
+				"Go duration", "ISO 8601 duration",
+			}},
+			Errors: []error{
+				errors.New("expected: string can be parsed to duration"),
+				err,
+			},
+		})
+		return newDuration(opChain, nil)
+	}
+
+	return newDuration(opChain, &d)
+}
+
+// DecodeGzip decodes base64-wrapped gzip payload from string and returns
+// a new String instance with decompressed data.
+//
+// Standard and URL-safe base64 alphabets are accepted, with or without
+// padding.
+//
+// If the string is not valid base64, or decoded data is not valid gzip,
+// DecodeGzip reports failure and returns empty (but non-nil) instance.
+//
+// Example:
+//
+//	str := NewString(t, "H4sIAAAAAAAA/wAJAPb/eyJhIjoiYiJ9AwCcXPZrCQAAAA==")
+//	str.DecodeGzip().IsEqual(`{"a":"b"}`)
+func (s *String) DecodeGzip() *String {
+	opChain := s.chain.enter("DecodeGzip()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	data, err := decompressBase64(s.value, func(r io.Reader) (io.Reader, error) {
+		return gzip.NewReader(r)
+	})
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{s.value},
+			Errors: []error{
+				errors.New("expected: string is base64-encoded gzip data"),
+				err,
+			},
+		})
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, data)
+}
+
+// DecodeDeflate decodes base64-wrapped deflate payload from string and
+// returns a new String instance with decompressed data.
+//
+// Both zlib-wrapped (RFC 1950), as used by HTTP "deflate" encoding, and
+// raw (RFC 1951) deflate streams are accepted. Standard and URL-safe
+// base64 alphabets are accepted, with or without padding.
+//
+// If the string is not valid base64, or decoded data is not valid deflate,
+// DecodeDeflate reports failure and returns empty (but non-nil) instance.
+//
+// Example:
+//
+//	str := NewString(t, "eJwACQD2/3siYSI6ImIifQMADHACfg==")
+//	str.DecodeDeflate().IsEqual(`{"a":"b"}`)
+func (s *String) DecodeDeflate() *String {
+	opChain := s.chain.enter("DecodeDeflate()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	data, err := decompressBase64(s.value, func(r io.Reader) (io.Reader, error) {
+		return zlib.NewReader(r)
+	})
+	if err != nil {
+		data, err = decompressBase64(s.value, func(r io.Reader) (io.Reader, error) {
+			return flate.NewReader(r), nil
+		})
+	}
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{s.value},
+			Errors: []error{
+				errors.New("expected: string is base64-encoded deflate data"),
+				err,
+			},
+		})
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, data)
+}
+
+func decompressBase64(
+	str string, newReader func(io.Reader) (io.Reader, error),
+) (string, error) {
+	var (
+		compressed []byte
+		err        error
+	)
+
+	for _, enc := range []*base64.Encoding{
+		base64.StdEncoding,
+		base64.URLEncoding,
+		base64.RawStdEncoding,
+		base64.RawURLEncoding,
+	} {
+		if compressed, err = enc.DecodeString(str); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+
+	reader, err := newReader(bytes.NewReader(compressed))
+	if err != nil {
+		return "", err
+	}
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// parseISO8601Duration parses ISO 8601 duration without years and months,
+// e.g. "P1W", "P2DT3H4M5.5S", or "-PT10S".
+func parseISO8601Duration(str string) (time.Duration, error) {
+	value := str
+
+	negative := false
+	if strings.HasPrefix(value, "-") {
+		negative = true
+		value = value[1:]
+	} else if strings.HasPrefix(value, "+") {
+		value = value[1:]
+	}
+
+	if !strings.HasPrefix(value, "P") || len(value) == 1 {
+		return 0, fmt.Errorf("invalid ISO 8601 duration %q", str)
+	}
+	value = value[1:]
+
+	var (
+		total     float64
+		inTime    bool
+		hasValue  bool
+		lastIndex = -1
+	)
+
+	designators := []struct {
+		unit   byte
+		inTime bool
+		scale  time.Duration
+	}{
+		{'W', false, 7 * 24 * time.Hour},
+		{'D', false, 24 * time.Hour},
+		{'H', true, time.Hour},
+		{'M', true, time.Minute},
+		{'S', true, time.Second},
+	}
+
+	for len(value) != 0 {
+		if value[0] == 'T' {
+			if inTime || len(value) == 1 {
+				return 0, fmt.Errorf("invalid ISO 8601 duration %q", str)
+			}
+			inTime = true
+			value = value[1:]
+			continue
+		}
+
+		n := strings.IndexFunc(value, func(r rune) bool {
+			return (r < '0' || r > '9') && r != '.' && r != ','
+		})
+		if n <= 0 {
+			return 0, fmt.Errorf("invalid ISO 8601 duration %q", str)
+		}
+
+		number, err := strconv.ParseFloat(strings.Replace(value[:n], ",", ".", 1), 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid ISO 8601 duration %q", str)
+		}
+
+		unit := value[n]
+		value = value[n+1:]
+
+		index := -1
+		for i, d := range designators {
+			if d.unit == unit && d.inTime == inTime {
+				index = i
+				break
+			}
+		}
+
+		if index < 0 {
+			if !inTime && (unit == 'Y' || unit == 'M') {
+				return 0, fmt.Errorf(
+					"unsupported ISO 8601 duration %q: years and months"+
+						" don't have fixed length", str)
+			}
+			return 0, fmt.Errorf("invalid ISO 8601 duration %q", str)
+		}
+
+		// designators must be unique and go in order
+		if index <= lastIndex {
+			return 0, fmt.Errorf("invalid ISO 8601 duration %q", str)
+		}
+		lastIndex = index
+
+		total += number * float64(designators[index].scale)
+		hasValue = true
+	}
+
+	if !hasValue {
+		return 0, fmt.Errorf("invalid ISO 8601 duration %q", str)
+	}
+
+	if total > math.MaxInt64 {
+		return 0, fmt.Errorf("ISO 8601 duration %q is out of range", str)
+	}
+
+	d := time.Duration(math.Round(total))
+	if negative {
+		d = -d
+	}
+
+	return d, nil
+}
+
+// Formats allowed for HTTP-date by RFC 7231, section 7.1.1.1.
+var httpDateFormats = []datetimeFormat{
+	{http.TimeFormat, "RFC1123+GMT"},
+	{time.RFC850, "RFC850"},
+	{time.ANSIC, "ANSIC"},
+}
+
 type datetimeFormat struct {
 	layout string
 	name   string
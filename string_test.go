@@ -1,11 +1,18 @@
 package httpexpect
 
 import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/base64"
+	"io"
 	"net/http"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestString_FailedChain(t *testing.T) {
@@ -37,6 +44,8 @@ func TestString_FailedChain(t *testing.T) {
 	value.NotContains("")
 	value.ContainsFold("")
 	value.NotContainsFold("")
+	value.ContainsJSONValue(nil)
+	value.NotContainsJSONValue(nil)
 	value.HasPrefix("")
 	value.NotHasPrefix("")
 	value.HasSuffix("")
@@ -56,6 +65,9 @@ func TestString_FailedChain(t *testing.T) {
 	value.AsBoolean().chain.assert(t, failure)
 	value.AsNumber().chain.assert(t, failure)
 	value.AsDateTime().chain.assert(t, failure)
+	value.AsDuration().chain.assert(t, failure)
+	value.DecodeGzip().chain.assert(t, failure)
+	value.DecodeDeflate().chain.assert(t, failure)
 }
 
 func TestString_Constructors(t *testing.T) {
@@ -372,6 +384,133 @@ func TestString_Contains(t *testing.T) {
 	}
 }
 
+func TestString_ContainsJSONValue(t *testing.T) {
+	doc := `{
+		"users": [
+			{"id": 5, "name": "john", "address": {"city": "Paris", "zip": "75001"}},
+			{"id": 6, "name": "jane", "tags": ["admin", null]}
+		],
+		"total": 2
+	}`
+
+	cases := []struct {
+		name         string
+		str          string
+		value        interface{}
+		wantContains chainResult
+	}{
+		{
+			name:         "object subset",
+			str:          doc,
+			value:        map[string]interface{}{"id": 5},
+			wantContains: success,
+		},
+		{
+			name: "nested object subset",
+			str:  doc,
+			value: map[string]interface{}{
+				"address": map[string]interface{}{"city": "Paris"},
+			},
+			wantContains: success,
+		},
+		{
+			name:         "object mismatch",
+			str:          doc,
+			value:        map[string]interface{}{"id": 5, "name": "jane"},
+			wantContains: failure,
+		},
+		{
+			name:         "string",
+			str:          doc,
+			value:        "jane",
+			wantContains: success,
+		},
+		{
+			name:         "number",
+			str:          doc,
+			value:        2,
+			wantContains: success,
+		},
+		{
+			name:         "null",
+			str:          doc,
+			value:        nil,
+			wantContains: success,
+		},
+		{
+			name:         "array",
+			str:          doc,
+			value:        []interface{}{"admin", nil},
+			wantContains: success,
+		},
+		{
+			name:         "array mismatch",
+			str:          doc,
+			value:        []interface{}{"admin"},
+			wantContains: failure,
+		},
+		{
+			name:         "key is not value",
+			str:          doc,
+			value:        "users",
+			wantContains: failure,
+		},
+		{
+			name: "struct",
+			str:  doc,
+			value: struct {
+				ID int `json:"id"`
+			}{6},
+			wantContains: success,
+		},
+		{
+			name:         "root",
+			str:          `"foo"`,
+			value:        "foo",
+			wantContains: success,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			NewString(reporter, tc.str).ContainsJSONValue(tc.value).
+				chain.assert(t, tc.wantContains)
+			NewString(reporter, tc.str).NotContainsJSONValue(tc.value).
+				chain.assert(t, !tc.wantContains)
+		})
+	}
+
+	t.Run("invalid json", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		NewString(reporter, `{"id": `).ContainsJSONValue(5).
+			chain.assert(t, failure)
+		NewString(reporter, `{"id": `).NotContainsJSONValue(5).
+			chain.assert(t, failure)
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		NewString(reporter, doc).ContainsJSONValue(func() {}).
+			chain.assert(t, failure)
+		NewString(reporter, doc).NotContainsJSONValue(func() {}).
+			chain.assert(t, failure)
+	})
+
+	t.Run("response body", func(t *testing.T) {
+		resp := NewResponse(newMockReporter(t), &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       newMockBody(doc),
+		})
+
+		resp.Body().ContainsJSONValue(map[string]interface{}{"name": "john"})
+		resp.chain.assert(t, success)
+	})
+}
+
 func TestString_HasPrefix(t *testing.T) {
 	cases := []struct {
 		name              string
@@ -884,3 +1023,186 @@ func TestString_AsDateTime(t *testing.T) {
 		}
 	}
 }
+
+func TestString_AsDuration(t *testing.T) {
+	cases := []struct {
+		str    string
+		want   time.Duration
+		result chainResult
+	}{
+		{"1h30m", 90 * time.Minute, success},
+		{"250ms", 250 * time.Millisecond, success},
+		{"-5s", -5 * time.Second, success},
+		{"0", 0, success},
+		{"PT5M", 5 * time.Minute, success},
+		{"PT0.5S", 500 * time.Millisecond, success},
+		{"PT0,5S", 500 * time.Millisecond, success},
+		{"P1DT2H", 26 * time.Hour, success},
+		{"P2W", 14 * 24 * time.Hour, success},
+		{"P1DT2H3M4S", 26*time.Hour + 3*time.Minute + 4*time.Second, success},
+		{"-PT10S", -10 * time.Second, success},
+		{"PT36H", 36 * time.Hour, success},
+		{"", 0, failure},
+		{"P", 0, failure},
+		{"PT", 0, failure},
+		{"P1DT", 0, failure},
+		{"P1Y", 0, failure},
+		{"P1M", 0, failure},
+		{"PT1H1H", 0, failure},
+		{"PT1S1M", 0, failure},
+		{"P1H", 0, failure},
+		{"PTTS", 0, failure},
+		{"PT1X", 0, failure},
+		{"PT1.2.3S", 0, failure},
+		{"five minutes", 0, failure},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.str, func(t *testing.T) {
+			reporter := newMockReporter(t)
+			value := NewString(reporter, tc.str)
+
+			d := value.AsDuration()
+			d.chain.assert(t, tc.result)
+			value.chain.assert(t, tc.result)
+
+			assert.Equal(t, tc.want, d.Raw())
+		})
+	}
+}
+
+func TestString_DecodeCompressed(t *testing.T) {
+	const payload = `{"a":"b"}`
+
+	compress := func(newWriter func(io.Writer) io.WriteCloser) []byte {
+		var buf bytes.Buffer
+		w := newWriter(&buf)
+		_, err := w.Write([]byte(payload))
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		return buf.Bytes()
+	}
+
+	gzipData := compress(func(w io.Writer) io.WriteCloser {
+		return gzip.NewWriter(w)
+	})
+	zlibData := compress(func(w io.Writer) io.WriteCloser {
+		return zlib.NewWriter(w)
+	})
+	flateData := compress(func(w io.Writer) io.WriteCloser {
+		fw, _ := flate.NewWriter(w, flate.DefaultCompression)
+		return fw
+	})
+
+	t.Run("gzip", func(t *testing.T) {
+		cases := []struct {
+			name   string
+			str    string
+			want   string
+			result chainResult
+		}{
+			{
+				name:   "std encoding",
+				str:    base64.StdEncoding.EncodeToString(gzipData),
+				want:   payload,
+				result: success,
+			},
+			{
+				name:   "url encoding",
+				str:    base64.URLEncoding.EncodeToString(gzipData),
+				want:   payload,
+				result: success,
+			},
+			{
+				name:   "raw url encoding",
+				str:    base64.RawURLEncoding.EncodeToString(gzipData),
+				want:   payload,
+				result: success,
+			},
+			{
+				name:   "not base64",
+				str:    "not base64!",
+				result: failure,
+			},
+			{
+				name:   "not gzip",
+				str:    base64.StdEncoding.EncodeToString([]byte(payload)),
+				result: failure,
+			},
+			{
+				name:   "zlib",
+				str:    base64.StdEncoding.EncodeToString(zlibData),
+				result: failure,
+			},
+			{
+				name:   "truncated",
+				str:    base64.StdEncoding.EncodeToString(gzipData[:len(gzipData)-4]),
+				result: failure,
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				reporter := newMockReporter(t)
+				value := NewString(reporter, tc.str)
+
+				str := value.DecodeGzip()
+				str.chain.assert(t, tc.result)
+				value.chain.assert(t, tc.result)
+
+				assert.Equal(t, tc.want, str.Raw())
+			})
+		}
+	})
+
+	t.Run("deflate", func(t *testing.T) {
+		cases := []struct {
+			name   string
+			str    string
+			want   string
+			result chainResult
+		}{
+			{
+				name:   "zlib",
+				str:    base64.StdEncoding.EncodeToString(zlibData),
+				want:   payload,
+				result: success,
+			},
+			{
+				name:   "raw deflate",
+				str:    base64.StdEncoding.EncodeToString(flateData),
+				want:   payload,
+				result: success,
+			},
+			{
+				name:   "url encoding",
+				str:    base64.RawURLEncoding.EncodeToString(zlibData),
+				want:   payload,
+				result: success,
+			},
+			{
+				name:   "not base64",
+				str:    "not base64!",
+				result: failure,
+			},
+			{
+				name:   "not deflate",
+				str:    base64.StdEncoding.EncodeToString([]byte(payload)),
+				result: failure,
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				reporter := newMockReporter(t)
+				value := NewString(reporter, tc.str)
+
+				str := value.DecodeDeflate()
+				str.chain.assert(t, tc.result)
+				value.chain.assert(t, tc.result)
+
+				assert.Equal(t, tc.want, str.Raw())
+			})
+		}
+	})
+}
@@ -0,0 +1,710 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"reflect"
+	"sync"
+)
+
+// StubServer is an ephemeral HTTP server that replaces external dependency
+// of the service under test, e.g. a third-party API.
+//
+// Expected outbound requests are defined using On, which returns Stub.
+// Every Stub matches requests by method, path and optional query, header,
+// and body matchers, and replies with canned response. After the test,
+// Verify checks that every stub was called expected number of times,
+// optionally in expected order, and that there were no unexpected requests.
+//
+// Requests that don't match any stub are answered with 501 Not Implemented
+// and reported by Verify.
+//
+// Example:
+//
+//	stub := e.StubServer()
+//	defer stub.Close()
+//
+//	stub.On("POST", "/v1/charges").
+//		WithJSON(map[string]interface{}{"amount": 100}).
+//		Times(1).
+//		RespondJSON(http.StatusOK, map[string]interface{}{"id": "ch_1"})
+//
+//	e.POST("/orders").WithJSON(map[string]interface{}{
+//		"payment_url": stub.URL(),
+//	}).
+//		Expect().
+//		Status(http.StatusCreated)
+//
+//	stub.Verify()
+type StubServer struct {
+	noCopy noCopy
+	config Config
+	chain  *chain
+
+	server *httptest.Server
+
+	mu        sync.Mutex
+	inOrder   bool
+	stubs     []*Stub
+	sequence  []int
+	unmatched []*webhookRecord
+}
+
+// StubServer starts a new StubServer and returns it.
+// Server should be closed with Close when it's no longer needed.
+//
+// Example:
+//
+//	stub := e.StubServer()
+//	defer stub.Close()
+func (e *Expect) StubServer() *StubServer {
+	opChain := e.chain.enter("StubServer()")
+	defer opChain.leave()
+
+	s := &StubServer{
+		config: e.config,
+		chain:  opChain.clone(),
+	}
+
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+func (s *StubServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	rec := &webhookRecord{
+		method: r.Method,
+		path:   r.URL.RequestURI(),
+		header: r.Header.Clone(),
+		body:   body,
+	}
+
+	s.mu.Lock()
+
+	var matched *Stub
+
+	for n, st := range s.stubs {
+		if st.matches(r, body) {
+			matched = st
+			st.calls = append(st.calls, rec)
+			s.sequence = append(s.sequence, n)
+			break
+		}
+	}
+
+	if matched == nil {
+		s.unmatched = append(s.unmatched, rec)
+		s.mu.Unlock()
+
+		http.Error(w, "no matching stub", http.StatusNotImplemented)
+		return
+	}
+
+	status := matched.status
+	header := matched.respHeader.Clone()
+	respBody := matched.respBody
+
+	s.mu.Unlock()
+
+	for k, v := range header {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(status)
+	_, _ = w.Write(respBody)
+}
+
+// URL returns base URL of the server, e.g. "http://127.0.0.1:12345".
+func (s *StubServer) URL() string {
+	return s.server.URL
+}
+
+// Close shuts down the server.
+// Recorded requests remain available after Close.
+func (s *StubServer) Close() {
+	s.server.Close()
+}
+
+// Alias is similar to Value.Alias.
+func (s *StubServer) Alias(name string) *StubServer {
+	opChain := s.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	s.chain.setAlias(name)
+	return s
+}
+
+// InOrder requires stubs to be called in the order in which they were
+// defined: all calls of a stub should precede calls of stubs defined
+// after it. Order is checked by Verify.
+//
+// Example:
+//
+//	stub.InOrder()
+//	stub.On("POST", "/v1/tokens").RespondJSON(http.StatusOK, token)
+//	stub.On("POST", "/v1/charges").RespondJSON(http.StatusOK, charge)
+func (s *StubServer) InOrder() *StubServer {
+	opChain := s.chain.enter("InOrder()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return s
+	}
+
+	s.mu.Lock()
+	s.inOrder = true
+	s.mu.Unlock()
+
+	return s
+}
+
+// On defines a new Stub matching requests with given method and path.
+//
+// path is matched against request path without query using path.Match,
+// so it may contain wildcards, e.g. "/v1/users/*". If a request matches
+// several stubs, the first defined one that still accepts calls is used
+// (see Stub.Times).
+//
+// New stub replies with 200 OK and empty body, unless changed using
+// Respond, RespondHeader, RespondBody, or RespondJSON.
+//
+// Example:
+//
+//	stub.On("GET", "/v1/users/*").
+//		RespondJSON(http.StatusOK, map[string]interface{}{"name": "john"})
+func (s *StubServer) On(method, urlPath string) *Stub {
+	opChain := s.chain.enter("On(%q, %q)", method, urlPath)
+	defer opChain.leave()
+
+	newStub := func() *Stub {
+		return &Stub{
+			server:     s,
+			chain:      opChain.clone(),
+			method:     method,
+			path:       urlPath,
+			times:      -1,
+			status:     http.StatusOK,
+			respHeader: http.Header{},
+		}
+	}
+
+	if opChain.failed() {
+		return newStub()
+	}
+
+	if _, err := path.Match(urlPath, ""); err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("invalid path pattern %q", urlPath),
+				err,
+			},
+		})
+		return newStub()
+	}
+
+	st := newStub()
+
+	s.mu.Lock()
+	s.stubs = append(s.stubs, st)
+	s.mu.Unlock()
+
+	return st
+}
+
+// Verify succeeds if every stub was called expected number of times
+// (see Stub.Times), stubs were called in order if InOrder was used, and
+// there were no requests not matching any stub.
+//
+// All violations are reported as a single failure.
+//
+// Example:
+//
+//	defer stub.Verify()
+func (s *StubServer) Verify() *StubServer {
+	opChain := s.chain.enter("Verify()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return s
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var (
+		errs   []error
+		actual []string
+	)
+
+	for _, st := range s.stubs {
+		actual = append(actual,
+			fmt.Sprintf("%s: %d calls", st.describe(), len(st.calls)))
+
+		if st.times >= 0 && len(st.calls) != st.times {
+			errs = append(errs, fmt.Errorf("%s: expected %d calls, got %d",
+				st.describe(), st.times, len(st.calls)))
+		}
+	}
+
+	if s.inOrder {
+		for i := 1; i < len(s.sequence); i++ {
+			if s.sequence[i] < s.sequence[i-1] {
+				cur, prev := s.stubs[s.sequence[i]], s.stubs[s.sequence[i-1]]
+				errs = append(errs, fmt.Errorf("%s: called after %s",
+					cur.describe(), prev.describe()))
+				break
+			}
+		}
+	}
+
+	for _, rec := range s.unmatched {
+		actual = append(actual, fmt.Sprintf("%s %s: unmatched", rec.method, rec.path))
+		errs = append(errs, fmt.Errorf("%s %s: unexpected request", rec.method, rec.path))
+	}
+
+	if len(errs) != 0 {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{actual},
+			Errors: append([]error{
+				errors.New("expected: stub server received expected requests"),
+			}, errs...),
+		})
+	}
+
+	return s
+}
+
+// Unmatched returns a new Number instance with number of received requests
+// that didn't match any stub.
+//
+// Example:
+//
+//	stub.Unmatched().IsEqual(0)
+func (s *StubServer) Unmatched() *Number {
+	opChain := s.chain.enter("Unmatched()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, 0)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return newNumber(opChain, float64(len(s.unmatched)))
+}
+
+// Stub defines expected outbound request and response to it.
+// Stub is created using StubServer.On.
+type Stub struct {
+	noCopy noCopy
+	server *StubServer
+	chain  *chain
+
+	// guarded by server.mu
+	method   string
+	path     string
+	query    map[string]string
+	header   map[string]string
+	body     *string
+	jsonBody interface{}
+	hasJSON  bool
+	times    int
+
+	status     int
+	respHeader http.Header
+	respBody   []byte
+
+	calls []*webhookRecord
+}
+
+func (st *Stub) describe() string {
+	return st.method + " " + st.path
+}
+
+func (st *Stub) matches(r *http.Request, body []byte) bool {
+	if r.Method != st.method {
+		return false
+	}
+
+	if ok, _ := path.Match(st.path, r.URL.Path); !ok {
+		return false
+	}
+
+	if st.times >= 0 && len(st.calls) >= st.times {
+		return false
+	}
+
+	query := r.URL.Query()
+	for k, v := range st.query {
+		if query.Get(k) != v {
+			return false
+		}
+	}
+
+	for k, v := range st.header {
+		if r.Header.Get(k) != v {
+			return false
+		}
+	}
+
+	if st.body != nil && string(body) != *st.body {
+		return false
+	}
+
+	if st.hasJSON {
+		var value interface{}
+		if err := jsonDecoderOrDefault(st.server.config.JSONDecoder).
+			Unmarshal(body, &value); err != nil {
+			return false
+		}
+		if !reflect.DeepEqual(value, st.jsonBody) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Alias is similar to Value.Alias.
+func (st *Stub) Alias(name string) *Stub {
+	opChain := st.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	st.chain.setAlias(name)
+	return st
+}
+
+// WithQuery requires request to have query parameter with given value.
+//
+// Example:
+//
+//	stub.On("GET", "/v1/users").WithQuery("limit", "10")
+func (st *Stub) WithQuery(key, value string) *Stub {
+	opChain := st.chain.enter("WithQuery()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return st
+	}
+
+	st.server.mu.Lock()
+	defer st.server.mu.Unlock()
+
+	if st.query == nil {
+		st.query = make(map[string]string)
+	}
+	st.query[key] = value
+
+	return st
+}
+
+// WithHeader requires request to have header with given value.
+//
+// Example:
+//
+//	stub.On("GET", "/v1/users").WithHeader("Authorization", "Bearer token")
+func (st *Stub) WithHeader(key, value string) *Stub {
+	opChain := st.chain.enter("WithHeader()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return st
+	}
+
+	st.server.mu.Lock()
+	defer st.server.mu.Unlock()
+
+	if st.header == nil {
+		st.header = make(map[string]string)
+	}
+	st.header[key] = value
+
+	return st
+}
+
+// WithBody requires request body to be equal to given string.
+//
+// Example:
+//
+//	stub.On("POST", "/v1/messages").WithBody("hello")
+func (st *Stub) WithBody(body string) *Stub {
+	opChain := st.chain.enter("WithBody()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return st
+	}
+
+	st.server.mu.Lock()
+	defer st.server.mu.Unlock()
+
+	st.body = &body
+
+	return st
+}
+
+// WithJSON requires request body to be JSON equal to given value.
+//
+// value is converted to JSON and compared with request body semantically,
+// i.e. ignoring formatting and order of object keys.
+//
+// Example:
+//
+//	stub.On("POST", "/v1/charges").
+//		WithJSON(map[string]interface{}{"amount": 100, "currency": "usd"})
+func (st *Stub) WithJSON(value interface{}) *Stub {
+	opChain := st.chain.enter("WithJSON()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return st
+	}
+
+	expected, ok := canonValue(opChain, value)
+	if !ok {
+		return st
+	}
+
+	st.server.mu.Lock()
+	defer st.server.mu.Unlock()
+
+	st.jsonBody = expected
+	st.hasJSON = true
+
+	return st
+}
+
+// Times sets exact number of calls expected by Verify.
+//
+// After stub was called given number of times, it doesn't match requests
+// anymore, so the next stub with same method and path may be used, e.g.
+// to reply differently on retries. By default, number of calls is not
+// limited and not checked.
+//
+// Example:
+//
+//	stub.On("POST", "/v1/charges").Times(1).Respond(http.StatusServiceUnavailable)
+//	stub.On("POST", "/v1/charges").Times(1).Respond(http.StatusOK)
+func (st *Stub) Times(n int) *Stub {
+	opChain := st.chain.enter("Times()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return st
+	}
+
+	if n < 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected negative count"),
+			},
+		})
+		return st
+	}
+
+	st.server.mu.Lock()
+	defer st.server.mu.Unlock()
+
+	st.times = n
+
+	return st
+}
+
+// Respond sets status code of response.
+// Default is 200 OK.
+//
+// Example:
+//
+//	stub.On("DELETE", "/v1/users/*").Respond(http.StatusNoContent)
+func (st *Stub) Respond(status int) *Stub {
+	opChain := st.chain.enter("Respond()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return st
+	}
+
+	if status < 100 || status > 999 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected invalid status code %d", status),
+			},
+		})
+		return st
+	}
+
+	st.server.mu.Lock()
+	defer st.server.mu.Unlock()
+
+	st.status = status
+
+	return st
+}
+
+// RespondHeader adds header to response.
+//
+// Example:
+//
+//	stub.On("GET", "/v1/users").RespondHeader("X-Total-Count", "10")
+func (st *Stub) RespondHeader(key, value string) *Stub {
+	opChain := st.chain.enter("RespondHeader()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return st
+	}
+
+	st.server.mu.Lock()
+	defer st.server.mu.Unlock()
+
+	st.respHeader.Add(key, value)
+
+	return st
+}
+
+// RespondBody sets status code and body of response.
+//
+// Example:
+//
+//	stub.On("GET", "/health").RespondBody(http.StatusOK, "ok")
+func (st *Stub) RespondBody(status int, body string) *Stub {
+	opChain := st.chain.enter("RespondBody()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return st
+	}
+
+	if status < 100 || status > 999 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected invalid status code %d", status),
+			},
+		})
+		return st
+	}
+
+	st.server.mu.Lock()
+	defer st.server.mu.Unlock()
+
+	st.status = status
+	st.respBody = []byte(body)
+
+	return st
+}
+
+// RespondJSON sets status code and body of response to given value
+// encoded as JSON, and sets "Content-Type" header to "application/json".
+//
+// Example:
+//
+//	stub.On("POST", "/v1/charges").
+//		RespondJSON(http.StatusOK, map[string]interface{}{"id": "ch_1"})
+func (st *Stub) RespondJSON(status int, value interface{}) *Stub {
+	opChain := st.chain.enter("RespondJSON()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return st
+	}
+
+	if status < 100 || status > 999 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected invalid status code %d", status),
+			},
+		})
+		return st
+	}
+
+	body, err := jsonEncoderOrDefault(st.server.config.JSONEncoder).Marshal(value)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("invalid json value"),
+				err,
+			},
+		})
+		return st
+	}
+
+	st.server.mu.Lock()
+	defer st.server.mu.Unlock()
+
+	st.status = status
+	st.respHeader.Set("Content-Type", "application/json; charset=utf-8")
+	st.respBody = body
+
+	return st
+}
+
+// Count returns a new Number instance with number of requests matched
+// by this stub.
+//
+// Example:
+//
+//	stub.On("POST", "/v1/charges").Count().IsEqual(1)
+func (st *Stub) Count() *Number {
+	opChain := st.chain.enter("Count()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, 0)
+	}
+
+	return newNumber(opChain, float64(len(st.snapshot())))
+}
+
+// Request returns a new WebhookRequest instance for request with given
+// index matched by this stub, in order of arrival.
+//
+// Example:
+//
+//	charges.Request(0).JSON().Object().HasValue("amount", 100)
+func (st *Stub) Request(index int) *WebhookRequest {
+	opChain := st.chain.enter("Request(%d)", index)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newWebhookRequest(opChain, st.server.config, nil)
+	}
+
+	records := st.snapshot()
+
+	if index < 0 || index >= len(records) {
+		opChain.fail(AssertionFailure{
+			Type:   AssertInRange,
+			Actual: &AssertionValue{index},
+			Expected: &AssertionValue{AssertionRange{
+				Min: 0,
+				Max: len(records) - 1,
+			}},
+			Errors: []error{
+				errors.New("expected: valid request index"),
+			},
+		})
+		return newWebhookRequest(opChain, st.server.config, nil)
+	}
+
+	return newWebhookRequest(opChain, st.server.config, records[index])
+}
+
+func (st *Stub) snapshot() []*webhookRecord {
+	st.server.mu.Lock()
+	defer st.server.mu.Unlock()
+
+	return append([]*webhookRecord(nil), st.calls...)
+}
@@ -0,0 +1,275 @@
+package httpexpect
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stubCall(t *testing.T, method, url, body string) (int, string) {
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return resp.StatusCode, string(b)
+}
+
+func TestStubServer_Match(t *testing.T) {
+	e := WithConfig(Config{
+		Reporter: newMockReporter(t),
+	})
+
+	stub := e.StubServer()
+	defer stub.Close()
+
+	charges := stub.On("POST", "/v1/charges").
+		WithJSON(map[string]interface{}{"amount": 100}).
+		WithHeader("Authorization", "Bearer token").
+		RespondJSON(http.StatusCreated, map[string]interface{}{"id": "ch_1"})
+
+	users := stub.On("GET", "/v1/users/*").
+		WithQuery("expand", "true").
+		RespondHeader("X-Foo", "bar").
+		RespondBody(http.StatusOK, "john")
+
+	charges.chain.assert(t, success)
+	users.chain.assert(t, success)
+
+	req, err := http.NewRequest("POST", stub.URL()+"/v1/charges",
+		strings.NewReader(`{ "amount": 100 }`))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer token")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "application/json; charset=utf-8", resp.Header.Get("Content-Type"))
+	assert.JSONEq(t, `{"id":"ch_1"}`, string(body))
+
+	status, text := stubCall(t, "GET", stub.URL()+"/v1/users/1?expand=true", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "john", text)
+
+	// query doesn't match
+	status, _ = stubCall(t, "GET", stub.URL()+"/v1/users/1", "")
+	assert.Equal(t, http.StatusNotImplemented, status)
+
+	// header doesn't match
+	status, _ = stubCall(t, "POST", stub.URL()+"/v1/charges", `{"amount":100}`)
+	assert.Equal(t, http.StatusNotImplemented, status)
+
+	charges.Count().IsEqual(1)
+	charges.Request(0).JSON().Object().HasValue("amount", 100)
+	charges.chain.assert(t, success)
+
+	users.Count().IsEqual(1)
+	users.Request(0).Path().IsEqual("/v1/users/1?expand=true")
+	users.chain.assert(t, success)
+
+	stub.Unmatched().IsEqual(2)
+	stub.chain.assert(t, success)
+
+	stub.Verify()
+	stub.chain.assert(t, failure)
+}
+
+func TestStubServer_Verify(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		e := WithConfig(Config{
+			Reporter: newMockReporter(t),
+		})
+
+		stub := e.StubServer()
+		defer stub.Close()
+
+		stub.On("GET", "/a").Times(1)
+		stub.On("GET", "/b")
+
+		stubCall(t, "GET", stub.URL()+"/a", "")
+
+		stub.Verify()
+		stub.chain.assert(t, success)
+	})
+
+	t.Run("times", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		e := WithConfig(Config{
+			AssertionHandler: handler,
+		})
+
+		stub := e.StubServer()
+		defer stub.Close()
+
+		stub.On("GET", "/a").Times(2)
+
+		stubCall(t, "GET", stub.URL()+"/a", "")
+
+		stub.Verify()
+		stub.chain.assert(t, failure)
+
+		require.NotNil(t, handler.failure)
+		assert.Contains(t, handler.failure.Errors[1].Error(),
+			"GET /a: expected 2 calls, got 1")
+	})
+
+	t.Run("sequential responses", func(t *testing.T) {
+		e := WithConfig(Config{
+			Reporter: newMockReporter(t),
+		})
+
+		stub := e.StubServer()
+		defer stub.Close()
+
+		stub.On("POST", "/a").Times(1).Respond(http.StatusServiceUnavailable)
+		stub.On("POST", "/a").Times(1).Respond(http.StatusOK)
+
+		status, _ := stubCall(t, "POST", stub.URL()+"/a", "")
+		assert.Equal(t, http.StatusServiceUnavailable, status)
+
+		status, _ = stubCall(t, "POST", stub.URL()+"/a", "")
+		assert.Equal(t, http.StatusOK, status)
+
+		status, _ = stubCall(t, "POST", stub.URL()+"/a", "")
+		assert.Equal(t, http.StatusNotImplemented, status)
+
+		stub.Verify()
+		stub.chain.assert(t, failure)
+	})
+
+	t.Run("in order", func(t *testing.T) {
+		e := WithConfig(Config{
+			Reporter: newMockReporter(t),
+		})
+
+		stub := e.StubServer().InOrder()
+		defer stub.Close()
+
+		stub.On("POST", "/token")
+		stub.On("POST", "/charge")
+
+		stubCall(t, "POST", stub.URL()+"/token", "")
+		stubCall(t, "POST", stub.URL()+"/charge", "")
+
+		stub.Verify()
+		stub.chain.assert(t, success)
+	})
+
+	t.Run("out of order", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		e := WithConfig(Config{
+			AssertionHandler: handler,
+		})
+
+		stub := e.StubServer().InOrder()
+		defer stub.Close()
+
+		stub.On("POST", "/token")
+		stub.On("POST", "/charge")
+
+		stubCall(t, "POST", stub.URL()+"/charge", "")
+		stubCall(t, "POST", stub.URL()+"/token", "")
+
+		stub.Verify()
+		stub.chain.assert(t, failure)
+
+		require.NotNil(t, handler.failure)
+		assert.Contains(t, handler.failure.Errors[1].Error(),
+			"POST /token: called after POST /charge")
+	})
+
+	t.Run("unordered", func(t *testing.T) {
+		e := WithConfig(Config{
+			Reporter: newMockReporter(t),
+		})
+
+		stub := e.StubServer()
+		defer stub.Close()
+
+		stub.On("POST", "/token")
+		stub.On("POST", "/charge")
+
+		stubCall(t, "POST", stub.URL()+"/charge", "")
+		stubCall(t, "POST", stub.URL()+"/token", "")
+
+		stub.Verify()
+		stub.chain.assert(t, success)
+	})
+}
+
+func TestStubServer_Usage(t *testing.T) {
+	cases := []struct {
+		name string
+		fn   func(stub *StubServer) *chain
+	}{
+		{
+			name: "invalid path pattern",
+			fn: func(stub *StubServer) *chain {
+				return stub.On("GET", "[").chain
+			},
+		},
+		{
+			name: "negative times",
+			fn: func(stub *StubServer) *chain {
+				return stub.On("GET", "/").Times(-1).chain
+			},
+		},
+		{
+			name: "invalid status",
+			fn: func(stub *StubServer) *chain {
+				return stub.On("GET", "/").Respond(42).chain
+			},
+		},
+		{
+			name: "invalid body status",
+			fn: func(stub *StubServer) *chain {
+				return stub.On("GET", "/").RespondBody(42, "").chain
+			},
+		},
+		{
+			name: "invalid json",
+			fn: func(stub *StubServer) *chain {
+				return stub.On("GET", "/").RespondJSON(200, make(chan int)).chain
+			},
+		},
+		{
+			name: "invalid json matcher",
+			fn: func(stub *StubServer) *chain {
+				return stub.On("GET", "/").WithJSON(make(chan int)).chain
+			},
+		},
+		{
+			name: "request index",
+			fn: func(stub *StubServer) *chain {
+				return stub.On("GET", "/").Request(0).chain
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := WithConfig(Config{
+				Reporter: newMockReporter(t),
+			})
+
+			stub := e.StubServer()
+			defer stub.Close()
+
+			tc.fn(stub).assert(t, failure)
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package httpexpect
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Telemetry receives instrumentation events for requests and assertions,
+// so that test traffic shows up in the same observability stack as the
+// service under test.
+//
+// When Telemetry is set in Config, StartRequest is invoked for every
+// request. Returned span is notified about every attempt (there are
+// several attempts when retries are enabled), about assertion failures
+// related to request or its response, and about request completion.
+//
+// httpexpect doesn't depend on any observability SDK. OpenTelemetry
+// implementation is provided by a separate module:
+//
+//	import "github.com/gavv/httpexpect/v2/otelexpect"
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		BaseURL:  "http://example.com",
+//		Reporter: httpexpect.NewAssertReporter(t),
+//		Telemetry: &otelexpect.Telemetry{
+//			TracerProvider: tracerProvider,
+//			MeterProvider:  meterProvider,
+//		},
+//	})
+//
+// URLs and error messages passed to Telemetry are redacted using
+// Config.Redactor.
+type Telemetry interface {
+	// StartRequest is invoked before the first attempt of request.
+	// ctx is the context of request.
+	StartRequest(ctx context.Context, info TelemetryRequest) TelemetryRequestSpan
+}
+
+// TelemetryRequestSpan receives events about single request.
+// Its methods may be invoked concurrently.
+type TelemetryRequestSpan interface {
+	// StartAttempt is invoked before every attempt of request.
+	//
+	// ctx is the context of http.Request for this attempt; returned context
+	// replaces it. Implementation may add headers to header, e.g. to
+	// propagate trace context to the server.
+	StartAttempt(
+		ctx context.Context, attempt int, header http.Header,
+	) (context.Context, TelemetryAttemptSpan)
+
+	// RecordFailure is invoked for every failed assertion related to request
+	// or its response. It may be invoked after End.
+	RecordFailure(failure TelemetryFailure)
+
+	// End is invoked after the last attempt of request.
+	// Either resp or err is nil.
+	End(resp *http.Response, err error)
+}
+
+// TelemetryAttemptSpan receives events about single attempt of request.
+type TelemetryAttemptSpan interface {
+	// End is invoked when attempt is finished.
+	// Either resp or err is nil.
+	End(resp *http.Response, err error, elapsed time.Duration)
+}
+
+// TelemetryRequest describes request passed to Telemetry.
+type TelemetryRequest struct {
+	// HTTP method, e.g. "GET".
+	Method string
+
+	// Request URL, redacted using Config.Redactor.
+	URL string
+
+	// Test name from Config.TestName.
+	// May be empty.
+	TestName string
+}
+
+// TelemetryFailure describes assertion failure passed to Telemetry.
+type TelemetryFailure struct {
+	// Type of failed assertion.
+	Type AssertionType
+
+	// Severity of failure.
+	Severity AssertionSeverity
+
+	// Path of the failed assertion in the chain, e.g. "Request.Expect".
+	Path string
+
+	// Error messages, redacted using Config.Redactor.
+	Errors []string
+}
+
+// Per-request telemetry state.
+type requestTelemetry struct {
+	mu sync.Mutex
+
+	telemetry Telemetry
+	redactor  *Redactor
+
+	// Set when request is started.
+	span TelemetryRequestSpan
+}
+
+func newRequestTelemetry(telemetry Telemetry, redactor *Redactor) *requestTelemetry {
+	if telemetry == nil {
+		return nil
+	}
+
+	return &requestTelemetry{
+		telemetry: telemetry,
+		redactor:  redactor,
+	}
+}
+
+// Start request span.
+func (rt *requestTelemetry) startRequest(r *Request) {
+	if rt == nil {
+		return
+	}
+
+	info := TelemetryRequest{
+		Method:   r.httpReq.Method,
+		TestName: r.config.TestName,
+	}
+
+	if r.httpReq.URL != nil {
+		info.URL = rt.redactor.redactURL(r.httpReq.URL).String()
+	}
+
+	span := rt.telemetry.StartRequest(r.httpReq.Context(), info)
+
+	rt.mu.Lock()
+	rt.span = span
+	rt.mu.Unlock()
+}
+
+// End request span.
+func (rt *requestTelemetry) endRequest(resp *http.Response, err error) {
+	span := rt.requestSpan()
+	if span == nil {
+		return
+	}
+
+	span.End(resp, rt.redactError(err))
+}
+
+// Start attempt span and attach its context to request.
+func (rt *requestTelemetry) startAttempt(r *Request, attempt int) TelemetryAttemptSpan {
+	span := rt.requestSpan()
+	if span == nil {
+		return nil
+	}
+
+	ctx, attemptSpan := span.StartAttempt(r.httpReq.Context(), attempt, r.httpReq.Header)
+
+	if ctx != nil {
+		r.httpReq = r.httpReq.WithContext(ctx)
+	}
+
+	return attemptSpan
+}
+
+// End attempt span.
+func (rt *requestTelemetry) endAttempt(
+	span TelemetryAttemptSpan, resp *http.Response, err error, elapsed time.Duration,
+) {
+	if rt == nil || span == nil {
+		return
+	}
+
+	span.End(resp, rt.redactError(err), elapsed)
+}
+
+// Report assertion failure to request span.
+func (rt *requestTelemetry) recordFailure(
+	ctx *AssertionContext, failure *AssertionFailure,
+) {
+	span := rt.requestSpan()
+	if span == nil {
+		return
+	}
+
+	info := TelemetryFailure{
+		Type:     failure.Type,
+		Severity: failure.Severity,
+		Path:     strings.Join(ctx.Path, "."),
+	}
+
+	for _, err := range failure.Errors {
+		if !refIsNil(err) {
+			info.Errors = append(info.Errors, rt.redactor.RedactString(err.Error()))
+		}
+	}
+
+	span.RecordFailure(info)
+}
+
+func (rt *requestTelemetry) requestSpan() TelemetryRequestSpan {
+	if rt == nil {
+		return nil
+	}
+
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	return rt.span
+}
+
+// Returns error with redacted message.
+func (rt *requestTelemetry) redactError(err error) error {
+	if err == nil || rt.redactor == nil {
+		return err
+	}
+
+	return errors.New(rt.redactor.RedactString(err.Error()))
+}
@@ -0,0 +1,308 @@
+package httpexpect
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTelemetry struct {
+	mu       sync.Mutex
+	requests []*mockTelemetrySpan
+}
+
+type mockTelemetrySpan struct {
+	mu       sync.Mutex
+	info     TelemetryRequest
+	attempts []*mockTelemetryAttempt
+	failures []TelemetryFailure
+	ended    bool
+	resp     *http.Response
+	err      error
+}
+
+type mockTelemetryAttempt struct {
+	attempt int
+	ended   bool
+	resp    *http.Response
+	err     error
+}
+
+func (mt *mockTelemetry) StartRequest(
+	ctx context.Context, info TelemetryRequest,
+) TelemetryRequestSpan {
+	mt.mu.Lock()
+	defer mt.mu.Unlock()
+
+	span := &mockTelemetrySpan{info: info}
+	mt.requests = append(mt.requests, span)
+
+	return span
+}
+
+func (ms *mockTelemetrySpan) StartAttempt(
+	ctx context.Context, attempt int, header http.Header,
+) (context.Context, TelemetryAttemptSpan) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	header.Set("X-Attempt", "mock")
+
+	span := &mockTelemetryAttempt{attempt: attempt}
+	ms.attempts = append(ms.attempts, span)
+
+	return ctx, span
+}
+
+func (ms *mockTelemetrySpan) RecordFailure(failure TelemetryFailure) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	ms.failures = append(ms.failures, failure)
+}
+
+func (ms *mockTelemetrySpan) End(resp *http.Response, err error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	ms.ended = true
+	ms.resp = resp
+	ms.err = err
+}
+
+func (ma *mockTelemetryAttempt) End(
+	resp *http.Response, err error, elapsed time.Duration,
+) {
+	ma.ended = true
+	ma.resp = resp
+	ma.err = err
+}
+
+func TestTelemetry_Spans(t *testing.T) {
+	var header string
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get("X-Attempt")
+	})
+
+	telemetry := &mockTelemetry{}
+
+	config := Config{
+		BaseURL:  "http://example.com",
+		Reporter: newMockReporter(t),
+		Client: &http.Client{
+			Transport: NewBinder(handler),
+		},
+		TestName:  "test",
+		Telemetry: telemetry,
+	}
+
+	NewRequestC(config, "GET", "/path").
+		Expect().
+		Status(http.StatusOK)
+
+	require.Equal(t, 1, len(telemetry.requests))
+
+	span := telemetry.requests[0]
+
+	assert.Equal(t, TelemetryRequest{
+		Method:   "GET",
+		URL:      "http://example.com/path",
+		TestName: "test",
+	}, span.info)
+
+	assert.True(t, span.ended)
+	require.NotNil(t, span.resp)
+	assert.Equal(t, http.StatusOK, span.resp.StatusCode)
+	assert.NoError(t, span.err)
+
+	require.Equal(t, 1, len(span.attempts))
+	assert.Equal(t, 1, span.attempts[0].attempt)
+	assert.True(t, span.attempts[0].ended)
+
+	assert.Empty(t, span.failures)
+
+	assert.Equal(t, "mock", header)
+}
+
+func TestTelemetry_Retries(t *testing.T) {
+	var count int
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		if count < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	})
+
+	telemetry := &mockTelemetry{}
+
+	config := Config{
+		BaseURL:  "http://example.com",
+		Reporter: newMockReporter(t),
+		Client: &http.Client{
+			Transport: NewBinder(handler),
+		},
+		Telemetry: telemetry,
+	}
+
+	NewRequestC(config, "GET", "/path").
+		WithMaxRetries(2).
+		WithRetryDelay(0, 0).
+		Expect().
+		Status(http.StatusOK)
+
+	require.Equal(t, 1, len(telemetry.requests))
+
+	span := telemetry.requests[0]
+
+	require.Equal(t, 3, len(span.attempts))
+
+	for n, attempt := range span.attempts {
+		assert.Equal(t, n+1, attempt.attempt)
+		assert.True(t, attempt.ended)
+		require.NotNil(t, attempt.resp)
+	}
+
+	assert.Equal(t, http.StatusServiceUnavailable, span.attempts[0].resp.StatusCode)
+	assert.Equal(t, http.StatusServiceUnavailable, span.attempts[1].resp.StatusCode)
+	assert.Equal(t, http.StatusOK, span.attempts[2].resp.StatusCode)
+
+	assert.True(t, span.ended)
+	require.NotNil(t, span.resp)
+	assert.Equal(t, http.StatusOK, span.resp.StatusCode)
+}
+
+func TestTelemetry_Failures(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	telemetry := &mockTelemetry{}
+
+	config := Config{
+		BaseURL:  "http://example.com",
+		Reporter: newMockReporter(t),
+		Client: &http.Client{
+			Transport: NewBinder(handler),
+		},
+		Telemetry: telemetry,
+	}
+
+	NewRequestC(config, "GET", "/path").
+		Expect().
+		Status(http.StatusOK)
+
+	require.Equal(t, 1, len(telemetry.requests))
+
+	span := telemetry.requests[0]
+
+	assert.True(t, span.ended)
+
+	require.Equal(t, 1, len(span.failures))
+	assert.Equal(t, AssertEqual, span.failures[0].Type)
+	assert.Equal(t, SeverityError, span.failures[0].Severity)
+	assert.Contains(t, span.failures[0].Path, "Status")
+}
+
+func TestTelemetry_Redaction(t *testing.T) {
+	t.Run("url and failure", func(t *testing.T) {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Token", "secret")
+		})
+
+		telemetry := &mockTelemetry{}
+
+		config := Config{
+			BaseURL:  "http://example.com",
+			Reporter: newMockReporter(t),
+			Client: &http.Client{
+				Transport: NewBinder(handler),
+			},
+			Redactor: &Redactor{
+				Patterns: []*regexp.Regexp{
+					regexp.MustCompile(`secret`),
+				},
+			},
+			Telemetry: telemetry,
+		}
+
+		NewRequestC(config, "GET", "/path").
+			WithQuery("token", "secret").
+			Expect().
+			Header("X-Token").
+			IsEqual("other")
+
+		require.Equal(t, 1, len(telemetry.requests))
+
+		span := telemetry.requests[0]
+
+		assert.Equal(t, "http://example.com/path?token=<redacted>", span.info.URL)
+
+		require.Equal(t, 1, len(span.failures))
+		require.NotEmpty(t, span.failures[0].Errors)
+
+		for _, msg := range span.failures[0].Errors {
+			assert.NotContains(t, msg, "secret")
+		}
+	})
+
+	t.Run("transport error", func(t *testing.T) {
+		telemetry := &mockTelemetry{}
+
+		config := Config{
+			BaseURL:  "http://example.com",
+			Reporter: newMockReporter(t),
+			Client: &mockClient{
+				err: errors.New("dial secret failed"),
+			},
+			Redactor: &Redactor{
+				Patterns: []*regexp.Regexp{
+					regexp.MustCompile(`secret`),
+				},
+			},
+			Telemetry: telemetry,
+		}
+
+		NewRequestC(config, "GET", "/path").
+			Expect()
+
+		require.Equal(t, 1, len(telemetry.requests))
+
+		span := telemetry.requests[0]
+
+		require.Equal(t, 1, len(span.attempts))
+		require.Error(t, span.attempts[0].err)
+		assert.Equal(t, "dial <redacted> failed", span.attempts[0].err.Error())
+
+		require.Error(t, span.err)
+		assert.Equal(t, "dial <redacted> failed", span.err.Error())
+	})
+}
+
+func TestTelemetry_Disabled(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("X-Attempt"))
+	})
+
+	config := Config{
+		BaseURL:  "http://example.com",
+		Reporter: newMockReporter(t),
+		Client: &http.Client{
+			Transport: NewBinder(handler),
+		},
+	}
+
+	req := NewRequestC(config, "GET", "/path")
+	assert.Nil(t, req.telemetry)
+
+	req.Expect().
+		Status(http.StatusOK)
+}
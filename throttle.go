@@ -0,0 +1,71 @@
+package httpexpect
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// throttleReader limits the rate at which the underlying reader is read.
+//
+// Used by Request.WithBodyThrottle to simulate slow client, which sends
+// request body in small chunks with pauses between them.
+type throttleReader struct {
+	ctx    context.Context
+	reader io.ReadCloser
+	rate   int64
+	chunk  int64
+	start  time.Time
+	sent   int64
+}
+
+func newThrottleReader(
+	ctx context.Context, reader io.ReadCloser, bytesPerSecond int64,
+) *throttleReader {
+	// send ten chunks per second, so that data is spread evenly
+	chunk := bytesPerSecond / 10
+	if chunk < 1 {
+		chunk = 1
+	}
+
+	return &throttleReader{
+		ctx:    ctx,
+		reader: reader,
+		rate:   bytesPerSecond,
+		chunk:  chunk,
+	}
+}
+
+func (r *throttleReader) Read(p []byte) (int, error) {
+	if r.start.IsZero() {
+		r.start = time.Now()
+	}
+
+	if int64(len(p)) > r.chunk {
+		p = p[:r.chunk]
+	}
+
+	n, err := r.reader.Read(p)
+	r.sent += int64(n)
+
+	if n > 0 {
+		due := r.start.Add(time.Duration(r.sent) * time.Second / time.Duration(r.rate))
+
+		if wait := time.Until(due); wait > 0 {
+			timer := time.NewTimer(wait)
+			defer timer.Stop()
+
+			select {
+			case <-r.ctx.Done():
+				return n, r.ctx.Err()
+			case <-timer.C:
+			}
+		}
+	}
+
+	return n, err
+}
+
+func (r *throttleReader) Close() error {
+	return r.reader.Close()
+}
@@ -0,0 +1,105 @@
+package httpexpect
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThrottle_Reader(t *testing.T) {
+	t.Run("rate", func(t *testing.T) {
+		r := newThrottleReader(context.Background(),
+			io.NopCloser(strings.NewReader(strings.Repeat("x", 200))), 1000)
+
+		buf := make([]byte, 1000)
+
+		start := time.Now()
+
+		n, err := r.Read(buf)
+		assert.NoError(t, err)
+		assert.Equal(t, 100, n)
+
+		b, err := io.ReadAll(r)
+		assert.NoError(t, err)
+		assert.Equal(t, 100, len(b))
+
+		assert.True(t, time.Since(start) >= 150*time.Millisecond)
+	})
+
+	t.Run("small rate", func(t *testing.T) {
+		r := newThrottleReader(context.Background(),
+			io.NopCloser(strings.NewReader("xx")), 5)
+
+		assert.Equal(t, int64(1), r.chunk)
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		r := newThrottleReader(ctx,
+			io.NopCloser(strings.NewReader(strings.Repeat("x", 200))), 1)
+
+		_, err := io.ReadAll(r)
+		assert.Equal(t, context.Canceled, err)
+	})
+}
+
+func TestThrottle_Request(t *testing.T) {
+	echoHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write(b)
+	})
+
+	t.Run("throttled", func(t *testing.T) {
+		server := httptest.NewServer(echoHandler)
+		defer server.Close()
+
+		body := strings.Repeat("x", 50)
+
+		req := NewRequestC(Config{
+			BaseURL:  server.URL,
+			Reporter: newMockReporter(t),
+		}, "POST", "/")
+
+		start := time.Now()
+
+		req.WithText(body).
+			WithBodyThrottle(500).
+			Expect().
+			Status(http.StatusOK).
+			Body().IsEqual(body)
+
+		req.chain.assert(t, success)
+
+		assert.True(t, time.Since(start) >= 80*time.Millisecond)
+	})
+
+	t.Run("aborted", func(t *testing.T) {
+		server := httptest.NewServer(echoHandler)
+		defer server.Close()
+
+		req := NewRequestC(Config{
+			BaseURL:  server.URL,
+			Reporter: newMockReporter(t),
+		}, "POST", "/")
+
+		req.WithText(strings.Repeat("x", 100)).
+			WithBodyThrottle(10).
+			WithClientAbort(50 * time.Millisecond).
+			ExpectError().
+			Contains("request aborted by client")
+
+		req.chain.assert(t, success)
+	})
+}
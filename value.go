@@ -1,16 +1,21 @@
 package httpexpect
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"reflect"
+	"strings"
+	"time"
 )
 
 // Value provides methods to inspect attached interface{} object
 // (Go representation of arbitrary JSON value) and cast it to
 // concrete type.
 type Value struct {
-	chain *chain
-	value interface{}
+	chain  *chain
+	value  interface{}
+	absent bool
 }
 
 // NewValue returns a new Value instance.
@@ -52,7 +57,7 @@ func NewValueC(config Config, value interface{}) *Value {
 }
 
 func newValue(parent *chain, val interface{}) *Value {
-	v := &Value{parent.clone(), nil}
+	v := &Value{chain: parent.clone()}
 
 	opChain := v.chain.enter("")
 	defer opChain.leave()
@@ -64,6 +69,12 @@ func newValue(parent *chain, val interface{}) *Value {
 	return v
 }
 
+func newAbsentValue(parent *chain) *Value {
+	v := newValue(parent, nil)
+	v.absent = true
+	return v
+}
+
 // Raw returns underlying value attached to Value.
 // This is the value originally passed to NewValue, converted to canonical form.
 //
@@ -110,6 +121,100 @@ func (v *Value) Decode(target interface{}) *Value {
 	return v
 }
 
+// ValueOf returns underlying value converted to Go type T.
+//
+// Unlike type assertion on Raw(), ValueOf reports failure through the chain
+// with a message describing both expected Go type and actual JSON type.
+// On failure, zero value of T is returned.
+//
+// T may be any type into which the value can be decoded using
+// encoding/json, e.g. string, bool, float64, int, map[string]interface{},
+// []interface{}, slice or struct. Numbers are converted to integer types only
+// if they are integral and fit into the type. Null can be converted only to
+// pointer, interface, map, or slice types.
+//
+// Example:
+//
+//	value := NewValue(t, map[string]interface{}{"id": 123, "name": "john"})
+//
+//	id := ValueOf[int](value.Path("$.id"))
+//	name := ValueOf[string](value.Path("$.name"))
+func ValueOf[T any](v *Value) T {
+	var target T
+
+	targetType := reflect.TypeOf(&target).Elem()
+
+	opChain := v.chain.enter("ValueOf[%s]()", targetType)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return target
+	}
+
+	result, ok := convertValue[T](v.value)
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertType,
+			Actual: &AssertionValue{v.value},
+			Errors: []error{
+				fmt.Errorf("expected: value can be converted to %s", targetType),
+				fmt.Errorf("actual value is JSON %s", jsonTypeName(v.value)),
+			},
+		})
+		return target
+	}
+
+	return result
+}
+
+// convertValue converts canonical value to Go type T, see ValueOf.
+func convertValue[T any](value interface{}) (T, bool) {
+	var target T
+
+	if value == nil {
+		switch reflect.TypeOf(&target).Elem().Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+			return target, true
+		}
+		return target, false
+	}
+
+	if converted, ok := value.(T); ok {
+		return converted, true
+	}
+
+	b, err := json.Marshal(value)
+	if err == nil {
+		err = json.Unmarshal(b, &target)
+	}
+
+	if err != nil {
+		var zero T
+		return zero, false
+	}
+
+	return target, true
+}
+
+// jsonTypeName returns JSON type of value in canonical form.
+func jsonTypeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("value of type %T", value)
+}
+
 // Alias returns a new Value object with alias.
 // When a test of Value object with alias is failed,
 // an assertion is displayed as a chain starting from the alias.
@@ -184,6 +289,9 @@ func (v *Value) Path(path string) *Value {
 //   - type convertible to string containing valid http:// or file:// URI,
 //     pointing to reachable and valid schema
 //
+// On mismatch, every validation error is reported as a separate
+// *SchemaError entry in failure Errors (see AssertionFailure.SchemaErrors).
+//
 // Example 1:
 //
 //	 schema := `{
@@ -218,6 +326,182 @@ func (v *Value) Schema(schema interface{}) *Value {
 	return v
 }
 
+// Assert invokes custom matcher registered using RegisterMatcher.
+//
+// Matcher receives underlying value and given arguments. If matcher reports
+// a failure, it is handled like a failure of any other assertion. If there is
+// no matcher with given name, failure is reported.
+//
+// Example:
+//
+//	httpexpect.RegisterMatcher("HasPrefix",
+//		func(opChain *httpexpect.Chain, actual interface{}, args ...interface{}) {
+//			// matcher code here
+//		})
+//
+//	value := NewValue(t, "SKU-123")
+//	value.Assert("HasPrefix", "SKU-")
+func (v *Value) Assert(name string, args ...interface{}) *Value {
+	opChain := v.chain.enter("Assert(%q)", name)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return v
+	}
+
+	matcher, ok := lookupMatcher(opChain, name)
+	if !ok {
+		return v
+	}
+
+	matcher(&Chain{opChain}, v.value, args...)
+
+	return v
+}
+
+// Eventually polls value until given assertion passes.
+//
+// First, assertion is applied to the value itself. If it fails, rebuilder
+// is invoked every given interval to re-fetch the value, typically by
+// sending the request that produced the original response again, and
+// assertion is applied to the new value. This is repeated until assertion
+// passes or time is out.
+//
+// rebuilder is invoked with an Expect instance derived from e, which should
+// be used to send requests. Failures of attempts (both in rebuilder and in
+// assertion) are not reported as errors, but only logged. If assertion
+// doesn't pass in time, a single failure is reported, with the value from
+// the last attempt. If rebuilder returns nil, attempt is considered failed.
+//
+// Returns value on which assertion has passed, or the value from the last
+// attempt. Timeout is measured in wall time, even if Config.Clock of e is
+// fake; pauses between attempts are made using Config.Clock.
+//
+// Example:
+//
+//	status := e.POST("/jobs").
+//		Expect().
+//		JSON().Path("$.status")
+//
+//	status.Eventually(e, func(e *httpexpect.Expect) *httpexpect.Value {
+//		return e.GET("/jobs/{id}", id).Expect().JSON().Path("$.status")
+//	}, 10*time.Second, 100*time.Millisecond, func(v *httpexpect.Value) {
+//		v.IsEqual("done")
+//	})
+func (v *Value) Eventually(
+	e *Expect,
+	rebuilder func(e *Expect) *Value,
+	within, every time.Duration,
+	assertion func(v *Value),
+) *Value {
+	opChain := v.chain.enter("Eventually()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return v
+	}
+
+	if e == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil Expect argument"),
+			},
+		})
+		return v
+	}
+
+	if rebuilder == nil || assertion == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil function argument"),
+			},
+		})
+		return v
+	}
+
+	if within < 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected negative timeout"),
+			},
+		})
+		return v
+	}
+
+	if every <= 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected non-positive interval"),
+			},
+		})
+		return v
+	}
+
+	clock := clockOrDefault(e.config.Clock)
+	deadline := time.Now().Add(within)
+
+	var (
+		lastValue interface{}
+		attempts  int
+	)
+
+	for {
+		if attempts != 0 {
+			<-clock.After(every)
+		}
+
+		var attemptChain *chain
+		if attempts == 0 {
+			attemptChain = opChain.clone()
+		} else {
+			attemptChain = e.chain.clone()
+		}
+
+		// Failures of attempts are only logged and should not propagate
+		// neither to the chain of value, nor to the chain of Expect.
+		attemptChain.setRoot()
+		attemptChain.setSeverity(SeverityLog)
+
+		var value *Value
+		if attempts == 0 {
+			value = newValue(attemptChain, v.value)
+		} else {
+			value = rebuilder(e.withChain(attemptChain))
+		}
+
+		attempts++
+
+		if value != nil {
+			assertion(value)
+
+			if !attemptChain.treeFailed() {
+				return newValue(opChain, value.value)
+			}
+
+			lastValue = value.value
+		}
+
+		if time.Now().Add(every).After(deadline) {
+			break
+		}
+	}
+
+	opChain.fail(AssertionFailure{
+		Type:   AssertValid,
+		Actual: &AssertionValue{lastValue},
+		Errors: []error{
+			fmt.Errorf("expected: value satisfies assertion within %s", within),
+			fmt.Errorf("assertion failed on all %d attempts", attempts),
+		},
+	})
+
+	return newValue(opChain, lastValue)
+}
+
 // Object returns a new Object attached to underlying value.
 //
 // If underlying value is not an object (map[string]interface{}), failure is reported
@@ -383,12 +667,88 @@ func (v *Value) Boolean() *Boolean {
 	return newBoolean(opChain, data)
 }
 
+// AsBoolean converts underlying value to bool and returns a new Boolean
+// instance with result.
+//
+// If lenient is false, AsBoolean behaves like Boolean and accepts only
+// JSON booleans. If lenient is true, it also accepts values that APIs
+// commonly use instead of booleans:
+//
+//   - strings "true" and "1", or "false" and "0" (case-insensitive)
+//   - numbers 1 or 0
+//
+// If underlying value can't be converted, failure is reported and empty
+// (but non-nil) value is returned.
+//
+// Example:
+//
+//	value := NewValue(t, "1")
+//	value.AsBoolean(true).IsTrue()
+//
+//	value := NewValue(t, 0)
+//	value.AsBoolean(true).IsFalse()
+func (v *Value) AsBoolean(lenient bool) *Boolean {
+	opChain := v.chain.enter("AsBoolean()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newBoolean(opChain, false)
+	}
+
+	switch data := v.value.(type) {
+	case bool:
+		return newBoolean(opChain, data)
+
+	case string:
+		if lenient {
+			switch strings.ToLower(data) {
+			case "true", "1":
+				return newBoolean(opChain, true)
+			case "false", "0":
+				return newBoolean(opChain, false)
+			}
+		}
+
+	case float64:
+		if lenient {
+			switch data {
+			case 1:
+				return newBoolean(opChain, true)
+			case 0:
+				return newBoolean(opChain, false)
+			}
+		}
+	}
+
+	if lenient {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{v.value},
+			Errors: []error{
+				errors.New("expected: value can be converted to boolean"),
+			},
+		})
+	} else {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{v.value},
+			Errors: []error{
+				errors.New("expected: value is boolean"),
+			},
+		})
+	}
+
+	return newBoolean(opChain, false)
+}
+
 // IsNull succeeds if value is nil.
 //
 // Note that non-nil interface{} that points to nil value (e.g. nil slice or map)
 // is also treated as null value. Empty (non-nil) slice or map, empty string, and
 // zero number are not treated as null value.
 //
+// Absent value (see IsAbsent) is not treated as null value either.
+//
 // Example:
 //
 //	value := NewValue(t, nil)
@@ -404,6 +764,18 @@ func (v *Value) IsNull() *Value {
 		return v
 	}
 
+	if v.absent {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNil,
+			Actual: &AssertionValue{v.value},
+			Errors: []error{
+				errors.New("expected: value is null"),
+				errors.New("value is absent"),
+			},
+		})
+		return v
+	}
+
 	if !(v.value == nil) {
 		opChain.fail(AssertionFailure{
 			Type:   AssertNil,
@@ -423,6 +795,9 @@ func (v *Value) IsNull() *Value {
 // is also treated as null value. Empty (non-nil) slice or map, empty string, and
 // zero number are not treated as null value.
 //
+// Absent value (see IsAbsent) is not treated as non-null value, so NotNull
+// succeeds only if value is present and is not null.
+//
 // Example:
 //
 //	value := NewValue(t, "")
@@ -439,11 +814,77 @@ func (v *Value) NotNull() *Value {
 	}
 
 	if v.value == nil {
+		errs := []error{
+			errors.New("expected: value is non-null"),
+		}
+		if v.absent {
+			errs = append(errs, errors.New("value is absent"))
+		}
 		opChain.fail(AssertionFailure{
 			Type:   AssertNotNil,
 			Actual: &AssertionValue{v.value},
+			Errors: errs,
+		})
+	}
+
+	return v
+}
+
+// IsAbsent succeeds if value is absent, i.e. it was obtained from an object
+// field that is missing (see Object.Field).
+//
+// Together with IsNull and NotNull, it allows to distinguish three cases:
+// field is missing (IsAbsent), field is present and is null (IsNull), and
+// field is present and has a value (NotNull), possibly zero or empty.
+//
+// Example:
+//
+//	object := NewObject(t, map[string]interface{}{"foo": nil})
+//	object.Field("foo").IsNull()
+//	object.Field("bar").IsAbsent()
+func (v *Value) IsAbsent() *Value {
+	opChain := v.chain.enter("IsAbsent()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return v
+	}
+
+	if !v.absent {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{v.value},
+			Errors: []error{
+				errors.New("expected: value is absent"),
+			},
+		})
+	}
+
+	return v
+}
+
+// NotAbsent succeeds if value is present, even if it is null.
+//
+// See IsAbsent for details.
+//
+// Example:
+//
+//	object := NewObject(t, map[string]interface{}{"foo": nil})
+//	object.Field("foo").NotAbsent()
+func (v *Value) NotAbsent() *Value {
+	opChain := v.chain.enter("NotAbsent()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return v
+	}
+
+	if v.absent {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotValid,
+			Actual: &AssertionValue{v.value},
 			Errors: []error{
-				errors.New("expected: value is non-null"),
+				errors.New("expected: value is present"),
 			},
 		})
 	}
@@ -751,11 +1192,13 @@ func (v *Value) NotBoolean() *Value {
 // IsEqual succeeds if value is equal to another value (e.g. map, slice, string, etc).
 // Before comparison, both values are converted to canonical form.
 //
+// Optional CompareOptions can be given to customize comparison.
+//
 // Example:
 //
 //	value := NewValue(t, "foo")
 //	value.IsEqual("foo")
-func (v *Value) IsEqual(value interface{}) *Value {
+func (v *Value) IsEqual(value interface{}, opts ...CompareOptions) *Value {
 	opChain := v.chain.enter("IsEqual()")
 	defer opChain.leave()
 
@@ -768,7 +1211,12 @@ func (v *Value) IsEqual(value interface{}) *Value {
 		return v
 	}
 
-	if !reflect.DeepEqual(expected, v.value) {
+	compareOpts, ok := getCompareOptions(opChain, opts)
+	if !ok {
+		return v
+	}
+
+	if !compareValues(compareOpts, v.value, expected) {
 		opChain.fail(AssertionFailure{
 			Type:     AssertEqual,
 			Actual:   &AssertionValue{v.value},
@@ -785,11 +1233,13 @@ func (v *Value) IsEqual(value interface{}) *Value {
 // NotEqual succeeds if value is not equal to another value (e.g. map, slice,
 // string, etc). Before comparison, both values are converted to canonical form.
 //
+// Optional CompareOptions can be given to customize comparison.
+//
 // Example:
 //
 //	value := NewValue(t, "foo")
 //	value.NorEqual("bar")
-func (v *Value) NotEqual(value interface{}) *Value {
+func (v *Value) NotEqual(value interface{}, opts ...CompareOptions) *Value {
 	opChain := v.chain.enter("NotEqual()")
 	defer opChain.leave()
 
@@ -802,7 +1252,12 @@ func (v *Value) NotEqual(value interface{}) *Value {
 		return v
 	}
 
-	if reflect.DeepEqual(expected, v.value) {
+	compareOpts, ok := getCompareOptions(opChain, opts)
+	if !ok {
+		return v
+	}
+
+	if compareValues(compareOpts, v.value, expected) {
 		opChain.fail(AssertionFailure{
 			Type:     AssertNotEqual,
 			Actual:   &AssertionValue{v.value},
@@ -0,0 +1,158 @@
+package httpexpect
+
+import (
+	"errors"
+
+	"github.com/yalp/jsonpath"
+)
+
+// ValueProvider provides a value for request parameter that is evaluated
+// when request is sent, i.e. during Request.Expect.
+//
+// Provide receives chain of Request.Expect. If value can't be provided,
+// it should report failure using opChain; in this case request is not sent.
+//
+// Builtin providers are FromResponse and FromEnv. ValueProviderFunc can be
+// used to adapt a function.
+//
+// See Request.WithPathFrom, Request.WithQueryFrom, Request.WithHeaderFrom.
+type ValueProvider interface {
+	Provide(opChain *Chain) interface{}
+}
+
+// ValueProviderFunc is an adapter that allows a function to be used
+// as the ValueProvider.
+//
+// If function returns error, failure is reported.
+//
+// Example:
+//
+//	req.WithHeaderFrom("Authorization",
+//		ValueProviderFunc(func() (interface{}, error) {
+//			token, err := auth.Token()
+//			return "Bearer " + token, err
+//		}))
+type ValueProviderFunc func() (interface{}, error)
+
+// Provide implements ValueProvider.Provide.
+func (f ValueProviderFunc) Provide(opChain *Chain) interface{} {
+	value, err := f()
+	if err != nil {
+		opChain.Fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("failed to provide value"),
+				err,
+			},
+		})
+		return nil
+	}
+
+	return value
+}
+
+// FromResponse returns ValueProvider that extracts value from JSON body
+// of given response using JSONPath expression (see Value.Path).
+//
+// Response body is decoded when the value is requested, so the response
+// should have "application/json" Content-Type, like for Response.JSON.
+//
+// Example:
+//
+//	user := e.POST("/users").WithJSON(newUser).Expect()
+//
+//	e.GET("/users/{id}").
+//		WithPathFrom("id", FromResponse(user, "$.id")).
+//		Expect().
+//		Status(http.StatusOK)
+func FromResponse(resp *Response, path string) ValueProvider {
+	return responseProvider{resp: resp, path: path}
+}
+
+type responseProvider struct {
+	resp *Response
+	path string
+}
+
+func (p responseProvider) Provide(opChain *Chain) interface{} {
+	if p.resp == nil {
+		opChain.Fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil response"),
+			},
+		})
+		return nil
+	}
+
+	value := p.resp.getJSON(opChain.chain, "JSON()")
+	if opChain.Failed() {
+		return nil
+	}
+
+	filterFn, err := jsonpath.Prepare(p.path)
+	if err != nil {
+		opChain.Fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{p.path},
+			Errors: []error{
+				errors.New("expected: valid json path"),
+				err,
+			},
+		})
+		return nil
+	}
+
+	result, err := filterFn(value)
+	if err != nil {
+		opChain.Fail(AssertionFailure{
+			Type:     AssertMatchPath,
+			Actual:   &AssertionValue{value},
+			Expected: &AssertionValue{p.path},
+			Errors: []error{
+				errors.New("expected: response body matches given json path"),
+				err,
+			},
+		})
+		return nil
+	}
+
+	return result
+}
+
+// FromEnv returns ValueProvider that reads value from environment.
+//
+// The value is read when request is sent, so it may be stored into
+// environment after the request is built.
+//
+// Example:
+//
+//	e.GET("/profile").
+//		WithHeaderFrom("Authorization", FromEnv(e.Env(), "token"))
+func FromEnv(env *Environment, key string) ValueProvider {
+	return envProvider{env: env, key: key}
+}
+
+type envProvider struct {
+	env *Environment
+	key string
+}
+
+func (p envProvider) Provide(opChain *Chain) interface{} {
+	if p.env == nil {
+		opChain.Fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil environment"),
+			},
+		})
+		return nil
+	}
+
+	p.env.store.mu.RLock()
+	defer p.env.store.mu.RUnlock()
+
+	value, _ := p.env.getValue(opChain.chain, p.key)
+
+	return value
+}
@@ -0,0 +1,151 @@
+package httpexpect
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newProviderResponse(t *testing.T, body string) *Response {
+	return NewResponse(newMockReporter(t), &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"Content-Type": {"application/json"},
+		},
+		Body: io.NopCloser(bytes.NewBufferString(body)),
+	})
+}
+
+func TestValueProvider_Request(t *testing.T) {
+	client := &mockClient{}
+
+	config := Config{
+		Client:   client,
+		Reporter: newMockReporter(t),
+	}
+
+	resp := newProviderResponse(t, `{"user": {"id": 123, "name": "john"}}`)
+
+	env := NewEnvironment(newMockReporter(t))
+
+	req := NewRequestC(config, "GET", "/users/{id}").
+		WithPathFrom("id", FromResponse(resp, "$.user.id")).
+		WithQueryFrom("name", FromResponse(resp, "$.user.name")).
+		WithHeaderFrom("X-Token", FromEnv(env, "token")).
+		WithHeaderFrom("X-Func", ValueProviderFunc(func() (interface{}, error) {
+			return true, nil
+		}))
+
+	// stored after request is built, but before it is sent
+	env.Put("token", "secret")
+
+	req.Expect().chain.assert(t, success)
+
+	assert.Equal(t, "/users/123", client.req.URL.Path)
+	assert.Equal(t, "name=john", client.req.URL.RawQuery)
+	assert.Equal(t, "secret", client.req.Header.Get("X-Token"))
+	assert.Equal(t, "true", client.req.Header.Get("X-Func"))
+}
+
+func TestValueProvider_Failures(t *testing.T) {
+	resp := newProviderResponse(t, `{"id": 123}`)
+
+	env := NewEnvironment(newMockReporter(t))
+
+	cases := []struct {
+		name     string
+		provider ValueProvider
+	}{
+		{
+			name:     "nil response",
+			provider: FromResponse(nil, "$.id"),
+		},
+		{
+			name:     "invalid json path",
+			provider: FromResponse(resp, "$["),
+		},
+		{
+			name:     "json path does not match",
+			provider: FromResponse(resp, "$.missing"),
+		},
+		{
+			name:     "not json",
+			provider: FromResponse(NewResponse(newMockReporter(t), &http.Response{}), "$"),
+		},
+		{
+			name:     "nil environment",
+			provider: FromEnv(nil, "key"),
+		},
+		{
+			name:     "missing key",
+			provider: FromEnv(env, "missing"),
+		},
+		{
+			name: "func error",
+			provider: ValueProviderFunc(func() (interface{}, error) {
+				return nil, errors.New("error")
+			}),
+		},
+		{
+			name: "nil value",
+			provider: ValueProviderFunc(func() (interface{}, error) {
+				return nil, nil
+			}),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &mockClient{}
+
+			config := Config{
+				Client:   client,
+				Reporter: newMockReporter(t),
+			}
+
+			req := NewRequestC(config, "GET", "/path").
+				WithHeaderFrom("X-Value", tc.provider)
+			req.chain.assert(t, success)
+
+			req.Expect().chain.assert(t, failure)
+
+			// request is not sent
+			assert.Nil(t, client.req)
+		})
+	}
+
+	t.Run("nil provider", func(t *testing.T) {
+		config := Config{
+			Client:   &mockClient{},
+			Reporter: newMockReporter(t),
+		}
+
+		NewRequestC(config, "GET", "/path").
+			WithPathFrom("id", nil).
+			chain.assert(t, failure)
+
+		NewRequestC(config, "GET", "/path").
+			WithQueryFrom("id", nil).
+			chain.assert(t, failure)
+
+		NewRequestC(config, "GET", "/path").
+			WithHeaderFrom("id", nil).
+			chain.assert(t, failure)
+	})
+
+	t.Run("path key not found", func(t *testing.T) {
+		config := Config{
+			Client:   &mockClient{},
+			Reporter: newMockReporter(t),
+		}
+
+		NewRequestC(config, "GET", "/path").
+			WithPathFrom("id", FromResponse(resp, "$.id")).
+			Expect().
+			chain.assert(t, failure)
+	})
+}
@@ -2,9 +2,11 @@ package httpexpect
 
 import (
 	"encoding/json"
+	"net/http"
 	"os"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -23,14 +25,19 @@ func TestValue_FailedChain(t *testing.T) {
 	var target interface{}
 	value.Decode(target)
 
+	assert.Equal(t, "", ValueOf[string](value))
+
 	value.Object().chain.assert(t, failure)
 	value.Array().chain.assert(t, failure)
 	value.String().chain.assert(t, failure)
 	value.Number().chain.assert(t, failure)
 	value.Boolean().chain.assert(t, failure)
+	value.AsBoolean(true).chain.assert(t, failure)
 
 	value.IsNull()
 	value.NotNull()
+	value.IsAbsent()
+	value.NotAbsent()
 	value.IsObject()
 	value.NotObject()
 	value.IsArray()
@@ -45,6 +52,8 @@ func TestValue_FailedChain(t *testing.T) {
 	value.NotEqual(nil)
 	value.InList(nil)
 	value.NotInList(nil)
+	value.Assert("foo")
+	value.Eventually(nil, nil, 0, 0, nil).chain.assert(t, failure)
 }
 
 func TestValue_Constructors(t *testing.T) {
@@ -138,6 +147,166 @@ func TestValue_Decode(t *testing.T) {
 	})
 }
 
+func TestValue_ValueOf(t *testing.T) {
+	type S struct {
+		A int `json:"a"`
+	}
+
+	t.Run("success", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		assert.Equal(t, "foo", ValueOf[string](NewValue(reporter, "foo")))
+		assert.Equal(t, true, ValueOf[bool](NewValue(reporter, true)))
+		assert.Equal(t, 1.5, ValueOf[float64](NewValue(reporter, 1.5)))
+		assert.Equal(t, 123, ValueOf[int](NewValue(reporter, 123)))
+		assert.Equal(t, int64(-5), ValueOf[int64](NewValue(reporter, -5)))
+		assert.Equal(t, uint8(255), ValueOf[uint8](NewValue(reporter, 255)))
+
+		assert.Equal(t,
+			map[string]interface{}{"a": 1.0},
+			ValueOf[map[string]interface{}](
+				NewValue(reporter, map[string]interface{}{"a": 1})))
+
+		assert.Equal(t,
+			[]interface{}{"a", 1.0},
+			ValueOf[[]interface{}](NewValue(reporter, []interface{}{"a", 1})))
+
+		assert.Equal(t,
+			[]string{"a", "b"},
+			ValueOf[[]string](NewValue(reporter, []interface{}{"a", "b"})))
+
+		assert.Equal(t,
+			S{A: 1},
+			ValueOf[S](NewValue(reporter, map[string]interface{}{"a": 1})))
+
+		assert.Equal(t, "foo", ValueOf[interface{}](NewValue(reporter, "foo")))
+
+		assert.Nil(t, ValueOf[*string](NewValue(reporter, nil)))
+		assert.Nil(t, ValueOf[interface{}](NewValue(reporter, nil)))
+		assert.Nil(t, ValueOf[map[string]interface{}](NewValue(reporter, nil)))
+		assert.Nil(t, ValueOf[[]interface{}](NewValue(reporter, nil)))
+
+		assert.False(t, reporter.reported)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		cases := []struct {
+			name   string
+			value  interface{}
+			result func(value *Value) interface{}
+			zero   interface{}
+		}{
+			{
+				name:  "number to string",
+				value: 123,
+				result: func(value *Value) interface{} {
+					return ValueOf[string](value)
+				},
+				zero: "",
+			},
+			{
+				name:  "string to int",
+				value: "123",
+				result: func(value *Value) interface{} {
+					return ValueOf[int](value)
+				},
+				zero: 0,
+			},
+			{
+				name:  "fractional to int",
+				value: 1.5,
+				result: func(value *Value) interface{} {
+					return ValueOf[int](value)
+				},
+				zero: 0,
+			},
+			{
+				name:  "out of range",
+				value: 256,
+				result: func(value *Value) interface{} {
+					return ValueOf[uint8](value)
+				},
+				zero: uint8(0),
+			},
+			{
+				name:  "null to bool",
+				value: nil,
+				result: func(value *Value) interface{} {
+					return ValueOf[bool](value)
+				},
+				zero: false,
+			},
+			{
+				name:  "array to object",
+				value: []interface{}{1},
+				result: func(value *Value) interface{} {
+					return ValueOf[map[string]interface{}](value)
+				},
+				zero: map[string]interface{}(nil),
+			},
+			{
+				name:  "partial struct",
+				value: map[string]interface{}{"a": "x"},
+				result: func(value *Value) interface{} {
+					return ValueOf[S](value)
+				},
+				zero: S{},
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				reporter := newMockReporter(t)
+				value := NewValue(reporter, tc.value)
+
+				assert.Equal(t, tc.zero, tc.result(value))
+				value.chain.assert(t, failure)
+			})
+		}
+	})
+
+	t.Run("message", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		value := NewValueC(Config{AssertionHandler: handler}, "foo")
+		ValueOf[int](value)
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertType, handler.failure.Type)
+		assert.Equal(t, []string{"Value()", "ValueOf[int]()"}, handler.ctx.Path)
+		assert.Equal(t, "expected: value can be converted to int",
+			handler.failure.Errors[0].Error())
+		assert.Equal(t, "actual value is JSON string",
+			handler.failure.Errors[1].Error())
+	})
+}
+
+func TestValue_IsAbsent(t *testing.T) {
+	t.Run("present", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		NewValue(reporter, nil).IsAbsent().chain.assert(t, failure)
+		NewValue(reporter, nil).NotAbsent().chain.assert(t, success)
+		NewValue(reporter, nil).IsNull().chain.assert(t, success)
+	})
+
+	t.Run("absent", func(t *testing.T) {
+		value := newAbsentValue(newMockChain(t))
+
+		value.IsAbsent().chain.assert(t, success)
+		value.NotAbsent().chain.assert(t, failure)
+		value.chain.clear()
+
+		value.IsNull().chain.assert(t, failure)
+		value.chain.clear()
+
+		value.NotNull().chain.assert(t, failure)
+		value.chain.clear()
+
+		assert.Nil(t, value.Raw())
+	})
+}
+
 func TestValue_Alias(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -464,6 +633,57 @@ func TestValue_GetBoolean(t *testing.T) {
 	}
 }
 
+func TestValue_AsBoolean(t *testing.T) {
+	cases := []struct {
+		name         string
+		data         interface{}
+		lenient      bool
+		result       chainResult
+		expectedBool bool
+	}{
+		{name: "strict true", data: true, lenient: false, result: success,
+			expectedBool: true},
+		{name: "strict false", data: false, lenient: false, result: success,
+			expectedBool: false},
+		{name: "strict string", data: "true", lenient: false, result: failure},
+		{name: "strict number", data: 1, lenient: false, result: failure},
+		{name: "lenient true", data: true, lenient: true, result: success,
+			expectedBool: true},
+		{name: "lenient string true", data: "True", lenient: true, result: success,
+			expectedBool: true},
+		{name: "lenient string false", data: "FALSE", lenient: true, result: success,
+			expectedBool: false},
+		{name: "lenient string 1", data: "1", lenient: true, result: success,
+			expectedBool: true},
+		{name: "lenient string 0", data: "0", lenient: true, result: success,
+			expectedBool: false},
+		{name: "lenient number 1", data: 1, lenient: true, result: success,
+			expectedBool: true},
+		{name: "lenient number 0", data: 0.0, lenient: true, result: success,
+			expectedBool: false},
+		{name: "lenient string yes", data: "yes", lenient: true, result: failure},
+		{name: "lenient number 2", data: 2, lenient: true, result: failure},
+		{name: "lenient null", data: nil, lenient: true, result: failure},
+		{name: "lenient array", data: []interface{}{}, lenient: true, result: failure},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			value := NewValue(reporter, tc.data)
+			inner := value.AsBoolean(tc.lenient)
+
+			value.chain.assert(t, tc.result)
+			inner.chain.assert(t, tc.result)
+
+			if tc.result {
+				assert.Equal(t, tc.expectedBool, inner.Raw())
+			}
+		})
+	}
+}
+
 func TestValue_IsObject(t *testing.T) {
 	cases := []struct {
 		name       string
@@ -1278,3 +1498,149 @@ func TestValue_Schema(t *testing.T) {
 			chain.assert(t, failure)
 	})
 }
+
+func TestValue_Eventually(t *testing.T) {
+	newStatusExpect := func(
+		t *testing.T, statuses ...string,
+	) (*Expect, *int, *mockReporter) {
+		calls := 0
+		reporter := newMockReporter(t)
+
+		client := ClientFunc(func(req *http.Request) (*http.Response, error) {
+			status := statuses[len(statuses)-1]
+			if calls < len(statuses) {
+				status = statuses[calls]
+			}
+			calls++
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body:       newMockBody(`{"status":"` + status + `"}`),
+				Request:    req,
+			}, nil
+		})
+
+		e := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Client:   client,
+			Clock:    NewFakeClock(time.Now()),
+			Reporter: reporter,
+		})
+
+		return e, &calls, reporter
+	}
+
+	rebuilder := func(e *Expect) *Value {
+		return e.GET("/job").Expect().JSON().Path("$.status")
+	}
+
+	isDone := func(v *Value) {
+		v.IsEqual("done")
+	}
+
+	t.Run("already passed", func(t *testing.T) {
+		e, calls, _ := newStatusExpect(t, "done")
+
+		value := NewValue(newMockReporter(t), "done")
+
+		result := value.Eventually(e, rebuilder, time.Second, 100*time.Millisecond, isDone)
+		result.chain.assert(t, success)
+		value.chain.assert(t, success)
+
+		assert.Equal(t, "done", result.Raw())
+		assert.Equal(t, 0, *calls)
+	})
+
+	t.Run("passed after polling", func(t *testing.T) {
+		e, calls, reporter := newStatusExpect(t, "pending", "pending", "done")
+
+		value := e.GET("/job").Expect().JSON().Path("$.status")
+
+		result := value.Eventually(e, rebuilder, time.Second, 100*time.Millisecond, isDone)
+		result.chain.assert(t, success)
+		value.chain.assert(t, success)
+
+		assert.Equal(t, "done", result.Raw())
+		assert.Equal(t, 3, *calls)
+		assert.False(t, reporter.reported)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		e, calls, reporter := newStatusExpect(t, "pending")
+
+		value := e.GET("/job").Expect().JSON().Path("$.status")
+
+		// pauses are fake, but timeout is measured in wall time
+		start := time.Now()
+
+		result := value.Eventually(e, rebuilder,
+			50*time.Millisecond, 10*time.Millisecond, isDone)
+		result.chain.assert(t, failure)
+		value.chain.assert(t, failure)
+
+		assert.True(t, time.Since(start) >= 40*time.Millisecond)
+
+		assert.Equal(t, "pending", result.Raw())
+		assert.Greater(t, *calls, 1)
+		assert.True(t, reporter.reported)
+	})
+
+	t.Run("zero timeout", func(t *testing.T) {
+		e, calls, _ := newStatusExpect(t, "done")
+
+		value := NewValue(newMockReporter(t), "pending")
+
+		result := value.Eventually(e, rebuilder, 0, 100*time.Millisecond, isDone)
+		result.chain.assert(t, failure)
+
+		assert.Equal(t, 0, *calls)
+	})
+
+	t.Run("nil rebuilder result", func(t *testing.T) {
+		e, _, _ := newStatusExpect(t, "done")
+
+		value := NewValue(newMockReporter(t), "pending")
+
+		result := value.Eventually(e, func(e *Expect) *Value {
+			return nil
+		}, time.Second, 100*time.Millisecond, isDone)
+		result.chain.assert(t, failure)
+
+		assert.Equal(t, "pending", result.Raw())
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		e, _, _ := newStatusExpect(t, "done")
+
+		cases := []struct {
+			name   string
+			invoke func(v *Value) *Value
+		}{
+			{"nil expect", func(v *Value) *Value {
+				return v.Eventually(nil, rebuilder, time.Second, time.Millisecond, isDone)
+			}},
+			{"nil rebuilder", func(v *Value) *Value {
+				return v.Eventually(e, nil, time.Second, time.Millisecond, isDone)
+			}},
+			{"nil assertion", func(v *Value) *Value {
+				return v.Eventually(e, rebuilder, time.Second, time.Millisecond, nil)
+			}},
+			{"negative timeout", func(v *Value) *Value {
+				return v.Eventually(e, rebuilder, -time.Second, time.Millisecond, isDone)
+			}},
+			{"zero interval", func(v *Value) *Value {
+				return v.Eventually(e, rebuilder, time.Second, 0, isDone)
+			}},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				value := NewValue(newMockReporter(t), "done")
+
+				tc.invoke(value).chain.assert(t, failure)
+				value.chain.assert(t, failure)
+			})
+		}
+	})
+}
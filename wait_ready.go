@@ -0,0 +1,131 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// WaitReady polls readiness endpoint until it responds with 2xx status,
+// and reports failure if this doesn't happen within given timeout.
+//
+// GET request is sent to given path every interval. Requests are built
+// in the same way as by Expect.GET, so they use Config.BaseURL, builders,
+// default headers, and so on. Failures of individual polls don't fail the
+// test; they are only logged.
+//
+// If target doesn't become ready in time, failure is reported on Expect
+// instance, including number of attempts and outcome of the last attempt.
+// Since Expect instance is marked failed, all further requests created
+// from it fail fast instead of running against a target that is down.
+//
+// Timeout is measured in wall time, even if Config.Clock is fake; pauses
+// between polls are made using Config.Clock.
+//
+// WaitReady is intended to replace sleep-based setup in integration suites.
+//
+// Example:
+//
+//	e := httpexpect.Default(t, "http://localhost:8080")
+//
+//	e.WaitReady("/health", 30*time.Second, 500*time.Millisecond)
+//
+//	e.GET("/users").
+//		Expect().
+//		Status(http.StatusOK)
+func (e *Expect) WaitReady(path string, timeout, interval time.Duration) *Expect {
+	opChain := e.chain.enter("WaitReady(%q)", path)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return e
+	}
+
+	if timeout <= 0 || interval <= 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected non-positive timeout or interval argument"),
+			},
+		})
+		return e
+	}
+
+	clock := clockOrDefault(e.config.Clock)
+	start := time.Now()
+
+	var (
+		attempts int
+		lastErr  error
+	)
+
+	for {
+		attempts++
+
+		lastErr = e.pollReady(opChain, path)
+		if lastErr == nil {
+			return e
+		}
+
+		if time.Since(start)+interval > timeout {
+			break
+		}
+
+		if e.config.Context != nil {
+			select {
+			case <-e.config.Context.Done():
+				opChain.fail(AssertionFailure{
+					Type: AssertOperation,
+					Errors: []error{
+						fmt.Errorf("waiting for %q to become ready was canceled", path),
+						e.config.Context.Err(),
+					},
+				})
+				return e
+			case <-clock.After(interval):
+			}
+		} else {
+			<-clock.After(interval)
+		}
+	}
+
+	opChain.fail(AssertionFailure{
+		Type: AssertOperation,
+		Errors: []error{
+			fmt.Errorf("expected: %q becomes ready within %s", path, timeout),
+			fmt.Errorf("gave up after %d attempts", attempts),
+			fmt.Errorf("last attempt: %s", lastErr),
+		},
+	})
+
+	return e
+}
+
+// Send one readiness request; returns nil if target is ready.
+func (e *Expect) pollReady(opChain *chain, path string) error {
+	// Failures of polls should neither fail the test,
+	// nor propagate to the Expect chain.
+	pollChain := opChain.clone()
+	pollChain.setRoot()
+	pollChain.setSeverity(SeverityLog)
+
+	resp := e.withChain(pollChain).GET(path).Expect()
+
+	if httpResp := resp.Raw(); httpResp != nil {
+		if httpResp.Body != nil {
+			defer httpResp.Body.Close()
+		}
+
+		if httpResp.StatusCode < 200 || httpResp.StatusCode > 299 {
+			return fmt.Errorf("got status %d (%s)",
+				httpResp.StatusCode, http.StatusText(httpResp.StatusCode))
+		}
+	}
+
+	if pollChain.treeFailed() {
+		return errors.New("request failed, see log for details")
+	}
+
+	return nil
+}
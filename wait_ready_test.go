@@ -0,0 +1,200 @@
+package httpexpect
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpect_WaitReady(t *testing.T) {
+	t.Run("ready after retries", func(t *testing.T) {
+		clock := NewFakeClock(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+
+		var paths []string
+
+		client := ClientFunc(func(req *http.Request) (*http.Response, error) {
+			paths = append(paths, req.URL.Path)
+			switch len(paths) {
+			case 1:
+				return nil, errors.New("connection refused")
+			case 2:
+				return &http.Response{StatusCode: http.StatusServiceUnavailable}, nil
+			}
+			return &http.Response{StatusCode: http.StatusOK}, nil
+		})
+
+		handler := &mockAssertionHandler{}
+
+		e := WithConfig(Config{
+			BaseURL:          "http://example.com",
+			Client:           client,
+			AssertionHandler: handler,
+			Clock:            clock,
+		})
+
+		e.WaitReady("/health", time.Minute, time.Second)
+		e.chain.assert(t, success)
+
+		assert.Equal(t, []string{"/health", "/health", "/health"}, paths)
+		assert.Equal(t, 2*time.Second,
+			clock.Now().Sub(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+		// failed polls are logged only
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, SeverityLog, handler.failure.Severity)
+	})
+
+	t.Run("never ready", func(t *testing.T) {
+		attempts := 0
+
+		client := ClientFunc(func(req *http.Request) (*http.Response, error) {
+			attempts++
+			return &http.Response{StatusCode: http.StatusServiceUnavailable}, nil
+		})
+
+		reporter := newMockReporter(t)
+
+		e := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Client:   client,
+			Reporter: reporter,
+		})
+
+		e.WaitReady("/health", 50*time.Millisecond, 10*time.Millisecond)
+		e.chain.assert(t, failure)
+
+		assert.GreaterOrEqual(t, attempts, 2)
+		assert.LessOrEqual(t, attempts, 6)
+		assert.Equal(t, 1, reporter.reportCalled)
+
+		// further requests fail fast
+		n := attempts
+		e.GET("/users").Expect().chain.assert(t, failure)
+		assert.Equal(t, n, attempts)
+	})
+
+	t.Run("fake clock", func(t *testing.T) {
+		clock := NewFakeClock(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+
+		client := ClientFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusServiceUnavailable}, nil
+		})
+
+		e := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Client:   client,
+			Reporter: newMockReporter(t),
+			Clock:    clock,
+		})
+
+		// pauses are fake, but timeout is measured in wall time
+		start := time.Now()
+
+		e.WaitReady("/health", 50*time.Millisecond, 10*time.Millisecond)
+		e.chain.assert(t, failure)
+
+		assert.True(t, time.Since(start) >= 40*time.Millisecond)
+	})
+
+	t.Run("failure message", func(t *testing.T) {
+		client := ClientFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusBadGateway}, nil
+		})
+
+		handler := &mockAssertionHandler{}
+
+		e := WithConfig(Config{
+			BaseURL:          "http://example.com",
+			Client:           client,
+			AssertionHandler: handler,
+		})
+
+		e.WaitReady("/health", 30*time.Millisecond, 10*time.Millisecond)
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertOperation, handler.failure.Type)
+		assert.Equal(t, SeverityError, handler.failure.Severity)
+		require.Equal(t, 3, len(handler.failure.Errors))
+		assert.Equal(t, `expected: "/health" becomes ready within 30ms`,
+			handler.failure.Errors[0].Error())
+		assert.Regexp(t, `^gave up after \d+ attempts$`,
+			handler.failure.Errors[1].Error())
+		assert.Equal(t, "last attempt: got status 502 (Bad Gateway)",
+			handler.failure.Errors[2].Error())
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		client := ClientFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusServiceUnavailable}, nil
+		})
+
+		e := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Client:   client,
+			Reporter: newMockReporter(t),
+			Context:  ctx,
+		})
+
+		e.WaitReady("/health", time.Hour, time.Minute)
+		e.chain.assert(t, failure)
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		for _, args := range [][2]time.Duration{
+			{0, time.Second},
+			{time.Second, 0},
+			{-time.Second, time.Second},
+		} {
+			e := WithConfig(Config{
+				BaseURL:  "http://example.com",
+				Client:   &mockClient{},
+				Reporter: newMockReporter(t),
+			})
+
+			e.WaitReady("/health", args[0], args[1])
+			e.chain.assert(t, failure)
+		}
+	})
+	t.Run("builders, matchers and body", func(t *testing.T) {
+		var (
+			bodies  []*mockBody
+			header  string
+			matched int
+		)
+
+		client := ClientFunc(func(req *http.Request) (*http.Response, error) {
+			header = req.Header.Get("X-Test")
+			body := newMockBody("body")
+			bodies = append(bodies, body)
+			return &http.Response{StatusCode: http.StatusOK, Body: body}, nil
+		})
+
+		e := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Client:   client,
+			Reporter: newMockReporter(t),
+		})
+
+		e.Builder(func(req *Request) {
+			req.WithHeader("X-Test", "test")
+		}).Matcher(func(resp *Response) {
+			matched++
+		}).WaitReady("/health", time.Minute, time.Second)
+
+		e.chain.assert(t, success)
+
+		assert.Equal(t, "test", header)
+		assert.Equal(t, 1, matched)
+
+		require.Equal(t, 1, len(bodies))
+		assert.Equal(t, 1, bodies[0].closeCount)
+	})
+}
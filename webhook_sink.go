@@ -0,0 +1,486 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WebhookSink is an ephemeral HTTP server that records incoming requests.
+//
+// It is useful for testing APIs that call back into the client, e.g.
+// webhooks or async job notifications. Pass URL to the API under test,
+// then wait for callbacks and inspect them.
+//
+// Every received request is answered with 200 OK, unless another
+// status is set via WithStatus.
+//
+// Example:
+//
+//	sink := e.WebhookSink()
+//	defer sink.Close()
+//
+//	e.POST("/subscriptions").WithJSON(map[string]interface{}{
+//		"callback": sink.URL(),
+//	}).
+//		Expect().
+//		Status(http.StatusCreated)
+//
+//	sink.Await(1, 5*time.Second)
+//
+//	sink.Request(0).
+//		HasSignature("X-Signature", "secret").
+//		JSON().Object().HasValue("event", "created")
+type WebhookSink struct {
+	noCopy noCopy
+	config Config
+	chain  *chain
+
+	server *httptest.Server
+
+	mu       sync.Mutex
+	status   int
+	requests []*webhookRecord
+	notify   chan struct{}
+}
+
+type webhookRecord struct {
+	method string
+	path   string
+	header http.Header
+	body   []byte
+}
+
+// WebhookSink starts a new WebhookSink server and returns it.
+// Sink should be closed with Close when it's no longer needed.
+//
+// Example:
+//
+//	sink := e.WebhookSink()
+//	defer sink.Close()
+func (e *Expect) WebhookSink() *WebhookSink {
+	opChain := e.chain.enter("WebhookSink()")
+	defer opChain.leave()
+
+	s := &WebhookSink{
+		config: e.config,
+		chain:  opChain.clone(),
+		status: http.StatusOK,
+		notify: make(chan struct{}),
+	}
+
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+func (s *WebhookSink) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	s.mu.Lock()
+	s.requests = append(s.requests, &webhookRecord{
+		method: r.Method,
+		path:   r.URL.RequestURI(),
+		header: r.Header.Clone(),
+		body:   body,
+	})
+	status := s.status
+	close(s.notify)
+	s.notify = make(chan struct{})
+	s.mu.Unlock()
+
+	w.WriteHeader(status)
+}
+
+// URL returns base URL of the sink, e.g. "http://127.0.0.1:12345".
+// Requests to any path under this URL are recorded.
+func (s *WebhookSink) URL() string {
+	return s.server.URL
+}
+
+// Close shuts down the sink server.
+// Recorded requests remain available after Close.
+func (s *WebhookSink) Close() {
+	s.server.Close()
+}
+
+// Alias is similar to Value.Alias.
+func (s *WebhookSink) Alias(name string) *WebhookSink {
+	opChain := s.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	s.chain.setAlias(name)
+	return s
+}
+
+// WithStatus sets HTTP status code that sink replies with.
+// Default is 200 OK.
+//
+// Example:
+//
+//	sink.WithStatus(http.StatusServiceUnavailable)
+func (s *WebhookSink) WithStatus(code int) *WebhookSink {
+	opChain := s.chain.enter("WithStatus()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return s
+	}
+
+	if code < 100 || code > 999 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected invalid status code %d", code),
+			},
+		})
+		return s
+	}
+
+	s.mu.Lock()
+	s.status = code
+	s.mu.Unlock()
+
+	return s
+}
+
+// Await waits until sink receives at least count requests.
+// Fails if requests didn't arrive within given timeout.
+//
+// Timeout is measured in wall time and is not affected by Config.Clock.
+//
+// Example:
+//
+//	sink.Await(2, 5*time.Second)
+//	sink.Count().IsEqual(2)
+func (s *WebhookSink) Await(count int, timeout time.Duration) *WebhookSink {
+	opChain := s.chain.enter("Await()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return s
+	}
+
+	if count < 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected negative count"),
+			},
+		})
+		return s
+	}
+
+	if timeout < 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected negative timeout"),
+			},
+		})
+		return s
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		s.mu.Lock()
+		received := len(s.requests)
+		notify := s.notify
+		s.mu.Unlock()
+
+		if received >= count {
+			return s
+		}
+
+		select {
+		case <-notify:
+		case <-timer.C:
+			opChain.fail(AssertionFailure{
+				Type:     AssertGe,
+				Actual:   &AssertionValue{received},
+				Expected: &AssertionValue{count},
+				Errors: []error{
+					fmt.Errorf("expected: sink receives %d requests within %s",
+						count, timeout),
+				},
+			})
+			return s
+		}
+	}
+}
+
+// Count returns a new Number instance with number of received requests.
+//
+// Example:
+//
+//	sink.Count().IsEqual(1)
+func (s *WebhookSink) Count() *Number {
+	opChain := s.chain.enter("Count()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, 0)
+	}
+
+	return newNumber(opChain, float64(len(s.snapshot())))
+}
+
+// Paths returns a new Array instance with request URIs (path and query)
+// of received requests, in order of arrival.
+//
+// Example:
+//
+//	sink.Paths().IsEqual([]string{"/created", "/updated"})
+func (s *WebhookSink) Paths() *Array {
+	opChain := s.chain.enter("Paths()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	records := s.snapshot()
+
+	paths := make([]interface{}, 0, len(records))
+	for _, rec := range records {
+		paths = append(paths, rec.path)
+	}
+
+	return newArray(opChain, paths)
+}
+
+// Request returns a new WebhookRequest instance for request with given
+// index, in order of arrival.
+//
+// Example:
+//
+//	sink.Request(0).Method().IsEqual("POST")
+func (s *WebhookSink) Request(index int) *WebhookRequest {
+	opChain := s.chain.enter("Request(%d)", index)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newWebhookRequest(opChain, s.config, nil)
+	}
+
+	records := s.snapshot()
+
+	if index < 0 || index >= len(records) {
+		opChain.fail(AssertionFailure{
+			Type:   AssertInRange,
+			Actual: &AssertionValue{index},
+			Expected: &AssertionValue{AssertionRange{
+				Min: 0,
+				Max: len(records) - 1,
+			}},
+			Errors: []error{
+				errors.New("expected: valid request index"),
+			},
+		})
+		return newWebhookRequest(opChain, s.config, nil)
+	}
+
+	return newWebhookRequest(opChain, s.config, records[index])
+}
+
+// Last returns a new WebhookRequest instance for last received request.
+//
+// Example:
+//
+//	sink.Last().JSON().Object().HasValue("event", "deleted")
+func (s *WebhookSink) Last() *WebhookRequest {
+	opChain := s.chain.enter("Last()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newWebhookRequest(opChain, s.config, nil)
+	}
+
+	records := s.snapshot()
+
+	if len(records) == 0 {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotEmpty,
+			Actual: &AssertionValue{[]interface{}{}},
+			Errors: []error{
+				errors.New("expected: sink received at least one request"),
+			},
+		})
+		return newWebhookRequest(opChain, s.config, nil)
+	}
+
+	return newWebhookRequest(opChain, s.config, records[len(records)-1])
+}
+
+func (s *WebhookSink) snapshot() []*webhookRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*webhookRecord(nil), s.requests...)
+}
+
+// WebhookRequest provides methods to inspect request received by WebhookSink.
+type WebhookRequest struct {
+	noCopy noCopy
+	config Config
+	chain  *chain
+
+	rec *webhookRecord
+}
+
+func newWebhookRequest(
+	parent *chain, config Config, rec *webhookRecord,
+) *WebhookRequest {
+	if rec == nil {
+		rec = &webhookRecord{header: http.Header{}}
+	}
+
+	return &WebhookRequest{
+		config: config,
+		chain:  parent.clone(),
+		rec:    rec,
+	}
+}
+
+// Alias is similar to Value.Alias.
+func (wr *WebhookRequest) Alias(name string) *WebhookRequest {
+	opChain := wr.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	wr.chain.setAlias(name)
+	return wr
+}
+
+// Method returns a new String instance with request method.
+//
+// Example:
+//
+//	sink.Request(0).Method().IsEqual("POST")
+func (wr *WebhookRequest) Method() *String {
+	opChain := wr.chain.enter("Method()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, wr.rec.method)
+}
+
+// Path returns a new String instance with request URI (path and query).
+//
+// Example:
+//
+//	sink.Request(0).Path().IsEqual("/events?type=created")
+func (wr *WebhookRequest) Path() *String {
+	opChain := wr.chain.enter("Path()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, wr.rec.path)
+}
+
+// Header returns a new String instance with given request header.
+//
+// Example:
+//
+//	sink.Request(0).Header("Content-Type").IsEqual("application/json")
+func (wr *WebhookRequest) Header(header string) *String {
+	opChain := wr.chain.enter("Header(%q)", header)
+	defer opChain.leave()
+
+	opChain.setHeader(header)
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, wr.rec.header.Get(header))
+}
+
+// Body returns a new String instance with request body.
+//
+// Example:
+//
+//	sink.Request(0).Body().IsEqual("hello")
+func (wr *WebhookRequest) Body() *String {
+	opChain := wr.chain.enter("Body()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, string(wr.rec.body))
+}
+
+// JSON returns a new Value instance with JSON decoded from request body.
+//
+// Example:
+//
+//	sink.Request(0).JSON().Object().HasValue("event", "created")
+func (wr *WebhookRequest) JSON() *Value {
+	opChain := wr.chain.enter("JSON()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newValue(opChain, nil)
+	}
+
+	var value interface{}
+
+	decoder := jsonDecoderOrDefault(wr.config.JSONDecoder)
+
+	if err := decoder.Unmarshal(wr.rec.body, &value); err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertValid,
+			Actual: &AssertionValue{
+				string(wr.rec.body),
+			},
+			Errors: []error{
+				errors.New("failed to decode json"),
+				err,
+			},
+		})
+		return newValue(opChain, nil)
+	}
+
+	return newValue(opChain, value)
+}
+
+// HasSignature succeeds if given request header contains hex-encoded
+// HMAC-SHA256 of request body computed with given secret.
+//
+// Header value may have "sha256=" prefix, as used by many webhook
+// providers. See Signature for other signature styles.
+//
+// Example:
+//
+//	sink.Request(0).HasSignature("X-Hub-Signature-256", "secret")
+func (wr *WebhookRequest) HasSignature(header, secret string) *WebhookRequest {
+	opChain := wr.chain.enter("HasSignature(%q)", header)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return wr
+	}
+
+	sig := newSignature(opChain, wr.config.Clock, wr.rec.body, wr.rec.header)
+
+	if value, ok := sig.headerValue(opChain, header); ok {
+		sig.checkDigest(opChain, secret, wr.rec.body,
+			[]string{strings.TrimPrefix(value, "sha256=")})
+	}
+
+	return wr
+}
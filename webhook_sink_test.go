@@ -0,0 +1,231 @@
+package httpexpect
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookSink_Receive(t *testing.T) {
+	e := WithConfig(Config{
+		Reporter: newMockReporter(t),
+	})
+
+	sink := e.WebhookSink()
+	defer sink.Close()
+
+	go func() {
+		resp, err := http.Post(sink.URL()+"/created", "application/json",
+			strings.NewReader(`{"event":"created","id":1}`))
+		if err == nil {
+			resp.Body.Close()
+		}
+
+		resp, err = http.Post(sink.URL()+"/updated?v=2", "text/plain",
+			strings.NewReader("updated"))
+		if err == nil {
+			resp.Body.Close()
+		}
+	}()
+
+	sink.Await(2, 5*time.Second)
+	sink.chain.assert(t, success)
+
+	sink.Count().IsEqual(2)
+	sink.Paths().IsEqual([]string{"/created", "/updated?v=2"})
+
+	first := sink.Request(0)
+	first.Method().IsEqual("POST")
+	first.Path().IsEqual("/created")
+	first.Header("Content-Type").IsEqual("application/json")
+	first.JSON().Object().HasValue("event", "created").HasValue("id", 1)
+	first.chain.assert(t, success)
+
+	last := sink.Last()
+	last.Body().IsEqual("updated")
+	last.chain.assert(t, success)
+
+	last.JSON()
+	last.chain.assert(t, failure)
+
+	sink.chain.assert(t, success)
+}
+
+func TestWebhookSink_Status(t *testing.T) {
+	e := WithConfig(Config{
+		Reporter: newMockReporter(t),
+	})
+
+	sink := e.WebhookSink()
+	defer sink.Close()
+
+	sink.WithStatus(http.StatusAccepted)
+	sink.chain.assert(t, success)
+
+	resp, err := http.Get(sink.URL())
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	sink.WithStatus(0)
+	sink.chain.assert(t, failure)
+}
+
+func TestWebhookSink_Await(t *testing.T) {
+	t.Run("timeout", func(t *testing.T) {
+		e := WithConfig(Config{
+			Reporter: newMockReporter(t),
+		})
+
+		sink := e.WebhookSink()
+		defer sink.Close()
+
+		sink.Await(1, time.Millisecond)
+		sink.chain.assert(t, failure)
+	})
+
+	t.Run("fake clock", func(t *testing.T) {
+		now := time.Unix(0, 0)
+		clock := NewFakeClock(now)
+
+		e := WithConfig(Config{
+			Reporter: newMockReporter(t),
+			Clock:    clock,
+		})
+
+		sink := e.WebhookSink()
+		defer sink.Close()
+
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			resp, err := http.Get(sink.URL())
+			if err == nil {
+				resp.Body.Close()
+			}
+		}()
+
+		sink.Await(1, 5*time.Second)
+		sink.chain.assert(t, success)
+
+		assert.Equal(t, now, clock.Now())
+	})
+
+	t.Run("zero", func(t *testing.T) {
+		e := WithConfig(Config{
+			Reporter: newMockReporter(t),
+		})
+
+		sink := e.WebhookSink()
+		defer sink.Close()
+
+		sink.Await(0, 0)
+		sink.chain.assert(t, success)
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		e := WithConfig(Config{
+			Reporter: newMockReporter(t),
+		})
+
+		sink := e.WebhookSink()
+		defer sink.Close()
+
+		sink.Await(-1, time.Second)
+		sink.chain.assert(t, failure)
+
+		sink.chain.clear()
+
+		sink.Await(1, -time.Second)
+		sink.chain.assert(t, failure)
+	})
+}
+
+func TestWebhookSink_Empty(t *testing.T) {
+	e := WithConfig(Config{
+		Reporter: newMockReporter(t),
+	})
+
+	sink := e.WebhookSink()
+	defer sink.Close()
+
+	sink.Count().IsEqual(0)
+	sink.Paths().IsEmpty()
+	sink.chain.assert(t, success)
+
+	sink.Request(0)
+	sink.chain.assert(t, failure)
+
+	sink.chain.clear()
+
+	sink.Last()
+	sink.chain.assert(t, failure)
+}
+
+func TestWebhookSink_Signature(t *testing.T) {
+	body := `{"event":"created"}`
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	_, _ = mac.Write([]byte(body))
+	signature := hex.EncodeToString(mac.Sum(nil))
+
+	cases := []struct {
+		name   string
+		header string
+		value  string
+		result chainResult
+	}{
+		{
+			name:   "plain",
+			header: "X-Signature",
+			value:  signature,
+			result: success,
+		},
+		{
+			name:   "prefixed",
+			header: "X-Signature",
+			value:  "sha256=" + signature,
+			result: success,
+		},
+		{
+			name:   "wrong secret",
+			header: "X-Signature",
+			value:  strings.Repeat("0", len(signature)),
+			result: failure,
+		},
+		{
+			name:   "not hex",
+			header: "X-Signature",
+			value:  "not-hex",
+			result: failure,
+		},
+		{
+			name:   "missing header",
+			header: "X-Other",
+			value:  signature,
+			result: failure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := newWebhookRequest(newMockChain(t), newMockConfig(newMockReporter(t)),
+				&webhookRecord{
+					method: http.MethodPost,
+					path:   "/",
+					header: http.Header{tc.header: {tc.value}},
+					body:   []byte(body),
+				})
+
+			req.HasSignature("X-Signature", "secret")
+			req.chain.assert(t, tc.result)
+		})
+	}
+}
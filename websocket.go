@@ -1,7 +1,6 @@
 package httpexpect
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -60,6 +59,9 @@ func NewWebsocketC(config Config, conn WebsocketConn) *Websocket {
 func newWebsocket(parent *chain, config Config, conn WebsocketConn) *Websocket {
 	config.validate()
 
+	config.JSONEncoder = jsonEncoderOrDefault(config.JSONEncoder)
+	config.JSONDecoder = jsonDecoderOrDefault(config.JSONDecoder)
+
 	return &Websocket{
 		config: config,
 		chain:  parent.clone(),
@@ -349,7 +351,7 @@ func (ws *Websocket) CloseWithJSON(
 		return ws
 	}
 
-	b, err := json.Marshal(object)
+	b, err := ws.config.JSONEncoder.Marshal(object)
 
 	if err != nil {
 		opChain.fail(AssertionFailure{
@@ -485,7 +487,7 @@ func (ws *Websocket) WriteJSON(object interface{}) *Websocket {
 		return ws
 	}
 
-	b, err := json.Marshal(object)
+	b, err := ws.config.JSONEncoder.Marshal(object)
 
 	if err != nil {
 		opChain.fail(AssertionFailure{
@@ -533,6 +535,7 @@ func (ws *Websocket) checkUnusable(opChain *chain, where string) bool {
 
 func (ws *Websocket) readMessage(opChain *chain) *WebsocketMessage {
 	wm := newEmptyWebsocketMessage(opChain)
+	wm.jsonDecoder = ws.config.JSONDecoder
 
 	if !ws.setReadDeadline(opChain) {
 		return nil
@@ -661,7 +664,7 @@ func (ws *Websocket) setWriteDeadline(opChain *chain) bool {
 func (ws *Websocket) printRead(typ int, content []byte, closeCode int) {
 	for _, printer := range ws.config.Printers {
 		if p, ok := printer.(WebsocketPrinter); ok {
-			p.WebsocketRead(typ, content, closeCode)
+			p.WebsocketRead(typ, ws.config.Redactor.RedactBody(content), closeCode)
 		}
 	}
 }
@@ -669,7 +672,7 @@ func (ws *Websocket) printRead(typ int, content []byte, closeCode int) {
 func (ws *Websocket) printWrite(typ int, content []byte, closeCode int) {
 	for _, printer := range ws.config.Printers {
 		if p, ok := printer.(WebsocketPrinter); ok {
-			p.WebsocketWrite(typ, content, closeCode)
+			p.WebsocketWrite(typ, ws.config.Redactor.RedactBody(content), closeCode)
 		}
 	}
 }
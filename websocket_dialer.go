@@ -2,6 +2,8 @@ package httpexpect
 
 import (
 	"bufio"
+	"bytes"
+	"io"
 	"net"
 	"net/http"
 	"net/http/httptest"
@@ -13,6 +15,12 @@ import (
 
 // NewWebsocketDialer produces new websocket.Dialer which dials to bound
 // http.Handler without creating a real net.Conn.
+//
+// Handler is invoked in a background goroutine for every handshake request.
+// If handler upgrades connection (e.g. using websocket.Upgrader), messages
+// are exchanged via in-memory pipe. Otherwise, response written by handler
+// is sent back, so rejected handshakes can be inspected like regular
+// responses.
 func NewWebsocketDialer(handler http.Handler) *websocket.Dialer {
 	return &websocket.Dialer{
 		NetDial: func(network, addr string) (net.Conn, error) {
@@ -63,14 +71,29 @@ func (hc *handlerConn) runHandler(handler http.Handler) {
 	go func() {
 		defer hc.wg.Done()
 
-		recorder := &hijackRecorder{conn: hc.backConn}
+		reader := bufio.NewReader(hc.backConn)
 
 		for {
-			req, err := http.ReadRequest(bufio.NewReader(hc.backConn))
+			req, err := http.ReadRequest(reader)
 			if err != nil {
 				return
 			}
+
+			recorder := &hijackRecorder{
+				ResponseRecorder: httptest.NewRecorder(),
+				conn:             hc.backConn,
+				reader:           reader,
+			}
+
 			handler.ServeHTTP(recorder, req)
+
+			if recorder.hijacked {
+				return
+			}
+
+			if err := recorder.flush(); err != nil {
+				return
+			}
 		}
 	}()
 }
@@ -90,22 +113,34 @@ func (hc *handlerConn) runFastHandler(handler fasthttp.RequestHandler) {
 //
 // Original idea is stolen from https://github.com/posener/wstest
 type hijackRecorder struct {
-	httptest.ResponseRecorder
-	conn net.Conn
+	*httptest.ResponseRecorder
+	conn     net.Conn
+	reader   *bufio.Reader
+	hijacked bool
 }
 
 // Hijack the connection for caller.
 //
 // Implements http.Hijacker interface.
 func (r *hijackRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
-	rw := bufio.NewReadWriter(bufio.NewReader(r.conn), bufio.NewWriter(r.conn))
+	r.hijacked = true
+	rw := bufio.NewReadWriter(r.reader, bufio.NewWriter(r.conn))
 	return r.conn, rw, nil
 }
 
-// WriteHeader write HTTP header to the client and closes the connection
-//
-// Implements http.ResponseWriter interface.
-func (r *hijackRecorder) WriteHeader(code int) {
-	resp := http.Response{StatusCode: code, Header: r.Header()}
-	_ = resp.Write(r.conn)
+// flush writes recorded response to the client.
+// Used when handler didn't hijack connection, e.g. rejected handshake.
+func (r *hijackRecorder) flush() error {
+	body := r.Body.Bytes()
+
+	resp := http.Response{
+		StatusCode:    r.Code,
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.Header(),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+	}
+
+	return resp.Write(r.conn)
 }
@@ -0,0 +1,127 @@
+package httpexpect
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestWebsocketDialer_Handler(t *testing.T) {
+	echoHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upgrader := websocket.Upgrader{}
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		for {
+			mt, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if err := conn.WriteMessage(mt, msg); err != nil {
+				return
+			}
+		}
+	})
+
+	t.Run("upgrade", func(t *testing.T) {
+		dialer := NewWebsocketDialer(echoHandler)
+
+		conn, resp, err := dialer.Dial("ws://example.com/ws", nil)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		assert.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+
+		require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte("hello")))
+
+		mt, msg, err := conn.ReadMessage()
+		require.NoError(t, err)
+		assert.Equal(t, websocket.TextMessage, mt)
+		assert.Equal(t, "hello", string(msg))
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "forbidden", http.StatusForbidden)
+		})
+
+		dialer := NewWebsocketDialer(handler)
+
+		conn, resp, err := dialer.Dial("ws://example.com/ws", nil)
+		assert.Equal(t, websocket.ErrBadHandshake, err)
+		assert.Nil(t, conn)
+		require.NotNil(t, resp)
+
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "forbidden\n", string(body))
+	})
+
+	t.Run("implicit status", func(t *testing.T) {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("not a websocket"))
+		})
+
+		dialer := NewWebsocketDialer(handler)
+
+		_, resp, err := dialer.Dial("ws://example.com/ws", nil)
+		assert.Equal(t, websocket.ErrBadHandshake, err)
+		require.NotNil(t, resp)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("request WithHandler", func(t *testing.T) {
+		config := Config{
+			BaseURL:  "http://example.com",
+			Reporter: newMockReporter(t),
+		}
+
+		resp := NewRequestC(config, "GET", "/ws").
+			WithHandler(echoHandler).
+			WithWebsocketUpgrade().
+			Expect()
+
+		resp.Status(http.StatusSwitchingProtocols)
+		resp.chain.assert(t, success)
+
+		ws := resp.Websocket()
+		defer ws.Disconnect()
+
+		ws.WriteText("hello").
+			Expect().
+			TextMessage().
+			Body().IsEqual("hello")
+
+		ws.chain.assert(t, success)
+	})
+
+	t.Run("request WithFastHandler rejected", func(t *testing.T) {
+		config := Config{
+			BaseURL:  "http://example.com",
+			Reporter: newMockReporter(t),
+		}
+
+		handler := func(ctx *fasthttp.RequestCtx) {
+			ctx.SetStatusCode(http.StatusForbidden)
+		}
+
+		resp := NewRequestC(config, "GET", "/ws").
+			WithFastHandler(handler).
+			WithWebsocketUpgrade().
+			Expect()
+
+		resp.chain.assert(t, failure)
+	})
+}
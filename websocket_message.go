@@ -1,7 +1,6 @@
 package httpexpect
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -16,6 +15,8 @@ type WebsocketMessage struct {
 	typ       int
 	content   []byte
 	closeCode int
+
+	jsonDecoder JSONDecoder
 }
 
 // NewWebsocketMessage returns a new WebsocketMessage instance.
@@ -50,12 +51,17 @@ func NewWebsocketMessage(
 func NewWebsocketMessageC(
 	config Config, typ int, content []byte, closeCode ...int,
 ) *WebsocketMessage {
-	return newWebsocketMessage(
-		newChainWithConfig("WebsocketMessage()", config.withDefaults()),
+	config = config.withDefaults()
+
+	wm := newWebsocketMessage(
+		newChainWithConfig("WebsocketMessage()", config),
 		typ,
 		content,
 		closeCode...,
 	)
+	wm.jsonDecoder = config.JSONDecoder
+
+	return wm
 }
 
 func newWebsocketMessage(
@@ -544,7 +550,9 @@ func (wm *WebsocketMessage) JSON() *Value {
 
 	var value interface{}
 
-	if err := json.Unmarshal(wm.content, &value); err != nil {
+	decoder := jsonDecoderOrDefault(wm.jsonDecoder)
+
+	if err := decoder.Unmarshal(wm.content, &value); err != nil {
 		opChain.fail(AssertionFailure{
 			Type: AssertValid,
 			Actual: &AssertionValue{